@@ -0,0 +1,219 @@
+package agouti
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sclevine/agouti/internal/element"
+)
+
+// AxeURL is the URL that axe-core is loaded from by RunAccessibilityAudit when
+// AxeSource is empty. The page must be able to load scripts from this URL.
+var AxeURL = "https://cdnjs.cloudflare.com/ajax/libs/axe-core/4.10.2/axe.min.js"
+
+// AxeSource is the source of axe-core that is injected by
+// RunAccessibilityAudit, ex. the contents of axe.min.js from the axe-core npm
+// package. If AxeSource is empty, axe-core is loaded from AxeURL instead.
+var AxeSource string
+
+// AxeOptions configures an accessibility audit.
+type AxeOptions struct {
+	// Rules limits the audit to the rules with the provided IDs, ex. "label".
+	// If provided, Tags is ignored.
+	Rules []string
+
+	// Tags limits the audit to rules with any of the provided tags, ex.
+	// "wcag2a" or "wcag2aa".
+	Tags []string
+
+	// DisabledRules excludes the rules with the provided IDs from the audit.
+	DisabledRules []string
+}
+
+// AxeResults are the results of an accessibility audit.
+type AxeResults struct {
+	// URL is the URL of the audited page
+	URL string
+
+	// Violations contains the rules that failed for any element
+	Violations []AxeRule
+
+	// Incomplete contains the rules that must be reviewed manually
+	Incomplete []AxeRule
+
+	// Passes contains the IDs of the rules that passed
+	Passes []string
+}
+
+// An AxeRule is an accessibility rule and the elements that it applies to.
+type AxeRule struct {
+	// ID identifies the rule, ex. "color-contrast"
+	ID string
+
+	// Impact is "minor", "moderate", "serious", or "critical"
+	Impact string
+
+	Description string
+	Help        string
+	HelpURL     string
+	Tags        []string
+	Nodes       []AxeNode
+}
+
+// An AxeNode is an element that an AxeRule applies to.
+type AxeNode struct {
+	// Target contains CSS selectors for the element, where each additional
+	// selector is within the iframe or shadow root of the previous element.
+	Target []string
+
+	// HTML is the outer HTML of the element, which may be truncated
+	HTML string
+
+	Impact         string
+	FailureSummary string
+}
+
+var axeImpacts = map[string]int{"minor": 1, "moderate": 2, "serious": 3, "critical": 4}
+
+// ViolationsWithImpact returns the violations with at least the provided
+// impact ("minor", "moderate", "serious", or "critical"). Violations without
+// an impact are always returned. If the impact is empty, all violations are
+// returned.
+func (r *AxeResults) ViolationsWithImpact(impact string) []AxeRule {
+	violations := []AxeRule{}
+	for _, violation := range r.Violations {
+		if axeImpacts[violation.Impact] >= axeImpacts[impact] || violation.Impact == "" {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+const axeLoadScript = `
+	var url = arguments[0], done = arguments[arguments.length - 1];
+	if (window.axe) {
+		done("");
+		return;
+	}
+	var script = document.createElement("script");
+	script.src = url;
+	script.onload = function() { done(""); };
+	script.onerror = function() { done("failed to load " + url); };
+	(document.head || document.documentElement).appendChild(script);
+`
+
+// Node targets are nested arrays for elements within iframes and shadow
+// roots, and rules that passed are only returned by ID to limit the size of
+// the results.
+const axeRunScript = `
+	var context = arguments[0] || document, options = arguments[1], done = arguments[arguments.length - 1];
+	var rules = function(results) {
+		return results.map(function(rule) {
+			return {
+				ID: rule.id, Impact: rule.impact || "", Description: rule.description,
+				Help: rule.help, HelpURL: rule.helpUrl, Tags: rule.tags,
+				Nodes: rule.nodes.map(function(node) {
+					return {
+						Target: [].concat.apply([], node.target.map(function(target) { return [].concat(target); })),
+						HTML: node.html, Impact: node.impact || "", FailureSummary: node.failureSummary || ""
+					};
+				})
+			};
+		});
+	};
+	window.axe.run(context, options).then(function(results) {
+		done({results: {
+			URL: results.url,
+			Violations: rules(results.violations),
+			Incomplete: rules(results.incomplete),
+			Passes: results.passes.map(function(rule) { return rule.id; })
+		}});
+	}, function(err) {
+		done({error: String(err)});
+	});
+`
+
+// RunAccessibilityAudit injects axe-core into the page, unless it is already
+// present, and runs an accessibility audit of the entire document. See AxeURL
+// and AxeSource for how axe-core is loaded. The maximum time to wait for the
+// audit is set by SetScriptTimeout.
+//
+// Example:
+//    results, err := page.RunAccessibilityAudit(agouti.AxeOptions{Tags: []string{"wcag2a", "wcag2aa"}})
+//    for _, violation := range results.ViolationsWithImpact("serious") {
+//        fmt.Println(violation.ID, violation.Help)
+//    }
+func (p *Page) RunAccessibilityAudit(options AxeOptions) (*AxeResults, error) {
+	return p.runAxe(nil, options)
+}
+
+// RunAccessibilityAudit runs an accessibility audit of exactly one element
+// and its descendants, as described by *Page.RunAccessibilityAudit.
+func (s *Selection) RunAccessibilityAudit(options AxeOptions) (*AxeResults, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return nil, selectionError("failed to select element from %s: %s", s, err)
+	}
+	apiElement, err := element.Unwrap(selectedElement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select element from %s: %s", s, err)
+	}
+	return s.runAxe(apiElement, options)
+}
+
+func (s *selectable) runAxe(context interface{}, options AxeOptions) (*AxeResults, error) {
+	if err := s.injectAxe(); err != nil {
+		return nil, fmt.Errorf("failed to inject axe-core: %s", err)
+	}
+
+	var result struct {
+		Results *AxeResults `json:"results"`
+		Error   string      `json:"error"`
+	}
+	if err := s.session.ExecuteAsync(axeRunScript, []interface{}{context, options.axeOptions()}, &result); err != nil {
+		return nil, fmt.Errorf("failed to run accessibility audit: %s", err)
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("failed to run accessibility audit: %s", result.Error)
+	}
+	if result.Results == nil {
+		return nil, errors.New("failed to run accessibility audit: no results were returned")
+	}
+	return result.Results, nil
+}
+
+func (s *selectable) injectAxe() error {
+	if AxeSource != "" {
+		var loaded bool
+		if err := s.session.Execute("return !!window.axe;", nil, &loaded); err != nil || loaded {
+			return err
+		}
+		return s.session.Execute(AxeSource+"\n;return null;", nil, nil)
+	}
+
+	var loadErr string
+	if err := s.session.ExecuteAsync(axeLoadScript, []interface{}{AxeURL}, &loadErr); err != nil {
+		return err
+	}
+	if loadErr != "" {
+		return errors.New(loadErr)
+	}
+	return nil
+}
+
+func (o AxeOptions) axeOptions() map[string]interface{} {
+	axeOptions := map[string]interface{}{}
+	if len(o.Rules) > 0 {
+		axeOptions["runOnly"] = map[string]interface{}{"type": "rule", "values": o.Rules}
+	} else if len(o.Tags) > 0 {
+		axeOptions["runOnly"] = map[string]interface{}{"type": "tag", "values": o.Tags}
+	}
+	if len(o.DisabledRules) > 0 {
+		rules := map[string]interface{}{}
+		for _, rule := range o.DisabledRules {
+			rules[rule] = map[string]bool{"enabled": false}
+		}
+		axeOptions["rules"] = rules
+	}
+	return axeOptions
+}
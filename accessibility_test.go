@@ -0,0 +1,148 @@
+package agouti_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Accessibility", func() {
+	var (
+		page    *Page
+		session *mocks.Session
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		page = NewTestPage(session)
+		session.ExecuteAsyncCall.Result = `{"results": {
+			"URL": "http://example.com",
+			"Violations": [{"ID": "label", "Impact": "critical", "Help": "Form elements must have labels",
+				"Tags": ["wcag2a"], "Nodes": [{"Target": ["#email"], "HTML": "<input id=\"email\">"}]}],
+			"Incomplete": [],
+			"Passes": ["region"]
+		}}`
+	})
+
+	AfterEach(func() {
+		AxeSource = ""
+	})
+
+	Describe("#RunAccessibilityAudit", func() {
+		It("should return the results of the audit", func() {
+			results, err := page.RunAccessibilityAudit(AxeOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results.URL).To(Equal("http://example.com"))
+			Expect(results.Violations).To(Equal([]AxeRule{{
+				ID:     "label",
+				Impact: "critical",
+				Help:   "Form elements must have labels",
+				Tags:   []string{"wcag2a"},
+				Nodes:  []AxeNode{{Target: []string{"#email"}, HTML: `<input id="email">`}},
+			}}))
+			Expect(results.Passes).To(Equal([]string{"region"}))
+		})
+
+		It("should audit the entire document with the provided options", func() {
+			page.RunAccessibilityAudit(AxeOptions{Tags: []string{"wcag2aa"}, DisabledRules: []string{"region"}})
+			Expect(session.ExecuteAsyncCall.Body).To(ContainSubstring("axe.run"))
+			Expect(session.ExecuteAsyncCall.Arguments).To(Equal([]interface{}{nil, map[string]interface{}{
+				"runOnly": map[string]interface{}{"type": "tag", "values": []string{"wcag2aa"}},
+				"rules":   map[string]interface{}{"region": map[string]bool{"enabled": false}},
+			}}))
+		})
+
+		It("should prefer rules to tags", func() {
+			page.RunAccessibilityAudit(AxeOptions{Rules: []string{"label"}, Tags: []string{"wcag2aa"}})
+			Expect(session.ExecuteAsyncCall.Arguments[1]).To(Equal(map[string]interface{}{
+				"runOnly": map[string]interface{}{"type": "rule", "values": []string{"label"}},
+			}))
+		})
+
+		Context("when axe-core source is provided", func() {
+			It("should inject the source", func() {
+				AxeSource = "window.axe = {};"
+				session.ExecuteCall.Result = "false"
+				_, err := page.RunAccessibilityAudit(AxeOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(session.ExecuteCall.Body).To(HavePrefix("window.axe = {};"))
+			})
+		})
+
+		Context("when axe-core cannot be injected", func() {
+			It("should return an error", func() {
+				AxeSource = "window.axe = {};"
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err := page.RunAccessibilityAudit(AxeOptions{})
+				Expect(err).To(MatchError("failed to inject axe-core: some error"))
+			})
+		})
+
+		Context("when the audit fails", func() {
+			It("should return an error", func() {
+				session.ExecuteAsyncCall.Result = `{"error": "some error"}`
+				_, err := page.RunAccessibilityAudit(AxeOptions{})
+				Expect(err).To(MatchError("failed to run accessibility audit: some error"))
+			})
+		})
+
+		Context("when the audit script fails", func() {
+			It("should return an error", func() {
+				session.ExecuteAsyncCall.Err = errors.New("some error")
+				_, err := page.RunAccessibilityAudit(AxeOptions{})
+				Expect(err).To(MatchError("failed to inject axe-core: some error"))
+			})
+		})
+	})
+
+	Describe("#RunAccessibilityAudit on a selection", func() {
+		var (
+			elementRepository *mocks.ElementRepository
+			selection         *Selection
+			element           *api.Element
+		)
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			element = &api.Element{ID: "some-id"}
+			elementRepository.GetExactlyOneCall.ReturnElement = element
+			selection = NewTestSelection(session, elementRepository, "#form")
+		})
+
+		It("should audit the selected element", func() {
+			results, err := selection.RunAccessibilityAudit(AxeOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results.Violations).To(HaveLen(1))
+			Expect(session.ExecuteAsyncCall.Arguments[0]).To(Equal(element))
+		})
+
+		Context("when exactly one element is not selected", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.RunAccessibilityAudit(AxeOptions{})
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #form [single]': some error"))
+			})
+		})
+	})
+
+	Describe("AxeResults#ViolationsWithImpact", func() {
+		It("should return the violations with at least the provided impact", func() {
+			results := &AxeResults{Violations: []AxeRule{
+				{ID: "minor", Impact: "minor"},
+				{ID: "serious", Impact: "serious"},
+				{ID: "critical", Impact: "critical"},
+				{ID: "unknown"},
+			}}
+			Expect(results.ViolationsWithImpact("serious")).To(Equal([]AxeRule{
+				{ID: "serious", Impact: "serious"},
+				{ID: "critical", Impact: "critical"},
+				{ID: "unknown"},
+			}))
+			Expect(results.ViolationsWithImpact("")).To(HaveLen(4))
+		})
+	})
+})
@@ -0,0 +1,218 @@
+package agouti
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+// An AXNode is a node of the accessibility tree of a page, as exposed by the
+// browser to assistive technologies such as screen readers.
+type AXNode struct {
+	// Role is the computed role of the node, ex. "button" or "heading"
+	Role string
+
+	// Name is the computed accessible name of the node
+	Name string
+
+	// Description is the computed accessible description of the node
+	Description string
+
+	// Value is the value of the node, ex. the text of a text field
+	Value string
+
+	// Properties contains the states and other properties of the node, ex.
+	// "focusable" (true), "checked" ("true", "false", or "mixed"), or
+	// "level" (2).
+	Properties map[string]interface{}
+
+	// Children contains the child nodes of the node
+	Children []*AXNode
+}
+
+// FindAll returns every node within the node, including the node itself,
+// that has the provided role and, if non-empty, the provided name. Nodes are
+// returned in tree order.
+//
+// Example:
+//    tree, err := page.AccessibilityTree()
+//    for _, button := range tree.FindAll("button", "") {
+//        Expect(button.Name).NotTo(BeEmpty())
+//    }
+func (n *AXNode) FindAll(role, name string) []*AXNode {
+	var nodes []*AXNode
+	if n.Role == role && (name == "" || n.Name == name) {
+		nodes = append(nodes, n)
+	}
+	for _, child := range n.Children {
+		nodes = append(nodes, child.FindAll(role, name)...)
+	}
+	return nodes
+}
+
+// Values of properties are JSON values of any type, depending on the type of
+// the property.
+type cdpAXNode struct {
+	NodeID      string      `json:"nodeId"`
+	ChildIDs    []string    `json:"childIds"`
+	Ignored     bool        `json:"ignored"`
+	Role        *cdpAXValue `json:"role"`
+	Name        *cdpAXValue `json:"name"`
+	Description *cdpAXValue `json:"description"`
+	Value       *cdpAXValue `json:"value"`
+	Properties  []struct {
+		Name  string     `json:"name"`
+		Value cdpAXValue `json:"value"`
+	} `json:"properties"`
+}
+
+type cdpAXValue struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+func (v *cdpAXValue) String() string {
+	if v == nil || v.Value == nil {
+		return ""
+	}
+	if value, ok := v.Value.(string); ok {
+		return value
+	}
+	return fmt.Sprint(v.Value)
+}
+
+var axNodeCount uint64
+
+const (
+	axMarkScript   = `arguments[0].setAttribute("data-agouti-ax", arguments[1]);`
+	axUnmarkScript = `arguments[0].removeAttribute("data-agouti-ax");`
+)
+
+// AccessibilityTree returns the root of the full accessibility tree of the
+// page using the DevTools Accessibility domain, so only Chrome supports it.
+// Nodes that are ignored by assistive technologies are omitted, and their
+// children are moved to the nearest node that is not ignored.
+func (p *Page) AccessibilityTree() (*AXNode, error) {
+	var result struct {
+		Nodes []cdpAXNode `json:"nodes"`
+	}
+	if err := p.session.ExecuteCDP("Accessibility.getFullAXTree", nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to retrieve accessibility tree: %s", err)
+	}
+
+	root, err := buildAXTree(result.Nodes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve accessibility tree: %s", err)
+	}
+	return root, nil
+}
+
+// AXNode returns the accessibility node of exactly one element, without its
+// children. Only Chrome supports this, and the element must be within the
+// top-level frame.
+//
+// Example:
+//    node, err := page.Find(".icon-close").AXNode()
+//    Expect(node.Role).To(Equal("button"))
+//    Expect(node.Name).To(Equal("Close"))
+func (s *Selection) AXNode() (*AXNode, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return nil, selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	// The element is found by the DevTools Protocol using a temporary
+	// attribute, as WebDriver element references cannot be used directly.
+	mark := strconv.FormatUint(atomic.AddUint64(&axNodeCount, 1), 10)
+	if err := selectedElement.Execute(axMarkScript, []interface{}{mark}, nil); err != nil {
+		return nil, fmt.Errorf("failed to retrieve accessibility node for %s: %s", s, err)
+	}
+	defer selectedElement.Execute(axUnmarkScript, nil, nil)
+
+	evaluateRequest := struct {
+		Expression string `json:"expression"`
+	}{`document.querySelector('[data-agouti-ax="` + mark + `"]')`}
+	var evaluateResult struct {
+		Result struct {
+			ObjectID string `json:"objectId"`
+		} `json:"result"`
+	}
+	if err := s.session.ExecuteCDP("Runtime.evaluate", evaluateRequest, &evaluateResult); err != nil {
+		return nil, fmt.Errorf("failed to retrieve accessibility node for %s: %s", s, err)
+	}
+	if evaluateResult.Result.ObjectID == "" {
+		return nil, fmt.Errorf("failed to retrieve accessibility node for %s: element is not within the top-level frame", s)
+	}
+
+	treeRequest := struct {
+		ObjectID       string `json:"objectId"`
+		FetchRelatives bool   `json:"fetchRelatives"`
+	}{evaluateResult.Result.ObjectID, false}
+	var treeResult struct {
+		Nodes []cdpAXNode `json:"nodes"`
+	}
+	if err := s.session.ExecuteCDP("Accessibility.getPartialAXTree", treeRequest, &treeResult); err != nil {
+		return nil, fmt.Errorf("failed to retrieve accessibility node for %s: %s", s, err)
+	}
+	if len(treeResult.Nodes) == 0 {
+		return nil, fmt.Errorf("failed to retrieve accessibility node for %s: no node was returned", s)
+	}
+	return newAXNode(treeResult.Nodes[0]), nil
+}
+
+func buildAXTree(cdpNodes []cdpAXNode) (*AXNode, error) {
+	if len(cdpNodes) == 0 {
+		return nil, errors.New("no nodes were returned")
+	}
+
+	nodesByID := map[string]cdpAXNode{}
+	for _, cdpNode := range cdpNodes {
+		nodesByID[cdpNode.NodeID] = cdpNode
+	}
+
+	var children func(cdpNode cdpAXNode) []*AXNode
+	children = func(cdpNode cdpAXNode) []*AXNode {
+		var nodes []*AXNode
+		for _, childID := range cdpNode.ChildIDs {
+			child, ok := nodesByID[childID]
+			if !ok {
+				continue
+			}
+			if child.Ignored {
+				nodes = append(nodes, children(child)...)
+				continue
+			}
+			node := newAXNode(child)
+			node.Children = children(child)
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+
+	root := newAXNode(cdpNodes[0])
+	root.Children = children(cdpNodes[0])
+	return root, nil
+}
+
+func newAXNode(cdpNode cdpAXNode) *AXNode {
+	node := &AXNode{
+		Role:        cdpNode.Role.String(),
+		Name:        cdpNode.Name.String(),
+		Description: cdpNode.Description.String(),
+		Value:       cdpNode.Value.String(),
+		Properties:  map[string]interface{}{},
+	}
+	for _, property := range cdpNode.Properties {
+		node.Properties[property.Name] = axPropertyValue(property.Value)
+	}
+	return node
+}
+
+// Numeric properties are provided as integers when possible, ex. "level".
+func axPropertyValue(value cdpAXValue) interface{} {
+	if number, ok := value.Value.(float64); ok && number == float64(int(number)) {
+		return int(number)
+	}
+	return value.Value
+}
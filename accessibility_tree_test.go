@@ -0,0 +1,150 @@
+package agouti_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Accessibility Tree", func() {
+	var session *mocks.Session
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+	})
+
+	Describe("#AccessibilityTree", func() {
+		var page *Page
+
+		BeforeEach(func() {
+			page = NewTestPage(session)
+			session.ExecuteCDPCall.Result = `{"nodes": [
+				{"nodeId": "1", "role": {"type": "internalRole", "value": "RootWebArea"}, "name": {"type": "computedString", "value": "Some Title"}, "childIds": ["2"]},
+				{"nodeId": "2", "ignored": true, "role": {"type": "role", "value": "none"}, "childIds": ["3", "4"]},
+				{"nodeId": "3", "role": {"type": "role", "value": "heading"}, "name": {"type": "computedString", "value": "Welcome"},
+					"properties": [{"name": "level", "value": {"type": "integer", "value": 1}}], "childIds": []},
+				{"nodeId": "4", "role": {"type": "role", "value": "button"}, "name": {"type": "computedString", "value": ""},
+					"properties": [{"name": "focusable", "value": {"type": "booleanOrUndefined", "value": true}}], "childIds": []}
+			]}`
+		})
+
+		It("should request the full accessibility tree", func() {
+			_, err := page.AccessibilityTree()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.ExecuteCDPCall.Command).To(Equal("Accessibility.getFullAXTree"))
+		})
+
+		It("should return the tree without ignored nodes", func() {
+			tree, err := page.AccessibilityTree()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tree).To(Equal(&AXNode{
+				Role:       "RootWebArea",
+				Name:       "Some Title",
+				Properties: map[string]interface{}{},
+				Children: []*AXNode{
+					{Role: "heading", Name: "Welcome", Properties: map[string]interface{}{"level": 1}},
+					{Role: "button", Properties: map[string]interface{}{"focusable": true}},
+				},
+			}))
+		})
+
+		Context("when the tree cannot be retrieved", func() {
+			It("should return an error", func() {
+				session.ExecuteCDPCall.Err = errors.New("some error")
+				_, err := page.AccessibilityTree()
+				Expect(err).To(MatchError("failed to retrieve accessibility tree: some error"))
+			})
+		})
+
+		Context("when the tree is empty", func() {
+			It("should return an error", func() {
+				session.ExecuteCDPCall.Result = `{"nodes": []}`
+				_, err := page.AccessibilityTree()
+				Expect(err).To(MatchError("failed to retrieve accessibility tree: no nodes were returned"))
+			})
+		})
+	})
+
+	Describe("AXNode#FindAll", func() {
+		It("should return the nodes with the provided role and name in tree order", func() {
+			tree := &AXNode{Role: "main", Children: []*AXNode{
+				{Role: "button", Name: "Save", Children: []*AXNode{{Role: "button", Name: "Nested"}}},
+				{Role: "link", Name: "Save"},
+				{Role: "button", Name: "Cancel"},
+			}}
+			Expect(tree.FindAll("button", "")).To(Equal([]*AXNode{
+				{Role: "button", Name: "Save", Children: []*AXNode{{Role: "button", Name: "Nested"}}},
+				{Role: "button", Name: "Nested"},
+				{Role: "button", Name: "Cancel"},
+			}))
+			Expect(tree.FindAll("button", "Cancel")).To(Equal([]*AXNode{{Role: "button", Name: "Cancel"}}))
+			Expect(tree.FindAll("heading", "")).To(BeEmpty())
+		})
+	})
+
+	Describe("#AXNode", func() {
+		var (
+			elementRepository *mocks.ElementRepository
+			selectedElement   *mocks.Element
+			selection         *Selection
+		)
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			selectedElement = &mocks.Element{}
+			elementRepository.GetExactlyOneCall.ReturnElement = selectedElement
+			selection = NewTestSelection(session, elementRepository, "#close")
+			session.ExecuteCDPCall.Result = `{
+				"result": {"objectId": "some-object"},
+				"nodes": [{"nodeId": "4", "role": {"type": "role", "value": "button"}, "name": {"type": "computedString", "value": "Close"}}]
+			}`
+		})
+
+		It("should return the accessibility node of the selected element", func() {
+			node, err := selection.AXNode()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(node).To(Equal(&AXNode{Role: "button", Name: "Close", Properties: map[string]interface{}{}}))
+			Expect(session.ExecuteCDPCall.Command).To(Equal("Accessibility.getPartialAXTree"))
+		})
+
+		It("should remove the temporary attribute from the element", func() {
+			selection.AXNode()
+			Expect(selectedElement.ExecuteCall.Body).To(Equal(`arguments[0].removeAttribute("data-agouti-ax");`))
+		})
+
+		Context("when the element is not within the top-level frame", func() {
+			It("should return an error", func() {
+				session.ExecuteCDPCall.Result = `{"result": {"type": "object", "subtype": "null"}}`
+				_, err := selection.AXNode()
+				Expect(err).To(MatchError("failed to retrieve accessibility node for selection 'CSS: #close [single]': element is not within the top-level frame"))
+			})
+		})
+
+		Context("when the DevTools Protocol command fails", func() {
+			It("should return an error", func() {
+				session.ExecuteCDPCall.Err = errors.New("some error")
+				_, err := selection.AXNode()
+				Expect(err).To(MatchError("failed to retrieve accessibility node for selection 'CSS: #close [single]': some error"))
+			})
+		})
+
+		Context("when the element cannot be marked", func() {
+			It("should return an error", func() {
+				selectedElement.ExecuteCall.Err = errors.New("some error")
+				_, err := selection.AXNode()
+				Expect(err).To(MatchError("failed to retrieve accessibility node for selection 'CSS: #close [single]': some error"))
+			})
+		})
+
+		Context("when exactly one element is not selected", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.AXNode()
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #close [single]': some error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,140 @@
+package agouti
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
+)
+
+// Actions is a chain of keyboard and mouse input actions that are performed
+// in order when Perform is called. Actions may be created using
+// *Page.Action() and require a WebDriver that supports the W3C dialect.
+//
+// Examples:
+//
+//    page.Action().DragAndDrop(page.Find("#item"), page.Find("#trash")).Perform()
+// Drags the item to the trash.
+//    page.Action().KeyDown(keys.Control).Click(SingleClick, LeftButton).KeyUp(keys.Control).Perform()
+// Control-clicks at the current mouse position.
+type Actions struct {
+	session apiSession
+	actions *api.Actions
+	err     error
+}
+
+// Action returns a new chain of input actions for the page.
+func (p *Page) Action() *Actions {
+	return &Actions{session: p.session, actions: api.NewActions()}
+}
+
+// KeyDown presses the provided key without releasing it.
+func (a *Actions) KeyDown(key string) *Actions {
+	a.actions.KeyDown(key)
+	return a
+}
+
+// KeyUp releases the provided key.
+func (a *Actions) KeyUp(key string) *Actions {
+	a.actions.KeyUp(key)
+	return a
+}
+
+// Press presses the provided keys together as a chord, ex. Control and "a",
+// and then releases them in reverse order.
+func (a *Actions) Press(keys ...string) *Actions {
+	for _, key := range keys {
+		a.actions.KeyDown(key)
+	}
+	for i := len(keys) - 1; i >= 0; i-- {
+		a.actions.KeyUp(keys[i])
+	}
+	return a
+}
+
+// MoveMouseTo moves the mouse over the center of exactly one element in the
+// provided *Selection or *MultiSelection.
+func (a *Actions) MoveMouseTo(selection interface{}) *Actions {
+	selectedElement, err := a.selectElement(selection)
+	if err != nil {
+		a.fail(err)
+		return a
+	}
+	a.actions.PointerMove(selectedElement, 0, 0)
+	return a
+}
+
+// MoveMouseBy moves the mouse by the provided offset.
+func (a *Actions) MoveMouseBy(xOffset, yOffset int) *Actions {
+	a.actions.PointerMoveBy(xOffset, yOffset)
+	return a
+}
+
+// Click performs the provided Click event using the provided Button at the
+// current mouse position.
+func (a *Actions) Click(event Click, button Button) *Actions {
+	switch event {
+	case SingleClick:
+		a.actions.PointerDown(api.Button(button)).PointerUp(api.Button(button))
+	case HoldClick:
+		a.actions.PointerDown(api.Button(button))
+	case ReleaseClick:
+		a.actions.PointerUp(api.Button(button))
+	default:
+		a.fail(errors.New("invalid click event"))
+	}
+	return a
+}
+
+// DragAndDrop drags exactly one element in the from selection to exactly
+// one element in the to selection using the left mouse button.
+func (a *Actions) DragAndDrop(from, to interface{}) *Actions {
+	return a.MoveMouseTo(from).
+		Click(HoldClick, LeftButton).
+		MoveMouseTo(to).
+		Click(ReleaseClick, LeftButton)
+}
+
+// Pause waits for the provided duration before performing the next action.
+func (a *Actions) Pause(duration time.Duration) *Actions {
+	a.actions.Pause(duration)
+	return a
+}
+
+// Perform performs each action in the chain. If any action in the chain
+// could not be prepared, no actions are performed.
+func (a *Actions) Perform() error {
+	if a.err != nil {
+		return fmt.Errorf("failed to prepare actions: %s", a.err)
+	}
+
+	if err := a.session.PerformActions(a.actions); err != nil {
+		return fmt.Errorf("failed to perform actions: %s", err)
+	}
+	return nil
+}
+
+func (a *Actions) selectElement(value interface{}) (*api.Element, error) {
+	selection, err := toSelection(value)
+	if err != nil {
+		return nil, err
+	}
+
+	selectedElement, err := selection.elements.GetExactlyOne()
+	if err != nil {
+		return nil, selectionError("failed to select element from %s: %s", selection, err)
+	}
+	apiElement, err := element.Unwrap(selectedElement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select element from %s: %s", selection, err)
+	}
+	return apiElement, nil
+}
+
+func (a *Actions) fail(err error) {
+	if a.err == nil {
+		a.err = err
+	}
+}
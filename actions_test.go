@@ -0,0 +1,133 @@
+package agouti_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Actions", func() {
+	var (
+		page              *Page
+		session           *mocks.Session
+		elementRepository *mocks.ElementRepository
+		selection         *Selection
+		firstElement      *api.Element
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		page = NewTestPage(session)
+		elementRepository = &mocks.ElementRepository{}
+		firstElement = &api.Element{ID: "some-id"}
+		elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		selection = NewTestSelection(session, elementRepository, "#selector")
+	})
+
+	Describe("#Perform", func() {
+		It("should successfully perform the chained actions using the session", func() {
+			Expect(page.Action().KeyDown("a").KeyUp("a").Perform()).To(Succeed())
+			Expect(session.PerformActionsCall.Actions).To(Equal(api.NewActions().KeyDown("a").KeyUp("a")))
+		})
+
+		Context("when an action could not be prepared", func() {
+			It("should return an error without performing any actions", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				err := page.Action().KeyDown("a").MoveMouseTo(selection).Perform()
+				Expect(err).To(MatchError("failed to prepare actions: failed to select element from selection 'CSS: #selector [single]': some error"))
+				Expect(session.PerformActionsCall.Actions).To(BeNil())
+			})
+		})
+
+		Context("when the session fails to perform the actions", func() {
+			It("should return an error", func() {
+				session.PerformActionsCall.Err = errors.New("some error")
+				err := page.Action().KeyDown("a").Perform()
+				Expect(err).To(MatchError("failed to perform actions: some error"))
+			})
+		})
+	})
+
+	Describe("#Press", func() {
+		It("should press the keys together and release them in reverse order", func() {
+			Expect(page.Action().Press("a", "b", "c").Perform()).To(Succeed())
+			expected := api.NewActions().KeyDown("a").KeyDown("b").KeyDown("c").KeyUp("c").KeyUp("b").KeyUp("a")
+			Expect(session.PerformActionsCall.Actions).To(Equal(expected))
+		})
+	})
+
+	Describe("#MoveMouseTo", func() {
+		It("should move the mouse to the center of the selected element", func() {
+			Expect(page.Action().MoveMouseTo(selection).Perform()).To(Succeed())
+			Expect(session.PerformActionsCall.Actions).To(Equal(api.NewActions().PointerMove(firstElement, 0, 0)))
+		})
+
+		It("should accept a *MultiSelection", func() {
+			multiSelection := NewTestMultiSelection(session, elementRepository, "#selector")
+			Expect(page.Action().MoveMouseTo(multiSelection).Perform()).To(Succeed())
+			Expect(session.PerformActionsCall.Actions).To(Equal(api.NewActions().PointerMove(firstElement, 0, 0)))
+		})
+
+		Context("when provided with something other than a selection", func() {
+			It("should return an error", func() {
+				err := page.Action().MoveMouseTo("not a selection").Perform()
+				Expect(err).To(MatchError("failed to prepare actions: must be *Selection or *MultiSelection"))
+			})
+		})
+	})
+
+	Describe("#MoveMouseBy", func() {
+		It("should move the mouse by the provided offset", func() {
+			Expect(page.Action().MoveMouseBy(10, 20).Perform()).To(Succeed())
+			Expect(session.PerformActionsCall.Actions).To(Equal(api.NewActions().PointerMoveBy(10, 20)))
+		})
+	})
+
+	Describe("#Click", func() {
+		It("should press and release the button for a single click", func() {
+			Expect(page.Action().Click(SingleClick, RightButton).Perform()).To(Succeed())
+			Expect(session.PerformActionsCall.Actions).To(Equal(api.NewActions().PointerDown(api.RightButton).PointerUp(api.RightButton)))
+		})
+
+		It("should press the button for a hold click", func() {
+			Expect(page.Action().Click(HoldClick, LeftButton).Perform()).To(Succeed())
+			Expect(session.PerformActionsCall.Actions).To(Equal(api.NewActions().PointerDown(api.LeftButton)))
+		})
+
+		It("should release the button for a release click", func() {
+			Expect(page.Action().Click(ReleaseClick, LeftButton).Perform()).To(Succeed())
+			Expect(session.PerformActionsCall.Actions).To(Equal(api.NewActions().PointerUp(api.LeftButton)))
+		})
+
+		Context("when the click event is invalid", func() {
+			It("should return an error", func() {
+				err := page.Action().Click(Click(-1), LeftButton).Perform()
+				Expect(err).To(MatchError("failed to prepare actions: invalid click event"))
+			})
+		})
+	})
+
+	Describe("#DragAndDrop", func() {
+		It("should drag the first selected element to the second selected element", func() {
+			Expect(page.Action().DragAndDrop(selection, selection).Perform()).To(Succeed())
+			expected := api.NewActions().
+				PointerMove(firstElement, 0, 0).
+				PointerDown(api.LeftButton).
+				PointerMove(firstElement, 0, 0).
+				PointerUp(api.LeftButton)
+			Expect(session.PerformActionsCall.Actions).To(Equal(expected))
+		})
+	})
+
+	Describe("#Pause", func() {
+		It("should pause between actions", func() {
+			Expect(page.Action().KeyDown("a").Pause(time.Second).KeyUp("a").Perform()).To(Succeed())
+			Expect(session.PerformActionsCall.Actions).To(Equal(api.NewActions().KeyDown("a").Pause(time.Second).KeyUp("a")))
+		})
+	})
+})
@@ -5,8 +5,13 @@ package agouti
 
 import (
 	"fmt"
+	"os/exec"
 	"path/filepath"
 	"runtime"
+
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/driverinstall"
+	"github.com/sclevine/agouti/drivers/docker"
 )
 
 // PhantomJS returns an instance of a PhantomJS WebDriver.
@@ -29,6 +34,11 @@ func PhantomJS(options ...Option) *WebDriver {
 
 // ChromeDriver returns an instance of a ChromeDriver WebDriver.
 //
+// If the InstallDriver Option is provided and chromedriver is not in the
+// PATH, the chromedriver that matches the installed version of Chrome is
+// downloaded and cached using driverinstall.ChromeDriver when the WebDriver
+// is started.
+//
 // Provided Options will apply as default arguments for new pages.
 // New pages will accept invalid SSL certificates by default. This
 // may be disabled using the RejectInvalidSSL Option.
@@ -40,25 +50,108 @@ func ChromeDriver(options ...Option) *WebDriver {
 		binaryName = "chromedriver"
 	}
 	command := []string{binaryName, "--port={{.Port}}"}
-	return NewWebDriver("http://{{.Address}}", command, options...)
+	driver := NewWebDriver("http://{{.Address}}", command, options...)
+	if driver.defaultOptions.InstallDriver {
+		driver.InstallBinary = installIfMissing(binaryName, driverinstall.ChromeDriver)
+	}
+	return driver
 }
 
-// EdgeDriver returns an instance of a EdgeDriver WebDriver.
+// GeckoDriver returns an instance of a GeckoDriver WebDriver, which controls
+// Firefox.
+//
+// If the InstallDriver Option is provided and geckodriver is not in the PATH,
+// the geckodriver that supports the installed version of Firefox is
+// downloaded and cached using driverinstall.GeckoDriver when the WebDriver is
+// started.
 //
 // Provided Options will apply as default arguments for new pages.
 // New pages will accept invalid SSL certificates by default. This
-// may be disabled using the RejectInvalidSSL Option.
+// may be disabled using the RejectInvalidSSL Option. As geckodriver only
+// supports the W3C protocol, legacy capabilities are translated to their
+// W3C equivalents (ex. "acceptSslCerts" to "acceptInsecureCerts"), and
+// other capabilities that are not vendor-prefixed are removed.
+func GeckoDriver(options ...Option) *WebDriver {
+	binaryName := "geckodriver"
+	if runtime.GOOS == "windows" {
+		binaryName = "geckodriver.exe"
+	}
+	command := []string{binaryName, "--host=127.0.0.1", "--port={{.Port}}"}
+	options = append([]Option{Browser("firefox"), w3cOnly}, options...)
+	driver := NewWebDriver("http://{{.Address}}", command, options...)
+	if driver.defaultOptions.InstallDriver {
+		driver.InstallBinary = installIfMissing(binaryName, driverinstall.GeckoDriver)
+	}
+	return driver
+}
+
+// installIfMissing returns a function for api.WebDriver.InstallBinary that
+// only installs the driver if it is not in the PATH.
+func installIfMissing(binaryName string, install func() (string, error)) func() (string, error) {
+	return func() (string, error) {
+		if _, err := exec.LookPath(binaryName); err == nil {
+			return binaryName, nil
+		}
+		return install()
+	}
+}
+
+// EdgeDriver returns an instance of a Microsoft Edge WebDriver, which
+// controls the Chromium-based Edge using msedgedriver.
+//
+// Provided Options will apply as default arguments for new pages.
+// New pages will accept invalid SSL certificates by default. This
+// may be disabled using the RejectInvalidSSL Option. Edge may be configured
+// using Capabilities.Edge.
 func EdgeDriver(options ...Option) *WebDriver {
-	var binaryName string
+	binaryName := "msedgedriver"
 	if runtime.GOOS == "windows" {
-		binaryName = "MicrosoftWebDriver.exe"
-	} else {
-		return nil
+		binaryName = "msedgedriver.exe"
 	}
 	command := []string{binaryName, "--port={{.Port}}"}
-	// Using {{.Address}} means using 127.0.0.1
-	// But MicrosoftWebDriver only supports localhost, not 127.0.0.1
-	return NewWebDriver("http://localhost:{{.Port}}", command, options...)
+	options = append([]Option{Browser("MicrosoftEdge")}, options...)
+	return NewWebDriver("http://{{.Address}}", command, options...)
+}
+
+// Safari returns an instance of a SafariDriver WebDriver, which controls
+// Safari on macOS.
+//
+// Remote automation must be enabled once before Safari can be controlled,
+// by running 'safaridriver --enable' (which requires an administrator
+// password) or by enabling "Allow Remote Automation" in the Develop menu of
+// Safari.
+//
+// Provided Options will apply as default arguments for new pages.
+// New pages will reject invalid SSL certificates, as Safari does not
+// support accepting them. As SafariDriver only supports one session at a
+// time, NewPage waits until the previous page is destroyed. Legacy
+// capabilities are translated to their W3C equivalents like GeckoDriver.
+func Safari(options ...Option) *WebDriver {
+	command := []string{"/usr/bin/safaridriver", "--port={{.Port}}"}
+	options = append([]Option{Browser("safari"), RejectInvalidSSL, w3cOnly, singleSession}, options...)
+	return NewWebDriver("http://{{.Address}}", command, options...)
+}
+
+// DockerChrome returns an instance of a WebDriver that runs Chrome in a
+// selenium/standalone-chrome Docker container, so that neither Chrome nor
+// ChromeDriver needs to be installed. The latest image is pulled when the
+// WebDriver is started if no image is present, and the container is removed
+// when the WebDriver is stopped. Docker must be installed.
+//
+// Provided Options will apply as default arguments for new pages.
+// The Timeout Option, which defaults to 60 seconds, does not include the
+// time taken to pull the image. The Debug Option writes the output of the
+// container to stdout.
+func DockerChrome(options ...Option) *WebDriver {
+	return DockerWebDriver(docker.Chrome(""), options...)
+}
+
+// DockerWebDriver returns an instance of a WebDriver that runs in the
+// provided Docker container, such as docker.Firefox("120.0"). It takes the
+// same Options as DockerChrome.
+func DockerWebDriver(container *docker.Container, options ...Option) *WebDriver {
+	options = append([]Option{Timeout(60)}, options...)
+	return newWebDriver(api.NewWebDriverWithService(container), options)
 }
 
 // Selenium returns an instance of a Selenium WebDriver.
@@ -0,0 +1,273 @@
+// Package agoutitest provides a fake browser for unit testing code that uses
+// agouti, such as page objects, without starting a real browser.
+//
+// The fake browser serves programmable documents, which are trees of
+// elements, and responds to the WebDriver commands that agouti sends for
+// them: finding elements, reading and changing element state, navigating,
+// cookies, and alert popups. Elements may be found using link text, tag
+// names, and CSS selectors made of type, ID, class, and attribute selectors,
+// the :scope and :root pseudo-classes, and the descendant and child
+// combinators. XPath is only supported for the expressions that agouti uses
+// for Select, FindByLabel, FindByButton, Parent, NextSibling, and
+// PrevSibling. Scripts cannot be run, so any scripts must be answered by a
+// ScriptHandler. Any command may be overridden using Handle, and all
+// commands are recorded so that they may be checked.
+//
+// A *Browser implements api.Bus, so it may be used as the Bus of an
+// *api.Session directly. NewServer serves the fake browser over HTTP using
+// the W3C WebDriver protocol, so that an *agouti.Page may be opened for it:
+//    browser := agoutitest.NewBrowser()
+//    browser.Route("http://example.com/login", func() *agoutitest.Document {
+//        return &agoutitest.Document{Title: "Login", Root: &agoutitest.Element{
+//            Tag: "form", Attributes: map[string]string{"action": "/welcome"},
+//            Children: []*agoutitest.Element{
+//                {Tag: "input", Attributes: map[string]string{"name": "user"}},
+//                {Tag: "button", Attributes: map[string]string{"id": "login"}, Text: "Log In"},
+//            },
+//        }}
+//    })
+//    server := agoutitest.NewServer(browser)
+//    defer server.Close()
+//
+//    page, err := agouti.NewPage(server.URL)
+//    ...
+//    loginPage.LogIn("some-user")
+//    Expect(browser.URL()).To(Equal("http://example.com/welcome"))
+package agoutitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/sclevine/agouti/api"
+)
+
+// A Handler responds to a command sent to the fake browser. It is provided
+// with the JSON body of the command, or nil if the command has no body, and
+// returns the value of the response, which is encoded as JSON. A Handler
+// may return an *api.Error to respond with a WebDriver error.
+type Handler func(body []byte) (interface{}, error)
+
+// A ScriptHandler responds to scripts run by the fake browser. Arguments
+// that refer to elements are provided as *Elements, and any *Elements in the
+// returned value are provided to the caller as element references.
+type ScriptHandler func(script string, arguments []interface{}) (interface{}, error)
+
+// A Command is a WebDriver command received by the fake browser. The
+// endpoint is relative to the session, ex. "element/element-1/click".
+type Command struct {
+	Method   string
+	Endpoint string
+	Body     []byte
+}
+
+func (c Command) String() string {
+	return c.Method + " " + c.Endpoint
+}
+
+type handlerRoute struct {
+	method  string
+	pattern string
+	handler Handler
+}
+
+// A Browser is a fake browser with a single window.
+type Browser struct {
+	mutex sync.Mutex
+
+	routes       map[string]func() *Document
+	document     *Document
+	history      []string
+	historyIndex int
+
+	elements   map[string]*Element
+	elementIDs map[*Element]string
+	active     *Element
+
+	alert    *string
+	cookies  []map[string]interface{}
+	deleted  bool
+	handlers []handlerRoute
+	script   ScriptHandler
+	commands []Command
+	expected []Command
+}
+
+// NewBrowser returns a fake browser with an empty "about:blank" page loaded.
+func NewBrowser() *Browser {
+	return &Browser{
+		routes:     map[string]func() *Document{},
+		document:   &Document{},
+		history:    []string{"about:blank"},
+		elements:   map[string]*Element{},
+		elementIDs: map[*Element]string{},
+	}
+}
+
+// Session returns an *api.Session that sends its commands to the fake
+// browser using the W3C WebDriver dialect.
+func (b *Browser) Session() *api.Session {
+	return &api.Session{Bus: b, W3C: true}
+}
+
+// Route provides the document that is loaded when the fake browser navigates
+// to the provided URL. The function is called each time the URL is loaded,
+// so that the page is reset by navigating to it again. URLs without a route
+// load an empty document.
+func (b *Browser) Route(url string, document func() *Document) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.routes[url] = document
+}
+
+// Load navigates the fake browser to the provided URL without sending a
+// command, which is useful for setting up the page under test.
+func (b *Browser) Load(url string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.navigate(url)
+}
+
+// Document returns the document that is currently loaded.
+func (b *Browser) Document() *Document {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.document
+}
+
+// URL returns the URL of the document that is currently loaded.
+func (b *Browser) URL() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.history[b.historyIndex]
+}
+
+// OpenAlert opens an alert popup with the provided text. While the popup is
+// open, any commands other than alert commands fail with an "unexpected
+// alert open" error.
+func (b *Browser) OpenAlert(text string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.alert = &text
+}
+
+// Handle overrides the response to any commands with the provided method
+// and an endpoint that matches the provided pattern. The pattern uses the
+// syntax of path.Match, so "element/*/click" matches clicks on any element.
+// Handlers that are provided later take precedence.
+func (b *Browser) Handle(method, pattern string, handler Handler) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.handlers = append([]handlerRoute{{method, pattern, handler}}, b.handlers...)
+}
+
+// HandleScript provides the ScriptHandler that responds to all scripts.
+// Without a ScriptHandler, scripts fail with a "javascript error".
+func (b *Browser) HandleScript(handler ScriptHandler) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.script = handler
+}
+
+// Commands returns all of the commands received by the fake browser, in the
+// order they were received.
+func (b *Browser) Commands() []Command {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return append([]Command(nil), b.commands...)
+}
+
+// Expect records that a command with the provided method and an endpoint
+// matching the provided pattern is expected to be received. The pattern uses
+// the syntax of path.Match. See Verify.
+func (b *Browser) Expect(method, pattern string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.expected = append(b.expected, Command{Method: method, Endpoint: pattern})
+}
+
+// Verify returns an error describing any expected commands that have not
+// been received.
+func (b *Browser) Verify() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var missing []string
+	for _, expected := range b.expected {
+		received := false
+		for _, command := range b.commands {
+			if commandMatches(command, expected.Method, expected.Endpoint) {
+				received = true
+				break
+			}
+		}
+		if !received {
+			missing = append(missing, expected.String())
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("expected commands were not received:\n    %s", strings.Join(missing, "\n    "))
+	}
+	return nil
+}
+
+// Send implements api.Bus by responding to the provided command as the
+// fake browser.
+func (b *Browser) Send(method, endpoint string, body, result interface{}) error {
+	var bodyJSON []byte
+	if body != nil {
+		var err error
+		if bodyJSON, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("invalid request body: %s", err)
+		}
+	}
+
+	value, err := b.handle(method, endpoint, bodyJSON)
+	if err != nil {
+		return err
+	}
+
+	if result == nil {
+		return nil
+	}
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("invalid response: %s", err)
+	}
+	if err := json.Unmarshal(valueJSON, result); err != nil {
+		return fmt.Errorf("unexpected response: %s", valueJSON)
+	}
+	return nil
+}
+
+// Handlers are called without holding the lock, so that they may use the
+// fake browser.
+func (b *Browser) handle(method, endpoint string, body []byte) (interface{}, error) {
+	b.mutex.Lock()
+	b.commands = append(b.commands, Command{method, endpoint, body})
+	var handler Handler
+	for _, route := range b.handlers {
+		if commandMatches(Command{Method: method, Endpoint: endpoint}, route.method, route.pattern) {
+			handler = route.handler
+			break
+		}
+	}
+	b.mutex.Unlock()
+
+	if handler != nil {
+		return handler(body)
+	}
+	return b.execute(method, endpoint, body)
+}
+
+func commandMatches(command Command, method, pattern string) bool {
+	if command.Method != method {
+		return false
+	}
+	matched, _ := path.Match(pattern, command.Endpoint)
+	return matched
+}
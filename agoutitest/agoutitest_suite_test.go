@@ -0,0 +1,13 @@
+package agoutitest_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestAgoutitest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Agoutitest Suite")
+}
@@ -0,0 +1,243 @@
+package agoutitest_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/agoutitest"
+	"github.com/sclevine/agouti/api"
+)
+
+var _ = Describe("Browser", func() {
+	var (
+		browser *Browser
+		session *api.Session
+		input   *Element
+	)
+
+	BeforeEach(func() {
+		browser = NewBrowser()
+		browser.Route("http://example.com/form", func() *Document {
+			input = &Element{Tag: "input", Attributes: map[string]string{"name": "user", "value": "some"}}
+			return &Document{Title: "Some Form", Root: &Element{Tag: "html", Children: []*Element{
+				{Tag: "form", Attributes: map[string]string{"id": "form", "action": "/submitted"}, Children: []*Element{
+					input,
+					{Tag: "input", Attributes: map[string]string{"type": "checkbox", "id": "terms"}},
+					{Tag: "select", Children: []*Element{
+						{Tag: "option", Text: "One", Selected: true},
+						{Tag: "option", Text: "Two"},
+					}},
+					{Tag: "button", Text: "Submit"},
+				}},
+				{Tag: "a", Attributes: map[string]string{"href": "/other"}, Text: "Other Page"},
+				{Tag: "p", Hidden: true, Text: "hidden text"},
+			}}}
+		})
+		browser.Load("http://example.com/form")
+		session = browser.Session()
+	})
+
+	Describe("#Load", func() {
+		It("should load the routed document for the URL", func() {
+			Expect(browser.URL()).To(Equal("http://example.com/form"))
+			Expect(browser.Document().Title).To(Equal("Some Form"))
+			Expect(session.GetTitle()).To(Equal("Some Form"))
+		})
+
+		It("should load an empty document for URLs without a route", func() {
+			browser.Load("http://example.com/missing")
+			Expect(browser.Document().Root).To(BeNil())
+			Expect(session.GetTitle()).To(BeEmpty())
+		})
+	})
+
+	Describe("navigation commands", func() {
+		It("should navigate to the provided URL and through the history", func() {
+			Expect(session.SetURL("http://example.com/other")).To(Succeed())
+			Expect(session.GetURL()).To(Equal("http://example.com/other"))
+			Expect(session.Back()).To(Succeed())
+			Expect(session.GetURL()).To(Equal("http://example.com/form"))
+			Expect(session.Forward()).To(Succeed())
+			Expect(session.GetURL()).To(Equal("http://example.com/other"))
+		})
+
+		It("should reload the document when the page is refreshed", func() {
+			element, err := session.GetElement(api.Selector{Using: "css selector", Value: "input"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.Refresh()).To(Succeed())
+			_, err = element.GetText()
+			Expect(api.IsStaleElement(err)).To(BeTrue())
+		})
+	})
+
+	Describe("element commands", func() {
+		var element *api.Element
+
+		find := func(selector string) *api.Element {
+			element, err := session.GetElement(api.Selector{Using: "css selector", Value: selector})
+			Expect(err).NotTo(HaveOccurred())
+			return element
+		}
+
+		It("should find elements within other elements", func() {
+			element = find("form")
+			elements, err := element.GetElements(api.Selector{Using: "tag name", Value: "option"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(elements).To(HaveLen(2))
+			Expect(elements[1].GetText()).To(Equal("Two"))
+		})
+
+		It("should return a no such element error when no elements are found", func() {
+			_, err := session.GetElement(api.Selector{Using: "css selector", Value: "#missing"})
+			Expect(api.IsNoSuchElement(err)).To(BeTrue())
+			elements, err := session.GetElements(api.Selector{Using: "css selector", Value: "#missing"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(elements).To(BeEmpty())
+		})
+
+		It("should return an invalid selector error for unsupported selectors", func() {
+			_, err := session.GetElement(api.Selector{Using: "xpath", Value: "//a"})
+			Expect(api.ErrorCode(err)).To(Equal(api.ErrorInvalidSelector))
+			Expect(err).To(MatchError("request unsuccessful: invalid selector: unsupported XPath expression: //a"))
+		})
+
+		It("should retrieve the text of visible elements", func() {
+			Expect(find("form").GetText()).To(Equal("One Two Submit"))
+			Expect(find("p").GetText()).To(BeEmpty())
+			Expect(find("p").IsDisplayed()).To(BeFalse())
+		})
+
+		It("should fill and clear the value of elements", func() {
+			element = find("input")
+			Expect(element.Value(" text")).To(Succeed())
+			Expect(element.GetAttribute("value")).To(Equal("some text"))
+			Expect(input.Attribute("value")).To(Equal("some text"))
+			Expect(element.Clear()).To(Succeed())
+			Expect(element.GetProperty("value")).To(Equal(""))
+		})
+
+		It("should toggle checkboxes and select options when they are clicked", func() {
+			element = find("#terms")
+			Expect(element.Click()).To(Succeed())
+			Expect(element.IsSelected()).To(BeTrue())
+			Expect(element.GetAttribute("checked")).To(Equal("true"))
+
+			options, err := session.GetElements(api.Selector{Using: "css selector", Value: "option"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(options[1].Click()).To(Succeed())
+			Expect(options[0].IsSelected()).To(BeFalse())
+			Expect(options[1].IsSelected()).To(BeTrue())
+		})
+
+		It("should submit forms and follow links when they are clicked", func() {
+			Expect(find("button").Click()).To(Succeed())
+			Expect(browser.URL()).To(Equal("http://example.com/submitted"))
+			Expect(session.Back()).To(Succeed())
+			Expect(find("a").Click()).To(Succeed())
+			Expect(browser.URL()).To(Equal("http://example.com/other"))
+		})
+
+		It("should not click hidden elements", func() {
+			err := find("p").Click()
+			Expect(api.ErrorCode(err)).To(Equal(api.ErrorElementNotInteractable))
+		})
+
+		It("should return a stale element error for elements removed from the document", func() {
+			element = find("input")
+			form := browser.Document().Root.Children[0]
+			form.Children = form.Children[1:]
+			_, err := element.GetText()
+			Expect(api.IsStaleElement(err)).To(BeTrue())
+		})
+	})
+
+	Describe("alert commands", func() {
+		It("should block other commands until the alert is closed", func() {
+			browser.OpenAlert("some alert")
+			Expect(session.GetAlertText()).To(Equal("some alert"))
+			_, err := session.GetTitle()
+			Expect(api.IsUnexpectedAlertOpen(err)).To(BeTrue())
+			Expect(session.AcceptAlert()).To(Succeed())
+			_, err = session.GetAlertText()
+			Expect(api.IsNoSuchAlert(err)).To(BeTrue())
+		})
+	})
+
+	Describe("cookie commands", func() {
+		It("should store cookies", func() {
+			Expect(session.SetCookie(&api.Cookie{Name: "some-name", Value: "some-value"})).To(Succeed())
+			Expect(session.SetCookie(&api.Cookie{Name: "other-name", Value: "other-value"})).To(Succeed())
+			Expect(session.DeleteCookie("other-name")).To(Succeed())
+			cookies, err := session.GetCookies()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cookies).To(HaveLen(1))
+			Expect(cookies[0].Value).To(Equal("some-value"))
+		})
+	})
+
+	Describe("#HandleScript", func() {
+		It("should respond to scripts with elements in place of element references", func() {
+			browser.HandleScript(func(script string, arguments []interface{}) (interface{}, error) {
+				Expect(script).To(Equal("return arguments[0].value;"))
+				return arguments[0].(*Element).Attribute("value"), nil
+			})
+			element, err := session.GetElement(api.Selector{Using: "css selector", Value: "input"})
+			Expect(err).NotTo(HaveOccurred())
+			var value string
+			Expect(session.Execute("return arguments[0].value;", []interface{}{element}, &value)).To(Succeed())
+			Expect(value).To(Equal("some"))
+		})
+
+		It("should return a javascript error when the handler fails", func() {
+			browser.HandleScript(func(string, []interface{}) (interface{}, error) {
+				return nil, errors.New("some error")
+			})
+			err := session.Execute("throw 'some error';", nil, nil)
+			Expect(api.ErrorCode(err)).To(Equal(api.ErrorJavaScript))
+		})
+
+		It("should return a javascript error when no handler is provided", func() {
+			err := session.Execute("return 1;", nil, nil)
+			Expect(api.ErrorCode(err)).To(Equal(api.ErrorJavaScript))
+		})
+	})
+
+	Describe("#Handle", func() {
+		It("should override the response to matching commands", func() {
+			browser.Handle("GET", "element/*/text", func(body []byte) (interface{}, error) {
+				return "some handled text", nil
+			})
+			element, err := session.GetElement(api.Selector{Using: "css selector", Value: "p"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(element.GetText()).To(Equal("some handled text"))
+		})
+
+		It("should allow handlers to respond with errors", func() {
+			browser.Handle("GET", "title", func([]byte) (interface{}, error) {
+				return nil, &api.Error{Code: api.ErrorTimeout, Message: "timed out"}
+			})
+			_, err := session.GetTitle()
+			Expect(api.IsTimeout(err)).To(BeTrue())
+		})
+	})
+
+	Describe("#Commands, #Expect, and #Verify", func() {
+		It("should record commands and verify expectations", func() {
+			browser.Expect("POST", "url")
+			browser.Expect("POST", "element/*/click")
+			Expect(session.SetURL("http://example.com/other")).To(Succeed())
+			Expect(browser.Commands()).To(Equal([]Command{
+				{Method: "POST", Endpoint: "url", Body: []byte(`{"url":"http://example.com/other"}`)},
+			}))
+			Expect(browser.Verify()).To(MatchError("expected commands were not received:\n    POST element/*/click"))
+		})
+	})
+
+	Describe("unsupported commands", func() {
+		It("should return an unknown command error", func() {
+			err := session.Send("GET", "some/endpoint", nil, nil)
+			Expect(api.ErrorCode(err)).To(Equal(api.ErrorUnknownCommand))
+		})
+	})
+})
@@ -0,0 +1,504 @@
+package agoutitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/sclevine/agouti/api"
+)
+
+const windowHandle = "window-1"
+
+// A 1x1 PNG image, which is returned for all screenshots.
+const screenshotPNG = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNkYPhfDwAChwGA60e6kgAAAABJRU5ErkJggg=="
+
+func driverError(statusCode int, code, format string, args ...interface{}) error {
+	return &api.Error{StatusCode: statusCode, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func unknownCommand(method, endpoint string) error {
+	return driverError(404, api.ErrorUnknownCommand, "agoutitest does not support %s %s", method, endpoint)
+}
+
+func decodeBody(body []byte, request interface{}) error {
+	if err := json.Unmarshal(body, request); err != nil {
+		return driverError(400, "invalid argument", "invalid request body: %s", err)
+	}
+	return nil
+}
+
+func (b *Browser) execute(method, endpoint string, body []byte) (interface{}, error) {
+	switch endpoint {
+	case "execute", "execute/sync", "execute_async", "execute/async":
+		return b.executeScript(body)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.deleted {
+		return nil, driverError(404, api.ErrorInvalidSessionID, "session deleted")
+	}
+
+	if strings.HasPrefix(endpoint, "alert") || strings.HasSuffix(endpoint, "_alert") {
+		return b.alertCommand(method, endpoint, body)
+	}
+	if b.alert != nil {
+		return nil, driverError(500, api.ErrorUnexpectedAlertOpen, "unexpected alert open: {Alert text : %s}", *b.alert)
+	}
+
+	segments := strings.SplitN(endpoint, "/", 3)
+	if len(segments) > 1 && segments[0] == "element" && segments[1] != "active" {
+		element, err := b.element(segments[1])
+		if err != nil {
+			return nil, err
+		}
+		var command string
+		if len(segments) > 2 {
+			command = segments[2]
+		}
+		return b.elementCommand(element, method, command, body)
+	}
+
+	switch method + " " + endpoint {
+	case "DELETE ":
+		b.deleted = true
+		return nil, nil
+	case "GET timeouts":
+		return map[string]int{"implicit": 0, "pageLoad": 300000, "script": 30000}, nil
+	case "POST timeouts", "POST timeouts/implicit_wait", "POST timeouts/async_script":
+		return nil, nil
+	case "GET url":
+		return b.history[b.historyIndex], nil
+	case "POST url":
+		var request struct{ URL string }
+		if err := decodeBody(body, &request); err != nil {
+			return nil, err
+		}
+		b.navigate(request.URL)
+		return nil, nil
+	case "POST back":
+		if b.historyIndex > 0 {
+			b.historyIndex--
+			b.load(b.history[b.historyIndex])
+		}
+		return nil, nil
+	case "POST forward":
+		if b.historyIndex < len(b.history)-1 {
+			b.historyIndex++
+			b.load(b.history[b.historyIndex])
+		}
+		return nil, nil
+	case "POST refresh":
+		b.load(b.history[b.historyIndex])
+		return nil, nil
+	case "GET title":
+		return b.document.Title, nil
+	case "GET source":
+		return b.document.source(), nil
+	case "GET screenshot":
+		return screenshotPNG, nil
+	case "POST element", "POST elements":
+		return b.findElements(nil, endpoint == "element", body)
+	case "GET element/active", "POST element/active":
+		active := b.active
+		if active == nil || !b.document.contains(active) {
+			active = b.document.Root
+		}
+		if active == nil {
+			return nil, driverError(404, api.ErrorNoSuchElement, "no active element")
+		}
+		return b.reference(active), nil
+	case "GET window", "GET window_handle":
+		return windowHandle, nil
+	case "GET window/handles", "GET window_handles":
+		return []string{windowHandle}, nil
+	case "POST window":
+		var request struct{ Handle, Name string }
+		if err := decodeBody(body, &request); err != nil {
+			return nil, err
+		}
+		if request.Handle != windowHandle && request.Name != windowHandle {
+			return nil, driverError(404, api.ErrorNoSuchWindow, "no such window")
+		}
+		return nil, nil
+	case "GET cookie":
+		return b.cookies, nil
+	case "POST cookie":
+		var request struct{ Cookie map[string]interface{} }
+		if err := decodeBody(body, &request); err != nil {
+			return nil, err
+		}
+		b.deleteCookie(request.Cookie["name"])
+		b.cookies = append(b.cookies, request.Cookie)
+		return nil, nil
+	case "DELETE cookie":
+		b.cookies = nil
+		return nil, nil
+	}
+
+	if method == "DELETE" && strings.HasPrefix(endpoint, "cookie/") {
+		b.deleteCookie(strings.TrimPrefix(endpoint, "cookie/"))
+		return nil, nil
+	}
+	return nil, unknownCommand(method, endpoint)
+}
+
+func (b *Browser) alertCommand(method, endpoint string, body []byte) (interface{}, error) {
+	if b.alert == nil {
+		return nil, driverError(404, api.ErrorNoSuchAlert, "no such alert")
+	}
+
+	switch method + " " + endpoint {
+	case "GET alert/text", "GET alert_text":
+		return *b.alert, nil
+	case "POST alert/text", "POST alert_text":
+		return nil, nil
+	case "POST alert/accept", "POST accept_alert", "POST alert/dismiss", "POST dismiss_alert":
+		b.alert = nil
+		return nil, nil
+	}
+	return nil, unknownCommand(method, endpoint)
+}
+
+func (b *Browser) elementCommand(element *Element, method, command string, body []byte) (interface{}, error) {
+	switch {
+	case method == "POST" && (command == "element" || command == "elements"):
+		return b.findElements(element, command == "element", body)
+	case method == "GET" && command == "text":
+		if !b.document.displayed(element) {
+			return "", nil
+		}
+		return element.visibleText(), nil
+	case method == "GET" && command == "name":
+		return element.Tag, nil
+	case method == "GET" && strings.HasPrefix(command, "attribute/"):
+		return attribute(element, strings.TrimPrefix(command, "attribute/")), nil
+	case method == "GET" && strings.HasPrefix(command, "property/"):
+		return property(element, strings.TrimPrefix(command, "property/")), nil
+	case method == "GET" && strings.HasPrefix(command, "css/"):
+		return element.CSS[strings.TrimPrefix(command, "css/")], nil
+	case method == "GET" && command == "selected":
+		return element.Selected, nil
+	case method == "GET" && command == "displayed":
+		return b.document.displayed(element), nil
+	case method == "GET" && command == "enabled":
+		return !element.Disabled, nil
+	case method == "GET" && strings.HasPrefix(command, "equals/"):
+		other, err := b.element(strings.TrimPrefix(command, "equals/"))
+		return other == element, err
+	case method == "GET" && command == "screenshot":
+		return screenshotPNG, nil
+	case method == "POST" && command == "click":
+		return nil, b.click(element)
+	case method == "POST" && command == "clear":
+		if element.Disabled {
+			return nil, driverError(400, api.ErrorInvalidElementState, "element is disabled")
+		}
+		element.SetAttribute("value", "")
+		return nil, nil
+	case method == "POST" && command == "value":
+		return nil, b.sendKeys(element, body)
+	case method == "POST" && command == "submit":
+		b.submit(element)
+		return nil, nil
+	}
+	return nil, unknownCommand(method, "element/"+b.elementIDs[element]+"/"+command)
+}
+
+// Boolean attributes are only present when they are true.
+func attribute(element *Element, name string) interface{} {
+	switch name {
+	case "checked", "selected":
+		if element.Selected {
+			return "true"
+		}
+		return nil
+	case "disabled":
+		if element.Disabled {
+			return "true"
+		}
+		return nil
+	}
+
+	if value, ok := element.Attributes[name]; ok {
+		return value
+	}
+	return nil
+}
+
+func property(element *Element, name string) interface{} {
+	switch name {
+	case "value":
+		return element.Attributes["value"]
+	case "checked", "selected":
+		return element.Selected
+	case "disabled":
+		return element.Disabled
+	case "tagName":
+		return strings.ToUpper(element.Tag)
+	case "textContent", "innerText":
+		return element.visibleText()
+	}
+	return attribute(element, name)
+}
+
+func (b *Browser) element(id string) (*Element, error) {
+	element, ok := b.elements[id]
+	if !ok {
+		return nil, driverError(404, api.ErrorNoSuchElement, "no element with ID %s", id)
+	}
+	if !b.document.contains(element) {
+		return nil, driverError(404, api.ErrorStaleElement, "element %s is no longer attached to the DOM", id)
+	}
+	return element, nil
+}
+
+func (b *Browser) reference(element *Element) map[string]string {
+	id, ok := b.elementIDs[element]
+	if !ok {
+		id = fmt.Sprintf("element-%d", len(b.elementIDs)+1)
+		b.elementIDs[element] = id
+		b.elements[id] = element
+	}
+	return map[string]string{api.W3CElementKey: id}
+}
+
+func (b *Browser) findElements(scope *Element, single bool, body []byte) (interface{}, error) {
+	var selector api.Selector
+	if err := decodeBody(body, &selector); err != nil {
+		return nil, err
+	}
+
+	elements, err := b.document.find(scope, selector.Using, selector.Value)
+	if err != nil {
+		return nil, driverError(400, api.ErrorInvalidSelector, "invalid selector: %s", err)
+	}
+
+	if single {
+		if len(elements) == 0 {
+			return nil, driverError(404, api.ErrorNoSuchElement, "no such element: %s %s", selector.Using, selector.Value)
+		}
+		return b.reference(elements[0]), nil
+	}
+
+	references := []map[string]string{}
+	for _, element := range elements {
+		references = append(references, b.reference(element))
+	}
+	return references, nil
+}
+
+func (b *Browser) click(element *Element) error {
+	if !b.document.displayed(element) {
+		return driverError(400, api.ErrorElementNotInteractable, "element not interactable")
+	}
+	b.active = element
+	if element.Disabled {
+		return nil
+	}
+
+	elementType := strings.ToLower(element.Attributes["type"])
+	switch {
+	case element.Tag == "input" && elementType == "checkbox":
+		element.Selected = !element.Selected
+	case element.Tag == "input" && elementType == "radio":
+		name := element.Attributes["name"]
+		radios, _ := b.document.find(nil, "css selector", "input")
+		for _, radio := range radios {
+			if radio.Attributes["type"] == "radio" && radio.Attributes["name"] == name {
+				radio.Selected = false
+			}
+		}
+		element.Selected = true
+	case element.Tag == "option":
+		ancestors, _ := b.document.ancestors(element)
+		for index := len(ancestors) - 1; index >= 0; index-- {
+			if ancestors[index].Tag != "select" {
+				continue
+			}
+			if _, multiple := ancestors[index].Attributes["multiple"]; multiple {
+				element.Selected = !element.Selected
+				return nil
+			}
+			options, _ := b.document.find(ancestors[index], "tag name", "option")
+			for _, option := range options {
+				option.Selected = false
+			}
+			break
+		}
+		element.Selected = true
+	case element.Tag == "input" && elementType == "submit",
+		element.Tag == "button" && (elementType == "" || elementType == "submit"):
+		b.submit(element)
+	case element.Tag == "a" && element.Attributes["href"] != "":
+		b.navigate(b.resolve(element.Attributes["href"]))
+	}
+	return nil
+}
+
+// Key codes, such as keys.Enter, are in the Unicode private use area and
+// are not entered as text.
+func (b *Browser) sendKeys(element *Element, body []byte) error {
+	var request struct {
+		Text  string
+		Value []string
+	}
+	if err := decodeBody(body, &request); err != nil {
+		return err
+	}
+	if element.Disabled {
+		return driverError(400, api.ErrorInvalidElementState, "element is disabled")
+	}
+	b.active = element
+
+	text := request.Text
+	if text == "" {
+		text = strings.Join(request.Value, "")
+	}
+	text = strings.Map(func(character rune) rune {
+		if character >= '\uE000' && character <= '\uF8FF' {
+			return -1
+		}
+		return character
+	}, text)
+	element.SetAttribute("value", element.Attributes["value"]+text)
+	return nil
+}
+
+// Submitting a form navigates to its action, or reloads the page if it has
+// no action.
+func (b *Browser) submit(element *Element) {
+	form := element
+	if form.Tag != "form" {
+		ancestors, _ := b.document.ancestors(element)
+		form = nil
+		for index := len(ancestors) - 1; index >= 0; index-- {
+			if ancestors[index].Tag == "form" {
+				form = ancestors[index]
+				break
+			}
+		}
+	}
+	if form == nil {
+		return
+	}
+
+	action := b.history[b.historyIndex]
+	if form.Attributes["action"] != "" {
+		action = b.resolve(form.Attributes["action"])
+	}
+	b.navigate(action)
+}
+
+func (b *Browser) resolve(reference string) string {
+	base, err := url.Parse(b.history[b.historyIndex])
+	if err != nil {
+		return reference
+	}
+	resolved, err := base.Parse(reference)
+	if err != nil {
+		return reference
+	}
+	return resolved.String()
+}
+
+func (b *Browser) navigate(url string) {
+	b.history = append(b.history[:b.historyIndex+1], url)
+	b.historyIndex = len(b.history) - 1
+	b.load(url)
+}
+
+func (b *Browser) load(url string) {
+	b.document = &Document{}
+	if route, ok := b.routes[url]; ok {
+		b.document = route()
+	}
+	b.active = nil
+}
+
+func (b *Browser) deleteCookie(name interface{}) {
+	var cookies []map[string]interface{}
+	for _, cookie := range b.cookies {
+		if cookie["name"] != name {
+			cookies = append(cookies, cookie)
+		}
+	}
+	b.cookies = cookies
+}
+
+func (b *Browser) executeScript(body []byte) (interface{}, error) {
+	var request struct {
+		Script string
+		Args   []interface{}
+	}
+	if err := decodeBody(body, &request); err != nil {
+		return nil, err
+	}
+
+	b.mutex.Lock()
+	handler := b.script
+	for index, argument := range request.Args {
+		request.Args[index] = b.fromReference(argument)
+	}
+	b.mutex.Unlock()
+
+	if handler == nil {
+		return nil, driverError(500, api.ErrorJavaScript, "javascript error: agoutitest cannot run scripts without a ScriptHandler")
+	}
+
+	result, err := handler(request.Script, request.Args)
+	if err != nil {
+		if _, ok := err.(*api.Error); ok {
+			return nil, err
+		}
+		return nil, driverError(500, api.ErrorJavaScript, "javascript error: %s", err)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.toReference(result), nil
+}
+
+func (b *Browser) fromReference(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if id, ok := value[api.W3CElementKey].(string); ok {
+			if element, ok := b.elements[id]; ok {
+				return element
+			}
+		}
+		for key, item := range value {
+			value[key] = b.fromReference(item)
+		}
+	case []interface{}:
+		for index, item := range value {
+			value[index] = b.fromReference(item)
+		}
+	}
+	return value
+}
+
+func (b *Browser) toReference(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *Element:
+		return b.reference(value)
+	case []*Element:
+		references := []interface{}{}
+		for _, element := range value {
+			references = append(references, b.reference(element))
+		}
+		return references
+	case []interface{}:
+		for index, item := range value {
+			value[index] = b.toReference(item)
+		}
+	case map[string]interface{}:
+		for key, item := range value {
+			value[key] = b.toReference(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,179 @@
+package agoutitest
+
+import (
+	"bytes"
+	"html"
+	"sort"
+	"strings"
+)
+
+// A Document is the content of a page loaded by the fake browser.
+type Document struct {
+	// Title is the title of the page.
+	Title string
+
+	// Root is the root element of the page, usually an <html> or <body>
+	// element. Any elements may be added to or removed from the tree while
+	// the document is loaded, and elements removed from the tree become
+	// stale.
+	Root *Element
+}
+
+// An Element is an element of a Document. The state of the element, such
+// as its value attribute or whether it is selected, is updated as commands
+// are sent to the fake browser.
+type Element struct {
+	// Tag is the lowercase tag name of the element, ex. "input".
+	Tag string
+
+	// Attributes are the attributes of the element, ex. "id", "class",
+	// "name", "type", "href", and "value".
+	Attributes map[string]string
+
+	// Text is the text of the element that precedes its children. The text
+	// of an element is its own text followed by the text of its visible
+	// children, separated by spaces.
+	Text string
+
+	// CSS contains the computed values of any CSS properties of the element.
+	CSS map[string]string
+
+	// Hidden elements and their children are not displayed.
+	Hidden bool
+
+	// Disabled elements are not enabled and ignore any keys sent to them.
+	Disabled bool
+
+	// Selected is true for selected <option> elements and checked
+	// checkboxes and radio buttons.
+	Selected bool
+
+	// Children are the child elements of the element, in document order.
+	Children []*Element
+}
+
+// Attribute returns the value of the provided attribute, or an empty string
+// if the element does not have the attribute.
+func (e *Element) Attribute(name string) string {
+	return e.Attributes[name]
+}
+
+// SetAttribute sets the provided attribute of the element.
+func (e *Element) SetAttribute(name, value string) {
+	if e.Attributes == nil {
+		e.Attributes = map[string]string{}
+	}
+	e.Attributes[name] = value
+}
+
+// HasClass returns true if the class attribute of the element contains the
+// provided class.
+func (e *Element) HasClass(class string) bool {
+	for _, elementClass := range strings.Fields(e.Attributes["class"]) {
+		if elementClass == class {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Element) visibleText() string {
+	if e.Hidden {
+		return ""
+	}
+
+	var parts []string
+	if text := strings.TrimSpace(e.Text); text != "" {
+		parts = append(parts, text)
+	}
+	for _, child := range e.Children {
+		if text := child.visibleText(); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// The text content of an element includes the text of hidden children.
+func (e *Element) textContent() string {
+	text := e.Text
+	for _, child := range e.Children {
+		text += " " + child.textContent()
+	}
+	return text
+}
+
+func (e *Element) source() string {
+	var source bytes.Buffer
+	source.WriteString("<" + e.Tag)
+
+	var names []string
+	for name := range e.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		source.WriteString(" " + name + `="` + html.EscapeString(e.Attributes[name]) + `"`)
+	}
+
+	source.WriteString(">" + html.EscapeString(e.Text))
+	for _, child := range e.Children {
+		source.WriteString(child.source())
+	}
+	source.WriteString("</" + e.Tag + ">")
+	return source.String()
+}
+
+// The ancestors of an element are found by searching the document, as
+// elements do not reference their parents.
+func (d *Document) ancestors(element *Element) ([]*Element, bool) {
+	if d.Root == nil {
+		return nil, false
+	}
+	return findPath(d.Root, element, nil)
+}
+
+func findPath(current, element *Element, ancestors []*Element) ([]*Element, bool) {
+	if current == element {
+		return ancestors, true
+	}
+	ancestors = append(ancestors, current)
+	for _, child := range current.Children {
+		if path, ok := findPath(child, element, ancestors); ok {
+			return path, true
+		}
+	}
+	return nil, false
+}
+
+func (d *Document) contains(element *Element) bool {
+	_, ok := d.ancestors(element)
+	return ok
+}
+
+func (d *Document) displayed(element *Element) bool {
+	ancestors, _ := d.ancestors(element)
+	for _, ancestor := range append(ancestors, element) {
+		if ancestor.Hidden {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *Document) source() string {
+	if d.Root == nil {
+		return ""
+	}
+	return d.Root.source()
+}
+
+// walk calls the provided function for each element under the provided
+// element, in document order, with the ancestors of each element.
+func walk(element *Element, ancestors []*Element, visit func(*Element, []*Element)) {
+	visit(element, ancestors)
+	ancestors = append(ancestors[:len(ancestors):len(ancestors)], element)
+	for _, child := range element.Children {
+		walk(child, ancestors, visit)
+	}
+}
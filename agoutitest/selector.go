@@ -0,0 +1,474 @@
+package agoutitest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// A complexSelector is a sequence of compound selectors separated by
+// descendant (' ') or child ('>') combinators.
+type complexSelector struct {
+	compounds   []compoundSelector
+	combinators []byte
+}
+
+type compoundSelector struct {
+	tag        string
+	id         string
+	classes    []string
+	attributes []attributeSelector
+	scope      bool
+	root       bool
+}
+
+type attributeSelector struct {
+	name     string
+	operator string
+	value    string
+}
+
+type selectorContext struct {
+	scope *Element
+	root  *Element
+}
+
+func (s complexSelector) matches(element *Element, ancestors []*Element, context selectorContext) bool {
+	return s.matchFrom(len(s.compounds)-1, element, ancestors, context)
+}
+
+func (s complexSelector) matchFrom(index int, element *Element, ancestors []*Element, context selectorContext) bool {
+	if !s.compounds[index].matches(element, context) {
+		return false
+	}
+	if index == 0 {
+		return true
+	}
+
+	if s.combinators[index-1] == '>' {
+		parent := len(ancestors) - 1
+		return parent >= 0 && s.matchFrom(index-1, ancestors[parent], ancestors[:parent], context)
+	}
+	for ancestor := len(ancestors) - 1; ancestor >= 0; ancestor-- {
+		if s.matchFrom(index-1, ancestors[ancestor], ancestors[:ancestor], context) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c compoundSelector) matches(element *Element, context selectorContext) bool {
+	if c.tag != "" && c.tag != element.Tag {
+		return false
+	}
+	if c.id != "" && c.id != element.Attributes["id"] {
+		return false
+	}
+	for _, class := range c.classes {
+		if !element.HasClass(class) {
+			return false
+		}
+	}
+	for _, attribute := range c.attributes {
+		if !attribute.matches(element) {
+			return false
+		}
+	}
+	if c.scope && element != context.scope {
+		return false
+	}
+	if c.root && element != context.root {
+		return false
+	}
+	return true
+}
+
+func (a attributeSelector) matches(element *Element) bool {
+	value, ok := element.Attributes[a.name]
+	if !ok {
+		return false
+	}
+
+	switch a.operator {
+	case "=":
+		return value == a.value
+	case "~=":
+		for _, word := range strings.Fields(value) {
+			if word == a.value {
+				return true
+			}
+		}
+		return false
+	case "|=":
+		return value == a.value || strings.HasPrefix(value, a.value+"-")
+	case "^=":
+		return a.value != "" && strings.HasPrefix(value, a.value)
+	case "$=":
+		return a.value != "" && strings.HasSuffix(value, a.value)
+	case "*=":
+		return a.value != "" && strings.Contains(value, a.value)
+	}
+	return true
+}
+
+type selectorParser struct {
+	input    string
+	position int
+}
+
+func parseSelector(input string) ([]complexSelector, error) {
+	parser := &selectorParser{input: input}
+
+	var selectors []complexSelector
+	for {
+		selector, err := parser.parseComplex()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+
+		if parser.done() {
+			return selectors, nil
+		}
+		parser.position++
+	}
+}
+
+func (p *selectorParser) parseComplex() (complexSelector, error) {
+	var selector complexSelector
+	p.skipSpace()
+	for {
+		compound, err := p.parseCompound()
+		if err != nil {
+			return selector, err
+		}
+		selector.compounds = append(selector.compounds, compound)
+
+		spaced := p.skipSpace()
+		if p.done() || p.peek() == ',' {
+			return selector, nil
+		}
+
+		combinator := byte(' ')
+		if p.peek() == '>' {
+			combinator = '>'
+			p.position++
+			p.skipSpace()
+		} else if !spaced {
+			return selector, p.unexpected()
+		}
+		selector.combinators = append(selector.combinators, combinator)
+	}
+}
+
+func (p *selectorParser) parseCompound() (compoundSelector, error) {
+	var compound compoundSelector
+	start := p.position
+
+	if p.peek() == '*' {
+		p.position++
+	} else {
+		compound.tag = strings.ToLower(p.parseIdentifier())
+	}
+
+	for !p.done() {
+		switch p.peek() {
+		case '#':
+			p.position++
+			if compound.id = p.parseIdentifier(); compound.id == "" {
+				return compound, p.unexpected()
+			}
+		case '.':
+			p.position++
+			class := p.parseIdentifier()
+			if class == "" {
+				return compound, p.unexpected()
+			}
+			compound.classes = append(compound.classes, class)
+		case '[':
+			p.position++
+			attribute, err := p.parseAttribute()
+			if err != nil {
+				return compound, err
+			}
+			compound.attributes = append(compound.attributes, attribute)
+		case ':':
+			p.position++
+			switch pseudoClass := p.parseIdentifier(); pseudoClass {
+			case "scope":
+				compound.scope = true
+			case "root":
+				compound.root = true
+			default:
+				return compound, fmt.Errorf("unsupported pseudo-class :%s in selector: %s", pseudoClass, p.input)
+			}
+		default:
+			if p.position == start {
+				return compound, p.unexpected()
+			}
+			return compound, nil
+		}
+	}
+
+	if p.position == start {
+		return compound, p.unexpected()
+	}
+	return compound, nil
+}
+
+func (p *selectorParser) parseAttribute() (attributeSelector, error) {
+	var attribute attributeSelector
+	p.skipSpace()
+	if attribute.name = p.parseIdentifier(); attribute.name == "" {
+		return attribute, p.unexpected()
+	}
+	p.skipSpace()
+
+	if p.peek() == ']' {
+		p.position++
+		return attribute, nil
+	}
+
+	for _, operator := range []string{"=", "~=", "|=", "^=", "$=", "*="} {
+		if strings.HasPrefix(p.input[p.position:], operator) {
+			attribute.operator = operator
+			p.position += len(operator)
+			break
+		}
+	}
+	if attribute.operator == "" {
+		return attribute, p.unexpected()
+	}
+	p.skipSpace()
+
+	if quote := p.peek(); quote == '"' || quote == '\'' {
+		value, err := p.parseString(quote)
+		if err != nil {
+			return attribute, err
+		}
+		attribute.value = value
+	} else if attribute.value = p.parseIdentifier(); attribute.value == "" {
+		return attribute, p.unexpected()
+	}
+	p.skipSpace()
+
+	if p.peek() != ']' {
+		return attribute, p.unexpected()
+	}
+	p.position++
+	return attribute, nil
+}
+
+func (p *selectorParser) parseString(quote byte) (string, error) {
+	p.position++
+	var value []byte
+	for !p.done() {
+		character := p.input[p.position]
+		p.position++
+		switch {
+		case character == quote:
+			return string(value), nil
+		case character == '\\' && !p.done():
+			value = append(value, p.input[p.position])
+			p.position++
+		default:
+			value = append(value, character)
+		}
+	}
+	return "", fmt.Errorf("unterminated string in selector: %s", p.input)
+}
+
+func (p *selectorParser) parseIdentifier() string {
+	start := p.position
+	for !p.done() {
+		character := p.input[p.position]
+		if !(character == '-' || character == '_' || character >= 0x80 ||
+			character >= 'a' && character <= 'z' ||
+			character >= 'A' && character <= 'Z' ||
+			character >= '0' && character <= '9') {
+			break
+		}
+		p.position++
+	}
+	return p.input[start:p.position]
+}
+
+func (p *selectorParser) skipSpace() bool {
+	start := p.position
+	for !p.done() && strings.IndexByte(" \t\n\r\f", p.input[p.position]) >= 0 {
+		p.position++
+	}
+	return p.position > start
+}
+
+func (p *selectorParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.input[p.position]
+}
+
+func (p *selectorParser) done() bool {
+	return p.position >= len(p.input)
+}
+
+func (p *selectorParser) unexpected() error {
+	if p.done() {
+		return fmt.Errorf("unexpected end of selector: %s", p.input)
+	}
+	return fmt.Errorf("unexpected %q at position %d of selector: %s", p.input[p.position], p.position, p.input)
+}
+
+// find returns the elements of the document that match the provided
+// WebDriver locator strategy and value, in document order. If a scope is
+// provided, only descendants of the scope are matched.
+func (d *Document) find(scope *Element, using, value string) ([]*Element, error) {
+	var match func(element *Element, ancestors []*Element) bool
+	switch using {
+	case "css selector":
+		selectors, err := parseSelector(value)
+		if err != nil {
+			return nil, err
+		}
+		context := selectorContext{scope: scope, root: d.Root}
+		if scope == nil {
+			context.scope = d.Root
+		}
+		match = func(element *Element, ancestors []*Element) bool {
+			for _, selector := range selectors {
+				if selector.matches(element, ancestors, context) {
+					return true
+				}
+			}
+			return false
+		}
+	case "link text", "partial link text":
+		match = func(element *Element, _ []*Element) bool {
+			if element.Tag != "a" {
+				return false
+			}
+			text := element.visibleText()
+			if using == "link text" {
+				return text == strings.TrimSpace(value)
+			}
+			return strings.Contains(text, value)
+		}
+	case "tag name":
+		match = func(element *Element, _ []*Element) bool {
+			return element.Tag == strings.ToLower(value)
+		}
+	case "id", "name":
+		match = func(element *Element, _ []*Element) bool {
+			attribute, ok := element.Attributes[using]
+			return ok && attribute == value
+		}
+	case "class name":
+		match = func(element *Element, _ []*Element) bool {
+			return element.HasClass(value)
+		}
+	case "xpath":
+		return d.findXPath(scope, value)
+	default:
+		return nil, fmt.Errorf("unsupported locator strategy: %s", using)
+	}
+
+	if d.Root == nil {
+		return nil, nil
+	}
+
+	var (
+		elements []*Element
+		start    = d.Root
+		path     []*Element
+	)
+	if scope != nil {
+		start = scope
+		path, _ = d.ancestors(scope)
+	}
+	walk(start, path, func(element *Element, ancestors []*Element) {
+		if element != scope && match(element, ancestors) {
+			elements = append(elements, element)
+		}
+	})
+	return elements, nil
+}
+
+var (
+	optionXPath = regexp.MustCompile(`^\./option\[normalize-space\(\)="(.*)"\]$`)
+	labelXPath  = regexp.MustCompile(`^//input\[@id=\(//label\[normalize-space\(\)="(.*)"\]/@for\)\] \| //label\[normalize-space\(\)="(.*)"\]/input$`)
+	buttonXPath = regexp.MustCompile(`^//input\[@type="submit" or @type="button"\]\[normalize-space\(@value\)="(.*)"\] \| //button\[normalize-space\(\)="(.*)"\]$`)
+)
+
+// XPath is not supported in general, but the XPath expressions generated by
+// agouti for Select, FindByLabel, FindByButton, Parent, NextSibling, and
+// PrevSibling are recognized.
+func (d *Document) findXPath(scope *Element, value string) ([]*Element, error) {
+	if d.Root == nil {
+		return nil, nil
+	}
+
+	var elements []*Element
+	switch {
+	case scope != nil && value == "..":
+		if ancestors, _ := d.ancestors(scope); len(ancestors) > 0 {
+			elements = append(elements, ancestors[len(ancestors)-1])
+		}
+	case scope != nil && (value == "following-sibling::*[1]" || value == "preceding-sibling::*[1]"):
+		if ancestors, _ := d.ancestors(scope); len(ancestors) > 0 {
+			siblings := ancestors[len(ancestors)-1].Children
+			for index, sibling := range siblings {
+				if sibling != scope {
+					continue
+				}
+				if value == "following-sibling::*[1]" && index+1 < len(siblings) {
+					elements = append(elements, siblings[index+1])
+				} else if value == "preceding-sibling::*[1]" && index > 0 {
+					elements = append(elements, siblings[index-1])
+				}
+			}
+		}
+	case scope != nil && optionXPath.MatchString(value):
+		text := optionXPath.FindStringSubmatch(value)[1]
+		for _, child := range scope.Children {
+			if child.Tag == "option" && normalizeSpace(child.textContent()) == text {
+				elements = append(elements, child)
+			}
+		}
+	case labelXPath.MatchString(value):
+		text := labelXPath.FindStringSubmatch(value)[1]
+		labelFor := map[string]bool{}
+		walk(d.Root, nil, func(element *Element, _ []*Element) {
+			if element.Tag == "label" && normalizeSpace(element.textContent()) == text {
+				labelFor[element.Attributes["for"]] = true
+			}
+		})
+		walk(d.Root, nil, func(element *Element, ancestors []*Element) {
+			if element.Tag != "input" {
+				return
+			}
+			id, hasID := element.Attributes["id"]
+			parent := len(ancestors) - 1
+			if hasID && labelFor[id] || parent >= 0 && ancestors[parent].Tag == "label" &&
+				normalizeSpace(ancestors[parent].textContent()) == text {
+				elements = append(elements, element)
+			}
+		})
+	case buttonXPath.MatchString(value):
+		text := buttonXPath.FindStringSubmatch(value)[1]
+		walk(d.Root, nil, func(element *Element, _ []*Element) {
+			elementType := element.Attributes["type"]
+			if element.Tag == "input" && (elementType == "submit" || elementType == "button") &&
+				normalizeSpace(element.Attributes["value"]) == text ||
+				element.Tag == "button" && normalizeSpace(element.textContent()) == text {
+				elements = append(elements, element)
+			}
+		})
+	default:
+		return nil, fmt.Errorf("unsupported XPath expression: %s", value)
+	}
+	return elements, nil
+}
+
+func normalizeSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
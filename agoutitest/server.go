@@ -0,0 +1,79 @@
+package agoutitest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/sclevine/agouti/api"
+)
+
+const sessionID = "agoutitest"
+
+// NewServer starts an HTTP server that serves the provided fake browser
+// using the W3C WebDriver protocol. The URL of the server may be provided to
+// agouti.NewPage, or to any other WebDriver client. Every new session
+// request returns a session for the same fake browser. The server should be
+// closed when it is no longer needed.
+func NewServer(browser *Browser) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := ioutil.ReadAll(request.Body)
+		if err != nil {
+			writeError(writer, driverError(400, "invalid argument", "failed to read request: %s", err))
+			return
+		}
+		if len(body) == 0 {
+			body = nil
+		}
+
+		requestPath := strings.TrimSuffix(request.URL.Path, "/")
+		switch {
+		case request.Method == "POST" && requestPath == "/session":
+			writeValue(writer, map[string]interface{}{
+				"sessionId":    sessionID,
+				"capabilities": map[string]interface{}{"browserName": "agoutitest"},
+			})
+		case request.Method == "GET" && requestPath == "/status":
+			writeValue(writer, map[string]interface{}{"ready": true, "message": "agoutitest is ready"})
+		case requestPath == "/session/"+sessionID || strings.HasPrefix(requestPath, "/session/"+sessionID+"/"):
+			endpoint := strings.TrimPrefix(strings.TrimPrefix(requestPath, "/session/"+sessionID), "/")
+			value, err := browser.handle(request.Method, endpoint, body)
+			if err != nil {
+				writeError(writer, err)
+				return
+			}
+			writeValue(writer, value)
+		case strings.HasPrefix(requestPath, "/session/"):
+			writeError(writer, driverError(404, api.ErrorInvalidSessionID, "invalid session id"))
+		default:
+			writeError(writer, unknownCommand(request.Method, requestPath))
+		}
+	}))
+}
+
+func writeValue(writer http.ResponseWriter, value interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(struct {
+		Value interface{} `json:"value"`
+	}{value})
+}
+
+func writeError(writer http.ResponseWriter, err error) {
+	driverErr, ok := err.(*api.Error)
+	if !ok {
+		driverErr = &api.Error{StatusCode: 500, Code: "unknown error", Message: err.Error()}
+	}
+
+	statusCode := driverErr.StatusCode
+	if statusCode == 0 {
+		statusCode = 500
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	json.NewEncoder(writer).Encode(map[string]interface{}{
+		"value": map[string]string{"error": driverErr.Code, "message": driverErr.Message},
+	})
+}
@@ -0,0 +1,62 @@
+package agoutitest_test
+
+import (
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti"
+	. "github.com/sclevine/agouti/agoutitest"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		browser *Browser
+		server  *httptest.Server
+		page    *agouti.Page
+	)
+
+	BeforeEach(func() {
+		browser = NewBrowser()
+		browser.Route("http://example.com/login", func() *Document {
+			return &Document{Title: "Login", Root: &Element{
+				Tag: "form", Attributes: map[string]string{"action": "/welcome"},
+				Children: []*Element{
+					{Tag: "label", Attributes: map[string]string{"for": "user"}, Text: "User"},
+					{Tag: "input", Attributes: map[string]string{"id": "user", "name": "user"}},
+					{Tag: "select", Attributes: map[string]string{"name": "role"}, Children: []*Element{
+						{Tag: "option", Text: "Admin"},
+						{Tag: "option", Text: "Guest"},
+					}},
+					{Tag: "button", Text: "Log In"},
+				},
+			}}
+		})
+		server = NewServer(browser)
+
+		var err error
+		page, err = agouti.NewPage(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(page.Destroy()).To(Succeed())
+		server.Close()
+	})
+
+	It("should serve the fake browser to an agouti page", func() {
+		Expect(page.Navigate("http://example.com/login")).To(Succeed())
+		Expect(page.Title()).To(Equal("Login"))
+		Expect(page.FindByLabel("User").Fill("some-user")).To(Succeed())
+		Expect(page.FindByName("role").Select("Guest")).To(Succeed())
+		Expect(page.FindByName("role").All("option").At(1).Selected()).To(BeTrue())
+		Expect(page.FindByButton("Log In").Click()).To(Succeed())
+		Expect(page.URL()).To(Equal("http://example.com/welcome"))
+	})
+
+	It("should respond with WebDriver errors", func() {
+		Expect(page.Navigate("http://example.com/login")).To(Succeed())
+		_, err := page.Find("#missing").Text()
+		Expect(err).To(MatchError("failed to select element from selection 'CSS: #missing [single]': element not found"))
+	})
+})
@@ -0,0 +1,122 @@
+package agouti
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sclevine/agouti/api"
+)
+
+// An AlertPolicy determines how a page handles an alert, confirm, or prompt
+// popup that prevents a command from completing. See *Page.OnAlert.
+type AlertPolicy int
+
+const (
+	// FailOnAlerts causes commands that are blocked by a popup to fail.
+	// This is the default policy.
+	FailOnAlerts AlertPolicy = iota
+
+	// AcceptAlerts confirms the popup and retries the command.
+	AcceptAlerts
+
+	// DismissAlerts cancels the popup and retries the command.
+	DismissAlerts
+
+	// CaptureAlerts records the text of the popup, which may be retrieved
+	// using *Page.CapturedAlerts, and then confirms the popup and retries
+	// the command.
+	CaptureAlerts
+)
+
+const alertPollInterval = 100 * time.Millisecond
+
+// OnAlert sets the policy used to handle alert, confirm, and prompt popups
+// that are open when a command is sent to the page. When the policy is not
+// FailOnAlerts, the popup is handled according to the policy and the
+// command is retried once.
+//
+// Example:
+//    page.OnAlert(agouti.CaptureAlerts)
+//    page.Find("#delete").Click()
+//    fmt.Println(page.CapturedAlerts())
+func (p *Page) OnAlert(policy AlertPolicy) error {
+	session, ok := p.session.(*api.Session)
+	if !ok {
+		return errors.New("failed to set alert policy: page does not support alert policies")
+	}
+
+	switch policy {
+	case FailOnAlerts:
+		session.OnUnexpectedAlert(nil)
+	case AcceptAlerts, DismissAlerts, CaptureAlerts:
+		session.OnUnexpectedAlert(func(alertSession *api.Session) error {
+			return p.handleAlert(alertSession, policy)
+		})
+	default:
+		return fmt.Errorf("failed to set alert policy: invalid policy %d", policy)
+	}
+	return nil
+}
+
+// The WebDriver may close the popup itself before the policy is applied,
+// depending on its unhandledPromptBehavior, so a missing popup is treated
+// as handled.
+func (p *Page) handleAlert(session *api.Session, policy AlertPolicy) error {
+	var err error
+	switch policy {
+	case AcceptAlerts:
+		err = session.AcceptAlert()
+	case DismissAlerts:
+		err = session.DismissAlert()
+	case CaptureAlerts:
+		var text string
+		if text, err = session.GetAlertText(); err == nil {
+			p.alertsMutex.Lock()
+			p.capturedAlerts = append(p.capturedAlerts, text)
+			p.alertsMutex.Unlock()
+			err = session.AcceptAlert()
+		}
+	}
+
+	if api.IsNoSuchAlert(err) {
+		return nil
+	}
+	return err
+}
+
+// CapturedAlerts returns the text of each popup that was handled while the
+// CaptureAlerts policy was set, in the order that they were opened.
+func (p *Page) CapturedAlerts() []string {
+	p.alertsMutex.Lock()
+	defer p.alertsMutex.Unlock()
+	return append([]string{}, p.capturedAlerts...)
+}
+
+// WaitForAlert waits up to the provided timeout for an alert, confirm, or
+// prompt popup to open, and returns its text. The popup is left open.
+func (p *Page) WaitForAlert(timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		text, err := p.session.GetAlertText()
+		if err == nil {
+			return text, nil
+		}
+		if !api.IsNoSuchAlert(err) {
+			return "", fmt.Errorf("failed to retrieve popup text: %s", err)
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("failed to find popup within %s", timeout)
+		}
+		time.Sleep(alertPollInterval)
+	}
+}
+
+// AnswerPrompt enters the provided text into an open prompt popup and then
+// confirms the popup.
+func (p *Page) AnswerPrompt(text string) error {
+	if err := p.EnterPopupText(text); err != nil {
+		return err
+	}
+	return p.ConfirmPopup()
+}
@@ -0,0 +1,145 @@
+package agouti_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+// An alertBus simulates a browser with a popup that blocks all other commands
+// while it is open.
+type alertBus struct {
+	alert    string
+	open     bool
+	requests []string
+}
+
+func (b *alertBus) Send(method, endpoint string, body, result interface{}) error {
+	b.requests = append(b.requests, method+" "+endpoint)
+
+	switch endpoint {
+	case "alert/text":
+		if !b.open {
+			return &api.Error{Code: api.ErrorNoSuchAlert, Message: "no such alert"}
+		}
+		if method == "GET" {
+			response, _ := json.Marshal(b.alert)
+			json.Unmarshal(response, result)
+		}
+		return nil
+	case "alert/accept", "alert/dismiss":
+		if !b.open {
+			return &api.Error{Code: api.ErrorNoSuchAlert, Message: "no such alert"}
+		}
+		b.open = false
+		return nil
+	}
+
+	if b.open {
+		return &api.Error{Code: api.ErrorUnexpectedAlertOpen, Message: "unexpected alert open"}
+	}
+	return nil
+}
+
+var _ = Describe("Alerts", func() {
+	var (
+		bus  *alertBus
+		page *Page
+	)
+
+	BeforeEach(func() {
+		bus = &alertBus{alert: "some alert", open: true}
+		page = NewTestPage(&api.Session{Bus: bus, W3C: true})
+	})
+
+	Describe("#OnAlert", func() {
+		It("should fail commands that are blocked by a popup by default", func() {
+			Expect(page.Navigate("some-url")).To(MatchError("failed to navigate: request unsuccessful: unexpected alert open"))
+		})
+
+		It("should accept the popup and retry the command when the policy is AcceptAlerts", func() {
+			Expect(page.OnAlert(AcceptAlerts)).To(Succeed())
+			Expect(page.Navigate("some-url")).To(Succeed())
+			Expect(bus.requests).To(Equal([]string{"POST url", "POST alert/accept", "POST url"}))
+		})
+
+		It("should dismiss the popup and retry the command when the policy is DismissAlerts", func() {
+			Expect(page.OnAlert(DismissAlerts)).To(Succeed())
+			Expect(page.Navigate("some-url")).To(Succeed())
+			Expect(bus.requests).To(Equal([]string{"POST url", "POST alert/dismiss", "POST url"}))
+		})
+
+		It("should record the popup text when the policy is CaptureAlerts", func() {
+			Expect(page.OnAlert(CaptureAlerts)).To(Succeed())
+			Expect(page.Navigate("some-url")).To(Succeed())
+			bus.alert = "some other alert"
+			bus.open = true
+			Expect(page.Navigate("some-url")).To(Succeed())
+			Expect(page.CapturedAlerts()).To(Equal([]string{"some alert", "some other alert"}))
+		})
+
+		It("should stop handling popups when the policy is FailOnAlerts", func() {
+			Expect(page.OnAlert(AcceptAlerts)).To(Succeed())
+			Expect(page.OnAlert(FailOnAlerts)).To(Succeed())
+			Expect(page.Navigate("some-url")).To(MatchError(ContainSubstring("unexpected alert open")))
+		})
+
+		Context("when the policy is invalid", func() {
+			It("should return an error", func() {
+				Expect(page.OnAlert(AlertPolicy(99))).To(MatchError("failed to set alert policy: invalid policy 99"))
+			})
+		})
+
+		Context("when the page does not use an *api.Session", func() {
+			It("should return an error", func() {
+				page = NewTestPage(&mocks.Session{})
+				Expect(page.OnAlert(AcceptAlerts)).To(MatchError("failed to set alert policy: page does not support alert policies"))
+			})
+		})
+	})
+
+	Describe("#WaitForAlert", func() {
+		It("should return the text of the open popup", func() {
+			Expect(page.WaitForAlert(time.Second)).To(Equal("some alert"))
+			Expect(bus.open).To(BeTrue())
+		})
+
+		Context("when no popup opens before the timeout", func() {
+			It("should return an error", func() {
+				bus.open = false
+				_, err := page.WaitForAlert(10 * time.Millisecond)
+				Expect(err).To(MatchError("failed to find popup within 10ms"))
+			})
+		})
+
+		Context("when the popup text cannot be retrieved", func() {
+			It("should return an error", func() {
+				session := &mocks.Session{}
+				session.GetAlertTextCall.Err = errors.New("some error")
+				_, err := NewTestPage(session).WaitForAlert(time.Second)
+				Expect(err).To(MatchError("failed to retrieve popup text: some error"))
+			})
+		})
+	})
+
+	Describe("#AnswerPrompt", func() {
+		It("should enter the text into the popup and confirm it", func() {
+			Expect(page.AnswerPrompt("some text")).To(Succeed())
+			Expect(bus.requests).To(Equal([]string{"POST alert/text", "POST alert/accept"}))
+			Expect(bus.open).To(BeFalse())
+		})
+
+		Context("when the text cannot be entered", func() {
+			It("should return an error", func() {
+				bus.open = false
+				Expect(page.AnswerPrompt("some text")).To(MatchError("failed to enter popup text: request unsuccessful: no such alert"))
+			})
+		})
+	})
+})
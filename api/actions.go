@@ -0,0 +1,182 @@
+package api
+
+import "time"
+
+type PointerType string
+
+const (
+	MousePointer PointerType = "mouse"
+	PenPointer   PointerType = "pen"
+	TouchPointer PointerType = "touch"
+)
+
+// Actions is a sequence of low-level keyboard and pointer input actions,
+// which may be performed using *Session.PerformActions. Actions are only
+// supported by sessions that use the W3C dialect.
+//
+// Each action is performed after the previous action completes. Key actions
+// use the most recently selected keyboard, and pointer actions use the most
+// recently selected pointer, so that multiple input sources may be combined.
+// By default, a keyboard with the ID "keyboard" and a mouse pointer with the
+// ID "mouse" are used.
+//
+// Example:
+//    actions := api.NewActions().
+//        KeyDown(keys.Control).
+//        PointerMove(element, 0, 0).
+//        PointerDown(api.LeftButton).
+//        PointerUp(api.LeftButton).
+//        KeyUp(keys.Control)
+//    session.PerformActions(actions)
+// Control-clicks the element.
+type Actions struct {
+	sources  []*inputSource
+	keyboard *inputSource
+	pointer  *inputSource
+	ticks    int
+}
+
+type inputSource struct {
+	Type       string                   `json:"type"`
+	ID         string                   `json:"id"`
+	Parameters map[string]string        `json:"parameters,omitempty"`
+	Actions    []map[string]interface{} `json:"actions"`
+}
+
+func NewActions() *Actions {
+	return &Actions{}
+}
+
+// Keyboard selects the keyboard with the provided ID for subsequent key
+// actions, creating it if necessary.
+func (a *Actions) Keyboard(id string) *Actions {
+	a.keyboard = a.source("key", id, nil)
+	return a
+}
+
+// Pointer selects the pointer with the provided ID for subsequent pointer
+// actions, creating it with the provided pointer type if necessary.
+func (a *Actions) Pointer(id string, pointerType PointerType) *Actions {
+	parameters := map[string]string{"pointerType": string(pointerType)}
+	a.pointer = a.source("pointer", id, parameters)
+	return a
+}
+
+func (a *Actions) KeyDown(key string) *Actions {
+	return a.addKey(map[string]interface{}{"type": "keyDown", "value": key})
+}
+
+func (a *Actions) KeyUp(key string) *Actions {
+	return a.addKey(map[string]interface{}{"type": "keyUp", "value": key})
+}
+
+// PointerMove moves the pointer to the provided offset from the center of
+// the element. If the element is nil, the offset is from the top-left
+// corner of the viewport.
+func (a *Actions) PointerMove(element *Element, x, y int) *Actions {
+	var origin interface{} = "viewport"
+	if element != nil {
+		origin = map[string]string{W3CElementKey: element.ID}
+	}
+	return a.addPointer(map[string]interface{}{"type": "pointerMove", "duration": 0, "origin": origin, "x": x, "y": y})
+}
+
+// PointerMoveBy moves the pointer by the provided offset from its
+// current position.
+func (a *Actions) PointerMoveBy(x, y int) *Actions {
+	return a.addPointer(map[string]interface{}{"type": "pointerMove", "duration": 0, "origin": "pointer", "x": x, "y": y})
+}
+
+// Duration sets the duration of the preceding pointer move, so that the
+// pointer moves gradually instead of instantaneously.
+func (a *Actions) Duration(duration time.Duration) *Actions {
+	if a.pointer != nil && len(a.pointer.Actions) > 0 {
+		move := a.pointer.Actions[len(a.pointer.Actions)-1]
+		if move["type"] == "pointerMove" {
+			move["duration"] = int64(duration / time.Millisecond)
+		}
+	}
+	return a
+}
+
+func (a *Actions) PointerDown(button Button) *Actions {
+	return a.addPointer(map[string]interface{}{"type": "pointerDown", "button": button})
+}
+
+func (a *Actions) PointerUp(button Button) *Actions {
+	return a.addPointer(map[string]interface{}{"type": "pointerUp", "button": button})
+}
+
+// Pause waits for the provided duration before performing the next action.
+func (a *Actions) Pause(duration time.Duration) *Actions {
+	if len(a.sources) == 0 {
+		a.source("none", "pause", nil)
+	}
+
+	pause := map[string]interface{}{"type": "pause", "duration": int64(duration / time.Millisecond)}
+	for _, source := range a.sources {
+		a.pad(source)
+		source.Actions = append(source.Actions, pause)
+	}
+	a.ticks++
+	return a
+}
+
+func (a *Actions) source(sourceType, id string, parameters map[string]string) *inputSource {
+	for _, source := range a.sources {
+		if source.ID == id {
+			return source
+		}
+	}
+
+	source := &inputSource{Type: sourceType, ID: id, Parameters: parameters}
+	a.sources = append(a.sources, source)
+	return source
+}
+
+func (a *Actions) addKey(action map[string]interface{}) *Actions {
+	if a.keyboard == nil {
+		a.Keyboard("keyboard")
+	}
+	return a.add(a.keyboard, action)
+}
+
+func (a *Actions) addPointer(action map[string]interface{}) *Actions {
+	if a.pointer == nil {
+		a.Pointer("mouse", MousePointer)
+	}
+	return a.add(a.pointer, action)
+}
+
+func (a *Actions) add(source *inputSource, action map[string]interface{}) *Actions {
+	a.pad(source)
+	source.Actions = append(source.Actions, action)
+	a.ticks++
+	return a
+}
+
+// Every input source must have an action for each tick, so sources that
+// are idle during a tick are given a pause.
+func (a *Actions) pad(source *inputSource) {
+	for len(source.Actions) < a.ticks {
+		source.Actions = append(source.Actions, map[string]interface{}{"type": "pause"})
+	}
+}
+
+// PerformActions performs the provided sequence of actions.
+func (s *Session) PerformActions(actions *Actions) error {
+	for _, source := range actions.sources {
+		actions.pad(source)
+	}
+
+	request := struct {
+		Actions []*inputSource `json:"actions"`
+	}{actions.sources}
+	return s.Send("POST", "actions", request, nil)
+}
+
+// ReleaseActions releases any keys and pointer buttons that are currently
+// held down as a result of previously performed actions.
+func (s *Session) ReleaseActions() error {
+	return s.Send("DELETE", "actions", nil, nil)
+}
@@ -0,0 +1,178 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/mocks"
+)
+
+var _ = Describe("Actions", func() {
+	var (
+		bus     *mocks.Bus
+		session *Session
+	)
+
+	BeforeEach(func() {
+		bus = &mocks.Bus{}
+		session = &Session{Bus: bus, W3C: true}
+	})
+
+	Describe("#PerformActions", func() {
+		It("should successfully send a POST to the actions endpoint", func() {
+			Expect(session.PerformActions(NewActions().KeyDown("a"))).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+		})
+
+		It("should send key actions using the default keyboard", func() {
+			session.PerformActions(NewActions().KeyDown("a").KeyUp("a"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+				"type": "key",
+				"id": "keyboard",
+				"actions": [{"type": "keyDown", "value": "a"}, {"type": "keyUp", "value": "a"}]
+			}]}`))
+		})
+
+		It("should send pointer actions using the default mouse", func() {
+			element := &Element{"some-id", session}
+			session.PerformActions(NewActions().
+				PointerMove(element, 1, 2).
+				PointerDown(RightButton).
+				PointerMoveBy(3, 4).
+				PointerMove(nil, 5, 6).
+				PointerUp(RightButton))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+				"type": "pointer",
+				"id": "mouse",
+				"parameters": {"pointerType": "mouse"},
+				"actions": [
+					{"type": "pointerMove", "duration": 0, "origin": {"element-6066-11e4-a52e-4f735466cecf": "some-id"}, "x": 1, "y": 2},
+					{"type": "pointerDown", "button": 2},
+					{"type": "pointerMove", "duration": 0, "origin": "pointer", "x": 3, "y": 4},
+					{"type": "pointerMove", "duration": 0, "origin": "viewport", "x": 5, "y": 6},
+					{"type": "pointerUp", "button": 2}
+				]
+			}]}`))
+		})
+
+		It("should pad idle input sources with pauses so that actions occur in order", func() {
+			session.PerformActions(NewActions().
+				KeyDown("a").
+				PointerDown(LeftButton).
+				PointerUp(LeftButton).
+				KeyUp("a"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [
+				{
+					"type": "key",
+					"id": "keyboard",
+					"actions": [{"type": "keyDown", "value": "a"}, {"type": "pause"}, {"type": "pause"}, {"type": "keyUp", "value": "a"}]
+				},
+				{
+					"type": "pointer",
+					"id": "mouse",
+					"parameters": {"pointerType": "mouse"},
+					"actions": [{"type": "pause"}, {"type": "pointerDown", "button": 0}, {"type": "pointerUp", "button": 0}, {"type": "pause"}]
+				}
+			]}`))
+		})
+
+		It("should pause every input source", func() {
+			session.PerformActions(NewActions().KeyDown("a").PointerDown(LeftButton).Pause(time.Second))
+			var request struct {
+				Actions []struct{ Actions []map[string]interface{} }
+			}
+			json.Unmarshal(bus.SendCall.BodyJSON, &request)
+			Expect(request.Actions).To(HaveLen(2))
+			for _, source := range request.Actions {
+				Expect(source.Actions).To(HaveLen(3))
+				Expect(source.Actions[2]).To(Equal(map[string]interface{}{"type": "pause", "duration": 1000.0}))
+			}
+		})
+
+		Context("when only a pause is provided", func() {
+			It("should pause using an input source without a device", func() {
+				session.PerformActions(NewActions().Pause(10 * time.Millisecond))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "none",
+					"id": "pause",
+					"actions": [{"type": "pause", "duration": 10}]
+				}]}`))
+			})
+		})
+
+		Context("when multiple input sources are selected", func() {
+			It("should send actions for each input source", func() {
+				session.PerformActions(NewActions().
+					Pointer("first", TouchPointer).
+					PointerDown(LeftButton).
+					Pointer("second", PenPointer).
+					PointerDown(LeftButton).
+					Pointer("first", TouchPointer).
+					PointerUp(LeftButton).
+					Keyboard("other").
+					KeyDown("b"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [
+					{
+						"type": "pointer",
+						"id": "first",
+						"parameters": {"pointerType": "touch"},
+						"actions": [{"type": "pointerDown", "button": 0}, {"type": "pause"}, {"type": "pointerUp", "button": 0}, {"type": "pause"}]
+					},
+					{
+						"type": "pointer",
+						"id": "second",
+						"parameters": {"pointerType": "pen"},
+						"actions": [{"type": "pause"}, {"type": "pointerDown", "button": 0}, {"type": "pause"}, {"type": "pause"}]
+					},
+					{
+						"type": "key",
+						"id": "other",
+						"actions": [{"type": "pause"}, {"type": "pause"}, {"type": "pause"}, {"type": "keyDown", "value": "b"}]
+					}
+				]}`))
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.PerformActions(NewActions().KeyDown("a"))).To(MatchError("some error"))
+			})
+		})
+
+		Context("when a duration is provided for a pointer move", func() {
+			It("should move the pointer over the duration", func() {
+				session.PerformActions(NewActions().PointerMoveBy(1, 2).Duration(time.Second).PointerDown(LeftButton).Duration(time.Second))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "mouse",
+					"parameters": {"pointerType": "mouse"},
+					"actions": [
+						{"type": "pointerMove", "duration": 1000, "origin": "pointer", "x": 1, "y": 2},
+						{"type": "pointerDown", "button": 0}
+					]
+				}]}`))
+			})
+		})
+	})
+
+	Describe("#ReleaseActions", func() {
+		It("should successfully send a DELETE to the actions endpoint", func() {
+			Expect(session.ReleaseActions()).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("DELETE"))
+			Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.ReleaseActions()).To(MatchError("some error"))
+			})
+		})
+	})
+})
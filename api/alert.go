@@ -0,0 +1,55 @@
+package api
+
+// An AlertHandler is called when a command fails because an alert, confirm,
+// or prompt popup is open. The handler is provided a copy of the session that
+// does not call the handler, and should close the popup.
+type AlertHandler func(session *Session) error
+
+// OnUnexpectedAlert registers an AlertHandler that is called when a
+// subsequent command fails because a popup is open. If the handler succeeds,
+// the command is retried once. Otherwise, the original error is returned.
+// Registering a handler replaces any previously registered handler, and a
+// nil handler disables alert handling. The handler also applies to copies of
+// the session returned by WithContext.
+//
+// Depending on the unhandledPromptBehavior capability, the WebDriver may
+// close the popup itself before the handler is called.
+func (s *Session) OnUnexpectedAlert(handler AlertHandler) {
+	state := s.shared()
+	state.alertMutex.Lock()
+	defer state.alertMutex.Unlock()
+	state.alertHandler = handler
+}
+
+func (s *Session) currentAlertHandler() AlertHandler {
+	if s.ignoreAlerts {
+		return nil
+	}
+	state := s.shared()
+	state.alertMutex.Lock()
+	defer state.alertMutex.Unlock()
+	return state.alertHandler
+}
+
+// The handler is provided a copy of the session when it is called, so that
+// it uses the current session ID if the session was reopened.
+func (s *Session) retryAlert(send func() error) error {
+	err := send()
+	handler := s.currentAlertHandler()
+	if handler == nil || !IsUnexpectedAlertOpen(err) {
+		return err
+	}
+
+	alertSession := &Session{
+		Bus:          s.Bus,
+		W3C:          s.W3C,
+		WebSocketURL: s.WebSocketURL,
+		Capabilities: s.Capabilities,
+		state:        s.shared(),
+		ignoreAlerts: true,
+	}
+	if handlerErr := handler(alertSession); handlerErr != nil {
+		return err
+	}
+	return send()
+}
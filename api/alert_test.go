@@ -0,0 +1,115 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/bus"
+)
+
+type alertTestBus struct {
+	endpoints []string
+	errs      []error
+}
+
+func (b *alertTestBus) Send(method, endpoint string, body, result interface{}) error {
+	b.endpoints = append(b.endpoints, endpoint)
+	if len(b.errs) == 0 {
+		return nil
+	}
+	err := b.errs[0]
+	b.errs = b.errs[1:]
+	return err
+}
+
+var _ = Describe("Alert", func() {
+	var (
+		testBus   *alertTestBus
+		session   *Session
+		alertErr  error
+		handled   int
+		handlerOK bool
+	)
+
+	BeforeEach(func() {
+		testBus = &alertTestBus{}
+		session = &Session{Bus: testBus, W3C: true}
+		alertErr = &bus.ResponseError{StatusCode: 500, Code: ErrorUnexpectedAlertOpen, Message: "some alert"}
+		handled = 0
+		handlerOK = true
+		session.OnUnexpectedAlert(func(alertSession *Session) error {
+			handled++
+			if !handlerOK {
+				return errors.New("some handler error")
+			}
+			return alertSession.AcceptAlert()
+		})
+	})
+
+	Describe("#OnUnexpectedAlert", func() {
+		It("should not call the handler when the command succeeds", func() {
+			Expect(session.SetURL("some-url")).To(Succeed())
+			Expect(handled).To(Equal(0))
+			Expect(testBus.endpoints).To(Equal([]string{"url"}))
+		})
+
+		It("should call the handler and retry the command when an alert is open", func() {
+			testBus.errs = []error{alertErr}
+			Expect(session.SetURL("some-url")).To(Succeed())
+			Expect(handled).To(Equal(1))
+			Expect(testBus.endpoints).To(Equal([]string{"url", "alert/accept", "url"}))
+		})
+
+		It("should only retry the command once", func() {
+			testBus.errs = []error{alertErr, nil, alertErr}
+			err := session.SetURL("some-url")
+			Expect(IsUnexpectedAlertOpen(err)).To(BeTrue())
+			Expect(handled).To(Equal(1))
+		})
+
+		It("should return the original error when the handler fails", func() {
+			testBus.errs = []error{alertErr}
+			handlerOK = false
+			err := session.SetURL("some-url")
+			Expect(IsUnexpectedAlertOpen(err)).To(BeTrue())
+			Expect(testBus.endpoints).To(Equal([]string{"url"}))
+		})
+
+		It("should not call the handler for other errors", func() {
+			testBus.errs = []error{errors.New("some error")}
+			Expect(session.SetURL("some-url")).To(MatchError(ContainSubstring("some error")))
+			Expect(handled).To(Equal(0))
+		})
+
+		It("should not replace the Bus of the session", func() {
+			Expect(session.Bus).To(BeIdenticalTo(testBus))
+		})
+
+		It("should call the handler for copies of the session", func() {
+			testBus.errs = []error{alertErr}
+			Expect(session.WithContext(context.Background()).SetURL("some-url")).To(Succeed())
+			Expect(handled).To(Equal(1))
+			Expect(testBus.endpoints).To(Equal([]string{"url", "alert/accept", "url"}))
+		})
+
+		It("should replace the previous handler", func() {
+			session.OnUnexpectedAlert(func(alertSession *Session) error {
+				return alertSession.DismissAlert()
+			})
+			testBus.errs = []error{alertErr}
+			Expect(session.SetURL("some-url")).To(Succeed())
+			Expect(handled).To(Equal(0))
+			Expect(testBus.endpoints).To(Equal([]string{"url", "alert/dismiss", "url"}))
+		})
+
+		It("should disable alert handling when the handler is nil", func() {
+			session.OnUnexpectedAlert(nil)
+			testBus.errs = []error{alertErr}
+			Expect(IsUnexpectedAlertOpen(session.SetURL("some-url"))).To(BeTrue())
+			Expect(handled).To(Equal(0))
+		})
+	})
+})
@@ -2,6 +2,10 @@
 // All methods map directly to endpoints of the WebDriver Wire Protocol:
 // https://code.google.com/p/selenium/wiki/JsonWireProtocol
 //
+// Sessions also support the W3C WebDriver dialect: https://www.w3.org/TR/webdriver/
+// The dialect is detected when the session is opened, and commands that differ
+// between the two dialects are translated automatically.
+//
 // This package was previously internal to the agouti package. It currently
 // does not have a fixed API, but this will change in the near future
 // (with the addition of adequate documentation).
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sclevine/agouti/api/internal/websocket"
+)
+
+// SetHTTPAuth answers HTTP authentication challenges, ex. Basic or Digest,
+// for requests made by the current window of the session with the provided
+// credentials, until ClearHTTPAuth is called or the session is deleted. The
+// credentials are only sent in response to a challenge, so they are never sent
+// to origins that do not request them.
+//
+// Challenges are answered using the Fetch domain of the DevTools Protocol over
+// a separate DevTools connection, so this requires a Chromium-based browser
+// that reports its debuggerAddress capability, ex. Chrome with ChromeDriver.
+func (s *Session) SetHTTPAuth(username, password string) error {
+	if err := s.ClearHTTPAuth(); err != nil {
+		return err
+	}
+
+	socketURL, err := s.devToolsURL()
+	if err != nil {
+		return err
+	}
+	conn, err := websocket.Dial(socketURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to DevTools: %s", err)
+	}
+
+	auth := newAuthHandler(conn, username, password)
+	if err := auth.enable(); err != nil {
+		conn.Close()
+		return err
+	}
+
+	state := s.shared()
+	state.authMutex.Lock()
+	state.auth = auth
+	state.authMutex.Unlock()
+	return nil
+}
+
+// ClearHTTPAuth stops answering HTTP authentication challenges with the
+// credentials provided to SetHTTPAuth.
+func (s *Session) ClearHTTPAuth() error {
+	state := s.shared()
+	state.authMutex.Lock()
+	auth := state.auth
+	state.auth = nil
+	state.authMutex.Unlock()
+
+	if auth == nil {
+		return nil
+	}
+	return auth.conn.Close()
+}
+
+// The DevTools target of the current window is found using the HTTP endpoint
+// of the debugger. ChromeDriver uses the ID of the target as the window
+// handle, optionally prefixed with "CDwindow-".
+func (s *Session) devToolsURL() (string, error) {
+	var address string
+	for _, optionsKey := range []string{"goog:chromeOptions", "ms:edgeOptions"} {
+		if options, ok := s.Capabilities[optionsKey].(map[string]interface{}); ok {
+			if debuggerAddress, ok := options["debuggerAddress"].(string); ok && debuggerAddress != "" {
+				address = debuggerAddress
+				break
+			}
+		}
+	}
+	if address == "" {
+		return "", errors.New("the DevTools Protocol is not available: the browser did not report a debuggerAddress")
+	}
+
+	window, err := s.GetWindow()
+	if err != nil {
+		return "", err
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	response, err := client.Get("http://" + address + "/json/list")
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve DevTools targets: %s", err)
+	}
+	defer response.Body.Close()
+
+	var targets []struct {
+		ID                   string `json:"id"`
+		Type                 string `json:"type"`
+		WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&targets); err != nil {
+		return "", fmt.Errorf("failed to retrieve DevTools targets: %s", err)
+	}
+
+	windowID := strings.TrimPrefix(window.ID, "CDwindow-")
+	for _, target := range targets {
+		if target.Type == "page" && strings.EqualFold(target.ID, windowID) && target.WebSocketDebuggerURL != "" {
+			return target.WebSocketDebuggerURL, nil
+		}
+	}
+	return "", fmt.Errorf("failed to find DevTools target for window %s", window.ID)
+}
+
+// An authHandler continues every request paused by the Fetch domain, and
+// answers each authentication challenge with the credentials once. Repeated
+// challenges for the same request indicate that the credentials were
+// rejected, so they are cancelled instead of being answered again.
+type authHandler struct {
+	conn     *websocket.Conn
+	username string
+	password string
+	mutex    sync.Mutex
+	nextID   int
+	answered map[string]bool
+	enabled  chan devToolsMessage
+	done     chan struct{}
+	err      error
+}
+
+type devToolsMessage struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func newAuthHandler(conn *websocket.Conn, username, password string) *authHandler {
+	auth := &authHandler{
+		conn:     conn,
+		username: username,
+		password: password,
+		answered: map[string]bool{},
+		enabled:  make(chan devToolsMessage, 1),
+		done:     make(chan struct{}),
+	}
+	go auth.read()
+	return auth
+}
+
+func (a *authHandler) enable() error {
+	request := struct {
+		HandleAuthRequests bool `json:"handleAuthRequests"`
+	}{true}
+	if err := a.send("Fetch.enable", request); err != nil {
+		return fmt.Errorf("failed to enable DevTools authentication: %s", err)
+	}
+
+	select {
+	case response := <-a.enabled:
+		if response.Error != nil {
+			return fmt.Errorf("failed to enable DevTools authentication: %s", response.Error.Message)
+		}
+		return nil
+	case <-a.done:
+		return fmt.Errorf("failed to enable DevTools authentication: %s", a.err)
+	}
+}
+
+// Commands sent in response to events are not awaited, so that the read
+// loop is never blocked. Only the response to Fetch.enable, the first
+// command, is reported.
+func (a *authHandler) send(method string, params interface{}) error {
+	a.mutex.Lock()
+	a.nextID++
+	id := a.nextID
+	a.mutex.Unlock()
+
+	request := struct {
+		ID     int         `json:"id"`
+		Method string      `json:"method"`
+		Params interface{} `json:"params"`
+	}{id, method, params}
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return a.conn.WriteMessage(requestJSON)
+}
+
+func (a *authHandler) read() {
+	defer close(a.done)
+	for {
+		messageJSON, err := a.conn.ReadMessage()
+		if err != nil {
+			a.err = err
+			return
+		}
+
+		var message devToolsMessage
+		if err := json.Unmarshal(messageJSON, &message); err != nil {
+			continue
+		}
+		if message.ID != nil && *message.ID == 1 {
+			a.enabled <- message
+			continue
+		}
+
+		var event struct {
+			RequestID string `json:"requestId"`
+		}
+		json.Unmarshal(message.Params, &event)
+		switch message.Method {
+		case "Fetch.requestPaused":
+			a.send("Fetch.continueRequest", event)
+		case "Fetch.authRequired":
+			a.send("Fetch.continueWithAuth", a.challengeResponse(event.RequestID))
+		}
+	}
+}
+
+func (a *authHandler) challengeResponse(requestID string) interface{} {
+	type authChallengeResponse struct {
+		Response string `json:"response"`
+		Username string `json:"username,omitempty"`
+		Password string `json:"password,omitempty"`
+	}
+	response := authChallengeResponse{"ProvideCredentials", a.username, a.password}
+	if a.answered[requestID] {
+		response = authChallengeResponse{Response: "CancelAuth"}
+	}
+	a.answered[requestID] = true
+
+	return struct {
+		RequestID             string                `json:"requestId"`
+		AuthChallengeResponse authChallengeResponse `json:"authChallengeResponse"`
+	}{requestID, response}
+}
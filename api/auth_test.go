@@ -0,0 +1,153 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/mocks"
+	"github.com/sclevine/agouti/api/internal/websocket"
+)
+
+var _ = Describe("HTTP Authentication", func() {
+	var (
+		server   *httptest.Server
+		bus      *mocks.Bus
+		session  *Session
+		commands chan bidiCommand
+		conns    chan *websocket.Conn
+		closed   chan bool
+		respond  func(conn *websocket.Conn, command bidiCommand)
+	)
+
+	BeforeEach(func() {
+		commands = make(chan bidiCommand, 10)
+		conns = make(chan *websocket.Conn, 10)
+		closed = make(chan bool, 10)
+		respond = func(conn *websocket.Conn, command bidiCommand) {
+			if command.Method == "Fetch.enable" {
+				conn.WriteMessage([]byte(`{"id": ` + jsonInt(command.ID) + `, "result": {}}`))
+			}
+		}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/json/list" {
+				socketURL := "ws" + strings.TrimPrefix(server.URL, "http")
+				w.Write([]byte(`[
+					{"id": "OTHER-ID", "type": "page", "webSocketDebuggerUrl": "` + socketURL + `/devtools/page/OTHER-ID"},
+					{"id": "SOME-ID", "type": "page", "webSocketDebuggerUrl": "` + socketURL + `/devtools/page/SOME-ID"}
+				]`))
+				return
+			}
+			if r.URL.Path != "/devtools/page/SOME-ID" {
+				http.NotFound(w, r)
+				return
+			}
+			conn, err := websocket.Upgrade(w, r)
+			if err != nil {
+				return
+			}
+			conns <- conn
+			for {
+				message, err := conn.ReadMessage()
+				if err != nil {
+					closed <- true
+					return
+				}
+				var command bidiCommand
+				json.Unmarshal(message, &command)
+				commands <- command
+				respond(conn, command)
+			}
+		}))
+
+		bus = &mocks.Bus{}
+		bus.SendCall.Result = `"some-id"`
+		session = &Session{Bus: bus, W3C: true, Capabilities: map[string]interface{}{
+			"goog:chromeOptions": map[string]interface{}{"debuggerAddress": strings.TrimPrefix(server.URL, "http://")},
+		}}
+	})
+
+	AfterEach(func() {
+		session.ClearHTTPAuth()
+		server.Close()
+	})
+
+	Describe("#SetHTTPAuth", func() {
+		It("should handle authentication requests using the Fetch domain of the current window", func() {
+			Expect(session.SetHTTPAuth("some-user", "some-password")).To(Succeed())
+			Expect(bus.SendCall.Endpoint).To(Equal("window"))
+			var command bidiCommand
+			Eventually(commands).Should(Receive(&command))
+			Expect(command.Method).To(Equal("Fetch.enable"))
+			Expect(command.Params).To(MatchJSON(`{"handleAuthRequests": true}`))
+		})
+
+		It("should continue paused requests without credentials", func() {
+			Expect(session.SetHTTPAuth("some-user", "some-password")).To(Succeed())
+			conn := <-conns
+			conn.WriteMessage([]byte(`{"method": "Fetch.requestPaused", "params": {"requestId": "some-request"}}`))
+			var command bidiCommand
+			Eventually(commands).Should(Receive(&command))
+			Eventually(commands).Should(Receive(&command))
+			Expect(command.Method).To(Equal("Fetch.continueRequest"))
+			Expect(command.Params).To(MatchJSON(`{"requestId": "some-request"}`))
+		})
+
+		It("should answer each challenge with the credentials once", func() {
+			Expect(session.SetHTTPAuth("some-user", "some-password")).To(Succeed())
+			conn := <-conns
+			var command bidiCommand
+			Eventually(commands).Should(Receive(&command))
+
+			conn.WriteMessage([]byte(`{"method": "Fetch.authRequired", "params": {"requestId": "some-request"}}`))
+			Eventually(commands).Should(Receive(&command))
+			Expect(command.Method).To(Equal("Fetch.continueWithAuth"))
+			Expect(command.Params).To(MatchJSON(`{
+				"requestId": "some-request",
+				"authChallengeResponse": {"response": "ProvideCredentials", "username": "some-user", "password": "some-password"}
+			}`))
+
+			conn.WriteMessage([]byte(`{"method": "Fetch.authRequired", "params": {"requestId": "some-request"}}`))
+			Eventually(commands).Should(Receive(&command))
+			Expect(command.Params).To(MatchJSON(`{"requestId": "some-request", "authChallengeResponse": {"response": "CancelAuth"}}`))
+		})
+
+		Context("when the browser rejects the Fetch domain", func() {
+			It("should return an error", func() {
+				respond = func(conn *websocket.Conn, command bidiCommand) {
+					conn.WriteMessage([]byte(`{"id": ` + jsonInt(command.ID) + `, "error": {"code": -32601, "message": "some message"}}`))
+				}
+				err := session.SetHTTPAuth("some-user", "some-password")
+				Expect(err).To(MatchError("failed to enable DevTools authentication: some message"))
+			})
+		})
+
+		Context("when the current window is not a DevTools target", func() {
+			It("should return an error", func() {
+				bus.SendCall.Result = `"missing-id"`
+				err := session.SetHTTPAuth("some-user", "some-password")
+				Expect(err).To(MatchError("failed to find DevTools target for window missing-id"))
+			})
+		})
+
+		Context("when the browser does not report a debuggerAddress", func() {
+			It("should return an error", func() {
+				session.Capabilities = map[string]interface{}{"browserName": "firefox"}
+				err := session.SetHTTPAuth("some-user", "some-password")
+				Expect(err).To(MatchError("the DevTools Protocol is not available: the browser did not report a debuggerAddress"))
+			})
+		})
+	})
+
+	Describe("#ClearHTTPAuth", func() {
+		It("should close the DevTools connection", func() {
+			Expect(session.SetHTTPAuth("some-user", "some-password")).To(Succeed())
+			Expect(session.ClearHTTPAuth()).To(Succeed())
+			Eventually(closed).Should(Receive())
+		})
+	})
+})
@@ -0,0 +1,410 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/sclevine/agouti/api/internal/websocket"
+)
+
+// An Event is a WebDriver BiDi event sent by the browser.
+type Event struct {
+	// Method is the name of the event, ex. "log.entryAdded"
+	Method string
+
+	// Params contains the JSON-encoded parameters of the event
+	Params json.RawMessage
+}
+
+// Events beyond this many waiting to be received by a subscription replace
+// the oldest waiting events, so that an unread channel cannot grow without
+// bound.
+const maxQueuedEvents = 1000
+
+// Subscribe subscribes to the provided WebDriver BiDi events, which may also
+// name entire modules (ex. "log" for every log event), and returns a channel
+// that receives them. The channel is closed when the BiDi connection is
+// closed by CloseBiDi or Delete, or when the subscription is ended by
+// Unsubscribe. If more than 1000 events are waiting to be received, the
+// oldest are discarded. The session must have been opened with the
+// webSocketUrl capability set to true.
+//
+// Example:
+//    entries, err := session.Subscribe("log.entryAdded")
+//    for entry := range entries {
+//        fmt.Printf("%s\n", entry.Params)
+//    }
+func (s *Session) Subscribe(events ...string) (<-chan Event, error) {
+	client, err := s.bidiClient()
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := client.subscribe(events)
+	request := struct {
+		Events []string `json:"events"`
+	}{events}
+	var result struct {
+		Subscription string `json:"subscription"`
+	}
+	if err := client.execute("session.subscribe", request, &result); err != nil {
+		client.unsubscribe(subscription)
+		return nil, err
+	}
+	subscription.id = result.Subscription
+	return subscription.events, nil
+}
+
+// Unsubscribe ends the subscription that returned the provided channel and
+// closes the channel. Browsers that identify subscriptions are asked to end
+// that subscription. Otherwise, its events are unsubscribed unless another
+// subscription on the session still requires them. Channels that were
+// already closed by CloseBiDi or Delete are ignored.
+func (s *Session) Unsubscribe(events <-chan Event) error {
+	state := s.shared()
+	state.bidiMutex.Lock()
+	client := state.bidi
+	state.bidiMutex.Unlock()
+
+	if client == nil || client.closed() {
+		return nil
+	}
+
+	subscription, unused := client.remove(events)
+	if subscription == nil {
+		return errors.New("failed to unsubscribe: the channel was not returned by Subscribe")
+	}
+
+	var request interface{}
+	if subscription.id != "" {
+		request = struct {
+			Subscriptions []string `json:"subscriptions"`
+		}{[]string{subscription.id}}
+	} else if len(unused) > 0 {
+		request = struct {
+			Events []string `json:"events"`
+		}{unused}
+	} else {
+		return nil
+	}
+	return client.execute("session.unsubscribe", request, nil)
+}
+
+// ExecuteBiDi sends a WebDriver BiDi command and decodes its result into the
+// provided result, which may be nil. Parameters may be nil for commands that
+// take no parameters. Errors returned by the browser are provided as an *Error.
+func (s *Session) ExecuteBiDi(method string, params, result interface{}) error {
+	client, err := s.bidiClient()
+	if err != nil {
+		return err
+	}
+	return client.execute(method, params, result)
+}
+
+// CloseBiDi closes the WebDriver BiDi connection of the session, if open,
+// which closes every channel returned by Subscribe.
+func (s *Session) CloseBiDi() error {
+	state := s.shared()
+	state.bidiMutex.Lock()
+	client := state.bidi
+	state.bidi = nil
+	state.bidiMutex.Unlock()
+
+	if client == nil {
+		return nil
+	}
+	return client.close()
+}
+
+func (s *Session) bidiClient() (*bidiClient, error) {
+	state := s.shared()
+	state.bidiMutex.Lock()
+	defer state.bidiMutex.Unlock()
+
+	if state.bidi != nil && !state.bidi.closed() {
+		return state.bidi, nil
+	}
+	if s.WebSocketURL == "" {
+		return nil, errors.New("WebDriver BiDi is not available: the session was not opened with the webSocketUrl capability")
+	}
+
+	conn, err := websocket.Dial(s.WebSocketURL)
+	if err != nil {
+		return nil, err
+	}
+	state.bidi = newBiDiClient(conn)
+	return state.bidi, nil
+}
+
+type bidiClient struct {
+	conn          *websocket.Conn
+	mutex         sync.Mutex
+	nextID        int
+	pending       map[int]chan bidiMessage
+	subscriptions []*bidiSubscription
+	done          chan struct{}
+	err           error
+}
+
+type bidiMessage struct {
+	Type    string          `json:"type"`
+	ID      *int            `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	Result  json.RawMessage `json:"result"`
+	Error   string          `json:"error"`
+	Message string          `json:"message"`
+}
+
+func newBiDiClient(conn *websocket.Conn) *bidiClient {
+	client := &bidiClient{
+		conn:    conn,
+		pending: map[int]chan bidiMessage{},
+		done:    make(chan struct{}),
+	}
+	go client.read()
+	return client
+}
+
+func (c *bidiClient) execute(method string, params, result interface{}) error {
+	if params == nil {
+		params = struct{}{}
+	}
+
+	c.mutex.Lock()
+	if c.err != nil {
+		c.mutex.Unlock()
+		return c.err
+	}
+	c.nextID++
+	id := c.nextID
+	responses := make(chan bidiMessage, 1)
+	c.pending[id] = responses
+	c.mutex.Unlock()
+
+	request := struct {
+		ID     int         `json:"id"`
+		Method string      `json:"method"`
+		Params interface{} `json:"params"`
+	}{id, method, params}
+	requestJSON, err := json.Marshal(request)
+	if err == nil {
+		err = c.conn.WriteMessage(requestJSON)
+	}
+	if err != nil {
+		c.mutex.Lock()
+		delete(c.pending, id)
+		c.mutex.Unlock()
+		return err
+	}
+
+	select {
+	case response := <-responses:
+		if response.Type == "error" {
+			return &Error{Code: response.Error, Message: response.Message}
+		}
+		if result == nil || len(response.Result) == 0 {
+			return nil
+		}
+		return json.Unmarshal(response.Result, result)
+	case <-c.done:
+		return c.err
+	}
+}
+
+// Messages that cannot be decoded are ignored, as they cannot be matched to
+// a command or subscription.
+func (c *bidiClient) read() {
+	for {
+		messageJSON, err := c.conn.ReadMessage()
+		if err != nil {
+			c.fail(err)
+			return
+		}
+
+		var message bidiMessage
+		if err := json.Unmarshal(messageJSON, &message); err != nil {
+			continue
+		}
+
+		c.mutex.Lock()
+		if message.ID != nil {
+			if responses, ok := c.pending[*message.ID]; ok {
+				delete(c.pending, *message.ID)
+				responses <- message
+			}
+		} else if message.Type == "event" {
+			for _, subscription := range c.subscriptions {
+				if subscription.matches(message.Method) {
+					subscription.push(Event{message.Method, message.Params})
+				}
+			}
+		}
+		c.mutex.Unlock()
+	}
+}
+
+func (c *bidiClient) fail(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.err == nil {
+		c.err = errors.New("WebDriver BiDi connection closed: " + err.Error())
+		close(c.done)
+	}
+	c.pending = map[int]chan bidiMessage{}
+	c.subscriptions = nil
+}
+
+func (c *bidiClient) closed() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.err != nil
+}
+
+func (c *bidiClient) close() error {
+	err := c.conn.Close()
+	c.fail(errors.New("closed by client"))
+	return err
+}
+
+func (c *bidiClient) subscribe(events []string) *bidiSubscription {
+	subscription := &bidiSubscription{
+		names:   events,
+		events:  make(chan Event),
+		ready:   make(chan struct{}, 1),
+		stopped: make(chan struct{}),
+	}
+	go subscription.forward(c.done)
+
+	c.mutex.Lock()
+	c.subscriptions = append(c.subscriptions, subscription)
+	c.mutex.Unlock()
+	return subscription
+}
+
+func (c *bidiClient) unsubscribe(subscription *bidiSubscription) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for index, existing := range c.subscriptions {
+		if existing == subscription {
+			c.subscriptions = append(c.subscriptions[:index], c.subscriptions[index+1:]...)
+			subscription.stop()
+			break
+		}
+	}
+}
+
+// The returned events are the names of the removed subscription that no
+// remaining subscription overlaps with.
+func (c *bidiClient) remove(events <-chan Event) (*bidiSubscription, []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var subscription *bidiSubscription
+	for index, existing := range c.subscriptions {
+		if existing.events == events {
+			subscription = existing
+			c.subscriptions = append(c.subscriptions[:index], c.subscriptions[index+1:]...)
+			break
+		}
+	}
+	if subscription == nil {
+		return nil, nil
+	}
+	subscription.stop()
+
+	var unused []string
+	for _, name := range subscription.names {
+		used := false
+		for _, remaining := range c.subscriptions {
+			used = used || remaining.overlaps(name)
+		}
+		if !used {
+			unused = append(unused, name)
+		}
+	}
+	return subscription, unused
+}
+
+// Events are queued for each subscription, so that a slow receiver does not
+// block the responses to other commands.
+type bidiSubscription struct {
+	id      string
+	names   []string
+	events  chan Event
+	mutex   sync.Mutex
+	queue   []Event
+	ready   chan struct{}
+	stopped chan struct{}
+}
+
+func (s *bidiSubscription) matches(method string) bool {
+	for _, name := range s.names {
+		if method == name || strings.HasPrefix(method, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Module subscriptions overlap with the events of the module, and event
+// subscriptions overlap with their module.
+func (s *bidiSubscription) overlaps(name string) bool {
+	for _, existing := range s.names {
+		if strings.HasPrefix(existing, name+".") {
+			return true
+		}
+	}
+	return s.matches(name)
+}
+
+func (s *bidiSubscription) push(event Event) {
+	s.mutex.Lock()
+	if len(s.queue) >= maxQueuedEvents {
+		s.queue = s.queue[1:]
+	}
+	s.queue = append(s.queue, event)
+	s.mutex.Unlock()
+
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Subscriptions must only be stopped once.
+func (s *bidiSubscription) stop() {
+	close(s.stopped)
+}
+
+func (s *bidiSubscription) forward(done <-chan struct{}) {
+	defer close(s.events)
+	for {
+		s.mutex.Lock()
+		queue := s.queue
+		s.queue = nil
+		s.mutex.Unlock()
+
+		for _, event := range queue {
+			select {
+			case s.events <- event:
+			case <-s.stopped:
+				return
+			case <-done:
+				return
+			}
+		}
+
+		select {
+		case <-s.ready:
+		case <-s.stopped:
+			return
+		case <-done:
+			return
+		}
+	}
+}
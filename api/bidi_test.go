@@ -0,0 +1,312 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/mocks"
+	"github.com/sclevine/agouti/api/internal/websocket"
+)
+
+type bidiCommand struct {
+	ID     int             `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+var _ = Describe("BiDi", func() {
+	var (
+		server   *httptest.Server
+		bus      *mocks.Bus
+		session  *Session
+		commands chan bidiCommand
+		conns    chan *websocket.Conn
+		respond  func(conn *websocket.Conn, command bidiCommand)
+	)
+
+	BeforeEach(func() {
+		commands = make(chan bidiCommand, 10)
+		conns = make(chan *websocket.Conn, 10)
+		respond = func(conn *websocket.Conn, command bidiCommand) {
+			conn.WriteMessage([]byte(`{"type": "success", "id": ` + jsonInt(command.ID) + `, "result": {"some": "result"}}`))
+		}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, err := websocket.Upgrade(w, r)
+			if err != nil {
+				return
+			}
+			conns <- conn
+			for {
+				message, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				var command bidiCommand
+				json.Unmarshal(message, &command)
+				commands <- command
+				respond(conn, command)
+			}
+		}))
+
+		bus = &mocks.Bus{}
+		session = &Session{Bus: bus, W3C: true, WebSocketURL: "ws" + strings.TrimPrefix(server.URL, "http") + "/session/some-id"}
+	})
+
+	AfterEach(func() {
+		session.CloseBiDi()
+		server.Close()
+	})
+
+	Describe("#ExecuteBiDi", func() {
+		It("should send the command over the WebSocket connection", func() {
+			Expect(session.ExecuteBiDi("browsingContext.getTree", map[string]int{"maxDepth": 1}, nil)).To(Succeed())
+			var command bidiCommand
+			Eventually(commands).Should(Receive(&command))
+			Expect(command.ID).To(Equal(1))
+			Expect(command.Method).To(Equal("browsingContext.getTree"))
+			Expect(command.Params).To(MatchJSON(`{"maxDepth": 1}`))
+		})
+
+		It("should reuse the connection for subsequent commands", func() {
+			Expect(session.ExecuteBiDi("some.command", nil, nil)).To(Succeed())
+			Expect(session.ExecuteBiDi("some.command", nil, nil)).To(Succeed())
+			Expect(conns).To(HaveLen(1))
+			var command bidiCommand
+			Eventually(commands).Should(Receive(&command))
+			Eventually(commands).Should(Receive(&command))
+			Expect(command.ID).To(Equal(2))
+			Expect(command.Params).To(MatchJSON(`{}`))
+		})
+
+		It("should fill the provided result interface", func() {
+			var result struct{ Some string }
+			Expect(session.ExecuteBiDi("some.command", nil, &result)).To(Succeed())
+			Expect(result.Some).To(Equal("result"))
+		})
+
+		Context("when the browser responds with an error", func() {
+			It("should return an *Error with the error code", func() {
+				respond = func(conn *websocket.Conn, command bidiCommand) {
+					conn.WriteMessage([]byte(`{
+						"type": "error", "id": ` + jsonInt(command.ID) + `,
+						"error": "unknown command", "message": "some message"
+					}`))
+				}
+				err := session.ExecuteBiDi("some.command", nil, nil)
+				Expect(err).To(MatchError("request unsuccessful: some message"))
+				Expect(ErrorCode(err)).To(Equal(ErrorUnknownCommand))
+			})
+		})
+
+		Context("when the connection is closed before the browser responds", func() {
+			It("should return an error", func() {
+				respond = func(conn *websocket.Conn, command bidiCommand) {
+					conn.Close()
+				}
+				err := session.ExecuteBiDi("some.command", nil, nil)
+				Expect(err).To(MatchError("WebDriver BiDi connection closed: EOF"))
+			})
+		})
+
+		Context("when the session was not opened with the webSocketUrl capability", func() {
+			It("should return an error", func() {
+				session.WebSocketURL = ""
+				err := session.ExecuteBiDi("some.command", nil, nil)
+				Expect(err).To(MatchError("WebDriver BiDi is not available: the session was not opened with the webSocketUrl capability"))
+			})
+		})
+	})
+
+	Describe("#Subscribe", func() {
+		BeforeEach(func() {
+			respond = func(conn *websocket.Conn, command bidiCommand) {
+				conn.WriteMessage([]byte(`{"type": "success", "id": ` + jsonInt(command.ID) + `, "result": {}}`))
+				if command.Method == "session.subscribe" {
+					conn.WriteMessage([]byte(`{"type": "event", "method": "log.entryAdded", "params": {"text": "first"}}`))
+					conn.WriteMessage([]byte(`{"type": "event", "method": "network.beforeRequestSent", "params": {}}`))
+					conn.WriteMessage([]byte(`{"type": "event", "method": "log.entryAdded", "params": {"text": "second"}}`))
+				}
+			}
+		})
+
+		It("should subscribe to the provided events", func() {
+			_, err := session.Subscribe("log.entryAdded", "browsingContext")
+			Expect(err).NotTo(HaveOccurred())
+			var command bidiCommand
+			Eventually(commands).Should(Receive(&command))
+			Expect(command.Method).To(Equal("session.subscribe"))
+			Expect(command.Params).To(MatchJSON(`{"events": ["log.entryAdded", "browsingContext"]}`))
+		})
+
+		It("should send matching events to the returned channel in order", func() {
+			events, err := session.Subscribe("log.entryAdded")
+			Expect(err).NotTo(HaveOccurred())
+			var event Event
+			Eventually(events).Should(Receive(&event))
+			Expect(event.Method).To(Equal("log.entryAdded"))
+			Expect(event.Params).To(MatchJSON(`{"text": "first"}`))
+			Eventually(events).Should(Receive(&event))
+			Expect(event.Params).To(MatchJSON(`{"text": "second"}`))
+		})
+
+		It("should send every event of a subscribed module", func() {
+			events, err := session.Subscribe("network")
+			Expect(err).NotTo(HaveOccurred())
+			var event Event
+			Eventually(events).Should(Receive(&event))
+			Expect(event.Method).To(Equal("network.beforeRequestSent"))
+		})
+
+		It("should close the channel when the connection is closed", func() {
+			events, err := session.Subscribe("log.entryAdded")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.CloseBiDi()).To(Succeed())
+			Eventually(events).Should(BeClosed())
+		})
+
+		It("should discard the oldest events when too many are waiting to be received", func() {
+			respond = func(conn *websocket.Conn, command bidiCommand) {
+				conn.WriteMessage([]byte(`{"type": "success", "id": ` + jsonInt(command.ID) + `, "result": {}}`))
+				if command.Method == "session.subscribe" {
+					for i := 0; i < 2500; i++ {
+						conn.WriteMessage([]byte(`{"type": "event", "method": "log.entryAdded", "params": {"index": ` + jsonInt(i) + `}}`))
+					}
+				}
+			}
+			events, err := session.Subscribe("log.entryAdded")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.ExecuteBiDi("some.command", nil, nil)).To(Succeed())
+			var received []Event
+			for len(received) == 0 || !strings.Contains(string(received[len(received)-1].Params), "2499") {
+				var event Event
+				Eventually(events).Should(Receive(&event))
+				received = append(received, event)
+			}
+			Expect(len(received)).To(BeNumerically("<", 2500))
+		})
+
+		Context("when the browser fails to subscribe", func() {
+			It("should return an error", func() {
+				respond = func(conn *websocket.Conn, command bidiCommand) {
+					conn.WriteMessage([]byte(`{"type": "error", "id": ` + jsonInt(command.ID) + `, "error": "invalid argument", "message": "some message"}`))
+				}
+				_, err := session.Subscribe("some.event")
+				Expect(err).To(MatchError("request unsuccessful: some message"))
+			})
+		})
+	})
+
+	Describe("#Unsubscribe", func() {
+		var subscriptionID string
+
+		BeforeEach(func() {
+			subscriptionID = ""
+			respond = func(conn *websocket.Conn, command bidiCommand) {
+				result := `{}`
+				if command.Method == "session.subscribe" && subscriptionID != "" {
+					result = `{"subscription": "` + subscriptionID + `"}`
+				}
+				conn.WriteMessage([]byte(`{"type": "success", "id": ` + jsonInt(command.ID) + `, "result": ` + result + `}`))
+			}
+		})
+
+		It("should close the channel", func() {
+			events, err := session.Subscribe("log.entryAdded")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.Unsubscribe(events)).To(Succeed())
+			Eventually(events).Should(BeClosed())
+		})
+
+		It("should leave other subscriptions open", func() {
+			events, err := session.Subscribe("log.entryAdded")
+			Expect(err).NotTo(HaveOccurred())
+			otherEvents, err := session.Subscribe("log.entryAdded")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.Unsubscribe(events)).To(Succeed())
+			Eventually(events).Should(BeClosed())
+			Consistently(otherEvents).ShouldNot(BeClosed())
+		})
+
+		Context("when the browser identifies the subscription", func() {
+			It("should unsubscribe from the subscription", func() {
+				subscriptionID = "some-subscription"
+				events, err := session.Subscribe("log.entryAdded")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(session.Unsubscribe(events)).To(Succeed())
+				var command bidiCommand
+				Eventually(commands).Should(Receive(&command))
+				Eventually(commands).Should(Receive(&command))
+				Expect(command.Method).To(Equal("session.unsubscribe"))
+				Expect(command.Params).To(MatchJSON(`{"subscriptions": ["some-subscription"]}`))
+			})
+		})
+
+		Context("when the browser does not identify the subscription", func() {
+			It("should unsubscribe from events that no other subscription requires", func() {
+				_, err := session.Subscribe("log")
+				Expect(err).NotTo(HaveOccurred())
+				events, err := session.Subscribe("log.entryAdded", "network")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(session.Unsubscribe(events)).To(Succeed())
+				var command bidiCommand
+				Eventually(commands).Should(Receive(&command))
+				Eventually(commands).Should(Receive(&command))
+				Eventually(commands).Should(Receive(&command))
+				Expect(command.Method).To(Equal("session.unsubscribe"))
+				Expect(command.Params).To(MatchJSON(`{"events": ["network"]}`))
+			})
+		})
+
+		Context("when the channel was not returned by Subscribe", func() {
+			It("should return an error", func() {
+				_, err := session.Subscribe("log.entryAdded")
+				Expect(err).NotTo(HaveOccurred())
+				err = session.Unsubscribe(make(chan Event))
+				Expect(err).To(MatchError("failed to unsubscribe: the channel was not returned by Subscribe"))
+			})
+		})
+
+		Context("when the connection was already closed", func() {
+			It("should succeed", func() {
+				events, err := session.Subscribe("log.entryAdded")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(session.CloseBiDi()).To(Succeed())
+				Expect(session.Unsubscribe(events)).To(Succeed())
+			})
+		})
+	})
+
+	Describe("#AddInitScript", func() {
+		Context("when the DevTools Protocol is not supported", func() {
+			It("should add a WebDriver BiDi preload script", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.AddInitScript("some script")).To(Succeed())
+				var command bidiCommand
+				Eventually(commands).Should(Receive(&command))
+				Expect(command.Method).To(Equal("script.addPreloadScript"))
+				Expect(command.Params).To(MatchJSON(`{"functionDeclaration": "function() {\nsome script\n}"}`))
+			})
+		})
+	})
+
+	Describe("#Delete", func() {
+		It("should close the WebDriver BiDi connection", func() {
+			events, err := session.Subscribe("log.entryAdded")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.Delete()).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("DELETE"))
+			Eventually(events).Should(BeClosed())
+		})
+	})
+})
+
+func jsonInt(value int) string {
+	valueJSON, _ := json.Marshal(value)
+	return string(valueJSON)
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// A cassette entry is one WebDriver command and its response. Cassettes are
+// stored as one JSON entry per line.
+type cassetteEntry struct {
+	Method   string          `json:"method"`
+	Path     string          `json:"path"`
+	Request  json.RawMessage `json:"request,omitempty"`
+	Status   int             `json:"status"`
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+// WithRecording provides a ConnectOption that records every WebDriver
+// command and its response to the provided cassette file, which is replaced
+// if it already exists. The cassette may be replayed using WithReplay.
+//
+// Example:
+//    client, err := api.NewHTTPClient(api.WithRecording("testdata/login.cassette"))
+//    page, err := agouti.NewPage(webDriverURL, agouti.HTTPClient(client))
+func WithRecording(filename string) ConnectOption {
+	return func(c *connectConfig) {
+		c.cassette = func(transport http.RoundTripper) (http.RoundTripper, error) {
+			if err := ioutil.WriteFile(filename, nil, 0666); err != nil {
+				return nil, err
+			}
+			if transport == nil {
+				transport = http.DefaultTransport
+			}
+			return &recorder{filename: filename, transport: transport}, nil
+		}
+	}
+}
+
+// WithReplay provides a ConnectOption that responds to WebDriver commands
+// using the responses recorded in the provided cassette file, so that no
+// browser or WebDriver is needed. Each command is answered by the first
+// unused recorded command with the same method, path, and request body. If
+// all such commands were used, the last of them is answered again, so that
+// polling eventually receives the final recorded response. Commands that
+// were never recorded fail. The WebDriver URL is not used, except for its
+// path, which must match the recorded path.
+//
+// Example:
+//    client, err := api.NewHTTPClient(api.WithReplay("testdata/login.cassette"))
+//    page, err := agouti.NewPage("http://replay/wd/hub", agouti.HTTPClient(client))
+func WithReplay(filename string) ConnectOption {
+	return func(c *connectConfig) {
+		c.cassette = func(http.RoundTripper) (http.RoundTripper, error) {
+			entries, err := readCassette(filename)
+			if err != nil {
+				return nil, err
+			}
+			return &replayer{entries: entries, used: make([]bool, len(entries))}, nil
+		}
+	}
+}
+
+type recorder struct {
+	filename  string
+	transport http.RoundTripper
+	mutex     sync.Mutex
+}
+
+func (r *recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBody, request, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compression is left to the transport, so that responses are recorded
+	// without it.
+	if request.Header.Get("Accept-Encoding") != "" {
+		request = request.Clone(request.Context())
+		request.Header.Del("Accept-Encoding")
+	}
+
+	response, err := r.transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+
+	entry := cassetteEntry{
+		Method:   request.Method,
+		Path:     request.URL.Path,
+		Request:  cassetteJSON(requestBody),
+		Status:   response.StatusCode,
+		Response: cassetteJSON(responseBody),
+	}
+	if err := r.write(entry); err != nil {
+		return nil, fmt.Errorf("failed to record command: %s", err)
+	}
+	return response, nil
+}
+
+func (r *recorder) write(entry cassetteEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	file, err := os.OpenFile(r.filename, os.O_APPEND|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+type replayer struct {
+	entries []cassetteEntry
+	used    []bool
+	mutex   sync.Mutex
+}
+
+func (r *replayer) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBody, request, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+	body := cassetteJSON(requestBody)
+
+	entry, ok := r.next(request.Method, request.URL.Path, body)
+	if !ok {
+		return nil, fmt.Errorf("no recorded response for %s %s %s", request.Method, request.URL.Path, body)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.Status, http.StatusText(entry.Status)),
+		StatusCode:    entry.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(entry.Response)),
+		ContentLength: int64(len(entry.Response)),
+		Request:       request,
+	}, nil
+}
+
+func (r *replayer) next(method, path string, body json.RawMessage) (cassetteEntry, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	last := -1
+	for index, entry := range r.entries {
+		if entry.Method != method || entry.Path != path || !bytes.Equal(entry.Request, body) {
+			continue
+		}
+		if !r.used[index] {
+			r.used[index] = true
+			return entry, true
+		}
+		last = index
+	}
+
+	if last < 0 {
+		return cassetteEntry{}, false
+	}
+	return r.entries[last], true
+}
+
+func readCassette(filename string) ([]cassetteEntry, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []cassetteEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry cassetteEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid cassette entry on line %d: %s", line, err)
+		}
+		entry.Request = cassetteJSON(entry.Request)
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// readRequestBody reads the body of the request and returns a copy of the
+// request that may be sent with the same body.
+func readRequestBody(request *http.Request) ([]byte, *http.Request, error) {
+	if request.Body == nil {
+		return nil, request, nil
+	}
+
+	body, err := ioutil.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request = request.Clone(request.Context())
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, request, nil
+}
+
+// Bodies are stored as compact JSON, so that recorded requests may be
+// compared to replayed requests byte for byte. Bodies that are not JSON are
+// stored as JSON strings.
+func cassetteJSON(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, body); err == nil {
+		return compact.Bytes()
+	}
+
+	quoted, _ := json.Marshal(string(body))
+	return quoted
+}
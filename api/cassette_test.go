@@ -0,0 +1,120 @@
+package api_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+)
+
+var _ = Describe("Cassettes", func() {
+	var (
+		directory string
+		cassette  string
+		server    *httptest.Server
+		titles    []string
+	)
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "cassette")
+		Expect(err).NotTo(HaveOccurred())
+		cassette = filepath.Join(directory, "some.cassette")
+
+		titles = []string{"first title", "second title"}
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			switch request.URL.Path {
+			case "/session":
+				response.Write([]byte(`{"value": {"sessionId": "some-id", "capabilities": {}}}`))
+			case "/session/some-id/title":
+				response.Write([]byte(`{"value": "` + titles[0] + `"}`))
+				titles = titles[1:]
+			case "/session/some-id/url":
+				response.Write([]byte(`{"value": null}`))
+			default:
+				response.WriteHeader(404)
+				response.Write([]byte(`{"value": {"error": "no such element", "message": "some message"}}`))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(directory)
+	})
+
+	record := func() {
+		session, err := Connect(server.URL, nil, WithRecording(cassette))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(session.SetURL("http://example.com")).To(Succeed())
+		Expect(session.GetTitle()).To(Equal("first title"))
+		Expect(session.GetTitle()).To(Equal("second title"))
+		_, err = session.GetElement(Selector{Using: "css selector", Value: "#missing"})
+		Expect(IsNoSuchElement(err)).To(BeTrue())
+	}
+
+	Describe(".WithRecording", func() {
+		It("should record each command and its response to the cassette", func() {
+			record()
+			contents, err := ioutil.ReadFile(cassette)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(ContainSubstring(`{"method":"POST","path":"/session/some-id/url","request":{"url":"http://example.com"},"status":200,"response":{"value":null}}` + "\n"))
+			Expect(string(contents)).To(ContainSubstring(`{"method":"GET","path":"/session/some-id/title","status":200,"response":{"value":"first title"}}` + "\n"))
+			Expect(string(contents)).To(ContainSubstring(`"status":404,"response":{"value":{"error":"no such element","message":"some message"}}}` + "\n"))
+		})
+
+		It("should replace an existing cassette", func() {
+			Expect(ioutil.WriteFile(cassette, []byte("some old contents\n"), 0666)).To(Succeed())
+			record()
+			contents, err := ioutil.ReadFile(cassette)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).NotTo(ContainSubstring("some old contents"))
+		})
+	})
+
+	Describe(".WithReplay", func() {
+		BeforeEach(func() {
+			record()
+			server.Close()
+		})
+
+		It("should respond to commands using the recorded responses without a WebDriver", func() {
+			session, err := Connect("http://replay.example.com", nil, WithReplay(cassette))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.ID()).To(Equal("some-id"))
+			Expect(session.SetURL("http://example.com")).To(Succeed())
+			Expect(session.GetTitle()).To(Equal("first title"))
+			Expect(session.GetTitle()).To(Equal("second title"))
+			Expect(session.GetTitle()).To(Equal("second title"))
+			_, err = session.GetElement(Selector{Using: "css selector", Value: "#missing"})
+			Expect(IsNoSuchElement(err)).To(BeTrue())
+		})
+
+		It("should fail commands that were not recorded", func() {
+			session, err := Connect("http://replay.example.com", nil, WithReplay(cassette))
+			Expect(err).NotTo(HaveOccurred())
+			err = session.SetURL("http://example.com/other")
+			Expect(err).To(MatchError(ContainSubstring(`no recorded response for POST /session/some-id/url {"url":"http://example.com/other"}`)))
+		})
+
+		Context("when the cassette cannot be read", func() {
+			It("should return an error", func() {
+				_, err := NewHTTPClient(WithReplay(filepath.Join(directory, "missing.cassette")))
+				Expect(err).To(MatchError(ContainSubstring("failed to open cassette: open")))
+			})
+		})
+
+		Context("when the cassette is invalid", func() {
+			It("should return an error", func() {
+				Expect(ioutil.WriteFile(cassette, []byte("not json\n"), 0666)).To(Succeed())
+				_, err := NewHTTPClient(WithReplay(cassette))
+				Expect(err).To(MatchError(ContainSubstring("failed to open cassette: invalid cassette entry on line 1")))
+			})
+		})
+	})
+})
@@ -0,0 +1,35 @@
+// Package cdp provides typed commands for common domains of the Chrome
+// DevTools Protocol, which are sent through the ChromeDriver endpoint used by
+// api.Session.ExecuteCDP. Only Chrome supports these commands.
+//
+// Example:
+//    session := cdp.Session{page.Session()}
+//    session.Emulation().SetTimezoneOverride("Europe/Berlin")
+package cdp
+
+import "github.com/sclevine/agouti/api"
+
+// A Session sends DevTools Protocol commands to the browser of an api.Session.
+type Session struct {
+	*api.Session
+}
+
+// Network returns the commands of the Network domain.
+func (s Session) Network() Network {
+	return Network{s.Session}
+}
+
+// Emulation returns the commands of the Emulation domain.
+func (s Session) Emulation() Emulation {
+	return Emulation{s.Session}
+}
+
+// Page returns the commands of the Page domain.
+func (s Session) Page() Page {
+	return Page{s.Session}
+}
+
+// Runtime returns the commands of the Runtime domain.
+func (s Session) Runtime() Runtime {
+	return Runtime{s.Session}
+}
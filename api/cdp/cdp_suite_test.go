@@ -0,0 +1,13 @@
+package cdp_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCDP(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CDP Suite")
+}
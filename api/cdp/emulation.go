@@ -0,0 +1,88 @@
+package cdp
+
+import "github.com/sclevine/agouti/api"
+
+// Emulation provides the commands of the DevTools Emulation domain.
+// See: https://chromedevtools.github.io/devtools-protocol/tot/Emulation/
+type Emulation struct {
+	session *api.Session
+}
+
+// DeviceMetrics defines the screen of an emulated device.
+type DeviceMetrics struct {
+	// Width is the width of the viewport in CSS pixels
+	Width int `json:"width"`
+
+	// Height is the height of the viewport in CSS pixels
+	Height int `json:"height"`
+
+	// DeviceScaleFactor is the number of device pixels per CSS pixel, or 0 for the default
+	DeviceScaleFactor float64 `json:"deviceScaleFactor"`
+
+	// Mobile emulates a mobile device, including its viewport meta tag handling
+	Mobile bool `json:"mobile"`
+}
+
+// SetDeviceMetricsOverride emulates the screen of the provided device.
+func (e Emulation) SetDeviceMetricsOverride(metrics DeviceMetrics) error {
+	return e.session.ExecuteCDP("Emulation.setDeviceMetricsOverride", metrics, nil)
+}
+
+// ClearDeviceMetricsOverride stops emulating the screen of a device.
+func (e Emulation) ClearDeviceMetricsOverride() error {
+	return e.session.ExecuteCDP("Emulation.clearDeviceMetricsOverride", nil, nil)
+}
+
+// SetGeolocationOverride overrides the geolocation reported by the browser.
+// The latitude and longitude are in decimal degrees, and the accuracy is in
+// meters.
+func (e Emulation) SetGeolocationOverride(latitude, longitude, accuracy float64) error {
+	request := struct {
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+		Accuracy  float64 `json:"accuracy"`
+	}{latitude, longitude, accuracy}
+	return e.session.ExecuteCDP("Emulation.setGeolocationOverride", request, nil)
+}
+
+// ClearGeolocationOverride stops overriding the geolocation of the browser.
+func (e Emulation) ClearGeolocationOverride() error {
+	return e.session.ExecuteCDP("Emulation.clearGeolocationOverride", nil, nil)
+}
+
+// SetTimezoneOverride overrides the time zone of the browser with the
+// provided IANA time zone ID (ex. "America/New_York"). An empty ID restores
+// the default time zone.
+func (e Emulation) SetTimezoneOverride(timezoneID string) error {
+	request := struct {
+		TimezoneID string `json:"timezoneId"`
+	}{timezoneID}
+	return e.session.ExecuteCDP("Emulation.setTimezoneOverride", request, nil)
+}
+
+// SetLocaleOverride overrides the locale of the browser with the provided
+// ICU locale (ex. "en_US"). An empty locale restores the default locale.
+func (e Emulation) SetLocaleOverride(locale string) error {
+	request := struct {
+		Locale string `json:"locale,omitempty"`
+	}{locale}
+	return e.session.ExecuteCDP("Emulation.setLocaleOverride", request, nil)
+}
+
+// SetEmulatedMedia emulates the provided CSS media type (ex. "print"). An
+// empty media type restores the default media type.
+func (e Emulation) SetEmulatedMedia(media string) error {
+	request := struct {
+		Media string `json:"media"`
+	}{media}
+	return e.session.ExecuteCDP("Emulation.setEmulatedMedia", request, nil)
+}
+
+// SetCPUThrottlingRate slows down the CPU of the browser by the provided
+// factor (ex. 4 for four times slower). A rate of 1 disables throttling.
+func (e Emulation) SetCPUThrottlingRate(rate float64) error {
+	request := struct {
+		Rate float64 `json:"rate"`
+	}{rate}
+	return e.session.ExecuteCDP("Emulation.setCPUThrottlingRate", request, nil)
+}
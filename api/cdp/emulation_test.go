@@ -0,0 +1,102 @@
+package cdp_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/api"
+	. "github.com/sclevine/agouti/api/cdp"
+	"github.com/sclevine/agouti/api/internal/mocks"
+)
+
+var _ = Describe("Emulation", func() {
+	var (
+		bus       *mocks.Bus
+		emulation Emulation
+	)
+
+	BeforeEach(func() {
+		bus = &mocks.Bus{}
+		emulation = Session{&api.Session{Bus: bus}}.Emulation()
+	})
+
+	Describe("#SetDeviceMetricsOverride", func() {
+		It("should successfully send the device metrics", func() {
+			metrics := DeviceMetrics{Width: 375, Height: 667, DeviceScaleFactor: 2, Mobile: true}
+			Expect(emulation.SetDeviceMetricsOverride(metrics)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("goog/cdp/execute"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{
+				"cmd": "Emulation.setDeviceMetricsOverride",
+				"params": {"width": 375, "height": 667, "deviceScaleFactor": 2, "mobile": true}
+			}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(emulation.SetDeviceMetricsOverride(DeviceMetrics{})).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#ClearDeviceMetricsOverride", func() {
+		It("should successfully send the Emulation.clearDeviceMetricsOverride command", func() {
+			Expect(emulation.ClearDeviceMetricsOverride()).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Emulation.clearDeviceMetricsOverride", "params": {}}`))
+		})
+	})
+
+	Describe("#SetGeolocationOverride", func() {
+		It("should successfully send the geolocation", func() {
+			Expect(emulation.SetGeolocationOverride(1.5, -2.5, 10)).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{
+				"cmd": "Emulation.setGeolocationOverride",
+				"params": {"latitude": 1.5, "longitude": -2.5, "accuracy": 10}
+			}`))
+		})
+	})
+
+	Describe("#ClearGeolocationOverride", func() {
+		It("should successfully send the Emulation.clearGeolocationOverride command", func() {
+			Expect(emulation.ClearGeolocationOverride()).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Emulation.clearGeolocationOverride", "params": {}}`))
+		})
+	})
+
+	Describe("#SetTimezoneOverride", func() {
+		It("should successfully send the time zone ID", func() {
+			Expect(emulation.SetTimezoneOverride("Europe/Berlin")).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Emulation.setTimezoneOverride", "params": {"timezoneId": "Europe/Berlin"}}`))
+		})
+	})
+
+	Describe("#SetLocaleOverride", func() {
+		It("should successfully send the locale", func() {
+			Expect(emulation.SetLocaleOverride("de_DE")).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Emulation.setLocaleOverride", "params": {"locale": "de_DE"}}`))
+		})
+
+		Context("when the locale is empty", func() {
+			It("should omit the locale to restore the default locale", func() {
+				Expect(emulation.SetLocaleOverride("")).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Emulation.setLocaleOverride", "params": {}}`))
+			})
+		})
+	})
+
+	Describe("#SetEmulatedMedia", func() {
+		It("should successfully send the media type", func() {
+			Expect(emulation.SetEmulatedMedia("print")).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Emulation.setEmulatedMedia", "params": {"media": "print"}}`))
+		})
+	})
+
+	Describe("#SetCPUThrottlingRate", func() {
+		It("should successfully send the throttling rate", func() {
+			Expect(emulation.SetCPUThrottlingRate(4)).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Emulation.setCPUThrottlingRate", "params": {"rate": 4}}`))
+		})
+	})
+})
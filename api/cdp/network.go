@@ -0,0 +1,94 @@
+package cdp
+
+import (
+	"encoding/base64"
+
+	"github.com/sclevine/agouti/api"
+)
+
+// Network provides the commands of the DevTools Network domain.
+// See: https://chromedevtools.github.io/devtools-protocol/tot/Network/
+type Network struct {
+	session *api.Session
+}
+
+// Enable enables network tracking, which some Network commands require.
+func (n Network) Enable() error {
+	return n.session.ExecuteCDP("Network.enable", nil, nil)
+}
+
+// Disable disables network tracking.
+func (n Network) Disable() error {
+	return n.session.ExecuteCDP("Network.disable", nil, nil)
+}
+
+// SetExtraHTTPHeaders adds the provided headers to every request made by the
+// browser. Network tracking must be enabled.
+func (n Network) SetExtraHTTPHeaders(headers map[string]string) error {
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	request := struct {
+		Headers map[string]string `json:"headers"`
+	}{headers}
+	return n.session.ExecuteCDP("Network.setExtraHTTPHeaders", request, nil)
+}
+
+// SetUserAgentOverride replaces the user agent sent by the browser.
+func (n Network) SetUserAgentOverride(userAgent string) error {
+	request := struct {
+		UserAgent string `json:"userAgent"`
+	}{userAgent}
+	return n.session.ExecuteCDP("Network.setUserAgentOverride", request, nil)
+}
+
+// SetBlockedURLs blocks requests to URLs that match any of the provided
+// patterns, which may contain "*" wildcards. Network tracking must be enabled.
+func (n Network) SetBlockedURLs(patterns []string) error {
+	if patterns == nil {
+		patterns = []string{}
+	}
+	request := struct {
+		URLs []string `json:"urls"`
+	}{patterns}
+	return n.session.ExecuteCDP("Network.setBlockedURLs", request, nil)
+}
+
+// SetCacheDisabled disables or enables the browser cache.
+func (n Network) SetCacheDisabled(disabled bool) error {
+	request := struct {
+		CacheDisabled bool `json:"cacheDisabled"`
+	}{disabled}
+	return n.session.ExecuteCDP("Network.setCacheDisabled", request, nil)
+}
+
+// ClearBrowserCache clears the browser cache.
+func (n Network) ClearBrowserCache() error {
+	return n.session.ExecuteCDP("Network.clearBrowserCache", nil, nil)
+}
+
+// ClearBrowserCookies deletes the cookies of every domain.
+func (n Network) ClearBrowserCookies() error {
+	return n.session.ExecuteCDP("Network.clearBrowserCookies", nil, nil)
+}
+
+// GetResponseBody returns the body of the response to the request with the
+// provided DevTools request ID. Network tracking must be enabled.
+func (n Network) GetResponseBody(requestID string) ([]byte, error) {
+	request := struct {
+		RequestID string `json:"requestId"`
+	}{requestID}
+
+	var result struct {
+		Body          string `json:"body"`
+		Base64Encoded bool   `json:"base64Encoded"`
+	}
+	if err := n.session.ExecuteCDP("Network.getResponseBody", request, &result); err != nil {
+		return nil, err
+	}
+
+	if !result.Base64Encoded {
+		return []byte(result.Body), nil
+	}
+	return base64.StdEncoding.DecodeString(result.Body)
+}
@@ -0,0 +1,136 @@
+package cdp_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/api"
+	. "github.com/sclevine/agouti/api/cdp"
+	"github.com/sclevine/agouti/api/internal/mocks"
+)
+
+var _ = Describe("Network", func() {
+	var (
+		bus     *mocks.Bus
+		network Network
+	)
+
+	BeforeEach(func() {
+		bus = &mocks.Bus{}
+		network = Session{&api.Session{Bus: bus}}.Network()
+	})
+
+	Describe("#Enable", func() {
+		It("should successfully send the Network.enable command", func() {
+			Expect(network.Enable()).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("goog/cdp/execute"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Network.enable", "params": {}}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(network.Enable()).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#Disable", func() {
+		It("should successfully send the Network.disable command", func() {
+			Expect(network.Disable()).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Network.disable", "params": {}}`))
+		})
+	})
+
+	Describe("#SetExtraHTTPHeaders", func() {
+		It("should successfully send the headers", func() {
+			Expect(network.SetExtraHTTPHeaders(map[string]string{"Some-Header": "some value"})).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{
+				"cmd": "Network.setExtraHTTPHeaders",
+				"params": {"headers": {"Some-Header": "some value"}}
+			}`))
+		})
+
+		Context("when the headers are nil", func() {
+			It("should send empty headers", func() {
+				Expect(network.SetExtraHTTPHeaders(nil)).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Network.setExtraHTTPHeaders", "params": {"headers": {}}}`))
+			})
+		})
+	})
+
+	Describe("#SetUserAgentOverride", func() {
+		It("should successfully send the user agent", func() {
+			Expect(network.SetUserAgentOverride("some agent")).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Network.setUserAgentOverride", "params": {"userAgent": "some agent"}}`))
+		})
+	})
+
+	Describe("#SetBlockedURLs", func() {
+		It("should successfully send the URL patterns", func() {
+			Expect(network.SetBlockedURLs([]string{"*.png"})).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Network.setBlockedURLs", "params": {"urls": ["*.png"]}}`))
+		})
+
+		Context("when the URL patterns are nil", func() {
+			It("should unblock all URLs", func() {
+				Expect(network.SetBlockedURLs(nil)).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Network.setBlockedURLs", "params": {"urls": []}}`))
+			})
+		})
+	})
+
+	Describe("#SetCacheDisabled", func() {
+		It("should successfully send whether the cache is disabled", func() {
+			Expect(network.SetCacheDisabled(true)).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Network.setCacheDisabled", "params": {"cacheDisabled": true}}`))
+		})
+	})
+
+	Describe("#ClearBrowserCache", func() {
+		It("should successfully send the Network.clearBrowserCache command", func() {
+			Expect(network.ClearBrowserCache()).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Network.clearBrowserCache", "params": {}}`))
+		})
+	})
+
+	Describe("#ClearBrowserCookies", func() {
+		It("should successfully send the Network.clearBrowserCookies command", func() {
+			Expect(network.ClearBrowserCookies()).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Network.clearBrowserCookies", "params": {}}`))
+		})
+	})
+
+	Describe("#GetResponseBody", func() {
+		It("should successfully request the body of the provided request", func() {
+			bus.SendCall.Result = `{"body": "some body", "base64Encoded": false}`
+			Expect(network.GetResponseBody("some-id")).To(Equal([]byte("some body")))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Network.getResponseBody", "params": {"requestId": "some-id"}}`))
+		})
+
+		Context("when the body is base64-encoded", func() {
+			It("should return the decoded body", func() {
+				bus.SendCall.Result = `{"body": "c29tZSBib2R5", "base64Encoded": true}`
+				Expect(network.GetResponseBody("some-id")).To(Equal([]byte("some body")))
+			})
+
+			Context("when the body is not valid base64", func() {
+				It("should return an error", func() {
+					bus.SendCall.Result = `{"body": "%%%", "base64Encoded": true}`
+					_, err := network.GetResponseBody("some-id")
+					Expect(err).To(MatchError("illegal base64 data at input byte 0"))
+				})
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := network.GetResponseBody("some-id")
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,91 @@
+package cdp
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"github.com/sclevine/agouti/api"
+)
+
+// Page provides the commands of the DevTools Page domain.
+// See: https://chromedevtools.github.io/devtools-protocol/tot/Page/
+type Page struct {
+	session *api.Session
+}
+
+// Navigate navigates the top-level frame to the provided URL.
+func (p Page) Navigate(url string) error {
+	request := struct {
+		URL string `json:"url"`
+	}{url}
+
+	var result struct {
+		ErrorText string `json:"errorText"`
+	}
+	if err := p.session.ExecuteCDP("Page.navigate", request, &result); err != nil {
+		return err
+	}
+
+	if result.ErrorText != "" {
+		return errors.New(result.ErrorText)
+	}
+	return nil
+}
+
+// Reload reloads the page. If ignoreCache is true, the browser cache is not used.
+func (p Page) Reload(ignoreCache bool) error {
+	request := struct {
+		IgnoreCache bool `json:"ignoreCache"`
+	}{ignoreCache}
+	return p.session.ExecuteCDP("Page.reload", request, nil)
+}
+
+// CaptureScreenshot returns a screenshot of the viewport in the provided image
+// format ("png", "jpeg", or "webp").
+func (p Page) CaptureScreenshot(format string) ([]byte, error) {
+	request := struct {
+		Format string `json:"format"`
+	}{format}
+	return p.data("Page.captureScreenshot", request)
+}
+
+// PrintToPDF returns the page printed as a PDF document.
+func (p Page) PrintToPDF() ([]byte, error) {
+	return p.data("Page.printToPDF", nil)
+}
+
+// AddScriptToEvaluateOnNewDocument runs the provided JavaScript source in
+// every frame before the scripts of the frame are run, and returns an
+// identifier that may be used to remove it.
+func (p Page) AddScriptToEvaluateOnNewDocument(source string) (string, error) {
+	request := struct {
+		Source string `json:"source"`
+	}{source}
+
+	var result struct {
+		Identifier string `json:"identifier"`
+	}
+	if err := p.session.ExecuteCDP("Page.addScriptToEvaluateOnNewDocument", request, &result); err != nil {
+		return "", err
+	}
+	return result.Identifier, nil
+}
+
+// RemoveScriptToEvaluateOnNewDocument removes a script added by
+// AddScriptToEvaluateOnNewDocument.
+func (p Page) RemoveScriptToEvaluateOnNewDocument(identifier string) error {
+	request := struct {
+		Identifier string `json:"identifier"`
+	}{identifier}
+	return p.session.ExecuteCDP("Page.removeScriptToEvaluateOnNewDocument", request, nil)
+}
+
+func (p Page) data(command string, parameters interface{}) ([]byte, error) {
+	var result struct {
+		Data string `json:"data"`
+	}
+	if err := p.session.ExecuteCDP(command, parameters, &result); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(result.Data)
+}
@@ -0,0 +1,109 @@
+package cdp_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/api"
+	. "github.com/sclevine/agouti/api/cdp"
+	"github.com/sclevine/agouti/api/internal/mocks"
+)
+
+var _ = Describe("Page", func() {
+	var (
+		bus  *mocks.Bus
+		page Page
+	)
+
+	BeforeEach(func() {
+		bus = &mocks.Bus{}
+		page = Session{&api.Session{Bus: bus}}.Page()
+	})
+
+	Describe("#Navigate", func() {
+		It("should successfully send the URL", func() {
+			bus.SendCall.Result = `{"frameId": "some-frame"}`
+			Expect(page.Navigate("http://example.com")).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("goog/cdp/execute"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Page.navigate", "params": {"url": "http://example.com"}}`))
+		})
+
+		Context("when the navigation fails", func() {
+			It("should return the navigation error", func() {
+				bus.SendCall.Result = `{"frameId": "some-frame", "errorText": "net::ERR_NAME_NOT_RESOLVED"}`
+				Expect(page.Navigate("http://example.invalid")).To(MatchError("net::ERR_NAME_NOT_RESOLVED"))
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(page.Navigate("http://example.com")).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#Reload", func() {
+		It("should successfully send whether to ignore the cache", func() {
+			Expect(page.Reload(true)).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Page.reload", "params": {"ignoreCache": true}}`))
+		})
+	})
+
+	Describe("#CaptureScreenshot", func() {
+		It("should successfully return the decoded screenshot", func() {
+			bus.SendCall.Result = `{"data": "c29tZSBpbWFnZQ=="}`
+			Expect(page.CaptureScreenshot("png")).To(Equal([]byte("some image")))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Page.captureScreenshot", "params": {"format": "png"}}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := page.CaptureScreenshot("png")
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#PrintToPDF", func() {
+		It("should successfully return the decoded PDF document", func() {
+			bus.SendCall.Result = `{"data": "c29tZSBwZGY="}`
+			Expect(page.PrintToPDF()).To(Equal([]byte("some pdf")))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Page.printToPDF", "params": {}}`))
+		})
+
+		Context("when the document is not valid base64", func() {
+			It("should return an error", func() {
+				bus.SendCall.Result = `{"data": "%%%"}`
+				_, err := page.PrintToPDF()
+				Expect(err).To(MatchError("illegal base64 data at input byte 0"))
+			})
+		})
+	})
+
+	Describe("#AddScriptToEvaluateOnNewDocument", func() {
+		It("should successfully send the script and return its identifier", func() {
+			bus.SendCall.Result = `{"identifier": "1"}`
+			Expect(page.AddScriptToEvaluateOnNewDocument("some script")).To(Equal("1"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Page.addScriptToEvaluateOnNewDocument", "params": {"source": "some script"}}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := page.AddScriptToEvaluateOnNewDocument("some script")
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#RemoveScriptToEvaluateOnNewDocument", func() {
+		It("should successfully send the identifier", func() {
+			Expect(page.RemoveScriptToEvaluateOnNewDocument("1")).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Page.removeScriptToEvaluateOnNewDocument", "params": {"identifier": "1"}}`))
+		})
+	})
+})
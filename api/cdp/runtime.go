@@ -0,0 +1,54 @@
+package cdp
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sclevine/agouti/api"
+)
+
+// Runtime provides the commands of the DevTools Runtime domain.
+// See: https://chromedevtools.github.io/devtools-protocol/tot/Runtime/
+type Runtime struct {
+	session *api.Session
+}
+
+// Evaluate evaluates the provided JavaScript expression in the top-level
+// frame and decodes its value into the provided result, which may be nil.
+// If the expression returns a promise, Evaluate waits for it to settle.
+// Exceptions thrown by the expression are returned as errors.
+func (r Runtime) Evaluate(expression string, result interface{}) error {
+	request := struct {
+		Expression    string `json:"expression"`
+		ReturnByValue bool   `json:"returnByValue"`
+		AwaitPromise  bool   `json:"awaitPromise"`
+	}{expression, true, true}
+
+	var response struct {
+		Result struct {
+			Value json.RawMessage `json:"value"`
+		} `json:"result"`
+		ExceptionDetails *struct {
+			Text      string `json:"text"`
+			Exception struct {
+				Description string `json:"description"`
+			} `json:"exception"`
+		} `json:"exceptionDetails"`
+	}
+	if err := r.session.ExecuteCDP("Runtime.evaluate", request, &response); err != nil {
+		return err
+	}
+
+	if details := response.ExceptionDetails; details != nil {
+		message := details.Exception.Description
+		if message == "" {
+			message = details.Text
+		}
+		return fmt.Errorf("javascript exception: %s", message)
+	}
+
+	if result == nil || len(response.Result.Value) == 0 {
+		return nil
+	}
+	return json.Unmarshal(response.Result.Value, result)
+}
@@ -0,0 +1,75 @@
+package cdp_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/api"
+	. "github.com/sclevine/agouti/api/cdp"
+	"github.com/sclevine/agouti/api/internal/mocks"
+)
+
+var _ = Describe("Runtime", func() {
+	var (
+		bus     *mocks.Bus
+		runtime Runtime
+	)
+
+	BeforeEach(func() {
+		bus = &mocks.Bus{}
+		runtime = Session{&api.Session{Bus: bus}}.Runtime()
+	})
+
+	Describe("#Evaluate", func() {
+		It("should successfully evaluate the expression by value", func() {
+			Expect(runtime.Evaluate("some expression", nil)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("goog/cdp/execute"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{
+				"cmd": "Runtime.evaluate",
+				"params": {"expression": "some expression", "returnByValue": true, "awaitPromise": true}
+			}`))
+		})
+
+		It("should fill the provided result interface", func() {
+			var result struct{ Some string }
+			bus.SendCall.Result = `{"result": {"type": "object", "value": {"some": "result"}}}`
+			Expect(runtime.Evaluate("some expression", &result)).To(Succeed())
+			Expect(result.Some).To(Equal("result"))
+		})
+
+		Context("when the expression returns undefined", func() {
+			It("should leave the result unchanged", func() {
+				result := "some result"
+				bus.SendCall.Result = `{"result": {"type": "undefined"}}`
+				Expect(runtime.Evaluate("some expression", &result)).To(Succeed())
+				Expect(result).To(Equal("some result"))
+			})
+		})
+
+		Context("when the expression throws an exception", func() {
+			It("should return the description of the exception", func() {
+				bus.SendCall.Result = `{
+					"result": {"type": "object"},
+					"exceptionDetails": {"text": "Uncaught", "exception": {"description": "Error: some error"}}
+				}`
+				Expect(runtime.Evaluate("some expression", nil)).To(MatchError("javascript exception: Error: some error"))
+			})
+
+			Context("when the exception has no description", func() {
+				It("should return the text of the exception", func() {
+					bus.SendCall.Result = `{"result": {}, "exceptionDetails": {"text": "Uncaught"}}`
+					Expect(runtime.Evaluate("some expression", nil)).To(MatchError("javascript exception: Uncaught"))
+				})
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(runtime.Evaluate("some expression", nil)).To(MatchError("some error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// A ConnectOption configures how WebDriver commands are sent. ConnectOptions
+// may be provided to Connect or NewHTTPClient.
+type ConnectOption func(*connectConfig)
+
+type connectConfig struct {
+	client       *http.Client
+	timeout      *time.Duration
+	tlsConfig    *tls.Config
+	proxyURL     *url.URL
+	keepAlive    *bool
+	maxIdle      int
+	tcpKeepAlive *time.Duration
+	http2        *bool
+	hasTransport bool
+	hooks        []CommandHook
+	decoding     Decoding
+	cassette     func(http.RoundTripper) (http.RoundTripper, error)
+}
+
+func newConnectConfig(options []ConnectOption) *connectConfig {
+	config := &connectConfig{}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// WithHTTPClient provides a ConnectOption for specifying the *http.Client
+// that the other ConnectOptions are applied to. The provided client is not
+// modified. By default, a client based on http.DefaultTransport is used.
+func WithHTTPClient(client *http.Client) ConnectOption {
+	return func(c *connectConfig) {
+		c.client = client
+	}
+}
+
+// WithTimeout provides a ConnectOption for specifying the time limit of
+// each WebDriver request, including reading the response. A timeout of zero
+// means no timeout.
+func WithTimeout(timeout time.Duration) ConnectOption {
+	return func(c *connectConfig) {
+		c.timeout = &timeout
+	}
+}
+
+// WithTLSConfig provides a ConnectOption for specifying the TLS configuration
+// used to connect to an HTTPS WebDriver, ex. one that trusts the self-signed
+// certificate of a Selenium Grid.
+func WithTLSConfig(config *tls.Config) ConnectOption {
+	return func(c *connectConfig) {
+		c.tlsConfig = config
+		c.hasTransport = true
+	}
+}
+
+// WithProxyURL provides a ConnectOption for specifying the HTTP proxy that
+// WebDriver requests are sent through. By default, the proxy is determined
+// by the HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables.
+func WithProxyURL(proxyURL *url.URL) ConnectOption {
+	return func(c *connectConfig) {
+		c.proxyURL = proxyURL
+		c.hasTransport = true
+	}
+}
+
+// WithKeepAlive provides a ConnectOption for specifying whether connections
+// to the WebDriver are reused between requests. Connections are reused by
+// default.
+func WithKeepAlive(enabled bool) ConnectOption {
+	return func(c *connectConfig) {
+		c.keepAlive = &enabled
+		c.hasTransport = true
+	}
+}
+
+// WithMaxIdleConnsPerHost provides a ConnectOption for specifying the number
+// of idle connections to the WebDriver that are kept for reuse. The default
+// of two connections causes new connections to be opened when more than two
+// sessions send commands concurrently.
+func WithMaxIdleConnsPerHost(count int) ConnectOption {
+	return func(c *connectConfig) {
+		c.maxIdle = count
+		c.hasTransport = true
+	}
+}
+
+// WithTCPKeepAlive provides a ConnectOption for specifying the interval
+// between TCP keep-alive probes, which prevent idle connections from being
+// closed by NAT gateways and load balancers between agouti and a remote
+// Selenium Grid. A negative interval disables the probes. The default
+// interval is 30 seconds.
+func WithTCPKeepAlive(interval time.Duration) ConnectOption {
+	return func(c *connectConfig) {
+		c.tcpKeepAlive = &interval
+		c.hasTransport = true
+	}
+}
+
+// WithHTTP2 provides a ConnectOption for specifying whether HTTP/2 is used
+// for HTTPS WebDrivers that support it, so that all commands share a single
+// connection. HTTP/2 is attempted by default, except for clients with a
+// custom TLS configuration. Plain HTTP WebDrivers always use HTTP/1.1.
+func WithHTTP2(enabled bool) ConnectOption {
+	return func(c *connectConfig) {
+		c.http2 = &enabled
+		c.hasTransport = true
+	}
+}
+
+// NewHTTPClient returns an *http.Client configured by the provided
+// ConnectOptions. The client may be provided to OpenWithClient,
+// WebDriver.HTTPClient, or the agouti.HTTPClient Option. The TLS, proxy,
+// keep-alive, and other connection ConnectOptions require the transport of
+// the client provided by WithHTTPClient, if any, to be an *http.Transport.
+//
+// Sessions that are opened with the same client share its connections, so a
+// single client should be created for all sessions that connect to the same
+// Selenium Grid, especially when the grid is far away. See
+// Session.ConnectionStats for measuring how often connections are reused.
+//
+// Example:
+//    proxyURL, _ := url.Parse("http://proxy.example.com:3128")
+//    client, err := api.NewHTTPClient(api.WithProxyURL(proxyURL), api.WithTimeout(30*time.Second))
+func NewHTTPClient(options ...ConnectOption) (*http.Client, error) {
+	config := newConnectConfig(options)
+
+	client := &http.Client{}
+	if config.client != nil {
+		*client = *config.client
+	}
+	if config.timeout != nil {
+		client.Timeout = *config.timeout
+	}
+	if !config.hasTransport {
+		return config.applyCassette(client)
+	}
+
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	httpTransport, ok := transport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("TLS, proxy, keep-alive, and connection options require an *http.Transport")
+	}
+	httpTransport = httpTransport.Clone()
+
+	if config.tlsConfig != nil {
+		httpTransport.TLSClientConfig = config.tlsConfig
+	}
+	if config.proxyURL != nil {
+		httpTransport.Proxy = http.ProxyURL(config.proxyURL)
+	}
+	if config.keepAlive != nil {
+		httpTransport.DisableKeepAlives = !*config.keepAlive
+	}
+	if config.maxIdle > 0 {
+		httpTransport.MaxIdleConnsPerHost = config.maxIdle
+		if httpTransport.MaxIdleConns != 0 && httpTransport.MaxIdleConns < config.maxIdle {
+			httpTransport.MaxIdleConns = config.maxIdle
+		}
+	}
+	if config.tcpKeepAlive != nil {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: *config.tcpKeepAlive}
+		httpTransport.DialContext = dialer.DialContext
+	}
+	if config.http2 != nil {
+		httpTransport.ForceAttemptHTTP2 = *config.http2
+		if !*config.http2 {
+			httpTransport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		}
+	}
+	client.Transport = httpTransport
+	return config.applyCassette(client)
+}
+
+func (c *connectConfig) applyCassette(client *http.Client) (*http.Client, error) {
+	if c.cassette == nil {
+		return client, nil
+	}
+	transport, err := c.cassette(client.Transport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cassette: %s", err)
+	}
+	client.Transport = transport
+	return client, nil
+}
+
+// Connect opens a session like OpenWithClient, using an *http.Client
+// configured by the provided ConnectOptions.
+//
+// Example:
+//    session, err := api.Connect("https://grid.example.com/wd/hub", capabilities,
+//        api.WithTLSConfig(&tls.Config{RootCAs: gridCertificates}),
+//        api.WithTimeout(time.Minute))
+func Connect(url string, capabilities map[string]interface{}, options ...ConnectOption) (*Session, error) {
+	client, err := NewHTTPClient(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := OpenWithClient(url, capabilities, client)
+	if err != nil {
+		return nil, err
+	}
+
+	config := newConnectConfig(options)
+	for _, hook := range config.hooks {
+		session.AddCommandHook(hook)
+	}
+	if config.decoding != (Decoding{}) {
+		session.SetDecoding(config.decoding)
+	}
+	return session, nil
+}
@@ -0,0 +1,167 @@
+package api_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+)
+
+var _ = Describe("Connect Options", func() {
+	var (
+		handler      http.HandlerFunc
+		sessionHosts []string
+	)
+
+	BeforeEach(func() {
+		sessionHosts = nil
+		handler = func(response http.ResponseWriter, request *http.Request) {
+			sessionHosts = append(sessionHosts, request.Host)
+			response.Write([]byte(`{"value": {"sessionId": "some-id", "capabilities": {}}}`))
+		}
+	})
+
+	Describe(".NewHTTPClient", func() {
+		It("should return a new client with the default transport when no options are provided", func() {
+			client, err := NewHTTPClient()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client).NotTo(BeIdenticalTo(http.DefaultClient))
+			Expect(client.Transport).To(BeNil())
+		})
+
+		It("should apply the options to a copy of the provided client", func() {
+			jar := &fakeJar{}
+			baseClient := &http.Client{Jar: jar, Timeout: time.Second}
+			client, err := NewHTTPClient(WithHTTPClient(baseClient), WithTimeout(time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.Jar).To(BeIdenticalTo(jar))
+			Expect(client.Timeout).To(Equal(time.Minute))
+			Expect(baseClient.Timeout).To(Equal(time.Second))
+		})
+
+		It("should configure a copy of the transport with the TLS, proxy, and keep-alive options", func() {
+			baseTransport := &http.Transport{MaxIdleConns: 7}
+			tlsConfig := &tls.Config{ServerName: "some-name"}
+			proxyURL, _ := url.Parse("http://proxy.example.com:3128")
+			client, err := NewHTTPClient(
+				WithHTTPClient(&http.Client{Transport: baseTransport}),
+				WithTLSConfig(tlsConfig),
+				WithProxyURL(proxyURL),
+				WithKeepAlive(false),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			transport := client.Transport.(*http.Transport)
+			Expect(transport).NotTo(BeIdenticalTo(baseTransport))
+			Expect(transport.MaxIdleConns).To(Equal(7))
+			Expect(transport.TLSClientConfig).To(BeIdenticalTo(tlsConfig))
+			Expect(transport.DisableKeepAlives).To(BeTrue())
+			Expect(baseTransport.DisableKeepAlives).To(BeFalse())
+
+			request, _ := http.NewRequest("GET", "http://webdriver.example.com", nil)
+			Expect(transport.Proxy(request)).To(Equal(proxyURL))
+		})
+
+		It("should configure a copy of the transport with the connection options", func() {
+			baseTransport := &http.Transport{MaxIdleConns: 4}
+			client, err := NewHTTPClient(
+				WithHTTPClient(&http.Client{Transport: baseTransport}),
+				WithMaxIdleConnsPerHost(16),
+				WithTCPKeepAlive(time.Minute),
+				WithHTTP2(false),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			transport := client.Transport.(*http.Transport)
+			Expect(transport.MaxIdleConnsPerHost).To(Equal(16))
+			Expect(transport.MaxIdleConns).To(Equal(16))
+			Expect(transport.DialContext).NotTo(BeNil())
+			Expect(transport.ForceAttemptHTTP2).To(BeFalse())
+			Expect(transport.TLSNextProto).To(BeEmpty())
+			Expect(transport.TLSNextProto).NotTo(BeNil())
+			Expect(baseTransport.MaxIdleConnsPerHost).To(BeZero())
+			Expect(baseTransport.DialContext).To(BeNil())
+		})
+
+		It("should enable HTTP/2 when requested", func() {
+			client, err := NewHTTPClient(WithHTTPClient(&http.Client{Transport: &http.Transport{}}), WithHTTP2(true))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.Transport.(*http.Transport).ForceAttemptHTTP2).To(BeTrue())
+		})
+
+		Context("when transport options are provided with a client that does not use an *http.Transport", func() {
+			It("should return an error", func() {
+				baseClient := &http.Client{Transport: http.NewFileTransport(http.Dir("."))}
+				_, err := NewHTTPClient(WithHTTPClient(baseClient), WithKeepAlive(false))
+				Expect(err).To(MatchError("TLS, proxy, keep-alive, and connection options require an *http.Transport"))
+			})
+		})
+	})
+
+	Describe(".Connect", func() {
+		It("should open a session using the configured client", func() {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			session, err := Connect(server.URL, nil, WithKeepAlive(false))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.ID()).To(Equal("some-id"))
+			Expect(session.W3C).To(BeTrue())
+		})
+
+		It("should send requests through the provided proxy", func() {
+			proxy := httptest.NewServer(handler)
+			defer proxy.Close()
+			proxyURL, _ := url.Parse(proxy.URL)
+
+			_, err := Connect("http://webdriver.example.com:4444", nil, WithProxyURL(proxyURL))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sessionHosts).To(Equal([]string{"webdriver.example.com:4444"}))
+		})
+
+		It("should connect to a WebDriver with a certificate trusted by the provided TLS configuration", func() {
+			server := httptest.NewTLSServer(handler)
+			defer server.Close()
+
+			_, err := Connect(server.URL, nil)
+			Expect(err).To(HaveOccurred())
+
+			certificates := x509.NewCertPool()
+			certificates.AddCert(server.Certificate())
+			_, err = Connect(server.URL, nil, WithTLSConfig(&tls.Config{RootCAs: certificates}))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should fail requests that exceed the provided timeout", func() {
+			done := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+				<-done
+			}))
+			defer server.Close()
+			defer close(done)
+
+			_, err := Connect(server.URL, nil, WithTimeout(10*time.Millisecond))
+			Expect(err).To(MatchError(ContainSubstring("Client.Timeout exceeded")))
+		})
+
+		Context("when the options cannot be applied", func() {
+			It("should return an error", func() {
+				baseClient := &http.Client{Transport: http.NewFileTransport(http.Dir("."))}
+				_, err := Connect("http://webdriver.example.com", nil, WithHTTPClient(baseClient), WithKeepAlive(false))
+				Expect(err).To(MatchError("TLS, proxy, keep-alive, and connection options require an *http.Transport"))
+			})
+		})
+	})
+})
+
+type fakeJar struct{}
+
+func (*fakeJar) SetCookies(*url.URL, []*http.Cookie) {}
+
+func (*fakeJar) Cookies(*url.URL) []*http.Cookie { return nil }
@@ -0,0 +1,49 @@
+package api
+
+import "context"
+
+// A ContextBus is a Bus that can cancel commands using a context.Context.
+type ContextBus interface {
+	Bus
+	SendContext(ctx context.Context, method, endpoint string, body, result interface{}) error
+}
+
+// WithContext returns a copy of the session that sends every command using
+// the provided context. When the context is canceled or its deadline passes,
+// any in-flight command is aborted and returns an error.
+//
+// If the session's Bus is not a ContextBus, the context is only checked
+// before each command is sent. The copy shares the state of the session,
+// such as its BiDi connection, so deleting either deletes both.
+func (s *Session) WithContext(ctx context.Context) *Session {
+	if ctx == nil {
+		panic("nil context")
+	}
+	return &Session{
+		Bus:          &contextBus{s.Bus, ctx},
+		W3C:          s.W3C,
+		WebSocketURL: s.WebSocketURL,
+		Capabilities: s.Capabilities,
+		state:        s.shared(),
+	}
+}
+
+type contextBus struct {
+	bus Bus
+	ctx context.Context
+}
+
+func (c *contextBus) Send(method, endpoint string, body, result interface{}) error {
+	return c.SendContext(c.ctx, method, endpoint, body, result)
+}
+
+func (c *contextBus) SendContext(ctx context.Context, method, endpoint string, body, result interface{}) error {
+	if contextBus, ok := c.bus.(ContextBus); ok {
+		return contextBus.SendContext(ctx, method, endpoint, body, result)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.bus.Send(method, endpoint, body, result)
+}
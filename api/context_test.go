@@ -0,0 +1,129 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/mocks"
+)
+
+type simpleBus struct {
+	called bool
+}
+
+func (b *simpleBus) Send(method, endpoint string, body, result interface{}) error {
+	b.called = true
+	return nil
+}
+
+var _ = Describe("Context", func() {
+	var (
+		bus     *mocks.Bus
+		session *Session
+		ctx     context.Context
+		cancel  context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		bus = &mocks.Bus{}
+		session = &Session{Bus: bus, W3C: true}
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	Describe("#WithContext", func() {
+		It("should return a session that sends commands using the provided context", func() {
+			Expect(session.WithContext(ctx).SetURL("some-url")).To(Succeed())
+			Expect(bus.SendCall.Context).To(Equal(ctx))
+			Expect(bus.SendCall.Endpoint).To(Equal("url"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"url": "some-url"}`))
+		})
+
+		It("should preserve the dialect of the session", func() {
+			Expect(session.WithContext(ctx).W3C).To(BeTrue())
+		})
+
+		It("should preserve the WebDriver BiDi URL of the session", func() {
+			session.WebSocketURL = "ws://some-url"
+			Expect(session.WithContext(ctx).WebSocketURL).To(Equal("ws://some-url"))
+		})
+
+		It("should preserve the capabilities of the session", func() {
+			session.Capabilities = map[string]interface{}{"browserName": "some-browser"}
+			Expect(session.WithContext(ctx).Capabilities).To(Equal(session.Capabilities))
+		})
+
+		It("should share the state of the session", func() {
+			bus.SendCall.Err = &Error{StatusCode: 404, Code: ErrorUnknownCommand}
+			_, err := session.WithContext(ctx).UploadFile("context_test.go")
+			Expect(IsUnknownCommand(err)).To(BeTrue())
+			bus.SendCall.Err = nil
+			bus.SendCall.Endpoint = ""
+			_, err = session.UploadFile("context_test.go")
+			Expect(IsUnknownCommand(err)).To(BeTrue())
+			Expect(bus.SendCall.Endpoint).To(BeEmpty())
+		})
+
+		It("should return elements that send commands using the provided context", func() {
+			bus.SendCall.Result = `{"ELEMENT": "some-id"}`
+			element, err := session.WithContext(ctx).GetElement(Selector{"css selector", "#selector"})
+			Expect(err).NotTo(HaveOccurred())
+			bus.SendCall.Context = nil
+			Expect(element.Click()).To(Succeed())
+			Expect(bus.SendCall.Context).To(Equal(ctx))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.WithContext(ctx).Refresh()).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the bus does not support contexts", func() {
+			var simple *simpleBus
+
+			BeforeEach(func() {
+				simple = &simpleBus{}
+				session = &Session{Bus: simple}
+			})
+
+			It("should send commands while the context is active", func() {
+				Expect(session.WithContext(ctx).Refresh()).To(Succeed())
+				Expect(simple.called).To(BeTrue())
+			})
+
+			It("should not send commands after the context is canceled", func() {
+				cancel()
+				Expect(session.WithContext(ctx).Refresh()).To(MatchError(context.Canceled))
+				Expect(simple.called).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("#SetURLContext", func() {
+		It("should successfully send a POST to the url endpoint using the provided context", func() {
+			Expect(session.SetURLContext(ctx, "some-url")).To(Succeed())
+			Expect(bus.SendCall.Context).To(Equal(ctx))
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("url"))
+		})
+	})
+
+	Describe("#ExecuteContext", func() {
+		It("should successfully execute the script using the provided context", func() {
+			var result string
+			bus.SendCall.Result = `"some result"`
+			Expect(session.ExecuteContext(ctx, "some javascript code", nil, &result)).To(Succeed())
+			Expect(bus.SendCall.Context).To(Equal(ctx))
+			Expect(bus.SendCall.Endpoint).To(Equal("execute/sync"))
+			Expect(result).To(Equal("some result"))
+		})
+	})
+})
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SameSite values of a Cookie.
+const (
+	SameSiteStrict = "Strict"
+	SameSiteLax    = "Lax"
+	SameSiteNone   = "None"
+)
+
+type cookieJSON Cookie
+
+// MarshalJSON encodes the cookie as a WebDriver cookie, with the expiry in
+// whole seconds since the epoch.
+func (c Cookie) MarshalJSON() ([]byte, error) {
+	var expiry int64
+	if !c.Expiry.IsZero() {
+		expiry = c.Expiry.Unix()
+	}
+	return json.Marshal(struct {
+		cookieJSON
+		Expiry int64 `json:"expiry,omitempty"`
+	}{cookieJSON(c), expiry})
+}
+
+// UnmarshalJSON decodes a WebDriver cookie. Some WebDrivers provide the
+// expiry with fractional seconds.
+func (c *Cookie) UnmarshalJSON(data []byte) error {
+	var cookie struct {
+		cookieJSON
+		Expiry float64 `json:"expiry"`
+	}
+	if err := json.Unmarshal(data, &cookie); err != nil {
+		return err
+	}
+
+	*c = Cookie(cookie.cookieJSON)
+	if cookie.Expiry != 0 {
+		seconds, fraction := math.Modf(cookie.Expiry)
+		c.Expiry = time.Unix(int64(seconds), int64(fraction*float64(time.Second)))
+	}
+	return nil
+}
+
+// Validate returns an error if the cookie would be rejected by the browser
+// because its path, domain, or SameSite value is malformed, or because it
+// has a SameSite value of "None" without being secure.
+func (c *Cookie) Validate() error {
+	if c.Path != "" && !strings.HasPrefix(c.Path, "/") {
+		return errors.New("invalid cookie: path must begin with /")
+	}
+	if strings.ContainsAny(c.Domain, "/:?#@ \t") {
+		return errors.New("invalid cookie: domain must be a host name without a scheme, port, or path")
+	}
+	switch c.SameSite {
+	case "", SameSiteStrict, SameSiteLax:
+	case SameSiteNone:
+		if !c.Secure {
+			return errors.New("invalid cookie: SameSite=None requires a secure cookie")
+		}
+	default:
+		return errors.New(`invalid cookie: SameSite must be "Strict", "Lax", or "None"`)
+	}
+	return nil
+}
+
+var httpSameSite = map[string]http.SameSite{
+	SameSiteStrict: http.SameSiteStrictMode,
+	SameSiteLax:    http.SameSiteLaxMode,
+	SameSiteNone:   http.SameSiteNoneMode,
+}
+
+// NewCookie converts a *http.Cookie into a Cookie. Cookies without an
+// expiration time are session cookies.
+func NewCookie(cookie *http.Cookie) *Cookie {
+	apiCookie := &Cookie{
+		Name:     cookie.Name,
+		Value:    cookie.Value,
+		Path:     cookie.Path,
+		Domain:   cookie.Domain,
+		Secure:   cookie.Secure,
+		HTTPOnly: cookie.HttpOnly,
+		Expiry:   cookie.Expires,
+	}
+	for sameSite, mode := range httpSameSite {
+		if cookie.SameSite == mode {
+			apiCookie.SameSite = sameSite
+		}
+	}
+	return apiCookie
+}
+
+// HTTPCookie converts the cookie into a *http.Cookie. Session cookies have no
+// expiration time.
+func (c *Cookie) HTTPCookie() *http.Cookie {
+	return &http.Cookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Path:     c.Path,
+		Domain:   c.Domain,
+		Secure:   c.Secure,
+		HttpOnly: c.HTTPOnly,
+		Expires:  c.Expiry,
+		SameSite: httpSameSite[c.SameSite],
+	}
+}
@@ -0,0 +1,133 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+)
+
+var _ = Describe("Cookie", func() {
+	Describe("#MarshalJSON", func() {
+		It("should encode the expiry in whole seconds since the epoch", func() {
+			cookie := &Cookie{Name: "some cookie", Value: "some value", SameSite: "Lax", Expiry: time.Unix(100, 500000000)}
+			Expect(json.Marshal(cookie)).To(MatchJSON(`{"name": "some cookie", "value": "some value", "sameSite": "Lax", "expiry": 100}`))
+		})
+
+		It("should omit the expiry of session cookies", func() {
+			Expect(json.Marshal(Cookie{Name: "some cookie"})).To(MatchJSON(`{"name": "some cookie", "value": ""}`))
+		})
+	})
+
+	Describe("#UnmarshalJSON", func() {
+		It("should decode the expiry from fractional seconds since the epoch", func() {
+			var cookie Cookie
+			Expect(json.Unmarshal([]byte(`{"name": "some cookie", "httpOnly": true, "sameSite": "None", "expiry": 100.5}`), &cookie)).To(Succeed())
+			Expect(cookie).To(Equal(Cookie{
+				Name:     "some cookie",
+				HTTPOnly: true,
+				SameSite: "None",
+				Expiry:   time.Unix(100, 500000000),
+			}))
+		})
+
+		It("should leave the expiry of session cookies as the zero time", func() {
+			var cookie Cookie
+			Expect(json.Unmarshal([]byte(`{"name": "some cookie"}`), &cookie)).To(Succeed())
+			Expect(cookie.Expiry.IsZero()).To(BeTrue())
+		})
+
+		It("should return an error for invalid JSON", func() {
+			var cookie Cookie
+			Expect(json.Unmarshal([]byte(`{"expiry": "soon"}`), &cookie)).NotTo(Succeed())
+		})
+	})
+
+	Describe("#Validate", func() {
+		It("should accept valid cookies", func() {
+			Expect((&Cookie{Name: "some cookie", Path: "/some/path", Domain: ".example.com", SameSite: "Strict"}).Validate()).To(Succeed())
+			Expect((&Cookie{Name: "some cookie", Secure: true, SameSite: "None"}).Validate()).To(Succeed())
+		})
+
+		It("should reject paths that do not begin with a slash", func() {
+			Expect((&Cookie{Path: "some/path"}).Validate()).To(MatchError("invalid cookie: path must begin with /"))
+		})
+
+		It("should reject domains with a scheme, port, or path", func() {
+			for _, domain := range []string{"http://example.com", "example.com:8080", "example.com/path"} {
+				Expect((&Cookie{Domain: domain}).Validate()).To(MatchError("invalid cookie: domain must be a host name without a scheme, port, or path"))
+			}
+		})
+
+		It("should reject unknown SameSite values", func() {
+			Expect((&Cookie{SameSite: "strict"}).Validate()).To(MatchError(`invalid cookie: SameSite must be "Strict", "Lax", or "None"`))
+		})
+
+		It("should reject insecure cookies with a SameSite value of None", func() {
+			Expect((&Cookie{SameSite: "None"}).Validate()).To(MatchError("invalid cookie: SameSite=None requires a secure cookie"))
+		})
+	})
+
+	Describe(".NewCookie", func() {
+		It("should convert the *http.Cookie into a Cookie", func() {
+			cookie := NewCookie(&http.Cookie{
+				Name:     "some cookie",
+				Value:    "some value",
+				Path:     "/",
+				Domain:   "example.com",
+				Secure:   true,
+				HttpOnly: true,
+				Expires:  time.Unix(100, 0),
+				SameSite: http.SameSiteNoneMode,
+			})
+			Expect(cookie).To(Equal(&Cookie{
+				Name:     "some cookie",
+				Value:    "some value",
+				Path:     "/",
+				Domain:   "example.com",
+				Secure:   true,
+				HTTPOnly: true,
+				Expiry:   time.Unix(100, 0),
+				SameSite: "None",
+			}))
+		})
+
+		It("should not set an expiry or SameSite value by default", func() {
+			cookie := NewCookie(&http.Cookie{Name: "some cookie"})
+			Expect(cookie.Expiry.IsZero()).To(BeTrue())
+			Expect(cookie.SameSite).To(BeEmpty())
+		})
+	})
+
+	Describe("#HTTPCookie", func() {
+		It("should convert the Cookie into a *http.Cookie", func() {
+			cookie := &Cookie{
+				Name:     "some cookie",
+				Value:    "some value",
+				Path:     "/",
+				Domain:   "example.com",
+				Secure:   true,
+				HTTPOnly: true,
+				Expiry:   time.Unix(100, 0),
+				SameSite: "Strict",
+			}
+			Expect(cookie.HTTPCookie()).To(Equal(&http.Cookie{
+				Name:     "some cookie",
+				Value:    "some value",
+				Path:     "/",
+				Domain:   "example.com",
+				Secure:   true,
+				HttpOnly: true,
+				Expires:  time.Unix(100, 0),
+				SameSite: http.SameSiteStrictMode,
+			}))
+		})
+
+		It("should not set an expiration time for session cookies", func() {
+			Expect((&Cookie{Name: "some cookie"}).HTTPCookie().Expires.IsZero()).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+
+	"github.com/sclevine/agouti/api/internal/bus"
+)
+
+// ErrDriverCrashed is returned by commands sent to a session opened by a
+// WebDriver when the WebDriver process has exited without being stopped,
+// ex. because it segfaulted or was killed for running out of memory. If
+// AutoRestart fails to restart the WebDriver or to reopen the session, later
+// commands return an error that wraps ErrDriverCrashed and describes the
+// failure, which may be detected using errors.Is.
+var ErrDriverCrashed = errors.New("WebDriver process crashed")
+
+// A crashDetector is a Service that can report that its process has exited
+// without being stopped.
+type crashDetector interface {
+	Crashed() error
+}
+
+// A driverBus sends the commands of a session opened by a WebDriver, and
+// returns ErrDriverCrashed when a command fails because the WebDriver
+// process has exited. If the WebDriver restarts, the bus is replaced with a
+// bus for a new session with the same capabilities. Deleting the session
+// removes it from the WebDriver, so that it is not reopened.
+type driverBus struct {
+	driver       *WebDriver
+	session      *Session
+	capabilities map[string]interface{}
+
+	mutex     sync.RWMutex
+	bus       Bus
+	hooks     []bus.Hook
+	decoding  Decoding
+	reopenErr error
+}
+
+func (d *driverBus) current() Bus {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.bus
+}
+
+func (d *driverBus) Send(method, endpoint string, body, result interface{}) error {
+	return d.SendContext(context.Background(), method, endpoint, body, result)
+}
+
+func (d *driverBus) SendContext(ctx context.Context, method, endpoint string, body, result interface{}) error {
+	if err := d.recoveryError(); err != nil {
+		return err
+	}
+	if method == "DELETE" && endpoint == "" {
+		d.driver.removeSession(d.session)
+	}
+
+	var err error
+	if contextBus, ok := d.current().(ContextBus); ok {
+		err = contextBus.SendContext(ctx, method, endpoint, body, result)
+	} else {
+		err = d.current().Send(method, endpoint, body, result)
+	}
+	return d.checkCrash(err)
+}
+
+// Failed commands return ErrDriverCrashed if the WebDriver process crashed,
+// or the error that prevented it from recovering.
+func (d *driverBus) checkCrash(err error) error {
+	if err == nil || d.driver.crashed() == nil {
+		return err
+	}
+	if err := d.driver.recover(); err != nil {
+		return err
+	}
+	if err := d.recoveryError(); err != nil {
+		return err
+	}
+	return ErrDriverCrashed
+}
+
+// Commands are not sent after the WebDriver failed to restart or the session
+// failed to reopen, as they would be sent to a session that no longer exists.
+func (d *driverBus) recoveryError() error {
+	if err := d.driver.restartError(); err != nil {
+		return err
+	}
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.reopenErr
+}
+
+func (d *driverBus) AddHook(hook bus.Hook) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.hooks = append(d.hooks, hook)
+	if hooks, ok := d.bus.(hookBus); ok {
+		hooks.AddHook(hook)
+	}
+}
+
+// The session is reopened with its original capabilities, so any state of
+// the browser, such as its URL and cookies, is lost.
+func (d *driverBus) reopen(url string) {
+	client, err := bus.Connect(url, d.capabilities, d.driver.HTTPClient)
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if err != nil {
+		d.reopenErr = fmt.Errorf("%w: failed to reopen session: %s", ErrDriverCrashed, wrapError(err))
+		return
+	}
+	for _, hook := range d.hooks {
+		client.AddHook(hook)
+	}
+	client.SetDecoding(d.decoding.Strict, d.decoding.UseNumber)
+	d.bus = client
+	d.session.W3C = client.W3C
+	d.session.WebSocketURL = client.WebSocketURL
+	d.session.Capabilities = client.Capabilities
+	d.session.shared().setID(path.Base(client.SessionURL))
+}
+
+// Crashed returns an error describing how the WebDriver process exited if it
+// exited without being stopped, or nil if it is running. Crashes are only
+// detected for WebDrivers that run a local process.
+func (w *WebDriver) Crashed() error {
+	return w.crashed()
+}
+
+func (w *WebDriver) crashed() error {
+	if detector, ok := w.service.(crashDetector); ok {
+		return detector.Crashed()
+	}
+	return nil
+}
+
+// If AutoRestart is enabled, the crashed WebDriver is restarted and all of
+// its sessions are reopened. Commands that fail while the WebDriver is
+// restarting wait for the restart to finish. If the restart fails, the error
+// is returned by this and every later command until the WebDriver is started
+// again. Sessions that fail to reopen keep their error in their driverBus.
+func (w *WebDriver) recover() error {
+	if !w.AutoRestart {
+		return nil
+	}
+
+	w.restartMutex.Lock()
+	defer w.restartMutex.Unlock()
+	if w.restartErr != nil || w.crashed() == nil {
+		return w.restartErr
+	}
+
+	if err := w.restart(); err != nil {
+		w.restartErr = fmt.Errorf("%w: failed to restart WebDriver: %s", ErrDriverCrashed, err)
+		return w.restartErr
+	}
+	for _, session := range w.openSessions() {
+		if driverBus, ok := session.Bus.(*driverBus); ok {
+			driverBus.reopen(w.service.URL())
+		}
+	}
+	return nil
+}
+
+func (w *WebDriver) restartError() error {
+	w.restartMutex.Lock()
+	defer w.restartMutex.Unlock()
+	return w.restartErr
+}
+
+func (w *WebDriver) restart() error {
+	w.service.Stop()
+	if err := w.startService(); err != nil {
+		return fmt.Errorf("failed to restart service: %s", err)
+	}
+	if err := w.service.WaitForBoot(w.Timeout); err != nil {
+		w.service.Stop()
+		return err
+	}
+	return nil
+}
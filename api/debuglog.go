@@ -0,0 +1,112 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Strings longer than this, such as base64-encoded screenshots, are
+// truncated in debug logs.
+const debugLogMaxString = 256
+
+// Values of JSON keys containing these words are replaced in debug logs.
+var debugLogSecrets = []string{"password", "passwd", "secret", "token", "authorization", "apikey", "api_key"}
+
+// WithDebugLog provides a ConnectOption for logging every command sent by
+// the session opened by Connect to the provided writer. See DebugLog.
+func WithDebugLog(w io.Writer) ConnectOption {
+	return WithCommandHook(DebugLog(w))
+}
+
+// DebugLog returns a CommandHook that logs each command to the provided
+// writer with its duration and pretty-printed JSON request and response
+// bodies. Cookie values and the values of keys that look like credentials,
+// ex. "password" or "token", are redacted. Text entered into fields is not
+// redacted. Long strings, such as screenshots, are truncated.
+//
+// Example:
+//    session.AddCommandHook(api.DebugLog(os.Stderr))
+func DebugLog(w io.Writer) CommandHook {
+	var mutex sync.Mutex
+	return func(method, endpoint string, body, result []byte, err error, duration time.Duration) {
+		redactCookies := strings.HasPrefix(endpoint, "cookie")
+
+		var entry bytes.Buffer
+		fmt.Fprintf(&entry, "[agouti] %s %s (%s)\n", method, endpoint, duration)
+		if len(body) > 0 {
+			fmt.Fprintf(&entry, "  request: %s\n", debugJSON(body, redactCookies))
+		}
+		if len(result) > 0 {
+			fmt.Fprintf(&entry, "  response: %s\n", debugJSON(result, redactCookies))
+		}
+		if err != nil {
+			fmt.Fprintf(&entry, "  error: %s\n", err)
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		w.Write(entry.Bytes())
+	}
+}
+
+func debugJSON(data []byte, redactCookies bool) string {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return truncate(string(data))
+	}
+
+	indented, err := json.MarshalIndent(redact(value, redactCookies), "  ", "  ")
+	if err != nil {
+		return truncate(string(data))
+	}
+	return string(indented)
+}
+
+// Cookies are identified by their "name" key, as the responses of cookie
+// commands are also wrapped in a "value" key.
+func redact(value interface{}, redactCookies bool) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		_, isCookie := value["name"]
+		redacted := map[string]interface{}{}
+		for key, member := range value {
+			if isSecret(key) || (redactCookies && isCookie && key == "value") {
+				redacted[key] = "[REDACTED]"
+			} else {
+				redacted[key] = redact(member, redactCookies)
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := []interface{}{}
+		for _, member := range value {
+			redacted = append(redacted, redact(member, redactCookies))
+		}
+		return redacted
+	case string:
+		return truncate(value)
+	}
+	return value
+}
+
+func isSecret(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range debugLogSecrets {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+func truncate(value string) string {
+	if len(value) <= debugLogMaxString {
+		return value
+	}
+	return fmt.Sprintf("%s...(%d bytes)", value[:debugLogMaxString], len(value))
+}
@@ -0,0 +1,72 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+)
+
+var _ = Describe("Debug Logging", func() {
+	var (
+		log  *bytes.Buffer
+		hook CommandHook
+	)
+
+	BeforeEach(func() {
+		log = &bytes.Buffer{}
+		hook = DebugLog(log)
+	})
+
+	Describe(".DebugLog", func() {
+		It("should log the command with its duration and pretty-printed request and response bodies", func() {
+			hook("POST", "element", []byte(`{"using":"css selector","value":"#some-id"}`), []byte(`{"value":{"some":"result"}}`), nil, 1500*time.Microsecond)
+			Expect(log.String()).To(Equal("[agouti] POST element (1.5ms)\n" +
+				"  request: {\n" +
+				"    \"using\": \"css selector\",\n" +
+				"    \"value\": \"#some-id\"\n" +
+				"  }\n" +
+				"  response: {\n" +
+				"    \"value\": {\n" +
+				"      \"some\": \"result\"\n" +
+				"    }\n" +
+				"  }\n"))
+		})
+
+		It("should omit missing bodies and log errors", func() {
+			hook("GET", "url", nil, nil, errors.New("some error"), time.Millisecond)
+			Expect(log.String()).To(Equal("[agouti] GET url (1ms)\n  error: some error\n"))
+		})
+
+		It("should redact the values of keys that look like credentials", func() {
+			hook("POST", "execute/sync", []byte(`{"args":[{"Password":"hunter2","apiToken":"abc"}],"script":"some script"}`), nil, nil, 0)
+			Expect(log.String()).NotTo(ContainSubstring("hunter2"))
+			Expect(log.String()).NotTo(ContainSubstring("abc"))
+			Expect(log.String()).To(ContainSubstring(`"Password": "[REDACTED]"`))
+			Expect(log.String()).To(ContainSubstring(`"script": "some script"`))
+		})
+
+		It("should redact cookie values", func() {
+			hook("POST", "cookie", []byte(`{"cookie":{"name":"session","value":"some-secret"}}`), nil, nil, 0)
+			hook("GET", "cookie", nil, []byte(`{"value":[{"name":"session","value":"some-secret"}]}`), nil, 0)
+			Expect(log.String()).NotTo(ContainSubstring("some-secret"))
+			Expect(log.String()).To(ContainSubstring(`"name": "session"`))
+		})
+
+		It("should truncate long strings", func() {
+			screenshot := strings.Repeat("a", 1000)
+			hook("GET", "screenshot", nil, []byte(`{"value":"`+screenshot+`"}`), nil, 0)
+			Expect(log.String()).To(ContainSubstring(strings.Repeat("a", 256) + "...(1000 bytes)"))
+			Expect(log.String()).NotTo(ContainSubstring(strings.Repeat("a", 257)))
+		})
+
+		It("should log bodies that are not JSON as text", func() {
+			hook("GET", "url", nil, []byte("some unexpected response"), nil, 0)
+			Expect(log.String()).To(ContainSubstring("  response: some unexpected response\n"))
+		})
+	})
+})
@@ -0,0 +1,72 @@
+package api
+
+import "errors"
+
+// Decoding configures how the values of WebDriver responses are decoded.
+type Decoding struct {
+	// Strict causes commands to fail when a response is missing its value,
+	// or contains fields that are not expected, ex. because a WebDriver or
+	// Selenium Grid upgrade changed the shape of its responses. Strict
+	// decoding is intended for catching protocol drift in tests of the api
+	// package itself, since most WebDrivers add vendor-specific fields.
+	Strict bool
+
+	// UseNumber causes numbers in results of an interface{} type, such as
+	// the results of Execute, to be provided as a json.Number instead of a
+	// float64, so that integers larger than 2^53 are not rounded.
+	UseNumber bool
+}
+
+type decodingBus interface {
+	SetDecoding(strict, useNumber bool)
+}
+
+// WithStrictDecoding provides a ConnectOption for enabling Decoding.Strict
+// on the session opened by Connect. It has no effect on NewHTTPClient.
+func WithStrictDecoding() ConnectOption {
+	return func(c *connectConfig) {
+		c.decoding.Strict = true
+	}
+}
+
+// WithNumberDecoding provides a ConnectOption for enabling
+// Decoding.UseNumber on the session opened by Connect. It has no effect on
+// NewHTTPClient.
+//
+// Example:
+//    session, err := api.Connect(url, capabilities, api.WithNumberDecoding())
+//    var id json.Number
+//    err = session.Execute("return 9007199254740993;", nil, &id)
+func WithNumberDecoding() ConnectOption {
+	return func(c *connectConfig) {
+		c.decoding.UseNumber = true
+	}
+}
+
+// SetDecoding configures how the values of subsequent responses to the
+// session are decoded, including responses to copies of the session returned
+// by WithContext. An error is returned if the session's Bus does not support
+// decoding options, which is only the case for custom Bus implementations.
+func (s *Session) SetDecoding(decoding Decoding) error {
+	bus, ok := s.Bus.(decodingBus)
+	if !ok {
+		return errors.New("the session's Bus does not support decoding options")
+	}
+	bus.SetDecoding(decoding.Strict, decoding.UseNumber)
+	return nil
+}
+
+func (c *contextBus) SetDecoding(strict, useNumber bool) {
+	if bus, ok := c.bus.(decodingBus); ok {
+		bus.SetDecoding(strict, useNumber)
+	}
+}
+
+func (d *driverBus) SetDecoding(strict, useNumber bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.decoding = Decoding{strict, useNumber}
+	if bus, ok := d.bus.(decodingBus); ok {
+		bus.SetDecoding(strict, useNumber)
+	}
+}
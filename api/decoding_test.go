@@ -0,0 +1,73 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/mocks"
+)
+
+var _ = Describe("Decoding", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			switch request.URL.Path {
+			case "/session":
+				response.Write([]byte(`{"value": {"sessionId": "some-id", "capabilities": {}}}`))
+			case "/session/some-id/execute/sync":
+				response.Write([]byte(`{"value": {"id": 9007199254740993}}`))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe(".WithNumberDecoding", func() {
+		It("should decode Execute results using json.Number", func() {
+			session, err := Connect(server.URL, nil, WithNumberDecoding())
+			Expect(err).NotTo(HaveOccurred())
+
+			var result map[string]interface{}
+			Expect(session.Execute("some script", nil, &result)).To(Succeed())
+			Expect(result["id"]).To(Equal(json.Number("9007199254740993")))
+		})
+	})
+
+	Describe(".WithStrictDecoding", func() {
+		It("should fail commands with unexpected response fields", func() {
+			session, err := Connect(server.URL, nil, WithStrictDecoding())
+			Expect(err).NotTo(HaveOccurred())
+
+			var result struct{ Name string }
+			err = session.Execute("some script", nil, &result)
+			Expect(err).To(MatchError(`unexpected response: json: unknown field "id"`))
+		})
+	})
+
+	Describe("#SetDecoding", func() {
+		It("should configure the decoding of copies of the session returned by WithContext", func() {
+			session, err := Connect(server.URL, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.WithContext(context.Background()).SetDecoding(Decoding{UseNumber: true})).To(Succeed())
+
+			var result map[string]interface{}
+			Expect(session.Execute("some script", nil, &result)).To(Succeed())
+			Expect(result["id"]).To(Equal(json.Number("9007199254740993")))
+		})
+
+		Context("when the session's Bus does not support decoding options", func() {
+			It("should return an error", func() {
+				session := &Session{Bus: &mocks.Bus{}}
+				Expect(session.SetDecoding(Decoding{Strict: true})).To(MatchError("the session's Bus does not support decoding options"))
+			})
+		})
+	})
+})
@@ -1,9 +1,12 @@
 package api
 
 import (
+	"encoding/base64"
 	"errors"
 	"path"
 	"strings"
+
+	"github.com/sclevine/agouti/keys"
 )
 
 type Element struct {
@@ -20,25 +23,25 @@ func (e *Element) GetID() string {
 }
 
 func (e *Element) GetElement(selector Selector) (*Element, error) {
-	var result struct{ Element string }
+	var result elementResult
 
-	if err := e.Send("POST", "element", selector, &result); err != nil {
+	if err := e.Send("POST", "element", e.Session.selector(selector), &result); err != nil {
 		return nil, err
 	}
 
-	return &Element{result.Element, e.Session}, nil
+	return &Element{result.ID(), e.Session}, nil
 }
 
 func (e *Element) GetElements(selector Selector) ([]*Element, error) {
-	var results []struct{ Element string }
+	var results []elementResult
 
-	if err := e.Send("POST", "elements", selector, &results); err != nil {
+	if err := e.Send("POST", "elements", e.Session.selector(selector), &results); err != nil {
 		return nil, err
 	}
 
 	elements := []*Element{}
 	for _, result := range results {
-		elements = append(elements, &Element{result.Element, e.Session})
+		elements = append(elements, &Element{result.ID(), e.Session})
 	}
 
 	return elements, nil
@@ -68,6 +71,16 @@ func (e *Element) GetAttribute(attribute string) (string, error) {
 	return value, nil
 }
 
+// GetProperty returns the current value of the provided DOM property, which
+// may differ from the attribute initially specified in the markup.
+func (e *Element) GetProperty(property string) (interface{}, error) {
+	var value interface{}
+	if err := e.Send("GET", path.Join("property", property), nil, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
 func (e *Element) GetCSS(property string) (string, error) {
 	var value string
 	if err := e.Send("GET", path.Join("css", property), nil, &value); err != nil {
@@ -86,12 +99,27 @@ func (e *Element) Clear() error {
 
 func (e *Element) Value(text string) error {
 	splitText := strings.Split(text, "")
+
+	if e.Session.W3C {
+		request := struct {
+			Text  string   `json:"text"`
+			Value []string `json:"value"`
+		}{text, splitText}
+		return e.Send("POST", "value", request, nil)
+	}
+
 	request := struct {
 		Value []string `json:"value"`
 	}{splitText}
 	return e.Send("POST", "value", request, nil)
 }
 
+// SendKeys types the provided text and keys (see the keys package) into the
+// element. Any modifier keys still pressed at the end are released.
+func (e *Element) SendKeys(text ...string) error {
+	return e.Value(keys.Sequence(text...))
+}
+
 func (e *Element) IsSelected() (bool, error) {
 	var selected bool
 	if err := e.Send("GET", "selected", nil, &selected); err != nil {
@@ -116,14 +144,49 @@ func (e *Element) IsEnabled() (bool, error) {
 	return enabled, nil
 }
 
+// W3C removed the submit endpoint, so the form is submitted using JavaScript.
 func (e *Element) Submit() error {
+	if e.Session.W3C {
+		script := `var form = arguments[0].form || arguments[0];
+			if (form.requestSubmit) { form.requestSubmit(); } else { form.submit(); }`
+		arguments := []interface{}{e.Session.elementReference(e)}
+		return e.Session.Execute(script, arguments, nil)
+	}
 	return e.Send("POST", "submit", nil, nil)
 }
 
+// GetElementsByScript runs the provided script with the element as its first
+// argument, followed by the provided arguments, and returns the elements in
+// the array returned by the script.
+func (e *Element) GetElementsByScript(script string, arguments ...interface{}) ([]*Element, error) {
+	var results []elementResult
+	if err := e.Execute(script, arguments, &results); err != nil {
+		return nil, err
+	}
+
+	elements := []*Element{}
+	for _, result := range results {
+		elements = append(elements, &Element{result.ID(), e.Session})
+	}
+	return elements, nil
+}
+
+// Execute runs the provided script with the element as its first argument,
+// followed by the provided arguments. See Session.Execute.
+func (e *Element) Execute(body string, arguments []interface{}, result interface{}) error {
+	arguments = append([]interface{}{e.Session.elementReference(e)}, arguments...)
+	return e.Session.Execute(body, arguments, result)
+}
+
+// W3C element references are unique per element, so they are compared
+// directly instead of using the removed equals endpoint.
 func (e *Element) IsEqualTo(other *Element) (bool, error) {
 	if other == nil {
 		return false, errors.New("nil element is invalid")
 	}
+	if e.Session.W3C {
+		return e.ID == other.ID, nil
+	}
 	var equal bool
 	if err := e.Send("GET", path.Join("equals", other.ID), nil, &equal); err != nil {
 		return false, err
@@ -132,16 +195,58 @@ func (e *Element) IsEqualTo(other *Element) (bool, error) {
 }
 
 func (e *Element) GetLocation() (x, y int, err error) {
+	endpoint := "location"
+	if e.Session.W3C {
+		endpoint = "rect"
+	}
+
 	var location struct {
 		X float64 `json:"x"`
 		Y float64 `json:"y"`
 	}
-	if err := e.Send("GET", "location", nil, &location); err != nil {
+	if err := e.Send("GET", endpoint, nil, &location); err != nil {
 		return 0, 0, err
 	}
 	return round(location.X), round(location.Y), nil
 }
 
+func (e *Element) GetRect() (Rect, error) {
+	var rect Rect
+	if err := e.Send("GET", "rect", nil, &rect); err != nil {
+		return Rect{}, err
+	}
+	return rect, nil
+}
+
+// GetComputedRole returns the WAI-ARIA role computed for the element
+// by the browser's accessibility tree.
+func (e *Element) GetComputedRole() (string, error) {
+	var role string
+	if err := e.Send("GET", "computedrole", nil, &role); err != nil {
+		return "", err
+	}
+	return role, nil
+}
+
+// GetComputedLabel returns the accessible name computed for the element
+// by the browser's accessibility tree.
+func (e *Element) GetComputedLabel() (string, error) {
+	var label string
+	if err := e.Send("GET", "computedlabel", nil, &label); err != nil {
+		return "", err
+	}
+	return label, nil
+}
+
+// GetScreenshot returns a screenshot of the element scrolled into view.
+func (e *Element) GetScreenshot() ([]byte, error) {
+	var base64Image string
+	if err := e.Send("GET", "screenshot", nil, &base64Image); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(base64Image)
+}
+
 func round(number float64) int {
 	return int(number + 0.5)
 }
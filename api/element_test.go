@@ -19,7 +19,7 @@ var _ = Describe("Element", func() {
 
 	BeforeEach(func() {
 		bus = &mocks.Bus{}
-		session = &Session{bus}
+		session = &Session{Bus: bus}
 		element = &Element{"some-id", session}
 	})
 
@@ -71,6 +71,17 @@ var _ = Describe("Element", func() {
 				Expect(err).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should translate the selector and return an element with the W3C element ID", func() {
+				session.W3C = true
+				bus.SendCall.Result = `{"element-6066-11e4-a52e-4f735466cecf": "some-child-id"}`
+				child, err := element.GetElement(Selector{"class name", "some-class"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"using": "css selector", "value": "[class~=\"some-class\"]"}`))
+				Expect(child.ID).To(Equal("some-child-id"))
+			})
+		})
 	})
 
 	Describe("#GetElements", func() {
@@ -99,6 +110,17 @@ var _ = Describe("Element", func() {
 				Expect(err).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should return a slice of elements with W3C element IDs", func() {
+				session.W3C = true
+				bus.SendCall.Result = `[{"element-6066-11e4-a52e-4f735466cecf": "some-id"}, {"element-6066-11e4-a52e-4f735466cecf": "some-other-id"}]`
+				elements, err := element.GetElements(Selector{"css selector", "#selector"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(elements[0].ID).To(Equal("some-id"))
+				Expect(elements[1].ID).To(Equal("some-other-id"))
+			})
+		})
 	})
 
 	Describe("#GetText", func() {
@@ -173,6 +195,30 @@ var _ = Describe("Element", func() {
 		})
 	})
 
+	Describe("#GetProperty", func() {
+		It("should successfully send a GET request to the property/some-property endpoint", func() {
+			_, err := element.GetProperty("some-property")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("element/some-id/property/some-property"))
+		})
+
+		It("should return the value of the property", func() {
+			bus.SendCall.Result = `true`
+			value, err := element.GetProperty("some-property")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal(true))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := element.GetProperty("some-property")
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
 	Describe("#GetCSS", func() {
 		It("should successfully send a GET request to the css/some-property endpoint", func() {
 			_, err := element.GetCSS("some-property")
@@ -241,6 +287,37 @@ var _ = Describe("Element", func() {
 				Expect(element.Value("text")).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send both the text and the split value", func() {
+				session.W3C = true
+				Expect(element.Value("text")).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("element/some-id/value"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"text": "text", "value": ["t", "e", "x", "t"]}`))
+			})
+		})
+	})
+
+	Describe("#SendKeys", func() {
+		It("should successfully send the joined keys to the value endpoint", func() {
+			Expect(element.SendKeys("ab", "\uE007")).To(Succeed())
+			Expect(bus.SendCall.Endpoint).To(Equal("element/some-id/value"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"value": ["a", "b", "\uE007"]}`))
+		})
+
+		Context("when a modifier key remains pressed", func() {
+			It("should release the modifier at the end of the sequence", func() {
+				Expect(element.SendKeys("\uE009", "a")).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"value": ["\uE009", "a", "\uE000"]}`))
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(element.SendKeys("text")).To(MatchError("some error"))
+			})
+		})
 	})
 
 	Describe("#IsSelected", func() {
@@ -328,6 +405,47 @@ var _ = Describe("Element", func() {
 				Expect(element.Submit()).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully submit the form using JavaScript", func() {
+				session.W3C = true
+				Expect(element.Submit()).To(Succeed())
+				Expect(bus.SendCall.Method).To(Equal("POST"))
+				Expect(bus.SendCall.Endpoint).To(Equal("execute/sync"))
+				Expect(bus.SendCall.BodyJSON).To(ContainSubstring(`"args":[{"element-6066-11e4-a52e-4f735466cecf":"some-id"}]`))
+				Expect(bus.SendCall.BodyJSON).To(ContainSubstring("arguments[0].form || arguments[0]"))
+			})
+		})
+	})
+
+	Describe("#GetElementsByScript", func() {
+		It("should run the script with the element and return the resulting elements", func() {
+			bus.SendCall.Result = `[{"ELEMENT": "other-id"}, {"element-6066-11e4-a52e-4f735466cecf": "another-id"}]`
+			elements, err := element.GetElementsByScript("return [arguments[0]];", "some argument")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(elements).To(Equal([]*Element{{"other-id", session}, {"another-id", session}}))
+			Expect(bus.SendCall.Endpoint).To(Equal("execute"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "return [arguments[0]];", "args": [{"ELEMENT": "some-id"}, "some argument"]}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := element.GetElementsByScript("return [];")
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#Execute", func() {
+		It("should run the script with the element as its first argument", func() {
+			bus.SendCall.Result = `"some result"`
+			var result string
+			Expect(element.Execute("return arguments[1];", []interface{}{"some argument"}, &result)).To(Succeed())
+			Expect(result).To(Equal("some result"))
+			Expect(bus.SendCall.Endpoint).To(Equal("execute"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "return arguments[1];", "args": [{"ELEMENT": "some-id"}, "some argument"]}`))
+		})
 	})
 
 	Describe("#IsEqualTo", func() {
@@ -360,6 +478,18 @@ var _ = Describe("Element", func() {
 				Expect(err).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			BeforeEach(func() {
+				session.W3C = true
+			})
+
+			It("should compare the element IDs without sending a request", func() {
+				Expect(element.IsEqualTo(&Element{"some-id", session})).To(BeTrue())
+				Expect(element.IsEqualTo(&Element{"other-id", session})).To(BeFalse())
+				Expect(bus.SendCall.Method).To(BeEmpty())
+			})
+		})
 	})
 
 	Describe("#GetLocation", func() {
@@ -385,5 +515,120 @@ var _ = Describe("Element", func() {
 				Expect(err).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a GET request to the rect endpoint", func() {
+				session.W3C = true
+				bus.SendCall.Result = `{"x": 100.7, "y": 200, "width": 10, "height": 20}`
+				x, y, err := element.GetLocation()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bus.SendCall.Endpoint).To(Equal("element/some-id/rect"))
+				Expect(x).To(Equal(101))
+				Expect(y).To(Equal(200))
+			})
+		})
+	})
+
+	Describe("#GetRect", func() {
+		It("should successfully send a GET request to the rect endpoint", func() {
+			_, err := element.GetRect()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("element/some-id/rect"))
+		})
+
+		It("should return the position and size of the element", func() {
+			bus.SendCall.Result = `{"x": 100.5, "y": 200, "width": 10, "height": 20.5}`
+			rect, err := element.GetRect()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rect).To(Equal(Rect{X: 100.5, Y: 200, Width: 10, Height: 20.5}))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := element.GetRect()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetComputedRole", func() {
+		It("should successfully send a GET request to the computedrole endpoint", func() {
+			_, err := element.GetComputedRole()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("element/some-id/computedrole"))
+		})
+
+		It("should return the computed role of the element", func() {
+			bus.SendCall.Result = `"button"`
+			role, err := element.GetComputedRole()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(role).To(Equal("button"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := element.GetComputedRole()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetComputedLabel", func() {
+		It("should successfully send a GET request to the computedlabel endpoint", func() {
+			_, err := element.GetComputedLabel()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("element/some-id/computedlabel"))
+		})
+
+		It("should return the computed label of the element", func() {
+			bus.SendCall.Result = `"some label"`
+			label, err := element.GetComputedLabel()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(label).To(Equal("some label"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := element.GetComputedLabel()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+	Describe("#GetScreenshot", func() {
+		It("should successfully send a GET request to the screenshot endpoint", func() {
+			_, err := element.GetScreenshot()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("element/some-id/screenshot"))
+		})
+
+		It("should return the decoded screenshot of the element", func() {
+			bus.SendCall.Result = `"c29tZS1wbmc="`
+			image, err := element.GetScreenshot()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(image)).To(Equal("some-png"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := element.GetScreenshot()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the image is not valid base64", func() {
+			It("should return an error", func() {
+				bus.SendCall.Result = `"..."`
+				_, err := element.GetScreenshot()
+				Expect(err).To(MatchError("illegal base64 data at input byte 0"))
+			})
+		})
 	})
 })
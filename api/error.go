@@ -0,0 +1,124 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/sclevine/agouti/api/internal/bus"
+)
+
+// WebDriver error codes, as defined by the W3C WebDriver specification.
+// Errors returned by JSON Wire Protocol servers are translated into the
+// equivalent W3C error code.
+const (
+	ErrorElementNotInteractable = "element not interactable"
+	ErrorInvalidElementState    = "invalid element state"
+	ErrorInvalidSelector        = "invalid selector"
+	ErrorInvalidSessionID       = "invalid session id"
+	ErrorJavaScript             = "javascript error"
+	ErrorNoSuchAlert            = "no such alert"
+	ErrorNoSuchCookie           = "no such cookie"
+	ErrorNoSuchElement          = "no such element"
+	ErrorNoSuchFrame            = "no such frame"
+	ErrorNoSuchWindow           = "no such window"
+	ErrorScriptTimeout          = "script timeout"
+	ErrorStaleElement           = "stale element reference"
+	ErrorTimeout                = "timeout"
+	ErrorUnexpectedAlertOpen    = "unexpected alert open"
+	ErrorUnknownCommand         = "unknown command"
+	ErrorUnknownMethod          = "unknown method"
+)
+
+// An Error is returned when the WebDriver server responds to a command with
+// an error. Errors that occur before a response is received, such as
+// connection failures, are not of this type.
+type Error struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+
+	// Code is the WebDriver error code, ex. "no such element"
+	Code string
+
+	// Message is the error message provided by the server
+	Message string
+}
+
+func (e *Error) Error() string {
+	return "request unsuccessful: " + e.Message
+}
+
+// Send sends a command using the session's Bus. WebDriver errors returned by
+// the server are provided as an *Error.
+func (s *Session) Send(method, endpoint string, body, result interface{}) error {
+	return s.retryAlert(func() error {
+		return wrapError(s.Bus.Send(method, endpoint, body, result))
+	})
+}
+
+func wrapError(err error) error {
+	if responseErr, ok := err.(*bus.ResponseError); ok {
+		return &Error{responseErr.StatusCode, responseErr.Code, responseErr.Message}
+	}
+	return err
+}
+
+// ErrorCode returns the WebDriver error code of the provided error, or an
+// empty string if the error is not an *Error.
+func ErrorCode(err error) string {
+	if apiErr, ok := err.(*Error); ok {
+		return apiErr.Code
+	}
+	return ""
+}
+
+// IsNoSuchElement returns true if the error indicates that an element could
+// not be found.
+func IsNoSuchElement(err error) bool {
+	return ErrorCode(err) == ErrorNoSuchElement
+}
+
+// IsStaleElement returns true if the error indicates that an element is no
+// longer attached to the DOM.
+func IsStaleElement(err error) bool {
+	return ErrorCode(err) == ErrorStaleElement
+}
+
+// IsTimeout returns true if the error indicates that a command or script
+// did not complete in time.
+func IsTimeout(err error) bool {
+	code := ErrorCode(err)
+	return code == ErrorTimeout || code == ErrorScriptTimeout
+}
+
+// IsUnexpectedAlertOpen returns true if the error indicates that a command
+// was blocked by an open alert, confirm, or prompt popup.
+func IsUnexpectedAlertOpen(err error) bool {
+	return ErrorCode(err) == ErrorUnexpectedAlertOpen
+}
+
+// IsNoSuchAlert returns true if the error indicates that no popup is open.
+func IsNoSuchAlert(err error) bool {
+	return ErrorCode(err) == ErrorNoSuchAlert
+}
+
+// IsNoSuchCookie returns true if the error indicates that no cookie with the
+// requested name exists.
+func IsNoSuchCookie(err error) bool {
+	return ErrorCode(err) == ErrorNoSuchCookie
+}
+
+// IsUnknownCommand returns true if the error indicates that the WebDriver
+// does not implement the requested command. Servers that respond to unknown
+// endpoints without a WebDriver error code are also matched.
+func IsUnknownCommand(err error) bool {
+	apiErr, ok := err.(*Error)
+	if !ok {
+		return false
+	}
+	switch apiErr.Code {
+	case ErrorUnknownCommand, ErrorUnknownMethod:
+		return true
+	case "":
+		return apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusMethodNotAllowed
+	}
+	return false
+}
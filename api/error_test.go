@@ -0,0 +1,110 @@
+package api_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/bus"
+	"github.com/sclevine/agouti/api/internal/mocks"
+)
+
+var _ = Describe("Error", func() {
+	var (
+		busMock *mocks.Bus
+		session *Session
+	)
+
+	BeforeEach(func() {
+		busMock = &mocks.Bus{}
+		session = &Session{Bus: busMock}
+	})
+
+	Describe("#Error", func() {
+		It("should return the server error message", func() {
+			err := &Error{StatusCode: 404, Code: "no such element", Message: "some error"}
+			Expect(err).To(MatchError("request unsuccessful: some error"))
+		})
+	})
+
+	Describe("Session#Send", func() {
+		It("should send the command using the bus", func() {
+			busMock.SendCall.Result = `"some result"`
+			var result string
+			Expect(session.Send("POST", "some/endpoint", "some body", &result)).To(Succeed())
+			Expect(busMock.SendCall.Method).To(Equal("POST"))
+			Expect(busMock.SendCall.Endpoint).To(Equal("some/endpoint"))
+			Expect(busMock.SendCall.BodyJSON).To(MatchJSON(`"some body"`))
+			Expect(result).To(Equal("some result"))
+		})
+
+		Context("when the server responds with an error", func() {
+			It("should return an *Error with the error code and HTTP status", func() {
+				busMock.SendCall.Err = &bus.ResponseError{StatusCode: 404, Code: "stale element reference", Message: "some error"}
+				err := session.Send("GET", "some/endpoint", nil, nil)
+				Expect(err).To(Equal(&Error{StatusCode: 404, Code: "stale element reference", Message: "some error"}))
+			})
+
+			It("should provide the error to element commands", func() {
+				busMock.SendCall.Err = &bus.ResponseError{StatusCode: 404, Code: "stale element reference", Message: "some error"}
+				element := &Element{"some-id", session}
+				Expect(IsStaleElement(element.Click())).To(BeTrue())
+			})
+		})
+
+		Context("when the command fails without a response", func() {
+			It("should return the original error", func() {
+				busMock.SendCall.Err = errors.New("some error")
+				Expect(session.Send("GET", "some/endpoint", nil, nil)).To(Equal(errors.New("some error")))
+			})
+		})
+	})
+
+	Describe(".ErrorCode", func() {
+		It("should return the error code of an *Error", func() {
+			Expect(ErrorCode(&Error{Code: "no such alert"})).To(Equal("no such alert"))
+		})
+
+		It("should return an empty string for other errors", func() {
+			Expect(ErrorCode(errors.New("some error"))).To(BeEmpty())
+			Expect(ErrorCode(nil)).To(BeEmpty())
+		})
+	})
+
+	Describe("predicates", func() {
+		It("should identify errors by their error code", func() {
+			Expect(IsNoSuchElement(&Error{Code: ErrorNoSuchElement})).To(BeTrue())
+			Expect(IsStaleElement(&Error{Code: ErrorStaleElement})).To(BeTrue())
+			Expect(IsTimeout(&Error{Code: ErrorTimeout})).To(BeTrue())
+			Expect(IsTimeout(&Error{Code: ErrorScriptTimeout})).To(BeTrue())
+			Expect(IsUnexpectedAlertOpen(&Error{Code: ErrorUnexpectedAlertOpen})).To(BeTrue())
+			Expect(IsNoSuchAlert(&Error{Code: ErrorNoSuchAlert})).To(BeTrue())
+			Expect(IsNoSuchCookie(&Error{Code: ErrorNoSuchCookie})).To(BeTrue())
+			Expect(IsUnknownCommand(&Error{Code: ErrorUnknownCommand})).To(BeTrue())
+			Expect(IsUnknownCommand(&Error{Code: ErrorUnknownMethod})).To(BeTrue())
+		})
+
+		It("should identify unknown commands without an error code by their status code", func() {
+			Expect(IsUnknownCommand(&Error{StatusCode: 404})).To(BeTrue())
+			Expect(IsUnknownCommand(&Error{StatusCode: 405})).To(BeTrue())
+			Expect(IsUnknownCommand(&Error{StatusCode: 500})).To(BeFalse())
+			Expect(IsUnknownCommand(&Error{StatusCode: 404, Code: ErrorNoSuchElement})).To(BeFalse())
+		})
+
+		It("should not match errors with other error codes", func() {
+			err := &Error{Code: ErrorNoSuchElement}
+			Expect(IsStaleElement(err)).To(BeFalse())
+			Expect(IsTimeout(err)).To(BeFalse())
+			Expect(IsUnexpectedAlertOpen(err)).To(BeFalse())
+			Expect(IsNoSuchAlert(err)).To(BeFalse())
+			Expect(IsNoSuchCookie(err)).To(BeFalse())
+			Expect(IsUnknownCommand(err)).To(BeFalse())
+		})
+
+		It("should not match errors that are not an *Error", func() {
+			Expect(IsNoSuchElement(errors.New("no such element"))).To(BeFalse())
+			Expect(IsStaleElement(nil)).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,48 @@
+package api
+
+import "fmt"
+
+// A Geolocation defines a position on the surface of the Earth.
+type Geolocation struct {
+	// Latitude is the latitude in decimal degrees
+	Latitude float64 `json:"latitude"`
+
+	// Longitude is the longitude in decimal degrees
+	Longitude float64 `json:"longitude"`
+
+	// Altitude is the altitude in meters
+	Altitude float64 `json:"altitude"`
+}
+
+// GetGeolocation returns the geolocation reported by the browser.
+func (s *Session) GetGeolocation() (Geolocation, error) {
+	var location Geolocation
+	if err := s.Send("GET", "location", nil, &location); err != nil {
+		return Geolocation{}, err
+	}
+	return location, nil
+}
+
+// SetGeolocation overrides the geolocation reported by the browser. If the
+// WebDriver does not support the location endpoint, the DevTools Protocol is
+// used instead. The DevTools override does not include the altitude.
+func (s *Session) SetGeolocation(latitude, longitude, altitude float64) error {
+	request := struct {
+		Location Geolocation `json:"location"`
+	}{Geolocation{latitude, longitude, altitude}}
+
+	err := s.Send("POST", "location", request, nil)
+	if err == nil {
+		return nil
+	}
+
+	override := map[string]float64{
+		"latitude":  latitude,
+		"longitude": longitude,
+		"accuracy":  1,
+	}
+	if cdpErr := s.ExecuteCDP("Emulation.setGeolocationOverride", override, nil); cdpErr != nil {
+		return fmt.Errorf("%s (DevTools fallback: %s)", err, cdpErr)
+	}
+	return nil
+}
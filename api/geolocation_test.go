@@ -0,0 +1,88 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+)
+
+type geolocationBus struct {
+	errs   map[string]error
+	bodies map[string]string
+}
+
+func (b *geolocationBus) Send(method, endpoint string, body, result interface{}) error {
+	bodyJSON, _ := json.Marshal(body)
+	b.bodies[method+" "+endpoint] = string(bodyJSON)
+	if err := b.errs[endpoint]; err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal([]byte(`{"latitude": 1.5, "longitude": -2.5, "altitude": 10}`), result)
+}
+
+var _ = Describe("Geolocation", func() {
+	var (
+		session *Session
+		bus     *geolocationBus
+	)
+
+	BeforeEach(func() {
+		bus = &geolocationBus{errs: map[string]error{}, bodies: map[string]string{}}
+		session = &Session{Bus: bus}
+	})
+
+	Describe("#GetGeolocation", func() {
+		It("should successfully send a GET to the location endpoint", func() {
+			_, err := session.GetGeolocation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.bodies).To(HaveKey("GET location"))
+		})
+
+		It("should return the geolocation", func() {
+			Expect(session.GetGeolocation()).To(Equal(Geolocation{Latitude: 1.5, Longitude: -2.5, Altitude: 10}))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.errs["location"] = errors.New("some error")
+				_, err := session.GetGeolocation()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#SetGeolocation", func() {
+		It("should successfully send a POST to the location endpoint", func() {
+			Expect(session.SetGeolocation(1.5, -2.5, 10)).To(Succeed())
+			Expect(bus.bodies["POST location"]).To(MatchJSON(`{"location": {"latitude": 1.5, "longitude": -2.5, "altitude": 10}}`))
+			Expect(bus.bodies).NotTo(HaveKey("POST goog/cdp/execute"))
+		})
+
+		Context("when the location endpoint is not supported", func() {
+			BeforeEach(func() {
+				bus.errs["location"] = errors.New("some error")
+			})
+
+			It("should override the geolocation using the DevTools Protocol", func() {
+				Expect(session.SetGeolocation(1.5, -2.5, 10)).To(Succeed())
+				Expect(bus.bodies["POST goog/cdp/execute"]).To(MatchJSON(`{
+					"cmd": "Emulation.setGeolocationOverride",
+					"params": {"latitude": 1.5, "longitude": -2.5, "accuracy": 1}
+				}`))
+			})
+
+			Context("when the DevTools Protocol is not supported", func() {
+				It("should return both errors", func() {
+					bus.errs["goog/cdp/execute"] = errors.New("some other error")
+					Expect(session.SetGeolocation(1.5, -2.5, 10)).To(MatchError("some error (DevTools fallback: some other error)"))
+				})
+			})
+		})
+	})
+})
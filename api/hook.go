@@ -0,0 +1,56 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sclevine/agouti/api/internal/bus"
+)
+
+// A CommandHook is called after each WebDriver command is sent, with the JSON
+// request and response bodies, any error, and the duration of the command.
+// The request body is nil for commands without a body, and the response body
+// is nil if no response was received. WebDriver errors are provided as an
+// *Error. Hooks may be called from multiple goroutines.
+type CommandHook func(method, endpoint string, body, result []byte, err error, duration time.Duration)
+
+type hookBus interface {
+	AddHook(hook bus.Hook)
+}
+
+// WithCommandHook provides a ConnectOption for registering a CommandHook on
+// the session opened by Connect. It has no effect on NewHTTPClient.
+//
+// Example:
+//    session, err := api.Connect(url, capabilities, api.WithCommandHook(
+//        func(method, endpoint string, body, result []byte, err error, duration time.Duration) {
+//            log.Printf("%s %s (%s): %v", method, endpoint, duration, err)
+//        }))
+func WithCommandHook(hook CommandHook) ConnectOption {
+	return func(c *connectConfig) {
+		c.hooks = append(c.hooks, hook)
+	}
+}
+
+// AddCommandHook registers a CommandHook that is called after every
+// subsequent command sent by the session, including commands sent by copies
+// of the session returned by WithContext. An error is returned if the
+// session's Bus does not support hooks, which is only the case for custom
+// Bus implementations.
+func (s *Session) AddCommandHook(hook CommandHook) error {
+	hooks, ok := s.Bus.(hookBus)
+	if !ok {
+		return errors.New("the session's Bus does not support command hooks")
+	}
+
+	hooks.AddHook(func(method, endpoint string, body, result []byte, err error, duration time.Duration) {
+		hook(method, endpoint, body, result, wrapError(err), duration)
+	})
+	return nil
+}
+
+func (c *contextBus) AddHook(hook bus.Hook) {
+	if hooks, ok := c.bus.(hookBus); ok {
+		hooks.AddHook(hook)
+	}
+}
@@ -0,0 +1,106 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/mocks"
+)
+
+var _ = Describe("Command Hooks", func() {
+	var (
+		server    *httptest.Server
+		endpoints []string
+		errs      []error
+		hook      CommandHook
+	)
+
+	BeforeEach(func() {
+		endpoints, errs = nil, nil
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			switch request.URL.Path {
+			case "/session":
+				response.Write([]byte(`{"value": {"sessionId": "some-id", "capabilities": {}}}`))
+			case "/session/some-id/url":
+				response.Write([]byte(`{"value": "some-url"}`))
+			default:
+				response.WriteHeader(404)
+				response.Write([]byte(`{"value": {"error": "no such element", "message": "some message"}}`))
+			}
+		}))
+		hook = func(method, endpoint string, body, result []byte, err error, duration time.Duration) {
+			endpoints = append(endpoints, method+" "+endpoint)
+			errs = append(errs, err)
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe(".WithCommandHook", func() {
+		It("should register the hook on the session opened by Connect", func() {
+			session, err := Connect(server.URL, nil, WithCommandHook(hook))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.GetURL()).To(Equal("some-url"))
+			Expect(endpoints).To(Equal([]string{"GET url"}))
+			Expect(errs).To(Equal([]error{nil}))
+		})
+	})
+
+	Describe("#AddCommandHook", func() {
+		It("should call the hook with WebDriver errors", func() {
+			session, err := Connect(server.URL, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.AddCommandHook(hook)).To(Succeed())
+
+			_, err = session.GetElement(Selector{"css selector", "#missing"})
+			Expect(err).To(HaveOccurred())
+			Expect(endpoints).To(Equal([]string{"POST element"}))
+			Expect(IsNoSuchElement(errs[0])).To(BeTrue())
+		})
+
+		It("should register the hook for copies of the session returned by WithContext", func() {
+			session, err := Connect(server.URL, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.WithContext(context.Background()).AddCommandHook(hook)).To(Succeed())
+
+			session.GetURL()
+			Expect(endpoints).To(Equal([]string{"GET url"}))
+		})
+
+		Context("when the session's Bus does not support hooks", func() {
+			It("should return an error", func() {
+				session := &Session{Bus: &mocks.Bus{}}
+				Expect(session.AddCommandHook(hook)).To(MatchError("the session's Bus does not support command hooks"))
+			})
+		})
+	})
+
+	Describe("#ConnectionStats", func() {
+		It("should return the connection statistics of the session and its copies", func() {
+			session, err := Connect(server.URL, nil, WithHTTPClient(&http.Client{Transport: &http.Transport{}}))
+			Expect(err).NotTo(HaveOccurred())
+			session.WithContext(context.Background()).GetURL()
+			session.GetURL()
+
+			stats, err := session.ConnectionStats()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stats.Requests).To(Equal(2))
+			Expect(stats.ReusedConnections).To(Equal(2 - stats.NewConnections))
+		})
+
+		Context("when the session's Bus does not record statistics", func() {
+			It("should return an error", func() {
+				session := &Session{Bus: &mocks.Bus{}}
+				_, err := session.ConnectionStats()
+				Expect(err).To(MatchError("the session's Bus does not record connection statistics"))
+			})
+		})
+	})
+})
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// UnmarshalJSON decodes a web element reference returned by a script, in
+// either dialect. The session of the element is set by Execute, so elements
+// decoded by other means must be provided a session before they are used.
+func (e *Element) UnmarshalJSON(data []byte) error {
+	var result elementResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	if result.ID() == "" {
+		return errors.New("value is not a web element reference")
+	}
+	e.ID = result.ID()
+	return nil
+}
+
+// hydrate provides the session to each *Element within the result of a
+// script, and replaces web element references within interface{} values of
+// the result with an *Element.
+func (s *Session) hydrate(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return
+		}
+		switch pointer := value.Interface().(type) {
+		case *Element:
+			if pointer.Session == nil {
+				pointer.Session = s
+			}
+		case *Session:
+			// sessions are never decoded from results
+		default:
+			s.hydrate(value.Elem())
+		}
+	case reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+		if element, ok := s.hydrateInterface(value.Elem()); ok && value.CanSet() {
+			value.Set(element)
+		}
+	case reflect.Struct:
+		if value.Type() == reflect.TypeOf(Element{}) {
+			if value.CanAddr() {
+				s.hydrate(value.Addr())
+			}
+			return
+		}
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).PkgPath == "" {
+				s.hydrate(value.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			s.hydrate(value.Index(i))
+		}
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			entry := value.MapIndex(key)
+			if entry.Kind() != reflect.Interface || entry.IsNil() {
+				s.hydrate(entry)
+				continue
+			}
+			if element, ok := s.hydrateInterface(entry.Elem()); ok {
+				value.SetMapIndex(key, element)
+			}
+		}
+	}
+}
+
+func (s *Session) hydrateInterface(value reflect.Value) (reflect.Value, bool) {
+	if object, ok := value.Interface().(map[string]interface{}); ok {
+		if id, ok := elementReferenceID(object); ok {
+			return reflect.ValueOf(&Element{id, s}), true
+		}
+	}
+	s.hydrate(value)
+	return reflect.Value{}, false
+}
+
+// Some WebDrivers provide both the legacy and W3C keys in each reference.
+func elementReferenceID(object map[string]interface{}) (string, bool) {
+	if len(object) == 0 || len(object) > 2 {
+		return "", false
+	}
+
+	var id string
+	for key, value := range object {
+		valueID, ok := value.(string)
+		if !ok || (key != W3CElementKey && key != "ELEMENT") || (id != "" && valueID != id) {
+			return "", false
+		}
+		id = valueID
+	}
+	return id, true
+}
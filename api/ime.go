@@ -0,0 +1,42 @@
+package api
+
+// GetIMEEngines returns the input method engines available on the machine,
+// such as "ibus:anthy" or "com.google.android.inputmethod.japanese".
+func (s *Session) GetIMEEngines() ([]string, error) {
+	var engines []string
+	if err := s.Send("GET", "ime/available_engines", nil, &engines); err != nil {
+		return nil, err
+	}
+	return engines, nil
+}
+
+// GetActiveIMEEngine returns the name of the active input method engine.
+func (s *Session) GetActiveIMEEngine() (string, error) {
+	var engine string
+	if err := s.Send("GET", "ime/active_engine", nil, &engine); err != nil {
+		return "", err
+	}
+	return engine, nil
+}
+
+// IsIMEActivated returns true if an input method engine is active.
+func (s *Session) IsIMEActivated() (bool, error) {
+	var activated bool
+	if err := s.Send("GET", "ime/activated", nil, &activated); err != nil {
+		return false, err
+	}
+	return activated, nil
+}
+
+// ActivateIMEEngine makes the provided input method engine active.
+func (s *Session) ActivateIMEEngine(engine string) error {
+	request := struct {
+		Engine string `json:"engine"`
+	}{engine}
+	return s.Send("POST", "ime/activate", request, nil)
+}
+
+// DeactivateIME deactivates the active input method engine.
+func (s *Session) DeactivateIME() error {
+	return s.Send("POST", "ime/deactivate", nil, nil)
+}
@@ -0,0 +1,104 @@
+package api_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/mocks"
+)
+
+var _ = Describe("IME", func() {
+	var (
+		bus     *mocks.Bus
+		session *Session
+	)
+
+	BeforeEach(func() {
+		bus = &mocks.Bus{}
+		session = &Session{Bus: bus}
+	})
+
+	Describe("#GetIMEEngines", func() {
+		It("should successfully send a GET to the ime/available_engines endpoint", func() {
+			bus.SendCall.Result = `["some-engine", "some-other-engine"]`
+			Expect(session.GetIMEEngines()).To(Equal([]string{"some-engine", "some-other-engine"}))
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("ime/available_engines"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := session.GetIMEEngines()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetActiveIMEEngine", func() {
+		It("should successfully send a GET to the ime/active_engine endpoint", func() {
+			bus.SendCall.Result = `"some-engine"`
+			Expect(session.GetActiveIMEEngine()).To(Equal("some-engine"))
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("ime/active_engine"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := session.GetActiveIMEEngine()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#IsIMEActivated", func() {
+		It("should successfully send a GET to the ime/activated endpoint", func() {
+			bus.SendCall.Result = "true"
+			Expect(session.IsIMEActivated()).To(BeTrue())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("ime/activated"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := session.IsIMEActivated()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#ActivateIMEEngine", func() {
+		It("should successfully send a POST to the ime/activate endpoint", func() {
+			Expect(session.ActivateIMEEngine("some-engine")).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("ime/activate"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"engine": "some-engine"}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.ActivateIMEEngine("some-engine")).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#DeactivateIME", func() {
+		It("should successfully send a POST to the ime/deactivate endpoint", func() {
+			Expect(session.DeactivateIME()).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("ime/deactivate"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.DeactivateIME()).To(MatchError("some error"))
+			})
+		})
+	})
+})
@@ -1,5 +1,5 @@
 package api
 
-func NewTestWebDriver(service driverService) *WebDriver {
+func NewTestWebDriver(service Service) *WebDriver {
 	return &WebDriver{service: service}
 }
@@ -2,40 +2,154 @@ package bus
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 )
 
 type Client struct {
 	SessionURL string
 	HTTPClient *http.Client
+
+	// W3C is true if the remote end responded to the new session request
+	// using the W3C WebDriver dialect.
+	W3C bool
+
+	// WebSocketURL is the WebDriver BiDi URL returned by the remote end when
+	// the webSocketUrl capability is requested, or empty if BiDi is unavailable.
+	WebSocketURL string
+
+	// Capabilities are the capabilities of the session returned by the remote
+	// end, or nil if the client was attached to a running session.
+	Capabilities map[string]interface{}
+
+	// Strict causes commands to fail when a response is missing its value,
+	// or contains fields that are not present in the result.
+	Strict bool
+
+	// UseNumber causes numbers that are decoded into an interface{} to be
+	// provided as a json.Number instead of a float64, so that large integers
+	// are not rounded.
+	UseNumber bool
+
+	hooks      []Hook
+	hooksMutex sync.RWMutex
+	stats      connectionStats
+}
+
+// A Hook is called after each command is sent, with the JSON request and
+// response bodies, any error, and the duration of the command. The request
+// body is nil for commands without a body, and the response body is nil if
+// no response was received.
+type Hook func(method, endpoint string, body, result []byte, err error, duration time.Duration)
+
+// AddHook registers a hook that is called after every subsequent command.
+func (c *Client) AddHook(hook Hook) {
+	c.hooksMutex.Lock()
+	defer c.hooksMutex.Unlock()
+	c.hooks = append(c.hooks, hook)
+}
+
+// SetDecoding configures how the values of subsequent responses are decoded,
+// as described by Strict and UseNumber.
+func (c *Client) SetDecoding(strict, useNumber bool) {
+	c.Strict = strict
+	c.UseNumber = useNumber
 }
 
 func (c *Client) Send(method, endpoint string, body interface{}, result interface{}) error {
+	return c.SendContext(context.Background(), method, endpoint, body, result)
+}
+
+func (c *Client) SendContext(ctx context.Context, method, endpoint string, body interface{}, result interface{}) error {
 	requestBody, err := bodyToJSON(body)
 	if err != nil {
 		return err
 	}
 
+	start := time.Now()
+	responseBody, err := c.send(ctx, method, endpoint, requestBody, result)
+	c.runHooks(method, endpoint, requestBody, responseBody, err, time.Since(start))
+	return err
+}
+
+func (c *Client) send(ctx context.Context, method, endpoint string, requestBody []byte, result interface{}) ([]byte, error) {
 	requestURL := strings.TrimSuffix(c.SessionURL+"/"+endpoint, "/")
-	responseBody, err := c.makeRequest(requestURL, method, requestBody)
+	responseBody, err := c.makeRequest(ctx, requestURL, method, requestBody)
 	if err != nil {
-		return err
+		return responseBody, err
 	}
 
 	if result != nil {
-		bodyValue := struct{ Value interface{} }{result}
-		if err := json.Unmarshal(responseBody, &bodyValue); err != nil {
-			return fmt.Errorf("unexpected response: %s", responseBody)
+		if err := c.decodeValue(responseBody, result); err != nil {
+			if c.Strict {
+				return responseBody, fmt.Errorf("unexpected response: %s", err)
+			}
+			return responseBody, fmt.Errorf("unexpected response: %s", responseBody)
+		}
+	}
+
+	return responseBody, nil
+}
+
+// The session ID and status are only provided by legacy remote ends, so they
+// are not unexpected when decoding strictly.
+func (c *Client) decodeValue(responseBody []byte, result interface{}) error {
+	var response struct {
+		Value     json.RawMessage `json:"value"`
+		SessionID json.RawMessage `json:"sessionId"`
+		Status    json.RawMessage `json:"status"`
+	}
+	if err := c.unmarshal(responseBody, &response); err != nil {
+		return err
+	}
+	if response.Value == nil {
+		if c.Strict {
+			return errors.New("missing value")
 		}
+		return nil
 	}
+	return c.unmarshal(response.Value, result)
+}
 
+func (c *Client) unmarshal(data []byte, v interface{}) error {
+	if !c.Strict && !c.UseNumber {
+		return json.Unmarshal(data, v)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if c.Strict {
+		decoder.DisallowUnknownFields()
+	}
+	if c.UseNumber {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("invalid data after top-level value")
+	}
 	return nil
 }
 
+func (c *Client) runHooks(method, endpoint string, body, result []byte, err error, duration time.Duration) {
+	c.hooksMutex.RLock()
+	hooks := c.hooks
+	c.hooksMutex.RUnlock()
+
+	for _, hook := range hooks {
+		hook(method, endpoint, body, result, err, duration)
+	}
+}
+
 func bodyToJSON(body interface{}) ([]byte, error) {
 	if body == nil {
 		return nil, nil
@@ -47,43 +161,114 @@ func bodyToJSON(body interface{}) ([]byte, error) {
 	return bodyJSON, nil
 }
 
-func (c *Client) makeRequest(url, method string, body []byte) ([]byte, error) {
+func (c *Client) makeRequest(ctx context.Context, url, method string, body []byte) ([]byte, error) {
+	response, err := c.do(ctx, url, method, body)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return responseBody, parseResponseError(response.StatusCode, responseBody)
+	}
+
+	return responseBody, nil
+}
+
+// The response body is decompressed according to its Content-Encoding, so
+// that the transport does not need to support compression.
+func (c *Client) do(ctx context.Context, url, method string, body []byte) (*http.Response, error) {
 	request, err := http.NewRequest(method, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("invalid request: %s", err)
 	}
+	request = request.WithContext(c.traceConnection(ctx))
 
 	if body != nil {
 		request.Header.Add("Content-Type", "application/json")
 	}
+	request.Header.Add("Accept-Encoding", "gzip, deflate")
 
 	response, err := c.HTTPClient.Do(request)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %s", err)
 	}
 
-	responseBody, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return nil, err
+	if err := decompress(response); err != nil {
+		response.Body.Close()
+		return nil, fmt.Errorf("invalid response encoding: %s", err)
 	}
+	return response, nil
+}
 
-	if response.StatusCode < 200 || response.StatusCode > 299 {
-		return nil, parseResponseError(responseBody)
-	}
+// A ResponseError is returned when the WebDriver server responds to a
+// command with a non-2xx status code.
+type ResponseError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
 
-	return responseBody, nil
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("request unsuccessful: %s", e.Message)
 }
 
-func parseResponseError(body []byte) error {
-	var errBody struct{ Value struct{ Message string } }
+// JSON Wire Protocol status codes and their W3C error code equivalents
+var legacyErrorCodes = map[int]string{
+	6:  "invalid session id",
+	7:  "no such element",
+	8:  "no such frame",
+	9:  "unknown command",
+	10: "stale element reference",
+	11: "element not interactable",
+	12: "invalid element state",
+	13: "unknown error",
+	17: "javascript error",
+	19: "invalid selector",
+	21: "timeout",
+	23: "no such window",
+	24: "invalid cookie domain",
+	25: "unable to set cookie",
+	26: "unexpected alert open",
+	27: "no such alert",
+	28: "script timeout",
+	32: "invalid selector",
+	33: "session not created",
+	34: "move target out of bounds",
+}
+
+func parseResponseError(statusCode int, body []byte) error {
+	var errBody struct {
+		Status int
+		Value  struct {
+			Error   string
+			Message string
+		}
+	}
 	if err := json.Unmarshal(body, &errBody); err != nil {
-		return fmt.Errorf("request unsuccessful: %s", body)
+		return &ResponseError{StatusCode: statusCode, Message: string(body)}
 	}
 
+	code := errBody.Value.Error
+	if code == "" {
+		code = legacyErrorCodes[errBody.Status]
+	}
+
+	message := errBody.Value.Message
 	var errMessage struct{ ErrorMessage string }
-	if err := json.Unmarshal([]byte(errBody.Value.Message), &errMessage); err != nil {
-		return fmt.Errorf("request unsuccessful: %s", errBody.Value.Message)
+	if err := json.Unmarshal([]byte(message), &errMessage); err == nil {
+		message = errMessage.ErrorMessage
+	}
+
+	// W3C-only servers, such as safaridriver, may omit the message.
+	if message == "" {
+		message = code
 	}
 
-	return fmt.Errorf("request unsuccessful: %s", errMessage.ErrorMessage)
+	return &ResponseError{StatusCode: statusCode, Code: code, Message: message}
 }
@@ -1,10 +1,13 @@
 package bus_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -141,6 +144,41 @@ var _ = Describe("Session", func() {
 					Expect(err).To(MatchError("request unsuccessful: $$$"))
 				})
 			})
+
+			Context("when the server responds with a W3C error code", func() {
+				It("should return a response error with the error code and HTTP status", func() {
+					responseStatus = 404
+					responseBody = `{"value": {"error": "no such element", "message": "some error"}}`
+					err := client.Send("GET", "some/endpoint", nil, nil)
+					Expect(err).To(Equal(&ResponseError{StatusCode: 404, Code: "no such element", Message: "some error"}))
+				})
+			})
+
+			Context("when the server responds with a W3C error code without a message", func() {
+				It("should return a response error with the error code as the message", func() {
+					responseStatus = 404
+					responseBody = `{"value": {"error": "no such window", "message": ""}}`
+					err := client.Send("GET", "some/endpoint", nil, nil)
+					Expect(err).To(MatchError("request unsuccessful: no such window"))
+				})
+			})
+
+			Context("when the server responds with a JSON Wire Protocol status", func() {
+				It("should return a response error with the equivalent W3C error code", func() {
+					responseStatus = 500
+					responseBody = `{"status": 10, "value": {"message": "some error"}}`
+					err := client.Send("GET", "some/endpoint", nil, nil)
+					Expect(err).To(Equal(&ResponseError{StatusCode: 500, Code: "stale element reference", Message: "some error"}))
+				})
+			})
+
+			Context("when the server responds with an unknown JSON Wire Protocol status", func() {
+				It("should return a response error without an error code", func() {
+					responseBody = `{"status": 999, "value": {"message": "some error"}}`
+					err := client.Send("GET", "some/endpoint", nil, nil)
+					Expect(err).To(Equal(&ResponseError{StatusCode: 400, Message: "some error"}))
+				})
+			})
 		})
 
 		Context("when the request succeeds", func() {
@@ -164,6 +202,141 @@ var _ = Describe("Session", func() {
 					Expect(err).To(MatchError("unexpected response: some unexpected response"))
 				})
 			})
+
+			Context("with strict decoding", func() {
+				BeforeEach(func() {
+					client.SetDecoding(true, false)
+				})
+
+				It("should allow the legacy session ID and status", func() {
+					responseBody = `{"sessionId": "some-id", "status": 0, "value": {"some": "response value"}}`
+					Expect(client.Send("GET", "some/endpoint", nil, &result)).To(Succeed())
+					Expect(result.Some).To(Equal("response value"))
+				})
+
+				It("should return an error when the value contains unknown fields", func() {
+					responseBody = `{"value": {"some": "response value", "other": "value"}}`
+					err := client.Send("GET", "some/endpoint", nil, &result)
+					Expect(err).To(MatchError(`unexpected response: json: unknown field "other"`))
+				})
+
+				It("should return an error when the response is missing its value", func() {
+					responseBody = `{}`
+					err := client.Send("GET", "some/endpoint", nil, &result)
+					Expect(err).To(MatchError("unexpected response: missing value"))
+				})
+			})
+
+			Context("with number decoding", func() {
+				It("should decode numbers without rounding them", func() {
+					client.SetDecoding(false, true)
+					responseBody = `{"value": [9007199254740993, 1.5]}`
+					var numbers interface{}
+					Expect(client.Send("GET", "some/endpoint", nil, &numbers)).To(Succeed())
+					Expect(numbers).To(Equal([]interface{}{json.Number("9007199254740993"), json.Number("1.5")}))
+				})
+			})
+		})
+	})
+	Describe("#SendContext", func() {
+		var client *Client
+
+		BeforeEach(func() {
+			client = &Client{
+				SessionURL: server.URL + "/session/some-id",
+				HTTPClient: http.DefaultClient,
+			}
+		})
+
+		It("should make a request with the method and full session endpoint", func() {
+			Expect(client.SendContext(context.Background(), "GET", "some/endpoint", nil, nil)).To(Succeed())
+			Expect(requestPath).To(Equal("/session/some-id/some/endpoint"))
+			Expect(requestMethod).To(Equal("GET"))
+		})
+
+		Context("when the context is canceled", func() {
+			It("should return an error without making a request", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				err := client.SendContext(ctx, "GET", "some/endpoint", nil, nil)
+				Expect(err).To(MatchError(ContainSubstring("context canceled")))
+				Expect(requestPath).To(BeEmpty())
+			})
+		})
+	})
+	Describe("#AddHook", func() {
+		type hookCall struct {
+			method, endpoint string
+			body, result     []byte
+			err              error
+			duration         time.Duration
+		}
+
+		var (
+			client *Client
+			calls  []hookCall
+		)
+
+		BeforeEach(func() {
+			calls = nil
+			client = &Client{
+				SessionURL: server.URL + "/session/some-id",
+				HTTPClient: http.DefaultClient,
+			}
+			hook := func(method, endpoint string, body, result []byte, err error, duration time.Duration) {
+				calls = append(calls, hookCall{method, endpoint, body, result, err, duration})
+			}
+			client.AddHook(hook)
+			client.AddHook(hook)
+		})
+
+		It("should call each hook with the request and response bodies of every command", func() {
+			responseBody = `{"value": "some value"}`
+			var result string
+			Expect(client.Send("POST", "some/endpoint", map[string]string{"some": "body"}, &result)).To(Succeed())
+			Expect(calls).To(HaveLen(2))
+			Expect(calls[0].method).To(Equal("POST"))
+			Expect(calls[0].endpoint).To(Equal("some/endpoint"))
+			Expect(string(calls[0].body)).To(MatchJSON(`{"some": "body"}`))
+			Expect(string(calls[0].result)).To(Equal(`{"value": "some value"}`))
+			Expect(calls[0].err).NotTo(HaveOccurred())
+			Expect(calls[0].duration).To(BeNumerically(">", 0))
+		})
+
+		It("should call the hooks with the response body and error of failed commands", func() {
+			responseStatus = 404
+			responseBody = `{"value": {"error": "no such element", "message": "some message"}}`
+			err := client.Send("GET", "some/endpoint", nil, nil)
+			Expect(calls).To(HaveLen(2))
+			Expect(calls[0].body).To(BeNil())
+			Expect(string(calls[0].result)).To(Equal(responseBody))
+			Expect(calls[0].err).To(Equal(err))
+		})
+
+		It("should call the hooks without a response body when no response is received", func() {
+			server.Close()
+			err := client.Send("GET", "some/endpoint", nil, nil)
+			Expect(calls).To(HaveLen(2))
+			Expect(calls[0].result).To(BeNil())
+			Expect(calls[0].err).To(Equal(err))
+		})
+	})
+
+	Describe("#ConnectionStats", func() {
+		It("should count new and reused connections", func() {
+			client := &Client{
+				SessionURL: server.URL + "/session/some-id",
+				HTTPClient: &http.Client{Transport: &http.Transport{}},
+			}
+			Expect(client.ConnectionStats()).To(Equal(ConnectionStats{}))
+
+			Expect(client.Send("GET", "some/endpoint", nil, nil)).To(Succeed())
+			Expect(client.Send("GET", "some/endpoint", nil, nil)).To(Succeed())
+			stats := client.ConnectionStats()
+			Expect(stats.Requests).To(Equal(2))
+			Expect(stats.NewConnections).To(Equal(1))
+			Expect(stats.ReusedConnections).To(Equal(1))
+			Expect(stats.ConnectDuration).To(BeNumerically(">", 0))
 		})
 	})
 })
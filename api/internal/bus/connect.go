@@ -2,15 +2,21 @@ package bus
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 )
 
 func Connect(url string, capabilities map[string]interface{}, httpClient *http.Client) (*Client, error) {
+	return ConnectContext(context.Background(), url, capabilities, httpClient)
+}
+
+func ConnectContext(ctx context.Context, url string, capabilities map[string]interface{}, httpClient *http.Client) (*Client, error) {
 	requestBody, err := capabilitiesToJSON(capabilities)
 	if err != nil {
 		return nil, err
@@ -20,22 +26,41 @@ func Connect(url string, capabilities map[string]interface{}, httpClient *http.C
 		httpClient = http.DefaultClient
 	}
 
-	sessionID, err := openSession(url, requestBody, httpClient)
+	session, err := openSession(ctx, url, requestBody, httpClient)
 	if err != nil {
 		return nil, err
 	}
 
+	client := Attach(url, session.ID, httpClient)
+	client.W3C = session.W3C
+	client.WebSocketURL = session.WebSocketURL
+	client.Capabilities = session.Capabilities
+	return client, nil
+}
+
+// Attach returns a client for a session that is already running.
+func Attach(url, sessionID string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
 	sessionURL := fmt.Sprintf("%s/session/%s", url, sessionID)
-	return &Client{sessionURL, httpClient}, nil
+	return &Client{SessionURL: sessionURL, HTTPClient: httpClient}
 }
 
 func capabilitiesToJSON(capabilities map[string]interface{}) (io.Reader, error) {
 	if capabilities == nil {
 		capabilities = map[string]interface{}{}
 	}
+
+	alwaysMatch := struct {
+		AlwaysMatch map[string]interface{} `json:"alwaysMatch"`
+	}{W3CCapabilities(capabilities)}
+
 	desiredCapabilities := struct {
 		DesiredCapabilities map[string]interface{} `json:"desiredCapabilities"`
-	}{capabilities}
+		Capabilities        interface{}            `json:"capabilities"`
+	}{capabilities, alwaysMatch}
 
 	capabiltiesJSON, err := json.Marshal(desiredCapabilities)
 	if err != nil {
@@ -44,32 +69,110 @@ func capabilitiesToJSON(capabilities map[string]interface{}) (io.Reader, error)
 	return bytes.NewReader(capabiltiesJSON), err
 }
 
-func openSession(url string, body io.Reader, httpClient *http.Client) (sessionID string, err error) {
+var w3cStandardCapabilities = map[string]bool{
+	"browserName":               true,
+	"browserVersion":            true,
+	"platformName":              true,
+	"acceptInsecureCerts":       true,
+	"pageLoadStrategy":          true,
+	"proxy":                     true,
+	"setWindowRect":             true,
+	"timeouts":                  true,
+	"strictFileInteractability": true,
+	"unhandledPromptBehavior":   true,
+	"webSocketUrl":              true,
+}
+
+var w3cRenamedCapabilities = map[string]string{
+	"acceptSslCerts": "acceptInsecureCerts",
+	"version":        "browserVersion",
+	"platform":       "platformName",
+}
+
+// W3CCapabilities translates legacy desired capabilities into the W3C
+// dialect, keeping only standard and vendor-prefixed (ex. "goog:") keys.
+func W3CCapabilities(capabilities map[string]interface{}) map[string]interface{} {
+	w3c := map[string]interface{}{}
+	for key, value := range capabilities {
+		if w3cKey, ok := w3cRenamedCapabilities[key]; ok {
+			if _, exists := capabilities[w3cKey]; exists {
+				continue
+			}
+			key = w3cKey
+		}
+
+		if !w3cStandardCapabilities[key] && !strings.Contains(key, ":") {
+			continue
+		}
+
+		if key == "platformName" {
+			if platform, ok := value.(string); ok {
+				if platform == "ANY" || platform == "" {
+					continue
+				}
+				value = strings.ToLower(platform)
+			}
+		}
+		w3c[key] = value
+	}
+	return w3c
+}
+
+type openedSession struct {
+	ID           string
+	W3C          bool
+	WebSocketURL string
+	Capabilities map[string]interface{}
+}
+
+func openSession(ctx context.Context, url string, body io.Reader, httpClient *http.Client) (openedSession, error) {
 	request, err := http.NewRequest("POST", fmt.Sprintf("%s/session", url), body)
 	if err != nil {
-		return "", err
+		return openedSession{}, err
 	}
+	request = request.WithContext(ctx)
 
 	request.Header.Add("Content-Type", "application/json")
 
 	response, err := httpClient.Do(request)
 	if err != nil {
-		return "", err
+		return openedSession{}, err
 	}
 
-	var sessionResponse struct{ SessionID string }
+	var sessionResponse struct {
+		SessionID string
+		Value     json.RawMessage
+	}
 	responseBody, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		return "", err
+		return openedSession{}, err
 	}
 
 	if err := json.Unmarshal(responseBody, &sessionResponse); err != nil {
-		return "", err
+		return openedSession{}, err
+	}
+
+	if sessionResponse.SessionID != "" {
+		var legacyCapabilities map[string]interface{}
+		json.Unmarshal(sessionResponse.Value, &legacyCapabilities)
+		return openedSession{ID: sessionResponse.SessionID, Capabilities: legacyCapabilities}, nil
+	}
+
+	var w3cValue struct {
+		SessionID    string
+		Capabilities map[string]interface{}
+	}
+	if len(sessionResponse.Value) > 0 {
+		json.Unmarshal(sessionResponse.Value, &w3cValue)
 	}
 
-	if sessionResponse.SessionID == "" {
-		return "", errors.New("failed to retrieve a session ID")
+	if w3cValue.SessionID == "" {
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			return openedSession{}, parseResponseError(response.StatusCode, responseBody)
+		}
+		return openedSession{}, errors.New("failed to retrieve a session ID")
 	}
 
-	return sessionResponse.SessionID, nil
+	webSocketURL, _ := w3cValue.Capabilities["webSocketUrl"].(string)
+	return openedSession{w3cValue.SessionID, true, webSocketURL, w3cValue.Capabilities}, nil
 }
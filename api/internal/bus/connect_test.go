@@ -1,6 +1,7 @@
 package bus_test
 
 import (
+	"context"
 	"errors"
 	"io/ioutil"
 	"net/http"
@@ -18,11 +19,12 @@ var _ = Describe(".Connect", func() {
 		requestBody        string
 		requestContentType string
 		responseBody       string
+		responseStatus     int
 		server             *httptest.Server
 	)
 
 	BeforeEach(func() {
-		responseBody = `{"sessionId": "some-id"}`
+		responseBody, responseStatus = `{"sessionId": "some-id"}`, 200
 		requestPath, requestMethod, requestBody, requestContentType = "", "", "", ""
 		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
 			requestPath = request.URL.Path
@@ -30,6 +32,7 @@ var _ = Describe(".Connect", func() {
 			requestBodyBytes, _ := ioutil.ReadAll(request.Body)
 			requestBody = string(requestBodyBytes)
 			requestContentType = request.Header.Get("Content-Type")
+			response.WriteHeader(responseStatus)
 			response.Write([]byte(responseBody))
 		}))
 	})
@@ -66,14 +69,97 @@ var _ = Describe(".Connect", func() {
 	It("should make the request with the provided desired capabilities", func() {
 		_, err := Connect(server.URL, map[string]interface{}{"some": "json"}, nil)
 		Expect(err).NotTo(HaveOccurred())
-		Expect(requestBody).To(MatchJSON(`{"desiredCapabilities": {"some": "json"}}`))
+		Expect(requestBody).To(MatchJSON(`{"desiredCapabilities": {"some": "json"}, "capabilities": {"alwaysMatch": {}}}`))
+	})
+
+	It("should make the request with W3C translations of the provided capabilities", func() {
+		capabilities := map[string]interface{}{
+			"browserName":    "firefox",
+			"acceptSslCerts": true,
+			"version":        "3.6",
+			"platform":       "LINUX",
+			"goog:some":      "option",
+			"some":           "json",
+		}
+		_, err := Connect(server.URL, capabilities, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requestBody).To(MatchJSON(`{
+			"desiredCapabilities": {
+				"browserName": "firefox",
+				"acceptSslCerts": true,
+				"version": "3.6",
+				"platform": "LINUX",
+				"goog:some": "option",
+				"some": "json"
+			},
+			"capabilities": {
+				"alwaysMatch": {
+					"browserName": "firefox",
+					"acceptInsecureCerts": true,
+					"browserVersion": "3.6",
+					"platformName": "linux",
+					"goog:some": "option"
+				}
+			}
+		}`))
 	})
 
 	Context("when the capabilities are nil", func() {
 		It("should make the request with empty capabilities", func() {
 			_, err := Connect(server.URL, nil, nil)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(requestBody).To(MatchJSON(`{"desiredCapabilities": {}}`))
+			Expect(requestBody).To(MatchJSON(`{"desiredCapabilities": {}, "capabilities": {"alwaysMatch": {}}}`))
+		})
+	})
+
+	Context("when the remote end responds with the legacy dialect", func() {
+		It("should return a client that does not use the W3C dialect", func() {
+			client, err := Connect(server.URL, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.W3C).To(BeFalse())
+		})
+
+		It("should return a client with the capabilities of the session", func() {
+			responseBody = `{"sessionId": "some-id", "status": 0, "value": {"browserName": "firefox", "version": "45.0"}}`
+			client, err := Connect(server.URL, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.Capabilities).To(Equal(map[string]interface{}{"browserName": "firefox", "version": "45.0"}))
+		})
+	})
+
+	Context("when the remote end responds with the W3C dialect", func() {
+		It("should return a client with a session URL that uses the W3C dialect", func() {
+			responseBody = `{"value": {"sessionId": "some-w3c-id", "capabilities": {}}}`
+			client, err := Connect(server.URL, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.SessionURL).To(HaveSuffix("/session/some-w3c-id"))
+			Expect(client.W3C).To(BeTrue())
+			Expect(client.WebSocketURL).To(BeEmpty())
+		})
+
+		Context("when the remote end provides a WebDriver BiDi URL", func() {
+			It("should return a client with the WebSocket URL", func() {
+				responseBody = `{"value": {
+					"sessionId": "some-w3c-id",
+					"capabilities": {"webSocketUrl": "ws://localhost:9515/session/some-w3c-id"}
+				}}`
+				client, err := Connect(server.URL, nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(client.WebSocketURL).To(Equal("ws://localhost:9515/session/some-w3c-id"))
+			})
+		})
+
+		It("should return a client with the capabilities of the session", func() {
+			responseBody = `{"value": {
+				"sessionId": "some-w3c-id",
+				"capabilities": {"browserName": "chrome", "se:vnc": "ws://grid:4444/session/some-w3c-id/se/vnc"}
+			}}`
+			client, err := Connect(server.URL, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.Capabilities).To(Equal(map[string]interface{}{
+				"browserName": "chrome",
+				"se:vnc":      "ws://grid:4444/session/some-w3c-id/se/vnc",
+			}))
 		})
 	})
 
@@ -138,5 +224,48 @@ var _ = Describe(".Connect", func() {
 			_, err := Connect(server.URL, nil, nil)
 			Expect(err).To(MatchError("failed to retrieve a session ID"))
 		})
+
+		Context("when the response indicates a W3C error", func() {
+			It("should return the error message", func() {
+				responseStatus = 500
+				responseBody = `{"value": {"error": "session not created", "message": "some error"}}`
+				_, err := Connect(server.URL, nil, nil)
+				Expect(err).To(MatchError("request unsuccessful: some error"))
+			})
+		})
+	})
+	Describe(".ConnectContext", func() {
+		It("should successfully open a session using the provided context", func() {
+			client, err := ConnectContext(context.Background(), server.URL, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.SessionURL).To(Equal(server.URL + "/session/some-id"))
+		})
+
+		Context("when the context is canceled", func() {
+			It("should return an error without opening a session", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_, err := ConnectContext(ctx, server.URL, nil, nil)
+				Expect(err).To(MatchError(ContainSubstring("context canceled")))
+				Expect(requestPath).To(BeEmpty())
+			})
+		})
+	})
+	Describe(".Attach", func() {
+		It("should return a client with a session URL for the provided session ID", func() {
+			client := Attach(server.URL, "some-id", nil)
+			Expect(client.SessionURL).To(Equal(server.URL + "/session/some-id"))
+			Expect(client.W3C).To(BeFalse())
+			Expect(requestPath).To(BeEmpty())
+		})
+
+		It("should use the provided HTTP client", func() {
+			httpClient := &http.Client{}
+			Expect(Attach(server.URL, "some-id", httpClient).HTTPClient).To(BeIdenticalTo(httpClient))
+		})
+
+		It("should use the default HTTP client when none is provided", func() {
+			Expect(Attach(server.URL, "some-id", nil).HTTPClient).To(BeIdenticalTo(http.DefaultClient))
+		})
 	})
 })
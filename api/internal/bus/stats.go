@@ -0,0 +1,50 @@
+package bus
+
+import (
+	"context"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// ConnectionStats describes how often commands reused an existing connection
+// to the remote end, instead of opening a new connection.
+type ConnectionStats struct {
+	Requests          int
+	NewConnections    int
+	ReusedConnections int
+
+	// ConnectDuration is the total time spent opening new connections,
+	// including DNS resolution and TLS handshakes
+	ConnectDuration time.Duration
+}
+
+type connectionStats struct {
+	mutex sync.Mutex
+	stats ConnectionStats
+}
+
+// ConnectionStats returns the connection statistics of every command sent
+// by the client.
+func (c *Client) ConnectionStats() ConnectionStats {
+	c.stats.mutex.Lock()
+	defer c.stats.mutex.Unlock()
+	return c.stats.stats
+}
+
+func (c *Client) traceConnection(ctx context.Context) context.Context {
+	start := time.Now()
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			c.stats.mutex.Lock()
+			defer c.stats.mutex.Unlock()
+			c.stats.stats.Requests++
+			if info.Reused {
+				c.stats.stats.ReusedConnections++
+			} else {
+				c.stats.stats.NewConnections++
+				c.stats.stats.ConnectDuration += time.Since(start)
+			}
+		},
+	})
+}
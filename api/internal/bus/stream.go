@@ -0,0 +1,270 @@
+package bus
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// SendStream sends a command like Send, but instead of decoding the entire
+// response, it calls read with a reader of the string value of the response
+// as it is received. Hooks are called without the response body of
+// successful commands.
+func (c *Client) SendStream(method, endpoint string, body interface{}, read func(value io.Reader) error) error {
+	return c.SendStreamContext(context.Background(), method, endpoint, body, read)
+}
+
+func (c *Client) SendStreamContext(ctx context.Context, method, endpoint string, body interface{}, read func(value io.Reader) error) error {
+	requestBody, err := bodyToJSON(body)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	responseBody, err := c.sendStream(ctx, method, endpoint, requestBody, read)
+	c.runHooks(method, endpoint, requestBody, responseBody, err, time.Since(start))
+	return err
+}
+
+func (c *Client) sendStream(ctx context.Context, method, endpoint string, requestBody []byte, read func(io.Reader) error) ([]byte, error) {
+	requestURL := strings.TrimSuffix(c.SessionURL+"/"+endpoint, "/")
+	response, err := c.do(ctx, requestURL, method, requestBody)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		responseBody, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		return responseBody, parseResponseError(response.StatusCode, responseBody)
+	}
+
+	value, err := findStringValue(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := read(value); err != nil {
+		return nil, err
+	}
+	return nil, value.err
+}
+
+func decompress(response *http.Response) error {
+	var (
+		reader io.ReadCloser
+		err    error
+	)
+	switch strings.ToLower(response.Header.Get("Content-Encoding")) {
+	case "gzip":
+		reader, err = gzip.NewReader(response.Body)
+	case "deflate":
+		reader, err = newDeflateReader(response.Body)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	response.Body = &decompressedBody{reader, response.Body}
+	response.Header.Del("Content-Encoding")
+	response.Header.Del("Content-Length")
+	response.ContentLength = -1
+	return nil
+}
+
+type decompressedBody struct {
+	io.ReadCloser
+	body io.Closer
+}
+
+func (d *decompressedBody) Close() error {
+	d.ReadCloser.Close()
+	return d.body.Close()
+}
+
+// The deflate encoding is zlib-wrapped, but some servers send raw deflate
+// data instead.
+func newDeflateReader(body io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(body)
+	header, err := buffered.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+	return flate.NewReader(buffered), nil
+}
+
+// findStringValue reads the response up to the start of its string value,
+// and returns a reader of the unescaped contents of the string.
+func findStringValue(body io.Reader) (*stringReader, error) {
+	decoder := json.NewDecoder(body)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, errors.New("unexpected response: expected a JSON object")
+	}
+
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("unexpected response: %s", err)
+		}
+		if key != "value" {
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return nil, fmt.Errorf("unexpected response: %s", err)
+			}
+			continue
+		}
+
+		value := bufio.NewReader(io.MultiReader(decoder.Buffered(), body))
+		if err := skipToString(value); err != nil {
+			return nil, err
+		}
+		return &stringReader{reader: value}, nil
+	}
+	return nil, errors.New("unexpected response: missing value")
+}
+
+func skipToString(reader *bufio.Reader) error {
+	for {
+		character, err := reader.ReadByte()
+		if err != nil {
+			return fmt.Errorf("unexpected response: %s", err)
+		}
+		switch character {
+		case ' ', '\t', '\r', '\n', ':':
+			continue
+		case '"':
+			return nil
+		}
+		return errors.New("unexpected response: value is not a string")
+	}
+}
+
+// A stringReader reads the contents of a JSON string up to its closing quote,
+// replacing escape sequences with the characters that they represent.
+type stringReader struct {
+	reader  *bufio.Reader
+	pending []byte
+	done    bool
+	err     error
+}
+
+func (s *stringReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(s.pending) > 0 {
+			copied := copy(p[n:], s.pending)
+			s.pending = s.pending[copied:]
+			n += copied
+			continue
+		}
+		if s.done || s.err != nil {
+			break
+		}
+		if n > 0 && s.reader.Buffered() == 0 {
+			break
+		}
+		s.next()
+	}
+
+	if n == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+		if s.done {
+			return 0, io.EOF
+		}
+	}
+	return n, nil
+}
+
+func (s *stringReader) next() {
+	character, err := s.reader.ReadByte()
+	if err != nil {
+		s.fail(err)
+		return
+	}
+	switch character {
+	case '"':
+		s.done = true
+	case '\\':
+		s.unescape()
+	default:
+		s.pending = append(s.pending[:0], character)
+	}
+}
+
+var escapes = map[byte]byte{'"': '"', '\\': '\\', '/': '/', 'b': '\b', 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t'}
+
+func (s *stringReader) unescape() {
+	character, err := s.reader.ReadByte()
+	if err != nil {
+		s.fail(err)
+		return
+	}
+	if unescaped, ok := escapes[character]; ok {
+		s.pending = append(s.pending[:0], unescaped)
+		return
+	}
+	if character != 'u' {
+		s.fail(fmt.Errorf("invalid escape '\\%c'", character))
+		return
+	}
+
+	r, ok := s.readHex()
+	if !ok {
+		return
+	}
+	if utf16.IsSurrogate(r) {
+		low := utf8.RuneError
+		if next, err := s.reader.Peek(2); err == nil && string(next) == `\u` {
+			s.reader.Discard(2)
+			if low, ok = s.readHex(); !ok {
+				return
+			}
+		}
+		r = utf16.DecodeRune(r, low)
+	}
+	encoded := make([]byte, utf8.UTFMax)
+	s.pending = encoded[:utf8.EncodeRune(encoded, r)]
+}
+
+func (s *stringReader) readHex() (rune, bool) {
+	hex := make([]byte, 4)
+	if _, err := io.ReadFull(s.reader, hex); err != nil {
+		s.fail(err)
+		return 0, false
+	}
+	value, err := strconv.ParseUint(string(hex), 16, 16)
+	if err != nil {
+		s.fail(fmt.Errorf("invalid escape '\\u%s'", hex))
+		return 0, false
+	}
+	return rune(value), true
+}
+
+func (s *stringReader) fail(err error) {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	s.err = fmt.Errorf("unexpected response: %s", err)
+}
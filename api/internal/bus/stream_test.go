@@ -0,0 +1,164 @@
+package bus_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api/internal/bus"
+)
+
+var _ = Describe("Streaming", func() {
+	var (
+		acceptEncoding   string
+		responseEncoding string
+		responseBody     []byte
+		responseStatus   int
+		server           *httptest.Server
+		client           *Client
+	)
+
+	BeforeEach(func() {
+		acceptEncoding, responseEncoding, responseBody, responseStatus = "", "", nil, 200
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			acceptEncoding = request.Header.Get("Accept-Encoding")
+			if responseEncoding != "" {
+				response.Header().Set("Content-Encoding", responseEncoding)
+			}
+			response.WriteHeader(responseStatus)
+			response.Write(responseBody)
+		}))
+		client = &Client{
+			SessionURL: server.URL + "/session/some-id",
+			HTTPClient: http.DefaultClient,
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	readValue := func() (string, error) {
+		var value []byte
+		err := client.SendStream("GET", "some/endpoint", nil, func(reader io.Reader) error {
+			var err error
+			value, err = ioutil.ReadAll(reader)
+			return err
+		})
+		return string(value), err
+	}
+
+	Describe("compressed responses", func() {
+		compress := func(newWriter func(io.Writer) io.WriteCloser) []byte {
+			var buffer bytes.Buffer
+			writer := newWriter(&buffer)
+			writer.Write([]byte(`{"value": "some value"}`))
+			writer.Close()
+			return buffer.Bytes()
+		}
+
+		It("should request gzip and deflate encodings", func() {
+			responseBody = []byte(`{"value": "some value"}`)
+			Expect(client.Send("GET", "some/endpoint", nil, nil)).To(Succeed())
+			Expect(acceptEncoding).To(Equal("gzip, deflate"))
+		})
+
+		It("should decompress gzip responses", func() {
+			responseEncoding = "gzip"
+			responseBody = compress(func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) })
+			var result string
+			Expect(client.Send("GET", "some/endpoint", nil, &result)).To(Succeed())
+			Expect(result).To(Equal("some value"))
+		})
+
+		It("should decompress zlib-wrapped and raw deflate responses", func() {
+			responseEncoding = "deflate"
+			responseBody = compress(func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) })
+			Expect(readValue()).To(Equal("some value"))
+
+			responseBody = compress(func(w io.Writer) io.WriteCloser {
+				writer, _ := flate.NewWriter(w, flate.DefaultCompression)
+				return writer
+			})
+			Expect(readValue()).To(Equal("some value"))
+		})
+
+		Context("when the response cannot be decompressed", func() {
+			It("should return an error", func() {
+				responseEncoding = "gzip"
+				responseBody = []byte(`{"value": "some value"}`)
+				err := client.Send("GET", "some/endpoint", nil, nil)
+				Expect(err).To(MatchError("invalid response encoding: gzip: invalid header"))
+			})
+		})
+	})
+
+	Describe("#SendStream", func() {
+		It("should read the unescaped string value of the response", func() {
+			responseBody = []byte(`{"sessionId": "some-id", "status": 0, "value": "some \"value\" é😀 a\/b\\c"}`)
+			Expect(readValue()).To(Equal(`some "value" é😀 a/b\c`))
+		})
+
+		It("should call the hooks without the response body", func() {
+			var result []byte
+			client.AddHook(func(_, _ string, _, hookResult []byte, _ error, _ time.Duration) {
+				result = hookResult
+			})
+			responseBody = []byte(`{"value": "some value"}`)
+			Expect(readValue()).To(Equal("some value"))
+			Expect(result).To(BeNil())
+		})
+
+		Context("when the request is unsuccessful", func() {
+			It("should return the WebDriver error", func() {
+				responseStatus = 404
+				responseBody = []byte(`{"value": {"error": "no such window", "message": "some message"}}`)
+				_, err := readValue()
+				Expect(err).To(MatchError("request unsuccessful: some message"))
+			})
+		})
+
+		Context("when the value is not a string", func() {
+			It("should return an error", func() {
+				responseBody = []byte(`{"value": 3}`)
+				_, err := readValue()
+				Expect(err).To(MatchError("unexpected response: value is not a string"))
+			})
+		})
+
+		Context("when the response is missing a value", func() {
+			It("should return an error", func() {
+				responseBody = []byte(`{"status": 0}`)
+				_, err := readValue()
+				Expect(err).To(MatchError("unexpected response: missing value"))
+			})
+		})
+
+		Context("when the string value is truncated", func() {
+			It("should return an error", func() {
+				responseBody = []byte(`{"value": "some val`)
+				_, err := readValue()
+				Expect(err).To(MatchError("unexpected response: unexpected EOF"))
+			})
+		})
+
+		Context("when the read function fails", func() {
+			It("should return its error", func() {
+				responseBody = []byte(`{"value": "some value"}`)
+				err := client.SendStream("GET", "some/endpoint", nil, func(io.Reader) error {
+					return errors.New("some error")
+				})
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+})
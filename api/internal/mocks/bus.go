@@ -1,9 +1,13 @@
 package mocks
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+)
 
 type Bus struct {
 	SendCall struct {
+		Context  context.Context
 		Endpoint string
 		Method   string
 		BodyJSON []byte
@@ -21,3 +25,8 @@ func (b *Bus) Send(method, endpoint string, body, result interface{}) error {
 	}
 	return b.SendCall.Err
 }
+
+func (b *Bus) SendContext(ctx context.Context, method, endpoint string, body, result interface{}) error {
+	b.SendCall.Context = ctx
+	return b.Send(method, endpoint, body, result)
+}
@@ -22,6 +22,10 @@ type Service struct {
 		Timeout time.Duration
 		Err     error
 	}
+
+	CrashedCall struct {
+		Err error
+	}
 }
 
 func (s *Service) URL() string {
@@ -43,3 +47,7 @@ func (s *Service) WaitForBoot(timeout time.Duration) error {
 	s.WaitForBootCall.Timeout = timeout
 	return s.WaitForBootCall.Err
 }
+
+func (s *Service) Crashed() error {
+	return s.CrashedCall.Err
+}
@@ -0,0 +1,34 @@
+package service
+
+import "sync"
+
+// outputSize is the number of bytes of output retained by a Service.
+const outputSize = 64 * 1024
+
+// A ringBuffer retains the last size bytes written to it.
+type ringBuffer struct {
+	size  int
+	data  []byte
+	mutex sync.Mutex
+}
+
+func newRingBuffer(size int) *ringBuffer {
+	return &ringBuffer{size: size}
+}
+
+func (r *ringBuffer) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.data = append(r.data, p...)
+	if overflow := len(r.data) - r.size; overflow > 0 {
+		r.data = append(r.data[:0], r.data[overflow:]...)
+	}
+	return len(p), nil
+}
+
+func (r *ringBuffer) Bytes() []byte {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]byte(nil), r.data...)
+}
@@ -3,12 +3,14 @@ package service
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
 	"os/exec"
 	"runtime"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -16,8 +18,15 @@ import (
 type Service struct {
 	URLTemplate string
 	CmdTemplate []string
+	Stdout      io.Writer
+	Stderr      io.Writer
+	output      *ringBuffer
 	url         string
+	address     addressInfo
 	command     *exec.Cmd
+	exited      chan struct{}
+	exitErr     error
+	mutex       sync.Mutex
 }
 
 type addressInfo struct {
@@ -27,10 +36,15 @@ type addressInfo struct {
 }
 
 func (s *Service) URL() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	return s.url
 }
 
 func (s *Service) Start(debug bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
 	if s.command != nil {
 		return errors.New("already running")
 	}
@@ -42,20 +56,36 @@ func (s *Service) Start(debug bool) error {
 
 	url, err := buildURL(s.URLTemplate, address)
 	if err != nil {
+		releaseAddress(address)
 		return fmt.Errorf("failed to parse URL: %s", err)
 	}
 
 	command, err := buildCommand(s.CmdTemplate, address)
 	if err != nil {
+		releaseAddress(address)
 		return fmt.Errorf("failed to parse command: %s", err)
 	}
 
+	if s.output == nil {
+		s.output = newRingBuffer(outputSize)
+	}
+	stdout := []io.Writer{s.output}
+	stderr := []io.Writer{s.output}
 	if debug {
-		command.Stdout = os.Stdout
-		command.Stderr = os.Stderr
+		stdout = append(stdout, os.Stdout)
+		stderr = append(stderr, os.Stderr)
+	}
+	if s.Stdout != nil {
+		stdout = append(stdout, s.Stdout)
+	}
+	if s.Stderr != nil {
+		stderr = append(stderr, s.Stderr)
 	}
+	command.Stdout = io.MultiWriter(stdout...)
+	command.Stderr = io.MultiWriter(stderr...)
 
 	if err := command.Start(); err != nil {
+		releaseAddress(address)
 		err = fmt.Errorf("failed to run command: %s", err)
 		if debug {
 			os.Stderr.WriteString("ERROR: " + err.Error() + "\n")
@@ -63,44 +93,125 @@ func (s *Service) Start(debug bool) error {
 		return err
 	}
 
+	exited := make(chan struct{})
+	go func() {
+		err := command.Wait()
+		s.mutex.Lock()
+		s.exitErr = err
+		s.mutex.Unlock()
+		close(exited)
+	}()
+
 	s.command = command
+	s.exited = exited
 	s.url = url
+	s.address = address
 
 	return nil
 }
 
 func (s *Service) Stop() error {
-	if s.command == nil {
+	s.mutex.Lock()
+	command, exited := s.command, s.exited
+	s.mutex.Unlock()
+
+	if command == nil {
 		return errors.New("already stopped")
 	}
 
 	var err error
 	if runtime.GOOS == "windows" {
-		err = s.command.Process.Kill()
+		err = command.Process.Kill()
 	} else {
-		err = s.command.Process.Signal(syscall.SIGTERM)
+		err = command.Process.Signal(syscall.SIGTERM)
 	}
-	if err != nil {
+	if err != nil && err != os.ErrProcessDone {
 		return fmt.Errorf("failed to stop command: %s", err)
 	}
 
-	s.command.Wait()
+	<-exited
+	s.mutex.Lock()
+	releaseAddress(s.address)
 	s.command = nil
+	s.exited = nil
 	s.url = ""
+	s.address = addressInfo{}
+	s.mutex.Unlock()
 
 	return nil
 }
 
+// Output returns the most recent output of the command, including output
+// from before it was last restarted. Stdout and stderr are interleaved.
+func (s *Service) Output() []byte {
+	s.mutex.Lock()
+	output := s.output
+	s.mutex.Unlock()
+
+	if output == nil {
+		return nil
+	}
+	return output.Bytes()
+}
+
+// Crashed returns an error describing how the command exited if it exited
+// before it was stopped, or nil if it is running or was never started.
+func (s *Service) Crashed() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.exited == nil {
+		return nil
+	}
+	select {
+	case <-s.exited:
+		if s.exitErr != nil {
+			return s.exitErr
+		}
+		return errors.New("exit status 0")
+	default:
+		return nil
+	}
+}
+
+// Addresses are reserved until the service that uses them is stopped, as
+// the OS may assign a port that was just released to another service in
+// the same process before the first service has bound it.
+var (
+	reservedAddresses      = map[string]bool{}
+	reservedAddressesMutex sync.Mutex
+)
+
+const maxAddressAttempts = 10
+
 func freeAddress() (addressInfo, error) {
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
-	if err != nil {
-		return addressInfo{}, err
+	reservedAddressesMutex.Lock()
+	defer reservedAddressesMutex.Unlock()
+
+	for attempt := 0; attempt < maxAddressAttempts; attempt++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return addressInfo{}, err
+		}
+		// The listener stays open until a free address is found, so that
+		// the OS does not assign the same reserved port again.
+		defer listener.Close()
+
+		address := listener.Addr().String()
+		if reservedAddresses[address] {
+			continue
+		}
+		reservedAddresses[address] = true
+		addressParts := strings.SplitN(address, ":", 2)
+		return addressInfo{address, addressParts[0], addressParts[1]}, nil
 	}
-	defer listener.Close()
+	return addressInfo{}, errors.New("all assigned ports are in use by other services")
+}
 
-	address := listener.Addr().String()
-	addressParts := strings.SplitN(address, ":", 2)
-	return addressInfo{address, addressParts[0], addressParts[1]}, nil
+func releaseAddress(address addressInfo) {
+	reservedAddressesMutex.Lock()
+	defer reservedAddressesMutex.Unlock()
+	delete(reservedAddresses, address.Address)
 }
 
 func (s *Service) WaitForBoot(timeout time.Duration) error {
@@ -133,7 +244,7 @@ func (s *Service) WaitForBoot(timeout time.Duration) error {
 
 func (s *Service) checkStatus() bool {
 	client := &http.Client{}
-	request, _ := http.NewRequest("GET", fmt.Sprintf("%s/status", s.url), nil)
+	request, _ := http.NewRequest("GET", fmt.Sprintf("%s/status", s.URL()), nil)
 	response, err := client.Do(request)
 	if err == nil && response.StatusCode == 200 {
 		return true
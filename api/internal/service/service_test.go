@@ -1,6 +1,7 @@
 package service_test
 
 import (
+	"bytes"
 	"net/http"
 	"net/http/httptest"
 	"time"
@@ -55,6 +56,32 @@ var _ = Describe("Service", func() {
 			})
 		})
 
+		Context("when many services are started at once", func() {
+			It("should provide each service with a different port", func() {
+				services := make([]*Service, 20)
+				urls := make(chan string, len(services))
+				for i := range services {
+					services[i] = &Service{URLTemplate: "{{.Port}}", CmdTemplate: []string{"sleep", "60"}}
+					go func(service *Service) {
+						defer GinkgoRecover()
+						Expect(service.Start(false)).To(Succeed())
+						urls <- service.URL()
+					}(services[i])
+				}
+
+				ports := map[string]bool{}
+				for range services {
+					var url string
+					Eventually(urls).Should(Receive(&url))
+					ports[url] = true
+				}
+				for _, service := range services {
+					Expect(service.Stop()).To(Succeed())
+				}
+				Expect(ports).To(HaveLen(len(services)))
+			})
+		})
+
 		Context("when the service is started in debug mode", func() {
 			It("should successfully start", func() {
 				defer service.Stop()
@@ -140,6 +167,67 @@ var _ = Describe("Service", func() {
 		})
 	})
 
+	Describe("#Output", func() {
+		It("should return the interleaved output of the command", func() {
+			service.CmdTemplate = []string{"sh", "-c", "echo some-output; echo some-error >&2"}
+			Expect(service.Output()).To(BeEmpty())
+			Expect(service.Start(false)).To(Succeed())
+			Eventually(service.Crashed).ShouldNot(Succeed())
+			Expect(service.Stop()).To(Succeed())
+			Expect(string(service.Output())).To(ContainSubstring("some-output\n"))
+			Expect(string(service.Output())).To(ContainSubstring("some-error\n"))
+		})
+
+		It("should only retain the most recent 64KB of output", func() {
+			service.CmdTemplate = []string{"sh", "-c", "head -c 70000 /dev/zero; printf some-end"}
+			Expect(service.Start(false)).To(Succeed())
+			Eventually(service.Crashed).ShouldNot(Succeed())
+			Expect(service.Stop()).To(Succeed())
+			Expect(service.Output()).To(HaveLen(64 * 1024))
+			Expect(string(service.Output())).To(HaveSuffix("some-end"))
+		})
+
+		Context("when writers are provided", func() {
+			It("should copy stdout and stderr to the writers", func() {
+				stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+				service.Stdout, service.Stderr = stdout, stderr
+				service.CmdTemplate = []string{"sh", "-c", "echo some-output; echo some-error >&2"}
+				Expect(service.Start(false)).To(Succeed())
+				Eventually(service.Crashed).ShouldNot(Succeed())
+				Expect(service.Stop()).To(Succeed())
+				Expect(stdout.String()).To(Equal("some-output\n"))
+				Expect(stderr.String()).To(Equal("some-error\n"))
+			})
+		})
+	})
+
+	Describe("#Crashed", func() {
+		It("should return nil while the command is running or stopped", func() {
+			service.CmdTemplate = []string{"sleep", "10"}
+			Expect(service.Crashed()).To(Succeed())
+			Expect(service.Start(false)).To(Succeed())
+			Expect(service.Crashed()).To(Succeed())
+			Expect(service.Stop()).To(Succeed())
+			Expect(service.Crashed()).To(Succeed())
+		})
+
+		Context("when the command exits before it is stopped", func() {
+			It("should return an error describing how it exited", func() {
+				service.CmdTemplate = []string{"sh", "-c", "exit 3"}
+				Expect(service.Start(false)).To(Succeed())
+				Eventually(service.Crashed).Should(MatchError("exit status 3"))
+			})
+
+			It("should successfully stop the service", func() {
+				service.CmdTemplate = []string{"sh", "-c", "exit 3"}
+				Expect(service.Start(false)).To(Succeed())
+				Eventually(service.Crashed).ShouldNot(Succeed())
+				Expect(service.Stop()).To(Succeed())
+				Expect(service.Crashed()).To(Succeed())
+			})
+		})
+	})
+
 	Describe("#WaitForBoot", func() {
 		var (
 			started bool
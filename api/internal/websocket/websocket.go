@@ -0,0 +1,304 @@
+// Package websocket implements the subset of the WebSocket protocol (RFC 6455)
+// needed to exchange WebDriver BiDi messages: text messages, fragmentation,
+// ping/pong, and closing handshakes. Extensions and subprotocols are not
+// supported.
+package websocket
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// DefaultMaxMessageSize is the largest message, in bytes, that a new Conn
+// will read. It leaves room for large BiDi results such as screenshots.
+const DefaultMaxMessageSize = 64 << 20
+
+// Control frames may not carry more than 125 bytes (RFC 6455, section 5.5).
+const maxControlPayload = 125
+
+const (
+	continuationFrame = 0x0
+	textFrame         = 0x1
+	binaryFrame       = 0x2
+	closeFrame        = 0x8
+	pingFrame         = 0x9
+	pongFrame         = 0xA
+)
+
+// A Conn is a WebSocket connection. Messages may be written by multiple
+// goroutines, but only one goroutine may read messages at a time.
+type Conn struct {
+	// MaxMessageSize limits the size of messages read from the other end.
+	// Larger messages close the connection with an error instead of being
+	// buffered in memory.
+	MaxMessageSize uint64
+
+	conn       net.Conn
+	reader     *bufio.Reader
+	client     bool
+	writeMutex sync.Mutex
+}
+
+// Dial opens a WebSocket connection to the provided ws:// or wss:// URL.
+func Dial(rawURL string) (*Conn, error) {
+	socketURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var conn net.Conn
+	switch socketURL.Scheme {
+	case "ws":
+		conn, err = net.Dial("tcp", hostPort(socketURL, "80"))
+	case "wss":
+		conn, err = tls.Dial("tcp", hostPort(socketURL, "443"), &tls.Config{ServerName: socketURL.Hostname()})
+	default:
+		return nil, fmt.Errorf("invalid WebSocket URL: %s", rawURL)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	keyBytes := make([]byte, 16)
+	if _, err := rand.Read(keyBytes); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(keyBytes)
+
+	request := &http.Request{
+		Method: "GET",
+		URL:    &url.URL{Path: socketURL.EscapedPath(), RawQuery: socketURL.RawQuery},
+		Host:   socketURL.Host,
+		Header: http.Header{
+			"Upgrade":               {"websocket"},
+			"Connection":            {"Upgrade"},
+			"Sec-WebSocket-Key":     {key},
+			"Sec-WebSocket-Version": {"13"},
+		},
+	}
+	if request.URL.Path == "" {
+		request.URL.Path = "/"
+	}
+	if err := request.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, request)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		conn.Close()
+		return nil, fmt.Errorf("WebSocket handshake failed: %s", response.Status)
+	}
+	if response.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		conn.Close()
+		return nil, errors.New("WebSocket handshake failed: invalid Sec-WebSocket-Accept header")
+	}
+
+	return &Conn{MaxMessageSize: DefaultMaxMessageSize, conn: conn, reader: reader, client: true}, nil
+}
+
+// Upgrade accepts a WebSocket connection from a client. It is used to test
+// WebSocket clients.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		http.Error(w, "expected WebSocket upgrade", http.StatusBadRequest)
+		return nil, errors.New("expected WebSocket upgrade")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errors.New("connection cannot be hijacked")
+	}
+	conn, buffer, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	handshake := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(r.Header.Get("Sec-WebSocket-Key")) + "\r\n\r\n"
+	if _, err := conn.Write([]byte(handshake)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &Conn{MaxMessageSize: DefaultMaxMessageSize, conn: conn, reader: buffer.Reader}, nil
+}
+
+func hostPort(socketURL *url.URL, defaultPort string) string {
+	if socketURL.Port() != "" {
+		return socketURL.Host
+	}
+	return net.JoinHostPort(socketURL.Hostname(), defaultPort)
+}
+
+func acceptKey(key string) string {
+	hash := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// ReadMessage returns the next text or binary message. Pings are answered
+// while waiting for a message. When the connection is closed by the other
+// end, the close is acknowledged and io.EOF is returned. Messages larger
+// than MaxMessageSize close the connection and return an error.
+func (c *Conn) ReadMessage() ([]byte, error) {
+	var message []byte
+	for {
+		final, opcode, payload, err := c.readFrame(c.MaxMessageSize - uint64(len(message)))
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case pingFrame:
+			if err := c.writeFrame(pongFrame, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case pongFrame:
+			continue
+		case closeFrame:
+			c.writeFrame(closeFrame, payload)
+			c.conn.Close()
+			return nil, io.EOF
+		}
+
+		message = append(message, payload...)
+		if final {
+			return message, nil
+		}
+	}
+}
+
+// The limit is the number of bytes the current message may still grow by.
+func (c *Conn) readFrame(limit uint64) (final bool, opcode byte, payload []byte, err error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return false, 0, nil, err
+	}
+	final = header[0]&0x80 != 0
+	opcode = header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		if _, err := io.ReadFull(c.reader, extended); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+		if _, err := io.ReadFull(c.reader, extended); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	if opcode >= closeFrame && length > maxControlPayload {
+		c.conn.Close()
+		return false, 0, nil, fmt.Errorf("WebSocket control frame exceeds %d bytes", maxControlPayload)
+	}
+	if opcode < closeFrame && length > limit {
+		c.writeFrame(closeFrame, []byte{0x03, 0xF1})
+		c.conn.Close()
+		return false, 0, nil, fmt.Errorf("WebSocket message exceeds the maximum size of %d bytes", c.MaxMessageSize)
+	}
+
+	var mask []byte
+	if masked {
+		mask = make([]byte, 4)
+		if _, err := io.ReadFull(c.reader, mask); err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for index := range payload {
+			payload[index] ^= mask[index%4]
+		}
+	}
+
+	if opcode != continuationFrame && opcode != textFrame && opcode != binaryFrame &&
+		opcode != closeFrame && opcode != pingFrame && opcode != pongFrame {
+		return false, 0, nil, fmt.Errorf("invalid WebSocket opcode: %d", opcode)
+	}
+	return final, opcode, payload, nil
+}
+
+// WriteMessage sends the provided data as a single text message.
+func (c *Conn) WriteMessage(data []byte) error {
+	return c.writeFrame(textFrame, data)
+}
+
+// Frames sent by clients must be masked.
+func (c *Conn) writeFrame(opcode byte, payload []byte) error {
+	frame := []byte{0x80 | opcode}
+
+	maskBit := byte(0)
+	if c.client {
+		maskBit = 0x80
+	}
+	switch length := len(payload); {
+	case length < 126:
+		frame = append(frame, maskBit|byte(length))
+	case length <= 0xFFFF:
+		frame = append(frame, maskBit|126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(length))
+	default:
+		frame = append(frame, maskBit|127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(length))
+	}
+
+	if c.client {
+		mask := make([]byte, 4)
+		if _, err := rand.Read(mask); err != nil {
+			return err
+		}
+		frame = append(frame, mask...)
+		for index, value := range payload {
+			frame = append(frame, value^mask[index%4])
+		}
+	} else {
+		frame = append(frame, payload...)
+	}
+
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// Close sends a normal closure to the other end and closes the connection.
+func (c *Conn) Close() error {
+	c.writeFrame(closeFrame, []byte{0x03, 0xE8})
+	return c.conn.Close()
+}
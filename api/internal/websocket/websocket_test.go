@@ -0,0 +1,120 @@
+package websocket_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api/internal/websocket"
+)
+
+var _ = Describe("WebSocket", func() {
+	var (
+		server      *httptest.Server
+		serverConns chan *Conn
+		requestPath string
+	)
+
+	BeforeEach(func() {
+		serverConns = make(chan *Conn, 1)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestPath = r.URL.RequestURI()
+			conn, err := Upgrade(w, r)
+			if err == nil {
+				serverConns <- conn
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	socketURL := func(path string) string {
+		return "ws" + strings.TrimPrefix(server.URL, "http") + path
+	}
+
+	Describe(".Dial", func() {
+		It("should open a connection to the provided URL", func() {
+			conn, err := Dial(socketURL("/session/some-id?some=query"))
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+			Eventually(serverConns).Should(Receive())
+			Expect(requestPath).To(Equal("/session/some-id?some=query"))
+		})
+
+		Context("when the URL is not a WebSocket URL", func() {
+			It("should return an error", func() {
+				_, err := Dial(server.URL)
+				Expect(err).To(MatchError("invalid WebSocket URL: " + server.URL))
+			})
+		})
+
+		Context("when the server does not upgrade the connection", func() {
+			It("should return an error", func() {
+				server.Config.Handler = http.NotFoundHandler()
+				_, err := Dial(socketURL("/"))
+				Expect(err).To(MatchError("WebSocket handshake failed: 404 Not Found"))
+			})
+		})
+	})
+
+	Describe("messages", func() {
+		var client, serverConn *Conn
+
+		BeforeEach(func() {
+			var err error
+			client, err = Dial(socketURL("/"))
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(serverConns).Should(Receive(&serverConn))
+		})
+
+		AfterEach(func() {
+			client.Close()
+		})
+
+		It("should send masked messages from the client to the server", func() {
+			Expect(client.WriteMessage([]byte("some message"))).To(Succeed())
+			Expect(serverConn.ReadMessage()).To(Equal([]byte("some message")))
+		})
+
+		It("should send unmasked messages from the server to the client", func() {
+			Expect(serverConn.WriteMessage([]byte("some message"))).To(Succeed())
+			Expect(client.ReadMessage()).To(Equal([]byte("some message")))
+		})
+
+		It("should send messages that require extended lengths", func() {
+			medium := strings.Repeat("a", 1000)
+			large := strings.Repeat("b", 70000)
+			go func() {
+				defer GinkgoRecover()
+				Expect(client.WriteMessage([]byte(medium))).To(Succeed())
+				Expect(client.WriteMessage([]byte(large))).To(Succeed())
+			}()
+			Expect(serverConn.ReadMessage()).To(Equal([]byte(medium)))
+			Expect(serverConn.ReadMessage()).To(Equal([]byte(large)))
+		})
+
+		Context("when a message exceeds the maximum message size", func() {
+			It("should close the connection and return an error", func() {
+				serverConn.MaxMessageSize = 10
+				Expect(client.WriteMessage([]byte("some message"))).To(Succeed())
+				_, err := serverConn.ReadMessage()
+				Expect(err).To(MatchError("WebSocket message exceeds the maximum size of 10 bytes"))
+				_, err = client.ReadMessage()
+				Expect(err).To(Equal(io.EOF))
+			})
+		})
+
+		Context("when the other end closes the connection", func() {
+			It("should return io.EOF", func() {
+				go serverConn.Close()
+				_, err := client.ReadMessage()
+				Expect(err).To(Equal(io.EOF))
+			})
+		})
+	})
+})
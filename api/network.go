@@ -0,0 +1,125 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// NetworkConditions defines the network conditions emulated by the browser.
+type NetworkConditions struct {
+	// Offline disconnects the browser from the network
+	Offline bool
+
+	// Latency is the additional round-trip time of each request
+	Latency time.Duration
+
+	// Download is the download throughput in bytes per second, or 0 for no limit
+	Download int
+
+	// Upload is the upload throughput in bytes per second, or 0 for no limit
+	Upload int
+}
+
+// SetExtraHTTPHeaders adds the provided headers to every request made by the
+// browser, replacing any headers provided previously. Passing nil removes
+// them. This requires a browser that supports the DevTools Protocol.
+func (s *Session) SetExtraHTTPHeaders(headers map[string]string) error {
+	if err := s.ExecuteCDP("Network.enable", nil, nil); err != nil {
+		return err
+	}
+
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	request := struct {
+		Headers map[string]string `json:"headers"`
+	}{headers}
+	return s.ExecuteCDP("Network.setExtraHTTPHeaders", request, nil)
+}
+
+// SetUserAgentOverride overrides the user agent and the Accept-Language
+// header of the browser using the DevTools Protocol. The current user agent
+// is kept if the provided user agent is empty, and the Accept-Language
+// header is unchanged if the provided languages are empty.
+func (s *Session) SetUserAgentOverride(userAgent, acceptLanguage string) error {
+	if userAgent == "" {
+		if err := s.Execute("return navigator.userAgent;", nil, &userAgent); err != nil {
+			return err
+		}
+	}
+
+	request := struct {
+		UserAgent      string `json:"userAgent"`
+		AcceptLanguage string `json:"acceptLanguage,omitempty"`
+	}{userAgent, acceptLanguage}
+	return s.ExecuteCDP("Network.setUserAgentOverride", request, nil)
+}
+
+// SetNetworkConditions emulates the provided network conditions until they
+// are cleared with ClearNetworkConditions. If the WebDriver does not support
+// the ChromeDriver network conditions endpoint, the DevTools Protocol is used
+// instead.
+func (s *Session) SetNetworkConditions(conditions NetworkConditions) error {
+	request := struct {
+		NetworkConditions interface{} `json:"network_conditions"`
+	}{struct {
+		Offline            bool    `json:"offline"`
+		Latency            float64 `json:"latency"`
+		DownloadThroughput int     `json:"download_throughput"`
+		UploadThroughput   int     `json:"upload_throughput"`
+	}{
+		conditions.Offline,
+		conditions.Latency.Seconds() * 1000,
+		throughput(conditions.Download),
+		throughput(conditions.Upload),
+	}}
+
+	err := s.Send("POST", "chromium/network_conditions", request, nil)
+	if err == nil {
+		return nil
+	}
+	if cdpErr := s.emulateNetworkConditions(conditions); cdpErr != nil {
+		return fmt.Errorf("%s (DevTools fallback: %s)", err, cdpErr)
+	}
+	return nil
+}
+
+// ClearNetworkConditions stops emulating the network conditions provided to
+// SetNetworkConditions.
+func (s *Session) ClearNetworkConditions() error {
+	err := s.Send("DELETE", "chromium/network_conditions", nil, nil)
+	if err == nil {
+		return nil
+	}
+	if cdpErr := s.emulateNetworkConditions(NetworkConditions{}); cdpErr != nil {
+		return fmt.Errorf("%s (DevTools fallback: %s)", err, cdpErr)
+	}
+	return nil
+}
+
+func (s *Session) emulateNetworkConditions(conditions NetworkConditions) error {
+	if err := s.ExecuteCDP("Network.enable", nil, nil); err != nil {
+		return err
+	}
+
+	parameters := struct {
+		Offline            bool    `json:"offline"`
+		Latency            float64 `json:"latency"`
+		DownloadThroughput int     `json:"downloadThroughput"`
+		UploadThroughput   int     `json:"uploadThroughput"`
+	}{
+		conditions.Offline,
+		conditions.Latency.Seconds() * 1000,
+		throughput(conditions.Download),
+		throughput(conditions.Upload),
+	}
+	return s.ExecuteCDP("Network.emulateNetworkConditions", parameters, nil)
+}
+
+// Both ChromeDriver and the DevTools Protocol disable throttling with -1.
+func throughput(bytesPerSecond int) int {
+	if bytesPerSecond <= 0 {
+		return -1
+	}
+	return bytesPerSecond
+}
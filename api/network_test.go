@@ -0,0 +1,186 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/mocks"
+)
+
+type networkBus struct {
+	errs   map[string]error
+	bodies map[string][]string
+}
+
+func (b *networkBus) Send(method, endpoint string, body, result interface{}) error {
+	bodyJSON, _ := json.Marshal(body)
+	b.bodies[method+" "+endpoint] = append(b.bodies[method+" "+endpoint], string(bodyJSON))
+	return b.errs[endpoint]
+}
+
+var _ = Describe("Network", func() {
+	var (
+		bus     *mocks.Bus
+		session *Session
+	)
+
+	BeforeEach(func() {
+		bus = &mocks.Bus{}
+		session = &Session{Bus: bus}
+	})
+
+	Describe("#SetExtraHTTPHeaders", func() {
+		It("should successfully set the headers using the DevTools Protocol", func() {
+			Expect(session.SetExtraHTTPHeaders(map[string]string{"Some-Header": "some value"})).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("goog/cdp/execute"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{
+				"cmd": "Network.setExtraHTTPHeaders",
+				"params": {"headers": {"Some-Header": "some value"}}
+			}`))
+		})
+
+		Context("when the headers are nil", func() {
+			It("should remove the headers", func() {
+				Expect(session.SetExtraHTTPHeaders(nil)).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Network.setExtraHTTPHeaders", "params": {"headers": {}}}`))
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.SetExtraHTTPHeaders(nil)).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#SetUserAgentOverride", func() {
+		It("should successfully override the user agent using the DevTools Protocol", func() {
+			Expect(session.SetUserAgentOverride("some-agent", "fr-CA,fr")).To(Succeed())
+			Expect(bus.SendCall.Endpoint).To(Equal("goog/cdp/execute"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{
+				"cmd": "Network.setUserAgentOverride",
+				"params": {"userAgent": "some-agent", "acceptLanguage": "fr-CA,fr"}
+			}`))
+		})
+
+		Context("when no user agent is provided", func() {
+			It("should keep the current user agent of the browser", func() {
+				endpointBus := &networkBus{errs: map[string]error{}, bodies: map[string][]string{}}
+				session = &Session{Bus: endpointBus}
+				Expect(session.SetUserAgentOverride("", "fr-CA")).To(Succeed())
+				Expect(endpointBus.bodies["POST execute"]).To(HaveLen(1))
+				Expect(endpointBus.bodies["POST execute"][0]).To(MatchJSON(`{"script": "return navigator.userAgent;", "args": []}`))
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.SetUserAgentOverride("some-agent", "")).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#SetNetworkConditions", func() {
+		var endpointBus *networkBus
+
+		BeforeEach(func() {
+			endpointBus = &networkBus{errs: map[string]error{}, bodies: map[string][]string{}}
+			session = &Session{Bus: endpointBus}
+		})
+
+		It("should successfully send a POST to the network conditions endpoint", func() {
+			conditions := NetworkConditions{Latency: 150 * time.Millisecond, Download: 1000, Upload: 500}
+			Expect(session.SetNetworkConditions(conditions)).To(Succeed())
+			Expect(endpointBus.bodies["POST chromium/network_conditions"]).To(HaveLen(1))
+			Expect(endpointBus.bodies["POST chromium/network_conditions"][0]).To(MatchJSON(`{"network_conditions": {
+				"offline": false,
+				"latency": 150,
+				"download_throughput": 1000,
+				"upload_throughput": 500
+			}}`))
+			Expect(endpointBus.bodies).NotTo(HaveKey("POST goog/cdp/execute"))
+		})
+
+		It("should disable throttling when no throughput is provided", func() {
+			Expect(session.SetNetworkConditions(NetworkConditions{Offline: true})).To(Succeed())
+			Expect(endpointBus.bodies["POST chromium/network_conditions"][0]).To(MatchJSON(`{"network_conditions": {
+				"offline": true,
+				"latency": 0,
+				"download_throughput": -1,
+				"upload_throughput": -1
+			}}`))
+		})
+
+		Context("when the network conditions endpoint is not supported", func() {
+			BeforeEach(func() {
+				endpointBus.errs["chromium/network_conditions"] = errors.New("some error")
+			})
+
+			It("should emulate the network conditions using the DevTools Protocol", func() {
+				conditions := NetworkConditions{Latency: 2 * time.Second, Download: 50000}
+				Expect(session.SetNetworkConditions(conditions)).To(Succeed())
+				cdpBodies := endpointBus.bodies["POST goog/cdp/execute"]
+				Expect(cdpBodies).To(HaveLen(2))
+				Expect(cdpBodies[0]).To(MatchJSON(`{"cmd": "Network.enable", "params": {}}`))
+				Expect(cdpBodies[1]).To(MatchJSON(`{
+					"cmd": "Network.emulateNetworkConditions",
+					"params": {"offline": false, "latency": 2000, "downloadThroughput": 50000, "uploadThroughput": -1}
+				}`))
+			})
+
+			Context("when the DevTools Protocol is not supported", func() {
+				It("should return both errors", func() {
+					endpointBus.errs["goog/cdp/execute"] = errors.New("some other error")
+					err := session.SetNetworkConditions(NetworkConditions{})
+					Expect(err).To(MatchError("some error (DevTools fallback: some other error)"))
+				})
+			})
+		})
+	})
+
+	Describe("#ClearNetworkConditions", func() {
+		var endpointBus *networkBus
+
+		BeforeEach(func() {
+			endpointBus = &networkBus{errs: map[string]error{}, bodies: map[string][]string{}}
+			session = &Session{Bus: endpointBus}
+		})
+
+		It("should successfully send a DELETE to the network conditions endpoint", func() {
+			Expect(session.ClearNetworkConditions()).To(Succeed())
+			Expect(endpointBus.bodies).To(HaveKey("DELETE chromium/network_conditions"))
+			Expect(endpointBus.bodies).NotTo(HaveKey("POST goog/cdp/execute"))
+		})
+
+		Context("when the network conditions endpoint is not supported", func() {
+			BeforeEach(func() {
+				endpointBus.errs["chromium/network_conditions"] = errors.New("some error")
+			})
+
+			It("should stop emulating network conditions using the DevTools Protocol", func() {
+				Expect(session.ClearNetworkConditions()).To(Succeed())
+				cdpBodies := endpointBus.bodies["POST goog/cdp/execute"]
+				Expect(cdpBodies).To(HaveLen(2))
+				Expect(cdpBodies[1]).To(MatchJSON(`{
+					"cmd": "Network.emulateNetworkConditions",
+					"params": {"offline": false, "latency": 0, "downloadThroughput": -1, "uploadThroughput": -1}
+				}`))
+			})
+
+			Context("when the DevTools Protocol is not supported", func() {
+				It("should return both errors", func() {
+					endpointBus.errs["goog/cdp/execute"] = errors.New("some other error")
+					err := session.ClearNetworkConditions()
+					Expect(err).To(MatchError("some error (DevTools fallback: some other error)"))
+				})
+			})
+		})
+	})
+})
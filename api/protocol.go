@@ -0,0 +1,76 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// W3CElementKey is the key used by the W3C WebDriver dialect to identify
+// web element references in JSON payloads.
+const W3CElementKey = "element-6066-11e4-a52e-4f735466cecf"
+
+type elementResult struct {
+	Element    string `json:"ELEMENT"`
+	W3CElement string `json:"element-6066-11e4-a52e-4f735466cecf"`
+}
+
+func (e elementResult) ID() string {
+	if e.W3CElement != "" {
+		return e.W3CElement
+	}
+	return e.Element
+}
+
+func (s *Session) elementReference(element *Element) interface{} {
+	if s.W3C {
+		return map[string]string{W3CElementKey: element.ID}
+	}
+	return struct {
+		Element string `json:"ELEMENT"`
+	}{element.ID}
+}
+
+// The W3C dialect removed the "id", "name", and "class name" strategies,
+// so they are translated into equivalent CSS attribute selectors.
+func (s *Session) selector(selector Selector) Selector {
+	if !s.W3C {
+		return selector
+	}
+
+	switch selector.Using {
+	case "id":
+		return Selector{"css selector", cssAttribute("id", "=", selector.Value)}
+	case "name":
+		return Selector{"css selector", cssAttribute("name", "=", selector.Value)}
+	case "class name":
+		return Selector{"css selector", cssAttribute("class", "~=", selector.Value)}
+	}
+	return selector
+}
+
+func cssAttribute(attribute, operator, value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return fmt.Sprintf(`[%s%s"%s"]`, attribute, operator, escaped)
+}
+
+// ExecuteCDP sends a command to the browser using the Chrome DevTools Protocol
+// and decodes its return value into the provided result, which may be nil.
+// Parameters may be nil for commands that take no parameters. Only Chrome
+// supports this, as ChromeDriver exposes the protocol through a
+// vendor-specific endpoint. See: https://chromedevtools.github.io/devtools-protocol/
+//
+// Example:
+//    var result struct{ UserAgent string `json:"userAgent"` }
+//    session.ExecuteCDP("Browser.getVersion", nil, &result)
+func (s *Session) ExecuteCDP(command string, parameters, result interface{}) error {
+	if parameters == nil {
+		parameters = struct{}{}
+	}
+
+	request := struct {
+		Command    string      `json:"cmd"`
+		Parameters interface{} `json:"params"`
+	}{command, parameters}
+
+	return s.Send("POST", "goog/cdp/execute", request, result)
+}
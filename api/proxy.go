@@ -0,0 +1,94 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// A ProxyConfig configures the proxies that the browser sends its requests
+// through, ex. to record traffic using a capture proxy such as mitmproxy.
+// See: https://www.w3.org/TR/webdriver/#proxy
+type ProxyConfig struct {
+	// HTTP is the address (host:port) of the proxy for HTTP requests
+	HTTP string
+
+	// SSL is the address (host:port) of the proxy for HTTPS requests
+	SSL string
+
+	// SOCKS is the address (host:port) of a SOCKS proxy for all requests
+	SOCKS string
+
+	// SOCKSVersion is the version of the SOCKS proxy, 4 or 5 (the default)
+	SOCKSVersion int
+
+	// PAC is the URL of a proxy auto-config file, which cannot be combined
+	// with other proxies
+	PAC string
+
+	// NoProxy lists the hosts that requests are sent to directly
+	NoProxy []string
+}
+
+// Validate returns an error if the proxy configuration is incomplete or
+// cannot be represented using the W3C proxy capability.
+func (p ProxyConfig) Validate() error {
+	if p.PAC != "" {
+		if p.HTTP != "" || p.SSL != "" || p.SOCKS != "" || len(p.NoProxy) > 0 {
+			return errors.New("a PAC URL cannot be combined with other proxy settings")
+		}
+		pacURL, err := url.Parse(p.PAC)
+		if err != nil || !pacURL.IsAbs() {
+			return fmt.Errorf("PAC URL must be an absolute URL: %s", p.PAC)
+		}
+		return nil
+	}
+
+	if p.HTTP == "" && p.SSL == "" && p.SOCKS == "" {
+		return errors.New("no proxy address provided")
+	}
+	for _, address := range []string{p.HTTP, p.SSL, p.SOCKS} {
+		if address == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return fmt.Errorf("proxy address must be host:port without a scheme: %s", address)
+		}
+	}
+	if p.SOCKSVersion != 0 && p.SOCKSVersion != 4 && p.SOCKSVersion != 5 {
+		return fmt.Errorf("unsupported SOCKS version: %d", p.SOCKSVersion)
+	}
+	return nil
+}
+
+// Capability returns the value of the W3C proxy capability for the proxy
+// configuration. The configuration should be validated first.
+func (p ProxyConfig) Capability() map[string]interface{} {
+	if p.PAC != "" {
+		return map[string]interface{}{
+			"proxyType":          "pac",
+			"proxyAutoconfigUrl": p.PAC,
+		}
+	}
+
+	capability := map[string]interface{}{"proxyType": "manual"}
+	if p.HTTP != "" {
+		capability["httpProxy"] = p.HTTP
+	}
+	if p.SSL != "" {
+		capability["sslProxy"] = p.SSL
+	}
+	if p.SOCKS != "" {
+		socksVersion := p.SOCKSVersion
+		if socksVersion == 0 {
+			socksVersion = 5
+		}
+		capability["socksProxy"] = p.SOCKS
+		capability["socksVersion"] = socksVersion
+	}
+	if len(p.NoProxy) > 0 {
+		capability["noProxy"] = p.NoProxy
+	}
+	return capability
+}
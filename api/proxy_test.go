@@ -0,0 +1,66 @@
+package api_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+)
+
+var _ = Describe("ProxyConfig", func() {
+	Describe("#Validate", func() {
+		It("should accept manual and PAC proxy configurations", func() {
+			Expect(ProxyConfig{HTTP: "127.0.0.1:8080", SSL: "127.0.0.1:8080"}.Validate()).To(Succeed())
+			Expect(ProxyConfig{SOCKS: "127.0.0.1:1080", SOCKSVersion: 4}.Validate()).To(Succeed())
+			Expect(ProxyConfig{PAC: "http://example.com/proxy.pac"}.Validate()).To(Succeed())
+		})
+
+		It("should reject a PAC URL combined with other proxy settings", func() {
+			err := ProxyConfig{PAC: "http://example.com/proxy.pac", HTTP: "127.0.0.1:8080"}.Validate()
+			Expect(err).To(MatchError("a PAC URL cannot be combined with other proxy settings"))
+		})
+
+		It("should reject a relative PAC URL", func() {
+			Expect(ProxyConfig{PAC: "proxy.pac"}.Validate()).To(MatchError("PAC URL must be an absolute URL: proxy.pac"))
+		})
+
+		It("should reject a configuration without a proxy", func() {
+			Expect(ProxyConfig{NoProxy: []string{"example.com"}}.Validate()).To(MatchError("no proxy address provided"))
+		})
+
+		It("should reject proxy addresses with a scheme", func() {
+			err := ProxyConfig{HTTP: "http://127.0.0.1:8080"}.Validate()
+			Expect(err).To(MatchError("proxy address must be host:port without a scheme: http://127.0.0.1:8080"))
+		})
+
+		It("should reject unsupported SOCKS versions", func() {
+			err := ProxyConfig{SOCKS: "127.0.0.1:1080", SOCKSVersion: 6}.Validate()
+			Expect(err).To(MatchError("unsupported SOCKS version: 6"))
+		})
+	})
+
+	Describe("#Capability", func() {
+		It("should serialize a manual proxy configuration", func() {
+			capability, _ := json.Marshal(ProxyConfig{
+				HTTP:    "127.0.0.1:8080",
+				SSL:     "127.0.0.1:8443",
+				SOCKS:   "127.0.0.1:1080",
+				NoProxy: []string{"localhost", ".example.com"},
+			}.Capability())
+			Expect(capability).To(MatchJSON(`{
+				"proxyType": "manual",
+				"httpProxy": "127.0.0.1:8080",
+				"sslProxy": "127.0.0.1:8443",
+				"socksProxy": "127.0.0.1:1080",
+				"socksVersion": 5,
+				"noProxy": ["localhost", ".example.com"]
+			}`))
+		})
+
+		It("should serialize a PAC proxy configuration", func() {
+			capability, _ := json.Marshal(ProxyConfig{PAC: "http://example.com/proxy.pac"}.Capability())
+			Expect(capability).To(MatchJSON(`{"proxyType": "pac", "proxyAutoconfigUrl": "http://example.com/proxy.pac"}`))
+		})
+	})
+})
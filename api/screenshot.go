@@ -0,0 +1,148 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/draw"
+	"image/png"
+)
+
+// GetFullPageScreenshot returns a PNG screenshot of the entire page,
+// including any content outside of the viewport. The DevTools Protocol is
+// used when the browser supports it. Otherwise, the page is scrolled one
+// viewport at a time and the resulting screenshots are stitched together.
+func (s *Session) GetFullPageScreenshot() ([]byte, error) {
+	if screenshot, err := s.getCDPFullPageScreenshot(); err == nil {
+		return screenshot, nil
+	}
+	return s.getStitchedScreenshot()
+}
+
+type size struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+func (s *Session) getCDPFullPageScreenshot() ([]byte, error) {
+	var metrics struct {
+		ContentSize    size  `json:"contentSize"`
+		CSSContentSize *size `json:"cssContentSize"`
+	}
+	if err := s.ExecuteCDP("Page.getLayoutMetrics", nil, &metrics); err != nil {
+		return nil, err
+	}
+
+	content := metrics.ContentSize
+	if metrics.CSSContentSize != nil {
+		content = *metrics.CSSContentSize
+	}
+
+	request := map[string]interface{}{
+		"format":                "png",
+		"captureBeyondViewport": true,
+		"clip": map[string]interface{}{
+			"x":      0,
+			"y":      0,
+			"width":  content.Width,
+			"height": content.Height,
+			"scale":  1,
+		},
+	}
+	var result struct {
+		Data string `json:"data"`
+	}
+	if err := s.ExecuteCDP("Page.captureScreenshot", request, &result); err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(result.Data)
+}
+
+type scrollPosition struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+const pageDimensionsScript = `var root = document.documentElement, body = document.body || root;
+	return {
+		width: Math.max(root.scrollWidth, body.scrollWidth),
+		height: Math.max(root.scrollHeight, body.scrollHeight),
+		viewportWidth: root.clientWidth || window.innerWidth,
+		viewportHeight: root.clientHeight || window.innerHeight,
+		x: window.pageXOffset,
+		y: window.pageYOffset
+	};`
+
+const scrollScript = `window.scrollTo(arguments[0], arguments[1]);
+	return {x: Math.round(window.pageXOffset), y: Math.round(window.pageYOffset)};`
+
+func (s *Session) getStitchedScreenshot() ([]byte, error) {
+	var page struct {
+		Width          int `json:"width"`
+		Height         int `json:"height"`
+		ViewportWidth  int `json:"viewportWidth"`
+		ViewportHeight int `json:"viewportHeight"`
+		scrollPosition
+	}
+	if err := s.Execute(pageDimensionsScript, nil, &page); err != nil {
+		return nil, err
+	}
+	if page.Width <= 0 || page.Height <= 0 || page.ViewportWidth <= 0 || page.ViewportHeight <= 0 {
+		return nil, errors.New("failed to determine page size")
+	}
+
+	var canvas *image.RGBA
+	var scale float64
+	for y := 0; y < page.Height; y += page.ViewportHeight {
+		for x := 0; x < page.Width; x += page.ViewportWidth {
+			var position scrollPosition
+			if err := s.Execute(scrollScript, []interface{}{x, y}, &position); err != nil {
+				return nil, err
+			}
+
+			tile, err := s.getScreenshotImage()
+			if err != nil {
+				return nil, err
+			}
+
+			// screenshots are captured in device pixels rather than CSS pixels
+			if canvas == nil {
+				scale = float64(tile.Bounds().Dx()) / float64(page.ViewportWidth)
+				canvas = image.NewRGBA(image.Rect(0, 0, scaled(page.Width, scale), scaled(page.Height, scale)))
+			}
+
+			viewport := image.Rect(0, 0, scaled(page.ViewportWidth, scale), scaled(page.ViewportHeight, scale))
+			offset := image.Pt(scaled(position.X, scale), scaled(position.Y, scale))
+			draw.Draw(canvas, viewport.Add(offset), tile, tile.Bounds().Min, draw.Src)
+		}
+	}
+
+	if err := s.Execute(scrollScript, []interface{}{page.X, page.Y}, nil); err != nil {
+		return nil, err
+	}
+
+	var screenshot bytes.Buffer
+	if err := png.Encode(&screenshot, canvas); err != nil {
+		return nil, err
+	}
+	return screenshot.Bytes(), nil
+}
+
+func (s *Session) getScreenshotImage() (image.Image, error) {
+	screenshot, err := s.GetScreenshot()
+	if err != nil {
+		return nil, err
+	}
+
+	tile, _, err := image.Decode(bytes.NewReader(screenshot))
+	if err != nil {
+		return nil, err
+	}
+	return tile, nil
+}
+
+func scaled(length int, scale float64) int {
+	return round(float64(length) * scale)
+}
@@ -0,0 +1,157 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+)
+
+type screenshotBus struct {
+	cdpErr    error
+	cdpBodies []string
+	tiles     map[string]color.Color
+	position  [2]int
+	scrolls   [][2]int
+}
+
+func (b *screenshotBus) Send(method, endpoint string, body, result interface{}) error {
+	bodyJSON, _ := json.Marshal(body)
+
+	var response string
+	switch endpoint {
+	case "goog/cdp/execute":
+		if b.cdpErr != nil {
+			return b.cdpErr
+		}
+		b.cdpBodies = append(b.cdpBodies, string(bodyJSON))
+		if strings.Contains(string(bodyJSON), "Page.getLayoutMetrics") {
+			response = `{"contentSize": {"width": 100, "height": 200}, "cssContentSize": {"width": 10, "height": 20}}`
+		} else {
+			response = `{"data": "c29tZS1wbmc="}`
+		}
+	case "execute":
+		var request struct {
+			Script string
+			Args   []int
+		}
+		json.Unmarshal(bodyJSON, &request)
+		if len(request.Args) == 2 {
+			b.position = [2]int{lesser(request.Args[0], 2), lesser(request.Args[1], 3)}
+			b.scrolls = append(b.scrolls, b.position)
+			response = fmt.Sprintf(`{"x": %d, "y": %d}`, b.position[0], b.position[1])
+		} else {
+			response = `{"width": 4, "height": 5, "viewportWidth": 2, "viewportHeight": 2, "x": 1, "y": 1}`
+		}
+	case "screenshot":
+		key := fmt.Sprintf("%d,%d", b.position[0], b.position[1])
+		response = fmt.Sprintf("%q", solidPNG(b.tiles[key]))
+	}
+
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal([]byte(response), result)
+}
+
+func lesser(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// screenshots are captured at twice the CSS pixel size of the viewport
+func solidPNG(fill color.Color) string {
+	tile := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 4; y++ {
+			tile.Set(x, y, fill)
+		}
+	}
+	var buffer bytes.Buffer
+	png.Encode(&buffer, tile)
+	return base64.StdEncoding.EncodeToString(buffer.Bytes())
+}
+
+var _ = Describe("Screenshot", func() {
+	var (
+		bus     *screenshotBus
+		session *Session
+	)
+
+	BeforeEach(func() {
+		bus = &screenshotBus{}
+		session = &Session{Bus: bus}
+	})
+
+	Describe("#GetFullPageScreenshot", func() {
+		Context("when the browser supports the DevTools Protocol", func() {
+			It("should capture the entire page using the CSS content size", func() {
+				Expect(session.GetFullPageScreenshot()).To(Equal([]byte("some-png")))
+				Expect(bus.cdpBodies).To(HaveLen(2))
+				Expect(bus.cdpBodies[0]).To(MatchJSON(`{"cmd": "Page.getLayoutMetrics", "params": {}}`))
+				Expect(bus.cdpBodies[1]).To(MatchJSON(`{
+					"cmd": "Page.captureScreenshot",
+					"params": {
+						"format": "png",
+						"captureBeyondViewport": true,
+						"clip": {"x": 0, "y": 0, "width": 10, "height": 20, "scale": 1}
+					}
+				}`))
+			})
+		})
+
+		Context("when the browser does not support the DevTools Protocol", func() {
+			var (
+				red   = color.RGBA{255, 0, 0, 255}
+				green = color.RGBA{0, 255, 0, 255}
+				blue  = color.RGBA{0, 0, 255, 255}
+				white = color.RGBA{255, 255, 255, 255}
+			)
+
+			BeforeEach(func() {
+				bus.cdpErr = errors.New("some error")
+				bus.tiles = map[string]color.Color{
+					"0,0": red, "2,0": green,
+					"0,2": blue, "2,2": white,
+					"0,3": green, "2,3": red,
+				}
+			})
+
+			It("should scroll through each viewport and restore the original scroll position", func() {
+				_, err := session.GetFullPageScreenshot()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bus.scrolls).To(Equal([][2]int{
+					{0, 0}, {2, 0},
+					{0, 2}, {2, 2},
+					{0, 3}, {2, 3},
+					{1, 1},
+				}))
+			})
+
+			It("should stitch the viewport screenshots together at device pixel scale", func() {
+				screenshot, err := session.GetFullPageScreenshot()
+				Expect(err).NotTo(HaveOccurred())
+				stitched, err := png.Decode(bytes.NewReader(screenshot))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stitched.Bounds()).To(Equal(image.Rect(0, 0, 8, 10)))
+				Expect(stitched.At(0, 0)).To(Equal(red))
+				Expect(stitched.At(7, 3)).To(Equal(green))
+				Expect(stitched.At(0, 4)).To(Equal(blue))
+				Expect(stitched.At(7, 5)).To(Equal(white))
+				Expect(stitched.At(0, 9)).To(Equal(green))
+				Expect(stitched.At(7, 9)).To(Equal(red))
+			})
+		})
+	})
+})
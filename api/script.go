@@ -0,0 +1,26 @@
+package api
+
+import "fmt"
+
+// AddInitScript runs the provided JavaScript in every new document of the
+// browser before the scripts of the document are run, including documents
+// loaded by links, reloads, and history navigation. The DevTools Protocol is
+// used if available, and otherwise a WebDriver BiDi preload script is added.
+func (s *Session) AddInitScript(script string) error {
+	request := struct {
+		Source string `json:"source"`
+	}{script}
+
+	err := s.ExecuteCDP("Page.addScriptToEvaluateOnNewDocument", request, nil)
+	if err == nil {
+		return nil
+	}
+
+	preload := struct {
+		FunctionDeclaration string `json:"functionDeclaration"`
+	}{"function() {\n" + script + "\n}"}
+	if bidiErr := s.ExecuteBiDi("script.addPreloadScript", preload, nil); bidiErr != nil {
+		return fmt.Errorf("%s (BiDi fallback: %s)", err, bidiErr)
+	}
+	return nil
+}
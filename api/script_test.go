@@ -0,0 +1,43 @@
+package api_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/mocks"
+)
+
+var _ = Describe("Script", func() {
+	var (
+		bus     *mocks.Bus
+		session *Session
+	)
+
+	BeforeEach(func() {
+		bus = &mocks.Bus{}
+		session = &Session{Bus: bus}
+	})
+
+	Describe("#AddInitScript", func() {
+		It("should add the script to new documents using the DevTools Protocol", func() {
+			Expect(session.AddInitScript("some script")).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("goog/cdp/execute"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{
+				"cmd": "Page.addScriptToEvaluateOnNewDocument",
+				"params": {"source": "some script"}
+			}`))
+		})
+
+		Context("when neither the DevTools Protocol nor WebDriver BiDi is supported", func() {
+			It("should return both errors", func() {
+				bus.SendCall.Err = errors.New("some error")
+				err := session.AddInitScript("some script")
+				Expect(err).To(MatchError("some error (BiDi fallback: WebDriver BiDi is not available: " +
+					"the session was not opened with the webSocketUrl capability)"))
+			})
+		})
+	})
+})
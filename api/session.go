@@ -1,16 +1,81 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
 	"errors"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"path"
+	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/sclevine/agouti/api/internal/bus"
 )
 
 type Session struct {
 	Bus
+
+	// W3C is true when the remote end speaks the W3C WebDriver dialect
+	// instead of the legacy JSON Wire Protocol. Sessions opened with Open
+	// or OpenWithClient detect the dialect automatically.
+	W3C bool
+
+	// WebSocketURL is the WebDriver BiDi URL of the session, which is only
+	// provided by the remote end when the webSocketUrl capability is true.
+	// See Subscribe.
+	WebSocketURL string
+
+	// Capabilities are the capabilities of the session returned by the
+	// remote end when the session was created, ex. the browserVersion. They
+	// are nil for sessions attached using OpenWithSessionID.
+	Capabilities map[string]interface{}
+
+	state        *sessionState
+	stateOnce    sync.Once
+	ignoreAlerts bool
+}
+
+// The sessionState of a session is shared with copies of the session, such
+// as those returned by WithContext, so that they refer to the same session
+// ID, BiDi connection, HTTP authentication handler, and AlertHandler.
+type sessionState struct {
+	id      string
+	idMutex sync.Mutex
+
+	bidi      *bidiClient
+	bidiMutex sync.Mutex
+
+	auth      *authHandler
+	authMutex sync.Mutex
+
+	uploadErr   error
+	uploadMutex sync.Mutex
+
+	alertHandler AlertHandler
+	alertMutex   sync.Mutex
+}
+
+// Sessions that are not opened by this package, ex. in tests, create their
+// state when it is first used.
+func (s *Session) shared() *sessionState {
+	s.stateOnce.Do(func() {
+		if s.state == nil {
+			s.state = &sessionState{}
+		}
+	})
+	return s.state
+}
+
+func (s *sessionState) setID(id string) {
+	s.idMutex.Lock()
+	defer s.idMutex.Unlock()
+	s.id = id
 }
 
 type Bus interface {
@@ -22,63 +87,140 @@ func Open(url string, capabilities map[string]interface{}) (*Session, error) {
 }
 
 func OpenWithClient(url string, capabilities map[string]interface{}, client *http.Client) (*Session, error) {
-	busClient, err := bus.Connect(url, capabilities, client)
+	return OpenContext(context.Background(), url, capabilities, client)
+}
+
+// OpenContext opens a session like OpenWithClient, aborting the new session
+// request if the provided context is done before the session is created.
+func OpenContext(ctx context.Context, url string, capabilities map[string]interface{}, client *http.Client) (*Session, error) {
+	busClient, err := bus.ConnectContext(ctx, url, capabilities, client)
 	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &Session{
+		Bus:          busClient,
+		W3C:          busClient.W3C,
+		WebSocketURL: busClient.WebSocketURL,
+		Capabilities: busClient.Capabilities,
+		state:        &sessionState{id: path.Base(busClient.SessionURL)},
+	}, nil
+}
+
+// W3CCapabilities translates legacy desired capabilities, such as
+// "acceptSslCerts", into the W3C dialect. Only standard and vendor-prefixed
+// (ex. "moz:") capabilities are kept.
+func W3CCapabilities(capabilities map[string]interface{}) map[string]interface{} {
+	return bus.W3CCapabilities(capabilities)
+}
+
+// OpenWithSessionID attaches to a session that is already running, such as
+// a session opened by another process. The session is not deleted when the
+// returned *Session is discarded.
+func OpenWithSessionID(url, sessionID string) (*Session, error) {
+	return OpenWithSessionIDAndClient(url, sessionID, nil)
+}
+
+// OpenWithSessionIDAndClient attaches to a session that is already running
+// using the provided *http.Client. The dialect of the session is detected
+// by requesting its timeouts, which only the W3C dialect supports.
+func OpenWithSessionIDAndClient(url, sessionID string, client *http.Client) (*Session, error) {
+	if sessionID == "" {
+		return nil, errors.New("empty session ID")
+	}
+
+	busClient := bus.Attach(url, sessionID, client)
+	session := &Session{Bus: busClient, state: &sessionState{id: sessionID}}
+
+	var timeouts struct {
+		PageLoad *int `json:"pageLoad"`
+	}
+	if err := session.Send("GET", "timeouts", nil, &timeouts); err == nil && timeouts.PageLoad != nil {
+		session.W3C, busClient.W3C = true, true
+		return session, nil
+	}
+
+	if _, err := session.GetURL(); err != nil {
 		return nil, err
 	}
-	return &Session{busClient}, nil
+	return session, nil
+}
+
+// ID returns the ID of the session, which may be provided to
+// OpenWithSessionID to attach to the session from another process.
+func (s *Session) ID() string {
+	state := s.shared()
+	state.idMutex.Lock()
+	defer state.idMutex.Unlock()
+	return state.id
 }
 
 func (s *Session) Delete() error {
+	s.CloseBiDi()
+	s.ClearHTTPAuth()
 	return s.Send("DELETE", "", nil, nil)
 }
 
 func (s *Session) GetElement(selector Selector) (*Element, error) {
-	var result struct{ Element string }
+	var result elementResult
 
-	if err := s.Send("POST", "element", selector, &result); err != nil {
+	if err := s.Send("POST", "element", s.selector(selector), &result); err != nil {
 		return nil, err
 	}
 
-	return &Element{result.Element, s}, nil
+	return &Element{result.ID(), s}, nil
 }
 
 func (s *Session) GetElements(selector Selector) ([]*Element, error) {
-	var results []struct{ Element string }
+	var results []elementResult
 
-	if err := s.Send("POST", "elements", selector, &results); err != nil {
+	if err := s.Send("POST", "elements", s.selector(selector), &results); err != nil {
 		return nil, err
 	}
 
 	elements := []*Element{}
 	for _, result := range results {
-		elements = append(elements, &Element{result.Element, s})
+		elements = append(elements, &Element{result.ID(), s})
 	}
 
 	return elements, nil
 }
 
 func (s *Session) GetActiveElement() (*Element, error) {
-	var result struct{ Element string }
+	var result elementResult
 
-	if err := s.Send("POST", "element/active", nil, &result); err != nil {
+	method := "POST"
+	if s.W3C {
+		method = "GET"
+	}
+
+	if err := s.Send(method, "element/active", nil, &result); err != nil {
 		return nil, err
 	}
 
-	return &Element{result.Element, s}, nil
+	return &Element{result.ID(), s}, nil
 }
 
 func (s *Session) GetWindow() (*Window, error) {
+	endpoint := "window_handle"
+	if s.W3C {
+		endpoint = "window"
+	}
+
 	var windowID string
-	if err := s.Send("GET", "window_handle", nil, &windowID); err != nil {
+	if err := s.Send("GET", endpoint, nil, &windowID); err != nil {
 		return nil, err
 	}
 	return &Window{windowID, s}, nil
 }
 
 func (s *Session) GetWindows() ([]*Window, error) {
+	endpoint := "window_handles"
+	if s.W3C {
+		endpoint = "window/handles"
+	}
+
 	var windowsID []string
-	if err := s.Send("GET", "window_handles", nil, &windowsID); err != nil {
+	if err := s.Send("GET", endpoint, nil, &windowsID); err != nil {
 		return nil, err
 	}
 
@@ -94,14 +236,18 @@ func (s *Session) SetWindow(window *Window) error {
 		return errors.New("nil window is invalid")
 	}
 
-	request := struct {
-		Name string `json:"name"`
-	}{window.ID}
-
-	return s.Send("POST", "window", request, nil)
+	return s.SetWindowByName(window.ID)
 }
 
 func (s *Session) SetWindowByName(name string) error {
+	if s.W3C {
+		request := struct {
+			Handle string `json:"handle"`
+		}{name}
+
+		return s.Send("POST", "window", request, nil)
+	}
+
 	request := struct {
 		Name string `json:"name"`
 	}{name}
@@ -109,6 +255,27 @@ func (s *Session) SetWindowByName(name string) error {
 	return s.Send("POST", "window", request, nil)
 }
 
+// NewWindow opens a new top-level browsing context and returns it without
+// switching to it. The kind must be "tab" or "window", though the WebDriver
+// may open the other kind if the requested one is unsupported.
+func (s *Session) NewWindow(kind string) (*Window, error) {
+	if kind != "tab" && kind != "window" {
+		return nil, fmt.Errorf("invalid window type: %s", kind)
+	}
+
+	request := struct {
+		Type string `json:"type"`
+	}{kind}
+
+	var result struct {
+		Handle string `json:"handle"`
+	}
+	if err := s.Send("POST", "window/new", request, &result); err != nil {
+		return nil, err
+	}
+	return &Window{result.Handle, s}, nil
+}
+
 func (s *Session) DeleteWindow() error {
 	if err := s.Send("DELETE", "window", nil, nil); err != nil {
 		return err
@@ -124,10 +291,27 @@ func (s *Session) GetCookies() ([]*Cookie, error) {
 	return cookies, nil
 }
 
+// GetNamedCookie returns the cookie with the provided name that is visible
+// to the current page. If no such cookie exists, the returned error satisfies
+// IsNoSuchCookie.
+func (s *Session) GetNamedCookie(name string) (*Cookie, error) {
+	var cookie Cookie
+	if err := s.Send("GET", "cookie/"+name, nil, &cookie); err != nil {
+		return nil, err
+	}
+	return &cookie, nil
+}
+
+// SetCookie adds the provided cookie to the current page. Cookies that would
+// be rejected by the browser are rejected without sending them, as described
+// by Cookie.Validate.
 func (s *Session) SetCookie(cookie *Cookie) error {
 	if cookie == nil {
 		return errors.New("nil cookie is invalid")
 	}
+	if err := cookie.Validate(); err != nil {
+		return err
+	}
 	request := struct {
 		Cookie *Cookie `json:"cookie"`
 	}{cookie}
@@ -144,13 +328,21 @@ func (s *Session) DeleteCookies() error {
 }
 
 func (s *Session) GetScreenshot() ([]byte, error) {
-	var base64Image string
-
-	if err := s.Send("GET", "screenshot", nil, &base64Image); err != nil {
+	var image bytes.Buffer
+	if err := s.WriteScreenshotTo(&image); err != nil {
 		return nil, err
 	}
+	return image.Bytes(), nil
+}
 
-	return base64.StdEncoding.DecodeString(base64Image)
+// WriteScreenshotTo writes a PNG screenshot to the provided writer. The
+// screenshot is decoded as it is received, so that the entire encoded
+// response is never held in memory.
+func (s *Session) WriteScreenshotTo(w io.Writer) error {
+	return s.sendStream("GET", "screenshot", nil, func(value io.Reader) error {
+		_, err := io.Copy(w, base64.NewDecoder(base64.StdEncoding, value))
+		return err
+	})
 }
 
 func (s *Session) GetURL() (string, error) {
@@ -170,6 +362,10 @@ func (s *Session) SetURL(url string) error {
 	return s.Send("POST", "url", request, nil)
 }
 
+func (s *Session) SetURLContext(ctx context.Context, url string) error {
+	return s.WithContext(ctx).SetURL(url)
+}
+
 func (s *Session) GetTitle() (string, error) {
 	var title string
 	if err := s.Send("GET", "title", nil, &title); err != nil {
@@ -180,15 +376,27 @@ func (s *Session) GetTitle() (string, error) {
 }
 
 func (s *Session) GetSource() (string, error) {
-	var source string
-	if err := s.Send("GET", "source", nil, &source); err != nil {
+	var source strings.Builder
+	if err := s.WriteSourceTo(&source); err != nil {
 		return "", err
 	}
+	return source.String(), nil
+}
 
-	return source, nil
+// WriteSourceTo writes the source of the current page to the provided
+// writer as it is received.
+func (s *Session) WriteSourceTo(w io.Writer) error {
+	return s.sendStream("GET", "source", nil, func(value io.Reader) error {
+		_, err := io.Copy(w, value)
+		return err
+	})
 }
 
 func (s *Session) MoveTo(region *Element, offset Offset) error {
+	if s.W3C {
+		return s.moveToW3C(region, offset)
+	}
+
 	request := map[string]interface{}{}
 
 	if region != nil {
@@ -208,13 +416,34 @@ func (s *Session) MoveTo(region *Element, offset Offset) error {
 	return s.Send("POST", "moveto", request, nil)
 }
 
+// W3C pointer moves are relative to the center of an element rather than
+// its top-left corner, so offsets from an element are adjusted accordingly.
+func (s *Session) moveToW3C(region *Element, offset Offset) error {
+	var x, y int
+	if offset != nil {
+		x, y = offset.position()
+	}
+
+	if region == nil {
+		return s.PerformActions(NewActions().PointerMoveBy(x, y))
+	}
+
+	if offset != nil {
+		rect, err := region.GetRect()
+		if err != nil {
+			return err
+		}
+		x -= round(rect.Width / 2)
+		y -= round(rect.Height / 2)
+	}
+	return s.PerformActions(NewActions().PointerMove(region, x, y))
+}
+
 func (s *Session) Frame(frame *Element) error {
 	var elementID interface{}
 
 	if frame != nil {
-		elementID = struct {
-			Element string `json:"ELEMENT"`
-		}{frame.ID}
+		elementID = s.elementReference(frame)
 	}
 
 	request := struct {
@@ -224,27 +453,75 @@ func (s *Session) Frame(frame *Element) error {
 	return s.Send("POST", "frame", request, nil)
 }
 
+// FrameIndex switches to the child frame of the current frame with the
+// provided index in window.frames.
+func (s *Session) FrameIndex(index int) error {
+	request := struct {
+		ID int `json:"id"`
+	}{index}
+
+	return s.Send("POST", "frame", request, nil)
+}
+
 func (s *Session) FrameParent() error {
 	return s.Send("POST", "frame/parent", nil, nil)
 }
 
+// Execute runs the provided script synchronously. Arguments that are
+// *Elements are sent as references to those elements. Elements returned by
+// the script may be decoded into *Element values anywhere within the result,
+// and are provided as *Element values within interface{} values of the
+// result, instead of as references.
+//
+// Example:
+//    var items []*api.Element
+//    err := session.Execute("return document.querySelectorAll('li.item');", nil, &items)
 func (s *Session) Execute(body string, arguments []interface{}, result interface{}) error {
-	if arguments == nil {
-		arguments = []interface{}{}
+	endpoint := "execute"
+	if s.W3C {
+		endpoint = "execute/sync"
+	}
+	return s.execute(endpoint, body, arguments, result)
+}
+
+// ExecuteAsync runs the provided script asynchronously. The script must call
+// the callback provided as its final argument to complete. The value passed
+// to the callback is unmarshalled into the result argument. The duration the
+// browser waits for the callback is set with SetScriptTimeout.
+func (s *Session) ExecuteAsync(body string, arguments []interface{}, result interface{}) error {
+	endpoint := "execute_async"
+	if s.W3C {
+		endpoint = "execute/async"
+	}
+	return s.execute(endpoint, body, arguments, result)
+}
+
+func (s *Session) execute(endpoint, body string, arguments []interface{}, result interface{}) error {
+	references := []interface{}{}
+	for _, argument := range arguments {
+		if element, ok := argument.(*Element); ok {
+			argument = s.elementReference(element)
+		}
+		references = append(references, argument)
 	}
 
 	request := struct {
 		Script string        `json:"script"`
 		Args   []interface{} `json:"args"`
-	}{body, arguments}
+	}{body, references}
 
-	if err := s.Send("POST", "execute", request, result); err != nil {
+	if err := s.Send("POST", endpoint, request, result); err != nil {
 		return err
 	}
 
+	s.hydrate(reflect.ValueOf(result))
 	return nil
 }
 
+func (s *Session) ExecuteContext(ctx context.Context, body string, arguments []interface{}, result interface{}) error {
+	return s.WithContext(ctx).Execute(body, arguments, result)
+}
+
 func (s *Session) Forward() error {
 	return s.Send("POST", "forward", nil, nil)
 }
@@ -259,7 +536,7 @@ func (s *Session) Refresh() error {
 
 func (s *Session) GetAlertText() (string, error) {
 	var text string
-	if err := s.Send("GET", "alert_text", nil, &text); err != nil {
+	if err := s.Send("GET", s.alertEndpoint("alert_text", "alert/text"), nil, &text); err != nil {
 		return "", err
 	}
 	return text, nil
@@ -269,15 +546,22 @@ func (s *Session) SetAlertText(text string) error {
 	request := struct {
 		Text string `json:"text"`
 	}{text}
-	return s.Send("POST", "alert_text", request, nil)
+	return s.Send("POST", s.alertEndpoint("alert_text", "alert/text"), request, nil)
 }
 
 func (s *Session) AcceptAlert() error {
-	return s.Send("POST", "accept_alert", nil, nil)
+	return s.Send("POST", s.alertEndpoint("accept_alert", "alert/accept"), nil, nil)
 }
 
 func (s *Session) DismissAlert() error {
-	return s.Send("POST", "dismiss_alert", nil, nil)
+	return s.Send("POST", s.alertEndpoint("dismiss_alert", "alert/dismiss"), nil, nil)
+}
+
+func (s *Session) alertEndpoint(legacy, w3c string) string {
+	if s.W3C {
+		return w3c
+	}
+	return legacy
 }
 
 func (s *Session) NewLogs(logType string) ([]Log, error) {
@@ -301,10 +585,21 @@ func (s *Session) GetLogTypes() ([]string, error) {
 }
 
 func (s *Session) DoubleClick() error {
+	if s.W3C {
+		return s.PerformActions(NewActions().
+			PointerDown(LeftButton).
+			PointerUp(LeftButton).
+			PointerDown(LeftButton).
+			PointerUp(LeftButton))
+	}
 	return s.Send("POST", "doubleclick", nil, nil)
 }
 
 func (s *Session) Click(button Button) error {
+	if s.W3C {
+		return s.PerformActions(NewActions().PointerDown(button).PointerUp(button))
+	}
+
 	request := struct {
 		Button Button `json:"button"`
 	}{button}
@@ -312,6 +607,10 @@ func (s *Session) Click(button Button) error {
 }
 
 func (s *Session) ButtonDown(button Button) error {
+	if s.W3C {
+		return s.PerformActions(NewActions().PointerDown(button))
+	}
+
 	request := struct {
 		Button Button `json:"button"`
 	}{button}
@@ -319,6 +618,10 @@ func (s *Session) ButtonDown(button Button) error {
 }
 
 func (s *Session) ButtonUp(button Button) error {
+	if s.W3C {
+		return s.PerformActions(NewActions().PointerUp(button))
+	}
+
 	request := struct {
 		Button Button `json:"button"`
 	}{button}
@@ -326,6 +629,10 @@ func (s *Session) ButtonUp(button Button) error {
 }
 
 func (s *Session) TouchDown(x, y int) error {
+	if s.W3C {
+		return s.PerformActions(touchActions().PointerMove(nil, x, y).PointerDown(LeftButton))
+	}
+
 	request := struct {
 		X int `json:"x"`
 		Y int `json:"y"`
@@ -334,6 +641,10 @@ func (s *Session) TouchDown(x, y int) error {
 }
 
 func (s *Session) TouchUp(x, y int) error {
+	if s.W3C {
+		return s.PerformActions(touchActions().PointerMove(nil, x, y).PointerUp(LeftButton))
+	}
+
 	request := struct {
 		X int `json:"x"`
 		Y int `json:"y"`
@@ -342,6 +653,10 @@ func (s *Session) TouchUp(x, y int) error {
 }
 
 func (s *Session) TouchMove(x, y int) error {
+	if s.W3C {
+		return s.PerformActions(touchActions().PointerMove(nil, x, y))
+	}
+
 	request := struct {
 		X int `json:"x"`
 		Y int `json:"y"`
@@ -354,6 +669,13 @@ func (s *Session) TouchClick(element *Element) error {
 		return errors.New("nil element is invalid")
 	}
 
+	if s.W3C {
+		return s.PerformActions(touchActions().
+			PointerMove(element, 0, 0).
+			PointerDown(LeftButton).
+			PointerUp(LeftButton))
+	}
+
 	request := struct {
 		Element string `json:"element"`
 	}{element.ID}
@@ -365,6 +687,15 @@ func (s *Session) TouchDoubleClick(element *Element) error {
 		return errors.New("nil element is invalid")
 	}
 
+	if s.W3C {
+		return s.PerformActions(touchActions().
+			PointerMove(element, 0, 0).
+			PointerDown(LeftButton).
+			PointerUp(LeftButton).
+			PointerDown(LeftButton).
+			PointerUp(LeftButton))
+	}
+
 	request := struct {
 		Element string `json:"element"`
 	}{element.ID}
@@ -376,6 +707,14 @@ func (s *Session) TouchLongClick(element *Element) error {
 		return errors.New("nil element is invalid")
 	}
 
+	if s.W3C {
+		return s.PerformActions(touchActions().
+			PointerMove(element, 0, 0).
+			PointerDown(LeftButton).
+			Pause(longPressDuration).
+			PointerUp(LeftButton))
+	}
+
 	request := struct {
 		Element string `json:"element"`
 	}{element.ID}
@@ -391,6 +730,10 @@ func (s *Session) TouchFlick(element *Element, offset Offset, speed Speed) error
 		return errors.New("element must be provided if offset is provided and vice versa")
 	}
 
+	if s.W3C {
+		return s.touchFlickW3C(element, offset, speed)
+	}
+
 	var request interface{}
 	if element == nil {
 		xSpeed, ySpeed := speed.vector()
@@ -411,6 +754,32 @@ func (s *Session) TouchFlick(element *Element, offset Offset, speed Speed) error
 	return s.Send("POST", "touch/flick", request, nil)
 }
 
+// W3C has no flick gesture, so the finger is moved across the screen over
+// the time it takes to travel the distance at the provided speed.
+func (s *Session) touchFlickW3C(element *Element, offset Offset, speed Speed) error {
+	actions := touchActions()
+
+	var x, y int
+	var duration time.Duration
+	if element == nil {
+		xSpeed, ySpeed := speed.vector()
+		x, y = xSpeed/10, ySpeed/10
+		duration = flickDuration
+	} else {
+		x, y = offset.position()
+		if scalar := speed.scalar(); scalar > 0 {
+			distance := math.Hypot(float64(x), float64(y))
+			duration = time.Duration(distance / float64(scalar) * float64(time.Second))
+		}
+		actions.PointerMove(element, 0, 0)
+	}
+
+	return s.PerformActions(actions.
+		PointerDown(LeftButton).
+		PointerMoveBy(x, y).Duration(duration).
+		PointerUp(LeftButton))
+}
+
 func (s *Session) TouchScroll(element *Element, offset Offset) error {
 	if element == nil {
 		element = &Element{}
@@ -421,6 +790,17 @@ func (s *Session) TouchScroll(element *Element, offset Offset) error {
 	}
 
 	xOffset, yOffset := offset.position()
+	if s.W3C {
+		actions := touchActions()
+		if element.ID != "" {
+			actions.PointerMove(element, 0, 0)
+		}
+		return s.PerformActions(actions.
+			PointerDown(LeftButton).
+			PointerMoveBy(xOffset, yOffset).Duration(scrollDuration).
+			PointerUp(LeftButton))
+	}
+
 	request := struct {
 		Element string `json:"element,omitempty"`
 		XOffset int    `json:"xoffset"`
@@ -429,23 +809,38 @@ func (s *Session) TouchScroll(element *Element, offset Offset) error {
 	return s.Send("POST", "touch/scroll", request, nil)
 }
 
+const (
+	longPressDuration = time.Second
+	flickDuration     = 100 * time.Millisecond
+	scrollDuration    = 500 * time.Millisecond
+)
+
+func touchActions() *Actions {
+	return NewActions().Pointer("finger", TouchPointer)
+}
+
 func (s *Session) Keys(text string) error {
 	splitText := strings.Split(text, "")
+
+	if s.W3C {
+		actions := NewActions()
+		for _, key := range splitText {
+			actions.KeyDown(key).KeyUp(key)
+		}
+		return s.PerformActions(actions)
+	}
+
 	request := struct {
 		Value []string `json:"value"`
 	}{splitText}
 	return s.Send("POST", "keys", request, nil)
 }
 
-func (s *Session) DeleteLocalStorage() error {
-	return s.Send("DELETE", "local_storage", nil, nil)
-}
-
-func (s *Session) DeleteSessionStorage() error {
-	return s.Send("DELETE", "session_storage", nil, nil)
-}
-
 func (s *Session) SetImplicitWait(timeout int) error {
+	if s.W3C {
+		return s.setW3CTimeout("implicit", timeout)
+	}
+
 	request := struct {
 		MS int `json:"ms"`
 	}{timeout}
@@ -453,6 +848,10 @@ func (s *Session) SetImplicitWait(timeout int) error {
 }
 
 func (s *Session) SetPageLoad(timeout int) error {
+	if s.W3C {
+		return s.setW3CTimeout("pageLoad", timeout)
+	}
+
 	request := struct {
 		MS   int    `json:"ms"`
 		Type string `json:"type"`
@@ -461,8 +860,110 @@ func (s *Session) SetPageLoad(timeout int) error {
 }
 
 func (s *Session) SetScriptTimeout(timeout int) error {
+	if s.W3C {
+		return s.setW3CTimeout("script", timeout)
+	}
+
 	request := struct {
 		MS int `json:"ms"`
 	}{timeout}
 	return s.Send("POST", "timeouts/async_script", request, nil)
 }
+
+// GetOrientation returns the screen orientation of a mobile device, either
+// "LANDSCAPE" or "PORTRAIT".
+func (s *Session) GetOrientation() (string, error) {
+	var orientation string
+	if err := s.Send("GET", "orientation", nil, &orientation); err != nil {
+		return "", err
+	}
+	return orientation, nil
+}
+
+// SetOrientation rotates a mobile device to the provided orientation, which
+// must be "LANDSCAPE" or "PORTRAIT".
+func (s *Session) SetOrientation(orientation string) error {
+	if orientation != "LANDSCAPE" && orientation != "PORTRAIT" {
+		return fmt.Errorf("invalid orientation: %s", orientation)
+	}
+
+	request := struct {
+		Orientation string `json:"orientation"`
+	}{orientation}
+	return s.Send("POST", "orientation", request, nil)
+}
+
+// SetTimeouts sets the non-zero session timeouts. Timeouts that are zero are
+// left unchanged, so that a single timeout may be set, and so that timeouts
+// without a limit, which GetTimeouts returns as zero, are not set to zero.
+// Use SetImplicitWait, SetPageLoad, or SetScriptTimeout to set a timeout to
+// zero. Durations are truncated to milliseconds.
+func (s *Session) SetTimeouts(timeouts Timeouts) error {
+	if s.W3C {
+		request := map[string]int64{}
+		if timeouts.Implicit != 0 {
+			request["implicit"] = toMilliseconds(timeouts.Implicit)
+		}
+		if timeouts.PageLoad != 0 {
+			request["pageLoad"] = toMilliseconds(timeouts.PageLoad)
+		}
+		if timeouts.Script != 0 {
+			request["script"] = toMilliseconds(timeouts.Script)
+		}
+		if len(request) == 0 {
+			return nil
+		}
+		return s.Send("POST", "timeouts", request, nil)
+	}
+
+	if timeouts.Implicit != 0 {
+		if err := s.SetImplicitWait(int(toMilliseconds(timeouts.Implicit))); err != nil {
+			return err
+		}
+	}
+	if timeouts.PageLoad != 0 {
+		if err := s.SetPageLoad(int(toMilliseconds(timeouts.PageLoad))); err != nil {
+			return err
+		}
+	}
+	if timeouts.Script != 0 {
+		return s.SetScriptTimeout(int(toMilliseconds(timeouts.Script)))
+	}
+	return nil
+}
+
+// GetTimeouts retrieves the session timeouts. A timeout without a limit is
+// returned as zero. Legacy WebDriver implementations may not support this.
+func (s *Session) GetTimeouts() (Timeouts, error) {
+	var result struct {
+		Implicit *int64 `json:"implicit"`
+		PageLoad *int64 `json:"pageLoad"`
+		Script   *int64 `json:"script"`
+	}
+
+	if err := s.Send("GET", "timeouts", nil, &result); err != nil {
+		return Timeouts{}, err
+	}
+
+	return Timeouts{
+		Implicit: fromMilliseconds(result.Implicit),
+		PageLoad: fromMilliseconds(result.PageLoad),
+		Script:   fromMilliseconds(result.Script),
+	}, nil
+}
+
+func toMilliseconds(d time.Duration) int64 {
+	return int64(d / time.Millisecond)
+}
+
+func fromMilliseconds(ms *int64) time.Duration {
+	if ms == nil {
+		return 0
+	}
+	return time.Duration(*ms) * time.Millisecond
+}
+
+func (s *Session) setW3CTimeout(timeoutType string, timeout int) error {
+	request := map[string]int{timeoutType: timeout}
+	return s.Send("POST", "timeouts", request, nil)
+}
@@ -1,7 +1,12 @@
 package api_test
 
 import (
+	"bytes"
 	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -18,7 +23,7 @@ var _ = Describe("Session", func() {
 
 	BeforeEach(func() {
 		bus = &mocks.Bus{}
-		session = &Session{bus}
+		session = &Session{Bus: bus}
 	})
 
 	Describe("#Delete", func() {
@@ -60,6 +65,31 @@ var _ = Describe("Session", func() {
 				Expect(err).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			BeforeEach(func() {
+				session.W3C = true
+			})
+
+			It("should return an element with the W3C element ID", func() {
+				bus.SendCall.Result = `{"element-6066-11e4-a52e-4f735466cecf": "some-id"}`
+				element, err := session.GetElement(Selector{"css selector", "#selector"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(element.ID).To(Equal("some-id"))
+			})
+
+			It("should translate unsupported selector strategies into CSS selectors", func() {
+				_, err := session.GetElement(Selector{"id", `some"id`})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"using": "css selector", "value": "[id=\"some\\\"id\"]"}`))
+				_, err = session.GetElement(Selector{"name", "some-name"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"using": "css selector", "value": "[name=\"some-name\"]"}`))
+				_, err = session.GetElement(Selector{"class name", "some-class"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"using": "css selector", "value": "[class~=\"some-class\"]"}`))
+			})
+		})
 	})
 
 	Describe("#GetElements", func() {
@@ -88,6 +118,17 @@ var _ = Describe("Session", func() {
 				Expect(err).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should return a slice of elements with W3C element IDs", func() {
+				session.W3C = true
+				bus.SendCall.Result = `[{"element-6066-11e4-a52e-4f735466cecf": "some-id"}, {"element-6066-11e4-a52e-4f735466cecf": "some-other-id"}]`
+				elements, err := session.GetElements(Selector{"css selector", "#selector"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(elements[0].ID).To(Equal("some-id"))
+				Expect(elements[1].ID).To(Equal("some-other-id"))
+			})
+		})
 	})
 
 	Describe("#GetActiveElement", func() {
@@ -113,6 +154,18 @@ var _ = Describe("Session", func() {
 				Expect(err).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a GET to the element/active endpoint", func() {
+				session.W3C = true
+				bus.SendCall.Result = `{"element-6066-11e4-a52e-4f735466cecf": "some-id"}`
+				element, err := session.GetActiveElement()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bus.SendCall.Method).To(Equal("GET"))
+				Expect(bus.SendCall.Endpoint).To(Equal("element/active"))
+				Expect(element.ID).To(Equal("some-id"))
+			})
+		})
 	})
 
 	Describe("#GetWindow", func() {
@@ -138,6 +191,16 @@ var _ = Describe("Session", func() {
 				Expect(err).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a GET to the window endpoint", func() {
+				session.W3C = true
+				_, err := session.GetWindow()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bus.SendCall.Method).To(Equal("GET"))
+				Expect(bus.SendCall.Endpoint).To(Equal("window"))
+			})
+		})
 	})
 
 	Describe("#GetWindows", func() {
@@ -165,6 +228,16 @@ var _ = Describe("Session", func() {
 				Expect(err).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a GET to the window/handles endpoint", func() {
+				session.W3C = true
+				_, err := session.GetWindows()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bus.SendCall.Method).To(Equal("GET"))
+				Expect(bus.SendCall.Endpoint).To(Equal("window/handles"))
+			})
+		})
 	})
 
 	Describe("#SetWindow", func() {
@@ -204,6 +277,46 @@ var _ = Describe("Session", func() {
 				Expect(session.SetWindowByName("")).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a POST to the window endpoint with a handle", func() {
+				session.W3C = true
+				Expect(session.SetWindowByName("some name")).To(Succeed())
+				Expect(bus.SendCall.Method).To(Equal("POST"))
+				Expect(bus.SendCall.Endpoint).To(Equal("window"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"handle": "some name"}`))
+			})
+		})
+	})
+
+	Describe("#NewWindow", func() {
+		It("should successfully send a POST to the window/new endpoint", func() {
+			_, err := session.NewWindow("tab")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("window/new"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"type": "tab"}`))
+		})
+
+		It("should return the new window", func() {
+			bus.SendCall.Result = `{"handle": "some-id", "type": "window"}`
+			Expect(session.NewWindow("window")).To(Equal(&Window{"some-id", session}))
+		})
+
+		Context("when the window type is invalid", func() {
+			It("should return an error", func() {
+				_, err := session.NewWindow("popup")
+				Expect(err).To(MatchError("invalid window type: popup"))
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := session.NewWindow("tab")
+				Expect(err).To(MatchError("some error"))
+			})
+		})
 	})
 
 	Describe("#DeleteWindow", func() {
@@ -249,6 +362,32 @@ var _ = Describe("Session", func() {
 		})
 	})
 
+	Describe("#GetNamedCookie", func() {
+		It("should successfully send a GET to the cookie/some-cookie endpoint", func() {
+			_, err := session.GetNamedCookie("some-cookie")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("cookie/some-cookie"))
+		})
+
+		It("should return the cookie", func() {
+			bus.SendCall.Result = `{"name": "some-cookie", "sameSite": "Lax", "expiry": 100}`
+			Expect(session.GetNamedCookie("some-cookie")).To(Equal(&Cookie{
+				Name:     "some-cookie",
+				SameSite: "Lax",
+				Expiry:   time.Unix(100, 0),
+			}))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := session.GetNamedCookie("some-cookie")
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
 	Describe("#SetCookie", func() {
 		It("should successfully send a POST to the cookie endpoint", func() {
 			Expect(session.SetCookie(&Cookie{Name: "some-cookie"})).To(Succeed())
@@ -263,6 +402,13 @@ var _ = Describe("Session", func() {
 			})
 		})
 
+		Context("when the cookie is invalid", func() {
+			It("should return an error without sending the cookie", func() {
+				Expect(session.SetCookie(&Cookie{Path: "some/path"})).To(MatchError("invalid cookie: path must begin with /"))
+				Expect(bus.SendCall.Endpoint).To(BeEmpty())
+			})
+		})
+
 		Context("when the bus indicates a failure", func() {
 			It("should return an error", func() {
 				bus.SendCall.Err = errors.New("some error")
@@ -320,7 +466,7 @@ var _ = Describe("Session", func() {
 
 		Context("when the image is not valid base64", func() {
 			It("should return an error", func() {
-				bus.SendCall.Result = `"..."`
+				bus.SendCall.Result = `"...."`
 				_, err := session.GetScreenshot()
 				Expect(err).To(MatchError("illegal base64 data at input byte 0"))
 			})
@@ -335,6 +481,23 @@ var _ = Describe("Session", func() {
 		})
 	})
 
+	Describe("#WriteScreenshotTo", func() {
+		It("should write the decoded image to the writer", func() {
+			bus.SendCall.Result = `"c29tZS1wbmc="`
+			var image bytes.Buffer
+			Expect(session.WriteScreenshotTo(&image)).To(Succeed())
+			Expect(bus.SendCall.Endpoint).To(Equal("screenshot"))
+			Expect(image.String()).To(Equal("some-png"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.WriteScreenshotTo(&bytes.Buffer{})).To(MatchError("some error"))
+			})
+		})
+	})
+
 	Describe("#GetURL", func() {
 		It("should successfully send a GET to the url endpoint", func() {
 			_, err := session.GetURL()
@@ -423,6 +586,24 @@ var _ = Describe("Session", func() {
 		})
 	})
 
+	Describe("#WriteSourceTo", func() {
+		It("should write the page source to the writer", func() {
+			bus.SendCall.Result = `"some source"`
+			var source bytes.Buffer
+			Expect(session.WriteSourceTo(&source)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("source"))
+			Expect(source.String()).To(Equal("some source"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.WriteSourceTo(&bytes.Buffer{})).To(MatchError("some error"))
+			})
+		})
+	})
+
 	Describe("#MoveTo", func() {
 		It("should successfully send a POST to the moveto endpoint", func() {
 			Expect(session.MoveTo(nil, nil)).To(Succeed())
@@ -467,6 +648,66 @@ var _ = Describe("Session", func() {
 				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"xoffset": 300, "yoffset": 400}`))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			BeforeEach(func() {
+				session.W3C = true
+			})
+
+			It("should successfully perform a pointer move relative to the pointer", func() {
+				Expect(session.MoveTo(nil, XYOffset{X: 100, Y: 200})).To(Succeed())
+				Expect(bus.SendCall.Method).To(Equal("POST"))
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "mouse",
+					"parameters": {"pointerType": "mouse"},
+					"actions": [{"type": "pointerMove", "duration": 0, "origin": "pointer", "x": 100, "y": 200}]
+				}]}`))
+			})
+
+			It("should successfully perform a pointer move to the center of an element", func() {
+				Expect(session.MoveTo(&Element{ID: "some-id"}, nil)).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "mouse",
+					"parameters": {"pointerType": "mouse"},
+					"actions": [{
+						"type": "pointerMove",
+						"duration": 0,
+						"origin": {"element-6066-11e4-a52e-4f735466cecf": "some-id"},
+						"x": 0,
+						"y": 0
+					}]
+				}]}`))
+			})
+
+			It("should adjust offsets from an element to be relative to its center", func() {
+				bus.SendCall.Result = `{"width": 40, "height": 60}`
+				Expect(session.MoveTo(&Element{ID: "some-id", Session: session}, XYOffset{X: 100, Y: 200})).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "mouse",
+					"parameters": {"pointerType": "mouse"},
+					"actions": [{
+						"type": "pointerMove",
+						"duration": 0,
+						"origin": {"element-6066-11e4-a52e-4f735466cecf": "some-id"},
+						"x": 80,
+						"y": 170
+					}]
+				}]}`))
+			})
+
+			Context("when retrieving the element size fails", func() {
+				It("should return an error", func() {
+					bus.SendCall.Err = errors.New("some error")
+					err := session.MoveTo(&Element{ID: "some-id", Session: session}, XYOffset{X: 100, Y: 200})
+					Expect(err).To(MatchError("some error"))
+					Expect(bus.SendCall.Endpoint).To(Equal("element/some-id/rect"))
+				})
+			})
+		})
 	})
 
 	Describe("#Frame", func() {
@@ -492,6 +733,30 @@ var _ = Describe("Session", func() {
 				Expect(session.Frame(nil)).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a POST to the frame endpoint with a W3C element reference", func() {
+				session.W3C = true
+				Expect(session.Frame(&Element{ID: "some-id"})).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"id": {"element-6066-11e4-a52e-4f735466cecf": "some-id"}}`))
+			})
+		})
+	})
+
+	Describe("#FrameIndex", func() {
+		It("should successfully send a POST to the frame endpoint with the index", func() {
+			Expect(session.FrameIndex(2)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("frame"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"id": 2}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.FrameIndex(2)).To(MatchError("some error"))
+			})
+		})
 	})
 
 	Describe("#FrameParent", func() {
@@ -525,6 +790,35 @@ var _ = Describe("Session", func() {
 			Expect(result.Some).To(Equal("result"))
 		})
 
+		It("should provide elements returned by the script with the session", func() {
+			var result struct {
+				Items []*Element
+				Other Element
+			}
+			bus.SendCall.Result = `{"items": [{"ELEMENT": "some-id"}], "other": {"element-6066-11e4-a52e-4f735466cecf": "some-other-id"}}`
+			Expect(session.Execute("some javascript code", nil, &result)).To(Succeed())
+			Expect(result.Items).To(Equal([]*Element{{ID: "some-id", Session: session}}))
+			Expect(result.Other).To(Equal(Element{ID: "some-other-id", Session: session}))
+		})
+
+		It("should replace element references within interface{} results with elements", func() {
+			var result interface{}
+			bus.SendCall.Result = `{"items": [{"ELEMENT": "some-id"}, {"ELEMENT": "some-id", "other": "value"}]}`
+			Expect(session.Execute("some javascript code", nil, &result)).To(Succeed())
+			Expect(result).To(Equal(map[string]interface{}{
+				"items": []interface{}{
+					&Element{ID: "some-id", Session: session},
+					map[string]interface{}{"ELEMENT": "some-id", "other": "value"},
+				},
+			}))
+		})
+
+		It("should send element arguments as element references", func() {
+			element := &Element{ID: "some-id", Session: session}
+			Expect(session.Execute("some javascript code", []interface{}{element, "two"}, nil)).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "some javascript code", "args": [{"ELEMENT": "some-id"}, "two"]}`))
+		})
+
 		Context("when called with nil arguments", func() {
 			It("should send an empty list for args", func() {
 				session.Execute("some javascript code", nil, nil)
@@ -538,6 +832,87 @@ var _ = Describe("Session", func() {
 				Expect(session.Execute("", nil, nil)).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a POST to the execute/sync endpoint", func() {
+				session.W3C = true
+				Expect(session.Execute("some javascript code", []interface{}{1, "two"}, nil)).To(Succeed())
+				Expect(bus.SendCall.Method).To(Equal("POST"))
+				Expect(bus.SendCall.Endpoint).To(Equal("execute/sync"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "some javascript code", "args": [1, "two"]}`))
+			})
+		})
+	})
+
+	Describe("#ExecuteAsync", func() {
+		It("should successfully send a POST to the execute_async endpoint", func() {
+			Expect(session.ExecuteAsync("some javascript code", []interface{}{1, "two"}, nil)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("execute_async"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "some javascript code", "args": [1, "two"]}`))
+		})
+
+		It("should fill the provided results interface", func() {
+			var result struct{ Some string }
+			bus.SendCall.Result = `{"some": "result"}`
+			err := session.ExecuteAsync("some javascript code", []interface{}{1, "two"}, &result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Some).To(Equal("result"))
+		})
+
+		Context("when called with nil arguments", func() {
+			It("should send an empty list for args", func() {
+				session.ExecuteAsync("some javascript code", nil, nil)
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "some javascript code", "args": []}`))
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.ExecuteAsync("", nil, nil)).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a POST to the execute/async endpoint", func() {
+				session.W3C = true
+				Expect(session.ExecuteAsync("some javascript code", []interface{}{1, "two"}, nil)).To(Succeed())
+				Expect(bus.SendCall.Method).To(Equal("POST"))
+				Expect(bus.SendCall.Endpoint).To(Equal("execute/async"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "some javascript code", "args": [1, "two"]}`))
+			})
+		})
+	})
+
+	Describe("#ExecuteCDP", func() {
+		It("should successfully send a POST to the DevTools Protocol endpoint", func() {
+			Expect(session.ExecuteCDP("Some.command", map[string]int{"some": 1}, nil)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("goog/cdp/execute"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Some.command", "params": {"some": 1}}`))
+		})
+
+		It("should fill the provided result interface", func() {
+			var result struct{ Some string }
+			bus.SendCall.Result = `{"some": "result"}`
+			Expect(session.ExecuteCDP("Some.command", nil, &result)).To(Succeed())
+			Expect(result.Some).To(Equal("result"))
+		})
+
+		Context("when called with nil parameters", func() {
+			It("should send empty parameters", func() {
+				Expect(session.ExecuteCDP("Some.command", nil, nil)).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Some.command", "params": {}}`))
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.ExecuteCDP("Some.command", nil, nil)).To(MatchError("some error"))
+			})
+		})
 	})
 
 	Describe("#Forward", func() {
@@ -607,6 +982,15 @@ var _ = Describe("Session", func() {
 				Expect(err).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully use the alert/text endpoint", func() {
+				session.W3C = true
+				_, err := session.GetAlertText()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bus.SendCall.Endpoint).To(Equal("alert/text"))
+			})
+		})
 	})
 
 	Describe("#SetAlertText", func() {
@@ -623,6 +1007,14 @@ var _ = Describe("Session", func() {
 				Expect(session.SetAlertText("some text")).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully use the alert/text endpoint", func() {
+				session.W3C = true
+				Expect(session.SetAlertText("some text")).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("alert/text"))
+			})
+		})
 	})
 
 	Describe("#AcceptAlert", func() {
@@ -638,6 +1030,14 @@ var _ = Describe("Session", func() {
 				Expect(session.AcceptAlert()).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully use the alert/accept endpoint", func() {
+				session.W3C = true
+				Expect(session.AcceptAlert()).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("alert/accept"))
+			})
+		})
 	})
 
 	Describe("#DismissAlert", func() {
@@ -653,6 +1053,14 @@ var _ = Describe("Session", func() {
 				Expect(session.DismissAlert()).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully use the alert/dismiss endpoint", func() {
+				session.W3C = true
+				Expect(session.DismissAlert()).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("alert/dismiss"))
+			})
+		})
 	})
 
 	Describe("#NewLogs", func() {
@@ -725,6 +1133,25 @@ var _ = Describe("Session", func() {
 				Expect(session.DoubleClick()).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully perform two left button clicks", func() {
+				session.W3C = true
+				Expect(session.DoubleClick()).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "mouse",
+					"parameters": {"pointerType": "mouse"},
+					"actions": [
+						{"type": "pointerDown", "button": 0},
+						{"type": "pointerUp", "button": 0},
+						{"type": "pointerDown", "button": 0},
+						{"type": "pointerUp", "button": 0}
+					]
+				}]}`))
+			})
+		})
 	})
 
 	Describe("#Click", func() {
@@ -741,6 +1168,20 @@ var _ = Describe("Session", func() {
 				Expect(session.Click(RightButton)).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully press and release the provided button", func() {
+				session.W3C = true
+				Expect(session.Click(RightButton)).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "mouse",
+					"parameters": {"pointerType": "mouse"},
+					"actions": [{"type": "pointerDown", "button": 2}, {"type": "pointerUp", "button": 2}]
+				}]}`))
+			})
+		})
 	})
 
 	Describe("#ButtonDown", func() {
@@ -757,6 +1198,20 @@ var _ = Describe("Session", func() {
 				Expect(session.ButtonDown(RightButton)).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully perform a pointerDown action with the provided button", func() {
+				session.W3C = true
+				Expect(session.ButtonDown(RightButton)).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "mouse",
+					"parameters": {"pointerType": "mouse"},
+					"actions": [{"type": "pointerDown", "button": 2}]
+				}]}`))
+			})
+		})
 	})
 
 	Describe("#ButtonUp", func() {
@@ -773,6 +1228,20 @@ var _ = Describe("Session", func() {
 				Expect(session.ButtonUp(RightButton)).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully perform a pointerUp action with the provided button", func() {
+				session.W3C = true
+				Expect(session.ButtonUp(RightButton)).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "mouse",
+					"parameters": {"pointerType": "mouse"},
+					"actions": [{"type": "pointerUp", "button": 2}]
+				}]}`))
+			})
+		})
 	})
 
 	Describe("#TouchDown", func() {
@@ -789,6 +1258,23 @@ var _ = Describe("Session", func() {
 				Expect(session.TouchDown(100, 200)).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully move a touch pointer to the location and press it", func() {
+				session.W3C = true
+				Expect(session.TouchDown(100, 200)).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "finger",
+					"parameters": {"pointerType": "touch"},
+					"actions": [
+						{"type": "pointerMove", "duration": 0, "origin": "viewport", "x": 100, "y": 200},
+						{"type": "pointerDown", "button": 0}
+					]
+				}]}`))
+			})
+		})
 	})
 
 	Describe("#TouchUp", func() {
@@ -805,6 +1291,23 @@ var _ = Describe("Session", func() {
 				Expect(session.TouchUp(100, 200)).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully move a touch pointer to the location and release it", func() {
+				session.W3C = true
+				Expect(session.TouchUp(100, 200)).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "finger",
+					"parameters": {"pointerType": "touch"},
+					"actions": [
+						{"type": "pointerMove", "duration": 0, "origin": "viewport", "x": 100, "y": 200},
+						{"type": "pointerUp", "button": 0}
+					]
+				}]}`))
+			})
+		})
 	})
 
 	Describe("#TouchMove", func() {
@@ -821,6 +1324,20 @@ var _ = Describe("Session", func() {
 				Expect(session.TouchMove(100, 200)).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully move a touch pointer to the location", func() {
+				session.W3C = true
+				Expect(session.TouchMove(100, 200)).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "finger",
+					"parameters": {"pointerType": "touch"},
+					"actions": [{"type": "pointerMove", "duration": 0, "origin": "viewport", "x": 100, "y": 200}]
+				}]}`))
+			})
+		})
 	})
 
 	Describe("#TouchClick", func() {
@@ -843,6 +1360,24 @@ var _ = Describe("Session", func() {
 				Expect(session.TouchClick(nil)).To(MatchError("nil element is invalid"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully tap the element using a touch pointer", func() {
+				session.W3C = true
+				Expect(session.TouchClick(&Element{ID: "some-id"})).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "finger",
+					"parameters": {"pointerType": "touch"},
+					"actions": [
+						{"type": "pointerMove", "duration": 0, "origin": {"element-6066-11e4-a52e-4f735466cecf": "some-id"}, "x": 0, "y": 0},
+						{"type": "pointerDown", "button": 0},
+						{"type": "pointerUp", "button": 0}
+					]
+				}]}`))
+			})
+		})
 	})
 
 	Describe("#TouchDoubleClick", func() {
@@ -865,6 +1400,26 @@ var _ = Describe("Session", func() {
 				Expect(session.TouchDoubleClick(nil)).To(MatchError("nil element is invalid"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully tap the element twice using a touch pointer", func() {
+				session.W3C = true
+				Expect(session.TouchDoubleClick(&Element{ID: "some-id"})).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "finger",
+					"parameters": {"pointerType": "touch"},
+					"actions": [
+						{"type": "pointerMove", "duration": 0, "origin": {"element-6066-11e4-a52e-4f735466cecf": "some-id"}, "x": 0, "y": 0},
+						{"type": "pointerDown", "button": 0},
+						{"type": "pointerUp", "button": 0},
+						{"type": "pointerDown", "button": 0},
+						{"type": "pointerUp", "button": 0}
+					]
+				}]}`))
+			})
+		})
 	})
 
 	Describe("#TouchLongClick", func() {
@@ -887,6 +1442,25 @@ var _ = Describe("Session", func() {
 				Expect(session.TouchLongClick(nil)).To(MatchError("nil element is invalid"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully press the element for one second using a touch pointer", func() {
+				session.W3C = true
+				Expect(session.TouchLongClick(&Element{ID: "some-id"})).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "finger",
+					"parameters": {"pointerType": "touch"},
+					"actions": [
+						{"type": "pointerMove", "duration": 0, "origin": {"element-6066-11e4-a52e-4f735466cecf": "some-id"}, "x": 0, "y": 0},
+						{"type": "pointerDown", "button": 0},
+						{"type": "pause", "duration": 1000},
+						{"type": "pointerUp", "button": 0}
+					]
+				}]}`))
+			})
+		})
 	})
 
 	Describe("#TouchFlick", func() {
@@ -974,6 +1548,43 @@ var _ = Describe("Session", func() {
 				Expect(session.TouchFlick(nil, nil, ScalarSpeed(0))).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			BeforeEach(func() {
+				session.W3C = true
+			})
+
+			It("should successfully swipe from the element by the offset at the provided speed", func() {
+				Expect(session.TouchFlick(&Element{ID: "some-id"}, XYOffset{X: 300, Y: 400}, ScalarSpeed(1000))).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "finger",
+					"parameters": {"pointerType": "touch"},
+					"actions": [
+						{"type": "pointerMove", "duration": 0, "origin": {"element-6066-11e4-a52e-4f735466cecf": "some-id"}, "x": 0, "y": 0},
+						{"type": "pointerDown", "button": 0},
+						{"type": "pointerMove", "duration": 500, "origin": "pointer", "x": 300, "y": 400},
+						{"type": "pointerUp", "button": 0}
+					]
+				}]}`))
+			})
+
+			It("should successfully swipe from the current position at the provided speed", func() {
+				Expect(session.TouchFlick(nil, nil, VectorSpeed{X: 1000, Y: 2000})).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "finger",
+					"parameters": {"pointerType": "touch"},
+					"actions": [
+						{"type": "pointerDown", "button": 0},
+						{"type": "pointerMove", "duration": 100, "origin": "pointer", "x": 100, "y": 200},
+						{"type": "pointerUp", "button": 0}
+					]
+				}]}`))
+			})
+		})
 	})
 
 	Describe("#TouchScroll", func() {
@@ -1018,6 +1629,42 @@ var _ = Describe("Session", func() {
 				Expect(session.TouchScroll(nil, offset)).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			BeforeEach(func() {
+				session.W3C = true
+			})
+
+			It("should successfully drag a touch pointer from the element by the offset", func() {
+				Expect(session.TouchScroll(&Element{ID: "some-id"}, XYOffset{X: 100, Y: 200})).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "finger",
+					"parameters": {"pointerType": "touch"},
+					"actions": [
+						{"type": "pointerMove", "duration": 0, "origin": {"element-6066-11e4-a52e-4f735466cecf": "some-id"}, "x": 0, "y": 0},
+						{"type": "pointerDown", "button": 0},
+						{"type": "pointerMove", "duration": 500, "origin": "pointer", "x": 100, "y": 200},
+						{"type": "pointerUp", "button": 0}
+					]
+				}]}`))
+			})
+
+			It("should successfully drag a touch pointer from its current position when no element is provided", func() {
+				Expect(session.TouchScroll(nil, XYOffset{X: 100, Y: 200})).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "pointer",
+					"id": "finger",
+					"parameters": {"pointerType": "touch"},
+					"actions": [
+						{"type": "pointerDown", "button": 0},
+						{"type": "pointerMove", "duration": 500, "origin": "pointer", "x": 100, "y": 200},
+						{"type": "pointerUp", "button": 0}
+					]
+				}]}`))
+			})
+		})
 	})
 
 	Describe("#Keys", func() {
@@ -1034,35 +1681,285 @@ var _ = Describe("Session", func() {
 				Expect(session.Keys("text")).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully press and release each key", func() {
+				session.W3C = true
+				Expect(session.Keys("ab")).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+					"type": "key",
+					"id": "keyboard",
+					"actions": [
+						{"type": "keyDown", "value": "a"},
+						{"type": "keyUp", "value": "a"},
+						{"type": "keyDown", "value": "b"},
+						{"type": "keyUp", "value": "b"}
+					]
+				}]}`))
+			})
+		})
 	})
 
-	Describe("#DeleteLocalStorage", func() {
-		It("should successfully send a POST to the delete local storage endpoint", func() {
-			Expect(session.DeleteLocalStorage()).To(Succeed())
-			Expect(bus.SendCall.Method).To(Equal("DELETE"))
-			Expect(bus.SendCall.Endpoint).To(Equal("local_storage"))
+	Describe("#SetImplicitWait", func() {
+		It("should successfully send a POST to the timeouts/implicit_wait endpoint", func() {
+			Expect(session.SetImplicitWait(100)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("timeouts/implicit_wait"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"ms": 100}`))
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a POST to the timeouts endpoint", func() {
+				session.W3C = true
+				Expect(session.SetImplicitWait(100)).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("timeouts"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"implicit": 100}`))
+			})
+		})
+	})
+
+	Describe("#SetPageLoad", func() {
+		It("should successfully send a POST to the timeouts endpoint", func() {
+			Expect(session.SetPageLoad(100)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("timeouts"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"ms": 100, "type": "page load"}`))
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a POST to the timeouts endpoint", func() {
+				session.W3C = true
+				Expect(session.SetPageLoad(100)).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("timeouts"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"pageLoad": 100}`))
+			})
+		})
+	})
+
+	Describe("#SetScriptTimeout", func() {
+		It("should successfully send a POST to the timeouts/async_script endpoint", func() {
+			Expect(session.SetScriptTimeout(100)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("timeouts/async_script"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"ms": 100}`))
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a POST to the timeouts endpoint", func() {
+				session.W3C = true
+				Expect(session.SetScriptTimeout(100)).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("timeouts"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": 100}`))
+			})
+		})
+	})
+
+	Describe("#GetOrientation", func() {
+		It("should successfully send a GET to the orientation endpoint", func() {
+			bus.SendCall.Result = `"LANDSCAPE"`
+			Expect(session.GetOrientation()).To(Equal("LANDSCAPE"))
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("orientation"))
 		})
 
 		Context("when the bus indicates a failure", func() {
 			It("should return an error", func() {
 				bus.SendCall.Err = errors.New("some error")
-				Expect(session.DeleteLocalStorage()).To(MatchError("some error"))
+				_, err := session.GetOrientation()
+				Expect(err).To(MatchError("some error"))
 			})
 		})
 	})
 
-	Describe("#DeleteSessionStorage", func() {
-		It("should successfully send a POST to the delete session storage endpoint", func() {
-			Expect(session.DeleteSessionStorage()).To(Succeed())
-			Expect(bus.SendCall.Method).To(Equal("DELETE"))
-			Expect(bus.SendCall.Endpoint).To(Equal("session_storage"))
+	Describe("#SetOrientation", func() {
+		It("should successfully send a POST to the orientation endpoint", func() {
+			Expect(session.SetOrientation("PORTRAIT")).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("orientation"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"orientation": "PORTRAIT"}`))
+		})
+
+		Context("when the orientation is invalid", func() {
+			It("should return an error without sending a request", func() {
+				Expect(session.SetOrientation("sideways")).To(MatchError("invalid orientation: sideways"))
+				Expect(bus.SendCall.Endpoint).To(BeEmpty())
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.SetOrientation("LANDSCAPE")).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#SetTimeouts", func() {
+		var timeouts Timeouts
+
+		BeforeEach(func() {
+			timeouts = Timeouts{Implicit: time.Second, PageLoad: time.Minute, Script: 1500 * time.Microsecond}
+		})
+
+		It("should successfully set each timeout using the legacy endpoints", func() {
+			Expect(session.SetTimeouts(timeouts)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("timeouts/async_script"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"ms": 1}`))
+		})
+
+		Context("when setting a timeout fails", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.SetTimeouts(timeouts)).To(MatchError("some error"))
+				Expect(bus.SendCall.Endpoint).To(Equal("timeouts/implicit_wait"))
+			})
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a POST to the timeouts endpoint with every timeout", func() {
+				session.W3C = true
+				Expect(session.SetTimeouts(timeouts)).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("timeouts"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"implicit": 1000, "pageLoad": 60000, "script": 1}`))
+			})
+
+			It("should only send the timeouts that are not zero", func() {
+				session.W3C = true
+				Expect(session.SetTimeouts(Timeouts{Script: 5 * time.Second})).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": 5000}`))
+			})
+
+			It("should not send a request when every timeout is zero", func() {
+				session.W3C = true
+				Expect(session.SetTimeouts(Timeouts{})).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(BeEmpty())
+			})
+		})
+
+		Context("when only one timeout is provided", func() {
+			It("should only set that timeout using the legacy endpoints", func() {
+				Expect(session.SetTimeouts(Timeouts{PageLoad: time.Minute})).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("timeouts"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"type": "page load", "ms": 60000}`))
+			})
+		})
+	})
+
+	Describe("#GetTimeouts", func() {
+		It("should successfully send a GET to the timeouts endpoint", func() {
+			_, err := session.GetTimeouts()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("timeouts"))
+		})
+
+		It("should return the timeouts as durations", func() {
+			bus.SendCall.Result = `{"implicit": 0, "pageLoad": 300000, "script": 30000}`
+			Expect(session.GetTimeouts()).To(Equal(Timeouts{PageLoad: 5 * time.Minute, Script: 30 * time.Second}))
+		})
+
+		Context("when a timeout has no limit", func() {
+			It("should return that timeout as zero", func() {
+				bus.SendCall.Result = `{"implicit": 1000, "pageLoad": 300000, "script": null}`
+				Expect(session.GetTimeouts()).To(Equal(Timeouts{Implicit: time.Second, PageLoad: 5 * time.Minute}))
+			})
 		})
 
 		Context("when the bus indicates a failure", func() {
 			It("should return an error", func() {
 				bus.SendCall.Err = errors.New("some error")
-				Expect(session.DeleteSessionStorage()).To(MatchError("some error"))
+				_, err := session.GetTimeouts()
+				Expect(err).To(MatchError("some error"))
 			})
 		})
 	})
 })
+
+var _ = Describe(".OpenWithSessionID", func() {
+	var (
+		server    *httptest.Server
+		requests  []string
+		responses map[string]string
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		responses = map[string]string{}
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			ioutil.ReadAll(request.Body)
+			requests = append(requests, request.Method+" "+request.URL.Path)
+			body, ok := responses[request.URL.Path]
+			if !ok {
+				response.WriteHeader(404)
+				body = `{"value": {"error": "unknown command", "message": "some error"}}`
+			}
+			response.Write([]byte(body))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should return a session with the provided ID without opening a new session", func() {
+		responses["/session/some-id/url"] = `{"value": "some-url"}`
+		session, err := OpenWithSessionID(server.URL, "some-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(session.ID()).To(Equal("some-id"))
+		Expect(requests).NotTo(ContainElement("POST /session"))
+	})
+
+	Context("when the session uses the W3C dialect", func() {
+		It("should return a session that uses the W3C dialect", func() {
+			responses["/session/some-id/timeouts"] = `{"value": {"implicit": 0, "pageLoad": 300000, "script": 30000}}`
+			session, err := OpenWithSessionID(server.URL, "some-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.W3C).To(BeTrue())
+			Expect(requests).To(Equal([]string{"GET /session/some-id/timeouts"}))
+		})
+	})
+
+	Context("when the session uses the JSON Wire Protocol", func() {
+		It("should return a session that does not use the W3C dialect", func() {
+			responses["/session/some-id/url"] = `{"value": "some-url"}`
+			session, err := OpenWithSessionID(server.URL, "some-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.W3C).To(BeFalse())
+			Expect(requests).To(Equal([]string{"GET /session/some-id/timeouts", "GET /session/some-id/url"}))
+		})
+	})
+
+	Context("when the session does not exist", func() {
+		It("should return an error", func() {
+			_, err := OpenWithSessionID(server.URL, "some-id")
+			Expect(err).To(MatchError("request unsuccessful: some error"))
+		})
+	})
+
+	Context("when the session ID is empty", func() {
+		It("should return an error", func() {
+			_, err := OpenWithSessionID(server.URL, "")
+			Expect(err).To(MatchError("empty session ID"))
+			Expect(requests).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe(".W3CCapabilities", func() {
+	It("should translate legacy capabilities and drop non-standard capabilities", func() {
+		Expect(W3CCapabilities(map[string]interface{}{
+			"browserName":        "firefox",
+			"acceptSslCerts":     true,
+			"platform":           "LINUX",
+			"javascriptEnabled":  false,
+			"moz:firefoxOptions": map[string]interface{}{"args": []string{"-headless"}},
+		})).To(Equal(map[string]interface{}{
+			"browserName":         "firefox",
+			"acceptInsecureCerts": true,
+			"platformName":        "linux",
+			"moz:firefoxOptions":  map[string]interface{}{"args": []string{"-headless"}},
+		}))
+	})
+})
@@ -0,0 +1,52 @@
+package api
+
+import "path"
+
+// W3CShadowRootKey is the key used by the W3C WebDriver dialect to identify
+// shadow root references in JSON payloads.
+const W3CShadowRootKey = "shadow-6066-11e4-a52e-4f735466cecf"
+
+// A ShadowRoot is the root of an element's shadow DOM tree. Elements within
+// the tree may only be found by searching from the ShadowRoot.
+type ShadowRoot struct {
+	ID      string
+	Session *Session
+}
+
+func (r *ShadowRoot) Send(method, endpoint string, body, result interface{}) error {
+	return r.Session.Send(method, path.Join("shadow", r.ID, endpoint), body, result)
+}
+
+// GetShadowRoot returns the shadow root attached to the element.
+func (e *Element) GetShadowRoot() (*ShadowRoot, error) {
+	var result map[string]string
+	if err := e.Send("GET", "shadow", nil, &result); err != nil {
+		return nil, err
+	}
+	return &ShadowRoot{result[W3CShadowRootKey], e.Session}, nil
+}
+
+func (r *ShadowRoot) GetElement(selector Selector) (*Element, error) {
+	var result elementResult
+
+	if err := r.Send("POST", "element", r.Session.selector(selector), &result); err != nil {
+		return nil, err
+	}
+
+	return &Element{result.ID(), r.Session}, nil
+}
+
+func (r *ShadowRoot) GetElements(selector Selector) ([]*Element, error) {
+	var results []elementResult
+
+	if err := r.Send("POST", "elements", r.Session.selector(selector), &results); err != nil {
+		return nil, err
+	}
+
+	elements := []*Element{}
+	for _, result := range results {
+		elements = append(elements, &Element{result.ID(), r.Session})
+	}
+
+	return elements, nil
+}
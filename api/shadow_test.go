@@ -0,0 +1,110 @@
+package api_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/mocks"
+	. "github.com/sclevine/agouti/internal/matchers"
+)
+
+var _ = Describe("ShadowRoot", func() {
+	var (
+		bus        *mocks.Bus
+		session    *Session
+		shadowRoot *ShadowRoot
+	)
+
+	BeforeEach(func() {
+		bus = &mocks.Bus{}
+		session = &Session{Bus: bus}
+		shadowRoot = &ShadowRoot{"some-id", session}
+	})
+
+	Describe("Element#GetShadowRoot", func() {
+		var element *Element
+
+		BeforeEach(func() {
+			element = &Element{"some-element-id", session}
+		})
+
+		It("should successfully send a GET request to the shadow endpoint", func() {
+			_, err := element.GetShadowRoot()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("element/some-element-id/shadow"))
+		})
+
+		It("should return the shadow root with the correct ID and session", func() {
+			bus.SendCall.Result = `{"shadow-6066-11e4-a52e-4f735466cecf": "some-id"}`
+			shadowRoot, err := element.GetShadowRoot()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shadowRoot.ID).To(Equal("some-id"))
+			Expect(shadowRoot.Session).To(ExactlyEqual(session))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := element.GetShadowRoot()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetElement", func() {
+		It("should successfully send a POST to the shadow/some-id/element endpoint", func() {
+			_, err := shadowRoot.GetElement(Selector{"css selector", "#selector"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("shadow/some-id/element"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"using": "css selector", "value": "#selector"}`))
+		})
+
+		It("should return an element with an ID and session", func() {
+			bus.SendCall.Result = `{"element-6066-11e4-a52e-4f735466cecf": "some-element-id"}`
+			element, err := shadowRoot.GetElement(Selector{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(element.ID).To(Equal("some-element-id"))
+			Expect(element.Session).To(ExactlyEqual(session))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := shadowRoot.GetElement(Selector{})
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetElements", func() {
+		It("should successfully send a POST to the shadow/some-id/elements endpoint", func() {
+			_, err := shadowRoot.GetElements(Selector{"css selector", "#selector"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("shadow/some-id/elements"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"using": "css selector", "value": "#selector"}`))
+		})
+
+		It("should return a slice of elements with IDs and sessions", func() {
+			bus.SendCall.Result = `[{"element-6066-11e4-a52e-4f735466cecf": "some-id"}, {"ELEMENT": "some-other-id"}]`
+			elements, err := shadowRoot.GetElements(Selector{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(elements[0].ID).To(Equal("some-id"))
+			Expect(elements[0].Session).To(ExactlyEqual(session))
+			Expect(elements[1].ID).To(Equal("some-other-id"))
+			Expect(elements[1].Session).To(ExactlyEqual(session))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := shadowRoot.GetElements(Selector{})
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+})
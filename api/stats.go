@@ -0,0 +1,53 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sclevine/agouti/api/internal/bus"
+)
+
+// ConnectionStats describes how often the commands sent by a session reused
+// an existing connection to the WebDriver. A high number of new connections
+// relative to requests indicates that idle connections are being closed,
+// ex. because more sessions share the HTTP client than WithMaxIdleConnsPerHost
+// allows, or because a load balancer closes them before they are reused.
+type ConnectionStats struct {
+	Requests          int
+	NewConnections    int
+	ReusedConnections int
+
+	// ConnectDuration is the total time spent opening new connections,
+	// including DNS resolution and TLS handshakes
+	ConnectDuration time.Duration
+}
+
+type statsBus interface {
+	ConnectionStats() bus.ConnectionStats
+}
+
+// ConnectionStats returns the connection statistics of every command sent by
+// the session, including commands sent by copies of the session returned by
+// WithContext. An error is returned if the session's Bus does not record
+// statistics, which is only the case for custom Bus implementations.
+func (s *Session) ConnectionStats() (ConnectionStats, error) {
+	stats, ok := s.Bus.(statsBus)
+	if !ok {
+		return ConnectionStats{}, errors.New("the session's Bus does not record connection statistics")
+	}
+	return ConnectionStats(stats.ConnectionStats()), nil
+}
+
+func (c *contextBus) ConnectionStats() bus.ConnectionStats {
+	if stats, ok := c.bus.(statsBus); ok {
+		return stats.ConnectionStats()
+	}
+	return bus.ConnectionStats{}
+}
+
+func (d *driverBus) ConnectionStats() bus.ConnectionStats {
+	if stats, ok := d.current().(statsBus); ok {
+		return stats.ConnectionStats()
+	}
+	return bus.ConnectionStats{}
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// DriverStatus describes whether a WebDriver server, such as a driver
+// process or Selenium Grid, can open new sessions. Fields that are not
+// provided by the server are empty.
+type DriverStatus struct {
+	// Ready is true when the server can open new sessions. Servers that use
+	// the JSON Wire Protocol do not report readiness, so they are ready when
+	// they respond successfully.
+	Ready bool
+
+	// Message describes the readiness of the server
+	Message string
+
+	Build struct {
+		Version  string `json:"version"`
+		Revision string `json:"revision"`
+		Time     string `json:"time"`
+	}
+
+	OS struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		Arch    string `json:"arch"`
+	}
+}
+
+// Status retrieves the status of the WebDriver server at the provided URL,
+// so that harnesses may wait for a driver or Grid to be ready before
+// opening sessions.
+func Status(url string) (*DriverStatus, error) {
+	return StatusWithClient(url, nil)
+}
+
+// StatusWithClient retrieves the status of the WebDriver server at the
+// provided URL using the provided *http.Client.
+func StatusWithClient(url string, client *http.Client) (*DriverStatus, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Get(strings.TrimSuffix(url, "/") + "/status")
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %s", err)
+	}
+	defer response.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("request unsuccessful: %s", response.Status)
+	}
+
+	var statusResponse struct {
+		Status *int
+		Value  struct {
+			Ready   *bool
+			Message string
+			Build   json.RawMessage
+			OS      json.RawMessage
+		}
+	}
+	if err := json.Unmarshal(responseBody, &statusResponse); err != nil {
+		return nil, fmt.Errorf("unexpected response: %s", responseBody)
+	}
+
+	status := &DriverStatus{Message: statusResponse.Value.Message}
+	if statusResponse.Value.Ready != nil {
+		status.Ready = *statusResponse.Value.Ready
+	} else {
+		status.Ready = statusResponse.Status == nil || *statusResponse.Status == 0
+	}
+
+	// Some servers report fields that do not match the standard types, such
+	// as numeric versions, so these fields are only decoded if possible.
+	json.Unmarshal(statusResponse.Value.Build, &status.Build)
+	json.Unmarshal(statusResponse.Value.OS, &status.OS)
+	return status, nil
+}
@@ -0,0 +1,104 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+)
+
+var _ = Describe(".Status", func() {
+	var (
+		server         *httptest.Server
+		requestPath    string
+		responseBody   string
+		responseStatus int
+	)
+
+	BeforeEach(func() {
+		responseStatus = 200
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			requestPath = request.URL.Path
+			response.WriteHeader(responseStatus)
+			response.Write([]byte(responseBody))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should successfully return the parsed status of a W3C server", func() {
+		responseBody = `{"value": {
+			"ready": true,
+			"message": "ChromeDriver ready for new sessions.",
+			"build": {"version": "120.0.6099.109"},
+			"os": {"name": "Linux", "version": "6.1.0", "arch": "x86_64"}
+		}}`
+		status, err := Status(server.URL + "/wd/hub/")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requestPath).To(Equal("/wd/hub/status"))
+		Expect(status.Ready).To(BeTrue())
+		Expect(status.Message).To(Equal("ChromeDriver ready for new sessions."))
+		Expect(status.Build.Version).To(Equal("120.0.6099.109"))
+		Expect(status.OS.Name).To(Equal("Linux"))
+		Expect(status.OS.Version).To(Equal("6.1.0"))
+		Expect(status.OS.Arch).To(Equal("x86_64"))
+	})
+
+	It("should report a W3C server that is not ready", func() {
+		responseBody = `{"value": {"ready": false, "message": "Selenium Grid not ready."}}`
+		status, err := Status(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Ready).To(BeFalse())
+		Expect(status.Message).To(Equal("Selenium Grid not ready."))
+	})
+
+	It("should report a JSON Wire Protocol server that responds successfully as ready", func() {
+		responseBody = `{"status": 0, "value": {"build": {"version": "2.1.1", "revision": "some-revision", "time": "some-time"}, "os": {"name": "mac"}}}`
+		status, err := Status(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Ready).To(BeTrue())
+		Expect(status.Build.Revision).To(Equal("some-revision"))
+		Expect(status.Build.Time).To(Equal("some-time"))
+
+		responseBody = `{"status": 13, "value": {}}`
+		status, err = Status(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Ready).To(BeFalse())
+	})
+
+	It("should ignore build and OS fields with unexpected types", func() {
+		responseBody = `{"value": {"ready": true, "build": 12, "os": "some-os"}}`
+		status, err := Status(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Ready).To(BeTrue())
+		Expect(status.Build.Version).To(BeEmpty())
+	})
+
+	Context("when the server responds with an unsuccessful status code", func() {
+		It("should return an error", func() {
+			responseStatus = 503
+			_, err := Status(server.URL)
+			Expect(err).To(MatchError("request unsuccessful: 503 Service Unavailable"))
+		})
+	})
+
+	Context("when the response cannot be parsed", func() {
+		It("should return an error", func() {
+			responseBody = "some unexpected response"
+			_, err := Status(server.URL)
+			Expect(err).To(MatchError("unexpected response: some unexpected response"))
+		})
+	})
+
+	Context("when the request fails", func() {
+		It("should return an error", func() {
+			server.Close()
+			_, err := Status(server.URL)
+			Expect(err).To(MatchError(ContainSubstring("request failed: ")))
+		})
+	})
+})
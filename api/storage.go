@@ -0,0 +1,114 @@
+package api
+
+import "path"
+
+// The W3C dialect dropped the web storage endpoints, so W3C sessions access
+// web storage through JavaScript instead.
+type webStorage struct {
+	endpoint string
+	object   string
+}
+
+var (
+	localStorage   = webStorage{"local_storage", "localStorage"}
+	sessionStorage = webStorage{"session_storage", "sessionStorage"}
+)
+
+func (s *Session) GetLocalStorageKeys() ([]string, error) {
+	return s.getStorageKeys(localStorage)
+}
+
+func (s *Session) GetLocalStorageItem(key string) (string, error) {
+	return s.getStorageItem(localStorage, key)
+}
+
+func (s *Session) SetLocalStorageItem(key, value string) error {
+	return s.setStorageItem(localStorage, key, value)
+}
+
+func (s *Session) DeleteLocalStorageItem(key string) error {
+	return s.deleteStorageItem(localStorage, key)
+}
+
+func (s *Session) DeleteLocalStorage() error {
+	return s.deleteStorage(localStorage)
+}
+
+func (s *Session) GetSessionStorageKeys() ([]string, error) {
+	return s.getStorageKeys(sessionStorage)
+}
+
+func (s *Session) GetSessionStorageItem(key string) (string, error) {
+	return s.getStorageItem(sessionStorage, key)
+}
+
+func (s *Session) SetSessionStorageItem(key, value string) error {
+	return s.setStorageItem(sessionStorage, key, value)
+}
+
+func (s *Session) DeleteSessionStorageItem(key string) error {
+	return s.deleteStorageItem(sessionStorage, key)
+}
+
+func (s *Session) DeleteSessionStorage() error {
+	return s.deleteStorage(sessionStorage)
+}
+
+func (s *Session) getStorageKeys(storage webStorage) ([]string, error) {
+	var keys []string
+
+	var err error
+	if s.W3C {
+		err = s.Execute("return Object.keys("+storage.object+");", nil, &keys)
+	} else {
+		err = s.Send("GET", storage.endpoint, nil, &keys)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (s *Session) getStorageItem(storage webStorage, key string) (string, error) {
+	var value *string
+
+	var err error
+	if s.W3C {
+		err = s.Execute("return "+storage.object+".getItem(arguments[0]);", []interface{}{key}, &value)
+	} else {
+		err = s.Send("GET", path.Join(storage.endpoint, "key", key), nil, &value)
+	}
+	if err != nil {
+		return "", err
+	}
+	if value == nil {
+		return "", nil
+	}
+	return *value, nil
+}
+
+func (s *Session) setStorageItem(storage webStorage, key, value string) error {
+	if s.W3C {
+		return s.Execute(storage.object+".setItem(arguments[0], arguments[1]);", []interface{}{key, value}, nil)
+	}
+
+	request := struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}{key, value}
+	return s.Send("POST", storage.endpoint, request, nil)
+}
+
+func (s *Session) deleteStorageItem(storage webStorage, key string) error {
+	if s.W3C {
+		return s.Execute(storage.object+".removeItem(arguments[0]);", []interface{}{key}, nil)
+	}
+	return s.Send("DELETE", path.Join(storage.endpoint, "key", key), nil, nil)
+}
+
+func (s *Session) deleteStorage(storage webStorage) error {
+	if s.W3C {
+		return s.Execute(storage.object+".clear();", nil, nil)
+	}
+	return s.Send("DELETE", storage.endpoint, nil, nil)
+}
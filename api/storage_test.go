@@ -0,0 +1,254 @@
+package api_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/mocks"
+)
+
+var _ = Describe("Storage", func() {
+	var (
+		bus     *mocks.Bus
+		session *Session
+	)
+
+	BeforeEach(func() {
+		bus = &mocks.Bus{}
+		session = &Session{Bus: bus}
+	})
+
+	Describe("#GetLocalStorageKeys", func() {
+		It("should successfully send a GET to the local_storage endpoint", func() {
+			_, err := session.GetLocalStorageKeys()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("local_storage"))
+		})
+
+		It("should return the keys", func() {
+			bus.SendCall.Result = `["some-key", "some-other-key"]`
+			Expect(session.GetLocalStorageKeys()).To(Equal([]string{"some-key", "some-other-key"}))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := session.GetLocalStorageKeys()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should retrieve the keys using JavaScript", func() {
+				session.W3C = true
+				bus.SendCall.Result = `["some-key"]`
+				Expect(session.GetLocalStorageKeys()).To(Equal([]string{"some-key"}))
+				Expect(bus.SendCall.Endpoint).To(Equal("execute/sync"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "return Object.keys(localStorage);", "args": []}`))
+			})
+		})
+	})
+
+	Describe("#GetLocalStorageItem", func() {
+		It("should successfully send a GET to the local_storage/key endpoint", func() {
+			_, err := session.GetLocalStorageItem("some-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("local_storage/key/some-key"))
+		})
+
+		It("should return the value", func() {
+			bus.SendCall.Result = `"some value"`
+			Expect(session.GetLocalStorageItem("some-key")).To(Equal("some value"))
+		})
+
+		Context("when the item does not exist", func() {
+			It("should return an empty value", func() {
+				bus.SendCall.Result = `null`
+				Expect(session.GetLocalStorageItem("some-key")).To(BeEmpty())
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := session.GetLocalStorageItem("some-key")
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should retrieve the value using JavaScript", func() {
+				session.W3C = true
+				bus.SendCall.Result = `"some value"`
+				Expect(session.GetLocalStorageItem("some-key")).To(Equal("some value"))
+				Expect(bus.SendCall.Endpoint).To(Equal("execute/sync"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "return localStorage.getItem(arguments[0]);", "args": ["some-key"]}`))
+			})
+		})
+	})
+
+	Describe("#SetLocalStorageItem", func() {
+		It("should successfully send a POST to the local_storage endpoint", func() {
+			Expect(session.SetLocalStorageItem("some-key", "some value")).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("local_storage"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"key": "some-key", "value": "some value"}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.SetLocalStorageItem("some-key", "some value")).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should set the item using JavaScript", func() {
+				session.W3C = true
+				Expect(session.SetLocalStorageItem("some-key", "some value")).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("execute/sync"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "localStorage.setItem(arguments[0], arguments[1]);", "args": ["some-key", "some value"]}`))
+			})
+		})
+	})
+
+	Describe("#DeleteLocalStorageItem", func() {
+		It("should successfully send a DELETE to the local_storage/key endpoint", func() {
+			Expect(session.DeleteLocalStorageItem("some-key")).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("DELETE"))
+			Expect(bus.SendCall.Endpoint).To(Equal("local_storage/key/some-key"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.DeleteLocalStorageItem("some-key")).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should remove the item using JavaScript", func() {
+				session.W3C = true
+				Expect(session.DeleteLocalStorageItem("some-key")).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "localStorage.removeItem(arguments[0]);", "args": ["some-key"]}`))
+			})
+		})
+	})
+
+	Describe("#DeleteLocalStorage", func() {
+		It("should successfully send a DELETE to the local_storage endpoint", func() {
+			Expect(session.DeleteLocalStorage()).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("DELETE"))
+			Expect(bus.SendCall.Endpoint).To(Equal("local_storage"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.DeleteLocalStorage()).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should clear local storage using JavaScript", func() {
+				session.W3C = true
+				Expect(session.DeleteLocalStorage()).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "localStorage.clear();", "args": []}`))
+			})
+		})
+	})
+
+	Describe("#GetSessionStorageKeys", func() {
+		It("should successfully send a GET to the session_storage endpoint", func() {
+			bus.SendCall.Result = `["some-key"]`
+			Expect(session.GetSessionStorageKeys()).To(Equal([]string{"some-key"}))
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("session_storage"))
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should retrieve the keys using JavaScript", func() {
+				session.W3C = true
+				Expect(session.GetSessionStorageKeys()).To(BeEmpty())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "return Object.keys(sessionStorage);", "args": []}`))
+			})
+		})
+	})
+
+	Describe("#GetSessionStorageItem", func() {
+		It("should successfully send a GET to the session_storage/key endpoint", func() {
+			bus.SendCall.Result = `"some value"`
+			Expect(session.GetSessionStorageItem("some-key")).To(Equal("some value"))
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("session_storage/key/some-key"))
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should retrieve the value using JavaScript", func() {
+				session.W3C = true
+				Expect(session.GetSessionStorageItem("some-key")).To(BeEmpty())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "return sessionStorage.getItem(arguments[0]);", "args": ["some-key"]}`))
+			})
+		})
+	})
+
+	Describe("#SetSessionStorageItem", func() {
+		It("should successfully send a POST to the session_storage endpoint", func() {
+			Expect(session.SetSessionStorageItem("some-key", "some value")).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("session_storage"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"key": "some-key", "value": "some value"}`))
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should set the item using JavaScript", func() {
+				session.W3C = true
+				Expect(session.SetSessionStorageItem("some-key", "some value")).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "sessionStorage.setItem(arguments[0], arguments[1]);", "args": ["some-key", "some value"]}`))
+			})
+		})
+	})
+
+	Describe("#DeleteSessionStorageItem", func() {
+		It("should successfully send a DELETE to the session_storage/key endpoint", func() {
+			Expect(session.DeleteSessionStorageItem("some-key")).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("DELETE"))
+			Expect(bus.SendCall.Endpoint).To(Equal("session_storage/key/some-key"))
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should remove the item using JavaScript", func() {
+				session.W3C = true
+				Expect(session.DeleteSessionStorageItem("some-key")).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "sessionStorage.removeItem(arguments[0]);", "args": ["some-key"]}`))
+			})
+		})
+	})
+
+	Describe("#DeleteSessionStorage", func() {
+		It("should successfully send a DELETE to the session_storage endpoint", func() {
+			Expect(session.DeleteSessionStorage()).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("DELETE"))
+			Expect(bus.SendCall.Endpoint).To(Equal("session_storage"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.DeleteSessionStorage()).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should clear session storage using JavaScript", func() {
+				session.W3C = true
+				Expect(session.DeleteSessionStorage()).To(Succeed())
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "sessionStorage.clear();", "args": []}`))
+			})
+		})
+	})
+})
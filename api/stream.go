@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// A streamBus is a Bus that can provide the string value of a response as it
+// is received, instead of decoding the entire response at once.
+type streamBus interface {
+	SendStream(method, endpoint string, body interface{}, read func(value io.Reader) error) error
+}
+
+type streamContextBus interface {
+	streamBus
+	SendStreamContext(ctx context.Context, method, endpoint string, body interface{}, read func(value io.Reader) error) error
+}
+
+// Buses that cannot stream responses decode the entire string value before
+// it is read.
+func sendStream(b Bus, method, endpoint string, body interface{}, read func(io.Reader) error) error {
+	if stream, ok := b.(streamBus); ok {
+		return stream.SendStream(method, endpoint, body, read)
+	}
+
+	var value string
+	if err := b.Send(method, endpoint, body, &value); err != nil {
+		return err
+	}
+	return read(strings.NewReader(value))
+}
+
+func sendStreamContext(ctx context.Context, b Bus, method, endpoint string, body interface{}, read func(io.Reader) error) error {
+	if stream, ok := b.(streamContextBus); ok {
+		return stream.SendStreamContext(ctx, method, endpoint, body, read)
+	}
+
+	if contextBus, ok := b.(ContextBus); ok {
+		var value string
+		if err := contextBus.SendContext(ctx, method, endpoint, body, &value); err != nil {
+			return err
+		}
+		return read(strings.NewReader(value))
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return sendStream(b, method, endpoint, body, read)
+}
+
+func (s *Session) sendStream(method, endpoint string, body interface{}, read func(io.Reader) error) error {
+	return s.retryAlert(func() error {
+		return wrapError(sendStream(s.Bus, method, endpoint, body, read))
+	})
+}
+
+func (c *contextBus) SendStream(method, endpoint string, body interface{}, read func(io.Reader) error) error {
+	return c.SendStreamContext(c.ctx, method, endpoint, body, read)
+}
+
+func (c *contextBus) SendStreamContext(ctx context.Context, method, endpoint string, body interface{}, read func(io.Reader) error) error {
+	return sendStreamContext(ctx, c.bus, method, endpoint, body, read)
+}
+
+func (d *driverBus) SendStream(method, endpoint string, body interface{}, read func(io.Reader) error) error {
+	return d.SendStreamContext(context.Background(), method, endpoint, body, read)
+}
+
+func (d *driverBus) SendStreamContext(ctx context.Context, method, endpoint string, body interface{}, read func(io.Reader) error) error {
+	if err := d.recoveryError(); err != nil {
+		return err
+	}
+	return d.checkCrash(sendStreamContext(ctx, d.current(), method, endpoint, body, read))
+}
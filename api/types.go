@@ -1,5 +1,7 @@
 package api
 
+import "time"
+
 type Log struct {
 	Message   string
 	Level     string
@@ -26,8 +28,22 @@ type Cookie struct {
 	// HTTPOnly is set to true for HTTP-Only cookies (default: false)
 	HTTPOnly bool `json:"httpOnly,omitempty"`
 
-	// Expiry is the time when the cookie expires
-	Expiry float64 `json:"expiry,omitempty"`
+	// Expiry is the time when the cookie expires, or the zero time for
+	// session cookies (default: session cookie). It is sent to and received
+	// from the WebDriver in whole seconds since the epoch.
+	Expiry time.Time `json:"-"`
+
+	// SameSite is "Strict", "Lax", or "None" (default: browser default)
+	SameSite string `json:"sameSite,omitempty"`
+}
+
+// A Rect defines the position and size of an element, in CSS pixels,
+// relative to the top-left corner of the document.
+type Rect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
 }
 
 type Selector struct {
@@ -42,3 +58,15 @@ const (
 	MiddleButton
 	RightButton
 )
+
+// Timeouts defines the session timeouts used by the WebDriver.
+type Timeouts struct {
+	// Implicit is the time to wait for elements to appear when locating them
+	Implicit time.Duration
+
+	// PageLoad is the time to wait for a page to finish loading
+	PageLoad time.Duration
+
+	// Script is the time to wait for a script to finish executing
+	Script time.Duration
+}
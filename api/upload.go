@@ -0,0 +1,86 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// UploadFile copies the provided local file to the machine running the
+// browser and returns the path of the copy on that machine. This allows
+// remote WebDriver servers, such as Selenium Grid nodes, to fill file inputs
+// with files that only exist locally. WebDriver implementations that run on
+// the local machine may not support this, in which case an error matched by
+// IsUnknownCommand is returned. That error is remembered, and later calls
+// return it without reading the file or sending a request.
+func (s *Session) UploadFile(filename string) (string, error) {
+	state := s.shared()
+	state.uploadMutex.Lock()
+	uploadErr := state.uploadErr
+	state.uploadMutex.Unlock()
+	if uploadErr != nil {
+		return "", uploadErr
+	}
+
+	archive, err := zipFile(filename)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := "file"
+	if s.W3C {
+		endpoint = "se/file"
+	}
+
+	request := struct {
+		File string `json:"file"`
+	}{base64.StdEncoding.EncodeToString(archive)}
+
+	var remotePath string
+	if err := s.Send("POST", endpoint, request, &remotePath); err != nil {
+		if IsUnknownCommand(err) {
+			state.uploadMutex.Lock()
+			state.uploadErr = err
+			state.uploadMutex.Unlock()
+		}
+		return "", err
+	}
+	return remotePath, nil
+}
+
+func zipFile(filename string) ([]byte, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return nil, err
+	}
+	header.Name = filepath.Base(filename)
+	header.Method = zip.Deflate
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	entry, err := writer.CreateHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(entry, file); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return archive.Bytes(), nil
+}
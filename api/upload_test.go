@@ -0,0 +1,108 @@
+package api_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/mocks"
+)
+
+var _ = Describe("Upload", func() {
+	var (
+		bus      *mocks.Bus
+		session  *Session
+		tempDir  string
+		filename string
+	)
+
+	BeforeEach(func() {
+		bus = &mocks.Bus{}
+		session = &Session{Bus: bus}
+		tempDir, _ = ioutil.TempDir("", "agouti-upload")
+		filename = filepath.Join(tempDir, "some-file.txt")
+		ioutil.WriteFile(filename, []byte("some contents"), 0644)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	Describe("#UploadFile", func() {
+		It("should successfully send a POST to the file endpoint with the zipped file", func() {
+			_, err := session.UploadFile(filename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("file"))
+
+			var request struct{ File string }
+			Expect(json.Unmarshal([]byte(bus.SendCall.BodyJSON), &request)).To(Succeed())
+			archive, err := base64.StdEncoding.DecodeString(request.File)
+			Expect(err).NotTo(HaveOccurred())
+			reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reader.File).To(HaveLen(1))
+			Expect(reader.File[0].Name).To(Equal("some-file.txt"))
+			contents, _ := reader.File[0].Open()
+			Expect(ioutil.ReadAll(contents)).To(Equal([]byte("some contents")))
+		})
+
+		It("should return the remote path of the file", func() {
+			bus.SendCall.Result = `"/tmp/remote/some-file.txt"`
+			Expect(session.UploadFile(filename)).To(Equal("/tmp/remote/some-file.txt"))
+		})
+
+		Context("when the file cannot be read", func() {
+			It("should return an error without sending a request", func() {
+				_, err := session.UploadFile(filepath.Join(tempDir, "missing-file"))
+				Expect(err).To(HaveOccurred())
+				Expect(bus.SendCall.Endpoint).To(BeEmpty())
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := session.UploadFile(filename)
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the WebDriver does not support file uploads", func() {
+			BeforeEach(func() {
+				bus.SendCall.Err = &Error{StatusCode: 404, Code: ErrorUnknownCommand, Message: "some error"}
+			})
+
+			It("should return an error matched by IsUnknownCommand", func() {
+				_, err := session.UploadFile(filename)
+				Expect(IsUnknownCommand(err)).To(BeTrue())
+			})
+
+			It("should return the same error for later calls without sending a request", func() {
+				session.UploadFile(filename)
+				bus.SendCall.Endpoint = ""
+				bus.SendCall.Err = nil
+				_, err := session.UploadFile(filename)
+				Expect(IsUnknownCommand(err)).To(BeTrue())
+				Expect(bus.SendCall.Endpoint).To(BeEmpty())
+			})
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a POST to the se/file endpoint", func() {
+				session.W3C = true
+				_, err := session.UploadFile(filename)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bus.SendCall.Endpoint).To(Equal("se/file"))
+			})
+		})
+	})
+})
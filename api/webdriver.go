@@ -2,7 +2,9 @@ package api
 
 import (
 	"fmt"
+	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/sclevine/agouti/api/internal/service"
@@ -12,11 +14,37 @@ type WebDriver struct {
 	Timeout    time.Duration
 	Debug      bool
 	HTTPClient *http.Client
-	service    driverService
-	sessions   []*Session
+
+	// AutoRestart restarts the WebDriver process if it crashes, and reopens
+	// all of its sessions that were not deleted with their original
+	// capabilities. The command that detected the crash still returns
+	// ErrDriverCrashed. If the WebDriver fails to restart or a session fails
+	// to reopen, commands return that error, which wraps ErrDriverCrashed.
+	AutoRestart bool
+
+	// Stdout and Stderr receive the output of the WebDriver process, in
+	// addition to stdout and stderr when Debug is true. The most recent
+	// output is also available from Output.
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// InstallBinary is called by Start, if set, to install the WebDriver
+	// binary before its process is started. The returned path replaces the
+	// first argument of the command. Start fails if the binary cannot be
+	// installed. It only applies to WebDrivers created using NewWebDriver.
+	InstallBinary func() (string, error)
+
+	service       Service
+	sessions      []*Session
+	sessionsMutex sync.Mutex
+	restartMutex  sync.Mutex
+	restartErr    error
 }
 
-type driverService interface {
+// A Service starts and stops the process that provides a WebDriver, such as
+// a local ChromeDriver process or a Docker container. See
+// NewWebDriverWithService.
+type Service interface {
 	URL() string
 	Start(debug bool) error
 	Stop() error
@@ -29,9 +57,15 @@ func NewWebDriver(url string, command []string) *WebDriver {
 		CmdTemplate: command,
 	}
 
+	return NewWebDriverWithService(driverService)
+}
+
+// NewWebDriverWithService returns a WebDriver that is started and stopped
+// using the provided Service.
+func NewWebDriverWithService(service Service) *WebDriver {
 	return &WebDriver{
 		Timeout: 10 * time.Second,
-		service: driverService,
+		service: service,
 	}
 }
 
@@ -49,13 +83,34 @@ func (w *WebDriver) Open(desiredCapabilites map[string]interface{}) (*Session, e
 	if err != nil {
 		return nil, err
 	}
+	session.Bus = &driverBus{
+		driver:       w,
+		session:      session,
+		capabilities: desiredCapabilites,
+		bus:          session.Bus,
+	}
 
+	w.sessionsMutex.Lock()
 	w.sessions = append(w.sessions, session)
+	w.sessionsMutex.Unlock()
 	return session, nil
 }
 
+// Status retrieves the status of the running WebDriver. See DriverStatus.
+func (w *WebDriver) Status() (*DriverStatus, error) {
+	url := w.service.URL()
+	if url == "" {
+		return nil, fmt.Errorf("service not started")
+	}
+	return StatusWithClient(url, w.HTTPClient)
+}
+
 func (w *WebDriver) Start() error {
-	if err := w.service.Start(w.Debug); err != nil {
+	w.restartMutex.Lock()
+	w.restartErr = nil
+	w.restartMutex.Unlock()
+
+	if err := w.startService(); err != nil {
 		return fmt.Errorf("failed to start service: %s", err)
 	}
 
@@ -67,14 +122,65 @@ func (w *WebDriver) Start() error {
 	return nil
 }
 
+func (w *WebDriver) startService() error {
+	if commandService, ok := w.service.(*service.Service); ok {
+		commandService.Stdout = w.Stdout
+		commandService.Stderr = w.Stderr
+		if w.InstallBinary != nil && len(commandService.CmdTemplate) > 0 {
+			binaryPath, err := w.InstallBinary()
+			if err != nil {
+				return err
+			}
+			commandService.CmdTemplate = append([]string{binaryPath}, commandService.CmdTemplate[1:]...)
+		}
+	}
+	return w.service.Start(w.Debug)
+}
+
+// Output returns the most recent output of the WebDriver process (up to
+// 64KB), with stdout and stderr interleaved, ex. to attach to the report of
+// a failed test. Output is retained after the process is stopped, and is
+// only available for WebDrivers that run a local process.
+func (w *WebDriver) Output() []byte {
+	if outputService, ok := w.service.(interface{ Output() []byte }); ok {
+		return outputService.Output()
+	}
+	return nil
+}
+
+// Stop deletes all sessions opened by the WebDriver and stops its process.
+// Sessions are not deleted if the process has crashed.
 func (w *WebDriver) Stop() error {
-	for _, session := range w.sessions {
-		session.Delete()
+	if w.crashed() == nil {
+		for _, session := range w.openSessions() {
+			session.Delete()
+		}
 	}
 
+	w.sessionsMutex.Lock()
+	w.sessions = nil
+	w.sessionsMutex.Unlock()
+
 	if err := w.service.Stop(); err != nil {
 		return fmt.Errorf("failed to stop service: %s", err)
 	}
 
 	return nil
 }
+
+func (w *WebDriver) openSessions() []*Session {
+	w.sessionsMutex.Lock()
+	defer w.sessionsMutex.Unlock()
+	return append([]*Session(nil), w.sessions...)
+}
+
+func (w *WebDriver) removeSession(session *Session) {
+	w.sessionsMutex.Lock()
+	defer w.sessionsMutex.Unlock()
+	for index, existing := range w.sessions {
+		if existing == session {
+			w.sessions = append(w.sessions[:index], w.sessions[index+1:]...)
+			return
+		}
+	}
+}
@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -31,20 +32,33 @@ var _ = Describe("WebDriver", func() {
 		webDriver.Timeout = 2 * time.Second
 	})
 
+	Describe(".NewWebDriverWithService", func() {
+		It("should start and stop the provided service", func() {
+			webDriver := NewWebDriverWithService(service)
+			Expect(webDriver.Start()).To(Succeed())
+			Expect(service.StartCall.Called).To(BeTrue())
+			Expect(service.WaitForBootCall.Timeout).To(Equal(10 * time.Second))
+			Expect(webDriver.Stop()).To(Succeed())
+			Expect(service.StopCall.Called).To(BeTrue())
+		})
+	})
+
 	Describe("#Open", func() {
 		var (
-			server        *httptest.Server
-			requestBody   string
-			requestMethod string
-			responseBody  string
+			server         *httptest.Server
+			requestBody    string
+			requestMethod  string
+			responseBody   string
+			responseStatus int
 		)
 
 		BeforeEach(func() {
-			responseBody = `{"sessionId": "some-id"}`
+			responseBody, responseStatus = `{"sessionId": "some-id"}`, 200
 			server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
 				requestBodyBytes, _ := ioutil.ReadAll(request.Body)
 				requestBody = string(requestBodyBytes)
 				requestMethod = request.Method
+				response.WriteHeader(responseStatus)
 				response.Write([]byte(responseBody))
 			}))
 			service.URLCall.ReturnURL = server.URL
@@ -57,7 +71,8 @@ var _ = Describe("WebDriver", func() {
 		It("should successfully return a session with the desired capabilities", func() {
 			session, err := webDriver.Open(map[string]interface{}{"some": "capability"})
 			Expect(err).NotTo(HaveOccurred())
-			Expect(requestBody).To(Equal(`{"desiredCapabilities":{"some":"capability"}}`))
+			Expect(requestBody).To(MatchJSON(`{"desiredCapabilities": {"some": "capability"}, "capabilities": {"alwaysMatch": {}}}`))
+			Expect(session.ID()).To(Equal("some-id"))
 			responseBody = `{"value": "some title"}`
 			Expect(session.GetTitle()).To(Equal("some title"))
 		})
@@ -89,6 +104,14 @@ var _ = Describe("WebDriver", func() {
 			})
 		})
 
+		Context("when the WebDriver fails to create a session", func() {
+			It("should return an *Error with the error code", func() {
+				responseBody, responseStatus = `{"value": {"error": "session not created", "message": "some error"}}`, 500
+				_, err := webDriver.Open(nil)
+				Expect(err).To(Equal(&Error{StatusCode: 500, Code: "session not created", Message: "some error"}))
+			})
+		})
+
 		Context("when a custom HTTP client is set", func() {
 			It("should open the session using that client", func() {
 				var path string
@@ -103,6 +126,184 @@ var _ = Describe("WebDriver", func() {
 		})
 	})
 
+	Describe("crash detection", func() {
+		var (
+			server          *httptest.Server
+			requests        []string
+			sessionCount    int
+			commandsSucceed bool
+			reopenFails     bool
+		)
+
+		BeforeEach(func() {
+			requests, sessionCount, commandsSucceed, reopenFails = nil, 0, false, false
+			server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				requests = append(requests, request.Method+" "+request.URL.Path)
+				if request.URL.Path == "/session" {
+					if reopenFails && sessionCount > 0 {
+						response.WriteHeader(500)
+						response.Write([]byte(`{"value": {"error": "session not created", "message": "some error"}}`))
+						return
+					}
+					sessionCount++
+					fmt.Fprintf(response, `{"value": {"sessionId": "some-id-%d", "capabilities": {}}}`, sessionCount)
+					return
+				}
+				if !commandsSucceed {
+					response.WriteHeader(500)
+					response.Write([]byte(`{"value": {"error": "unknown error", "message": "some error"}}`))
+					return
+				}
+				response.Write([]byte(`{"value": "some title"}`))
+			}))
+			service.URLCall.ReturnURL = server.URL
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		Context("when the WebDriver process has crashed", func() {
+			It("should return ErrDriverCrashed from failed commands", func() {
+				session, err := webDriver.Open(nil)
+				Expect(err).NotTo(HaveOccurred())
+				service.CrashedCall.Err = errors.New("signal: killed")
+				_, err = session.GetTitle()
+				Expect(err).To(Equal(ErrDriverCrashed))
+				Expect(webDriver.Crashed()).To(MatchError("signal: killed"))
+				Expect(service.StartCall.Called).To(BeFalse())
+			})
+
+			It("should not delete its sessions when stopped", func() {
+				_, err := webDriver.Open(nil)
+				Expect(err).NotTo(HaveOccurred())
+				service.CrashedCall.Err = errors.New("signal: killed")
+				Expect(webDriver.Stop()).To(Succeed())
+				Expect(requests).To(Equal([]string{"POST /session"}))
+			})
+
+			Context("when AutoRestart is enabled", func() {
+				It("should restart the WebDriver and reopen its sessions", func() {
+					webDriver.AutoRestart = true
+					session, err := webDriver.Open(map[string]interface{}{"browserName": "some-browser"})
+					Expect(err).NotTo(HaveOccurred())
+					var hookEndpoints []string
+					Expect(session.AddCommandHook(func(method, endpoint string, body, result []byte, err error, duration time.Duration) {
+						hookEndpoints = append(hookEndpoints, endpoint)
+					})).To(Succeed())
+
+					service.CrashedCall.Err = errors.New("signal: killed")
+					_, err = session.GetTitle()
+					Expect(err).To(Equal(ErrDriverCrashed))
+					Expect(service.StopCall.Called).To(BeTrue())
+					Expect(service.StartCall.Called).To(BeTrue())
+					Expect(session.ID()).To(Equal("some-id-2"))
+
+					service.CrashedCall.Err = nil
+					commandsSucceed = true
+					Expect(session.GetTitle()).To(Equal("some title"))
+					Expect(requests[len(requests)-1]).To(Equal("GET /session/some-id-2/title"))
+					Expect(hookEndpoints).To(Equal([]string{"title", "title"}))
+				})
+
+				It("should not reopen sessions that were deleted", func() {
+					webDriver.AutoRestart = true
+					deletedSession, err := webDriver.Open(nil)
+					Expect(err).NotTo(HaveOccurred())
+					session, err := webDriver.Open(nil)
+					Expect(err).NotTo(HaveOccurred())
+					deletedSession.Delete()
+
+					service.CrashedCall.Err = errors.New("signal: killed")
+					_, err = session.GetTitle()
+					Expect(err).To(Equal(ErrDriverCrashed))
+					Expect(sessionCount).To(Equal(3))
+					Expect(session.ID()).To(Equal("some-id-3"))
+				})
+
+				Context("when the WebDriver fails to restart", func() {
+					It("should return the restart error from the failed command and later commands", func() {
+						webDriver.AutoRestart = true
+						session, err := webDriver.Open(nil)
+						Expect(err).NotTo(HaveOccurred())
+
+						service.CrashedCall.Err = errors.New("signal: killed")
+						service.WaitForBootCall.Err = errors.New("some error")
+						_, err = session.GetTitle()
+						Expect(err).To(MatchError("WebDriver process crashed: failed to restart WebDriver: some error"))
+						Expect(errors.Is(err, ErrDriverCrashed)).To(BeTrue())
+
+						service.CrashedCall.Err = nil
+						requests = nil
+						_, err = session.GetTitle()
+						Expect(err).To(MatchError("WebDriver process crashed: failed to restart WebDriver: some error"))
+						Expect(requests).To(BeEmpty())
+					})
+				})
+
+				Context("when a session fails to reopen", func() {
+					It("should return the reopen error from the failed command and later commands", func() {
+						webDriver.AutoRestart = true
+						session, err := webDriver.Open(nil)
+						Expect(err).NotTo(HaveOccurred())
+
+						reopenFails = true
+						service.CrashedCall.Err = errors.New("signal: killed")
+						_, err = session.GetTitle()
+						Expect(err).To(MatchError("WebDriver process crashed: failed to reopen session: request unsuccessful: some error"))
+						Expect(errors.Is(err, ErrDriverCrashed)).To(BeTrue())
+
+						service.CrashedCall.Err = nil
+						requests = nil
+						_, err = session.GetTitle()
+						Expect(errors.Is(err, ErrDriverCrashed)).To(BeTrue())
+						Expect(requests).To(BeEmpty())
+					})
+				})
+			})
+		})
+
+		Context("when a command fails but the WebDriver process has not crashed", func() {
+			It("should return the error from the WebDriver", func() {
+				webDriver.AutoRestart = true
+				session, err := webDriver.Open(nil)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = session.GetTitle()
+				Expect(err).To(MatchError("request unsuccessful: some error"))
+				Expect(service.StartCall.Called).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("#Status", func() {
+		It("should successfully return the status of the running WebDriver using its client", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				response.Write([]byte(`{"value": {"ready": true, "message": "some message"}}`))
+			}))
+			defer server.Close()
+			service.URLCall.ReturnURL = server.URL
+
+			requested := false
+			webDriver.HTTPClient = &http.Client{Transport: roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+				requested = true
+				return http.DefaultTransport.RoundTrip(request)
+			})}
+
+			status, err := webDriver.Status()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Ready).To(BeTrue())
+			Expect(status.Message).To(Equal("some message"))
+			Expect(requested).To(BeTrue())
+		})
+
+		Context("when the WebDriver is not running", func() {
+			It("should return an error", func() {
+				_, err := webDriver.Status()
+				Expect(err).To(MatchError("service not started"))
+			})
+		})
+	})
+
 	Describe("#Start", func() {
 		It("should successfully start the WebDriver service", func() {
 			Expect(webDriver.Start()).To(Succeed())
@@ -133,6 +334,31 @@ var _ = Describe("WebDriver", func() {
 				Expect(service.StopCall.Called).To(BeTrue())
 			})
 		})
+
+		Context("when the WebDriver has an InstallBinary function", func() {
+			var commandDriver *WebDriver
+
+			BeforeEach(func() {
+				commandDriver = NewWebDriver("http://{{.Address}}", []string{"some-binary", "--port={{.Port}}"})
+			})
+
+			It("should run the installed binary", func() {
+				commandDriver.InstallBinary = func() (string, error) {
+					return "some-installed-binary", nil
+				}
+				err := commandDriver.Start()
+				Expect(err).To(MatchError(ContainSubstring("some-installed-binary")))
+			})
+
+			Context("when the binary cannot be installed", func() {
+				It("should return an error", func() {
+					commandDriver.InstallBinary = func() (string, error) {
+						return "", errors.New("some error")
+					}
+					Expect(commandDriver.Start()).To(MatchError("failed to start service: some error"))
+				})
+			})
+		})
 	})
 
 	Describe("#Stop", func() {
@@ -11,11 +11,89 @@ func (w *Window) Send(method, endpoint string, body, result interface{}) error {
 	return w.Session.Send(method, path.Join("window", w.ID, endpoint), body, result)
 }
 
+// SetSize sets the size of the window. For W3C sessions, the window must be
+// the current window, as the W3C dialect only supports resizing it.
 func (w *Window) SetSize(width, height int) error {
 	request := struct {
 		Width  int `json:"width"`
 		Height int `json:"height"`
 	}{width, height}
 
+	if w.Session.W3C {
+		return w.Session.Send("POST", "window/rect", request, nil)
+	}
 	return w.Send("POST", "size", request, nil)
 }
+
+// GetSize returns the size of the window. For W3C sessions, the window must be
+// the current window.
+func (w *Window) GetSize() (width, height int, err error) {
+	var size struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	}
+
+	if w.Session.W3C {
+		err = w.Session.Send("GET", "window/rect", nil, &size)
+	} else {
+		err = w.Send("GET", "size", nil, &size)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return size.Width, size.Height, nil
+}
+
+// SetPosition moves the top-left corner of the window to the provided screen
+// coordinates. For W3C sessions, the window must be the current window.
+func (w *Window) SetPosition(x, y int) error {
+	request := struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}{x, y}
+
+	if w.Session.W3C {
+		return w.Session.Send("POST", "window/rect", request, nil)
+	}
+	return w.Send("POST", "position", request, nil)
+}
+
+// GetPosition returns the screen coordinates of the top-left corner of the
+// window. For W3C sessions, the window must be the current window.
+func (w *Window) GetPosition() (x, y int, err error) {
+	var position struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+
+	if w.Session.W3C {
+		err = w.Session.Send("GET", "window/rect", nil, &position)
+	} else {
+		err = w.Send("GET", "position", nil, &position)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return position.X, position.Y, nil
+}
+
+// Maximize maximizes the window. For W3C sessions, the window must be the
+// current window.
+func (w *Window) Maximize() error {
+	if w.Session.W3C {
+		return w.Session.Send("POST", "window/maximize", nil, nil)
+	}
+	return w.Send("POST", "maximize", nil, nil)
+}
+
+// Minimize minimizes (iconifies) the window. The legacy dialect has no
+// equivalent command, so this always applies to the current window.
+func (w *Window) Minimize() error {
+	return w.Session.Send("POST", "window/minimize", nil, nil)
+}
+
+// Fullscreen makes the window fill the screen. The legacy dialect has no
+// equivalent command, so this always applies to the current window.
+func (w *Window) Fullscreen() error {
+	return w.Session.Send("POST", "window/fullscreen", nil, nil)
+}
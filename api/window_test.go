@@ -17,7 +17,7 @@ var _ = Describe("Window", func() {
 
 	BeforeEach(func() {
 		bus = &mocks.Bus{}
-		window = &Window{"some-id", &Session{bus}}
+		window = &Window{"some-id", &Session{Bus: bus}}
 	})
 
 	Describe("#Send", func() {
@@ -58,5 +58,165 @@ var _ = Describe("Window", func() {
 				Expect(window.SetSize(640, 480)).To(MatchError("some error"))
 			})
 		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a POST request to the window/rect endpoint", func() {
+				window.Session.W3C = true
+				Expect(window.SetSize(640, 480)).To(Succeed())
+				Expect(bus.SendCall.Method).To(Equal("POST"))
+				Expect(bus.SendCall.Endpoint).To(Equal("window/rect"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"width":640,"height":480}`))
+			})
+		})
+	})
+
+	Describe("#GetSize", func() {
+		It("should successfully send a GET request to the size endpoint", func() {
+			_, _, err := window.GetSize()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("window/some-id/size"))
+		})
+
+		It("should return the width and height of the window", func() {
+			bus.SendCall.Result = `{"width": 640, "height": 480}`
+			width, height, _ := window.GetSize()
+			Expect(width).To(Equal(640))
+			Expect(height).To(Equal(480))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, _, err := window.GetSize()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a GET request to the window/rect endpoint", func() {
+				window.Session.W3C = true
+				bus.SendCall.Result = `{"x": 10, "y": 20, "width": 640, "height": 480}`
+				width, height, err := window.GetSize()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bus.SendCall.Endpoint).To(Equal("window/rect"))
+				Expect(width).To(Equal(640))
+				Expect(height).To(Equal(480))
+			})
+		})
+	})
+
+	Describe("#SetPosition", func() {
+		It("should successfully send a POST request to the position endpoint", func() {
+			Expect(window.SetPosition(10, 20)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("window/some-id/position"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"x":10,"y":20}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(window.SetPosition(10, 20)).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a POST request to the window/rect endpoint", func() {
+				window.Session.W3C = true
+				Expect(window.SetPosition(10, 20)).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("window/rect"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"x":10,"y":20}`))
+			})
+		})
+	})
+
+	Describe("#GetPosition", func() {
+		It("should successfully send a GET request to the position endpoint", func() {
+			_, _, err := window.GetPosition()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("window/some-id/position"))
+		})
+
+		It("should return the position of the window", func() {
+			bus.SendCall.Result = `{"x": 10, "y": 20}`
+			x, y, _ := window.GetPosition()
+			Expect(x).To(Equal(10))
+			Expect(y).To(Equal(20))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, _, err := window.GetPosition()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a GET request to the window/rect endpoint", func() {
+				window.Session.W3C = true
+				bus.SendCall.Result = `{"x": 10, "y": 20, "width": 640, "height": 480}`
+				x, y, err := window.GetPosition()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bus.SendCall.Endpoint).To(Equal("window/rect"))
+				Expect(x).To(Equal(10))
+				Expect(y).To(Equal(20))
+			})
+		})
+	})
+
+	Describe("#Maximize", func() {
+		It("should successfully send a POST request to the maximize endpoint", func() {
+			Expect(window.Maximize()).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("window/some-id/maximize"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(window.Maximize()).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the session uses the W3C dialect", func() {
+			It("should successfully send a POST request to the window/maximize endpoint", func() {
+				window.Session.W3C = true
+				Expect(window.Maximize()).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("window/maximize"))
+			})
+		})
+	})
+
+	Describe("#Minimize", func() {
+		It("should successfully send a POST request to the window/minimize endpoint", func() {
+			Expect(window.Minimize()).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("window/minimize"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(window.Minimize()).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#Fullscreen", func() {
+		It("should successfully send a POST request to the window/fullscreen endpoint", func() {
+			Expect(window.Fullscreen()).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("window/fullscreen"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(window.Fullscreen()).To(MatchError("some error"))
+			})
+		})
 	})
 })
@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/sclevine/agouti"
-	"github.com/sclevine/agouti/api"
 	"github.com/sclevine/agouti/api/mobile"
 	"github.com/sclevine/agouti/internal/element"
 	"github.com/sclevine/agouti/internal/target"
@@ -166,7 +165,11 @@ func (t *TouchAction) Perform() error {
 			if err != nil {
 				return fmt.Errorf("failed to retrieve element for selection %q: %s", action.Elements(), err)
 			}
-			action.Options.Element = selectedElement.(*api.Element).ID
+			apiElement, err := element.Unwrap(selectedElement)
+			if err != nil {
+				return fmt.Errorf("failed to retrieve element for selection %q: %s", action.Elements(), err)
+			}
+			action.Options.Element = apiElement.ID
 		}
 
 		actions = append(actions, action.Action)
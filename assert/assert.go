@@ -0,0 +1,83 @@
+// Package assert provides assertions for agouti pages and selections that
+// report failures using a *testing.T, so that agouti may be used with the
+// standard testing package or testify without depending on Gomega.
+//
+// Each assertion mirrors a matcher from the matchers package, marks itself as
+// a test helper, and stops the test with t.Fatalf when it fails:
+//    func TestLogin(t *testing.T) {
+//        ...
+//        assert.Title(t, page, "Dashboard")
+//        assert.Text(t, page.Find("#welcome"), "Welcome back!")
+//        assert.Visible(t, page.Find("#logout"))
+//    }
+package assert
+
+import "fmt"
+
+// TestingT is the subset of *testing.T used to report failures.
+// It is also satisfied by *testing.B and testify's require.TestingT wrappers.
+type TestingT interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+}
+
+// Page is the subset of *agouti.Page used by the page assertions.
+type Page interface {
+	String() string
+	Title() (string, error)
+	URL() (string, error)
+	PopupText() (string, error)
+	WindowCount() (int, error)
+}
+
+// Selection is the subset of *agouti.Selection used by the selection
+// assertions. It is satisfied by *agouti.Selection and *agouti.MultiSelection.
+type Selection interface {
+	String() string
+	Count() (int, error)
+	Text() (string, error)
+	Value() (string, error)
+	HasClass(class string) (bool, error)
+	Attribute(attribute string) (string, error)
+	CSS(property string) (string, error)
+	Selected() (bool, error)
+	Visible() (bool, error)
+	Enabled() (bool, error)
+	Active() (bool, error)
+}
+
+const tab = "    "
+
+func valueMessage(actual interface{}, message string, expected, actualValue interface{}) string {
+	return fmt.Sprintf("Expected %s %s\n%s%v\nbut found\n%s%v", actual, message, tab, expected, tab, actualValue)
+}
+
+func booleanMessage(actual interface{}, message string) string {
+	return fmt.Sprintf("Expected %s %s", actual, message)
+}
+
+func assertValue(t TestingT, actual interface{}, property string, expected, actualValue interface{}, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s", err)
+		return
+	}
+	if actualValue != expected {
+		t.Fatalf("%s", valueMessage(actual, "to have "+property+" equaling", expected, actualValue))
+	}
+}
+
+func assertState(t TestingT, actual interface{}, state string, expected, actualState bool, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s", err)
+		return
+	}
+	if actualState != expected {
+		if expected {
+			t.Fatalf("%s", booleanMessage(actual, "to be "+state))
+		} else {
+			t.Fatalf("%s", booleanMessage(actual, "not to be "+state))
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package mocks
+
+type Page struct {
+	TitleCall struct {
+		ReturnTitle string
+		Err         error
+	}
+
+	URLCall struct {
+		ReturnURL string
+		Err       error
+	}
+
+	PopupTextCall struct {
+		ReturnText string
+		Err        error
+	}
+
+	WindowCountCall struct {
+		ReturnCount int
+		Err         error
+	}
+}
+
+func (*Page) String() string {
+	return "page"
+}
+
+func (p *Page) Title() (string, error) {
+	return p.TitleCall.ReturnTitle, p.TitleCall.Err
+}
+
+func (p *Page) URL() (string, error) {
+	return p.URLCall.ReturnURL, p.URLCall.Err
+}
+
+func (p *Page) PopupText() (string, error) {
+	return p.PopupTextCall.ReturnText, p.PopupTextCall.Err
+}
+
+func (p *Page) WindowCount() (int, error) {
+	return p.WindowCountCall.ReturnCount, p.WindowCountCall.Err
+}
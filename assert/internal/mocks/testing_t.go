@@ -0,0 +1,23 @@
+package mocks
+
+import "fmt"
+
+type TestingT struct {
+	HelperCall struct {
+		Called bool
+	}
+
+	FatalfCall struct {
+		Called  bool
+		Message string
+	}
+}
+
+func (t *TestingT) Helper() {
+	t.HelperCall.Called = true
+}
+
+func (t *TestingT) Fatalf(format string, args ...interface{}) {
+	t.FatalfCall.Called = true
+	t.FatalfCall.Message = fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,31 @@
+package assert
+
+// Title asserts that the title of the page is equal to the expected title.
+func Title(t TestingT, page Page, title string) {
+	t.Helper()
+	actual, err := page.Title()
+	assertValue(t, page, "title", title, actual, err)
+}
+
+// URL asserts that the URL of the page is equal to the expected URL.
+func URL(t TestingT, page Page, url string) {
+	t.Helper()
+	actual, err := page.URL()
+	assertValue(t, page, "URL", url, actual, err)
+}
+
+// PopupText asserts that the text of the open alert, confirm, or prompt
+// popup is equal to the expected text.
+func PopupText(t TestingT, page Page, text string) {
+	t.Helper()
+	actual, err := page.PopupText()
+	assertValue(t, page, "popup text", text, actual, err)
+}
+
+// WindowCount asserts that the number of open windows is equal to the
+// expected count.
+func WindowCount(t TestingT, page Page, count int) {
+	t.Helper()
+	actual, err := page.WindowCount()
+	assertValue(t, page, "window count", count, actual, err)
+}
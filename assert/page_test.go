@@ -0,0 +1,73 @@
+package assert_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/assert"
+	"github.com/sclevine/agouti/assert/internal/mocks"
+)
+
+var _ = Describe("Page Assertions", func() {
+	var (
+		t    *mocks.TestingT
+		page *mocks.Page
+	)
+
+	BeforeEach(func() {
+		t = &mocks.TestingT{}
+		page = &mocks.Page{}
+	})
+
+	Describe(".Title", func() {
+		It("should pass when the title is equal to the expected title", func() {
+			page.TitleCall.ReturnTitle = "Some Title"
+			assert.Title(t, page, "Some Title")
+			Expect(t.HelperCall.Called).To(BeTrue())
+			Expect(t.FatalfCall.Called).To(BeFalse())
+		})
+
+		It("should fail with the expected and actual titles when they differ", func() {
+			page.TitleCall.ReturnTitle = "Other Title"
+			assert.Title(t, page, "Some Title")
+			Expect(t.FatalfCall.Message).To(Equal("Expected page to have title equaling\n    Some Title\nbut found\n    Other Title"))
+		})
+
+		It("should fail with the error when retrieving the title fails", func() {
+			page.TitleCall.Err = errors.New("some error")
+			assert.Title(t, page, "Some Title")
+			Expect(t.FatalfCall.Message).To(Equal("some error"))
+		})
+	})
+
+	Describe(".URL", func() {
+		It("should compare the URL of the page to the expected URL", func() {
+			page.URLCall.ReturnURL = "http://example.com/other"
+			assert.URL(t, page, "http://example.com/other")
+			Expect(t.FatalfCall.Called).To(BeFalse())
+			assert.URL(t, page, "http://example.com")
+			Expect(t.FatalfCall.Message).To(Equal("Expected page to have URL equaling\n    http://example.com\nbut found\n    http://example.com/other"))
+		})
+	})
+
+	Describe(".PopupText", func() {
+		It("should compare the popup text to the expected text", func() {
+			page.PopupTextCall.ReturnText = "some text"
+			assert.PopupText(t, page, "some text")
+			Expect(t.FatalfCall.Called).To(BeFalse())
+			assert.PopupText(t, page, "other text")
+			Expect(t.FatalfCall.Message).To(Equal("Expected page to have popup text equaling\n    other text\nbut found\n    some text"))
+		})
+	})
+
+	Describe(".WindowCount", func() {
+		It("should compare the number of windows to the expected count", func() {
+			page.WindowCountCall.ReturnCount = 2
+			assert.WindowCount(t, page, 2)
+			Expect(t.FatalfCall.Called).To(BeFalse())
+			assert.WindowCount(t, page, 1)
+			Expect(t.FatalfCall.Message).To(Equal("Expected page to have window count equaling\n    1\nbut found\n    2"))
+		})
+	})
+})
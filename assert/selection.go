@@ -0,0 +1,159 @@
+package assert
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Text asserts that the text of exactly one element is equal to the expected
+// text.
+func Text(t TestingT, selection Selection, text string) {
+	t.Helper()
+	actual, err := selection.Text()
+	assertValue(t, selection, "text", text, actual, err)
+}
+
+// MatchesText asserts that the expected regular expression matches the text
+// of exactly one element.
+func MatchesText(t TestingT, selection Selection, pattern string) {
+	t.Helper()
+	actual, err := selection.Text()
+	if err != nil {
+		t.Fatalf("%s", err)
+		return
+	}
+	matched, err := regexp.MatchString(pattern, actual)
+	if err != nil {
+		t.Fatalf("invalid regular expression %q: %s", pattern, err)
+		return
+	}
+	if !matched {
+		t.Fatalf("%s", valueMessage(selection, "to have text matching", pattern, actual))
+	}
+}
+
+// Count asserts that the selection refers to the expected number of elements.
+func Count(t TestingT, selection Selection, count int) {
+	t.Helper()
+	actual, err := selection.Count()
+	assertValue(t, selection, "element count", count, actual, err)
+}
+
+// Found asserts that the selection refers to at least one element.
+func Found(t TestingT, selection Selection) {
+	t.Helper()
+	found, err := isFound(selection)
+	assertState(t, selection, "found", true, found, err)
+}
+
+// NotFound asserts that the selection does not refer to any elements.
+func NotFound(t TestingT, selection Selection) {
+	t.Helper()
+	found, err := isFound(selection)
+	assertState(t, selection, "found", false, found, err)
+}
+
+// Selections of a single element fail to count their elements when the
+// element does not exist, which only means that nothing was found.
+func isFound(selection Selection) (bool, error) {
+	count, err := selection.Count()
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "element not found") ||
+			strings.HasSuffix(err.Error(), "element index out of range") {
+			return false, nil
+		}
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Value asserts that the value of exactly one form element is equal to the
+// expected value.
+func Value(t TestingT, selection Selection, value string) {
+	t.Helper()
+	actual, err := selection.Value()
+	assertValue(t, selection, "value", value, actual, err)
+}
+
+// HasClass asserts that the class list of exactly one element contains the
+// expected class.
+func HasClass(t TestingT, selection Selection, class string) {
+	t.Helper()
+	hasClass, err := selection.HasClass(class)
+	if err != nil {
+		t.Fatalf("%s", err)
+		return
+	}
+	if !hasClass {
+		t.Fatalf("%s", booleanMessage(selection, fmt.Sprintf(`to have class "%s"`, class)))
+	}
+}
+
+// Attribute asserts that the provided attribute of exactly one element is
+// equal to the expected value.
+func Attribute(t TestingT, selection Selection, attribute, value string) {
+	t.Helper()
+	actual, err := selection.Attribute(attribute)
+	assertValue(t, selection, fmt.Sprintf("attribute '%s'", attribute), value, actual, err)
+}
+
+// CSS asserts that the computed value of the provided CSS property of exactly
+// one element is equal to the expected value.
+func CSS(t TestingT, selection Selection, property, value string) {
+	t.Helper()
+	actual, err := selection.CSS(property)
+	assertValue(t, selection, fmt.Sprintf("CSS property '%s'", property), value, actual, err)
+}
+
+// Selected asserts that every element in the selection is selected.
+func Selected(t TestingT, selection Selection) {
+	t.Helper()
+	selected, err := selection.Selected()
+	assertState(t, selection, "selected", true, selected, err)
+}
+
+// NotSelected asserts that the selection is not selected, which is the case
+// when any element in the selection is not selected.
+func NotSelected(t TestingT, selection Selection) {
+	t.Helper()
+	selected, err := selection.Selected()
+	assertState(t, selection, "selected", false, selected, err)
+}
+
+// Visible asserts that every element in the selection is visible.
+func Visible(t TestingT, selection Selection) {
+	t.Helper()
+	visible, err := selection.Visible()
+	assertState(t, selection, "visible", true, visible, err)
+}
+
+// NotVisible asserts that the selection is not visible, which is the case
+// when any element in the selection is not visible.
+func NotVisible(t TestingT, selection Selection) {
+	t.Helper()
+	visible, err := selection.Visible()
+	assertState(t, selection, "visible", false, visible, err)
+}
+
+// Enabled asserts that every element in the selection is enabled.
+func Enabled(t TestingT, selection Selection) {
+	t.Helper()
+	enabled, err := selection.Enabled()
+	assertState(t, selection, "enabled", true, enabled, err)
+}
+
+// NotEnabled asserts that the selection is not enabled, which is the case
+// when any element in the selection is not enabled.
+func NotEnabled(t TestingT, selection Selection) {
+	t.Helper()
+	enabled, err := selection.Enabled()
+	assertState(t, selection, "enabled", false, enabled, err)
+}
+
+// Focused asserts that exactly one element is the focused element of the page.
+func Focused(t TestingT, selection Selection) {
+	t.Helper()
+	focused, err := selection.Active()
+	assertState(t, selection, "focused", true, focused, err)
+}
@@ -0,0 +1,200 @@
+package assert_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/assert"
+	"github.com/sclevine/agouti/assert/internal/mocks"
+)
+
+var _ = Describe("Selection Assertions", func() {
+	var (
+		t         *mocks.TestingT
+		selection *mocks.Selection
+	)
+
+	BeforeEach(func() {
+		t = &mocks.TestingT{}
+		selection = &mocks.Selection{}
+	})
+
+	Describe(".Text", func() {
+		It("should pass when the text is equal to the expected text", func() {
+			selection.TextCall.ReturnText = "some text"
+			assert.Text(t, selection, "some text")
+			Expect(t.HelperCall.Called).To(BeTrue())
+			Expect(t.FatalfCall.Called).To(BeFalse())
+		})
+
+		It("should fail with the expected and actual text when they differ", func() {
+			selection.TextCall.ReturnText = "other text"
+			assert.Text(t, selection, "some text")
+			Expect(t.FatalfCall.Message).To(Equal("Expected selection 'CSS: #selector' to have text equaling\n    some text\nbut found\n    other text"))
+		})
+
+		It("should fail with the error when retrieving the text fails", func() {
+			selection.TextCall.Err = errors.New("some error")
+			assert.Text(t, selection, "some text")
+			Expect(t.FatalfCall.Message).To(Equal("some error"))
+		})
+	})
+
+	Describe(".MatchesText", func() {
+		BeforeEach(func() {
+			selection.TextCall.ReturnText = "some text"
+		})
+
+		It("should pass when the regular expression matches the text", func() {
+			assert.MatchesText(t, selection, "s[^t]+text")
+			Expect(t.FatalfCall.Called).To(BeFalse())
+		})
+
+		It("should fail when the regular expression does not match the text", func() {
+			assert.MatchesText(t, selection, "^text")
+			Expect(t.FatalfCall.Message).To(Equal("Expected selection 'CSS: #selector' to have text matching\n    ^text\nbut found\n    some text"))
+		})
+
+		It("should fail when the regular expression is invalid", func() {
+			assert.MatchesText(t, selection, "(")
+			Expect(t.FatalfCall.Message).To(HavePrefix(`invalid regular expression "(": `))
+		})
+	})
+
+	Describe(".Count", func() {
+		It("should compare the number of elements to the expected count", func() {
+			selection.CountCall.ReturnCount = 2
+			assert.Count(t, selection, 2)
+			Expect(t.FatalfCall.Called).To(BeFalse())
+			assert.Count(t, selection, 3)
+			Expect(t.FatalfCall.Message).To(Equal("Expected selection 'CSS: #selector' to have element count equaling\n    3\nbut found\n    2"))
+		})
+	})
+
+	Describe(".Found", func() {
+		It("should pass when the selection refers to elements", func() {
+			selection.CountCall.ReturnCount = 1
+			assert.Found(t, selection)
+			Expect(t.FatalfCall.Called).To(BeFalse())
+		})
+
+		It("should fail when the element is not found", func() {
+			selection.CountCall.Err = errors.New("some error: element not found")
+			assert.Found(t, selection)
+			Expect(t.FatalfCall.Message).To(Equal("Expected selection 'CSS: #selector' to be found"))
+		})
+
+		It("should fail with any other error", func() {
+			selection.CountCall.Err = errors.New("some error")
+			assert.Found(t, selection)
+			Expect(t.FatalfCall.Message).To(Equal("some error"))
+		})
+	})
+
+	Describe(".NotFound", func() {
+		It("should pass when the element is not found", func() {
+			selection.CountCall.Err = errors.New("some error: element index out of range")
+			assert.NotFound(t, selection)
+			Expect(t.FatalfCall.Called).To(BeFalse())
+		})
+
+		It("should fail when the selection refers to elements", func() {
+			selection.CountCall.ReturnCount = 1
+			assert.NotFound(t, selection)
+			Expect(t.FatalfCall.Message).To(Equal("Expected selection 'CSS: #selector' not to be found"))
+		})
+	})
+
+	Describe(".Value", func() {
+		It("should compare the value to the expected value", func() {
+			selection.ValueCall.ReturnValue = "some value"
+			assert.Value(t, selection, "some value")
+			Expect(t.FatalfCall.Called).To(BeFalse())
+			assert.Value(t, selection, "other value")
+			Expect(t.FatalfCall.Message).To(Equal("Expected selection 'CSS: #selector' to have value equaling\n    other value\nbut found\n    some value"))
+		})
+	})
+
+	Describe(".HasClass", func() {
+		It("should check for the provided class", func() {
+			selection.HasClassCall.ReturnHasClass = true
+			assert.HasClass(t, selection, "some-class")
+			Expect(selection.HasClassCall.Class).To(Equal("some-class"))
+			Expect(t.FatalfCall.Called).To(BeFalse())
+		})
+
+		It("should fail when the element does not have the class", func() {
+			assert.HasClass(t, selection, "some-class")
+			Expect(t.FatalfCall.Message).To(Equal(`Expected selection 'CSS: #selector' to have class "some-class"`))
+		})
+	})
+
+	Describe(".Attribute", func() {
+		It("should compare the provided attribute to the expected value", func() {
+			selection.AttributeCall.ReturnValue = "some value"
+			assert.Attribute(t, selection, "some-attribute", "some value")
+			Expect(selection.AttributeCall.Attribute).To(Equal("some-attribute"))
+			Expect(t.FatalfCall.Called).To(BeFalse())
+			assert.Attribute(t, selection, "some-attribute", "other value")
+			Expect(t.FatalfCall.Message).To(Equal("Expected selection 'CSS: #selector' to have attribute 'some-attribute' equaling\n    other value\nbut found\n    some value"))
+		})
+	})
+
+	Describe(".CSS", func() {
+		It("should compare the provided CSS property to the expected value", func() {
+			selection.CSSCall.ReturnValue = "block"
+			assert.CSS(t, selection, "display", "block")
+			Expect(selection.CSSCall.Property).To(Equal("display"))
+			Expect(t.FatalfCall.Called).To(BeFalse())
+			assert.CSS(t, selection, "display", "none")
+			Expect(t.FatalfCall.Message).To(Equal("Expected selection 'CSS: #selector' to have CSS property 'display' equaling\n    none\nbut found\n    block"))
+		})
+	})
+
+	Describe(".Visible and .NotVisible", func() {
+		It("should pass or fail according to the visibility of the selection", func() {
+			selection.VisibleCall.ReturnVisible = true
+			assert.Visible(t, selection)
+			Expect(t.FatalfCall.Called).To(BeFalse())
+			assert.NotVisible(t, selection)
+			Expect(t.FatalfCall.Message).To(Equal("Expected selection 'CSS: #selector' not to be visible"))
+		})
+
+		It("should fail with the error when retrieving the visibility fails", func() {
+			selection.VisibleCall.Err = errors.New("some error")
+			assert.Visible(t, selection)
+			Expect(t.FatalfCall.Message).To(Equal("some error"))
+		})
+	})
+
+	Describe(".Selected and .NotSelected", func() {
+		It("should pass or fail according to whether the selection is selected", func() {
+			assert.NotSelected(t, selection)
+			Expect(t.FatalfCall.Called).To(BeFalse())
+			assert.Selected(t, selection)
+			Expect(t.FatalfCall.Message).To(Equal("Expected selection 'CSS: #selector' to be selected"))
+		})
+	})
+
+	Describe(".Enabled and .NotEnabled", func() {
+		It("should pass or fail according to whether the selection is enabled", func() {
+			selection.EnabledCall.ReturnEnabled = true
+			assert.Enabled(t, selection)
+			Expect(t.FatalfCall.Called).To(BeFalse())
+			assert.NotEnabled(t, selection)
+			Expect(t.FatalfCall.Message).To(Equal("Expected selection 'CSS: #selector' not to be enabled"))
+		})
+	})
+
+	Describe(".Focused", func() {
+		It("should pass or fail according to whether the element is focused", func() {
+			selection.ActiveCall.ReturnActive = true
+			assert.Focused(t, selection)
+			Expect(t.FatalfCall.Called).To(BeFalse())
+			selection.ActiveCall.ReturnActive = false
+			assert.Focused(t, selection)
+			Expect(t.FatalfCall.Message).To(Equal("Expected selection 'CSS: #selector' to be focused"))
+		})
+	})
+})
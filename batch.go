@@ -0,0 +1,160 @@
+package agouti
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// A Batcher collects reads of elements selected by CSS selectors, so that
+// *Page.Batch can perform them using a single script. Each read stores its
+// result in the provided pointer once the batch has been performed.
+//
+// Except for Count, reads require their selector to select exactly one
+// element, like the corresponding Selection methods.
+type Batcher struct {
+	reads []batchRead
+}
+
+type batchRead struct {
+	Type     string `json:"type"`
+	Selector string `json:"selector"`
+	Name     string `json:"name,omitempty"`
+
+	description string
+	result      interface{}
+}
+
+// Text reads the rendered text of the selected element, as in
+// *Selection.Text.
+func (b *Batcher) Text(selector string, text *string) {
+	b.add(batchRead{Type: "text", Selector: selector, description: "text", result: text})
+}
+
+// Attribute reads the provided attribute of the selected element, or an
+// empty string if the element does not have the attribute.
+func (b *Batcher) Attribute(selector, attribute string, value *string) {
+	b.add(batchRead{Type: "attribute", Selector: selector, Name: attribute, description: fmt.Sprintf("attribute '%s'", attribute), result: value})
+}
+
+// CSS reads the computed value of the provided CSS property of the selected
+// element.
+func (b *Batcher) CSS(selector, property string, value *string) {
+	b.add(batchRead{Type: "css", Selector: selector, Name: property, description: fmt.Sprintf("CSS property '%s'", property), result: value})
+}
+
+// Value reads the value of the selected form element.
+func (b *Batcher) Value(selector string, value *string) {
+	b.add(batchRead{Type: "value", Selector: selector, description: "value", result: value})
+}
+
+// Visible reads whether the selected element is rendered with a non-zero
+// size and is not hidden by the visibility CSS property.
+func (b *Batcher) Visible(selector string, visible *bool) {
+	b.add(batchRead{Type: "visible", Selector: selector, description: "visibility", result: visible})
+}
+
+// Count reads the number of elements selected by the selector, which may be
+// zero.
+func (b *Batcher) Count(selector string, count *int) {
+	b.add(batchRead{Type: "count", Selector: selector, description: "count", result: count})
+}
+
+func (b *Batcher) add(read batchRead) {
+	b.reads = append(b.reads, read)
+}
+
+// Each read returns either its value or an error, so that one missing element
+// does not prevent the other reads from being reported.
+const batchScript = `
+	return arguments[0].map(function(read) {
+		try {
+			var elements = document.querySelectorAll(read.selector);
+			if (read.type === "count") {
+				return {value: elements.length};
+			}
+			if (elements.length === 0) {
+				return {error: "element not found"};
+			}
+			if (elements.length > 1) {
+				return {error: "method does not support multiple elements (" + elements.length + ")"};
+			}
+			var element = elements[0];
+			switch (read.type) {
+			case "text":
+				return {value: typeof element.innerText === "string" ? element.innerText : element.textContent};
+			case "attribute":
+				return {value: element.getAttribute(read.name) || ""};
+			case "css":
+				return {value: window.getComputedStyle(element).getPropertyValue(read.name)};
+			case "value":
+				return {value: element.value === undefined ? "" : String(element.value)};
+			case "visible":
+				var rendered = element.getClientRects().length > 0 && (element.offsetWidth > 0 || element.offsetHeight > 0);
+				return {value: rendered && window.getComputedStyle(element).visibility !== "hidden"};
+			}
+			return {error: "unknown read " + read.type};
+		} catch (err) {
+			return {error: String(err.message || err)};
+		}
+	});
+`
+
+// Batch calls the provided function to collect reads of elements, and then
+// performs all of the reads using a single script, so that they require one
+// WebDriver command instead of one or more commands per read. This greatly
+// reduces the duration of tests with many assertions, especially when the
+// browser is remote. Reads are performed within the current frame.
+//
+// If any read fails, ex. because its selector does not select exactly one
+// element, an error is returned for the first failed read. The results of
+// the other reads are still stored.
+//
+// Example:
+//    var (
+//        heading string
+//        items   int
+//        href    string
+//    )
+//    err := page.Batch(func(b *agouti.Batcher) {
+//        b.Text("h1", &heading)
+//        b.Count("ul.cart > li", &items)
+//        b.Attribute("a.checkout", "href", &href)
+//    })
+func (p *Page) Batch(build func(b *Batcher)) error {
+	batcher := &Batcher{}
+	build(batcher)
+	if len(batcher.reads) == 0 {
+		return nil
+	}
+
+	var results []struct {
+		Value json.RawMessage `json:"value"`
+		Error string          `json:"error"`
+	}
+	if err := p.session.Execute(batchScript, []interface{}{batcher.reads}, &results); err != nil {
+		return fmt.Errorf("failed to run batch: %s", err)
+	}
+	if len(results) != len(batcher.reads) {
+		return errors.New("failed to run batch: unexpected number of results")
+	}
+
+	var firstErr error
+	for index, read := range batcher.reads {
+		err := read.store(results[index].Value, results[index].Error)
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to retrieve %s of '%s': %s", read.description, read.Selector, err)
+		}
+	}
+	return firstErr
+}
+
+func (r batchRead) store(value json.RawMessage, readErr string) error {
+	if readErr != "" {
+		return errors.New(readErr)
+	}
+	if err := json.Unmarshal(value, r.result); err != nil {
+		return fmt.Errorf("invalid result: %s", err)
+	}
+	return nil
+}
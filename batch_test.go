@@ -0,0 +1,109 @@
+package agouti_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Batch", func() {
+	var (
+		page    *Page
+		session *mocks.Session
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		page = NewTestPage(session)
+	})
+
+	Describe("#Batch", func() {
+		var (
+			text, href, color, value string
+			visible                  bool
+			count                    int
+		)
+
+		batch := func(b *Batcher) {
+			b.Text("h1", &text)
+			b.Attribute("a", "href", &href)
+			b.CSS("h1", "color", &color)
+			b.Value("input", &value)
+			b.Visible("#dialog", &visible)
+			b.Count("li", &count)
+		}
+
+		BeforeEach(func() {
+			text, href, color, value, visible, count = "", "", "", "", false, 0
+		})
+
+		It("should perform all of the reads using a single script", func() {
+			session.ExecuteCall.Result = `[
+				{"value": "some text"}, {"value": "/some/path"}, {"value": "rgb(0, 0, 0)"},
+				{"value": "some value"}, {"value": true}, {"value": 3}
+			]`
+			Expect(page.Batch(batch)).To(Succeed())
+			Expect(session.ExecuteCall.Body).To(ContainSubstring("document.querySelectorAll(read.selector)"))
+			arguments, err := json.Marshal(session.ExecuteCall.Arguments)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(arguments).To(MatchJSON(`[[
+				{"type": "text", "selector": "h1"},
+				{"type": "attribute", "selector": "a", "name": "href"},
+				{"type": "css", "selector": "h1", "name": "color"},
+				{"type": "value", "selector": "input"},
+				{"type": "visible", "selector": "#dialog"},
+				{"type": "count", "selector": "li"}
+			]]`))
+
+			Expect(text).To(Equal("some text"))
+			Expect(href).To(Equal("/some/path"))
+			Expect(color).To(Equal("rgb(0, 0, 0)"))
+			Expect(value).To(Equal("some value"))
+			Expect(visible).To(BeTrue())
+			Expect(count).To(Equal(3))
+		})
+
+		It("should not run a script when there are no reads", func() {
+			Expect(page.Batch(func(*Batcher) {})).To(Succeed())
+			Expect(session.ExecuteCall.Body).To(BeEmpty())
+		})
+
+		Context("when any read fails", func() {
+			It("should return an error for the first failed read and store the other results", func() {
+				session.ExecuteCall.Result = `[
+					{"value": "some text"}, {"error": "element not found"}, {"value": "rgb(0, 0, 0)"},
+					{"error": "method does not support multiple elements (2)"}, {"value": true}, {"value": 3}
+				]`
+				Expect(page.Batch(batch)).To(MatchError("failed to retrieve attribute 'href' of 'a': element not found"))
+				Expect(text).To(Equal("some text"))
+				Expect(count).To(Equal(3))
+			})
+		})
+
+		Context("when a result has the wrong type", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Result = `[{"value": 3}]`
+				err := page.Batch(func(b *Batcher) { b.Text("h1", &text) })
+				Expect(err).To(MatchError(HavePrefix("failed to retrieve text of 'h1': invalid result: ")))
+			})
+		})
+
+		Context("when the script returns the wrong number of results", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Result = `[]`
+				Expect(page.Batch(batch)).To(MatchError("failed to run batch: unexpected number of results"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(page.Batch(batch)).To(MatchError("failed to run batch: some error"))
+			})
+		})
+	})
+})
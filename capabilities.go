@@ -1,6 +1,9 @@
 package agouti
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"path/filepath"
+)
 
 // A Capabilities instance defines the desired capabilities the WebDriver
 // should use to configure a Page.
@@ -56,6 +59,136 @@ func (c Capabilities) Without(feature string) Capabilities {
 	return c
 }
 
+// PageLoadStrategy sets the page load strategy ("normal", "eager", or "none"),
+// which determines when navigation commands return.
+func (c Capabilities) PageLoadStrategy(strategy string) Capabilities {
+	c["pageLoadStrategy"] = strategy
+	return c
+}
+
+// DownloadDirectory configures Chrome and Firefox to save downloaded files to
+// the provided directory without prompting. Relative paths are converted to
+// absolute paths.
+func (c Capabilities) DownloadDirectory(directory string) Capabilities {
+	if absDirectory, err := filepath.Abs(directory); err == nil {
+		directory = absDirectory
+	}
+
+	chromePrefs := map[string]interface{}{
+		"download.default_directory":   directory,
+		"download.prompt_for_download": false,
+		"download.directory_upgrade":   true,
+	}
+	c.setPrefs("chromeOptions", chromePrefs)
+	c.setPrefs("goog:chromeOptions", chromePrefs)
+
+	c.setPrefs("moz:firefoxOptions", map[string]interface{}{
+		"browser.download.dir":                      directory,
+		"browser.download.folderList":               2,
+		"browser.download.useDownloadDir":           true,
+		"browser.download.manager.showWhenStarting": false,
+		"browser.helperApps.neverAsk.saveToDisk":    downloadMIMETypes,
+	})
+	return c
+}
+
+const downloadMIMETypes = "application/octet-stream,application/pdf,application/zip," +
+	"application/json,application/xml,text/csv,text/plain,image/png,image/jpeg," +
+	"application/vnd.ms-excel,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// Proxy configures the browser to send HTTP requests through the proxy at
+// the provided address (ex. "127.0.0.1:8080"), including requests to
+// localhost. HTTPS requests are not sent through the proxy.
+func (c Capabilities) Proxy(address string) Capabilities {
+	c["proxy"] = map[string]interface{}{
+		"proxyType": "manual",
+		"httpProxy": address,
+	}
+
+	c.addArguments("chromeOptions", "--proxy-bypass-list=<-loopback>")
+	c.addArguments("goog:chromeOptions", "--proxy-bypass-list=<-loopback>")
+	c.setPrefs("moz:firefoxOptions", map[string]interface{}{
+		"network.proxy.allow_hijacking_localhost": true,
+	})
+	return c
+}
+
+// PerformanceLogging enables the Chrome performance log, which contains the
+// DevTools events of the browser, including all of its network activity.
+// Other log types that are already enabled are preserved.
+func (c Capabilities) PerformanceLogging() Capabilities {
+	for _, prefsKey := range []string{"loggingPrefs", "goog:loggingPrefs"} {
+		prefs := c.vendorOptions(prefsKey)
+		prefs["performance"] = "ALL"
+		c[prefsKey] = prefs
+	}
+	return c
+}
+
+// The vendor options are copied before they are modified, as they may be
+// shared with other Capabilities instances.
+func (c Capabilities) vendorOptions(optionsKey string) map[string]interface{} {
+	options := map[string]interface{}{}
+	if existing, ok := c[optionsKey].(map[string]interface{}); ok {
+		for key, value := range existing {
+			options[key] = value
+		}
+	}
+	return options
+}
+
+func (c Capabilities) addArguments(optionsKey string, arguments ...string) {
+	c.appendValues(optionsKey, "args", arguments)
+}
+
+func (c Capabilities) appendValues(optionsKey, listKey string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	options := c.vendorOptions(optionsKey)
+
+	mergedValues := listValues(options, listKey)
+	for _, value := range values {
+		mergedValues = append(mergedValues, value)
+	}
+
+	options[listKey] = mergedValues
+	c[optionsKey] = options
+}
+
+func listValues(options map[string]interface{}, listKey string) []interface{} {
+	var values []interface{}
+	switch existing := options[listKey].(type) {
+	case []string:
+		for _, value := range existing {
+			values = append(values, value)
+		}
+	case []interface{}:
+		values = append(values, existing...)
+	}
+	return values
+}
+
+func (c Capabilities) setPrefs(optionsKey string, prefs map[string]interface{}) {
+	if len(prefs) == 0 {
+		return
+	}
+	options := c.vendorOptions(optionsKey)
+
+	mergedPrefs := map[string]interface{}{}
+	if existing, ok := options["prefs"].(map[string]interface{}); ok {
+		for key, value := range existing {
+			mergedPrefs[key] = value
+		}
+	}
+	for key, value := range prefs {
+		mergedPrefs[key] = value
+	}
+
+	options["prefs"] = mergedPrefs
+	c[optionsKey] = options
+}
+
 // JSON returns a JSON string representing the desired capabilities.
 func (c Capabilities) JSON() (string, error) {
 	capabilitiesJSON, err := json.Marshal(c)
@@ -0,0 +1,303 @@
+package agouti
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ChromeOptions configures Chrome when provided to Capabilities.Chrome.
+// Zero-valued fields are omitted. See:
+// https://chromedriver.chromium.org/capabilities#h.p_ID_102
+type ChromeOptions struct {
+	// Args are the command-line arguments to start Chrome with,
+	// ex. "--disable-gpu".
+	Args []string
+
+	// Binary is the path to the Chrome executable.
+	Binary string
+
+	// Extensions are the base64-encoded contents of the packed (.crx)
+	// extensions to install. See AddExtension.
+	Extensions []string
+
+	// Prefs are the user preferences to set, ex. "intl.accept_languages".
+	Prefs map[string]interface{}
+
+	// MobileEmulation configures Chrome to emulate a mobile device.
+	MobileEmulation *MobileEmulation
+
+	unpackedExtensions []string
+}
+
+// EdgeOptions configures Microsoft Edge when provided to Capabilities.Edge.
+// As Edge is based on Chromium, it accepts the same options as Chrome.
+type EdgeOptions ChromeOptions
+
+// MobileEmulation configures a Chromium-based browser to emulate a mobile
+// device, either by the name of a device known to its DevTools
+// (ex. "iPhone 12") or by the provided metrics. The DeviceName takes
+// precedence if set.
+type MobileEmulation struct {
+	DeviceName string
+	DeviceMetrics
+}
+
+// DeviceMetrics describes the viewport and user agent of an emulated mobile
+// device. The Width and Height are in CSS pixels. Touch enables touch events.
+type DeviceMetrics struct {
+	Width      int
+	Height     int
+	PixelRatio float64
+	UserAgent  string
+	Touch      bool
+}
+
+// FirefoxOptions configures Firefox when provided to Capabilities.Firefox.
+// Zero-valued fields are omitted. See:
+// https://developer.mozilla.org/en-US/docs/Web/WebDriver/Capabilities/firefoxOptions
+type FirefoxOptions struct {
+	// Args are the command-line arguments to start Firefox with,
+	// ex. "-private".
+	Args []string
+
+	// Binary is the path to the Firefox executable.
+	Binary string
+
+	// Prefs are the about:config preferences to set.
+	Prefs map[string]interface{}
+
+	// Profile is the base64-encoded zip of the profile directory to
+	// start Firefox with.
+	Profile string
+}
+
+// EncodeExtension returns the base64-encoded contents of the extension at
+// the provided path, for use in ChromeOptions.Extensions.
+func EncodeExtension(path string) (string, error) {
+	extension, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(extension), nil
+}
+
+// AddExtension installs the packed (.crx) extension at the provided path, or
+// loads the unpacked extension in the provided directory, when the options
+// are provided to Capabilities.Chrome.
+//
+// Example:
+//    options := agouti.ChromeOptions{}
+//    if err := options.AddExtension("extensions/some-helper"); err != nil {
+//        return err
+//    }
+//    capabilities := agouti.NewCapabilities().Browser("chrome").Chrome(options)
+func (o *ChromeOptions) AddExtension(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to add extension: %s", err)
+	}
+
+	if info.IsDir() {
+		if absPath, err := filepath.Abs(path); err == nil {
+			path = absPath
+		}
+		o.unpackedExtensions = append(o.unpackedExtensions, path)
+		return nil
+	}
+
+	extension, err := EncodeExtension(path)
+	if err != nil {
+		return fmt.Errorf("failed to add extension: %s", err)
+	}
+	o.Extensions = append(o.Extensions, extension)
+	return nil
+}
+
+// AddExtensionBase64 installs the packed extension with the provided
+// base64-encoded contents.
+func (o *ChromeOptions) AddExtensionBase64(extension string) error {
+	if _, err := base64.StdEncoding.DecodeString(extension); err != nil {
+		return fmt.Errorf("failed to add extension: %s", err)
+	}
+	o.Extensions = append(o.Extensions, extension)
+	return nil
+}
+
+// AddExtension installs or loads an extension like ChromeOptions.AddExtension.
+func (o *EdgeOptions) AddExtension(path string) error {
+	return (*ChromeOptions)(o).AddExtension(path)
+}
+
+// AddExtensionBase64 installs an extension like
+// ChromeOptions.AddExtensionBase64.
+func (o *EdgeOptions) AddExtensionBase64(extension string) error {
+	return (*ChromeOptions)(o).AddExtensionBase64(extension)
+}
+
+// Chrome configures Chrome using the provided options. Arguments and
+// extensions are appended to, and preferences are merged with, any that
+// are already configured. Other options replace existing values.
+//
+// For example, to emulate a phone:
+//    capabilities := agouti.NewCapabilities().Browser("chrome").Chrome(agouti.ChromeOptions{
+//        Args:            []string{"--disable-gpu"},
+//        MobileEmulation: &agouti.MobileEmulation{DeviceName: "iPhone 12"},
+//    })
+func (c Capabilities) Chrome(options ChromeOptions) Capabilities {
+	c.setChromiumOptions("chromeOptions", options)
+	c.setChromiumOptions("goog:chromeOptions", options)
+	return c
+}
+
+// Edge configures Microsoft Edge using the provided options, which are
+// merged with existing options like Chrome.
+func (c Capabilities) Edge(options EdgeOptions) Capabilities {
+	c.setChromiumOptions("ms:edgeOptions", ChromeOptions(options))
+	return c
+}
+
+// Firefox configures Firefox using the provided options. Arguments are
+// appended to, and preferences are merged with, any that are already
+// configured. Other options replace existing values.
+func (c Capabilities) Firefox(options FirefoxOptions) Capabilities {
+	c.addArguments("moz:firefoxOptions", options.Args...)
+	c.setPrefs("moz:firefoxOptions", options.Prefs)
+	c.setOption("moz:firefoxOptions", "binary", options.Binary)
+	c.setOption("moz:firefoxOptions", "profile", options.Profile)
+	return c
+}
+
+// Headless configures Chrome, Edge, and Firefox to run without a visible
+// window.
+func (c Capabilities) Headless() Capabilities {
+	c.headless("--headless=new")
+	return c
+}
+
+func (c Capabilities) headless(chromiumArgument string) {
+	c.addArguments("chromeOptions", chromiumArgument)
+	c.addArguments("goog:chromeOptions", chromiumArgument)
+	c.addArguments("ms:edgeOptions", chromiumArgument)
+	c.addArguments("moz:firefoxOptions", "-headless")
+}
+
+// WindowSize configures Chrome, Edge, and Firefox to open their initial
+// window with the provided width and height in pixels. Unlike
+// Page.Size, this takes effect before the first page is loaded, and
+// applies to headless browsers.
+func (c Capabilities) WindowSize(width, height int) Capabilities {
+	chromiumSize := "--window-size=" + strconv.Itoa(width) + "," + strconv.Itoa(height)
+	c.addArguments("chromeOptions", chromiumSize)
+	c.addArguments("goog:chromeOptions", chromiumSize)
+	c.addArguments("ms:edgeOptions", chromiumSize)
+	c.addArguments("moz:firefoxOptions", "-width="+strconv.Itoa(width), "-height="+strconv.Itoa(height))
+	return c
+}
+
+// UserAgent configures Chrome, Edge, and Firefox to send the provided user
+// agent instead of their own.
+func (c Capabilities) UserAgent(userAgent string) Capabilities {
+	c.addArguments("chromeOptions", "--user-agent="+userAgent)
+	c.addArguments("goog:chromeOptions", "--user-agent="+userAgent)
+	c.addArguments("ms:edgeOptions", "--user-agent="+userAgent)
+	c.setPrefs("moz:firefoxOptions", map[string]interface{}{
+		"general.useragent.override": userAgent,
+	})
+	return c
+}
+
+// AcceptLanguage configures Chrome, Edge, and Firefox to request content in
+// the provided languages (ex. "fr-CA", "fr"), in order of preference. The
+// first language is also used as the language of the browser itself.
+func (c Capabilities) AcceptLanguage(languages ...string) Capabilities {
+	if len(languages) == 0 {
+		return c
+	}
+	acceptLanguage := strings.Join(languages, ",")
+	prefs := map[string]interface{}{"intl.accept_languages": acceptLanguage}
+	for _, optionsKey := range []string{"chromeOptions", "goog:chromeOptions", "ms:edgeOptions"} {
+		c.addArguments(optionsKey, "--lang="+languages[0])
+		c.setPrefs(optionsKey, prefs)
+	}
+	c.setPrefs("moz:firefoxOptions", prefs)
+	return c
+}
+
+func (c Capabilities) hasWindowSize() bool {
+	for _, optionsKey := range []string{"goog:chromeOptions", "ms:edgeOptions", "moz:firefoxOptions"} {
+		for _, argument := range listValues(c.vendorOptions(optionsKey), "args") {
+			if argument, ok := argument.(string); ok {
+				if strings.HasPrefix(argument, "--window-size=") || strings.HasPrefix(argument, "-width=") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (c Capabilities) setChromiumOptions(optionsKey string, options ChromeOptions) {
+	c.addArguments(optionsKey, options.Args...)
+	c.loadExtensions(optionsKey, options.unpackedExtensions)
+	c.appendValues(optionsKey, "extensions", options.Extensions)
+	c.setPrefs(optionsKey, options.Prefs)
+	c.setOption(optionsKey, "binary", options.Binary)
+	if options.MobileEmulation != nil {
+		c.setOption(optionsKey, "mobileEmulation", options.MobileEmulation.capability())
+	}
+}
+
+// Chromium only applies the last --load-extension argument, so unpacked
+// extensions are added to any existing argument.
+func (c Capabilities) loadExtensions(optionsKey string, directories []string) {
+	if len(directories) == 0 {
+		return
+	}
+	const loadExtension = "--load-extension="
+
+	options := c.vendorOptions(optionsKey)
+	arguments := listValues(options, "args")
+	for index, argument := range arguments {
+		if argument, ok := argument.(string); ok && strings.HasPrefix(argument, loadExtension) {
+			merged := append([]string{strings.TrimPrefix(argument, loadExtension)}, directories...)
+			arguments[index] = loadExtension + strings.Join(merged, ",")
+			options["args"] = arguments
+			c[optionsKey] = options
+			return
+		}
+	}
+	c.addArguments(optionsKey, loadExtension+strings.Join(directories, ","))
+}
+
+func (c Capabilities) setOption(optionsKey, key string, value interface{}) {
+	if value == "" {
+		return
+	}
+	options := c.vendorOptions(optionsKey)
+	options[key] = value
+	c[optionsKey] = options
+}
+
+func (m *MobileEmulation) capability() map[string]interface{} {
+	if m.DeviceName != "" {
+		return map[string]interface{}{"deviceName": m.DeviceName}
+	}
+
+	emulation := map[string]interface{}{
+		"deviceMetrics": map[string]interface{}{
+			"width":      m.Width,
+			"height":     m.Height,
+			"pixelRatio": m.PixelRatio,
+			"touch":      m.Touch,
+		},
+	}
+	if m.UserAgent != "" {
+		emulation["userAgent"] = m.UserAgent
+	}
+	return emulation
+}
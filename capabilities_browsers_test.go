@@ -0,0 +1,294 @@
+package agouti_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+)
+
+var _ = Describe("Browser Capabilities", func() {
+	var capabilities Capabilities
+
+	BeforeEach(func() {
+		capabilities = NewCapabilities()
+	})
+
+	It("should support chaining with the other Capabilities methods", func() {
+		capabilities.Browser("chrome").Headless().WindowSize(1280, 800).Proxy("127.0.0.1:8080")
+		Expect(capabilities["browserName"]).To(Equal("chrome"))
+		chromeOptions := capabilities["goog:chromeOptions"].(map[string]interface{})
+		Expect(chromeOptions["args"]).To(Equal([]interface{}{
+			"--headless=new",
+			"--window-size=1280,800",
+			"--proxy-bypass-list=<-loopback>",
+		}))
+	})
+
+	Describe("#Chrome", func() {
+		It("should encode the provided options into JSON", func() {
+			capabilities.Chrome(ChromeOptions{
+				Args:            []string{"--disable-gpu"},
+				Binary:          "/some/chrome",
+				Extensions:      []string{"c29tZS1leHRlbnNpb24="},
+				Prefs:           map[string]interface{}{"some.pref": true},
+				MobileEmulation: &MobileEmulation{DeviceName: "Pixel 7"},
+			})
+			Expect(capabilities.JSON()).To(MatchJSON(`{
+				"chromeOptions": {
+					"args": ["--disable-gpu"],
+					"binary": "/some/chrome",
+					"extensions": ["c29tZS1leHRlbnNpb24="],
+					"prefs": {"some.pref": true},
+					"mobileEmulation": {"deviceName": "Pixel 7"}
+				},
+				"goog:chromeOptions": {
+					"args": ["--disable-gpu"],
+					"binary": "/some/chrome",
+					"extensions": ["c29tZS1leHRlbnNpb24="],
+					"prefs": {"some.pref": true},
+					"mobileEmulation": {"deviceName": "Pixel 7"}
+				}
+			}`))
+		})
+
+		It("should omit options that are not provided", func() {
+			capabilities.Chrome(ChromeOptions{Binary: "/some/chrome"})
+			Expect(capabilities["goog:chromeOptions"]).To(Equal(map[string]interface{}{"binary": "/some/chrome"}))
+		})
+
+		It("should merge the provided options with existing options", func() {
+			capabilities.DownloadDirectory("/some/directory").Headless()
+			capabilities.Chrome(ChromeOptions{
+				Args:  []string{"--disable-gpu"},
+				Prefs: map[string]interface{}{"some.pref": true},
+			})
+			chromeOptions := capabilities["goog:chromeOptions"].(map[string]interface{})
+			Expect(chromeOptions["args"]).To(Equal([]interface{}{"--headless=new", "--disable-gpu"}))
+			Expect(chromeOptions["prefs"]).To(HaveKeyWithValue("download.default_directory", "/some/directory"))
+			Expect(chromeOptions["prefs"]).To(HaveKeyWithValue("some.pref", true))
+		})
+
+		Context("when device metrics are provided for mobile emulation", func() {
+			It("should encode the metrics and user agent", func() {
+				capabilities.Chrome(ChromeOptions{MobileEmulation: &MobileEmulation{
+					DeviceMetrics: DeviceMetrics{
+						Width:      360,
+						Height:     640,
+						PixelRatio: 3,
+						UserAgent:  "some-agent",
+						Touch:      true,
+					},
+				}})
+				Expect(capabilities.JSON()).To(MatchJSON(`{
+					"chromeOptions": {
+						"mobileEmulation": {
+							"deviceMetrics": {"width": 360, "height": 640, "pixelRatio": 3, "touch": true},
+							"userAgent": "some-agent"
+						}
+					},
+					"goog:chromeOptions": {
+						"mobileEmulation": {
+							"deviceMetrics": {"width": 360, "height": 640, "pixelRatio": 3, "touch": true},
+							"userAgent": "some-agent"
+						}
+					}
+				}`))
+			})
+		})
+	})
+
+	Describe("#Edge", func() {
+		It("should encode the provided options as Edge options", func() {
+			capabilities.Edge(EdgeOptions{Args: []string{"--inprivate"}, Binary: "/some/edge"})
+			Expect(capabilities.JSON()).To(MatchJSON(`{
+				"ms:edgeOptions": {"args": ["--inprivate"], "binary": "/some/edge"}
+			}`))
+		})
+	})
+
+	Describe("#Firefox", func() {
+		It("should encode the provided options into JSON", func() {
+			capabilities.Firefox(FirefoxOptions{
+				Args:    []string{"-private"},
+				Binary:  "/some/firefox",
+				Prefs:   map[string]interface{}{"some.pref": 1},
+				Profile: "c29tZS1wcm9maWxl",
+			})
+			Expect(capabilities.JSON()).To(MatchJSON(`{
+				"moz:firefoxOptions": {
+					"args": ["-private"],
+					"binary": "/some/firefox",
+					"prefs": {"some.pref": 1},
+					"profile": "c29tZS1wcm9maWxl"
+				}
+			}`))
+		})
+	})
+
+	Describe("#Headless", func() {
+		It("should configure every supported browser to run headless", func() {
+			capabilities.Headless()
+			Expect(capabilities.JSON()).To(MatchJSON(`{
+				"chromeOptions": {"args": ["--headless=new"]},
+				"goog:chromeOptions": {"args": ["--headless=new"]},
+				"ms:edgeOptions": {"args": ["--headless=new"]},
+				"moz:firefoxOptions": {"args": ["-headless"]}
+			}`))
+		})
+	})
+
+	Describe("#WindowSize", func() {
+		It("should configure every supported browser with the provided window size", func() {
+			capabilities.WindowSize(1280, 800)
+			Expect(capabilities.JSON()).To(MatchJSON(`{
+				"chromeOptions": {"args": ["--window-size=1280,800"]},
+				"goog:chromeOptions": {"args": ["--window-size=1280,800"]},
+				"ms:edgeOptions": {"args": ["--window-size=1280,800"]},
+				"moz:firefoxOptions": {"args": ["-width=1280", "-height=800"]}
+			}`))
+		})
+	})
+
+	Describe("#UserAgent", func() {
+		It("should configure every supported browser with the provided user agent", func() {
+			capabilities.UserAgent("some-agent")
+			Expect(capabilities.JSON()).To(MatchJSON(`{
+				"chromeOptions": {"args": ["--user-agent=some-agent"]},
+				"goog:chromeOptions": {"args": ["--user-agent=some-agent"]},
+				"ms:edgeOptions": {"args": ["--user-agent=some-agent"]},
+				"moz:firefoxOptions": {"prefs": {"general.useragent.override": "some-agent"}}
+			}`))
+		})
+	})
+
+	Describe("#AcceptLanguage", func() {
+		It("should configure every supported browser with the provided languages", func() {
+			capabilities.AcceptLanguage("fr-CA", "fr")
+			Expect(capabilities.JSON()).To(MatchJSON(`{
+				"chromeOptions": {"args": ["--lang=fr-CA"], "prefs": {"intl.accept_languages": "fr-CA,fr"}},
+				"goog:chromeOptions": {"args": ["--lang=fr-CA"], "prefs": {"intl.accept_languages": "fr-CA,fr"}},
+				"ms:edgeOptions": {"args": ["--lang=fr-CA"], "prefs": {"intl.accept_languages": "fr-CA,fr"}},
+				"moz:firefoxOptions": {"prefs": {"intl.accept_languages": "fr-CA,fr"}}
+			}`))
+		})
+
+		Context("when no languages are provided", func() {
+			It("should not modify the capabilities", func() {
+				capabilities.AcceptLanguage()
+				Expect(capabilities).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ChromeOptions", func() {
+		var (
+			options   ChromeOptions
+			directory string
+		)
+
+		BeforeEach(func() {
+			options = ChromeOptions{}
+			var err error
+			directory, err = ioutil.TempDir("", "agouti")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(directory)
+		})
+
+		Describe("#AddExtension", func() {
+			It("should install packed extensions", func() {
+				path := filepath.Join(directory, "some-extension.crx")
+				Expect(ioutil.WriteFile(path, []byte("some-extension"), 0644)).To(Succeed())
+				Expect(options.AddExtension(path)).To(Succeed())
+				capabilities.Chrome(options)
+				chromeOptions := capabilities["goog:chromeOptions"].(map[string]interface{})
+				Expect(chromeOptions["extensions"]).To(Equal([]interface{}{"c29tZS1leHRlbnNpb24="}))
+			})
+
+			It("should load unpacked extensions using a single argument", func() {
+				firstDirectory := filepath.Join(directory, "first")
+				secondDirectory := filepath.Join(directory, "second")
+				Expect(os.Mkdir(firstDirectory, 0755)).To(Succeed())
+				Expect(os.Mkdir(secondDirectory, 0755)).To(Succeed())
+				Expect(options.AddExtension(firstDirectory)).To(Succeed())
+				Expect(options.AddExtension(secondDirectory)).To(Succeed())
+				capabilities.Headless().Chrome(options)
+				chromeOptions := capabilities["goog:chromeOptions"].(map[string]interface{})
+				Expect(chromeOptions["args"]).To(Equal([]interface{}{
+					"--headless=new",
+					"--load-extension=" + firstDirectory + "," + secondDirectory,
+				}))
+				Expect(chromeOptions).NotTo(HaveKey("extensions"))
+			})
+
+			It("should add unpacked extensions to an existing load argument", func() {
+				capabilities["goog:chromeOptions"] = map[string]interface{}{
+					"args": []string{"--load-extension=/some/extension", "--disable-gpu"},
+				}
+				Expect(options.AddExtension(directory)).To(Succeed())
+				capabilities.Chrome(options)
+				chromeOptions := capabilities["goog:chromeOptions"].(map[string]interface{})
+				Expect(chromeOptions["args"]).To(Equal([]interface{}{
+					"--load-extension=/some/extension," + directory,
+					"--disable-gpu",
+				}))
+			})
+
+			It("should add extensions to Edge options", func() {
+				edgeOptions := EdgeOptions{}
+				Expect(edgeOptions.AddExtension(directory)).To(Succeed())
+				capabilities.Edge(edgeOptions)
+				Expect(capabilities["ms:edgeOptions"]).To(Equal(map[string]interface{}{
+					"args": []interface{}{"--load-extension=" + directory},
+				}))
+			})
+
+			Context("when the extension does not exist", func() {
+				It("should return an error", func() {
+					err := options.AddExtension(filepath.Join(directory, "missing.crx"))
+					Expect(err).To(MatchError(HavePrefix("failed to add extension: ")))
+				})
+			})
+		})
+
+		Describe("#AddExtensionBase64", func() {
+			It("should install the provided extension", func() {
+				Expect(options.AddExtensionBase64("c29tZS1leHRlbnNpb24=")).To(Succeed())
+				Expect(options.Extensions).To(Equal([]string{"c29tZS1leHRlbnNpb24="}))
+			})
+
+			Context("when the extension is not valid base64", func() {
+				It("should return an error", func() {
+					err := options.AddExtensionBase64("not base64")
+					Expect(err).To(MatchError(HavePrefix("failed to add extension: illegal base64 data")))
+					Expect(options.Extensions).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe(".EncodeExtension", func() {
+		It("should return the base64-encoded contents of the extension", func() {
+			directory, err := ioutil.TempDir("", "agouti")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(directory)
+			path := filepath.Join(directory, "some-extension.crx")
+			Expect(ioutil.WriteFile(path, []byte("some-extension"), 0644)).To(Succeed())
+
+			Expect(EncodeExtension(path)).To(Equal("c29tZS1leHRlbnNpb24="))
+		})
+
+		Context("when the extension cannot be read", func() {
+			It("should return an error", func() {
+				_, err := EncodeExtension("/some/missing/extension.crx")
+				Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+			})
+		})
+	})
+})
@@ -1,6 +1,8 @@
 package agouti_test
 
 import (
+	"path/filepath"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/sclevine/agouti"
@@ -27,6 +29,79 @@ var _ = Describe("Capabilities", func() {
 		}`))
 	})
 
+	Describe("#DownloadDirectory", func() {
+		It("should configure Chrome and Firefox to download files to the provided directory", func() {
+			capabilities.DownloadDirectory("/some/directory")
+			Expect(capabilities["goog:chromeOptions"]).To(Equal(map[string]interface{}{
+				"prefs": map[string]interface{}{
+					"download.default_directory":   "/some/directory",
+					"download.prompt_for_download": false,
+					"download.directory_upgrade":   true,
+				},
+			}))
+			Expect(capabilities["chromeOptions"]).To(Equal(capabilities["goog:chromeOptions"]))
+			firefoxPrefs := capabilities["moz:firefoxOptions"].(map[string]interface{})["prefs"]
+			Expect(firefoxPrefs).To(HaveKeyWithValue("browser.download.dir", "/some/directory"))
+			Expect(firefoxPrefs).To(HaveKeyWithValue("browser.download.folderList", 2))
+		})
+
+		It("should convert relative directories to absolute directories", func() {
+			capabilities.DownloadDirectory("some-directory")
+			chromePrefs := capabilities["goog:chromeOptions"].(map[string]interface{})["prefs"]
+			Expect(chromePrefs).To(HaveKeyWithValue("download.default_directory", HaveSuffix(filepath.Join("agouti", "some-directory"))))
+		})
+
+		It("should preserve existing browser options without modifying them", func() {
+			existingPrefs := map[string]interface{}{"some.pref": true}
+			existing := map[string]interface{}{"args": []string{"headless"}, "prefs": existingPrefs}
+			capabilities["goog:chromeOptions"] = existing
+			capabilities.DownloadDirectory("/some/directory")
+			options := capabilities["goog:chromeOptions"].(map[string]interface{})
+			Expect(options["args"]).To(Equal([]string{"headless"}))
+			Expect(options["prefs"]).To(HaveKeyWithValue("some.pref", true))
+			Expect(options["prefs"]).To(HaveKeyWithValue("download.default_directory", "/some/directory"))
+			Expect(existingPrefs).To(HaveLen(1))
+		})
+	})
+
+	Describe("#Proxy", func() {
+		It("should configure the browser to send HTTP requests through the proxy", func() {
+			capabilities.Proxy("127.0.0.1:8080")
+			Expect(capabilities["proxy"]).To(Equal(map[string]interface{}{
+				"proxyType": "manual",
+				"httpProxy": "127.0.0.1:8080",
+			}))
+			firefoxPrefs := capabilities["moz:firefoxOptions"].(map[string]interface{})["prefs"]
+			Expect(firefoxPrefs).To(HaveKeyWithValue("network.proxy.allow_hijacking_localhost", true))
+		})
+
+		It("should append to existing Chrome arguments", func() {
+			capabilities["goog:chromeOptions"] = map[string]interface{}{"args": []string{"headless"}}
+			capabilities.Proxy("127.0.0.1:8080")
+			chromeOptions := capabilities["goog:chromeOptions"].(map[string]interface{})
+			Expect(chromeOptions["args"]).To(Equal([]interface{}{"headless", "--proxy-bypass-list=<-loopback>"}))
+		})
+	})
+
+	Describe("#PageLoadStrategy", func() {
+		It("should set the page load strategy", func() {
+			capabilities.PageLoadStrategy("eager")
+			Expect(capabilities["pageLoadStrategy"]).To(Equal("eager"))
+		})
+	})
+
+	Describe("#PerformanceLogging", func() {
+		It("should enable the performance log while preserving other log types", func() {
+			capabilities["goog:loggingPrefs"] = map[string]interface{}{"browser": "ALL"}
+			capabilities.PerformanceLogging()
+			Expect(capabilities["loggingPrefs"]).To(Equal(map[string]interface{}{"performance": "ALL"}))
+			Expect(capabilities["goog:loggingPrefs"]).To(Equal(map[string]interface{}{
+				"browser":     "ALL",
+				"performance": "ALL",
+			}))
+		})
+	})
+
 	Context("when the provided options cannot be converted to JSON", func() {
 		It("should return an error", func() {
 			capabilities["some-feature"] = func() {}
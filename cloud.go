@@ -0,0 +1,265 @@
+package agouti
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BrowserStackURL is the URL of the BrowserStack Automate WebDriver hub.
+const BrowserStackURL = "https://hub.browserstack.com/wd/hub"
+
+// SauceLabsURL returns the URL of the Sauce Labs WebDriver hub in the
+// provided data center region (ex. "eu-central-1"), or in "us-west-1" if
+// the region is empty.
+func SauceLabsURL(region string) string {
+	if region == "" {
+		region = defaultSauceRegion
+	}
+	return "https://ondemand." + region + ".saucelabs.com/wd/hub"
+}
+
+const defaultSauceRegion = "us-west-1"
+
+// SauceOptions configures a Sauce Labs session when provided to
+// Capabilities.Sauce. Zero-valued fields are omitted, except for the
+// following defaults:
+//   - Username and AccessKey default to the SAUCE_USERNAME and
+//     SAUCE_ACCESS_KEY environment variables.
+//   - Name defaults to the name of the running test binary, ex. "login" for
+//     "login.test".
+//   - Build defaults to the ID of the current CI build, if known.
+//
+// See: https://docs.saucelabs.com/dev/test-configuration-options/
+type SauceOptions struct {
+	Username  string
+	AccessKey string
+
+	// Name and Build identify the session and group it with the other
+	// sessions of the same build in the Sauce Labs UI.
+	Name  string
+	Build string
+
+	// Tags are the tags to label the session with.
+	Tags []string
+
+	// TunnelName is the name of the Sauce Connect tunnel to use.
+	TunnelName string
+
+	// ScreenResolution is the resolution of the virtual machine display,
+	// ex. "1920x1080".
+	ScreenResolution string
+}
+
+// BrowserStackOptions configures a BrowserStack Automate session when
+// provided to Capabilities.BrowserStack. Zero-valued fields are omitted,
+// except for the following defaults:
+//   - Username and AccessKey default to the BROWSERSTACK_USERNAME and
+//     BROWSERSTACK_ACCESS_KEY environment variables.
+//   - SessionName defaults to the name of the running test binary.
+//   - BuildName defaults to the ID of the current CI build, if known.
+//
+// See: https://www.browserstack.com/docs/automate/capabilities
+type BrowserStackOptions struct {
+	Username  string
+	AccessKey string
+
+	// ProjectName, BuildName, and SessionName identify the session in the
+	// BrowserStack dashboard.
+	ProjectName string
+	BuildName   string
+	SessionName string
+
+	// Local enables BrowserStack Local, which tests sites that are only
+	// accessible from the local network.
+	Local bool
+
+	// OS and OSVersion select the platform, ex. "Windows" and "11".
+	OS        string
+	OSVersion string
+}
+
+// Sauce configures Sauce Labs using the provided options, which are set as
+// the "sauce:options" capability. Pages opened with these capabilities may
+// report their result to Sauce Labs using *Page.SetTestStatus.
+//
+// Example:
+//    capabilities := agouti.NewCapabilities().Browser("chrome").Sauce(agouti.SauceOptions{Tags: []string{"smoke"}})
+//    page, err := agouti.Remote(agouti.SauceLabsURL("eu-central-1"), agouti.Desired(capabilities))
+func (c Capabilities) Sauce(options SauceOptions) Capabilities {
+	sauceOptions := c.vendorOptions("sauce:options")
+	setValue(sauceOptions, "username", options.Username, os.Getenv("SAUCE_USERNAME"))
+	setValue(sauceOptions, "accessKey", options.AccessKey, os.Getenv("SAUCE_ACCESS_KEY"))
+	setValue(sauceOptions, "name", options.Name, testBinaryName())
+	setValue(sauceOptions, "build", options.Build, ciBuildName())
+	setValue(sauceOptions, "tunnelName", options.TunnelName)
+	setValue(sauceOptions, "screenResolution", options.ScreenResolution)
+	if len(options.Tags) > 0 {
+		sauceOptions["tags"] = options.Tags
+	}
+	c["sauce:options"] = sauceOptions
+	return c
+}
+
+// BrowserStack configures BrowserStack Automate using the provided options,
+// which are set as the "bstack:options" capability. Pages opened with these
+// capabilities may report their result to BrowserStack using
+// *Page.SetTestStatus.
+//
+// Example:
+//    capabilities := agouti.NewCapabilities().Browser("chrome").BrowserStack(agouti.BrowserStackOptions{ProjectName: "shop"})
+//    page, err := agouti.Remote(agouti.BrowserStackURL, agouti.Desired(capabilities))
+func (c Capabilities) BrowserStack(options BrowserStackOptions) Capabilities {
+	bstackOptions := c.vendorOptions("bstack:options")
+	setValue(bstackOptions, "userName", options.Username, os.Getenv("BROWSERSTACK_USERNAME"))
+	setValue(bstackOptions, "accessKey", options.AccessKey, os.Getenv("BROWSERSTACK_ACCESS_KEY"))
+	setValue(bstackOptions, "projectName", options.ProjectName)
+	setValue(bstackOptions, "buildName", options.BuildName, ciBuildName())
+	setValue(bstackOptions, "sessionName", options.SessionName, testBinaryName())
+	setValue(bstackOptions, "os", options.OS)
+	setValue(bstackOptions, "osVersion", options.OSVersion)
+	if options.Local {
+		bstackOptions["local"] = true
+	}
+	c["bstack:options"] = bstackOptions
+	return c
+}
+
+// The value is set to the first non-empty candidate.
+func setValue(options map[string]interface{}, key string, candidates ...string) {
+	for _, value := range candidates {
+		if value != "" {
+			options[key] = value
+			return
+		}
+	}
+}
+
+func testBinaryName() string {
+	name := filepath.Base(os.Args[0])
+	name = strings.TrimSuffix(name, ".exe")
+	return strings.TrimSuffix(name, ".test")
+}
+
+func ciBuildName() string {
+	for _, variable := range []string{"BUILD_TAG", "GITHUB_RUN_ID", "CI_PIPELINE_ID", "CIRCLE_WORKFLOW_ID"} {
+		if value := os.Getenv(variable); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// SetTestStatus marks the test that used the page as passed or failed, with
+// an optional reason. The status is reported to the Sauce Labs or
+// BrowserStack REST API when the page is destroyed. An error is returned if
+// the page was not opened with Capabilities.Sauce or
+// Capabilities.BrowserStack.
+func (p *Page) SetTestStatus(passed bool, reason string) error {
+	if p.cloud == nil {
+		return fmt.Errorf("failed to set test status: page is not a Sauce Labs or BrowserStack session")
+	}
+	p.cloud.status = &testStatus{passed, reason}
+	return nil
+}
+
+type testStatus struct {
+	passed bool
+	reason string
+}
+
+type cloudSession struct {
+	statusURL string
+	username  string
+	accessKey string
+	client    *http.Client
+	body      func(status *testStatus) interface{}
+	status    *testStatus
+}
+
+// The Sauce Labs REST API is hosted in the same region as the hub that the
+// session was opened with.
+func newCloudSession(hubURL, sessionID string, pageOptions *config) *cloudSession {
+	client := pageOptions.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	capabilities := pageOptions.DesiredCapabilities
+
+	if sauceOptions, ok := capabilities["sauce:options"].(map[string]interface{}); ok {
+		region := defaultSauceRegion
+		if parsedURL, err := url.Parse(hubURL); err == nil {
+			host := strings.Split(parsedURL.Hostname(), ".")
+			if len(host) == 4 && host[0] == "ondemand" {
+				region = host[1]
+			}
+		}
+		username, _ := sauceOptions["username"].(string)
+		accessKey, _ := sauceOptions["accessKey"].(string)
+		return &cloudSession{
+			statusURL: fmt.Sprintf("https://api.%s.saucelabs.com/rest/v1/%s/jobs/%s", region, url.PathEscape(username), sessionID),
+			username:  username,
+			accessKey: accessKey,
+			client:    client,
+			body: func(status *testStatus) interface{} {
+				body := map[string]interface{}{"passed": status.passed}
+				if status.reason != "" {
+					body["custom-data"] = map[string]string{"reason": status.reason}
+				}
+				return body
+			},
+		}
+	}
+
+	if bstackOptions, ok := capabilities["bstack:options"].(map[string]interface{}); ok {
+		username, _ := bstackOptions["userName"].(string)
+		accessKey, _ := bstackOptions["accessKey"].(string)
+		return &cloudSession{
+			statusURL: "https://api.browserstack.com/automate/sessions/" + sessionID + ".json",
+			username:  username,
+			accessKey: accessKey,
+			client:    client,
+			body: func(status *testStatus) interface{} {
+				result := "failed"
+				if status.passed {
+					result = "passed"
+				}
+				return map[string]string{"status": result, "reason": status.reason}
+			},
+		}
+	}
+	return nil
+}
+
+func (c *cloudSession) report() error {
+	if c.status == nil {
+		return nil
+	}
+
+	body, err := json.Marshal(c.body(c.status))
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest("PUT", c.statusURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.SetBasicAuth(c.username, c.accessKey)
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("request unsuccessful: %s", response.Status)
+	}
+	return nil
+}
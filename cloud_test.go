@@ -0,0 +1,207 @@
+package agouti_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+)
+
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request.URL.Scheme = t.target.Scheme
+	request.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+var _ = Describe("Cloud Providers", func() {
+	var (
+		server         *httptest.Server
+		client         *http.Client
+		statusRequests []*http.Request
+		statusBodies   []map[string]interface{}
+		statusCode     int
+	)
+
+	BeforeEach(func() {
+		statusRequests, statusBodies = nil, nil
+		statusCode = 200
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			switch request.URL.Path {
+			case "/wd/hub/session":
+				response.Write([]byte(`{"value": {"sessionId": "some-id", "capabilities": {}}}`))
+			case "/rest/v1/some-user/jobs/some-id", "/automate/sessions/some-id.json":
+				var body map[string]interface{}
+				json.NewDecoder(request.Body).Decode(&body)
+				statusRequests = append(statusRequests, request)
+				statusBodies = append(statusBodies, body)
+				response.WriteHeader(statusCode)
+			default:
+				if request.Method == "GET" {
+					response.WriteHeader(404)
+					return
+				}
+				response.Write([]byte(`{"value": null}`))
+			}
+		}))
+		serverURL, _ := url.Parse(server.URL)
+		client = &http.Client{Transport: redirectTransport{serverURL}}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe(".SauceLabsURL", func() {
+		It("should return the hub URL for the provided region", func() {
+			Expect(SauceLabsURL("eu-central-1")).To(Equal("https://ondemand.eu-central-1.saucelabs.com/wd/hub"))
+		})
+
+		It("should default to the us-west-1 region", func() {
+			Expect(SauceLabsURL("")).To(Equal("https://ondemand.us-west-1.saucelabs.com/wd/hub"))
+		})
+	})
+
+	Describe("Capabilities#Sauce", func() {
+		It("should set the provided options as Sauce Labs options", func() {
+			capabilities := NewCapabilities().Sauce(SauceOptions{
+				Username:         "some-user",
+				AccessKey:        "some-key",
+				Name:             "some-test",
+				Build:            "some-build",
+				Tags:             []string{"some-tag"},
+				TunnelName:       "some-tunnel",
+				ScreenResolution: "1920x1080",
+			})
+			Expect(capabilities.JSON()).To(MatchJSON(`{"sauce:options": {
+				"username": "some-user",
+				"accessKey": "some-key",
+				"name": "some-test",
+				"build": "some-build",
+				"tags": ["some-tag"],
+				"tunnelName": "some-tunnel",
+				"screenResolution": "1920x1080"
+			}}`))
+		})
+
+		It("should default to credentials from the environment and the test binary name", func() {
+			os.Setenv("SAUCE_USERNAME", "env-user")
+			os.Setenv("SAUCE_ACCESS_KEY", "env-key")
+			defer os.Unsetenv("SAUCE_USERNAME")
+			defer os.Unsetenv("SAUCE_ACCESS_KEY")
+			sauceOptions := NewCapabilities().Sauce(SauceOptions{})["sauce:options"]
+			Expect(sauceOptions).To(HaveKeyWithValue("username", "env-user"))
+			Expect(sauceOptions).To(HaveKeyWithValue("accessKey", "env-key"))
+			Expect(sauceOptions).To(HaveKeyWithValue("name", "agouti"))
+		})
+
+		It("should default to the CI build name", func() {
+			os.Setenv("BUILD_TAG", "jenkins-some-job-1")
+			defer os.Unsetenv("BUILD_TAG")
+			sauceOptions := NewCapabilities().Sauce(SauceOptions{})["sauce:options"]
+			Expect(sauceOptions).To(HaveKeyWithValue("build", "jenkins-some-job-1"))
+		})
+	})
+
+	Describe("Capabilities#BrowserStack", func() {
+		It("should set the provided options as BrowserStack options", func() {
+			capabilities := NewCapabilities().BrowserStack(BrowserStackOptions{
+				Username:    "some-user",
+				AccessKey:   "some-key",
+				ProjectName: "some-project",
+				BuildName:   "some-build",
+				SessionName: "some-test",
+				Local:       true,
+				OS:          "Windows",
+				OSVersion:   "11",
+			})
+			Expect(capabilities.JSON()).To(MatchJSON(`{"bstack:options": {
+				"userName": "some-user",
+				"accessKey": "some-key",
+				"projectName": "some-project",
+				"buildName": "some-build",
+				"sessionName": "some-test",
+				"local": true,
+				"os": "Windows",
+				"osVersion": "11"
+			}}`))
+		})
+
+		It("should default the session name to the test binary name", func() {
+			bstackOptions := NewCapabilities().BrowserStack(BrowserStackOptions{})["bstack:options"]
+			Expect(bstackOptions).To(HaveKeyWithValue("sessionName", "agouti"))
+		})
+	})
+
+	Describe("#SetTestStatus", func() {
+		It("should report the status to the regional Sauce Labs REST API when the page is destroyed", func() {
+			capabilities := NewCapabilities().Sauce(SauceOptions{Username: "some-user", AccessKey: "some-key"})
+			page, err := Remote(SauceLabsURL("eu-central-1"), Desired(capabilities), HTTPClient(client))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.SetTestStatus(false, "some reason")).To(Succeed())
+			Expect(statusRequests).To(BeEmpty())
+
+			Expect(page.Destroy()).To(Succeed())
+			Expect(statusRequests).To(HaveLen(1))
+			Expect(statusRequests[0].Method).To(Equal("PUT"))
+			Expect(statusRequests[0].Host).To(Equal("api.eu-central-1.saucelabs.com"))
+			username, accessKey, _ := statusRequests[0].BasicAuth()
+			Expect(username).To(Equal("some-user"))
+			Expect(accessKey).To(Equal("some-key"))
+			Expect(statusBodies[0]).To(Equal(map[string]interface{}{
+				"passed":      false,
+				"custom-data": map[string]interface{}{"reason": "some reason"},
+			}))
+		})
+
+		It("should report the status to the BrowserStack REST API when the page is destroyed", func() {
+			capabilities := NewCapabilities().BrowserStack(BrowserStackOptions{Username: "some-user", AccessKey: "some-key"})
+			page, err := NewPage(BrowserStackURL, Desired(capabilities), HTTPClient(client))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.SetTestStatus(true, "some reason")).To(Succeed())
+
+			Expect(page.Destroy()).To(Succeed())
+			Expect(statusRequests).To(HaveLen(1))
+			Expect(statusRequests[0].Host).To(Equal("api.browserstack.com"))
+			Expect(statusBodies[0]).To(Equal(map[string]interface{}{
+				"status": "passed",
+				"reason": "some reason",
+			}))
+		})
+
+		It("should not report a status that was never set", func() {
+			capabilities := NewCapabilities().BrowserStack(BrowserStackOptions{Username: "some-user"})
+			page, err := Remote(BrowserStackURL, Desired(capabilities), HTTPClient(client))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Destroy()).To(Succeed())
+			Expect(statusRequests).To(BeEmpty())
+		})
+
+		Context("when reporting the status fails", func() {
+			It("should return an error", func() {
+				statusCode = 401
+				capabilities := NewCapabilities().BrowserStack(BrowserStackOptions{Username: "some-user"})
+				page, err := Remote(BrowserStackURL, Desired(capabilities), HTTPClient(client))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(page.SetTestStatus(true, "")).To(Succeed())
+				Expect(page.Destroy()).To(MatchError("failed to report test status: request unsuccessful: 401 Unauthorized"))
+			})
+		})
+
+		Context("when the page is not a cloud provider session", func() {
+			It("should return an error", func() {
+				page, err := Remote(server.URL + "/wd/hub")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(page.SetTestStatus(true, "")).To(MatchError("failed to set test status: page is not a Sauce Labs or BrowserStack session"))
+			})
+		})
+	})
+})
@@ -0,0 +1,171 @@
+package agouti
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/sclevine/agouti/api"
+)
+
+var consoleLogInterval = 250 * time.Millisecond
+
+type consoleLogStream struct {
+	logs chan Log
+	stop chan struct{}
+}
+
+// ConsoleLogs returns a channel that receives browser console logs, such as
+// JavaScript logs and errors, as they are logged. Repeated calls return the
+// same channel, which is closed when the page is destroyed or logs can no
+// longer be retrieved.
+//
+// Pages opened with the BiDi Option receive logs over WebDriver BiDi.
+// Otherwise, the "browser" log is polled, and logs that are received by the
+// channel are no longer returned by ReadNewLogs (but are returned by
+// ReadAllLogs).
+//
+// Example:
+//    go func() {
+//        for log := range page.ConsoleLogs() {
+//            fmt.Printf("%s %s:%d %s\n", log.Level, log.Source, log.Line, log.Text)
+//        }
+//    }()
+func (p *Page) ConsoleLogs() <-chan Log {
+	p.logsMutex.Lock()
+	defer p.logsMutex.Unlock()
+
+	if p.consoleLogs != nil {
+		return p.consoleLogs.logs
+	}
+
+	stream := &consoleLogStream{logs: make(chan Log), stop: make(chan struct{})}
+	p.consoleLogs = stream
+	if events, err := p.session.Subscribe("log.entryAdded"); err == nil {
+		go p.forwardConsoleLogs(stream, events)
+	} else {
+		go p.pollConsoleLogs(stream)
+	}
+	return stream.logs
+}
+
+func (p *Page) pollConsoleLogs(stream *consoleLogStream) {
+	defer p.closeConsoleLogs(stream)
+
+	for {
+		p.logsMutex.Lock()
+		logs, err := p.readNewLogs("browser")
+		p.logsMutex.Unlock()
+		if err != nil {
+			return
+		}
+
+		for _, log := range logs {
+			select {
+			case stream.logs <- log:
+			case <-stream.stop:
+				return
+			}
+		}
+
+		select {
+		case <-time.After(consoleLogInterval):
+		case <-stream.stop:
+			return
+		}
+	}
+}
+
+func (p *Page) forwardConsoleLogs(stream *consoleLogStream, events <-chan api.Event) {
+	defer p.closeConsoleLogs(stream)
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			log, ok := bidiLog(event)
+			if !ok {
+				continue
+			}
+			select {
+			case stream.logs <- log:
+			case <-stream.stop:
+				return
+			}
+		case <-stream.stop:
+			return
+		}
+	}
+}
+
+func (p *Page) closeConsoleLogs(stream *consoleLogStream) {
+	p.logsMutex.Lock()
+	if p.consoleLogs == stream {
+		p.consoleLogs = nil
+	}
+	p.logsMutex.Unlock()
+	close(stream.logs)
+}
+
+func (p *Page) stopConsoleLogs() {
+	p.logsMutex.Lock()
+	stream := p.consoleLogs
+	p.consoleLogs = nil
+	p.logsMutex.Unlock()
+
+	if stream != nil {
+		close(stream.stop)
+	}
+}
+
+var bidiLogLevels = map[string]string{
+	"debug": "DEBUG",
+	"info":  "INFO",
+	"warn":  "WARNING",
+	"error": "SEVERE",
+}
+
+// BiDi positions are zero-based, while WebDriver log positions are one-based.
+func bidiLog(event api.Event) (Log, bool) {
+	var entry struct {
+		Type       string `json:"type"`
+		Level      string `json:"level"`
+		Text       string `json:"text"`
+		Timestamp  int64  `json:"timestamp"`
+		StackTrace struct {
+			CallFrames []struct {
+				URL          string `json:"url"`
+				LineNumber   int    `json:"lineNumber"`
+				ColumnNumber int    `json:"columnNumber"`
+			} `json:"callFrames"`
+		} `json:"stackTrace"`
+	}
+	if err := json.Unmarshal(event.Params, &entry); err != nil {
+		return Log{}, false
+	}
+
+	log := Log{
+		Message: entry.Text,
+		Text:    entry.Text,
+		Source:  "javascript",
+		Level:   bidiLogLevels[entry.Level],
+		Time:    msToTime(entry.Timestamp),
+	}
+	if entry.Type == "console" {
+		log.Source = "console-api"
+	}
+	if log.Level == "" {
+		log.Level = "INFO"
+	}
+
+	if frames := entry.StackTrace.CallFrames; len(frames) > 0 {
+		log.Line, log.Column = frames[0].LineNumber+1, frames[0].ColumnNumber+1
+		log.Location = strconv.Itoa(log.Line) + ":" + strconv.Itoa(log.Column)
+		if frames[0].URL != "" {
+			log.Source = frames[0].URL
+		}
+	}
+	return log, true
+}
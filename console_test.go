@@ -0,0 +1,112 @@
+package agouti_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Console Logs", func() {
+	var (
+		page    *Page
+		session *mocks.Session
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		page = NewTestPage(session)
+	})
+
+	Describe("#ConsoleLogs", func() {
+		Context("when the session does not support WebDriver BiDi", func() {
+			BeforeEach(func() {
+				session.SubscribeCall.Err = errors.New("some error")
+				session.NewLogsCall.ReturnLogs = []api.Log{
+					{Message: "http://example.com/app.js 12:34 some message", Level: "WARNING", Timestamp: 1418196097543},
+				}
+			})
+
+			It("should stream parsed logs from the browser log", func() {
+				var log Log
+				Eventually(page.ConsoleLogs()).Should(Receive(&log))
+				Expect(log.Message).To(Equal("http://example.com/app.js 12:34 some message"))
+				Expect(log.Text).To(Equal("some message"))
+				Expect(log.Source).To(Equal("http://example.com/app.js"))
+				Expect(log.Line).To(Equal(12))
+				Expect(log.Column).To(Equal(34))
+				Expect(log.Level).To(Equal("WARNING"))
+				Expect(log.Time.Unix()).To(BeEquivalentTo(1418196097))
+			})
+
+			It("should return the same channel when called repeatedly", func() {
+				Expect(page.ConsoleLogs()).To(Equal(page.ConsoleLogs()))
+			})
+
+			It("should close the channel when the page is destroyed", func() {
+				logs := page.ConsoleLogs()
+				Expect(page.Destroy()).To(Succeed())
+				Eventually(logs).Should(BeClosed())
+			})
+
+			Context("when the session fails to retrieve logs", func() {
+				It("should close the channel", func() {
+					session.NewLogsCall.Err = errors.New("some error")
+					Eventually(page.ConsoleLogs()).Should(BeClosed())
+				})
+			})
+		})
+
+		Context("when the session supports WebDriver BiDi", func() {
+			var events chan api.Event
+
+			BeforeEach(func() {
+				events = make(chan api.Event, 2)
+				session.SubscribeCall.ReturnEvents = events
+			})
+
+			It("should subscribe to log entries", func() {
+				page.ConsoleLogs()
+				Expect(session.SubscribeCall.Events).To(Equal([]string{"log.entryAdded"}))
+			})
+
+			It("should stream logs from the log entry events", func() {
+				events <- api.Event{Method: "log.entryAdded", Params: json.RawMessage(`{
+					"type": "console", "level": "warn", "text": "some message", "timestamp": 1418196097543,
+					"stackTrace": {"callFrames": [{"url": "http://example.com/app.js", "lineNumber": 11, "columnNumber": 33}]}
+				}`)}
+				events <- api.Event{Method: "log.entryAdded", Params: json.RawMessage(`{
+					"type": "javascript", "level": "error", "text": "Error: some error", "timestamp": 1418196098376
+				}`)}
+
+				logs := page.ConsoleLogs()
+				var log Log
+				Eventually(logs).Should(Receive(&log))
+				Expect(log.Message).To(Equal("some message"))
+				Expect(log.Text).To(Equal("some message"))
+				Expect(log.Source).To(Equal("http://example.com/app.js"))
+				Expect(log.Location).To(Equal("12:34"))
+				Expect(log.Line).To(Equal(12))
+				Expect(log.Column).To(Equal(34))
+				Expect(log.Level).To(Equal("WARNING"))
+				Expect(log.Time.Unix()).To(BeEquivalentTo(1418196097))
+
+				Eventually(logs).Should(Receive(&log))
+				Expect(log.Message).To(Equal("Error: some error"))
+				Expect(log.Source).To(Equal("javascript"))
+				Expect(log.Level).To(Equal("SEVERE"))
+				Expect(log.Line).To(BeZero())
+			})
+
+			It("should close the channel when the events end", func() {
+				logs := page.ConsoleLogs()
+				close(events)
+				Eventually(logs).Should(BeClosed())
+			})
+		})
+	})
+})
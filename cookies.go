@@ -0,0 +1,83 @@
+package agouti
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+)
+
+// ExportCookies returns a cookie jar containing the cookies of the page, so
+// that requests made using net/http share the browser session, ex. to call an
+// API as the user that is logged in to the page.
+//
+// Example:
+//    jar, err := page.ExportCookies()
+//    client := &http.Client{Jar: jar}
+//    response, err := client.Get("https://example.com/api/orders")
+func (p *Page) ExportCookies() (http.CookieJar, error) {
+	pageURL, err := p.currentURL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to export cookies: %s", err)
+	}
+
+	cookies, err := p.GetCookies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to export cookies: %s", err)
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export cookies: %s", err)
+	}
+	for _, cookie := range cookies {
+		cookieURL := *pageURL
+		cookieURL.Path = cookie.Path
+
+		// Browsers report the domain of host-only cookies without a leading
+		// dot, while a cookie jar treats any domain as a domain cookie.
+		if cookie.Domain != "" && !strings.HasPrefix(cookie.Domain, ".") {
+			cookieURL.Host = cookie.Domain
+			cookie.Domain = ""
+		}
+		jar.SetCookies(&cookieURL, []*http.Cookie{cookie})
+	}
+	return jar, nil
+}
+
+// ImportCookies sets the cookies of the provided jar for the current URL of
+// the page on the page, so that a session created using net/http may be used
+// by the browser, ex. to skip logging in through the UI. Cookie jars only
+// provide the names and values of their cookies, so the cookies are set on
+// the domain of the page at the root path as session cookies. The page must
+// already be at a URL on the domain of the cookies.
+//
+// Example:
+//    jar, _ := cookiejar.New(nil)
+//    client := &http.Client{Jar: jar}
+//    client.PostForm("https://example.com/login", url.Values{"user": {"some-user"}, "password": {"some-password"}})
+//    page.Navigate("https://example.com")
+//    page.ImportCookies(jar)
+//    page.Navigate("https://example.com/account")
+func (p *Page) ImportCookies(jar http.CookieJar) error {
+	pageURL, err := p.currentURL()
+	if err != nil {
+		return fmt.Errorf("failed to import cookies: %s", err)
+	}
+
+	for _, cookie := range jar.Cookies(pageURL) {
+		if err := p.SetCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value}); err != nil {
+			return fmt.Errorf("failed to import cookies: %s", err)
+		}
+	}
+	return nil
+}
+
+func (p *Page) currentURL() (*url.URL, error) {
+	rawURL, err := p.session.GetURL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve URL: %s", err)
+	}
+	return url.Parse(rawURL)
+}
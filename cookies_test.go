@@ -0,0 +1,103 @@
+package agouti_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Cookies", func() {
+	var (
+		page    *Page
+		session *mocks.Session
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		page = NewTestPage(session)
+		session.GetURLCall.ReturnURL = "https://www.example.com/account"
+	})
+
+	Describe("#ExportCookies", func() {
+		BeforeEach(func() {
+			session.GetCookiesCall.ReturnCookies = []*api.Cookie{
+				{Name: "session", Value: "some-session", Path: "/", Domain: "www.example.com", Secure: true, HTTPOnly: true},
+				{Name: "theme", Value: "dark", Path: "/", Domain: ".example.com"},
+				{Name: "cart", Value: "some-cart", Path: "/shop", Domain: "www.example.com"},
+			}
+		})
+
+		It("should return a jar containing the cookies of the page", func() {
+			jar, err := page.ExportCookies()
+			Expect(err).NotTo(HaveOccurred())
+			wwwURL, _ := url.Parse("https://www.example.com/")
+			Expect(jar.Cookies(wwwURL)).To(ConsistOf(
+				&http.Cookie{Name: "session", Value: "some-session"},
+				&http.Cookie{Name: "theme", Value: "dark"},
+			))
+		})
+
+		It("should preserve the paths and domains of the cookies", func() {
+			jar, err := page.ExportCookies()
+			Expect(err).NotTo(HaveOccurred())
+			shopURL, _ := url.Parse("https://www.example.com/shop/cart")
+			Expect(jar.Cookies(shopURL)).To(ContainElement(&http.Cookie{Name: "cart", Value: "some-cart"}))
+			otherURL, _ := url.Parse("https://api.example.com/")
+			Expect(jar.Cookies(otherURL)).To(Equal([]*http.Cookie{{Name: "theme", Value: "dark"}}))
+		})
+
+		Context("when the cookies cannot be retrieved", func() {
+			It("should return an error", func() {
+				session.GetCookiesCall.Err = errors.New("some error")
+				_, err := page.ExportCookies()
+				Expect(err).To(MatchError("failed to export cookies: failed to get cookies: some error"))
+			})
+		})
+
+		Context("when the URL cannot be retrieved", func() {
+			It("should return an error", func() {
+				session.GetURLCall.Err = errors.New("some error")
+				_, err := page.ExportCookies()
+				Expect(err).To(MatchError("failed to export cookies: failed to retrieve URL: some error"))
+			})
+		})
+	})
+
+	Describe("#ImportCookies", func() {
+		var jar http.CookieJar
+
+		BeforeEach(func() {
+			jar, _ = cookiejar.New(nil)
+			accountURL, _ := url.Parse("https://www.example.com/account")
+			jar.SetCookies(accountURL, []*http.Cookie{{Name: "session", Value: "some-session", Path: "/"}})
+		})
+
+		It("should set the cookies of the jar for the current URL on the page", func() {
+			Expect(page.ImportCookies(jar)).To(Succeed())
+			Expect(session.SetCookieCall.Cookie).To(Equal(&api.Cookie{Name: "session", Value: "some-session"}))
+		})
+
+		Context("when a cookie cannot be set", func() {
+			It("should return an error", func() {
+				session.SetCookieCall.Err = errors.New("some error")
+				err := page.ImportCookies(jar)
+				Expect(err).To(MatchError("failed to import cookies: failed to set cookie: some error"))
+			})
+		})
+
+		Context("when the URL cannot be retrieved", func() {
+			It("should return an error", func() {
+				session.GetURLCall.Err = errors.New("some error")
+				err := page.ImportCookies(jar)
+				Expect(err).To(MatchError("failed to import cookies: failed to retrieve URL: some error"))
+			})
+		})
+	})
+})
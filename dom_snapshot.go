@@ -0,0 +1,139 @@
+package agouti
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DOMSnapshotOptions configures DOMSnapshot.
+type DOMSnapshotOptions struct {
+	// Selector is a CSS selector for the element to serialize, instead of
+	// the entire document
+	Selector string
+
+	// StripAttributes lists attributes that are removed from every element,
+	// ex. attributes that contain generated IDs or timestamps. Names that end
+	// in "*" remove every attribute with the preceding prefix, ex. "data-v-*".
+	StripAttributes []string
+
+	// MaskSelectors lists CSS selectors of elements whose contents are
+	// replaced with "...", ex. elements that display the current time
+	MaskSelectors []string
+}
+
+// The snapshot lists each element and text node on its own line, indented by
+// its depth. Elements without element children are written on a single line.
+const domSnapshotScript = `
+	var options = arguments[0];
+	var root = options.selector ? document.querySelector(options.selector) : document.documentElement;
+	if (!root) {
+		return {error: "no element matches '" + options.selector + "'"};
+	}
+
+	var voidElements = {area: 1, base: 1, br: 1, col: 1, embed: 1, hr: 1, img: 1, input: 1, link: 1, meta: 1, source: 1, track: 1, wbr: 1};
+	var stripped = function(name) {
+		return (options.stripAttributes || []).some(function(pattern) {
+			return pattern.slice(-1) === "*" ? name.indexOf(pattern.slice(0, -1)) === 0 : name === pattern;
+		});
+	};
+	var masked = function(element) {
+		return (options.maskSelectors || []).some(function(selector) { return element.matches(selector); });
+	};
+	var escape = function(text) {
+		return text.replace(/&/g, "&amp;").replace(/</g, "&lt;").replace(/>/g, "&gt;").replace(/"/g, "&quot;");
+	};
+
+	var lines = [];
+	var serialize = function(node, indent) {
+		if (node.nodeType === Node.TEXT_NODE) {
+			var text = node.nodeValue.replace(/\s+/g, " ").trim();
+			if (text) {
+				lines.push(indent + escape(text));
+			}
+			return;
+		}
+		if (node.nodeType !== Node.ELEMENT_NODE) {
+			return;
+		}
+
+		var tag = node.tagName.toLowerCase();
+		var names = [];
+		for (var i = 0; i < node.attributes.length; i++) {
+			if (!stripped(node.attributes[i].name)) {
+				names.push(node.attributes[i].name);
+			}
+		}
+		var attributes = names.sort().map(function(name) {
+			var value = node.getAttribute(name);
+			if (name === "class") {
+				value = value.split(/\s+/).filter(Boolean).sort().join(" ");
+			}
+			return " " + name + '="' + escape(value) + '"';
+		});
+		var open = indent + "<" + tag + attributes.join("") + ">";
+		var close = "</" + tag + ">";
+
+		if (voidElements[tag]) {
+			lines.push(open);
+			return;
+		}
+		if (masked(node)) {
+			lines.push(open + "..." + close);
+			return;
+		}
+		if (tag === "script" || tag === "style") {
+			lines.push(open + close);
+			return;
+		}
+
+		var start = lines.length;
+		lines.push(open);
+		var children = node.content ? node.content.childNodes : node.childNodes;
+		for (var j = 0; j < children.length; j++) {
+			serialize(children[j], indent + "  ");
+		}
+		if (lines.length === start + 1) {
+			lines[start] += close;
+		} else if (lines.length === start + 2 && lines[start + 1].trim().charAt(0) !== "<") {
+			lines[start] += lines.pop().trim() + close;
+		} else {
+			lines.push(indent + close);
+		}
+	};
+	serialize(root, "");
+	return {snapshot: lines.join("\n") + "\n"};
+`
+
+// DOMSnapshot returns a normalized serialization of the current DOM of the
+// page, which is stable across browsers and renders, so that it may be
+// compared with a previous snapshot. Each element and non-empty text node is
+// serialized on its own line, indented by its depth. Attributes and class
+// names are sorted, whitespace within text is collapsed, and comments and the
+// contents of script and style elements are omitted. Volatile attributes and
+// elements may be excluded using the provided options.
+//
+// Example:
+//    snapshot, err := page.DOMSnapshot(agouti.DOMSnapshotOptions{
+//        Selector:        "main",
+//        StripAttributes: []string{"data-reactid", "data-v-*"},
+//        MaskSelectors:   []string{"time.updated"},
+//    })
+func (p *Page) DOMSnapshot(options DOMSnapshotOptions) (string, error) {
+	request := struct {
+		Selector        string   `json:"selector"`
+		StripAttributes []string `json:"stripAttributes"`
+		MaskSelectors   []string `json:"maskSelectors"`
+	}{options.Selector, options.StripAttributes, options.MaskSelectors}
+
+	var result struct {
+		Snapshot string `json:"snapshot"`
+		Error    string `json:"error"`
+	}
+	if err := p.session.Execute(domSnapshotScript, []interface{}{request}, &result); err != nil {
+		return "", fmt.Errorf("failed to snapshot DOM: %s", err)
+	}
+	if result.Error != "" {
+		return "", errors.New("failed to snapshot DOM: " + result.Error)
+	}
+	return result.Snapshot, nil
+}
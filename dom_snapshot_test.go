@@ -0,0 +1,56 @@
+package agouti_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("DOM Snapshots", func() {
+	var (
+		page    *Page
+		session *mocks.Session
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		page = NewTestPage(session)
+	})
+
+	Describe("#DOMSnapshot", func() {
+		It("should serialize the DOM using the provided options", func() {
+			session.ExecuteCall.Result = `{"snapshot": "<main>\n  <h1>Title</h1>\n</main>\n"}`
+			snapshot, err := page.DOMSnapshot(DOMSnapshotOptions{
+				Selector:        "main",
+				StripAttributes: []string{"data-v-*"},
+				MaskSelectors:   []string{"time"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshot).To(Equal("<main>\n  <h1>Title</h1>\n</main>\n"))
+			Expect(session.ExecuteCall.Body).To(ContainSubstring("document.documentElement"))
+			arguments, err := json.Marshal(session.ExecuteCall.Arguments)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(arguments).To(MatchJSON(`[{"selector": "main", "stripAttributes": ["data-v-*"], "maskSelectors": ["time"]}]`))
+		})
+
+		Context("when the selector does not match an element", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Result = `{"error": "no element matches 'main'"}`
+				_, err := page.DOMSnapshot(DOMSnapshotOptions{Selector: "main"})
+				Expect(err).To(MatchError("failed to snapshot DOM: no element matches 'main'"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err := page.DOMSnapshot(DOMSnapshotOptions{})
+				Expect(err).To(MatchError("failed to snapshot DOM: some error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,464 @@
+// Package driverinstall downloads the chromedriver and geckodriver binaries
+// that match the versions of Chrome and Firefox installed on the host.
+// Downloaded binaries are cached, so that each version is only downloaded
+// once.
+//
+// agouti.ChromeDriver and agouti.GeckoDriver use this package when they are
+// provided the agouti.InstallDriver Option and the driver is not in the
+// PATH. To use it directly:
+//    path, err := driverinstall.ChromeDriver()
+//    if err != nil {
+//        return err
+//    }
+//    driver := agouti.NewWebDriver("http://{{.Address}}", []string{path, "--port={{.Port}}"})
+package driverinstall
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// An Installer downloads WebDriver binaries into a cache directory. The zero
+// value is ready to use.
+type Installer struct {
+	// CacheDir is the directory that binaries are downloaded into. It
+	// defaults to the "agouti/drivers" directory in the user cache
+	// directory, ex. ~/.cache/agouti/drivers.
+	CacheDir string
+
+	// HTTPClient is used for all downloads. It defaults to a client that
+	// times out after two minutes.
+	HTTPClient *http.Client
+
+	// BrowserVersion is the browser version to install the WebDriver for,
+	// ex. "120.0.6099.109". It defaults to the version of the browser that
+	// is installed on the host.
+	BrowserVersion string
+}
+
+// ChromeDriver installs the chromedriver that matches the installed version
+// of Chrome using the default Installer, and returns its path.
+func ChromeDriver() (string, error) {
+	return (&Installer{}).ChromeDriver()
+}
+
+// GeckoDriver installs the geckodriver that supports the installed version
+// of Firefox using the default Installer, and returns its path.
+func GeckoDriver() (string, error) {
+	return (&Installer{}).GeckoDriver()
+}
+
+const chromeVersionsURL = "https://googlechromelabs.github.io/chrome-for-testing/latest-patch-versions-per-build-with-downloads.json"
+
+// ChromeDriver installs the newest chromedriver from Chrome for Testing with
+// the same major, minor, and build version as Chrome, and returns its path.
+// The download is verified against the MD5 digest reported by Google Cloud
+// Storage, which hosts Chrome for Testing. Only Chrome 115 and later are
+// supported.
+func (i *Installer) ChromeDriver() (string, error) {
+	driverPath, err := i.installChromeDriver()
+	if err != nil {
+		return "", fmt.Errorf("failed to install chromedriver: %s", err)
+	}
+	return driverPath, nil
+}
+
+func (i *Installer) installChromeDriver() (string, error) {
+	version, err := i.browserVersion(ChromeVersion)
+	if err != nil {
+		return "", err
+	}
+	versionParts := strings.Split(version, ".")
+	if len(versionParts) < 3 {
+		return "", fmt.Errorf("invalid Chrome version: %s", version)
+	}
+	build := strings.Join(versionParts[:3], ".")
+
+	binaryName := executable("chromedriver")
+	if driverPath, ok := i.cached("chromedriver", build, binaryName); ok {
+		return driverPath, nil
+	}
+
+	var versions struct {
+		Builds map[string]struct {
+			Version   string `json:"version"`
+			Downloads struct {
+				ChromeDriver []struct {
+					Platform string `json:"platform"`
+					URL      string `json:"url"`
+				} `json:"chromedriver"`
+			} `json:"downloads"`
+		} `json:"builds"`
+	}
+	if err := i.getJSON(chromeVersionsURL, &versions); err != nil {
+		return "", err
+	}
+
+	release, ok := versions.Builds[build]
+	if !ok {
+		return "", fmt.Errorf("no chromedriver found for Chrome %s", version)
+	}
+
+	platform := chromePlatform()
+	for _, download := range release.Downloads.ChromeDriver {
+		if download.Platform == platform {
+			archive, header, err := i.download(download.URL)
+			if err != nil {
+				return "", err
+			}
+			if err := verifyGoogleHash(archive, header.Get("X-Goog-Hash")); err != nil {
+				return "", err
+			}
+			return i.extract("chromedriver", build, binaryName, download.URL, archive)
+		}
+	}
+	return "", fmt.Errorf("no chromedriver %s found for platform %s", release.Version, platform)
+}
+
+// The minimum version of Firefox supported by each geckodriver release,
+// newest first. See:
+// https://firefox-source-docs.mozilla.org/testing/geckodriver/Support.html
+var geckoDriverReleases = []struct {
+	minFirefox int
+	version    string
+}{
+	{128, "0.36.0"},
+	{115, "0.35.0"},
+	{102, "0.33.0"},
+	{91, "0.31.0"},
+	{78, "0.30.0"},
+}
+
+const geckoDriverReleaseURL = "https://api.github.com/repos/mozilla/geckodriver/releases/tags/v"
+
+// GeckoDriver installs the newest geckodriver that supports the version of
+// Firefox, and returns its path. The download is verified against the
+// SHA-256 digest published by GitHub. Only Firefox 78 and later are
+// supported.
+func (i *Installer) GeckoDriver() (string, error) {
+	driverPath, err := i.installGeckoDriver()
+	if err != nil {
+		return "", fmt.Errorf("failed to install geckodriver: %s", err)
+	}
+	return driverPath, nil
+}
+
+func (i *Installer) installGeckoDriver() (string, error) {
+	version, err := i.browserVersion(FirefoxVersion)
+	if err != nil {
+		return "", err
+	}
+	var major int
+	if _, err := fmt.Sscanf(version, "%d", &major); err != nil {
+		return "", fmt.Errorf("invalid Firefox version: %s", version)
+	}
+
+	driverVersion := ""
+	for _, release := range geckoDriverReleases {
+		if major >= release.minFirefox {
+			driverVersion = release.version
+			break
+		}
+	}
+	if driverVersion == "" {
+		return "", fmt.Errorf("no geckodriver found for Firefox %s", version)
+	}
+
+	binaryName := executable("geckodriver")
+	if driverPath, ok := i.cached("geckodriver", driverVersion, binaryName); ok {
+		return driverPath, nil
+	}
+
+	var release struct {
+		Assets []struct {
+			Name        string `json:"name"`
+			DownloadURL string `json:"browser_download_url"`
+			Digest      string `json:"digest"`
+		} `json:"assets"`
+	}
+	if err := i.getJSON(geckoDriverReleaseURL+driverVersion, &release); err != nil {
+		return "", err
+	}
+
+	assetName := "geckodriver-v" + driverVersion + "-" + geckoPlatform()
+	for _, asset := range release.Assets {
+		if asset.Name == assetName+".tar.gz" || asset.Name == assetName+".zip" {
+			archive, err := i.get(asset.DownloadURL)
+			if err != nil {
+				return "", err
+			}
+			if err := verifyDigest(archive, asset.Digest); err != nil {
+				return "", err
+			}
+			return i.extract("geckodriver", driverVersion, binaryName, asset.Name, archive)
+		}
+	}
+	return "", fmt.Errorf("no geckodriver %s found for platform %s", driverVersion, geckoPlatform())
+}
+
+func (i *Installer) browserVersion(detect func() (string, error)) (string, error) {
+	if i.BrowserVersion != "" {
+		return i.BrowserVersion, nil
+	}
+	return detect()
+}
+
+func (i *Installer) cacheDir() (string, error) {
+	if i.CacheDir != "" {
+		return i.CacheDir, nil
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userCacheDir, "agouti", "drivers"), nil
+}
+
+// A cached binary is only used if its checksum matches the checksum that
+// was recorded when it was installed.
+func (i *Installer) cached(name, version, binaryName string) (string, bool) {
+	cacheDir, err := i.cacheDir()
+	if err != nil {
+		return "", false
+	}
+	binaryPath := filepath.Join(cacheDir, name, version, binaryName)
+
+	binary, err := ioutil.ReadFile(binaryPath)
+	if err != nil {
+		return "", false
+	}
+	checksum, err := ioutil.ReadFile(binaryPath + ".sha256")
+	if err != nil || strings.TrimSpace(string(checksum)) != sha256Hex(binary) {
+		return "", false
+	}
+	return binaryPath, true
+}
+
+func (i *Installer) extract(name, version, binaryName, archiveName string, archive []byte) (string, error) {
+	var binary []byte
+	var err error
+	if strings.HasSuffix(archiveName, ".zip") {
+		binary, err = unzipFile(archive, binaryName)
+	} else {
+		binary, err = untarFile(archive, binaryName)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to extract %s: %s", archiveName, err)
+	}
+
+	cacheDir, err := i.cacheDir()
+	if err != nil {
+		return "", err
+	}
+	versionDir := filepath.Join(cacheDir, name, version)
+	if err := os.MkdirAll(versionDir, 0755); err != nil {
+		return "", err
+	}
+
+	binaryPath := filepath.Join(versionDir, binaryName)
+	if err := writeFileAtomic(binaryPath, binary, 0755); err != nil {
+		return "", err
+	}
+	if err := writeFileAtomic(binaryPath+".sha256", []byte(sha256Hex(binary)+"\n"), 0644); err != nil {
+		return "", err
+	}
+	return binaryPath, nil
+}
+
+// Files are renamed into place, so that drivers installed concurrently by
+// parallel tests never run or verify a partially written binary.
+func writeFileAtomic(path string, contents []byte, mode os.FileMode) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(contents); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(file.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// Downloads must not block forever, ex. when the installer is used by
+// agouti.ChromeDriver while starting a test suite.
+var defaultHTTPClient = &http.Client{Timeout: 2 * time.Minute}
+
+func (i *Installer) get(url string) ([]byte, error) {
+	body, _, err := i.download(url)
+	return body, err
+}
+
+func (i *Installer) download(url string) ([]byte, http.Header, error) {
+	client := i.HTTPClient
+	if client == nil {
+		client = defaultHTTPClient
+	}
+
+	response, err := client.Get(url)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return nil, nil, fmt.Errorf("failed to download %s: %s", url, response.Status)
+	}
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return body, response.Header, nil
+}
+
+func (i *Installer) getJSON(url string, result interface{}) error {
+	body, err := i.get(url)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("invalid response from %s: %s", url, err)
+	}
+	return nil
+}
+
+// Digests have the form "sha256:<hex>". Releases published before GitHub
+// provided digests are not verified.
+func verifyDigest(archive []byte, digest string) error {
+	if digest == "" {
+		return nil
+	}
+	expected := strings.TrimPrefix(digest, "sha256:")
+	if actual := sha256Hex(archive); actual != expected {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// Google Cloud Storage reports digests in the form
+// "crc32c=<base64>,md5=<base64>". Unlike GitHub digests, the digest is
+// required, so that chromedriver is never installed unverified.
+func verifyGoogleHash(archive []byte, hashes string) error {
+	for _, hash := range strings.Split(hashes, ",") {
+		hash = strings.TrimSpace(hash)
+		if strings.HasPrefix(hash, "md5=") {
+			sum := md5.Sum(archive)
+			if actual := base64.StdEncoding.EncodeToString(sum[:]); actual != strings.TrimPrefix(hash, "md5=") {
+				return fmt.Errorf("checksum mismatch: expected %s, got %s", strings.TrimPrefix(hash, "md5="), actual)
+			}
+			return nil
+		}
+	}
+	return errors.New("no MD5 digest was provided for the download")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func unzipFile(archive []byte, binaryName string) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range reader.File {
+		if path.Base(file.Name) == binaryName {
+			contents, err := file.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer contents.Close()
+			return ioutil.ReadAll(contents)
+		}
+	}
+	return nil, fmt.Errorf("%s not found", binaryName)
+}
+
+func untarFile(archive []byte, binaryName string) ([]byte, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	reader := tar.NewReader(gzipReader)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s not found", binaryName)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if path.Base(header.Name) == binaryName {
+			return ioutil.ReadAll(reader)
+		}
+	}
+}
+
+func executable(name string) string {
+	if runtime.GOOS == "windows" {
+		return name + ".exe"
+	}
+	return name
+}
+
+func chromePlatform() string {
+	switch runtime.GOOS + "/" + runtime.GOARCH {
+	case "darwin/arm64":
+		return "mac-arm64"
+	case "darwin/amd64":
+		return "mac-x64"
+	case "windows/386":
+		return "win32"
+	case "windows/amd64", "windows/arm64":
+		return "win64"
+	default:
+		return "linux64"
+	}
+}
+
+func geckoPlatform() string {
+	switch runtime.GOOS + "/" + runtime.GOARCH {
+	case "darwin/arm64":
+		return "macos-aarch64"
+	case "darwin/amd64":
+		return "macos"
+	case "windows/386":
+		return "win32"
+	case "windows/arm64":
+		return "win-aarch64"
+	case "windows/amd64":
+		return "win64"
+	case "linux/arm64":
+		return "linux-aarch64"
+	case "linux/386":
+		return "linux32"
+	default:
+		return "linux64"
+	}
+}
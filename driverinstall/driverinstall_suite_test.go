@@ -0,0 +1,13 @@
+package driverinstall_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestDriverInstall(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "DriverInstall Suite")
+}
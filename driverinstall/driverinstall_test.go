@@ -0,0 +1,244 @@
+package driverinstall_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/driverinstall"
+)
+
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request.URL.Scheme = t.target.Scheme
+	request.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+// The archives contain the binary for every platform, as the platform that
+// is downloaded depends on the platform running the tests.
+func zipArchive(name string, contents string) []byte {
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	for _, binaryName := range []string{name, name + ".exe"} {
+		file, _ := writer.Create(name + "-some-platform/" + binaryName)
+		file.Write([]byte(contents))
+	}
+	writer.Close()
+	return buffer.Bytes()
+}
+
+func tarArchive(name string, contents string) []byte {
+	buffer := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buffer)
+	writer := tar.NewWriter(gzipWriter)
+	for _, binaryName := range []string{name, name + ".exe"} {
+		writer.WriteHeader(&tar.Header{Name: binaryName, Mode: 0755, Size: int64(len(contents))})
+		writer.Write([]byte(contents))
+	}
+	writer.Close()
+	gzipWriter.Close()
+	return buffer.Bytes()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func googleHash(data []byte) string {
+	sum := md5.Sum(data)
+	return "crc32c=AAAAAA==,md5=" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+var _ = Describe("Installer", func() {
+	var (
+		server      *httptest.Server
+		installer   *Installer
+		cacheDir    string
+		requests    []string
+		geckoDigest string
+		chromeHash  string
+	)
+
+	chromePlatforms := []string{"linux64", "mac-arm64", "mac-x64", "win32", "win64"}
+	geckoPlatforms := []string{"linux64", "linux32", "linux-aarch64", "macos", "macos-aarch64", "win32", "win64", "win-aarch64"}
+	geckoArchive := tarArchive("geckodriver", "some-geckodriver")
+	chromeArchive := zipArchive("chromedriver", "some-chromedriver")
+
+	BeforeEach(func() {
+		requests = nil
+		geckoDigest = "sha256:" + sha256Hex(geckoArchive)
+		chromeHash = googleHash(chromeArchive)
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			requests = append(requests, request.Host+request.URL.Path)
+			switch request.URL.Path {
+			case "/chrome-for-testing/latest-patch-versions-per-build-with-downloads.json":
+				downloads := ""
+				for _, platform := range chromePlatforms {
+					downloads += fmt.Sprintf(`{"platform": %q, "url": "https://storage.example.com/chromedriver.zip"},`, platform)
+				}
+				fmt.Fprintf(response, `{"builds": {"120.0.6099": {"version": "120.0.6099.109", "downloads": {
+					"chromedriver": [%s]
+				}}}}`, downloads[:len(downloads)-1])
+			case "/chromedriver.zip":
+				if chromeHash != "" {
+					response.Header().Set("X-Goog-Hash", chromeHash)
+				}
+				response.Write(chromeArchive)
+			case "/repos/mozilla/geckodriver/releases/tags/v0.35.0":
+				assets := ""
+				for _, platform := range geckoPlatforms {
+					assets += fmt.Sprintf(`{"name": "geckodriver-v0.35.0-%s.tar.gz", "browser_download_url": "https://github.com/geckodriver.tar.gz", "digest": %q},`, platform, geckoDigest)
+					assets += fmt.Sprintf(`{"name": "geckodriver-v0.35.0-%s.zip", "browser_download_url": "https://github.com/geckodriver.tar.gz", "digest": %q},`, platform, geckoDigest)
+				}
+				fmt.Fprintf(response, `{"assets": [%s]}`, assets[:len(assets)-1])
+			case "/geckodriver.tar.gz":
+				response.Write(geckoArchive)
+			default:
+				response.WriteHeader(404)
+			}
+		}))
+
+		var err error
+		cacheDir, err = ioutil.TempDir("", "agouti-drivers")
+		Expect(err).NotTo(HaveOccurred())
+		serverURL, _ := url.Parse(server.URL)
+		installer = &Installer{
+			CacheDir:   cacheDir,
+			HTTPClient: &http.Client{Transport: redirectTransport{serverURL}},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(cacheDir)
+	})
+
+	Describe("#ChromeDriver", func() {
+		BeforeEach(func() {
+			installer.BrowserVersion = "120.0.6099.71"
+		})
+
+		It("should install the chromedriver for the same Chrome build into the cache directory", func() {
+			driverPath, err := installer.ChromeDriver()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(filepath.Dir(driverPath)).To(Equal(filepath.Join(cacheDir, "chromedriver", "120.0.6099")))
+			Expect(ioutil.ReadFile(driverPath)).To(Equal([]byte("some-chromedriver")))
+			info, err := os.Stat(driverPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode() & 0111).NotTo(BeZero())
+			Expect(requests).To(Equal([]string{
+				"googlechromelabs.github.io/chrome-for-testing/latest-patch-versions-per-build-with-downloads.json",
+				"storage.example.com/chromedriver.zip",
+			}))
+		})
+
+		It("should use the cached chromedriver without downloading it", func() {
+			firstPath, err := installer.ChromeDriver()
+			Expect(err).NotTo(HaveOccurred())
+			requests = nil
+			Expect(installer.ChromeDriver()).To(Equal(firstPath))
+			Expect(requests).To(BeEmpty())
+		})
+
+		Context("when the cached chromedriver has been modified", func() {
+			It("should download it again", func() {
+				driverPath, err := installer.ChromeDriver()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.WriteFile(driverPath, []byte("some-corrupt-file"), 0755)).To(Succeed())
+				requests = nil
+				Expect(installer.ChromeDriver()).To(Equal(driverPath))
+				Expect(requests).To(HaveLen(2))
+				Expect(ioutil.ReadFile(driverPath)).To(Equal([]byte("some-chromedriver")))
+			})
+		})
+
+		Context("when the download does not match the digest reported by the server", func() {
+			It("should return an error without installing it", func() {
+				chromeHash = googleHash([]byte("some-other-archive"))
+				_, err := installer.ChromeDriver()
+				Expect(err).To(MatchError(HavePrefix("failed to install chromedriver: checksum mismatch: ")))
+				Expect(filepath.Join(cacheDir, "chromedriver")).NotTo(BeADirectory())
+			})
+		})
+
+		Context("when the server does not report a digest", func() {
+			It("should return an error", func() {
+				chromeHash = ""
+				_, err := installer.ChromeDriver()
+				Expect(err).To(MatchError("failed to install chromedriver: no MD5 digest was provided for the download"))
+			})
+		})
+
+		Context("when there is no chromedriver for the Chrome build", func() {
+			It("should return an error", func() {
+				installer.BrowserVersion = "99.0.4844.51"
+				_, err := installer.ChromeDriver()
+				Expect(err).To(MatchError("failed to install chromedriver: no chromedriver found for Chrome 99.0.4844.51"))
+			})
+		})
+
+		Context("when the Chrome version is invalid", func() {
+			It("should return an error", func() {
+				installer.BrowserVersion = "120"
+				_, err := installer.ChromeDriver()
+				Expect(err).To(MatchError("failed to install chromedriver: invalid Chrome version: 120"))
+			})
+		})
+	})
+
+	Describe("#GeckoDriver", func() {
+		BeforeEach(func() {
+			installer.BrowserVersion = "121.0"
+		})
+
+		It("should install the newest geckodriver that supports the Firefox version", func() {
+			driverPath, err := installer.GeckoDriver()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(filepath.Dir(driverPath)).To(Equal(filepath.Join(cacheDir, "geckodriver", "0.35.0")))
+			Expect(ioutil.ReadFile(driverPath)).To(Equal([]byte("some-geckodriver")))
+		})
+
+		It("should use the cached geckodriver without downloading it", func() {
+			firstPath, err := installer.GeckoDriver()
+			Expect(err).NotTo(HaveOccurred())
+			requests = nil
+			Expect(installer.GeckoDriver()).To(Equal(firstPath))
+			Expect(requests).To(BeEmpty())
+		})
+
+		Context("when the download does not match the published digest", func() {
+			It("should return an error", func() {
+				geckoDigest = "sha256:" + sha256Hex([]byte("some-other-archive"))
+				_, err := installer.GeckoDriver()
+				Expect(err).To(MatchError(HavePrefix("failed to install geckodriver: checksum mismatch: ")))
+			})
+		})
+
+		Context("when Firefox is too old for any geckodriver", func() {
+			It("should return an error", func() {
+				installer.BrowserVersion = "60.0"
+				_, err := installer.GeckoDriver()
+				Expect(err).To(MatchError("failed to install geckodriver: no geckodriver found for Firefox 60.0"))
+			})
+		})
+	})
+})
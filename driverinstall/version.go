@@ -0,0 +1,62 @@
+package driverinstall
+
+import (
+	"errors"
+	"os/exec"
+	"regexp"
+	"runtime"
+)
+
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// ChromeVersion returns the version of Chrome or Chromium that is installed
+// on the host, ex. "120.0.6099.109".
+func ChromeVersion() (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		return registryVersion(`HKEY_CURRENT_USER\Software\Google\Chrome\BLBeacon`, "version")
+	case "darwin":
+		return commandVersion("/Applications/Google Chrome.app/Contents/MacOS/Google Chrome", "/Applications/Chromium.app/Contents/MacOS/Chromium")
+	default:
+		return commandVersion("google-chrome", "google-chrome-stable", "chromium", "chromium-browser")
+	}
+}
+
+// FirefoxVersion returns the version of Firefox that is installed on the
+// host, ex. "121.0".
+func FirefoxVersion() (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		return registryVersion(`HKEY_LOCAL_MACHINE\SOFTWARE\Mozilla\Mozilla Firefox`, "CurrentVersion")
+	case "darwin":
+		return commandVersion("/Applications/Firefox.app/Contents/MacOS/firefox")
+	default:
+		return commandVersion("firefox")
+	}
+}
+
+// The version is parsed from the output of the first browser command that
+// succeeds, ex. "Google Chrome 120.0.6099.109".
+func commandVersion(commands ...string) (string, error) {
+	for _, command := range commands {
+		output, err := exec.Command(command, "--version").Output()
+		if err != nil {
+			continue
+		}
+		if version := versionPattern.FindString(string(output)); version != "" {
+			return version, nil
+		}
+	}
+	return "", errors.New("browser not found")
+}
+
+func registryVersion(key, value string) (string, error) {
+	output, err := exec.Command("reg", "query", key, "/v", value).Output()
+	if err != nil {
+		return "", errors.New("browser not found")
+	}
+	if version := versionPattern.FindString(string(output)); version != "" {
+		return version, nil
+	}
+	return "", errors.New("browser not found")
+}
@@ -0,0 +1,228 @@
+// Package docker runs WebDrivers in Docker containers, so that neither the
+// browser nor its WebDriver needs to be installed on the host. A Container
+// may be used as the service of a WebDriver, which pulls and starts the
+// container when the WebDriver is started and removes it when the WebDriver
+// is stopped.
+//
+// Example:
+//    driver := agouti.DockerChrome()
+//    if err := driver.Start(); err != nil {
+//        return err
+//    }
+//    defer driver.Stop()
+package docker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ChromeImage is the Selenium image that provides Chrome.
+const ChromeImage = "selenium/standalone-chrome"
+
+// FirefoxImage is the Selenium image that provides Firefox.
+const FirefoxImage = "selenium/standalone-firefox"
+
+// A Container is a Docker container that provides a WebDriver on a port
+// that is mapped to a free port on the loopback interface of the host.
+type Container struct {
+	// Image is the image to run, ex. "selenium/standalone-chrome:120.0".
+	// The image is pulled if it is not present on the host.
+	Image string
+
+	// Port is the port of the WebDriver inside the container.
+	Port int
+
+	// Path is the path of the WebDriver URL, ex. "/wd/hub".
+	Path string
+
+	// Args are additional arguments to docker run, ex. "--shm-size=2g".
+	Args []string
+
+	// Binary is the Docker command. It defaults to "docker".
+	Binary string
+
+	id   string
+	url  string
+	logs *exec.Cmd
+}
+
+// Chrome returns a Container that runs the Selenium standalone Chrome image
+// with the provided tag, or the latest image if the tag is empty.
+func Chrome(tag string) *Container {
+	return selenium(ChromeImage, tag)
+}
+
+// Firefox returns a Container that runs the Selenium standalone Firefox
+// image with the provided tag, or the latest image if the tag is empty.
+func Firefox(tag string) *Container {
+	return selenium(FirefoxImage, tag)
+}
+
+// The browsers crash when the container has the default 64MB of /dev/shm.
+func selenium(image, tag string) *Container {
+	if tag != "" {
+		image += ":" + tag
+	}
+	return &Container{
+		Image: image,
+		Port:  4444,
+		Args:  []string{"--shm-size=2g"},
+	}
+}
+
+// URL returns the URL of the WebDriver, or an empty string if the container
+// is not running.
+func (c *Container) URL() string {
+	return c.url
+}
+
+// Start pulls the image if necessary and starts the container in the
+// background. If debug is true, the output of the pull and the logs of the
+// container are written to stdout.
+func (c *Container) Start(debug bool) error {
+	if c.id != "" {
+		return errors.New("already running")
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return fmt.Errorf("failed to locate a free port: %s", err)
+	}
+
+	pullOutput := ioutil.Discard
+	if debug {
+		pullOutput = os.Stdout
+	}
+
+	if _, err := c.docker(ioutil.Discard, "image", "inspect", c.Image); err != nil {
+		if _, err := c.docker(pullOutput, "pull", c.Image); err != nil {
+			return fmt.Errorf("failed to pull image: %s", err)
+		}
+	}
+
+	arguments := []string{"run", "--detach", "--rm", "--publish", fmt.Sprintf("127.0.0.1:%d:%d", port, c.Port)}
+	arguments = append(arguments, c.Args...)
+	id, err := c.docker(ioutil.Discard, append(arguments, c.Image)...)
+	if err != nil {
+		return fmt.Errorf("failed to run container: %s", err)
+	}
+
+	c.id = id
+	c.url = fmt.Sprintf("http://127.0.0.1:%d%s", port, c.Path)
+
+	if debug {
+		c.logs = exec.Command(c.binary(), "logs", "--follow", c.id)
+		c.logs.Stdout = os.Stdout
+		c.logs.Stderr = os.Stderr
+		if err := c.logs.Start(); err != nil {
+			c.logs = nil
+		}
+	}
+	return nil
+}
+
+// Stop removes the container.
+func (c *Container) Stop() error {
+	if c.id == "" {
+		return errors.New("already stopped")
+	}
+
+	if c.logs != nil {
+		c.logs.Process.Kill()
+		c.logs.Wait()
+		c.logs = nil
+	}
+
+	if _, err := c.docker(ioutil.Discard, "rm", "--force", c.id); err != nil {
+		return fmt.Errorf("failed to remove container: %s", err)
+	}
+
+	c.id = ""
+	c.url = ""
+	return nil
+}
+
+// WaitForBoot waits until the WebDriver in the container reports that it is
+// ready to create new sessions. Selenium reports its status before its
+// browser is available, so a "ready" value of false is not considered ready.
+func (c *Container) WaitForBoot(timeout time.Duration) error {
+	if c.url == "" {
+		return errors.New("not running")
+	}
+
+	deadline := time.Now().Add(timeout)
+	for !c.ready() {
+		if time.Now().After(deadline) {
+			return errors.New("failed to start before timeout")
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return nil
+}
+
+func (c *Container) ready() bool {
+	response, err := http.Get(c.url + "/status")
+	if err != nil {
+		return false
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return false
+	}
+
+	var status struct {
+		Value struct {
+			Ready *bool `json:"ready"`
+		} `json:"value"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&status); err != nil {
+		return true
+	}
+	return status.Value.Ready == nil || *status.Value.Ready
+}
+
+func (c *Container) binary() string {
+	if c.Binary == "" {
+		return "docker"
+	}
+	return c.Binary
+}
+
+// The standard output of the command is returned, and its standard error
+// is included in any error. Both are also copied to the provided output.
+func (c *Container) docker(output io.Writer, arguments ...string) (string, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	command := exec.Command(c.binary(), arguments...)
+	command.Stdout = io.MultiWriter(stdout, output)
+	command.Stderr = io.MultiWriter(stderr, output)
+
+	if err := command.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%s: %s", err, message)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
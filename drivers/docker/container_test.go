@@ -0,0 +1,144 @@
+package docker_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/drivers/docker"
+)
+
+// The fake docker command logs its arguments, and only has the image
+// "some-image:present" available locally.
+const fakeDocker = `#!/bin/sh
+echo "$@" >> "$(dirname "$0")/commands"
+case "$1 $2" in
+  "image inspect") [ "$3" = "some-image:present" ] ;;
+  "pull "*) [ "$2" != "some-image:missing" ] || { echo "manifest unknown" >&2; exit 1; } ;;
+  "run "*) echo "some-container-id" ;;
+esac
+`
+
+var _ = Describe("Container", func() {
+	var (
+		container *Container
+		tempDir   string
+	)
+
+	commands := func() []string {
+		log, _ := ioutil.ReadFile(filepath.Join(tempDir, "commands"))
+		return strings.Split(strings.TrimSpace(string(log)), "\n")
+	}
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "agouti-docker")
+		Expect(err).NotTo(HaveOccurred())
+		binary := filepath.Join(tempDir, "docker")
+		Expect(ioutil.WriteFile(binary, []byte(fakeDocker), 0755)).To(Succeed())
+		container = &Container{
+			Image:  "some-image:present",
+			Port:   4444,
+			Path:   "/wd/hub",
+			Args:   []string{"--shm-size=2g"},
+			Binary: binary,
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	Describe(".Chrome", func() {
+		It("should return a container for the tagged Selenium Chrome image", func() {
+			chrome := Chrome("120.0")
+			Expect(chrome.Image).To(Equal("selenium/standalone-chrome:120.0"))
+			Expect(chrome.Port).To(Equal(4444))
+			Expect(chrome.Args).To(Equal([]string{"--shm-size=2g"}))
+		})
+
+		It("should use the latest image when the tag is empty", func() {
+			Expect(Chrome("").Image).To(Equal("selenium/standalone-chrome"))
+		})
+	})
+
+	Describe(".Firefox", func() {
+		It("should return a container for the tagged Selenium Firefox image", func() {
+			Expect(Firefox("120.0").Image).To(Equal("selenium/standalone-firefox:120.0"))
+		})
+	})
+
+	Describe("#Start", func() {
+		It("should run the container with its port mapped to a free local port", func() {
+			Expect(container.Start(false)).To(Succeed())
+			Expect(container.URL()).To(MatchRegexp(`^http://127\.0\.0\.1:\d+/wd/hub$`))
+			port := strings.TrimSuffix(strings.TrimPrefix(container.URL(), "http://127.0.0.1:"), "/wd/hub")
+			Expect(commands()).To(Equal([]string{
+				"image inspect some-image:present",
+				"run --detach --rm --publish 127.0.0.1:" + port + ":4444 --shm-size=2g some-image:present",
+			}))
+		})
+
+		It("should pull the image when it is not present", func() {
+			container.Image = "some-image:latest"
+			Expect(container.Start(false)).To(Succeed())
+			Expect(commands()[1]).To(Equal("pull some-image:latest"))
+		})
+
+		Context("when the container is already running", func() {
+			It("should return an error", func() {
+				Expect(container.Start(false)).To(Succeed())
+				Expect(container.Start(false)).To(MatchError("already running"))
+			})
+		})
+
+		Context("when the image cannot be pulled", func() {
+			It("should return an error", func() {
+				container.Image = "some-image:missing"
+				Expect(container.Start(false)).To(MatchError("failed to pull image: exit status 1: manifest unknown"))
+				Expect(container.URL()).To(BeEmpty())
+			})
+		})
+
+		Context("when docker is not available", func() {
+			It("should return an error", func() {
+				container.Binary = filepath.Join(tempDir, "not-docker")
+				Expect(container.Start(false)).To(MatchError(HavePrefix("failed to pull image: ")))
+			})
+		})
+	})
+
+	Describe("#Stop", func() {
+		It("should remove the container", func() {
+			Expect(container.Start(false)).To(Succeed())
+			Expect(container.Stop()).To(Succeed())
+			Expect(commands()[2]).To(Equal("rm --force some-container-id"))
+			Expect(container.URL()).To(BeEmpty())
+		})
+
+		Context("when the container is not running", func() {
+			It("should return an error", func() {
+				Expect(container.Stop()).To(MatchError("already stopped"))
+			})
+		})
+	})
+
+	Describe("#WaitForBoot", func() {
+		Context("when the WebDriver does not become ready", func() {
+			It("should return an error", func() {
+				Expect(container.Start(false)).To(Succeed())
+				Expect(container.WaitForBoot(time.Second)).To(MatchError("failed to start before timeout"))
+			})
+		})
+
+		Context("when the container is not running", func() {
+			It("should return an error", func() {
+				Expect(container.WaitForBoot(time.Second)).To(MatchError("not running"))
+			})
+		})
+	})
+})
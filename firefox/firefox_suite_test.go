@@ -0,0 +1,13 @@
+package firefox_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestFirefox(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Firefox Suite")
+}
@@ -0,0 +1,202 @@
+// Package firefox builds Firefox profiles that may be provided to
+// geckodriver in the desired capabilities of a Page.
+//
+// Example:
+//    profile := firefox.NewProfile().
+//        SetPreference("intl.accept_languages", "de-DE").
+//        AddExtension("extensions/some-extension.xpi").
+//        AddCertificates("certificates")
+//    capabilities := agouti.NewCapabilities().Browser("firefox")
+//    if err := profile.Apply(capabilities); err != nil {
+//        return err
+//    }
+//    page, err := driver.NewPage(agouti.Desired(capabilities))
+package firefox
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/sclevine/agouti"
+)
+
+// The NSS database files that contain the certificates trusted by Firefox.
+var certificateFiles = []string{"cert9.db", "key4.db", "pkcs11.txt"}
+
+// A Profile is a Firefox profile directory that is built in memory. All
+// methods called on a Profile modify the original instance. Files are not
+// read until the profile is encoded, so that each method may be chained.
+type Profile struct {
+	prefs          map[string]interface{}
+	extensions     []string
+	certificateDir string
+}
+
+// NewProfile returns an empty Profile.
+func NewProfile() *Profile {
+	return &Profile{prefs: map[string]interface{}{}}
+}
+
+// SetPreference sets the about:config preference with the provided name to
+// the provided string, boolean, or integer value. Preferences are written
+// to the user.js file of the profile, which Firefox applies on every start.
+func (p *Profile) SetPreference(name string, value interface{}) *Profile {
+	p.prefs[name] = value
+	return p
+}
+
+// AddExtension installs the packed WebExtension (.xpi) at the provided path.
+// The extension must specify its ID in the browser_specific_settings.gecko.id
+// (or applications.gecko.id) key of its manifest.json. Release versions of
+// Firefox only install signed extensions.
+func (p *Profile) AddExtension(path string) *Profile {
+	p.extensions = append(p.extensions, path)
+	return p
+}
+
+// AddCertificates includes the NSS certificate database in the provided
+// directory, so that Firefox trusts the certificate authorities it contains,
+// ex. the CA of a proxy or of a staging environment. The database may be
+// created using the NSS certutil tool:
+//    certutil -N -d sql:certificates --empty-password
+//    certutil -A -d sql:certificates -n "Some CA" -t "C,," -i some-ca.pem
+func (p *Profile) AddCertificates(directory string) *Profile {
+	p.certificateDir = directory
+	return p
+}
+
+// Encode returns the profile as a base64-encoded zip, as expected by
+// geckodriver in the "profile" key of the "moz:firefoxOptions" capability.
+func (p *Profile) Encode() (string, error) {
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+
+	if err := writeFile(writer, "user.js", p.userJS()); err != nil {
+		return "", err
+	}
+
+	for _, path := range p.extensions {
+		extension, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read extension: %s", err)
+		}
+		id, err := extensionID(extension)
+		if err != nil {
+			return "", fmt.Errorf("failed to read extension %s: %s", path, err)
+		}
+		if err := writeFile(writer, "extensions/"+id+".xpi", extension); err != nil {
+			return "", err
+		}
+	}
+
+	if p.certificateDir != "" {
+		for _, name := range certificateFiles {
+			certificates, err := ioutil.ReadFile(filepath.Join(p.certificateDir, name))
+			if os.IsNotExist(err) && name == "pkcs11.txt" {
+				continue
+			}
+			if err != nil {
+				return "", fmt.Errorf("failed to read certificates: %s", err)
+			}
+			if err := writeFile(writer, name, certificates); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to encode profile: %s", err)
+	}
+	return base64.StdEncoding.EncodeToString(archive.Bytes()), nil
+}
+
+// Apply encodes the profile and sets it as the Firefox profile of the
+// provided capabilities.
+func (p *Profile) Apply(capabilities agouti.Capabilities) error {
+	profile, err := p.Encode()
+	if err != nil {
+		return err
+	}
+	capabilities.Firefox(agouti.FirefoxOptions{Profile: profile})
+	return nil
+}
+
+func (p *Profile) userJS() []byte {
+	var names []string
+	for name := range p.prefs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var userJS bytes.Buffer
+	for _, name := range names {
+		nameJSON, _ := json.Marshal(name)
+		valueJSON, err := json.Marshal(p.prefs[name])
+		if err != nil {
+			valueJSON, _ = json.Marshal(fmt.Sprint(p.prefs[name]))
+		}
+		fmt.Fprintf(&userJS, "user_pref(%s, %s);\n", nameJSON, valueJSON)
+	}
+	return userJS.Bytes()
+}
+
+func writeFile(writer *zip.Writer, name string, contents []byte) error {
+	file, err := writer.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to encode profile: %s", err)
+	}
+	if _, err := file.Write(contents); err != nil {
+		return fmt.Errorf("failed to encode profile: %s", err)
+	}
+	return nil
+}
+
+func extensionID(extension []byte) (string, error) {
+	reader, err := zip.NewReader(bytes.NewReader(extension), int64(len(extension)))
+	if err != nil {
+		return "", err
+	}
+
+	for _, file := range reader.File {
+		if file.Name != "manifest.json" {
+			continue
+		}
+		manifestFile, err := file.Open()
+		if err != nil {
+			return "", err
+		}
+		defer manifestFile.Close()
+
+		var manifest struct {
+			BrowserSpecificSettings struct {
+				Gecko struct {
+					ID string `json:"id"`
+				} `json:"gecko"`
+			} `json:"browser_specific_settings"`
+			Applications struct {
+				Gecko struct {
+					ID string `json:"id"`
+				} `json:"gecko"`
+			} `json:"applications"`
+		}
+		if err := json.NewDecoder(manifestFile).Decode(&manifest); err != nil {
+			return "", fmt.Errorf("invalid manifest.json: %s", err)
+		}
+		if id := manifest.BrowserSpecificSettings.Gecko.ID; id != "" {
+			return id, nil
+		}
+		if id := manifest.Applications.Gecko.ID; id != "" {
+			return id, nil
+		}
+		return "", errors.New("manifest.json does not specify an extension ID")
+	}
+	return "", errors.New("manifest.json not found")
+}
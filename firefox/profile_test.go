@@ -0,0 +1,151 @@
+package firefox_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti"
+	. "github.com/sclevine/agouti/firefox"
+)
+
+var _ = Describe("Profile", func() {
+	var (
+		profile   *Profile
+		directory string
+	)
+
+	BeforeEach(func() {
+		profile = NewProfile()
+		var err error
+		directory, err = ioutil.TempDir("", "agouti")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+	})
+
+	writeExtension := func(name, manifest string) string {
+		var extension bytes.Buffer
+		writer := zip.NewWriter(&extension)
+		if manifest != "" {
+			file, err := writer.Create("manifest.json")
+			Expect(err).NotTo(HaveOccurred())
+			file.Write([]byte(manifest))
+		}
+		Expect(writer.Close()).To(Succeed())
+		path := filepath.Join(directory, name)
+		Expect(ioutil.WriteFile(path, extension.Bytes(), 0644)).To(Succeed())
+		return path
+	}
+
+	decode := func(encoded string) map[string]string {
+		archive, err := base64.StdEncoding.DecodeString(encoded)
+		Expect(err).NotTo(HaveOccurred())
+		reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		Expect(err).NotTo(HaveOccurred())
+		files := map[string]string{}
+		for _, file := range reader.File {
+			contents, err := file.Open()
+			Expect(err).NotTo(HaveOccurred())
+			data, err := ioutil.ReadAll(contents)
+			Expect(err).NotTo(HaveOccurred())
+			contents.Close()
+			files[file.Name] = string(data)
+		}
+		return files
+	}
+
+	Describe("#Encode", func() {
+		It("should write the preferences to user.js in name order", func() {
+			profile.SetPreference("some.string", `some "value"`).
+				SetPreference("some.bool", true).
+				SetPreference("some.int", 2)
+			encoded, err := profile.Encode()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decode(encoded)).To(Equal(map[string]string{
+				"user.js": `user_pref("some.bool", true);` + "\n" +
+					`user_pref("some.int", 2);` + "\n" +
+					`user_pref("some.string", "some \"value\"");` + "\n",
+			}))
+		})
+
+		It("should install extensions under their manifest IDs", func() {
+			profile.AddExtension(writeExtension("first.xpi", `{"browser_specific_settings": {"gecko": {"id": "first@example.com"}}}`))
+			profile.AddExtension(writeExtension("second.xpi", `{"applications": {"gecko": {"id": "second@example.com"}}}`))
+			encoded, err := profile.Encode()
+			Expect(err).NotTo(HaveOccurred())
+			files := decode(encoded)
+			Expect(files).To(HaveKey("extensions/first@example.com.xpi"))
+			Expect(files).To(HaveKey("extensions/second@example.com.xpi"))
+		})
+
+		It("should include the certificate database", func() {
+			Expect(ioutil.WriteFile(filepath.Join(directory, "cert9.db"), []byte("some-certificates"), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(directory, "key4.db"), []byte("some-keys"), 0644)).To(Succeed())
+			encoded, err := profile.AddCertificates(directory).Encode()
+			Expect(err).NotTo(HaveOccurred())
+			files := decode(encoded)
+			Expect(files).To(HaveKeyWithValue("cert9.db", "some-certificates"))
+			Expect(files).To(HaveKeyWithValue("key4.db", "some-keys"))
+			Expect(files).NotTo(HaveKey("pkcs11.txt"))
+		})
+
+		Context("when an extension cannot be read", func() {
+			It("should return an error", func() {
+				_, err := profile.AddExtension(filepath.Join(directory, "missing.xpi")).Encode()
+				Expect(err).To(MatchError(ContainSubstring("failed to read extension: ")))
+			})
+		})
+
+		Context("when an extension does not specify an ID", func() {
+			It("should return an error", func() {
+				path := writeExtension("some.xpi", `{"name": "some-extension"}`)
+				_, err := profile.AddExtension(path).Encode()
+				Expect(err).To(MatchError("failed to read extension " + path + ": manifest.json does not specify an extension ID"))
+			})
+		})
+
+		Context("when an extension does not have a manifest", func() {
+			It("should return an error", func() {
+				path := writeExtension("some.xpi", "")
+				_, err := profile.AddExtension(path).Encode()
+				Expect(err).To(MatchError("failed to read extension " + path + ": manifest.json not found"))
+			})
+		})
+
+		Context("when the certificate database is incomplete", func() {
+			It("should return an error", func() {
+				Expect(ioutil.WriteFile(filepath.Join(directory, "cert9.db"), []byte("some-certificates"), 0644)).To(Succeed())
+				_, err := profile.AddCertificates(directory).Encode()
+				Expect(err).To(MatchError(ContainSubstring("failed to read certificates: ")))
+			})
+		})
+	})
+
+	Describe("#Apply", func() {
+		It("should set the encoded profile in the Firefox options of the capabilities", func() {
+			capabilities := agouti.NewCapabilities().Headless()
+			Expect(profile.SetPreference("some.pref", true).Apply(capabilities)).To(Succeed())
+
+			firefoxOptions := capabilities["moz:firefoxOptions"].(map[string]interface{})
+			Expect(firefoxOptions["args"]).To(Equal([]interface{}{"-headless"}))
+			Expect(decode(firefoxOptions["profile"].(string))).To(HaveKeyWithValue("user.js", `user_pref("some.pref", true);`+"\n"))
+		})
+
+		Context("when the profile cannot be encoded", func() {
+			It("should return an error", func() {
+				capabilities := agouti.NewCapabilities()
+				err := profile.AddExtension(filepath.Join(directory, "missing.xpi")).Apply(capabilities)
+				Expect(err).To(MatchError(ContainSubstring("failed to read extension: ")))
+				Expect(capabilities).NotTo(HaveKey("moz:firefoxOptions"))
+			})
+		})
+	})
+})
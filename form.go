@@ -0,0 +1,155 @@
+package agouti
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+type formField struct {
+	name  string
+	value interface{}
+}
+
+// FillForm fills the fields of the provided form selection with the provided
+// values. Each field is found within the form by its name attribute, and is
+// filled according to its type:
+//   - <select> elements have the option with the value as its text selected
+//   - checkboxes are checked if the value is true and unchecked if it is false
+//   - the radio button with the value as its value attribute is chosen
+//   - file inputs have the file with the value as its filename uploaded
+//   - all other fields are filled with the value as text
+//
+// The values may be a map with string keys, in which case the fields are
+// filled in the order of their names, or a struct (or pointer to a struct),
+// in which case the fields are filled in the order of the struct fields.
+// Struct fields are matched to form fields using their name or their
+// `agouti:"field-name"` tag. Fields tagged with `agouti:"-"` and unexported
+// fields are ignored.
+//
+// Example:
+//    err := page.FillForm(page.Find("#signup"), map[string]interface{}{
+//        "email":   "user@example.com",
+//        "country": "Canada",
+//        "terms":   true,
+//    })
+func (p *Page) FillForm(form interface{}, values interface{}) error {
+	selection, err := toSelection(form)
+	if err != nil {
+		return fmt.Errorf("failed to fill form: %s", err)
+	}
+
+	fields, err := formFields(values)
+	if err != nil {
+		return fmt.Errorf("failed to fill form: %s", err)
+	}
+
+	for _, field := range fields {
+		if err := selection.fillField(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SubmitForm fills the provided form selection with the provided values, as
+// described by FillForm, and then submits the form.
+func (p *Page) SubmitForm(form interface{}, values interface{}) error {
+	if err := p.FillForm(form, values); err != nil {
+		return err
+	}
+
+	selection, _ := toSelection(form)
+	return selection.Submit()
+}
+
+func formFields(values interface{}) ([]formField, error) {
+	value := reflect.ValueOf(values)
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	var fields []formField
+	switch value.Kind() {
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil, errors.New("map of values must have string keys")
+		}
+		for _, key := range value.MapKeys() {
+			fields = append(fields, formField{key.String(), value.MapIndex(key).Interface()})
+		}
+		sort.Slice(fields, func(i, j int) bool { return fields[i].name < fields[j].name })
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			structField := value.Type().Field(i)
+			name := structField.Tag.Get("agouti")
+			if structField.PkgPath != "" || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = structField.Name
+			}
+			fields = append(fields, formField{name, value.Field(i).Interface()})
+		}
+	default:
+		return nil, errors.New("values must be a map or a struct")
+	}
+	return fields, nil
+}
+
+func (s *Selection) fillField(field formField) error {
+	fieldSelection := s.AllByName(field.name)
+	elements, err := fieldSelection.elements.GetAtLeastOne()
+	if err != nil {
+		return selectionError("failed to select elements from %s: %s", fieldSelection, err)
+	}
+
+	tagName, err := elements[0].GetName()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve tag name of %s: %s", fieldSelection, err)
+	}
+	fieldType, err := elements[0].GetAttribute("type")
+	if err != nil {
+		return fmt.Errorf("failed to retrieve type attribute of %s: %s", fieldSelection, err)
+	}
+
+	text := fmt.Sprint(field.value)
+	switch {
+	case tagName == "select":
+		return fieldSelection.Select(text)
+	case fieldType == "checkbox":
+		checked, ok := field.value.(bool)
+		if !ok {
+			return fmt.Errorf("value for %s must be a bool", fieldSelection)
+		}
+		if checked {
+			return fieldSelection.Check()
+		}
+		return fieldSelection.Uncheck()
+	case fieldType == "radio":
+		return fieldSelection.choose(text)
+	case fieldType == "file":
+		return fieldSelection.UploadFile(text)
+	}
+	return fieldSelection.Fill(text)
+}
+
+func (s *MultiSelection) choose(value string) error {
+	chosen := false
+	err := s.ForEach(func(radio *Selection) error {
+		radioValue, err := radio.Attribute("value")
+		if err != nil || radioValue != value {
+			return err
+		}
+		chosen = true
+		return radio.Click()
+	})
+	if err != nil {
+		return err
+	}
+	if !chosen {
+		return fmt.Errorf(`no radio button with value "%s" found for %s`, value, s)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package agouti_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+)
+
+// A formBus responds to element commands for a form with a text field named
+// "email", a checkbox named "terms", a select named "country", and two radio
+// buttons named "plan".
+type formBus struct {
+	requests []string
+}
+
+var formResults = map[string]string{
+	"elements":                        `[{"ELEMENT": "form"}]`,
+	"element/email/name":              `"input"`,
+	"element/email/attribute/type":    `"text"`,
+	"element/terms/name":              `"input"`,
+	"element/terms/attribute/type":    `"checkbox"`,
+	"element/terms/selected":          `false`,
+	"element/country/name":            `"select"`,
+	"element/country/attribute/type":  `"select-one"`,
+	"element/country/elements":        `[{"ELEMENT": "canada"}]`,
+	"element/basic/name":              `"input"`,
+	"element/basic/attribute/type":    `"radio"`,
+	"element/basic/attribute/value":   `"basic"`,
+	"element/premium/attribute/value": `"premium"`,
+}
+
+func (b *formBus) Send(method, endpoint string, body, result interface{}) error {
+	bodyJSON, _ := json.Marshal(body)
+	b.requests = append(b.requests, method+" "+endpoint)
+
+	response := formResults[endpoint]
+	if endpoint == "element/form/elements" {
+		for _, name := range []string{"email", "terms", "country"} {
+			if strings.Contains(string(bodyJSON), name) {
+				response = `[{"ELEMENT": "` + name + `"}]`
+			}
+		}
+		if strings.Contains(string(bodyJSON), "plan") {
+			response = `[{"ELEMENT": "basic"}, {"ELEMENT": "premium"}]`
+		}
+	}
+	if result != nil && response != "" {
+		json.Unmarshal([]byte(response), result)
+	}
+	return nil
+}
+
+var _ = Describe("Forms", func() {
+	var (
+		bus  *formBus
+		page *Page
+	)
+
+	BeforeEach(func() {
+		bus = &formBus{}
+		page = NewTestPage(&api.Session{Bus: bus})
+	})
+
+	Describe("#FillForm", func() {
+		It("should fill each field according to its type", func() {
+			Expect(page.FillForm(page.Find("form"), map[string]interface{}{
+				"terms":   true,
+				"email":   "user@example.com",
+				"country": "Canada",
+				"plan":    "premium",
+			})).To(Succeed())
+			Expect(bus.requests).To(ContainElement("POST element/email/value"))
+			Expect(bus.requests).To(ContainElement("POST element/terms/click"))
+			Expect(bus.requests).To(ContainElement("POST element/canada/click"))
+			Expect(bus.requests).To(ContainElement("POST element/premium/click"))
+			Expect(bus.requests).NotTo(ContainElement("POST element/basic/click"))
+		})
+
+		It("should fill fields from a struct using their tags", func() {
+			values := struct {
+				Email    string `agouti:"email"`
+				Terms    bool   `agouti:"terms"`
+				Internal string `agouti:"-"`
+			}{"user@example.com", false, "ignored"}
+			Expect(page.FillForm(page.Find("form"), &values)).To(Succeed())
+			Expect(bus.requests).To(ContainElement("POST element/email/value"))
+			Expect(bus.requests).NotTo(ContainElement("POST element/terms/click"))
+		})
+
+		Context("when a checkbox value is not a bool", func() {
+			It("should return an error", func() {
+				err := page.FillForm(page.Find("form"), map[string]string{"terms": "yes"})
+				Expect(err).To(MatchError(`value for selection 'CSS: form [single] | Name: "terms"' must be a bool`))
+			})
+		})
+
+		Context("when no radio button has the provided value", func() {
+			It("should return an error", func() {
+				err := page.FillForm(page.Find("form"), map[string]string{"plan": "enterprise"})
+				Expect(err).To(MatchError(`no radio button with value "enterprise" found for selection 'CSS: form [single] | Name: "plan"'`))
+			})
+		})
+
+		Context("when the values are not a map or struct", func() {
+			It("should return an error", func() {
+				err := page.FillForm(page.Find("form"), []string{"email"})
+				Expect(err).To(MatchError("failed to fill form: values must be a map or a struct"))
+			})
+		})
+
+		Context("when the form is not a selection", func() {
+			It("should return an error", func() {
+				err := page.FillForm("form", map[string]string{})
+				Expect(err).To(MatchError("failed to fill form: must be *Selection or *MultiSelection"))
+			})
+		})
+	})
+
+	Describe("#SubmitForm", func() {
+		It("should fill and then submit the form", func() {
+			Expect(page.SubmitForm(page.Find("form"), map[string]string{"email": "user@example.com"})).To(Succeed())
+			Expect(bus.requests[len(bus.requests)-1]).To(Equal("POST element/form/submit"))
+		})
+	})
+})
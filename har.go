@@ -0,0 +1,410 @@
+package agouti
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sclevine/agouti/har"
+)
+
+// StartHAR begins recording the network traffic of the page as a HAR page
+// with the provided name. Traffic is read from the Chrome performance log, so
+// the page must have been opened in Chrome with the PerformanceLogging Option.
+// Any recording that was already in progress is discarded. Traffic continues
+// to be recorded while a trace or screencast reads the performance log.
+func (p *Page) StartHAR(name string) error {
+	logs, err := p.performanceLog.subscribe(p.session)
+	if err != nil {
+		return fmt.Errorf("failed to start HAR capture: %s", err)
+	}
+	if p.harRecording != nil {
+		p.harRecording.logs.close()
+	}
+	p.harRecording = newHARRecording(name, logs)
+	return nil
+}
+
+// StopHAR stops the recording started by StartHAR and returns every request
+// made by the page since StartHAR was called. Response bodies are not recorded.
+//
+// Example:
+//    page.StartHAR("checkout")
+//    ... interact with the page ...
+//    archive, err := page.StopHAR()
+//    archive.Save("checkout.har")
+func (p *Page) StopHAR() (*har.HAR, error) {
+	if p.harRecording == nil {
+		return nil, errors.New("failed to stop HAR capture: HAR capture was not started")
+	}
+
+	recording := p.harRecording
+	logs, err := recording.logs.read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop HAR capture: %s", err)
+	}
+
+	recording.logs.close()
+	p.harRecording = nil
+	for _, log := range logs {
+		recording.record(log.Message)
+	}
+	return recording.archive(), nil
+}
+
+type harRecording struct {
+	name          string
+	logs          *performanceSubscription
+	started       time.Time
+	entries       []*harEntry
+	requests      map[string]*harEntry
+	contentLoaded float64
+	loaded        float64
+}
+
+type harEntry struct {
+	entry  har.Entry
+	start  float64
+	end    float64
+	timing *networkTiming
+}
+
+func newHARRecording(name string, logs *performanceSubscription) *harRecording {
+	return &harRecording{name: name, logs: logs, started: time.Now(), requests: map[string]*harEntry{}}
+}
+
+type networkEvent struct {
+	Message struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	} `json:"message"`
+}
+
+// Timestamps are in seconds on a monotonic clock, and wall times are in
+// seconds since the epoch.
+type networkParams struct {
+	RequestID         string           `json:"requestId"`
+	Timestamp         float64          `json:"timestamp"`
+	WallTime          float64          `json:"wallTime"`
+	Request           *networkRequest  `json:"request"`
+	Response          *networkResponse `json:"response"`
+	RedirectResponse  *networkResponse `json:"redirectResponse"`
+	EncodedDataLength float64          `json:"encodedDataLength"`
+	ErrorText         string           `json:"errorText"`
+}
+
+type networkRequest struct {
+	URL      string                 `json:"url"`
+	Method   string                 `json:"method"`
+	Headers  map[string]interface{} `json:"headers"`
+	PostData string                 `json:"postData"`
+}
+
+type networkResponse struct {
+	Status            int                    `json:"status"`
+	StatusText        string                 `json:"statusText"`
+	Headers           map[string]interface{} `json:"headers"`
+	MimeType          string                 `json:"mimeType"`
+	Protocol          string                 `json:"protocol"`
+	RemoteIPAddress   string                 `json:"remoteIPAddress"`
+	EncodedDataLength float64                `json:"encodedDataLength"`
+	Timing            *networkTiming         `json:"timing"`
+}
+
+// Request times are in seconds, and all other times are in milliseconds
+// relative to the request time, or -1 if the phase did not occur.
+type networkTiming struct {
+	RequestTime       float64 `json:"requestTime"`
+	DNSStart          float64 `json:"dnsStart"`
+	DNSEnd            float64 `json:"dnsEnd"`
+	ConnectStart      float64 `json:"connectStart"`
+	ConnectEnd        float64 `json:"connectEnd"`
+	SSLStart          float64 `json:"sslStart"`
+	SSLEnd            float64 `json:"sslEnd"`
+	SendStart         float64 `json:"sendStart"`
+	SendEnd           float64 `json:"sendEnd"`
+	ReceiveHeadersEnd float64 `json:"receiveHeadersEnd"`
+}
+
+// Performance log messages that are not network or page events are ignored.
+func (r *harRecording) record(message string) {
+	var event networkEvent
+	if err := json.Unmarshal([]byte(message), &event); err != nil {
+		return
+	}
+	var params networkParams
+	if err := json.Unmarshal(event.Message.Params, &params); err != nil {
+		return
+	}
+
+	entry := r.requests[params.RequestID]
+	switch event.Message.Method {
+	case "Network.requestWillBeSent":
+		if params.Request == nil {
+			return
+		}
+		if entry != nil && params.RedirectResponse != nil {
+			entry.respond(params.RedirectResponse)
+			entry.finish(params.Timestamp, params.RedirectResponse.EncodedDataLength)
+		}
+		r.begin(&params)
+	case "Network.responseReceived":
+		if entry != nil && params.Response != nil {
+			entry.respond(params.Response)
+		}
+	case "Network.loadingFinished":
+		if entry != nil {
+			entry.finish(params.Timestamp, params.EncodedDataLength)
+		}
+	case "Network.loadingFailed":
+		if entry != nil {
+			entry.entry.Comment = params.ErrorText
+			entry.finish(params.Timestamp, -1)
+		}
+	case "Page.domContentEventFired":
+		r.contentLoaded = params.Timestamp
+	case "Page.loadEventFired":
+		r.loaded = params.Timestamp
+	}
+}
+
+func (r *harRecording) begin(params *networkParams) {
+	request := params.Request
+	startedDateTime := r.started
+	if params.WallTime > 0 {
+		seconds, fraction := math.Modf(params.WallTime)
+		startedDateTime = time.Unix(int64(seconds), int64(fraction*float64(time.Second)))
+	}
+
+	harRequest := har.Request{
+		Method:      request.Method,
+		URL:         request.URL,
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     harCookies((&http.Request{Header: httpHeader(request.Headers)}).Cookies()),
+		Headers:     harHeaders(request.Headers),
+		QueryString: []har.NameValuePair{},
+		HeadersSize: -1,
+		BodySize:    int64(len(request.PostData)),
+	}
+	if requestURL, err := url.Parse(request.URL); err == nil {
+		harRequest.QueryString = harValues(requestURL.Query())
+	}
+	if request.PostData != "" {
+		harRequest.PostData = &har.PostData{
+			MimeType: httpHeader(request.Headers).Get("Content-Type"),
+			Text:     request.PostData,
+		}
+	}
+
+	entry := &harEntry{
+		entry: har.Entry{
+			PageRef:         r.name,
+			StartedDateTime: startedDateTime,
+			Request:         harRequest,
+			Response: har.Response{
+				Cookies:     []har.Cookie{},
+				Headers:     []har.NameValuePair{},
+				HeadersSize: -1,
+				BodySize:    -1,
+				Content:     har.Content{Size: -1},
+			},
+		},
+		start: params.Timestamp,
+		end:   params.Timestamp,
+	}
+	r.entries = append(r.entries, entry)
+	r.requests[params.RequestID] = entry
+}
+
+func (e *harEntry) respond(response *networkResponse) {
+	header := httpHeader(response.Headers)
+	httpVersion := strings.ToUpper(response.Protocol)
+	if httpVersion == "" {
+		httpVersion = "HTTP/1.1"
+	}
+
+	e.entry.Request.HTTPVersion = httpVersion
+	e.entry.Response = har.Response{
+		Status:      response.Status,
+		StatusText:  response.StatusText,
+		HTTPVersion: httpVersion,
+		Cookies:     harCookies((&http.Response{Header: header}).Cookies()),
+		Headers:     harHeaders(response.Headers),
+		Content:     har.Content{Size: -1, MimeType: response.MimeType},
+		RedirectURL: header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+	e.entry.ServerIPAddress = strings.Trim(response.RemoteIPAddress, "[]")
+	e.timing = response.Timing
+}
+
+func (e *harEntry) finish(timestamp, encodedDataLength float64) {
+	e.end = timestamp
+	if encodedDataLength >= 0 {
+		e.entry.Response.BodySize = int64(encodedDataLength)
+		e.entry.Response.Content.Size = int64(encodedDataLength)
+	}
+}
+
+func (r *harRecording) archive() *har.HAR {
+	page := har.Page{
+		StartedDateTime: r.started,
+		ID:              r.name,
+		Title:           r.name,
+		PageTimings:     har.PageTimings{OnContentLoad: -1, OnLoad: -1},
+	}
+
+	entries := []har.Entry{}
+	for _, entry := range r.entries {
+		entry.entry.Timings = entry.timings()
+		entry.entry.Time = totalTime(entry.entry.Timings)
+		entries = append(entries, entry.entry)
+	}
+
+	if len(r.entries) > 0 {
+		first := r.entries[0]
+		page.StartedDateTime = first.entry.StartedDateTime
+		if r.contentLoaded > 0 {
+			page.PageTimings.OnContentLoad = milliseconds(r.contentLoaded - first.start)
+		}
+		if r.loaded > 0 {
+			page.PageTimings.OnLoad = milliseconds(r.loaded - first.start)
+		}
+	}
+
+	return &har.HAR{Log: har.Log{
+		Version: "1.2",
+		Creator: har.Creator{Name: "agouti"},
+		Pages:   []har.Page{page},
+		Entries: entries,
+	}}
+}
+
+// Responses served from the cache have no timing, so their entire duration
+// is reported as time spent receiving them.
+func (e *harEntry) timings() har.Timings {
+	timing := e.timing
+	if timing == nil {
+		return har.Timings{
+			Blocked: -1,
+			DNS:     -1,
+			Connect: -1,
+			SSL:     -1,
+			Receive: nonNegative(milliseconds(e.end - e.start)),
+		}
+	}
+
+	blocked := milliseconds(timing.RequestTime - e.start)
+	for _, phaseStart := range []float64{timing.DNSStart, timing.ConnectStart, timing.SendStart} {
+		if phaseStart >= 0 {
+			blocked += phaseStart
+			break
+		}
+	}
+
+	return har.Timings{
+		Blocked: nonNegative(blocked),
+		DNS:     phase(timing.DNSStart, timing.DNSEnd),
+		Connect: phase(timing.ConnectStart, timing.ConnectEnd),
+		SSL:     phase(timing.SSLStart, timing.SSLEnd),
+		Send:    nonNegative(timing.SendEnd - timing.SendStart),
+		Wait:    nonNegative(timing.ReceiveHeadersEnd - timing.SendEnd),
+		Receive: nonNegative(milliseconds(e.end-timing.RequestTime) - timing.ReceiveHeadersEnd),
+	}
+}
+
+// SSL is excluded, as HAR includes it in the connect time.
+func totalTime(timings har.Timings) float64 {
+	var total float64
+	for _, phaseTime := range []float64{timings.Blocked, timings.DNS, timings.Connect, timings.Send, timings.Wait, timings.Receive} {
+		if phaseTime > 0 {
+			total += phaseTime
+		}
+	}
+	return total
+}
+
+func phase(start, end float64) float64 {
+	if start < 0 {
+		return -1
+	}
+	return nonNegative(end - start)
+}
+
+func nonNegative(value float64) float64 {
+	return math.Max(value, 0)
+}
+
+func milliseconds(seconds float64) float64 {
+	return seconds * 1000
+}
+
+// DevTools joins repeated headers with newlines.
+func httpHeader(headers map[string]interface{}) http.Header {
+	header := http.Header{}
+	for name, value := range headers {
+		for _, line := range strings.Split(fmt.Sprint(value), "\n") {
+			header.Add(name, line)
+		}
+	}
+	return header
+}
+
+func harHeaders(headers map[string]interface{}) []har.NameValuePair {
+	var names []string
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := []har.NameValuePair{}
+	for _, name := range names {
+		for _, line := range strings.Split(fmt.Sprint(headers[name]), "\n") {
+			pairs = append(pairs, har.NameValuePair{Name: name, Value: line})
+		}
+	}
+	return pairs
+}
+
+func harValues(values url.Values) []har.NameValuePair {
+	var names []string
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := []har.NameValuePair{}
+	for _, name := range names {
+		for _, value := range values[name] {
+			pairs = append(pairs, har.NameValuePair{Name: name, Value: value})
+		}
+	}
+	return pairs
+}
+
+func harCookies(cookies []*http.Cookie) []har.Cookie {
+	harCookies := []har.Cookie{}
+	for _, cookie := range cookies {
+		harCookie := har.Cookie{
+			Name:     cookie.Name,
+			Value:    cookie.Value,
+			Path:     cookie.Path,
+			Domain:   cookie.Domain,
+			HTTPOnly: cookie.HttpOnly,
+			Secure:   cookie.Secure,
+		}
+		if !cookie.Expires.IsZero() {
+			expires := cookie.Expires
+			harCookie.Expires = &expires
+		}
+		harCookies = append(harCookies, harCookie)
+	}
+	return harCookies
+}
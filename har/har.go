@@ -0,0 +1,143 @@
+// Package har provides the types of the HTTP Archive (HAR) 1.2 format, which
+// is used by *agouti.Page.StopHAR to report the network traffic of a page.
+// HAR files may be opened with the network panel of most browsers.
+// See: http://www.softwareishard.com/blog/har-12-spec/
+package har
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"time"
+)
+
+// HAR is the root of an HTTP Archive.
+type HAR struct {
+	Log Log `json:"log"`
+}
+
+// Save writes the archive to the provided file as indented JSON.
+func (h *HAR) Save(filename string) error {
+	harJSON, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, harJSON, 0644)
+}
+
+// Log contains the pages and requests recorded in an archive.
+type Log struct {
+	Version string  `json:"version"`
+	Creator Creator `json:"creator"`
+	Pages   []Page  `json:"pages"`
+	Entries []Entry `json:"entries"`
+	Comment string  `json:"comment,omitempty"`
+}
+
+// Creator identifies the application that recorded an archive.
+type Creator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Page describes a page that requests were made from.
+type Page struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	ID              string      `json:"id"`
+	Title           string      `json:"title"`
+	PageTimings     PageTimings `json:"pageTimings"`
+}
+
+// PageTimings contains the times in milliseconds, relative to the start of
+// the page, that the page fired its DOMContentLoaded and load events. Times
+// that are unknown are -1.
+type PageTimings struct {
+	OnContentLoad float64 `json:"onContentLoad"`
+	OnLoad        float64 `json:"onLoad"`
+}
+
+// Entry describes a single request and its response. Time is the total time
+// of the request in milliseconds.
+type Entry struct {
+	PageRef         string    `json:"pageref,omitempty"`
+	StartedDateTime time.Time `json:"startedDateTime"`
+	Time            float64   `json:"time"`
+	Request         Request   `json:"request"`
+	Response        Response  `json:"response"`
+	Cache           Cache     `json:"cache"`
+	Timings         Timings   `json:"timings"`
+	ServerIPAddress string    `json:"serverIPAddress,omitempty"`
+	Comment         string    `json:"comment,omitempty"`
+}
+
+// Request describes an HTTP request. Sizes that are unknown are -1.
+type Request struct {
+	Method      string          `json:"method"`
+	URL         string          `json:"url"`
+	HTTPVersion string          `json:"httpVersion"`
+	Cookies     []Cookie        `json:"cookies"`
+	Headers     []NameValuePair `json:"headers"`
+	QueryString []NameValuePair `json:"queryString"`
+	PostData    *PostData       `json:"postData,omitempty"`
+	HeadersSize int64           `json:"headersSize"`
+	BodySize    int64           `json:"bodySize"`
+}
+
+// Response describes an HTTP response. Sizes that are unknown are -1.
+type Response struct {
+	Status      int             `json:"status"`
+	StatusText  string          `json:"statusText"`
+	HTTPVersion string          `json:"httpVersion"`
+	Cookies     []Cookie        `json:"cookies"`
+	Headers     []NameValuePair `json:"headers"`
+	Content     Content         `json:"content"`
+	RedirectURL string          `json:"redirectURL"`
+	HeadersSize int64           `json:"headersSize"`
+	BodySize    int64           `json:"bodySize"`
+}
+
+// NameValuePair is a single header or query string parameter.
+type NameValuePair struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Cookie describes a cookie sent with a request or set by a response.
+type Cookie struct {
+	Name     string     `json:"name"`
+	Value    string     `json:"value"`
+	Path     string     `json:"path,omitempty"`
+	Domain   string     `json:"domain,omitempty"`
+	Expires  *time.Time `json:"expires,omitempty"`
+	HTTPOnly bool       `json:"httpOnly,omitempty"`
+	Secure   bool       `json:"secure,omitempty"`
+}
+
+// PostData describes the body of a request.
+type PostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// Content describes the body of a response.
+type Content struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// Cache describes the browser cache entries used by a request.
+type Cache struct{}
+
+// Timings contains the time in milliseconds spent in each phase of a request.
+// Blocked, DNS, Connect, and SSL are -1 when the phase did not occur. SSL is
+// included in Connect.
+type Timings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
@@ -0,0 +1,13 @@
+package har_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestHAR(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HAR Suite")
+}
@@ -0,0 +1,69 @@
+package har_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/har"
+)
+
+var _ = Describe("HAR", func() {
+	var archive *har.HAR
+
+	BeforeEach(func() {
+		archive = &har.HAR{Log: har.Log{
+			Version: "1.2",
+			Creator: har.Creator{Name: "agouti"},
+			Pages: []har.Page{{
+				StartedDateTime: time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC),
+				ID:              "some page",
+				Title:           "some page",
+				PageTimings:     har.PageTimings{OnContentLoad: -1, OnLoad: -1},
+			}},
+			Entries: []har.Entry{},
+		}}
+	})
+
+	Describe("#Save", func() {
+		var directory string
+
+		BeforeEach(func() {
+			var err error
+			directory, err = ioutil.TempDir("", "agouti-har")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(directory)
+		})
+
+		It("should write the archive to the provided file as HAR JSON", func() {
+			filename := filepath.Join(directory, "some.har")
+			Expect(archive.Save(filename)).To(Succeed())
+			harJSON, err := ioutil.ReadFile(filename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(harJSON).To(MatchJSON(`{"log": {
+				"version": "1.2",
+				"creator": {"name": "agouti", "version": ""},
+				"pages": [{
+					"startedDateTime": "2015-06-01T12:00:00Z",
+					"id": "some page",
+					"title": "some page",
+					"pageTimings": {"onContentLoad": -1, "onLoad": -1}
+				}],
+				"entries": []
+			}}`))
+		})
+
+		Context("when the file cannot be written", func() {
+			It("should return an error", func() {
+				err := archive.Save(filepath.Join(directory, "missing", "some.har"))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
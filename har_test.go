@@ -0,0 +1,203 @@
+package agouti_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/har"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+func performanceLog(method, params string) api.Log {
+	return api.Log{Message: `{"message": {"method": "` + method + `", "params": ` + params + `}, "webview": "some-webview"}`}
+}
+
+var _ = Describe("HAR Capture", func() {
+	var (
+		page    *Page
+		session *mocks.Session
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		page = NewTestPage(session)
+	})
+
+	Describe("#StartHAR", func() {
+		It("should discard existing performance logs", func() {
+			Expect(page.StartHAR("some page")).To(Succeed())
+			Expect(session.NewLogsCall.LogType).To(Equal("performance"))
+		})
+
+		Context("when the session fails to retrieve logs", func() {
+			It("should return an error", func() {
+				session.NewLogsCall.Err = errors.New("some error")
+				Expect(page.StartHAR("some page")).To(MatchError("failed to start HAR capture: some error"))
+			})
+		})
+	})
+
+	Describe("#StopHAR", func() {
+		var archive *har.HAR
+
+		BeforeEach(func() {
+			Expect(page.StartHAR("some page")).To(Succeed())
+			session.NewLogsCall.ReturnLogs = []api.Log{
+				performanceLog("Network.requestWillBeSent", `{
+					"requestId": "1", "timestamp": 100, "wallTime": 1433160000.5,
+					"request": {
+						"url": "http://example.com/login?next=%2Fhome&lang=en",
+						"method": "POST",
+						"headers": {"Content-Type": "application/x-www-form-urlencoded", "Cookie": "session=abc"},
+						"postData": "user=bob"
+					}
+				}`),
+				performanceLog("Network.requestWillBeSent", `{
+					"requestId": "1", "timestamp": 100.2, "wallTime": 1433160000.7,
+					"request": {"url": "http://example.com/home", "method": "GET", "headers": {}},
+					"redirectResponse": {
+						"status": 302, "statusText": "Found", "protocol": "http/1.1",
+						"headers": {"Location": "/home", "Set-Cookie": "session=def; Path=/\nlang=en"},
+						"mimeType": "text/html", "encodedDataLength": 120
+					}
+				}`),
+				performanceLog("Network.responseReceived", `{
+					"requestId": "1", "timestamp": 100.5,
+					"response": {
+						"status": 200, "statusText": "OK", "protocol": "h2",
+						"headers": {"Content-Type": "text/html"}, "mimeType": "text/html",
+						"remoteIPAddress": "[::1]",
+						"timing": {
+							"requestTime": 100.21, "dnsStart": 0, "dnsEnd": 10, "connectStart": 10, "connectEnd": 40,
+							"sslStart": 20, "sslEnd": 40, "sendStart": 40, "sendEnd": 50, "receiveHeadersEnd": 250
+						}
+					}
+				}`),
+				performanceLog("Network.loadingFinished", `{"requestId": "1", "timestamp": 100.51, "encodedDataLength": 2048}`),
+				performanceLog("Network.requestWillBeSent", `{
+					"requestId": "2", "timestamp": 100.6, "wallTime": 1433160001.1,
+					"request": {"url": "http://example.com/missing.png", "method": "GET", "headers": {}}
+				}`),
+				performanceLog("Network.loadingFailed", `{"requestId": "2", "timestamp": 100.7, "errorText": "net::ERR_CONNECTION_REFUSED"}`),
+				performanceLog("Page.domContentEventFired", `{"timestamp": 100.8}`),
+				performanceLog("Page.loadEventFired", `{"timestamp": 101}`),
+				{Message: "some unrelated message"},
+			}
+
+			var err error
+			archive, err = page.StopHAR()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should read new performance logs from the session", func() {
+			Expect(session.NewLogsCall.LogType).To(Equal("performance"))
+		})
+
+		It("should return a HAR 1.2 archive with a single named page", func() {
+			Expect(archive.Log.Version).To(Equal("1.2"))
+			Expect(archive.Log.Creator.Name).To(Equal("agouti"))
+			Expect(archive.Log.Pages).To(HaveLen(1))
+			Expect(archive.Log.Pages[0].ID).To(Equal("some page"))
+			Expect(archive.Log.Pages[0].StartedDateTime).To(Equal(time.Unix(1433160000, 5e8)))
+			Expect(archive.Log.Pages[0].PageTimings.OnContentLoad).To(BeNumerically("~", 800, 0.001))
+			Expect(archive.Log.Pages[0].PageTimings.OnLoad).To(BeNumerically("~", 1000, 0.001))
+		})
+
+		It("should record an entry for each request, including redirects", func() {
+			Expect(archive.Log.Entries).To(HaveLen(3))
+			for _, entry := range archive.Log.Entries {
+				Expect(entry.PageRef).To(Equal("some page"))
+			}
+		})
+
+		It("should record the request and response of redirected requests", func() {
+			entry := archive.Log.Entries[0]
+			Expect(entry.StartedDateTime).To(Equal(time.Unix(1433160000, 5e8)))
+			Expect(entry.Request.Method).To(Equal("POST"))
+			Expect(entry.Request.URL).To(Equal("http://example.com/login?next=%2Fhome&lang=en"))
+			Expect(entry.Request.HTTPVersion).To(Equal("HTTP/1.1"))
+			Expect(entry.Request.Headers).To(Equal([]har.NameValuePair{
+				{Name: "Content-Type", Value: "application/x-www-form-urlencoded"},
+				{Name: "Cookie", Value: "session=abc"},
+			}))
+			Expect(entry.Request.QueryString).To(Equal([]har.NameValuePair{
+				{Name: "lang", Value: "en"},
+				{Name: "next", Value: "/home"},
+			}))
+			Expect(entry.Request.Cookies).To(Equal([]har.Cookie{{Name: "session", Value: "abc"}}))
+			Expect(entry.Request.PostData).To(Equal(&har.PostData{MimeType: "application/x-www-form-urlencoded", Text: "user=bob"}))
+			Expect(entry.Request.BodySize).To(Equal(int64(8)))
+			Expect(entry.Response.Status).To(Equal(302))
+			Expect(entry.Response.RedirectURL).To(Equal("/home"))
+			Expect(entry.Response.Cookies).To(Equal([]har.Cookie{
+				{Name: "session", Value: "def", Path: "/"},
+				{Name: "lang", Value: "en"},
+			}))
+			Expect(entry.Response.BodySize).To(Equal(int64(120)))
+			Expect(entry.Time).To(BeNumerically("~", 200, 0.001))
+		})
+
+		It("should record the timings of completed requests", func() {
+			entry := archive.Log.Entries[1]
+			Expect(entry.Request.URL).To(Equal("http://example.com/home"))
+			Expect(entry.Request.HTTPVersion).To(Equal("H2"))
+			Expect(entry.Response.Status).To(Equal(200))
+			Expect(entry.Response.Content).To(Equal(har.Content{Size: 2048, MimeType: "text/html"}))
+			Expect(entry.ServerIPAddress).To(Equal("::1"))
+			Expect(entry.Timings.Blocked).To(BeNumerically("~", 10, 0.001))
+			Expect(entry.Timings.DNS).To(BeNumerically("~", 10, 0.001))
+			Expect(entry.Timings.Connect).To(BeNumerically("~", 30, 0.001))
+			Expect(entry.Timings.SSL).To(BeNumerically("~", 20, 0.001))
+			Expect(entry.Timings.Send).To(BeNumerically("~", 10, 0.001))
+			Expect(entry.Timings.Wait).To(BeNumerically("~", 200, 0.001))
+			Expect(entry.Timings.Receive).To(BeNumerically("~", 50, 0.001))
+			Expect(entry.Time).To(BeNumerically("~", 310, 0.001))
+		})
+
+		It("should record failed requests with their error", func() {
+			entry := archive.Log.Entries[2]
+			Expect(entry.Request.URL).To(Equal("http://example.com/missing.png"))
+			Expect(entry.Response.Status).To(Equal(0))
+			Expect(entry.Comment).To(Equal("net::ERR_CONNECTION_REFUSED"))
+			Expect(entry.Timings.DNS).To(Equal(-1.0))
+			Expect(entry.Time).To(BeNumerically("~", 100, 0.001))
+		})
+
+		It("should stop recording", func() {
+			_, err := page.StopHAR()
+			Expect(err).To(MatchError("failed to stop HAR capture: HAR capture was not started"))
+		})
+
+		Context("when the session fails to retrieve logs", func() {
+			It("should return an error", func() {
+				Expect(page.StartHAR("some page")).To(Succeed())
+				session.NewLogsCall.Err = errors.New("some error")
+				_, err := page.StopHAR()
+				Expect(err).To(MatchError("failed to stop HAR capture: some error"))
+			})
+		})
+	})
+
+	Context("when the performance log is read by tracing during the recording", func() {
+		It("should record the traffic that was read", func() {
+			Expect(page.StartHAR("some page")).To(Succeed())
+			session.NewLogsCall.ReturnLogs = []api.Log{
+				performanceLog("Network.requestWillBeSent", `{
+					"requestId": "1", "timestamp": 100, "wallTime": 1433160000.5,
+					"request": {"url": "http://example.com/", "method": "GET", "headers": {}}
+				}`),
+			}
+			Expect(page.StartTracing()).To(Succeed())
+			session.NewLogsCall.ReturnLogs = nil
+
+			archive, err := page.StopHAR()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(archive.Log.Entries).To(HaveLen(1))
+			Expect(archive.Log.Entries[0].Request.URL).To(Equal("http://example.com/"))
+		})
+	})
+})
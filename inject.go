@@ -0,0 +1,35 @@
+package agouti
+
+import "fmt"
+
+// InjectScriptOnLoad runs the provided JavaScript in the current document,
+// and in every document that the page loads afterwards before the scripts
+// of the document are run, so that test helpers such as custom finders and
+// event recorders remain available after the application navigates. Helpers
+// should be assigned to properties of window, as variables declared by the
+// script are not global when it is run in the current document.
+//
+// When the browser does not support init scripts (see
+// *api.Session.AddInitScript), the script is instead run after each document
+// loaded by Navigate, Back, Forward, Refresh, or Reset is loaded, and is not
+// run in documents loaded by the application itself.
+//
+// Example:
+//    page.InjectScriptOnLoad(`window.clicks = []; document.addEventListener("click", function(e) { window.clicks.push(e.target.id); }, true);`)
+func (p *Page) InjectScriptOnLoad(script string) error {
+	if err := p.session.Execute(script, nil, nil); err != nil {
+		return fmt.Errorf("failed to inject script: %s", err)
+	}
+	if err := p.session.AddInitScript(script); err != nil {
+		p.loadScripts = append(p.loadScripts, script)
+	}
+	return nil
+}
+
+// Failures to run scripts after navigating are not fatal, as the document
+// may not allow scripts, ex. if it is not HTML.
+func (p *Page) runLoadScripts() {
+	for _, script := range p.loadScripts {
+		p.session.Execute(script, nil, nil)
+	}
+}
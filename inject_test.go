@@ -0,0 +1,58 @@
+package agouti_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Script Injection", func() {
+	var (
+		page    *Page
+		session *mocks.Session
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		page = NewTestPage(session)
+	})
+
+	Describe("#InjectScriptOnLoad", func() {
+		It("should run the script in the current document and add it to new documents", func() {
+			Expect(page.InjectScriptOnLoad("window.helper = 1;")).To(Succeed())
+			Expect(session.ExecuteCall.Body).To(Equal("window.helper = 1;"))
+			Expect(session.AddInitScriptCall.Script).To(Equal("window.helper = 1;"))
+		})
+
+		It("should not run the script after navigating", func() {
+			Expect(page.InjectScriptOnLoad("window.helper = 1;")).To(Succeed())
+			session.ExecuteCall.Body = ""
+			Expect(page.Navigate("http://example.com")).To(Succeed())
+			Expect(session.ExecuteCall.Body).To(BeEmpty())
+		})
+
+		Context("when the browser does not support init scripts", func() {
+			It("should run the script after each navigation", func() {
+				session.AddInitScriptCall.Err = errors.New("some error")
+				Expect(page.InjectScriptOnLoad("window.helper = 1;")).To(Succeed())
+				session.ExecuteCall.Body = ""
+				Expect(page.Navigate("http://example.com")).To(Succeed())
+				Expect(session.ExecuteCall.Body).To(Equal("window.helper = 1;"))
+				session.ExecuteCall.Body = ""
+				Expect(page.Refresh()).To(Succeed())
+				Expect(session.ExecuteCall.Body).To(Equal("window.helper = 1;"))
+			})
+		})
+
+		Context("when the script cannot be run in the current document", func() {
+			It("should return an error without adding it to new documents", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(page.InjectScriptOnLoad("window.helper = 1;")).To(MatchError("failed to inject script: some error"))
+				Expect(session.AddInitScriptCall.Script).To(BeEmpty())
+			})
+		})
+	})
+})
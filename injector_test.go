@@ -1,22 +1,45 @@
 package agouti
 
-import "github.com/sclevine/agouti/internal/target"
+import (
+	"github.com/sclevine/agouti/internal/element"
+	"github.com/sclevine/agouti/internal/target"
+)
 
 func NewTestSelection(session apiSession, elements elementRepository, firstSelector string) *Selection {
 	selector := target.Selector{Type: target.CSS, Value: firstSelector, Single: true}
-	return &Selection{selectable{session, target.Selectors{selector}}, elements}
+	return &Selection{selectable{session, target.Selectors{selector}, 0, "data-testid", nil}, elements}
 }
 
 func NewTestMultiSelection(session apiSession, elements elementRepository, firstSelector string) *MultiSelection {
 	selector := target.Selector{Type: target.CSS, Value: firstSelector}
-	selection := Selection{selectable{session, target.Selectors{selector}}, elements}
+	selection := Selection{selectable{session, target.Selectors{selector}, 0, "data-testid", nil}, elements}
 	return &MultiSelection{selection}
 }
 
 func NewTestPage(session apiSession) *Page {
-	return &Page{selectable{session, nil}, nil}
+	return &Page{selectable: selectable{session, nil, 0, "data-testid", nil}}
+}
+
+func NewTestPageWithDownloadDirectory(session apiSession, directory string) *Page {
+	return &Page{selectable: selectable{session, nil, 0, "data-testid", nil}, downloadDirectory: directory}
+}
+
+func NewTestPageWithDriverOutput(session apiSession, output func() []byte) *Page {
+	return &Page{selectable: selectable{session, nil, 0, "data-testid", nil}, driverOutput: output}
+}
+
+func NewTestPageWithElementCache(session apiSession) *Page {
+	return &Page{selectable: selectable{session, nil, 0, "data-testid", element.NewCache()}}
+}
+
+func NewTestPageCollectingJSErrors(session apiSession) *Page {
+	page := NewTestPage(session)
+	page.startCollectingJSErrors()
+	return page
 }
 
 func NewTestConfig() *config {
 	return &config{}
 }
+
+var SingleSession Option = singleSession
@@ -0,0 +1,95 @@
+package agouti
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/sclevine/agouti/internal/proxy"
+)
+
+// A RequestMatcher reports whether an intercepted request should be handled.
+type RequestMatcher func(request *http.Request) bool
+
+// MatchURL returns a RequestMatcher that matches requests with URLs that match
+// the provided regular expression. MatchURL panics if the expression is invalid.
+func MatchURL(expression string) RequestMatcher {
+	pattern := regexp.MustCompile(expression)
+	return func(request *http.Request) bool {
+		return pattern.MatchString(request.URL.String())
+	}
+}
+
+// MatchAllRequests is a RequestMatcher that matches every request.
+var MatchAllRequests RequestMatcher = func(*http.Request) bool {
+	return true
+}
+
+// InterceptRequests routes HTTP requests made by the page that match the
+// provided matcher to the provided handler instead of their destinations.
+// Handlers added later take precedence over handlers added earlier. The page
+// must have been opened with the RequestInterception Option.
+//
+// Examples:
+//    page.InterceptRequests(agouti.MatchURL(`/api/users$`), agouti.FulfillRequest(200, "application/json", `[]`))
+// Responds to requests for users with an empty list.
+//    page.InterceptRequests(agouti.MatchURL(`\.png$`), agouti.BlockRequest)
+// Blocks all PNG images.
+//    page.InterceptRequests(agouti.MatchAllRequests, agouti.DelayRequest(time.Second, nil))
+// Delays every request by one second.
+//    page.InterceptRequests(agouti.MatchURL(`/api/`), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//        r.Header.Set("X-Test", "true")
+//        agouti.ForwardRequest.ServeHTTP(w, r)
+//    }))
+// Adds a header to every API request.
+func (p *Page) InterceptRequests(matcher RequestMatcher, handler http.Handler) error {
+	if p.interceptor == nil {
+		return errors.New("failed to intercept requests: page was not opened with the RequestInterception Option")
+	}
+	p.interceptor.Handle(matcher, handler)
+	return nil
+}
+
+// StopInterceptingRequests removes all handlers added by InterceptRequests,
+// so that every request is sent to its destination.
+func (p *Page) StopInterceptingRequests() error {
+	if p.interceptor == nil {
+		return errors.New("failed to stop intercepting requests: page was not opened with the RequestInterception Option")
+	}
+	p.interceptor.Reset()
+	return nil
+}
+
+// ForwardRequest is a handler that sends intercepted requests to their
+// destinations. It may be used by other handlers to send modified requests.
+var ForwardRequest http.Handler = http.HandlerFunc(proxy.Forward)
+
+// BlockRequest is a handler that causes intercepted requests to fail with a
+// network error.
+var BlockRequest http.Handler = http.HandlerFunc(proxy.Abort)
+
+// FulfillRequest returns a handler that responds to intercepted requests with
+// the provided status code, content type, and body.
+func FulfillRequest(status int, contentType, body string) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, _ *http.Request) {
+		if contentType != "" {
+			response.Header().Set("Content-Type", contentType)
+		}
+		response.WriteHeader(status)
+		response.Write([]byte(body))
+	})
+}
+
+// DelayRequest returns a handler that waits for the provided delay before
+// passing intercepted requests to the provided handler. If the handler is nil,
+// the requests are sent to their destinations.
+func DelayRequest(delay time.Duration, handler http.Handler) http.Handler {
+	if handler == nil {
+		handler = ForwardRequest
+	}
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		time.Sleep(delay)
+		handler.ServeHTTP(response, request)
+	})
+}
@@ -0,0 +1,141 @@
+package agouti_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Request Interception", func() {
+	Describe(".MatchURL", func() {
+		It("should match requests with URLs that match the expression", func() {
+			request, _ := http.NewRequest("GET", "http://example.com/api/users?page=2", nil)
+			Expect(MatchURL(`/api/users\?`)(request)).To(BeTrue())
+			Expect(MatchURL(`/api/posts`)(request)).To(BeFalse())
+		})
+	})
+
+	Describe(".FulfillRequest", func() {
+		It("should respond with the provided status, content type, and body", func() {
+			response := httptest.NewRecorder()
+			FulfillRequest(201, "application/json", `{"some": "body"}`).ServeHTTP(response, nil)
+			Expect(response.Code).To(Equal(201))
+			Expect(response.Header().Get("Content-Type")).To(Equal("application/json"))
+			Expect(response.Body.String()).To(Equal(`{"some": "body"}`))
+		})
+	})
+
+	Describe(".DelayRequest", func() {
+		It("should delay the request before passing it to the provided handler", func() {
+			response := httptest.NewRecorder()
+			start := time.Now()
+			DelayRequest(100*time.Millisecond, FulfillRequest(200, "", "some body")).ServeHTTP(response, nil)
+			Expect(time.Since(start)).To(BeNumerically(">=", 100*time.Millisecond))
+			Expect(response.Body.String()).To(Equal("some body"))
+		})
+	})
+
+	Context("when the page was not opened with the RequestInterception Option", func() {
+		var page *Page
+
+		BeforeEach(func() {
+			page = NewTestPage(&mocks.Session{})
+		})
+
+		It("should fail to intercept requests", func() {
+			err := page.InterceptRequests(MatchAllRequests, BlockRequest)
+			Expect(err).To(MatchError("failed to intercept requests: page was not opened with the RequestInterception Option"))
+		})
+
+		It("should fail to stop intercepting requests", func() {
+			err := page.StopInterceptingRequests()
+			Expect(err).To(MatchError("failed to stop intercepting requests: page was not opened with the RequestInterception Option"))
+		})
+	})
+
+	Context("when the page was opened with the RequestInterception Option", func() {
+		var (
+			driver       *httptest.Server
+			app          *httptest.Server
+			capabilities map[string]interface{}
+			page         *Page
+			client       *http.Client
+		)
+
+		BeforeEach(func() {
+			driver = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				if request.Method == "POST" && request.URL.Path == "/session" {
+					var body struct {
+						DesiredCapabilities map[string]interface{} `json:"desiredCapabilities"`
+					}
+					json.NewDecoder(request.Body).Decode(&body)
+					capabilities = body.DesiredCapabilities
+					response.Write([]byte(`{"sessionId": "some-id", "value": {}}`))
+					return
+				}
+				response.Write([]byte(`{"value": null}`))
+			}))
+			app = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				response.Write([]byte("some app response"))
+			}))
+
+			var err error
+			page, err = NewPage(driver.URL, RequestInterception)
+			Expect(err).NotTo(HaveOccurred())
+
+			proxyAddress := capabilities["proxy"].(map[string]interface{})["httpProxy"].(string)
+			proxyURL, _ := url.Parse("http://" + proxyAddress)
+			client = &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+		})
+
+		AfterEach(func() {
+			page.Destroy()
+			driver.Close()
+			app.Close()
+		})
+
+		get := func(url string) string {
+			response, err := client.Get(url)
+			Expect(err).NotTo(HaveOccurred())
+			defer response.Body.Close()
+			body, _ := ioutil.ReadAll(response.Body)
+			return string(body)
+		}
+
+		It("should configure the browser to use a proxy", func() {
+			Expect(capabilities["proxy"]).To(HaveKeyWithValue("proxyType", "manual"))
+			chromeOptions := capabilities["goog:chromeOptions"].(map[string]interface{})
+			Expect(chromeOptions["args"]).To(ContainElement("--proxy-bypass-list=<-loopback>"))
+		})
+
+		It("should forward requests that are not intercepted", func() {
+			Expect(get(app.URL)).To(Equal("some app response"))
+		})
+
+		It("should handle intercepted requests", func() {
+			Expect(page.InterceptRequests(MatchURL(`/stubbed$`), FulfillRequest(200, "text/plain", "some stub"))).To(Succeed())
+			Expect(get(app.URL + "/stubbed")).To(Equal("some stub"))
+			Expect(get(app.URL + "/other")).To(Equal("some app response"))
+		})
+
+		It("should block intercepted requests", func() {
+			Expect(page.InterceptRequests(MatchAllRequests, BlockRequest)).To(Succeed())
+			_, err := client.Get(app.URL)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should stop intercepting requests", func() {
+			Expect(page.InterceptRequests(MatchAllRequests, FulfillRequest(200, "", "some stub"))).To(Succeed())
+			Expect(page.StopInterceptingRequests()).To(Succeed())
+			Expect(get(app.URL)).To(Equal("some app response"))
+		})
+	})
+})
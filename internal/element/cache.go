@@ -0,0 +1,37 @@
+package element
+
+import "sync"
+
+// A Cache stores the elements that selections were resolved to, keyed by
+// their selectors, until it is invalidated. A nil *Cache stores nothing.
+type Cache struct {
+	mutex    sync.Mutex
+	elements map[string][]Element
+}
+
+func NewCache() *Cache {
+	return &Cache{elements: map[string][]Element{}}
+}
+
+// Invalidate discards all cached elements.
+func (c *Cache) Invalidate() {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.elements = map[string][]Element{}
+}
+
+func (c *Cache) load(key string) ([]Element, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	elements, ok := c.elements[key]
+	return elements, ok
+}
+
+func (c *Cache) store(key string, elements []Element) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.elements[key] = elements
+}
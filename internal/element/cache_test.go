@@ -0,0 +1,139 @@
+package element_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/api"
+	. "github.com/sclevine/agouti/internal/element"
+	"github.com/sclevine/agouti/internal/target"
+)
+
+type frameClient struct {
+	*staleClient
+	frames int
+}
+
+func (c *frameClient) Frame(frame *api.Element) error {
+	c.frames++
+	return nil
+}
+
+var _ = Describe("Element cache", func() {
+	var (
+		bus        *commandBus
+		client     *staleClient
+		cache      *Cache
+		repository *Repository
+		other      *Repository
+	)
+
+	BeforeEach(func() {
+		bus = &commandBus{}
+		client = &staleClient{bus: bus, count: 1}
+		cache = NewCache()
+		repository = &Repository{
+			Client:    client,
+			Selectors: target.Selectors{{Type: target.CSS, Value: "#parent"}},
+			Cache:     cache,
+		}
+		other = &Repository{
+			Client:    client,
+			Selectors: target.Selectors{{Type: target.CSS, Value: "#other"}},
+			Cache:     cache,
+		}
+	})
+
+	Describe("#Get", func() {
+		It("should only retrieve the elements of the selection once", func() {
+			first, err := repository.Get()
+			Expect(err).NotTo(HaveOccurred())
+			second, err := repository.Get()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second).To(Equal(first))
+			Expect(client.retrievals).To(Equal(1))
+		})
+
+		It("should share elements between repositories with the same selectors", func() {
+			Expect(repository.Get()).To(HaveLen(1))
+			identical := &Repository{Client: client, Selectors: repository.Selectors, Cache: cache}
+			Expect(identical.Get()).To(HaveLen(1))
+			Expect(client.retrievals).To(Equal(1))
+		})
+
+		It("should not cache errors", func() {
+			client.retrieveErr = []error{errors.New("some error")}
+			_, err := repository.Get()
+			Expect(err).To(MatchError("some error"))
+			Expect(repository.Get()).To(HaveLen(1))
+			Expect(client.retrievals).To(Equal(2))
+		})
+
+		It("should retrieve the elements again after the cache is invalidated", func() {
+			Expect(repository.Get()).To(HaveLen(1))
+			cache.Invalidate()
+			elements, err := repository.Get()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(elements[0].GetID()).To(Equal("b0"))
+		})
+
+		Context("when the selection is within a frame", func() {
+			It("should not cache the elements, and should invalidate the cache", func() {
+				framed := &frameClient{staleClient: client}
+				repository.Client = framed
+				Expect(repository.Get()).To(HaveLen(1))
+
+				frame := &Repository{
+					Client:    framed,
+					Selectors: repository.Selectors.Append(target.Frame, "").Single(),
+					Cache:     cache,
+				}
+				Expect(frame.Get()).To(HaveLen(1))
+				Expect(frame.Get()).To(HaveLen(1))
+				Expect(framed.frames).To(Equal(4))
+
+				Expect(repository.Get()).To(HaveLen(1))
+				Expect(client.retrievals).To(Equal(6))
+			})
+		})
+
+		Context("when a cached element is stale", func() {
+			It("should re-resolve and cache the selection when retrying a command", func() {
+				repository.StaleRetries = 1
+				elements, err := repository.Get()
+				Expect(err).NotTo(HaveOccurred())
+
+				bus.errs = []error{&api.Error{Code: api.ErrorStaleElement}}
+				Expect(elements[0].Click()).To(Succeed())
+				Expect(bus.endpoints).To(Equal([]string{"element/a0/click", "element/b0/click"}))
+
+				elements, err = repository.Get()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(elements[0].GetID()).To(Equal("b0"))
+				Expect(client.retrievals).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("#Reload", func() {
+		It("should retrieve the elements of the selection and of other selections again", func() {
+			Expect(repository.Get()).To(HaveLen(1))
+			Expect(other.Get()).To(HaveLen(1))
+			Expect(client.retrievals).To(Equal(2))
+
+			elements, err := repository.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(elements[0].GetID()).To(Equal("c0"))
+			Expect(other.Get()).To(HaveLen(1))
+			Expect(client.retrievals).To(Equal(4))
+		})
+	})
+
+	Describe("a nil *Cache", func() {
+		It("should not panic when invalidated", func() {
+			var nilCache *Cache
+			Expect(nilCache.Invalidate).NotTo(Panic())
+		})
+	})
+})
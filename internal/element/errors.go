@@ -0,0 +1,23 @@
+package element
+
+// A NotFoundError indicates that a selection does not refer to the element it
+// selects, because no element matches its selector, its index is out of
+// range, or it refers to no elements at all. Unlike other errors, it usually only means that the element does not
+// exist yet, or no longer exists.
+type NotFoundError string
+
+func (e NotFoundError) Error() string {
+	return string(e)
+}
+
+const (
+	ErrNotFound        NotFoundError = "element not found"
+	ErrIndexOutOfRange NotFoundError = "element index out of range"
+	ErrNoElements      NotFoundError = "no elements found"
+)
+
+// IsNotFound reports whether the provided error is a NotFoundError.
+func IsNotFound(err error) bool {
+	_, ok := err.(NotFoundError)
+	return ok
+}
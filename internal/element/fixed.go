@@ -0,0 +1,25 @@
+package element
+
+// A Fixed repository refers to a single element that was already retrieved,
+// ex. while iterating over the elements of a selection. The element is not
+// re-resolved, though commands sent to it are still retried if it was
+// retrieved by a Repository with StaleRetries.
+type Fixed struct {
+	Element Element
+}
+
+func (f *Fixed) Get() ([]Element, error) {
+	return []Element{f.Element}, nil
+}
+
+func (f *Fixed) GetAtLeastOne() ([]Element, error) {
+	return []Element{f.Element}, nil
+}
+
+func (f *Fixed) GetExactlyOne() (Element, error) {
+	return f.Element, nil
+}
+
+func (f *Fixed) Reload() ([]Element, error) {
+	return []Element{f.Element}, nil
+}
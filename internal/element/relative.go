@@ -0,0 +1,102 @@
+package element
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/target"
+)
+
+// NearDistance is the maximum distance in pixels between the edges of an
+// element and an element that it is near.
+const NearDistance = 50
+
+type relativeElement struct {
+	element  Element
+	distance float64
+}
+
+// Relative elements are ordered by the distance between their centers and
+// the center of the anchor element, so that the closest element is first.
+func retrieveRelativeElements(client Client, anchors []Element, selector target.Selector) ([]Element, error) {
+	candidates, err := client.GetElements(selector.API())
+	if err != nil {
+		return nil, err
+	}
+
+	rects := make([]api.Rect, len(candidates))
+	for index, candidate := range candidates {
+		if rects[index], err = candidate.GetRect(); err != nil {
+			return nil, err
+		}
+	}
+
+	elements := []Element{}
+	for _, anchor := range anchors {
+		anchorRect, err := anchor.GetRect()
+		if err != nil {
+			return nil, err
+		}
+
+		var related []relativeElement
+		for index, candidate := range candidates {
+			if candidate.GetID() == anchor.GetID() || !isRelated(selector.Type, anchorRect, rects[index]) {
+				continue
+			}
+			related = append(related, relativeElement{candidate, centerDistance(anchorRect, rects[index])})
+		}
+		sort.SliceStable(related, func(i, j int) bool {
+			return related[i].distance < related[j].distance
+		})
+		for _, element := range related {
+			elements = append(elements, element.element)
+		}
+	}
+	return selectElements(selector, elements)
+}
+
+func isRelated(relation target.Type, anchor, candidate api.Rect) bool {
+	switch relation {
+	case target.Above:
+		return candidate.Y+candidate.Height <= anchor.Y
+	case target.Below:
+		return candidate.Y >= anchor.Y+anchor.Height
+	case target.LeftOf:
+		return candidate.X+candidate.Width <= anchor.X
+	case target.RightOf:
+		return candidate.X >= anchor.X+anchor.Width
+	case target.Near:
+		return edgeDistance(anchor, candidate) <= NearDistance
+	}
+	return false
+}
+
+func edgeDistance(a, b api.Rect) float64 {
+	dx := math.Max(0, math.Max(a.X-(b.X+b.Width), b.X-(a.X+a.Width)))
+	dy := math.Max(0, math.Max(a.Y-(b.Y+b.Height), b.Y-(a.Y+a.Height)))
+	return math.Hypot(dx, dy)
+}
+
+func centerDistance(a, b api.Rect) float64 {
+	dx := (a.X + a.Width/2) - (b.X + b.Width/2)
+	dy := (a.Y + a.Height/2) - (b.Y + b.Height/2)
+	return math.Hypot(dx, dy)
+}
+
+// selectElements applies single-element-only and indexed selectors to
+// elements that were filtered after they were retrieved.
+func selectElements(selector target.Selector, elements []Element) ([]Element, error) {
+	switch {
+	case selector.Single && len(elements) == 0:
+		return nil, ErrNotFound
+	case selector.Single && len(elements) > 1:
+		return nil, errors.New("ambiguous find")
+	case selector.Indexed && selector.Index >= len(elements):
+		return nil, ErrIndexOutOfRange
+	case selector.Indexed:
+		return []Element{elements[selector.Index]}, nil
+	}
+	return elements, nil
+}
@@ -3,6 +3,7 @@ package element
 import (
 	"errors"
 	"fmt"
+	"regexp"
 
 	"github.com/sclevine/agouti/api"
 	"github.com/sclevine/agouti/internal/target"
@@ -11,6 +12,15 @@ import (
 type Repository struct {
 	Client    Client
 	Selectors target.Selectors
+
+	// StaleRetries is the number of times that the selection is re-resolved
+	// when it or an element it refers to is no longer attached to the DOM.
+	StaleRetries int
+
+	// Cache stores the elements that the selection is resolved to, if
+	// non-nil, so that they are only retrieved again after the cache is
+	// invalidated or the selection is reloaded.
+	Cache *Cache
 }
 
 type Client interface {
@@ -18,6 +28,13 @@ type Client interface {
 	GetElements(selector api.Selector) ([]*api.Element, error)
 }
 
+// A FrameClient is a Client that can switch to a frame, or to the root frame
+// if the provided frame is nil.
+type FrameClient interface {
+	Client
+	Frame(frame *api.Element) error
+}
+
 type Element interface {
 	Client
 	GetID() string
@@ -32,8 +49,14 @@ type Element interface {
 	Click() error
 	Clear() error
 	Value(text string) error
+	SendKeys(text ...string) error
 	Submit() error
 	GetLocation() (x, y int, err error)
+	GetRect() (api.Rect, error)
+	GetElementsByScript(script string, arguments ...interface{}) ([]*api.Element, error)
+	Execute(body string, arguments []interface{}, result interface{}) error
+	GetScreenshot() ([]byte, error)
+	GetShadowRoot() (*api.ShadowRoot, error)
 }
 
 func (e *Repository) GetAtLeastOne() ([]Element, error) {
@@ -43,7 +66,7 @@ func (e *Repository) GetAtLeastOne() ([]Element, error) {
 	}
 
 	if len(elements) == 0 {
-		return nil, errors.New("no elements found")
+		return nil, ErrNoElements
 	}
 
 	return elements, nil
@@ -63,31 +86,143 @@ func (e *Repository) GetExactlyOne() (Element, error) {
 }
 
 func (e *Repository) Get() ([]Element, error) {
+	elements, err := e.cachedGet()
+	for retry := 0; retry < e.StaleRetries && api.IsStaleElement(err); retry++ {
+		elements, err = e.cachedGet()
+	}
+	if err != nil || e.StaleRetries == 0 {
+		return elements, err
+	}
+
+	retryElements := []Element{}
+	for index, element := range elements {
+		retryElements = append(retryElements, &retryElement{element, e, index})
+	}
+	return retryElements, nil
+}
+
+// Reload invalidates the cache, as any other cached elements may have changed
+// as well, and resolves the selection again.
+func (e *Repository) Reload() ([]Element, error) {
+	e.Cache.Invalidate()
+	return e.Get()
+}
+
+// Selections within frames are not cached, as their elements may only be used
+// after switching to their frame. As resolving them switches frames, the
+// elements of other selections are invalidated instead.
+func (e *Repository) cachedGet() ([]Element, error) {
+	if e.Cache == nil {
+		return e.get()
+	}
+	if e.Selectors.HasFrame() {
+		e.Cache.Invalidate()
+		return e.get()
+	}
+
+	key := e.Selectors.String()
+	if elements, ok := e.Cache.load(key); ok {
+		return elements, nil
+	}
+	elements, err := e.get()
+	if err == nil {
+		e.Cache.store(key, elements)
+	}
+	return elements, err
+}
+
+func (e *Repository) retrieveFirst() ([]Element, error) {
+	if first := e.Selectors[0]; first.Type == target.Element {
+		return []Element{first.Element}, nil
+	}
+	return retrieveElements(e.Client, e.Selectors[0])
+}
+
+func (e *Repository) get() ([]Element, error) {
 	if len(e.Selectors) == 0 {
 		return nil, errors.New("empty selection")
 	}
 
-	lastElements, err := retrieveElements(e.Client, e.Selectors[0])
+	if e.Selectors[0].Shadow {
+		return nil, errors.New("shadow root selection requires a parent selection")
+	}
+
+	if e.Selectors[0].Type.Relative() {
+		return nil, errors.New("relative selection requires an anchor selection")
+	}
+
+	if e.Selectors[0].Script() != "" {
+		return nil, errors.New("traversal requires a parent selection")
+	}
+
+	if e.Selectors[0].Type == target.Frame {
+		return nil, errors.New("frame selection requires a parent selection")
+	}
+
+	if e.Selectors.HasFrame() {
+		if err := e.switchToRootFrame(); err != nil {
+			return nil, err
+		}
+	}
+
+	lastElements, err := e.retrieveFirst()
 	if err != nil {
 		return nil, err
 	}
 
 	for _, selector := range e.Selectors[1:] {
+		if selector.Type.Relative() {
+			if lastElements, err = retrieveRelativeElements(e.Client, lastElements, selector); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if selector.Type == target.Frame {
+			if lastElements, err = e.retrieveFrameRoot(lastElements, selector); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if selector.Script() != "" {
+			if lastElements, err = retrieveScriptedElements(lastElements, selector); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
 		elements := []Element{}
 		for _, element := range lastElements {
-			subElements, err := retrieveElements(element, selector)
+			var client Client = element
+			if selector.Shadow {
+				shadowRoot, err := element.GetShadowRoot()
+				if err != nil {
+					return nil, err
+				}
+				client = shadowRoot
+			}
+
+			subElements, err := retrieveElements(client, selector)
 			if err != nil {
 				return nil, err
 			}
 
 			elements = append(elements, subElements...)
 		}
+		if selector.Type.Unique() {
+			elements = uniqueElements(elements)
+		}
 		lastElements = elements
 	}
 	return lastElements, nil
 }
 
 func retrieveElements(client Client, selector target.Selector) ([]Element, error) {
+	if selector.Type == target.TextMatching {
+		return retrieveMatchingElements(client, selector)
+	}
+
 	if selector.Single {
 		elements, err := client.GetElements(selector.API())
 		if err != nil {
@@ -95,7 +230,7 @@ func retrieveElements(client Client, selector target.Selector) ([]Element, error
 		}
 
 		if len(elements) == 0 {
-			return nil, errors.New("element not found")
+			return nil, ErrNotFound
 		} else if len(elements) > 1 {
 			return nil, errors.New("ambiguous find")
 		}
@@ -110,7 +245,7 @@ func retrieveElements(client Client, selector target.Selector) ([]Element, error
 		}
 
 		if selector.Index >= len(elements) {
-			return nil, errors.New("element index out of range")
+			return nil, ErrIndexOutOfRange
 		}
 
 		return []Element{Element(elements[selector.Index])}, nil
@@ -136,3 +271,85 @@ func retrieveElements(client Client, selector target.Selector) ([]Element, error
 
 	return newElements, nil
 }
+
+func retrieveMatchingElements(client Client, selector target.Selector) ([]Element, error) {
+	pattern, err := regexp.Compile(selector.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid text pattern: %s", err)
+	}
+
+	candidates, err := client.GetElements(selector.API())
+	if err != nil {
+		return nil, err
+	}
+
+	elements := []Element{}
+	for _, candidate := range candidates {
+		text, err := candidate.GetText()
+		if err != nil {
+			return nil, err
+		}
+		if pattern.MatchString(text) {
+			elements = append(elements, candidate)
+		}
+	}
+	return selectElements(selector, elements)
+}
+
+func retrieveScriptedElements(parents []Element, selector target.Selector) ([]Element, error) {
+	elements := []Element{}
+	for _, parent := range parents {
+		subElements, err := parent.GetElementsByScript(selector.Script(), selector.Value)
+		if err != nil {
+			return nil, err
+		}
+		for _, element := range subElements {
+			elements = append(elements, element)
+		}
+	}
+	if selector.Type.Unique() {
+		elements = uniqueElements(elements)
+	}
+	return selectElements(selector, elements)
+}
+
+func uniqueElements(elements []Element) []Element {
+	seen := map[string]bool{}
+	unique := []Element{}
+	for _, element := range elements {
+		if !seen[element.GetID()] {
+			seen[element.GetID()] = true
+			unique = append(unique, element)
+		}
+	}
+	return unique
+}
+
+func (e *Repository) switchToRootFrame() error {
+	client, ok := e.Client.(FrameClient)
+	if !ok {
+		return errors.New("frame selection is not supported by this client")
+	}
+	return client.Frame(nil)
+}
+
+// Frames are switched to using the session client, so the root element of
+// the frame document is retrieved using the session client as well.
+func (e *Repository) retrieveFrameRoot(frames []Element, selector target.Selector) ([]Element, error) {
+	if len(frames) != 1 {
+		return nil, fmt.Errorf("frame selection must refer to exactly one frame (%d)", len(frames))
+	}
+
+	client, ok := e.Client.(FrameClient)
+	if !ok {
+		return nil, errors.New("frame selection is not supported by this client")
+	}
+	frame, err := Unwrap(frames[0])
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Frame(frame); err != nil {
+		return nil, err
+	}
+	return retrieveElements(e.Client, selector)
+}
@@ -41,6 +41,7 @@ var _ = Describe("ElementRepository", func() {
 				client.GetElementsCall.ReturnElements = []*api.Element{}
 				_, err := repository.GetAtLeastOne()
 				Expect(err).To(MatchError("no elements found"))
+				Expect(IsNotFound(err)).To(BeTrue())
 			})
 		})
 
@@ -128,6 +129,15 @@ var _ = Describe("ElementRepository", func() {
 			})
 		})
 
+		Context("when the first selector is an Element selector", func() {
+			It("should retrieve the child elements of the provided element without finding it", func() {
+				repository.Selectors = append(target.ElementSelectors(firstParent), childSelector)
+				Expect(repository.Get()).To(Equal(children[:2]))
+				Expect(client.GetElementsCall.Selector).To(Equal(api.Selector{}))
+				Expect(firstParentBus.SendCall.BodyJSON).To(MatchJSON(childSelectorJSON))
+			})
+		})
+
 		Context("when a non-zero-indexed element is successfully retrieved", func() {
 			BeforeEach(func() {
 				parentSelector.Index = 1
@@ -179,6 +189,251 @@ var _ = Describe("ElementRepository", func() {
 			})
 		})
 
+		Context("when a child selector applies to shadow roots", func() {
+			BeforeEach(func() {
+				childSelector.Shadow = true
+				repository.Selectors = target.Selectors{parentSelector, childSelector}
+				firstParentBus.SendCall.Result = `{"shadow-6066-11e4-a52e-4f735466cecf": "some-root"}`
+				secondParentBus.SendCall.Result = firstParentBus.SendCall.Result
+			})
+
+			It("should retrieve the shadow root of each parent element and search within it", func() {
+				_, err := repository.Get()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(firstParentBus.SendCall.Endpoint).To(Equal("shadow/some-root/elements"))
+				Expect(firstParentBus.SendCall.BodyJSON).To(MatchJSON(childSelectorJSON))
+				Expect(secondParentBus.SendCall.Endpoint).To(Equal("shadow/some-root/elements"))
+			})
+
+			Context("when a parent element has no shadow root", func() {
+				It("should return an error", func() {
+					secondParentBus.SendCall.Err = errors.New("some error")
+					_, err := repository.Get()
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the selector matches text using a regular expression", func() {
+			var firstBus, secondBus *mocks.Bus
+
+			BeforeEach(func() {
+				firstBus, secondBus = &mocks.Bus{}, &mocks.Bus{}
+				firstBus.SendCall.Result = `"Total: 5"`
+				secondBus.SendCall.Result = `"Subtotal"`
+				client.GetElementsCall.ReturnElements = []*api.Element{
+					{ID: "first", Session: &api.Session{Bus: firstBus}},
+					{ID: "second", Session: &api.Session{Bus: secondBus}},
+				}
+				repository.Selectors = target.Selectors{{Type: target.TextMatching, Value: `^Total: \d+$`}}
+			})
+
+			It("should return only the elements with matching text", func() {
+				elements, err := repository.Get()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(elements).To(HaveLen(1))
+				Expect(elements[0].GetID()).To(Equal("first"))
+				Expect(firstBus.SendCall.Endpoint).To(Equal("element/first/text"))
+			})
+
+			It("should apply single-element-only and indexed selectors to the matching elements", func() {
+				repository.Selectors = target.Selectors{{Type: target.TextMatching, Value: "total", Single: true}}
+				_, err := repository.Get()
+				Expect(err).To(MatchError("element not found"))
+				repository.Selectors = target.Selectors{{Type: target.TextMatching, Value: "otal", Single: true}}
+				_, err = repository.Get()
+				Expect(err).To(MatchError("ambiguous find"))
+				repository.Selectors = target.Selectors{{Type: target.TextMatching, Value: "otal", Indexed: true, Index: 1}}
+				elements, err := repository.Get()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(elements[0].GetID()).To(Equal("second"))
+			})
+
+			Context("when the regular expression is invalid", func() {
+				It("should return an error", func() {
+					repository.Selectors = target.Selectors{{Type: target.TextMatching, Value: "("}}
+					_, err := repository.Get()
+					Expect(err).To(MatchError(HavePrefix("invalid text pattern: ")))
+				})
+			})
+
+			Context("when the text of an element cannot be retrieved", func() {
+				It("should return an error", func() {
+					secondBus.SendCall.Err = errors.New("some error")
+					_, err := repository.Get()
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when a selector selects elements relative to the preceding selection", func() {
+			var elements []*api.Element
+
+			BeforeEach(func() {
+				rects := []string{
+					`{"x": 0, "y": 0, "width": 100, "height": 20}`,
+					`{"x": 0, "y": 300, "width": 100, "height": 20}`,
+					`{"x": 0, "y": 100, "width": 100, "height": 20}`,
+					`{"x": 0, "y": 140, "width": 100, "height": 20}`,
+				}
+				elements = nil
+				for index, rect := range rects {
+					bus := &mocks.Bus{}
+					bus.SendCall.Result = rect
+					elements = append(elements, &api.Element{ID: string(rune('a' + index)), Session: &api.Session{Bus: bus}})
+				}
+				client.GetElementsCall.ReturnElements = elements
+				parentSelector = target.Selector{Type: target.CSS, Value: "parents", Indexed: true, Index: 2}
+			})
+
+			It("should return the related elements ordered from closest to farthest", func() {
+				repository.Selectors = target.Selectors{parentSelector, {Type: target.Below, Value: "children"}}
+				Expect(repository.Get()).To(Equal([]Element{elements[3], elements[1]}))
+				Expect(client.GetElementsCall.Selector).To(Equal(api.Selector{Using: "css selector", Value: "children"}))
+				repository.Selectors = target.Selectors{parentSelector, {Type: target.Above, Value: "children"}}
+				Expect(repository.Get()).To(Equal([]Element{elements[0]}))
+				repository.Selectors = target.Selectors{parentSelector, {Type: target.Near, Value: "children"}}
+				Expect(repository.Get()).To(Equal([]Element{elements[3]}))
+				repository.Selectors = target.Selectors{parentSelector, {Type: target.RightOf, Value: "children"}}
+				Expect(repository.Get()).To(BeEmpty())
+			})
+
+			It("should apply indexed selectors to the ordered elements", func() {
+				repository.Selectors = target.Selectors{parentSelector, {Type: target.Below, Value: "children", Indexed: true, Index: 0}}
+				Expect(repository.Get()).To(Equal([]Element{elements[3]}))
+				repository.Selectors = target.Selectors{parentSelector, {Type: target.LeftOf, Value: "children", Indexed: true, Index: 0}}
+				_, err := repository.Get()
+				Expect(err).To(MatchError("element index out of range"))
+			})
+
+			Context("when the relative selector is not preceded by an anchor selection", func() {
+				It("should return an error", func() {
+					repository.Selectors = target.Selectors{{Type: target.Near, Value: "children"}}
+					_, err := repository.Get()
+					Expect(err).To(MatchError("relative selection requires an anchor selection"))
+				})
+			})
+
+			Context("when the rect of an element cannot be retrieved", func() {
+				It("should return an error", func() {
+					elements[1].Session.Bus.(*mocks.Bus).SendCall.Err = errors.New("some error")
+					repository.Selectors = target.Selectors{parentSelector, {Type: target.Above, Value: "children"}}
+					_, err := repository.Get()
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when a selector selects the root element of a frame", func() {
+			var (
+				frame   *api.Element
+				rootBus *mocks.Bus
+			)
+
+			BeforeEach(func() {
+				rootBus = &mocks.Bus{}
+				frame = &api.Element{ID: "frame", Session: &api.Session{Bus: rootBus}}
+				client.GetElementsCall.ReturnElements = []*api.Element{frame}
+				rootBus.SendCall.Result = `[{"ELEMENT": "button"}]`
+				repository.Selectors = target.Selectors{
+					{Type: target.CSS, Value: "iframe", Single: true},
+					{Type: target.Frame, Single: true},
+					{Type: target.CSS, Value: "button"},
+				}
+			})
+
+			It("should switch to the frame and retrieve elements within its document", func() {
+				elements, err := repository.Get()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(elements[0].GetID()).To(Equal("button"))
+				Expect(client.FrameCall.Frame).To(ExactlyEqual(frame))
+				Expect(client.GetElementsCall.Selector).To(Equal(api.Selector{Using: "css selector", Value: ":root"}))
+			})
+
+			Context("when the frame cannot be switched to", func() {
+				It("should return an error", func() {
+					client.FrameCall.Err = errors.New("some error")
+					_, err := repository.Get()
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+
+			Context("when the frame selection refers to multiple frames", func() {
+				It("should return an error", func() {
+					repository.Selectors[0].Single = false
+					client.GetElementsCall.ReturnElements = []*api.Element{frame, frame}
+					_, err := repository.Get()
+					Expect(err).To(MatchError("frame selection must refer to exactly one frame (2)"))
+				})
+			})
+
+			Context("when the frame selector is not preceded by a frame selection", func() {
+				It("should return an error", func() {
+					repository.Selectors = target.Selectors{{Type: target.Frame}}
+					_, err := repository.Get()
+					Expect(err).To(MatchError("frame selection requires a parent selection"))
+				})
+			})
+		})
+
+		Context("when a selector selects the parents of the preceding selection", func() {
+			It("should select each parent once", func() {
+				firstParentBus.SendCall.Result = `[{"ELEMENT": "some-parent"}]`
+				secondParentBus.SendCall.Result = `[{"ELEMENT": "some-parent"}]`
+				repository.Selectors = target.Selectors{parentSelector, {Type: target.Parent, Single: true}}
+				elements, err := repository.Get()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(elements).To(HaveLen(1))
+				Expect(elements[0].GetID()).To(Equal("some-parent"))
+				Expect(firstParentBus.SendCall.BodyJSON).To(MatchJSON(`{"using": "xpath", "value": ".."}`))
+			})
+		})
+
+		Context("when a selector is applied to the preceding selection using a script", func() {
+			BeforeEach(func() {
+				firstParentBus.SendCall.Result = `[{"ELEMENT": "first parent"}]`
+				secondParentBus.SendCall.Result = `[]`
+				repository.Selectors = target.Selectors{parentSelector, {Type: target.Filter, Value: ".selected"}}
+			})
+
+			It("should return the elements returned by the script for each element", func() {
+				Expect(repository.Get()).To(Equal([]Element{firstParent}))
+				Expect(firstParentBus.SendCall.Endpoint).To(Equal("execute"))
+				Expect(firstParentBus.SendCall.BodyJSON).To(ContainSubstring(`"args":[{"ELEMENT":"first parent"},".selected"]`))
+			})
+
+			It("should apply single-element-only and indexed selectors to the returned elements", func() {
+				repository.Selectors = target.Selectors{parentSelector, {Type: target.Filter, Value: ".selected", Indexed: true, Index: 1}}
+				_, err := repository.Get()
+				Expect(err).To(MatchError("element index out of range"))
+			})
+
+			Context("when the script fails", func() {
+				It("should return an error", func() {
+					secondParentBus.SendCall.Err = errors.New("some error")
+					_, err := repository.Get()
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+
+			Context("when the selector is not preceded by a parent selection", func() {
+				It("should return an error", func() {
+					repository.Selectors = target.Selectors{{Type: target.Closest, Value: "form"}}
+					_, err := repository.Get()
+					Expect(err).To(MatchError("traversal requires a parent selection"))
+				})
+			})
+		})
+
+		Context("when the first selector applies to shadow roots", func() {
+			It("should return an error", func() {
+				parentSelector.Shadow = true
+				repository.Selectors = target.Selectors{parentSelector}
+				_, err := repository.Get()
+				Expect(err).To(MatchError("shadow root selection requires a parent selection"))
+			})
+		})
+
 		Context("when there is no selection", func() {
 			It("should return an error", func() {
 				repository.Selectors = target.Selectors{}
@@ -203,6 +458,7 @@ var _ = Describe("ElementRepository", func() {
 				client.GetElementsCall.ReturnElements = []*api.Element{}
 				_, err := repository.Get()
 				Expect(err).To(MatchError("element not found"))
+				Expect(IsNotFound(err)).To(BeTrue())
 			})
 		})
 
@@ -233,6 +489,7 @@ var _ = Describe("ElementRepository", func() {
 				repository.Selectors = target.Selectors{parentSelector}
 				_, err := repository.Get()
 				Expect(err).To(MatchError("element index out of range"))
+				Expect(IsNotFound(err)).To(BeTrue())
 			})
 		})
 
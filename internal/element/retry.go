@@ -0,0 +1,193 @@
+package element
+
+import (
+	"fmt"
+
+	"github.com/sclevine/agouti/api"
+)
+
+// A retryElement re-resolves its selection and retries commands that fail
+// because the element is no longer attached to the DOM, ex. after the page
+// re-renders. Elements are matched to the re-resolved selection by index.
+type retryElement struct {
+	Element
+	repository *Repository
+	index      int
+}
+
+// Unwrap returns the *api.Element that the provided element currently refers
+// to, or an error if the element is not backed by a WebDriver element.
+func Unwrap(element Element) (*api.Element, error) {
+	if retrying, ok := element.(*retryElement); ok {
+		return Unwrap(retrying.Element)
+	}
+	apiElement, ok := element.(*api.Element)
+	if !ok {
+		return nil, fmt.Errorf("element of type %T is not a WebDriver element", element)
+	}
+	return apiElement, nil
+}
+
+func (e *retryElement) retry(command func(Element) error) error {
+	err := command(e.Element)
+	for retry := 0; retry < e.repository.StaleRetries && api.IsStaleElement(err); retry++ {
+		e.repository.Cache.Invalidate()
+		elements, getErr := e.repository.cachedGet()
+		if getErr != nil || e.index >= len(elements) {
+			return err
+		}
+		e.Element = elements[e.index]
+		err = command(e.Element)
+	}
+	return err
+}
+
+func (e *retryElement) GetElement(selector api.Selector) (element *api.Element, err error) {
+	err = e.retry(func(current Element) error {
+		element, err = current.GetElement(selector)
+		return err
+	})
+	return element, err
+}
+
+func (e *retryElement) GetElements(selector api.Selector) (elements []*api.Element, err error) {
+	err = e.retry(func(current Element) error {
+		elements, err = current.GetElements(selector)
+		return err
+	})
+	return elements, err
+}
+
+func (e *retryElement) GetText() (text string, err error) {
+	err = e.retry(func(current Element) error {
+		text, err = current.GetText()
+		return err
+	})
+	return text, err
+}
+
+func (e *retryElement) GetName() (name string, err error) {
+	err = e.retry(func(current Element) error {
+		name, err = current.GetName()
+		return err
+	})
+	return name, err
+}
+
+func (e *retryElement) GetAttribute(attribute string) (value string, err error) {
+	err = e.retry(func(current Element) error {
+		value, err = current.GetAttribute(attribute)
+		return err
+	})
+	return value, err
+}
+
+func (e *retryElement) GetCSS(property string) (value string, err error) {
+	err = e.retry(func(current Element) error {
+		value, err = current.GetCSS(property)
+		return err
+	})
+	return value, err
+}
+
+func (e *retryElement) IsSelected() (selected bool, err error) {
+	err = e.retry(func(current Element) error {
+		selected, err = current.IsSelected()
+		return err
+	})
+	return selected, err
+}
+
+func (e *retryElement) IsDisplayed() (displayed bool, err error) {
+	err = e.retry(func(current Element) error {
+		displayed, err = current.IsDisplayed()
+		return err
+	})
+	return displayed, err
+}
+
+func (e *retryElement) IsEnabled() (enabled bool, err error) {
+	err = e.retry(func(current Element) error {
+		enabled, err = current.IsEnabled()
+		return err
+	})
+	return enabled, err
+}
+
+func (e *retryElement) IsEqualTo(other *api.Element) (equal bool, err error) {
+	err = e.retry(func(current Element) error {
+		equal, err = current.IsEqualTo(other)
+		return err
+	})
+	return equal, err
+}
+
+func (e *retryElement) Click() error {
+	return e.retry(Element.Click)
+}
+
+func (e *retryElement) Clear() error {
+	return e.retry(Element.Clear)
+}
+
+func (e *retryElement) Value(text string) error {
+	return e.retry(func(current Element) error {
+		return current.Value(text)
+	})
+}
+
+func (e *retryElement) SendKeys(text ...string) error {
+	return e.retry(func(current Element) error {
+		return current.SendKeys(text...)
+	})
+}
+
+func (e *retryElement) Submit() error {
+	return e.retry(Element.Submit)
+}
+
+func (e *retryElement) GetLocation() (x, y int, err error) {
+	err = e.retry(func(current Element) error {
+		x, y, err = current.GetLocation()
+		return err
+	})
+	return x, y, err
+}
+
+func (e *retryElement) GetElementsByScript(script string, arguments ...interface{}) (elements []*api.Element, err error) {
+	err = e.retry(func(current Element) error {
+		elements, err = current.GetElementsByScript(script, arguments...)
+		return err
+	})
+	return elements, err
+}
+
+func (e *retryElement) Execute(body string, arguments []interface{}, result interface{}) error {
+	return e.retry(func(current Element) error {
+		return current.Execute(body, arguments, result)
+	})
+}
+
+func (e *retryElement) GetRect() (rect api.Rect, err error) {
+	err = e.retry(func(current Element) error {
+		rect, err = current.GetRect()
+		return err
+	})
+	return rect, err
+}
+
+func (e *retryElement) GetScreenshot() (screenshot []byte, err error) {
+	err = e.retry(func(current Element) error {
+		screenshot, err = current.GetScreenshot()
+		return err
+	})
+	return screenshot, err
+}
+
+func (e *retryElement) GetShadowRoot() (shadowRoot *api.ShadowRoot, err error) {
+	err = e.retry(func(current Element) error {
+		shadowRoot, err = current.GetShadowRoot()
+		return err
+	})
+	return shadowRoot, err
+}
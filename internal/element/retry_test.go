@@ -0,0 +1,191 @@
+package element_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/api"
+	. "github.com/sclevine/agouti/internal/element"
+	"github.com/sclevine/agouti/internal/mocks"
+	"github.com/sclevine/agouti/internal/target"
+)
+
+// Each command sends the next error, or succeeds with result if none remain.
+type commandBus struct {
+	endpoints []string
+	errs      []error
+	result    string
+}
+
+func (b *commandBus) Send(method, endpoint string, body, result interface{}) error {
+	b.endpoints = append(b.endpoints, endpoint)
+	if len(b.errs) > 0 {
+		err := b.errs[0]
+		b.errs = b.errs[1:]
+		return err
+	}
+	if result != nil {
+		return json.Unmarshal([]byte(b.result), result)
+	}
+	return nil
+}
+
+// Each retrieval returns the next error, or a new set of elements if none remain.
+type staleClient struct {
+	bus         *commandBus
+	retrievals  int
+	count       int
+	retrieveErr []error
+}
+
+func (c *staleClient) GetElement(selector api.Selector) (*api.Element, error) {
+	elements, err := c.GetElements(selector)
+	if err != nil {
+		return nil, err
+	}
+	return elements[0], nil
+}
+
+func (c *staleClient) GetElements(selector api.Selector) ([]*api.Element, error) {
+	c.retrievals++
+	if len(c.retrieveErr) > 0 {
+		err := c.retrieveErr[0]
+		c.retrieveErr = c.retrieveErr[1:]
+		return nil, err
+	}
+	elements := []*api.Element{}
+	for index := 0; index < c.count; index++ {
+		id := string(rune('a'+c.retrievals-1)) + string(rune('0'+index))
+		elements = append(elements, &api.Element{ID: id, Session: &api.Session{Bus: c.bus}})
+	}
+	return elements, nil
+}
+
+var _ = Describe("Stale element retries", func() {
+	var (
+		bus        *commandBus
+		client     *staleClient
+		repository *Repository
+		staleErr   error
+	)
+
+	BeforeEach(func() {
+		bus = &commandBus{}
+		client = &staleClient{bus: bus, count: 2}
+		repository = &Repository{
+			Client:       client,
+			Selectors:    target.Selectors{{Type: target.CSS, Value: "#selector"}},
+			StaleRetries: 2,
+		}
+		staleErr = &api.Error{Code: api.ErrorStaleElement, Message: "stale element"}
+	})
+
+	Describe("#Get", func() {
+		It("should retry retrieving the elements when an element is stale", func() {
+			client.retrieveErr = []error{staleErr, staleErr}
+			elements, err := repository.Get()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(elements).To(HaveLen(2))
+			Expect(client.retrievals).To(Equal(3))
+		})
+
+		It("should return the stale element error after the retries are exhausted", func() {
+			client.retrieveErr = []error{staleErr, staleErr, staleErr}
+			_, err := repository.Get()
+			Expect(err).To(Equal(staleErr))
+			Expect(client.retrievals).To(Equal(3))
+		})
+
+		It("should not retry other errors", func() {
+			client.retrieveErr = []error{errors.New("some error")}
+			_, err := repository.Get()
+			Expect(err).To(MatchError("some error"))
+			Expect(client.retrievals).To(Equal(1))
+		})
+
+		Context("when there are no retries", func() {
+			It("should return the unwrapped elements without retrying", func() {
+				repository.StaleRetries = 0
+				client.retrieveErr = []error{staleErr}
+				_, err := repository.Get()
+				Expect(err).To(Equal(staleErr))
+
+				elements, err := repository.Get()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(elements[0]).To(BeAssignableToTypeOf(&api.Element{}))
+			})
+		})
+	})
+
+	Describe("element commands", func() {
+		var elements []Element
+
+		BeforeEach(func() {
+			var err error
+			elements, err = repository.Get()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should re-resolve the selection and retry the command on the element at the same index", func() {
+			bus.errs = []error{staleErr}
+			Expect(elements[1].Click()).To(Succeed())
+			Expect(bus.endpoints).To(Equal([]string{"element/a1/click", "element/b1/click"}))
+			apiElement, err := Unwrap(elements[1])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apiElement.ID).To(Equal("b1"))
+			Expect(elements[1].GetID()).To(Equal("b1"))
+		})
+
+		It("should return values from the retried command", func() {
+			bus.errs = []error{staleErr}
+			bus.result = `"some text"`
+			Expect(elements[0].GetText()).To(Equal("some text"))
+			Expect(bus.endpoints).To(Equal([]string{"element/a0/text", "element/b0/text"}))
+		})
+
+		It("should return the stale element error after the retries are exhausted", func() {
+			bus.errs = []error{staleErr, staleErr, staleErr}
+			Expect(elements[0].Click()).To(Equal(staleErr))
+			Expect(bus.endpoints).To(HaveLen(3))
+		})
+
+		It("should not retry other errors", func() {
+			bus.errs = []error{errors.New("some error")}
+			Expect(elements[0].Click()).To(MatchError("some error"))
+			Expect(bus.endpoints).To(HaveLen(1))
+		})
+
+		Context("when the re-resolved selection no longer contains the element", func() {
+			It("should return the stale element error", func() {
+				client.count = 1
+				bus.errs = []error{staleErr}
+				Expect(elements[1].Click()).To(Equal(staleErr))
+				Expect(bus.endpoints).To(HaveLen(1))
+			})
+		})
+
+		Context("when the selection cannot be re-resolved", func() {
+			It("should return the stale element error", func() {
+				client.retrieveErr = []error{errors.New("some error")}
+				bus.errs = []error{staleErr}
+				Expect(elements[0].Click()).To(Equal(staleErr))
+			})
+		})
+	})
+
+	Describe(".Unwrap", func() {
+		It("should return the provided *api.Element", func() {
+			element := &api.Element{ID: "some element"}
+			Expect(Unwrap(element)).To(BeIdenticalTo(element))
+		})
+
+		Context("when the element is not backed by a WebDriver element", func() {
+			It("should return an error", func() {
+				_, err := Unwrap(&mocks.Element{})
+				Expect(err).To(MatchError("element of type *mocks.Element is not a WebDriver element"))
+			})
+		})
+	})
+})
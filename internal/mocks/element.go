@@ -1,6 +1,10 @@
 package mocks
 
-import "github.com/sclevine/agouti/api"
+import (
+	"encoding/json"
+
+	"github.com/sclevine/agouti/api"
+)
 
 type Element struct {
 	GetElementCall struct {
@@ -56,6 +60,11 @@ type Element struct {
 		Err  error
 	}
 
+	SendKeysCall struct {
+		Text []string
+		Err  error
+	}
+
 	IsSelectedCall struct {
 		ReturnSelected bool
 		Err            error
@@ -87,6 +96,35 @@ type Element struct {
 		ReturnY int
 		Err     error
 	}
+
+	GetElementsByScriptCall struct {
+		Script         string
+		Arguments      []interface{}
+		ReturnElements []*api.Element
+		Err            error
+	}
+
+	ExecuteCall struct {
+		Body      string
+		Arguments []interface{}
+		Result    string
+		Err       error
+	}
+
+	GetRectCall struct {
+		ReturnRect api.Rect
+		Err        error
+	}
+
+	GetScreenshotCall struct {
+		ReturnImage []byte
+		Err         error
+	}
+
+	GetShadowRootCall struct {
+		ReturnShadowRoot *api.ShadowRoot
+		Err              error
+	}
 }
 
 func (e *Element) GetElement(selector api.Selector) (*api.Element, error) {
@@ -136,6 +174,11 @@ func (e *Element) Value(text string) error {
 	return e.ValueCall.Err
 }
 
+func (e *Element) SendKeys(text ...string) error {
+	e.SendKeysCall.Text = text
+	return e.SendKeysCall.Err
+}
+
 func (e *Element) IsSelected() (bool, error) {
 	return e.IsSelectedCall.ReturnSelected, e.IsSelectedCall.Err
 }
@@ -161,3 +204,28 @@ func (e *Element) IsEqualTo(other *api.Element) (bool, error) {
 func (e *Element) GetLocation() (x, y int, err error) {
 	return e.GetLocationCall.ReturnX, e.GetLocationCall.ReturnY, e.GetLocationCall.Err
 }
+
+func (e *Element) GetElementsByScript(script string, arguments ...interface{}) ([]*api.Element, error) {
+	e.GetElementsByScriptCall.Script = script
+	e.GetElementsByScriptCall.Arguments = arguments
+	return e.GetElementsByScriptCall.ReturnElements, e.GetElementsByScriptCall.Err
+}
+
+func (e *Element) Execute(body string, arguments []interface{}, result interface{}) error {
+	e.ExecuteCall.Body = body
+	e.ExecuteCall.Arguments = arguments
+	json.Unmarshal([]byte(e.ExecuteCall.Result), result)
+	return e.ExecuteCall.Err
+}
+
+func (e *Element) GetRect() (api.Rect, error) {
+	return e.GetRectCall.ReturnRect, e.GetRectCall.Err
+}
+
+func (e *Element) GetScreenshot() ([]byte, error) {
+	return e.GetScreenshotCall.ReturnImage, e.GetScreenshotCall.Err
+}
+
+func (e *Element) GetShadowRoot() (*api.ShadowRoot, error) {
+	return e.GetShadowRootCall.ReturnShadowRoot, e.GetShadowRootCall.Err
+}
@@ -17,6 +17,12 @@ type ElementRepository struct {
 		ReturnElements []element.Element
 		Err            error
 	}
+
+	ReloadCall struct {
+		Called         bool
+		ReturnElements []element.Element
+		Err            error
+	}
 }
 
 func (e *ElementRepository) Get() ([]element.Element, error) {
@@ -30,3 +36,8 @@ func (e *ElementRepository) GetExactlyOne() (element.Element, error) {
 func (e *ElementRepository) GetAtLeastOne() ([]element.Element, error) {
 	return e.GetAtLeastOneCall.ReturnElements, e.GetAtLeastOneCall.Err
 }
+
+func (e *ElementRepository) Reload() ([]element.Element, error) {
+	e.ReloadCall.Called = true
+	return e.ReloadCall.ReturnElements, e.ReloadCall.Err
+}
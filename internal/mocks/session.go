@@ -49,6 +49,44 @@ type Session struct {
 		Err  error
 	}
 
+	SetExtraHTTPHeadersCall struct {
+		Headers map[string]string
+		Err     error
+	}
+
+	SetHTTPAuthCall struct {
+		Username string
+		Password string
+		Err      error
+	}
+
+	ClearHTTPAuthCall struct {
+		Called bool
+		Err    error
+	}
+
+	SetNetworkConditionsCall struct {
+		Conditions api.NetworkConditions
+		Err        error
+	}
+
+	ClearNetworkConditionsCall struct {
+		Called bool
+		Err    error
+	}
+
+	UploadFileCall struct {
+		Filename         string
+		ReturnRemotePath string
+		Err              error
+	}
+
+	NewWindowCall struct {
+		Kind         string
+		ReturnWindow *api.Window
+		Err          error
+	}
+
 	DeleteWindowCall struct {
 		Called bool
 		Err    error
@@ -59,11 +97,22 @@ type Session struct {
 		Err         error
 	}
 
+	GetFullPageScreenshotCall struct {
+		ReturnImage []byte
+		Err         error
+	}
+
 	GetCookiesCall struct {
 		ReturnCookies []*api.Cookie
 		Err           error
 	}
 
+	GetNamedCookieCall struct {
+		Name         string
+		ReturnCookie *api.Cookie
+		Err          error
+	}
+
 	SetCookieCall struct {
 		Cookie *api.Cookie
 		Err    error
@@ -110,6 +159,11 @@ type Session struct {
 		Err   error
 	}
 
+	FrameIndexCall struct {
+		Indexes []int
+		Err     error
+	}
+
 	FrameParentCall struct {
 		Called bool
 		Err    error
@@ -122,6 +176,23 @@ type Session struct {
 		Err       error
 	}
 
+	AddInitScriptCall struct {
+		Script string
+		Err    error
+	}
+
+	PerformActionsCall struct {
+		Actions *api.Actions
+		Err     error
+	}
+
+	ExecuteAsyncCall struct {
+		Body      string
+		Arguments []interface{}
+		Result    string
+		Err       error
+	}
+
 	ForwardCall struct {
 		Called bool
 		Err    error
@@ -163,6 +234,19 @@ type Session struct {
 		Err        error
 	}
 
+	ExecuteCDPCall struct {
+		Command    string
+		Parameters interface{}
+		Result     string
+		Err        error
+	}
+
+	SubscribeCall struct {
+		Events       []string
+		ReturnEvents <-chan api.Event
+		Err          error
+	}
+
 	GetLogTypesCall struct {
 		ReturnTypes []string
 		Err         error
@@ -258,6 +342,38 @@ type Session struct {
 		Called bool
 		Err    error
 	}
+
+	SetTimeoutsCall struct {
+		Timeouts api.Timeouts
+		Err      error
+	}
+
+	GetTimeoutsCall struct {
+		ReturnTimeouts api.Timeouts
+		Err            error
+	}
+
+	GetOrientationCall struct {
+		ReturnOrientation string
+		Err               error
+	}
+
+	SetOrientationCall struct {
+		Orientation string
+		Err         error
+	}
+
+	GetGeolocationCall struct {
+		ReturnGeolocation api.Geolocation
+		Err               error
+	}
+
+	SetGeolocationCall struct {
+		Latitude  float64
+		Longitude float64
+		Altitude  float64
+		Err       error
+	}
 }
 
 func (s *Session) Delete() error {
@@ -297,6 +413,42 @@ func (s *Session) SetWindowByName(name string) error {
 	return s.SetWindowByNameCall.Err
 }
 
+func (s *Session) SetExtraHTTPHeaders(headers map[string]string) error {
+	s.SetExtraHTTPHeadersCall.Headers = headers
+	return s.SetExtraHTTPHeadersCall.Err
+}
+
+func (s *Session) SetHTTPAuth(username, password string) error {
+	s.SetHTTPAuthCall.Username = username
+	s.SetHTTPAuthCall.Password = password
+	return s.SetHTTPAuthCall.Err
+}
+
+func (s *Session) ClearHTTPAuth() error {
+	s.ClearHTTPAuthCall.Called = true
+	return s.ClearHTTPAuthCall.Err
+}
+
+func (s *Session) SetNetworkConditions(conditions api.NetworkConditions) error {
+	s.SetNetworkConditionsCall.Conditions = conditions
+	return s.SetNetworkConditionsCall.Err
+}
+
+func (s *Session) ClearNetworkConditions() error {
+	s.ClearNetworkConditionsCall.Called = true
+	return s.ClearNetworkConditionsCall.Err
+}
+
+func (s *Session) UploadFile(filename string) (string, error) {
+	s.UploadFileCall.Filename = filename
+	return s.UploadFileCall.ReturnRemotePath, s.UploadFileCall.Err
+}
+
+func (s *Session) NewWindow(kind string) (*api.Window, error) {
+	s.NewWindowCall.Kind = kind
+	return s.NewWindowCall.ReturnWindow, s.NewWindowCall.Err
+}
+
 func (s *Session) DeleteWindow() error {
 	s.DeleteWindowCall.Called = true
 	return s.DeleteWindowCall.Err
@@ -306,10 +458,19 @@ func (s *Session) GetScreenshot() ([]byte, error) {
 	return s.GetScreenshotCall.ReturnImage, s.GetScreenshotCall.Err
 }
 
+func (s *Session) GetFullPageScreenshot() ([]byte, error) {
+	return s.GetFullPageScreenshotCall.ReturnImage, s.GetFullPageScreenshotCall.Err
+}
+
 func (s *Session) GetCookies() ([]*api.Cookie, error) {
 	return s.GetCookiesCall.ReturnCookies, s.GetCookiesCall.Err
 }
 
+func (s *Session) GetNamedCookie(name string) (*api.Cookie, error) {
+	s.GetNamedCookieCall.Name = name
+	return s.GetNamedCookieCall.ReturnCookie, s.GetNamedCookieCall.Err
+}
+
 func (s *Session) SetCookie(cookie *api.Cookie) error {
 	s.SetCookieCall.Cookie = cookie
 	return s.SetCookieCall.Err
@@ -353,11 +514,21 @@ func (s *Session) Frame(frame *api.Element) error {
 	return s.FrameCall.Err
 }
 
+func (s *Session) FrameIndex(index int) error {
+	s.FrameIndexCall.Indexes = append(s.FrameIndexCall.Indexes, index)
+	return s.FrameIndexCall.Err
+}
+
 func (s *Session) FrameParent() error {
 	s.FrameParentCall.Called = true
 	return s.FrameParentCall.Err
 }
 
+func (s *Session) AddInitScript(script string) error {
+	s.AddInitScriptCall.Script = script
+	return s.AddInitScriptCall.Err
+}
+
 func (s *Session) Execute(body string, arguments []interface{}, result interface{}) error {
 	s.ExecuteCall.Body = body
 	s.ExecuteCall.Arguments = arguments
@@ -365,6 +536,18 @@ func (s *Session) Execute(body string, arguments []interface{}, result interface
 	return s.ExecuteCall.Err
 }
 
+func (s *Session) PerformActions(actions *api.Actions) error {
+	s.PerformActionsCall.Actions = actions
+	return s.PerformActionsCall.Err
+}
+
+func (s *Session) ExecuteAsync(body string, arguments []interface{}, result interface{}) error {
+	s.ExecuteAsyncCall.Body = body
+	s.ExecuteAsyncCall.Arguments = arguments
+	json.Unmarshal([]byte(s.ExecuteAsyncCall.Result), result)
+	return s.ExecuteAsyncCall.Err
+}
+
 func (s *Session) Forward() error {
 	s.ForwardCall.Called = true
 	return s.ForwardCall.Err
@@ -404,6 +587,20 @@ func (s *Session) NewLogs(logType string) ([]api.Log, error) {
 	return s.NewLogsCall.ReturnLogs, s.NewLogsCall.Err
 }
 
+func (s *Session) ExecuteCDP(command string, parameters, result interface{}) error {
+	s.ExecuteCDPCall.Command = command
+	s.ExecuteCDPCall.Parameters = parameters
+	if result != nil {
+		json.Unmarshal([]byte(s.ExecuteCDPCall.Result), result)
+	}
+	return s.ExecuteCDPCall.Err
+}
+
+func (s *Session) Subscribe(events ...string) (<-chan api.Event, error) {
+	s.SubscribeCall.Events = events
+	return s.SubscribeCall.ReturnEvents, s.SubscribeCall.Err
+}
+
 func (s *Session) GetLogTypes() ([]string, error) {
 	return s.GetLogTypesCall.ReturnTypes, s.GetLogTypesCall.Err
 }
@@ -498,3 +695,32 @@ func (s *Session) SetScriptTimeout(timeout int) error {
 	s.SetScriptTimeoutCall.Called = true
 	return s.SetScriptTimeoutCall.Err
 }
+
+func (s *Session) SetTimeouts(timeouts api.Timeouts) error {
+	s.SetTimeoutsCall.Timeouts = timeouts
+	return s.SetTimeoutsCall.Err
+}
+
+func (s *Session) GetTimeouts() (api.Timeouts, error) {
+	return s.GetTimeoutsCall.ReturnTimeouts, s.GetTimeoutsCall.Err
+}
+
+func (s *Session) GetOrientation() (string, error) {
+	return s.GetOrientationCall.ReturnOrientation, s.GetOrientationCall.Err
+}
+
+func (s *Session) SetOrientation(orientation string) error {
+	s.SetOrientationCall.Orientation = orientation
+	return s.SetOrientationCall.Err
+}
+
+func (s *Session) GetGeolocation() (api.Geolocation, error) {
+	return s.GetGeolocationCall.ReturnGeolocation, s.GetGeolocationCall.Err
+}
+
+func (s *Session) SetGeolocation(latitude, longitude, altitude float64) error {
+	s.SetGeolocationCall.Latitude = latitude
+	s.SetGeolocationCall.Longitude = longitude
+	s.SetGeolocationCall.Altitude = altitude
+	return s.SetGeolocationCall.Err
+}
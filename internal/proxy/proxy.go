@@ -0,0 +1,197 @@
+// Package proxy provides an HTTP proxy that allows requests made by a browser
+// to be intercepted and handled by Go code.
+package proxy
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type rule struct {
+	match   func(*http.Request) bool
+	handler http.Handler
+}
+
+// A Proxy forwards HTTP requests to their destinations, unless they match
+// an interception rule. HTTPS requests are tunneled without interception.
+type Proxy struct {
+	listener net.Listener
+	mutex    sync.RWMutex
+	rules    []rule
+}
+
+// Start starts a proxy listening on a random local port.
+func Start() (*Proxy, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := &Proxy{listener: listener}
+	go http.Serve(listener, proxy)
+	return proxy, nil
+}
+
+// Addr returns the host and port that the proxy is listening on.
+func (p *Proxy) Addr() string {
+	return p.listener.Addr().String()
+}
+
+// Close stops the proxy from accepting new connections.
+func (p *Proxy) Close() error {
+	return p.listener.Close()
+}
+
+// Handle routes requests that match the provided function to the provided
+// handler. Rules added later take precedence over rules added earlier.
+func (p *Proxy) Handle(match func(*http.Request) bool, handler http.Handler) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.rules = append(p.rules, rule{match, handler})
+}
+
+// Reset removes all rules, so that all requests are forwarded.
+func (p *Proxy) Reset() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.rules = nil
+}
+
+func (p *Proxy) handler(request *http.Request) http.Handler {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	for i := len(p.rules) - 1; i >= 0; i-- {
+		if p.rules[i].match(request) {
+			return p.rules[i].handler
+		}
+	}
+	return nil
+}
+
+func (p *Proxy) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	if request.Method == "CONNECT" {
+		tunnel(response, request)
+		return
+	}
+
+	if handler := p.handler(request); handler != nil {
+		handler.ServeHTTP(response, request)
+		return
+	}
+	Forward(response, request)
+}
+
+var transport = &http.Transport{
+	DialContext: (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	IdleConnTimeout: 90 * time.Second,
+}
+
+// Headers that apply to a single connection, which must not be forwarded.
+var hopByHopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"TE",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// Forward sends the provided proxy request to its destination and copies
+// the response.
+func Forward(response http.ResponseWriter, request *http.Request) {
+	outgoing := new(http.Request)
+	*outgoing = *request
+	outgoing.RequestURI = ""
+	outgoing.Header = cloneHeader(request.Header)
+	removeHopByHopHeaders(outgoing.Header)
+
+	result, err := transport.RoundTrip(outgoing)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer result.Body.Close()
+
+	removeHopByHopHeaders(result.Header)
+	for key, values := range result.Header {
+		response.Header()[key] = values
+	}
+	response.WriteHeader(result.StatusCode)
+	io.Copy(response, result.Body)
+}
+
+// Abort closes the connection of the provided request without responding.
+func Abort(response http.ResponseWriter, request *http.Request) {
+	hijacker, ok := response.(http.Hijacker)
+	if !ok {
+		http.Error(response, "request blocked", http.StatusBadGateway)
+		return
+	}
+
+	connection, _, err := hijacker.Hijack()
+	if err != nil {
+		http.Error(response, "request blocked", http.StatusBadGateway)
+		return
+	}
+	connection.Close()
+}
+
+func tunnel(response http.ResponseWriter, request *http.Request) {
+	destination, err := net.DialTimeout("tcp", request.Host, 30*time.Second)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	hijacker, ok := response.(http.Hijacker)
+	if !ok {
+		destination.Close()
+		http.Error(response, "tunneling not supported", http.StatusInternalServerError)
+		return
+	}
+
+	source, _, err := hijacker.Hijack()
+	if err != nil {
+		destination.Close()
+		http.Error(response, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := io.WriteString(source, "HTTP/1.1 200 Connection Established\r\n\r\n"); err != nil {
+		source.Close()
+		destination.Close()
+		return
+	}
+
+	go copyAndClose(destination, source)
+	go copyAndClose(source, destination)
+}
+
+func copyAndClose(destination, source net.Conn) {
+	defer destination.Close()
+	defer source.Close()
+	io.Copy(destination, source)
+}
+
+func cloneHeader(header http.Header) http.Header {
+	clone := make(http.Header, len(header))
+	for key, values := range header {
+		clone[key] = append([]string(nil), values...)
+	}
+	return clone
+}
+
+func removeHopByHopHeaders(header http.Header) {
+	for _, key := range hopByHopHeaders {
+		header.Del(key)
+	}
+}
@@ -0,0 +1,149 @@
+package proxy_test
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/internal/proxy"
+)
+
+var _ = Describe("Proxy", func() {
+	var (
+		proxy      *Proxy
+		server     *httptest.Server
+		client     *http.Client
+		lastHeader http.Header
+	)
+
+	BeforeEach(func() {
+		var err error
+		proxy, err = Start()
+		Expect(err).NotTo(HaveOccurred())
+
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			lastHeader = request.Header
+			response.Header().Set("Some-Header", "some value")
+			response.WriteHeader(http.StatusTeapot)
+			response.Write([]byte("some body from " + request.URL.Path))
+		}))
+
+		proxyURL, _ := url.Parse("http://" + proxy.Addr())
+		client = &http.Client{Transport: &http.Transport{
+			Proxy:           http.ProxyURL(proxyURL),
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}}
+	})
+
+	AfterEach(func() {
+		proxy.Close()
+		server.Close()
+	})
+
+	get := func(url string) (int, string, http.Header) {
+		response, err := client.Get(url)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		return response.StatusCode, string(body), response.Header
+	}
+
+	matchPath := func(path string) func(*http.Request) bool {
+		return func(request *http.Request) bool {
+			return request.URL.Path == path
+		}
+	}
+
+	respond := func(body string) http.Handler {
+		return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			response.Write([]byte(body))
+		})
+	}
+
+	Describe("#Addr", func() {
+		It("should return the local address of the proxy", func() {
+			Expect(proxy.Addr()).To(HavePrefix("127.0.0.1:"))
+		})
+	})
+
+	Context("when no rules match a request", func() {
+		It("should forward the request and copy the response", func() {
+			status, body, header := get(server.URL + "/some/path")
+			Expect(status).To(Equal(http.StatusTeapot))
+			Expect(body).To(Equal("some body from /some/path"))
+			Expect(header.Get("Some-Header")).To(Equal("some value"))
+		})
+
+		It("should not forward proxy headers", func() {
+			request, _ := http.NewRequest("GET", server.URL, nil)
+			request.Header.Set("Proxy-Authorization", "some credentials")
+			request.Header.Set("Other-Header", "other value")
+			response, err := client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+			response.Body.Close()
+			Expect(lastHeader.Get("Proxy-Authorization")).To(BeEmpty())
+			Expect(lastHeader.Get("Other-Header")).To(Equal("other value"))
+		})
+
+		Context("when the destination is unreachable", func() {
+			It("should respond with a bad gateway error", func() {
+				address := server.Listener.Addr().String()
+				server.Close()
+				status, _, _ := get("http://" + address)
+				Expect(status).To(Equal(http.StatusBadGateway))
+			})
+		})
+	})
+
+	Describe("#Handle", func() {
+		It("should route matching requests to the handler", func() {
+			proxy.Handle(matchPath("/some/path"), respond("some stub"))
+			_, body, _ := get(server.URL + "/some/path")
+			Expect(body).To(Equal("some stub"))
+			_, body, _ = get(server.URL + "/other/path")
+			Expect(body).To(Equal("some body from /other/path"))
+		})
+
+		It("should give precedence to rules added later", func() {
+			proxy.Handle(matchPath("/some/path"), respond("some stub"))
+			proxy.Handle(matchPath("/some/path"), respond("some other stub"))
+			_, body, _ := get(server.URL + "/some/path")
+			Expect(body).To(Equal("some other stub"))
+		})
+	})
+
+	Describe("#Reset", func() {
+		It("should remove all rules", func() {
+			proxy.Handle(matchPath("/some/path"), respond("some stub"))
+			proxy.Reset()
+			_, body, _ := get(server.URL + "/some/path")
+			Expect(body).To(Equal("some body from /some/path"))
+		})
+	})
+
+	Describe(".Abort", func() {
+		It("should close the connection without responding", func() {
+			proxy.Handle(matchPath("/some/path"), http.HandlerFunc(Abort))
+			_, err := client.Get(server.URL + "/some/path")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when an HTTPS request is made", func() {
+		It("should tunnel the request to the destination", func() {
+			secureServer := httptest.NewTLSServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				response.Write([]byte("some secure body"))
+			}))
+			defer secureServer.Close()
+
+			proxy.Handle(func(*http.Request) bool { return true }, respond("some stub"))
+			_, body, _ := get(secureServer.URL)
+			Expect(strings.TrimSpace(body)).To(Equal("some secure body"))
+		})
+	})
+})
@@ -0,0 +1,84 @@
+package target
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	Role         Type = "Role: %s"
+	LabeledInput Type = `Labeled Input: "%s"`
+)
+
+// Implicit ARIA roles of common HTML elements. Elements with an explicit
+// role attribute only match that role. See: https://www.w3.org/TR/html-aria/
+var implicitRoles = map[string]string{
+	"article":      `self::article`,
+	"banner":       `self::header`,
+	"button":       `self::button or self::input[@type="button" or @type="submit" or @type="reset" or @type="image"]`,
+	"cell":         `self::td`,
+	"checkbox":     `self::input[@type="checkbox"]`,
+	"columnheader": `self::th`,
+	"combobox":     `self::select[not(@multiple)]`,
+	"contentinfo":  `self::footer`,
+	"dialog":       `self::dialog`,
+	"form":         `self::form`,
+	"heading":      `self::h1 or self::h2 or self::h3 or self::h4 or self::h5 or self::h6`,
+	"img":          `self::img[not(@alt="")]`,
+	"link":         `self::a[@href] or self::area[@href]`,
+	"list":         `self::ul or self::ol`,
+	"listbox":      `self::select[@multiple]`,
+	"listitem":     `self::li`,
+	"main":         `self::main`,
+	"navigation":   `self::nav`,
+	"option":       `self::option`,
+	"progressbar":  `self::progress`,
+	"radio":        `self::input[@type="radio"]`,
+	"region":       `self::section[@aria-label or @aria-labelledby]`,
+	"row":          `self::tr`,
+	"searchbox":    `self::input[@type="search"]`,
+	"slider":       `self::input[@type="range"]`,
+	"spinbutton":   `self::input[@type="number"]`,
+	"table":        `self::table`,
+	"textbox":      `self::textarea or self::input[not(@type) or @type="text" or @type="email" or @type="tel" or @type="url"]`,
+}
+
+// The accessible name is approximated by the ARIA label, the text of the
+// elements that label the element, its text content, or its value, alt, or
+// title attributes.
+const accessibleNameXPath = `normalize-space(@aria-label)="%[1]s" or @aria-labelledby=//*[normalize-space()="%[1]s"]/@id or ` +
+	`@id=//label[normalize-space()="%[1]s"]/@for or ancestor::label[normalize-space()="%[1]s"] or ` +
+	`normalize-space()="%[1]s" or normalize-space(@value)="%[1]s" or normalize-space(@alt)="%[1]s" or normalize-space(@title)="%[1]s"`
+
+// Labeled inputs are the form controls that are labeled by a label element
+// or by ARIA attributes.
+const labeledInputXPath = `//*[self::input or self::select or self::textarea or @role="textbox" or @role="combobox"]` +
+	`[@id=//label[normalize-space()="%[1]s"]/@for or ancestor::label[normalize-space()="%[1]s"] or ` +
+	`normalize-space(@aria-label)="%[1]s" or @aria-labelledby=//*[normalize-space()="%[1]s"]/@id]`
+
+func roleXPath(role, name string) string {
+	matchesRole := fmt.Sprintf(`@role="%s"`, role)
+	if implicitRole, ok := implicitRoles[role]; ok {
+		matchesRole = fmt.Sprintf(`%s or (not(@role) and (%s))`, matchesRole, implicitRole)
+	}
+
+	xpath := fmt.Sprintf("//*[%s]", matchesRole)
+	if name != "" {
+		xpath += fmt.Sprintf("["+accessibleNameXPath+"]", name)
+	}
+	return xpath
+}
+
+func (s Selector) roleValue() string {
+	if s.Name == "" {
+		return s.Value
+	}
+	return s.Value + ` "` + s.Name + `"`
+}
+
+func ariaAttribute(attribute string) string {
+	if strings.HasPrefix(attribute, "aria-") {
+		return attribute
+	}
+	return "aria-" + attribute
+}
@@ -0,0 +1,13 @@
+package target
+
+import "github.com/sclevine/agouti/api"
+
+// An Element selector selects an element that was already retrieved, ex. as
+// the result of a script, instead of finding it. It may only be used as the
+// first selector.
+const Element Type = "Element: %s"
+
+// ElementSelectors returns selectors that select the provided element.
+func ElementSelectors(element *api.Element) Selectors {
+	return Selectors{{Type: Element, Value: element.ID, Element: element, Single: true}}
+}
@@ -0,0 +1,16 @@
+package target
+
+// A Frame selector selects the root element of the document of the frame
+// element selected by the preceding selectors. Selections that contain a
+// Frame selector switch to each of their frames before they are retrieved.
+const Frame Type = "Frame"
+
+// HasFrame returns true if any of the selectors is a Frame selector.
+func (s Selectors) HasFrame() bool {
+	for _, selector := range s {
+		if selector.Type == Frame {
+			return true
+		}
+	}
+	return false
+}
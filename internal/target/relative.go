@@ -0,0 +1,21 @@
+package target
+
+// Relative selectors select elements by CSS selector, anywhere in the page,
+// by their position relative to each element of the preceding selection.
+const (
+	Near    Type = "Near: %s"
+	Above   Type = "Above: %s"
+	Below   Type = "Below: %s"
+	LeftOf  Type = "Left Of: %s"
+	RightOf Type = "Right Of: %s"
+)
+
+// Relative returns true if the selector type selects elements by their
+// position relative to the preceding selection.
+func (t Type) Relative() bool {
+	switch t {
+	case Near, Above, Below, LeftOf, RightOf:
+		return true
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package target
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/sclevine/agouti/api"
 )
@@ -26,15 +27,23 @@ const (
 )
 
 func (t Type) format(value string) string {
+	if !strings.Contains(string(t), "%") {
+		return string(t)
+	}
 	return fmt.Sprintf(string(t), value)
 }
 
 type Selector struct {
 	Type    Type
 	Value   string
+	Name    string
 	Index   int
 	Indexed bool
 	Single  bool
+	Shadow  bool
+
+	// Element is the element selected by an Element selector
+	Element *api.Element
 }
 
 func (s Selector) String() string {
@@ -46,7 +55,17 @@ func (s Selector) String() string {
 		suffix = fmt.Sprintf(" [%d]", s.Index)
 	}
 
-	return s.Type.format(s.Value) + suffix
+	var prefix string
+	if s.Shadow {
+		prefix = "Shadow "
+	}
+
+	value := s.Value
+	if s.Type == Role {
+		value = s.roleValue()
+	}
+
+	return prefix + s.Type.format(value) + suffix
 }
 
 func (s Selector) API() api.Selector {
@@ -54,8 +73,12 @@ func (s Selector) API() api.Selector {
 }
 
 func (s Selector) apiType() string {
+	if s.Type.Relative() {
+		return "css selector"
+	}
+
 	switch s.Type {
-	case CSS:
+	case CSS, Children, Frame:
 		return "css selector"
 	case Class:
 		return "class name"
@@ -81,6 +104,29 @@ func (s Selector) value() string {
 		return fmt.Sprintf(labelXPath, s.Value)
 	case Button:
 		return fmt.Sprintf(buttonXPath, s.Value)
+	case Role:
+		return roleXPath(s.Value, s.Name)
+	case LabeledInput:
+		return fmt.Sprintf(labeledInputXPath, s.Value)
+	case Text:
+		return textSelectorXPath(textXPath, s.Value)
+	case PartialText:
+		return textSelectorXPath(partialTextXPath, s.Value)
+	case TextMatching:
+		return textCandidatesXPath
+	case Parent:
+		return ".."
+	case NextSibling:
+		return "following-sibling::*[1]"
+	case PrevSibling:
+		return "preceding-sibling::*[1]"
+	case Frame:
+		return ":root"
+	case Children:
+		if s.Value == "" {
+			return ":scope > *"
+		}
+		return ":scope > " + s.Value
 	}
 	return s.Value
 }
@@ -15,6 +15,11 @@ var _ = Describe("Selector", func() {
 			Expect(Selector{Type: CSS, Value: "value", Indexed: true, Index: 4}.String()).To(Equal("CSS: value [4]"))
 		})
 
+		It("should return a valid string prefix for shadow root Selectors", func() {
+			Expect(Selector{Type: CSS, Value: "value", Shadow: true}.String()).To(Equal("Shadow CSS: value"))
+			Expect(Selector{Type: CSS, Value: "value", Shadow: true, Single: true}.String()).To(Equal("Shadow CSS: value [single]"))
+		})
+
 		It("should return valid string formatting for the Selector", func() {
 			Expect(Selector{Type: CSS, Value: "value"}.String()).To(Equal("CSS: value"))
 			Expect(Selector{Type: XPath, Value: "value"}.String()).To(Equal("XPath: value"))
@@ -22,7 +27,28 @@ var _ = Describe("Selector", func() {
 			Expect(Selector{Type: Label, Value: "value"}.String()).To(Equal(`Label: "value"`))
 			Expect(Selector{Type: Button, Value: "value"}.String()).To(Equal(`Button: "value"`))
 			Expect(Selector{Type: Name, Value: "value"}.String()).To(Equal(`Name: "value"`))
+			Expect(Selector{Type: Role, Value: "button"}.String()).To(Equal(`Role: button`))
+			Expect(Selector{Type: Role, Value: "button", Name: "value"}.String()).To(Equal(`Role: button "value"`))
+			Expect(Selector{Type: LabeledInput, Value: "value"}.String()).To(Equal(`Labeled Input: "value"`))
+			Expect(Selector{Type: Text, Value: "value"}.String()).To(Equal(`Text: "value"`))
+			Expect(Selector{Type: PartialText, Value: "value"}.String()).To(Equal(`Partial Text: "value"`))
+			Expect(Selector{Type: TextMatching, Value: "^value$"}.String()).To(Equal(`Text Matching: /^value$/`))
+			Expect(Selector{Type: Near, Value: "value"}.String()).To(Equal(`Near: value`))
+			Expect(Selector{Type: Parent, Single: true}.String()).To(Equal(`Parent [single]`))
+			Expect(Selector{Type: Frame, Single: true}.String()).To(Equal(`Frame [single]`))
+			Expect(Selector{Type: Children, Value: "value"}.String()).To(Equal(`Children: value`))
+			Expect(Selector{Type: PrevSibling}.String()).To(Equal(`Previous Sibling`))
+			Expect(Selector{Type: Filter, Value: "value"}.String()).To(Equal(`Filter: value`))
+			Expect(Selector{Type: LeftOf, Value: "value", Indexed: true}.String()).To(Equal(`Left Of: value [0]`))
+
+		})
+	})
 
+	Describe("#Script", func() {
+		It("should return a script for selectors that cannot be applied to an element", func() {
+			Expect(Selector{Type: Closest, Value: "value"}.Script()).To(ContainSubstring("arguments[0].closest(arguments[1])"))
+			Expect(Selector{Type: Filter, Value: "value"}.Script()).To(ContainSubstring("arguments[0].matches(arguments[1])"))
+			Expect(Selector{Type: Parent}.Script()).To(BeEmpty())
 		})
 	})
 
@@ -35,5 +61,64 @@ var _ = Describe("Selector", func() {
 			Expect(Selector{Type: Button, Value: "value"}.API()).To(Equal(api.Selector{Using: "xpath", Value: `//input[@type="submit" or @type="button"][normalize-space(@value)="value"] | //button[normalize-space()="value"]`}))
 			Expect(Selector{Type: Name, Value: "value"}.API()).To(Equal(api.Selector{Using: "name", Value: "value"}))
 		})
+
+		It("should return an XPath that matches elements with an explicit or implicit role", func() {
+			Expect(Selector{Type: Role, Value: "checkbox"}.API()).To(Equal(api.Selector{
+				Using: "xpath",
+				Value: `//*[@role="checkbox" or (not(@role) and (self::input[@type="checkbox"]))]`,
+			}))
+			Expect(Selector{Type: Role, Value: "tab"}.API()).To(Equal(api.Selector{Using: "xpath", Value: `//*[@role="tab"]`}))
+		})
+
+		It("should return an XPath that matches elements with the provided accessible name", func() {
+			selector := Selector{Type: Role, Value: "tab", Name: "value"}.API()
+			Expect(selector.Value).To(HavePrefix(`//*[@role="tab"][normalize-space(@aria-label)="value" or `))
+			Expect(selector.Value).To(ContainSubstring(`@id=//label[normalize-space()="value"]/@for`))
+			Expect(selector.Value).To(HaveSuffix(`normalize-space(@title)="value"]`))
+		})
+
+		It("should return an XPath that matches the innermost elements with the provided text", func() {
+			Expect(Selector{Type: Text, Value: " some   value "}.API()).To(Equal(api.Selector{
+				Using: "xpath",
+				Value: `.//*[not(self::script or self::style)][normalize-space()="some value"][not(.//*[normalize-space()="some value"])]`,
+			}))
+			Expect(Selector{Type: PartialText, Value: "value"}.API()).To(Equal(api.Selector{
+				Using: "xpath",
+				Value: `.//*[not(self::script or self::style)][contains(normalize-space(), "value")][not(.//*[contains(normalize-space(), "value")])]`,
+			}))
+		})
+
+		It("should quote text containing quotes correctly", func() {
+			Expect(Selector{Type: Text, Value: `some "value"`}.API().Value).To(ContainSubstring(`[normalize-space()='some "value"']`))
+			Expect(Selector{Type: Text, Value: `it's "value"`}.API().Value).To(ContainSubstring(`[normalize-space()=concat("it's ", '"', "value", '"')]`))
+		})
+
+		It("should return an XPath that matches elements with their own text for regular expressions", func() {
+			Expect(Selector{Type: TextMatching, Value: "^value$"}.API()).To(Equal(api.Selector{
+				Using: "xpath",
+				Value: `.//*[not(self::script or self::style)][text()[normalize-space()]]`,
+			}))
+		})
+
+		It("should return element-scoped selectors for traversal selectors", func() {
+			Expect(Selector{Type: Parent}.API()).To(Equal(api.Selector{Using: "xpath", Value: ".."}))
+			Expect(Selector{Type: NextSibling}.API()).To(Equal(api.Selector{Using: "xpath", Value: "following-sibling::*[1]"}))
+			Expect(Selector{Type: PrevSibling}.API()).To(Equal(api.Selector{Using: "xpath", Value: "preceding-sibling::*[1]"}))
+			Expect(Selector{Type: Children, Value: "li"}.API()).To(Equal(api.Selector{Using: "css selector", Value: ":scope > li"}))
+			Expect(Selector{Type: Children}.API()).To(Equal(api.Selector{Using: "css selector", Value: ":scope > *"}))
+			Expect(Selector{Type: Frame}.API()).To(Equal(api.Selector{Using: "css selector", Value: ":root"}))
+		})
+
+		It("should return a CSS selector for relative selectors", func() {
+			for _, relation := range []Type{Near, Above, Below, LeftOf, RightOf} {
+				Expect(Selector{Type: relation, Value: "value"}.API()).To(Equal(api.Selector{Using: "css selector", Value: "value"}))
+			}
+		})
+
+		It("should return an XPath that matches form controls by their label", func() {
+			selector := Selector{Type: LabeledInput, Value: "value"}.API()
+			Expect(selector.Using).To(Equal("xpath"))
+			Expect(selector.Value).To(ContainSubstring(`[@id=//label[normalize-space()="value"]/@for or ancestor::label[normalize-space()="value"] or `))
+		})
 	})
 })
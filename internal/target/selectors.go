@@ -1,6 +1,9 @@
 package target
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 type Selectors []Selector
 
@@ -19,11 +22,30 @@ func (s Selectors) Append(selectorType Type, value string) Selectors {
 	if s.canMergeType(selectorType) {
 		lastIndex := len(s) - 1
 		selector.Value = s[lastIndex].Value + " " + selector.Value
+		selector.Shadow = s[lastIndex].Shadow
 		return s[:lastIndex].append(selector)
 	}
 	return s.append(selector)
 }
 
+// AppendShadow appends a CSS selector that is applied to the shadow root of
+// each element selected by the preceding selectors.
+func (s Selectors) AppendShadow(value string) Selectors {
+	return s.append(Selector{Type: CSS, Value: value, Shadow: true})
+}
+
+// AppendRole appends a selector for elements with the provided ARIA role,
+// either explicitly or implicitly, and the provided accessible name, if any.
+func (s Selectors) AppendRole(role, name string) Selectors {
+	return s.append(Selector{Type: Role, Value: role, Name: name})
+}
+
+// AppendAria appends a CSS selector for elements with the provided ARIA
+// attribute value. The "aria-" prefix of the attribute is optional.
+func (s Selectors) AppendAria(attribute, value string) Selectors {
+	return s.Append(CSS, fmt.Sprintf(`[%s="%s"]`, ariaAttribute(attribute), value))
+}
+
 func (s Selectors) Single() Selectors {
 	lastIndex := len(s) - 1
 	if lastIndex < 0 {
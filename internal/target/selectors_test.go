@@ -3,6 +3,7 @@ package target_test
 import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/api"
 	. "github.com/sclevine/agouti/internal/target"
 )
 
@@ -56,6 +57,36 @@ var _ = Describe("Selectors", func() {
 		})
 	})
 
+	Describe("#AppendRole", func() {
+		It("should append a new role selector", func() {
+			Expect(selectors.Append(CSS, "#selector").AppendRole("button", "some name").String()).To(Equal(`CSS: #selector | Role: button "some name"`))
+		})
+	})
+
+	Describe("#AppendAria", func() {
+		It("should append a CSS selector for the ARIA attribute", func() {
+			Expect(selectors.AppendAria("label", "some label").String()).To(Equal(`CSS: [aria-label="some label"]`))
+			Expect(selectors.Append(CSS, "#selector").AppendAria("aria-hidden", "true").String()).To(Equal(`CSS: #selector [aria-hidden="true"]`))
+		})
+	})
+
+	Describe("#AppendShadow", func() {
+		It("should append a new shadow root CSS selector", func() {
+			shadow := selectors.Append(CSS, "#host").AppendShadow("#selector")
+			Expect(shadow).To(Equal(Selectors{
+				Selector{Type: CSS, Value: "#host"},
+				Selector{Type: CSS, Value: "#selector", Shadow: true},
+			}))
+		})
+
+		Context("when a CSS selector is appended to a shadow root CSS selector", func() {
+			It("should modify the shadow root CSS selector to include the new selector", func() {
+				shadow := selectors.Append(CSS, "#host").AppendShadow("#selector").Append(CSS, "#subselector")
+				Expect(shadow.String()).To(Equal("CSS: #host | Shadow CSS: #selector #subselector"))
+			})
+		})
+	})
+
 	Describe("#At", func() {
 		Context("when called on a selection with no selectors", func() {
 			It("should return an empty selection", func() {
@@ -84,6 +115,15 @@ var _ = Describe("Selectors", func() {
 		})
 	})
 
+	Describe(".ElementSelectors", func() {
+		It("should select exactly the provided element", func() {
+			element := &api.Element{ID: "some-id"}
+			selectors := ElementSelectors(element)
+			Expect(selectors.String()).To(Equal("Element: some-id [single]"))
+			Expect(selectors[0].Element).To(BeIdenticalTo(element))
+		})
+	})
+
 	Describe("selectors are always copied", func() {
 		Context("when two CSS selections are created from the same XPath parent", func() {
 			It("should not overwrite the first created child", func() {
@@ -0,0 +1,51 @@
+package target
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	Text         Type = `Text: "%s"`
+	PartialText  Type = `Partial Text: "%s"`
+	TextMatching Type = "Text Matching: /%s/"
+)
+
+// Text selectors match the innermost elements with the text, so that the
+// ancestors of an element with the text are not also selected. Unlike other
+// generated XPaths, they only match descendants of the parent selection.
+const (
+	textXPath        = `.//*[not(self::script or self::style)][normalize-space()=%[1]s][not(.//*[normalize-space()=%[1]s])]`
+	partialTextXPath = `.//*[not(self::script or self::style)][contains(normalize-space(), %[1]s)][not(.//*[contains(normalize-space(), %[1]s)])]`
+
+	// Elements with their own text are matched against the pattern after
+	// they are retrieved, as XPath 1.0 does not support regular expressions.
+	textCandidatesXPath = `.//*[not(self::script or self::style)][text()[normalize-space()]]`
+)
+
+// xpathLiteral quotes the provided string for use in an XPath 1.0
+// expression, which has no escape sequences. Strings containing both kinds
+// of quotes are split into a call to concat().
+func xpathLiteral(value string) string {
+	if !strings.Contains(value, `"`) {
+		return `"` + value + `"`
+	}
+	if !strings.Contains(value, `'`) {
+		return `'` + value + `'`
+	}
+
+	var parts []string
+	for index, part := range strings.Split(value, `"`) {
+		if index > 0 {
+			parts = append(parts, `'"'`)
+		}
+		if part != "" {
+			parts = append(parts, `"`+part+`"`)
+		}
+	}
+	return "concat(" + strings.Join(parts, ", ") + ")"
+}
+
+func textSelectorXPath(xpath, text string) string {
+	return fmt.Sprintf(xpath, xpathLiteral(strings.Join(strings.Fields(text), " ")))
+}
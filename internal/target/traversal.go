@@ -0,0 +1,39 @@
+package target
+
+// Traversal selectors select elements relative to each element of the
+// preceding selection in the DOM tree.
+const (
+	Parent      Type = "Parent"
+	Children    Type = "Children: %s"
+	NextSibling Type = "Next Sibling"
+	PrevSibling Type = "Previous Sibling"
+	Closest     Type = "Closest: %s"
+	Filter      Type = "Filter: %s"
+)
+
+// Scripts for traversal selectors that cannot be expressed as selectors
+// applied to an element. Each is passed an element and a CSS selector, and
+// returns an array of elements.
+const (
+	closestScript = `var element = arguments[0].closest(arguments[1]); return element ? [element] : [];`
+	filterScript  = `return arguments[0].matches(arguments[1]) ? [arguments[0]] : [];`
+)
+
+// Script returns the script that applies the selector to an element, or an
+// empty string if the selector is not applied using a script.
+func (s Selector) Script() string {
+	switch s.Type {
+	case Closest:
+		return closestScript
+	case Filter:
+		return filterScript
+	}
+	return ""
+}
+
+// Unique returns true if elements selected by the selector from different
+// elements of the preceding selection may be the same element, and should
+// only be selected once.
+func (t Type) Unique() bool {
+	return t == Parent || t == Closest
+}
@@ -0,0 +1,80 @@
+// Package istanbul provides the types of the Istanbul coverage format, which
+// is produced by agouti.IstanbulCoverage from the JavaScript coverage
+// collected by *agouti.Page.StopJSCoverage. Coverage files may be merged and
+// reported on by nyc and other Istanbul-compatible tools.
+// See: https://github.com/istanbuljs/istanbuljs/blob/master/docs/raw-output.md
+package istanbul
+
+import (
+	"encoding/json"
+	"io/ioutil"
+)
+
+// CoverageMap contains the coverage of each file, keyed by its path.
+type CoverageMap map[string]*FileCoverage
+
+// Save writes the coverage map to the provided file as JSON, ex. to
+// .nyc_output/coverage.json for reporting with nyc.
+func (c CoverageMap) Save(filename string) error {
+	coverageJSON, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, coverageJSON, 0644)
+}
+
+// FileCoverage contains the coverage of a single file. Statements, functions,
+// and branches are identified by the keys of their maps, and their counts are
+// stored in S, F, and B under the same keys.
+type FileCoverage struct {
+	Path         string              `json:"path"`
+	StatementMap map[string]Range    `json:"statementMap"`
+	FnMap        map[string]Function `json:"fnMap"`
+	BranchMap    map[string]Branch   `json:"branchMap"`
+	S            map[string]int      `json:"s"`
+	F            map[string]int      `json:"f"`
+	B            map[string][]int    `json:"b"`
+}
+
+// NewFileCoverage returns empty coverage for the file with the provided path.
+func NewFileCoverage(path string) *FileCoverage {
+	return &FileCoverage{
+		Path:         path,
+		StatementMap: map[string]Range{},
+		FnMap:        map[string]Function{},
+		BranchMap:    map[string]Branch{},
+		S:            map[string]int{},
+		F:            map[string]int{},
+		B:            map[string][]int{},
+	}
+}
+
+// Range is a span of source code.
+type Range struct {
+	Start Location `json:"start"`
+	End   Location `json:"end"`
+}
+
+// Location is a position in source code. Lines start at 1 and columns start
+// at 0.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Function describes a function in source code.
+type Function struct {
+	Name string `json:"name"`
+	Decl Range  `json:"decl"`
+	Loc  Range  `json:"loc"`
+	Line int    `json:"line"`
+}
+
+// Branch describes a branch in source code, where each location is a path
+// that may be taken.
+type Branch struct {
+	Type      string  `json:"type"`
+	Line      int     `json:"line"`
+	Loc       Range   `json:"loc"`
+	Locations []Range `json:"locations"`
+}
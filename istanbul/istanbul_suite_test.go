@@ -0,0 +1,13 @@
+package istanbul_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestIstanbul(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Istanbul Suite")
+}
@@ -0,0 +1,71 @@
+package istanbul_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/istanbul"
+)
+
+var _ = Describe("Istanbul", func() {
+	Describe("#NewFileCoverage", func() {
+		It("should return empty coverage for the provided path", func() {
+			coverage := istanbul.NewFileCoverage("app.js")
+			Expect(coverage.Path).To(Equal("app.js"))
+			Expect(coverage.StatementMap).To(BeEmpty())
+			Expect(coverage.S).NotTo(BeNil())
+			Expect(coverage.B).NotTo(BeNil())
+		})
+	})
+
+	Describe("CoverageMap#Save", func() {
+		var (
+			directory   string
+			coverageMap istanbul.CoverageMap
+		)
+
+		BeforeEach(func() {
+			var err error
+			directory, err = ioutil.TempDir("", "agouti-istanbul")
+			Expect(err).NotTo(HaveOccurred())
+
+			coverage := istanbul.NewFileCoverage("app.js")
+			coverage.StatementMap["0"] = istanbul.Range{
+				Start: istanbul.Location{Line: 1, Column: 0},
+				End:   istanbul.Location{Line: 1, Column: 10},
+			}
+			coverage.S["0"] = 2
+			coverageMap = istanbul.CoverageMap{"app.js": coverage}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(directory)
+		})
+
+		It("should write the coverage map to the provided file as Istanbul JSON", func() {
+			filename := filepath.Join(directory, "coverage.json")
+			Expect(coverageMap.Save(filename)).To(Succeed())
+			coverageJSON, err := ioutil.ReadFile(filename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(coverageJSON).To(MatchJSON(`{"app.js": {
+				"path": "app.js",
+				"statementMap": {"0": {"start": {"line": 1, "column": 0}, "end": {"line": 1, "column": 10}}},
+				"fnMap": {},
+				"branchMap": {},
+				"s": {"0": 2},
+				"f": {},
+				"b": {}
+			}}`))
+		})
+
+		Context("when the file cannot be written", func() {
+			It("should return an error", func() {
+				err := coverageMap.Save(filepath.Join(directory, "missing", "coverage.json"))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
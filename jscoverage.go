@@ -0,0 +1,262 @@
+package agouti
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+
+	"github.com/sclevine/agouti/istanbul"
+)
+
+// JSCoverage is the precise coverage of a single script, as reported by V8.
+type JSCoverage struct {
+	// URL is the URL of the script, or empty for inline and evaluated scripts
+	URL string
+
+	// Source is the source of the script
+	Source string
+
+	// Functions contains the coverage of each function in the script,
+	// including the top-level code of the script as a function with no name
+	Functions []JSFunctionCoverage
+}
+
+// JSFunctionCoverage is the coverage of a single function.
+type JSFunctionCoverage struct {
+	Name string `json:"functionName"`
+
+	// Ranges contains the execution counts of the function, followed by the
+	// execution counts of blocks within the function if IsBlockCoverage is
+	// true. Nested ranges override the counts of the ranges that contain them.
+	Ranges []JSCoverageRange `json:"ranges"`
+
+	IsBlockCoverage bool `json:"isBlockCoverage"`
+}
+
+// A JSCoverageRange is the execution count of a range of a script source.
+// Offsets are in UTF-16 code units, as in JavaScript strings.
+type JSCoverageRange struct {
+	StartOffset int `json:"startOffset"`
+	EndOffset   int `json:"endOffset"`
+	Count       int `json:"count"`
+}
+
+// StartJSCoverage starts collecting the precise JavaScript coverage of the
+// page using the DevTools Profiler domain, so only Chrome supports it.
+// Coverage is collected from every script that is run, until StopJSCoverage
+// is called.
+func (p *Page) StartJSCoverage() error {
+	if err := p.session.ExecuteCDP("Profiler.enable", nil, nil); err != nil {
+		return fmt.Errorf("failed to start JavaScript coverage: %s", err)
+	}
+	if err := p.session.ExecuteCDP("Debugger.enable", nil, nil); err != nil {
+		return fmt.Errorf("failed to start JavaScript coverage: %s", err)
+	}
+
+	request := struct {
+		CallCount bool `json:"callCount"`
+		Detailed  bool `json:"detailed"`
+	}{true, true}
+	if err := p.session.ExecuteCDP("Profiler.startPreciseCoverage", request, nil); err != nil {
+		return fmt.Errorf("failed to start JavaScript coverage: %s", err)
+	}
+	return nil
+}
+
+// StopJSCoverage stops collecting JavaScript coverage and returns the
+// coverage of each script that was run since StartJSCoverage was called.
+// Scripts injected by agouti or the WebDriver are included. Coverage may be
+// converted to the Istanbul format using IstanbulCoverage.
+//
+// Example:
+//    page.StartJSCoverage()
+//    ... interact with the page ...
+//    coverage, err := page.StopJSCoverage()
+//    agouti.IstanbulCoverage(coverage, nil).Save(".nyc_output/acceptance.json")
+func (p *Page) StopJSCoverage() ([]JSCoverage, error) {
+	var result struct {
+		Result []struct {
+			ScriptID  string               `json:"scriptId"`
+			URL       string               `json:"url"`
+			Functions []JSFunctionCoverage `json:"functions"`
+		} `json:"result"`
+	}
+	if err := p.session.ExecuteCDP("Profiler.takePreciseCoverage", nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to stop JavaScript coverage: %s", err)
+	}
+
+	coverage := []JSCoverage{}
+	for _, script := range result.Result {
+		request := struct {
+			ScriptID string `json:"scriptId"`
+		}{script.ScriptID}
+		var source struct {
+			ScriptSource string `json:"scriptSource"`
+		}
+		if err := p.session.ExecuteCDP("Debugger.getScriptSource", request, &source); err != nil {
+			return nil, fmt.Errorf("failed to retrieve source of script %s: %s", script.URL, err)
+		}
+		coverage = append(coverage, JSCoverage{URL: script.URL, Source: source.ScriptSource, Functions: script.Functions})
+	}
+
+	for _, command := range []string{"Profiler.stopPreciseCoverage", "Profiler.disable", "Debugger.disable"} {
+		if err := p.session.ExecuteCDP(command, nil, nil); err != nil {
+			return nil, fmt.Errorf("failed to stop JavaScript coverage: %s", err)
+		}
+	}
+	return coverage, nil
+}
+
+// IstanbulCoverage converts JavaScript coverage to the Istanbul format, so
+// that it may be merged with the coverage of unit tests. Each line of a
+// script is reported as a statement, each named or nested function is
+// reported as a function, and each block is reported as a branch.
+//
+// Each script is stored under the path returned by the provided function for
+// its URL, or under its URL if the function is nil. Scripts with an empty URL
+// or path are omitted, and the counts of scripts with the same path, such as
+// a script that was loaded by multiple documents, are added together.
+func IstanbulCoverage(coverage []JSCoverage, pathForURL func(url string) string) istanbul.CoverageMap {
+	coverageMap := istanbul.CoverageMap{}
+	for _, script := range coverage {
+		path := script.URL
+		if pathForURL != nil && path != "" {
+			path = pathForURL(path)
+		}
+		if path == "" {
+			continue
+		}
+
+		fileCoverage := istanbul.NewFileCoverage(path)
+		newScriptSource(script.Source).addCoverage(fileCoverage, script.Functions)
+		if existing, ok := coverageMap[path]; ok {
+			mergeCoverage(existing, fileCoverage)
+		} else {
+			coverageMap[path] = fileCoverage
+		}
+	}
+	return coverageMap
+}
+
+// Scripts with the same path are assumed to have the same source, so their
+// statements, functions, and branches have the same IDs.
+func mergeCoverage(coverage, other *istanbul.FileCoverage) {
+	for id, count := range other.S {
+		coverage.S[id] += count
+	}
+	for id, count := range other.F {
+		coverage.F[id] += count
+	}
+	for id, counts := range other.B {
+		for index, count := range counts {
+			if index < len(coverage.B[id]) {
+				coverage.B[id][index] += count
+			}
+		}
+	}
+}
+
+// A scriptSource maps UTF-16 offsets in a script to lines and columns.
+type scriptSource struct {
+	lineStarts []int
+	lineEnds   []int
+	lineFirsts []int
+	length     int
+}
+
+func newScriptSource(source string) *scriptSource {
+	script := &scriptSource{lineStarts: []int{0}, lineFirsts: []int{-1}}
+	offset := 0
+	for _, character := range source {
+		line := len(script.lineStarts) - 1
+		switch {
+		case character == '\n':
+			script.lineEnds = append(script.lineEnds, offset)
+			script.lineStarts = append(script.lineStarts, offset+1)
+			script.lineFirsts = append(script.lineFirsts, -1)
+		case !unicode.IsSpace(character) && script.lineFirsts[line] == -1:
+			script.lineFirsts[line] = offset
+		}
+		if character > 0xFFFF {
+			offset += 2
+		} else {
+			offset++
+		}
+	}
+	script.lineEnds = append(script.lineEnds, offset)
+	script.length = offset
+	return script
+}
+
+func (s *scriptSource) location(offset int) istanbul.Location {
+	line := 0
+	for line+1 < len(s.lineStarts) && s.lineStarts[line+1] <= offset {
+		line++
+	}
+	return istanbul.Location{Line: line + 1, Column: offset - s.lineStarts[line]}
+}
+
+func (s *scriptSource) span(start, end int) istanbul.Range {
+	return istanbul.Range{Start: s.location(start), End: s.location(end)}
+}
+
+// Blank lines are not reported as statements. Lines that are not within any
+// range are reported as not executed.
+func (s *scriptSource) addCoverage(fileCoverage *istanbul.FileCoverage, functions []JSFunctionCoverage) {
+	var ranges []JSCoverageRange
+	for _, function := range functions {
+		ranges = append(ranges, function.Ranges...)
+	}
+
+	for line, first := range s.lineFirsts {
+		if first == -1 {
+			continue
+		}
+		id := strconv.Itoa(len(fileCoverage.StatementMap))
+		fileCoverage.StatementMap[id] = istanbul.Range{
+			Start: istanbul.Location{Line: line + 1, Column: first - s.lineStarts[line]},
+			End:   istanbul.Location{Line: line + 1, Column: s.lineEnds[line] - s.lineStarts[line]},
+		}
+		fileCoverage.S[id] = innermostCount(ranges, first)
+	}
+
+	for _, function := range functions {
+		if len(function.Ranges) == 0 {
+			continue
+		}
+		functionRange := function.Ranges[0]
+		if function.Name != "" || functionRange.StartOffset != 0 || functionRange.EndOffset < s.length {
+			id := strconv.Itoa(len(fileCoverage.FnMap))
+			name := function.Name
+			if name == "" {
+				name = "(anonymous_" + id + ")"
+			}
+			loc := s.span(functionRange.StartOffset, functionRange.EndOffset)
+			fileCoverage.FnMap[id] = istanbul.Function{Name: name, Decl: loc, Loc: loc, Line: loc.Start.Line}
+			fileCoverage.F[id] = functionRange.Count
+		}
+
+		if !function.IsBlockCoverage {
+			continue
+		}
+		for _, block := range function.Ranges[1:] {
+			id := strconv.Itoa(len(fileCoverage.BranchMap))
+			loc := s.span(block.StartOffset, block.EndOffset)
+			fileCoverage.BranchMap[id] = istanbul.Branch{Type: "branch", Line: loc.Start.Line, Loc: loc, Locations: []istanbul.Range{loc}}
+			fileCoverage.B[id] = []int{block.Count}
+		}
+	}
+}
+
+func innermostCount(ranges []JSCoverageRange, offset int) int {
+	count, width := 0, -1
+	for _, coverageRange := range ranges {
+		if offset < coverageRange.StartOffset || offset >= coverageRange.EndOffset {
+			continue
+		}
+		if rangeWidth := coverageRange.EndOffset - coverageRange.StartOffset; width == -1 || rangeWidth <= width {
+			count, width = coverageRange.Count, rangeWidth
+		}
+	}
+	return count
+}
@@ -0,0 +1,144 @@
+package agouti_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+	"github.com/sclevine/agouti/istanbul"
+)
+
+var _ = Describe("JavaScript Coverage", func() {
+	var (
+		page    *Page
+		session *mocks.Session
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		page = NewTestPage(session)
+	})
+
+	Describe("#StartJSCoverage", func() {
+		It("should start collecting precise coverage", func() {
+			Expect(page.StartJSCoverage()).To(Succeed())
+			Expect(session.ExecuteCDPCall.Command).To(Equal("Profiler.startPreciseCoverage"))
+			Expect(session.ExecuteCDPCall.Parameters).To(BeEquivalentTo(struct {
+				CallCount bool `json:"callCount"`
+				Detailed  bool `json:"detailed"`
+			}{true, true}))
+		})
+
+		Context("when the browser does not support the DevTools Protocol", func() {
+			It("should return an error", func() {
+				session.ExecuteCDPCall.Err = errors.New("some error")
+				Expect(page.StartJSCoverage()).To(MatchError("failed to start JavaScript coverage: some error"))
+			})
+		})
+	})
+
+	Describe("#StopJSCoverage", func() {
+		BeforeEach(func() {
+			session.ExecuteCDPCall.Result = `{
+				"result": [{"scriptId": "12", "url": "http://example.com/app.js", "functions": [
+					{"functionName": "add", "isBlockCoverage": true, "ranges": [{"startOffset": 0, "endOffset": 20, "count": 3}]}
+				]}],
+				"scriptSource": "function add() {}"
+			}`
+		})
+
+		It("should return the coverage and source of each script", func() {
+			coverage, err := page.StopJSCoverage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(coverage).To(Equal([]JSCoverage{{
+				URL:    "http://example.com/app.js",
+				Source: "function add() {}",
+				Functions: []JSFunctionCoverage{{
+					Name:            "add",
+					Ranges:          []JSCoverageRange{{StartOffset: 0, EndOffset: 20, Count: 3}},
+					IsBlockCoverage: true,
+				}},
+			}}))
+		})
+
+		It("should stop collecting coverage", func() {
+			page.StopJSCoverage()
+			Expect(session.ExecuteCDPCall.Command).To(Equal("Debugger.disable"))
+		})
+
+		Context("when the coverage cannot be retrieved", func() {
+			It("should return an error", func() {
+				session.ExecuteCDPCall.Err = errors.New("some error")
+				_, err := page.StopJSCoverage()
+				Expect(err).To(MatchError("failed to stop JavaScript coverage: some error"))
+			})
+		})
+	})
+
+	Describe(".IstanbulCoverage", func() {
+		var (
+			source   string
+			coverage []JSCoverage
+		)
+
+		BeforeEach(func() {
+			source = "function add(a, b) {\n  if (a) {\n    return a + b;\n  }\n  return b;\n}\n\nadd(0, 1);\n"
+			coverage = []JSCoverage{{
+				URL:    "http://example.com/app.js",
+				Source: source,
+				Functions: []JSFunctionCoverage{
+					{Ranges: []JSCoverageRange{{StartOffset: 0, EndOffset: len(source), Count: 1}}, IsBlockCoverage: true},
+					{Name: "add", Ranges: []JSCoverageRange{
+						{StartOffset: 0, EndOffset: strings.Index(source, "\n\n"), Count: 1},
+						{StartOffset: strings.Index(source, "{\n    return"), EndOffset: strings.Index(source, "\n  return b"), Count: 0},
+					}, IsBlockCoverage: true},
+				},
+			}}
+		})
+
+		It("should report each non-blank line as a statement with the count of its innermost range", func() {
+			fileCoverage := IstanbulCoverage(coverage, nil)["http://example.com/app.js"]
+			Expect(fileCoverage.Path).To(Equal("http://example.com/app.js"))
+			Expect(fileCoverage.StatementMap).To(HaveLen(7))
+			Expect(fileCoverage.StatementMap["1"]).To(Equal(istanbul.Range{
+				Start: istanbul.Location{Line: 2, Column: 2},
+				End:   istanbul.Location{Line: 2, Column: 10},
+			}))
+			Expect(fileCoverage.S).To(Equal(map[string]int{"0": 1, "1": 1, "2": 0, "3": 0, "4": 1, "5": 1, "6": 1}))
+		})
+
+		It("should report named functions and blocks", func() {
+			fileCoverage := IstanbulCoverage(coverage, nil)["http://example.com/app.js"]
+			Expect(fileCoverage.FnMap).To(HaveLen(1))
+			Expect(fileCoverage.FnMap["0"].Name).To(Equal("add"))
+			Expect(fileCoverage.FnMap["0"].Loc.End).To(Equal(istanbul.Location{Line: 6, Column: 1}))
+			Expect(fileCoverage.F).To(Equal(map[string]int{"0": 1}))
+			Expect(fileCoverage.BranchMap["0"].Loc.Start).To(Equal(istanbul.Location{Line: 2, Column: 9}))
+			Expect(fileCoverage.B).To(Equal(map[string][]int{"0": {0}}))
+		})
+
+		It("should add the counts of scripts with the same path", func() {
+			fileCoverage := IstanbulCoverage(append(coverage, coverage...), nil)["http://example.com/app.js"]
+			Expect(fileCoverage.S["0"]).To(Equal(2))
+			Expect(fileCoverage.F["0"]).To(Equal(2))
+		})
+
+		It("should store each script under the path for its URL", func() {
+			coverageMap := IstanbulCoverage(append(coverage, JSCoverage{URL: "http://cdn.example.com/lib.js"}), func(url string) string {
+				if strings.HasPrefix(url, "http://example.com/") {
+					return "src/" + strings.TrimPrefix(url, "http://example.com/")
+				}
+				return ""
+			})
+			Expect(coverageMap).To(HaveLen(1))
+			Expect(coverageMap).To(HaveKey("src/app.js"))
+		})
+
+		It("should omit scripts without a URL", func() {
+			Expect(IstanbulCoverage([]JSCoverage{{Source: "eval()"}}, nil)).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,103 @@
+package agouti
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A JSError represents an uncaught JavaScript exception or unhandled promise
+// rejection collected from a page opened with the CollectJSErrors Option.
+type JSError struct {
+	// Message is the error message, ex. "Uncaught TypeError: x is undefined"
+	Message string
+
+	// Source is the URL of the script that threw the error, if known
+	Source string
+
+	// Line and Column are the position in the source that threw the error, or 0 if unknown
+	Line   int
+	Column int
+
+	// Stack is the JavaScript stack trace of the error, if available
+	Stack string
+}
+
+const jsErrorHook = `
+	if (window.__agoutiJSErrors) {
+		return;
+	}
+	var errors = window.__agoutiJSErrors = [];
+	window.addEventListener("error", function(event) {
+		errors.push({
+			message: event.message,
+			source: event.filename || "",
+			line: event.lineno || 0,
+			column: event.colno || 0,
+			stack: (event.error && event.error.stack) || ""
+		});
+	});
+	window.addEventListener("unhandledrejection", function(event) {
+		var reason = event.reason;
+		errors.push({
+			message: "Unhandled promise rejection: " + ((reason && reason.message) || String(reason)),
+			source: "",
+			line: 0,
+			column: 0,
+			stack: (reason && reason.stack) || ""
+		});
+	});`
+
+const jsErrorRead = `return (window.__agoutiJSErrors || []).splice(0);`
+
+// JSErrors returns every JavaScript error collected since the page was
+// opened. The page must have been opened with the CollectJSErrors Option.
+// Errors are retrieved from each document before the page navigates away
+// from it using Navigate, Back, Forward, or Refresh. When the browser does not
+// support init scripts (see *api.Session.AddInitScript), errors are only
+// collected from documents loaded by those methods, after they are loaded.
+func (p *Page) JSErrors() ([]JSError, error) {
+	if !p.collectJSErrors {
+		return nil, errors.New("failed to retrieve JavaScript errors: page was not opened with the CollectJSErrors Option")
+	}
+
+	if err := p.readJSErrors(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve JavaScript errors: %s", err)
+	}
+	if !p.jsErrorHookPreloaded {
+		p.session.Execute(jsErrorHook, nil, nil)
+	}
+	return append([]JSError(nil), p.jsErrors...), nil
+}
+
+// The hook is also added to the current document, as init scripts only run
+// in new documents.
+func (p *Page) startCollectingJSErrors() {
+	p.collectJSErrors = true
+	p.jsErrorHookPreloaded = p.session.AddInitScript(jsErrorHook) == nil
+	p.session.Execute(jsErrorHook, nil, nil)
+}
+
+func (p *Page) readJSErrors() error {
+	var jsErrors []JSError
+	if err := p.session.Execute(jsErrorRead, nil, &jsErrors); err != nil {
+		return err
+	}
+	p.jsErrors = append(p.jsErrors, jsErrors...)
+	return nil
+}
+
+// Errors from the current document cannot be reported if they are not read
+// before navigating, so failures to read them are not fatal.
+func (p *Page) navigate(navigate func() error) error {
+	if p.collectJSErrors {
+		p.readJSErrors()
+	}
+	if err := navigate(); err != nil {
+		return err
+	}
+	if p.collectJSErrors && !p.jsErrorHookPreloaded {
+		p.session.Execute(jsErrorHook, nil, nil)
+	}
+	p.runLoadScripts()
+	return nil
+}
@@ -0,0 +1,122 @@
+package agouti_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("JavaScript Error Collection", func() {
+	var session *mocks.Session
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+	})
+
+	Context("when a page starts collecting JavaScript errors", func() {
+		It("should add the error hook to new documents and the current document", func() {
+			NewTestPageCollectingJSErrors(session)
+			Expect(session.AddInitScriptCall.Script).To(ContainSubstring(`window.addEventListener("error"`))
+			Expect(session.AddInitScriptCall.Script).To(ContainSubstring(`window.addEventListener("unhandledrejection"`))
+			Expect(session.ExecuteCall.Body).To(Equal(session.AddInitScriptCall.Script))
+		})
+	})
+
+	Describe("#JSErrors", func() {
+		var page *Page
+
+		BeforeEach(func() {
+			page = NewTestPageCollectingJSErrors(session)
+			session.ExecuteCall.Result = `[{
+				"message": "Uncaught Error: some error",
+				"source": "http://example.com/app.js",
+				"line": 12,
+				"column": 34,
+				"stack": "Error: some error\n    at app.js:12:34"
+			}]`
+		})
+
+		It("should read and clear the errors collected by the current document", func() {
+			jsErrors, err := page.JSErrors()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.ExecuteCall.Body).To(Equal("return (window.__agoutiJSErrors || []).splice(0);"))
+			Expect(jsErrors).To(Equal([]JSError{{
+				Message: "Uncaught Error: some error",
+				Source:  "http://example.com/app.js",
+				Line:    12,
+				Column:  34,
+				Stack:   "Error: some error\n    at app.js:12:34",
+			}}))
+		})
+
+		It("should return previously read errors", func() {
+			page.JSErrors()
+			session.ExecuteCall.Result = `[{"message": "some other error"}]`
+			jsErrors, err := page.JSErrors()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jsErrors).To(HaveLen(2))
+			Expect(jsErrors[0].Message).To(Equal("Uncaught Error: some error"))
+			Expect(jsErrors[1].Message).To(Equal("some other error"))
+		})
+
+		It("should read errors from the current document before navigating", func() {
+			Expect(page.Navigate("http://example.com/next")).To(Succeed())
+			Expect(session.SetURLCall.URL).To(Equal("http://example.com/next"))
+			session.ExecuteCall.Result = `[]`
+			jsErrors, err := page.JSErrors()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jsErrors).To(HaveLen(1))
+			Expect(jsErrors[0].Message).To(Equal("Uncaught Error: some error"))
+		})
+
+		It("should read errors from the current document before refreshing", func() {
+			Expect(page.Refresh()).To(Succeed())
+			Expect(session.RefreshCall.Called).To(BeTrue())
+			session.ExecuteCall.Result = `[]`
+			Expect(page.JSErrors()).To(HaveLen(1))
+		})
+
+		Context("when navigating fails", func() {
+			It("should return an error", func() {
+				session.SetURLCall.Err = errors.New("some error")
+				Expect(page.Navigate("http://example.com/next")).To(MatchError("failed to navigate: some error"))
+			})
+		})
+
+		Context("when the browser does not support init scripts", func() {
+			BeforeEach(func() {
+				session.AddInitScriptCall.Err = errors.New("some error")
+				page = NewTestPageCollectingJSErrors(session)
+			})
+
+			It("should add the error hook to the current document after reading errors", func() {
+				_, err := page.JSErrors()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(session.ExecuteCall.Body).To(ContainSubstring("window.__agoutiJSErrors = []"))
+			})
+
+			It("should add the error hook to documents loaded by navigating", func() {
+				Expect(page.Navigate("http://example.com/next")).To(Succeed())
+				Expect(session.ExecuteCall.Body).To(ContainSubstring("window.__agoutiJSErrors = []"))
+			})
+		})
+
+		Context("when the session fails to read the errors", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err := page.JSErrors()
+				Expect(err).To(MatchError("failed to retrieve JavaScript errors: some error"))
+			})
+		})
+
+		Context("when the page was not opened with the CollectJSErrors Option", func() {
+			It("should return an error", func() {
+				_, err := NewTestPage(session).JSErrors()
+				Expect(err).To(MatchError("failed to retrieve JavaScript errors: page was not opened with the CollectJSErrors Option"))
+			})
+		})
+	})
+})
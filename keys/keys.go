@@ -0,0 +1,138 @@
+// Package keys provides the WebDriver key codes for keys that cannot be
+// typed as text, such as Enter, Tab, the arrow keys, and modifier keys.
+//
+// Keys may be combined with text and passed to Selection.SendKeys:
+//    selection.SendKeys("agouti", keys.Enter)
+// Modifier keys remain pressed until the end of the key sequence, so keyboard
+// shortcuts are sent by placing the modifier before the key it modifies:
+//    selection.SendKeys(keys.Control, "a")
+// Use Chord to release the modifiers before the rest of the sequence:
+//    selection.SendKeys(keys.Chord(keys.Control, "a"), "replacement text")
+package keys
+
+import "strings"
+
+const (
+	Null      = "\uE000"
+	Cancel    = "\uE001"
+	Help      = "\uE002"
+	Backspace = "\uE003"
+	Tab       = "\uE004"
+	Clear     = "\uE005"
+	Return    = "\uE006"
+	Enter     = "\uE007"
+	Shift     = "\uE008"
+	Control   = "\uE009"
+	Alt       = "\uE00A"
+	Pause     = "\uE00B"
+	Escape    = "\uE00C"
+	Space     = "\uE00D"
+	PageUp    = "\uE00E"
+	PageDown  = "\uE00F"
+	End       = "\uE010"
+	Home      = "\uE011"
+	Left      = "\uE012"
+	Up        = "\uE013"
+	Right     = "\uE014"
+	Down      = "\uE015"
+	Insert    = "\uE016"
+	Delete    = "\uE017"
+	Semicolon = "\uE018"
+	Equals    = "\uE019"
+
+	Numpad0   = "\uE01A"
+	Numpad1   = "\uE01B"
+	Numpad2   = "\uE01C"
+	Numpad3   = "\uE01D"
+	Numpad4   = "\uE01E"
+	Numpad5   = "\uE01F"
+	Numpad6   = "\uE020"
+	Numpad7   = "\uE021"
+	Numpad8   = "\uE022"
+	Numpad9   = "\uE023"
+	Multiply  = "\uE024"
+	Add       = "\uE025"
+	Separator = "\uE026"
+	Subtract  = "\uE027"
+	Decimal   = "\uE028"
+	Divide    = "\uE029"
+
+	F1  = "\uE031"
+	F2  = "\uE032"
+	F3  = "\uE033"
+	F4  = "\uE034"
+	F5  = "\uE035"
+	F6  = "\uE036"
+	F7  = "\uE037"
+	F8  = "\uE038"
+	F9  = "\uE039"
+	F10 = "\uE03A"
+	F11 = "\uE03B"
+	F12 = "\uE03C"
+
+	Meta           = "\uE03D"
+	Command        = Meta
+	ZenkakuHankaku = "\uE040"
+
+	RightShift   = "\uE050"
+	RightControl = "\uE051"
+	RightAlt     = "\uE052"
+	RightMeta    = "\uE053"
+
+	NumpadPageUp   = "\uE054"
+	NumpadPageDown = "\uE055"
+	NumpadEnd      = "\uE056"
+	NumpadHome     = "\uE057"
+	NumpadLeft     = "\uE058"
+	NumpadUp       = "\uE059"
+	NumpadRight    = "\uE05A"
+	NumpadDown     = "\uE05B"
+	NumpadInsert   = "\uE05C"
+	NumpadDelete   = "\uE05D"
+)
+
+var modifiers = map[rune]bool{
+	[]rune(Shift)[0]:        true,
+	[]rune(Control)[0]:      true,
+	[]rune(Alt)[0]:          true,
+	[]rune(Meta)[0]:         true,
+	[]rune(RightShift)[0]:   true,
+	[]rune(RightControl)[0]: true,
+	[]rune(RightAlt)[0]:     true,
+	[]rune(RightMeta)[0]:    true,
+}
+
+// IsModifier returns true if the provided key is a modifier key, such as
+// Shift, Control, Alt, or Meta.
+func IsModifier(key string) bool {
+	runes := []rune(key)
+	return len(runes) == 1 && modifiers[runes[0]]
+}
+
+// Chord returns a key sequence that presses the provided keys in order and
+// then releases any modifier keys that were pressed.
+func Chord(keys ...string) string {
+	return strings.Join(keys, "") + Null
+}
+
+// Sequence joins the provided keys into a single key sequence. If a modifier
+// key would otherwise remain pressed at the end of the sequence, the sequence
+// is terminated with Null so that the modifier is released.
+func Sequence(keys ...string) string {
+	sequence := strings.Join(keys, "")
+
+	held := false
+	for _, key := range sequence {
+		switch {
+		case modifiers[key]:
+			held = true
+		case string(key) == Null:
+			held = false
+		}
+	}
+
+	if held {
+		return sequence + Null
+	}
+	return sequence
+}
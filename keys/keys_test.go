@@ -0,0 +1,49 @@
+package keys_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/keys"
+)
+
+var _ = Describe("Keys", func() {
+	Describe(".IsModifier", func() {
+		It("should return true for modifier keys", func() {
+			Expect(IsModifier(Shift)).To(BeTrue())
+			Expect(IsModifier(Control)).To(BeTrue())
+			Expect(IsModifier(Alt)).To(BeTrue())
+			Expect(IsModifier(Command)).To(BeTrue())
+			Expect(IsModifier(RightMeta)).To(BeTrue())
+		})
+
+		It("should return false for other keys", func() {
+			Expect(IsModifier(Enter)).To(BeFalse())
+			Expect(IsModifier("a")).To(BeFalse())
+			Expect(IsModifier(Shift + "a")).To(BeFalse())
+		})
+	})
+
+	Describe(".Chord", func() {
+		It("should join the keys and release the modifiers", func() {
+			Expect(Chord(Control, Shift, "t")).To(Equal(Control + Shift + "t" + Null))
+		})
+	})
+
+	Describe(".Sequence", func() {
+		It("should join the keys", func() {
+			Expect(Sequence("some", Space, "text", Enter)).To(Equal("some" + Space + "text" + Enter))
+		})
+
+		Context("when a modifier remains pressed at the end of the sequence", func() {
+			It("should release the modifier", func() {
+				Expect(Sequence(Control, "a")).To(Equal(Control + "a" + Null))
+			})
+		})
+
+		Context("when the modifiers are already released", func() {
+			It("should not add another release", func() {
+				Expect(Sequence(Chord(Control, "a"), "text")).To(Equal(Control + "a" + Null + "text"))
+			})
+		})
+	})
+})
@@ -0,0 +1,20 @@
+package matchers
+
+import "github.com/sclevine/agouti/matchers/internal"
+
+// SaveSnapshotsTo enables saving a snapshot of the page whenever an agouti
+// matcher fails. The snapshot consists of a screenshot and the HTML source
+// of the page, which are saved to the provided directory using
+// *Page.SaveSnapshot, and their paths are included in the failure message.
+// Providing an empty directory disables snapshots.
+//
+// Snapshots may also be enabled without changing any code by setting the
+// AGOUTI_ARTIFACTS_DIR environment variable to the directory, ex. in CI.
+//
+// Example:
+//    var _ = BeforeSuite(func() {
+//        matchers.SaveSnapshotsTo("artifacts")
+//    })
+func SaveSnapshotsTo(directory string) {
+	internal.SetArtifactsDirectory(directory)
+}
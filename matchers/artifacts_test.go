@@ -0,0 +1,26 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("Failure Artifacts", func() {
+	AfterEach(func() {
+		SaveSnapshotsTo("")
+	})
+
+	Describe("#SaveSnapshotsTo", func() {
+		It("should save a snapshot to the provided directory when a matcher fails", func() {
+			selection := &mocks.Selection{}
+			selection.SaveSnapshotCall.ReturnPaths = []string{"some/directory/failure.png"}
+			SaveSnapshotsTo("some/directory")
+			matcher := HaveText("some text")
+			Expect(matcher.Match(selection)).To(BeFalse())
+			Expect(matcher.FailureMessage(selection)).To(HaveSuffix("Snapshot saved to:\n    some/directory/failure.png"))
+			Expect(selection.SaveSnapshotCall.Directory).To(Equal("some/directory"))
+		})
+	})
+})
@@ -0,0 +1,56 @@
+package matchers
+
+import (
+	"github.com/onsi/gomega/types"
+	"github.com/sclevine/agouti/matchers/internal"
+)
+
+// A DOMSnapshotOption configures HaveDOMMatchingSnapshot.
+type DOMSnapshotOption func(*internal.MatchDOMSnapshotMatcher)
+
+// SnapshotSelector provides a DOMSnapshotOption for serializing only the
+// element selected by the provided CSS selector, instead of the entire
+// document.
+func SnapshotSelector(selector string) DOMSnapshotOption {
+	return func(m *internal.MatchDOMSnapshotMatcher) {
+		m.Options.Selector = selector
+	}
+}
+
+// StripAttributes provides a DOMSnapshotOption for removing volatile
+// attributes, such as generated IDs, from the snapshot. Names that end in "*"
+// remove every attribute with the preceding prefix.
+func StripAttributes(names ...string) DOMSnapshotOption {
+	return func(m *internal.MatchDOMSnapshotMatcher) {
+		m.Options.StripAttributes = append(m.Options.StripAttributes, names...)
+	}
+}
+
+// MaskElements provides a DOMSnapshotOption for replacing the contents of the
+// elements selected by the provided CSS selectors with "...", such as
+// elements that display the current time.
+func MaskElements(selectors ...string) DOMSnapshotOption {
+	return func(m *internal.MatchDOMSnapshotMatcher) {
+		m.Options.MaskSelectors = append(m.Options.MaskSelectors, selectors...)
+	}
+}
+
+// HaveDOMMatchingSnapshot passes when the normalized DOM snapshot of the
+// provided *Page matches the golden snapshot at the provided path. See
+// *agouti.Page.DOMSnapshot for the snapshot format. When the snapshot does
+// not match, the first differing line is reported, and the snapshot is saved
+// next to the golden snapshot with the suffix ".actual.html".
+//
+// As with MatchScreenshot, when the AGOUTI_UPDATE_GOLDENS environment variable
+// is set to any non-empty value, the snapshot is saved as the golden snapshot
+// instead, and the matcher passes.
+//
+// Example:
+//    Expect(page).To(HaveDOMMatchingSnapshot("golden/cart.html", SnapshotSelector("#cart"), StripAttributes("data-v-*")))
+func HaveDOMMatchingSnapshot(golden string, options ...DOMSnapshotOption) types.GomegaMatcher {
+	matcher := &internal.MatchDOMSnapshotMatcher{Golden: golden}
+	for _, option := range options {
+		option(matcher)
+	}
+	return matcher
+}
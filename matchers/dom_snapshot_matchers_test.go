@@ -0,0 +1,52 @@
+package matchers_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti"
+	. "github.com/sclevine/agouti/matchers"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("DOM Snapshot Matchers", func() {
+	var (
+		page      *mocks.Page
+		directory string
+		golden    string
+	)
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "golden")
+		Expect(err).NotTo(HaveOccurred())
+		golden = filepath.Join(directory, "cart.html")
+		Expect(ioutil.WriteFile(golden, []byte("<ul></ul>\n"), 0666)).To(Succeed())
+
+		page = &mocks.Page{}
+		page.DOMSnapshotCall.ReturnSnapshot = "<ul></ul>\n"
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+	})
+
+	Describe("#HaveDOMMatchingSnapshot", func() {
+		It("should return a MatchDOMSnapshotMatcher for the provided golden snapshot", func() {
+			Expect(page).To(HaveDOMMatchingSnapshot(golden))
+			Expect(page).NotTo(HaveDOMMatchingSnapshot(filepath.Join(directory, "missing.html")))
+		})
+
+		It("should apply the provided options", func() {
+			Expect(page).To(HaveDOMMatchingSnapshot(golden, SnapshotSelector("ul"), StripAttributes("id", "data-v-*"), MaskElements("time")))
+			Expect(page.DOMSnapshotCall.Options).To(Equal(agouti.DOMSnapshotOptions{
+				Selector:        "ul",
+				StripAttributes: []string{"id", "data-v-*"},
+				MaskSelectors:   []string{"time"},
+			}))
+		})
+	})
+})
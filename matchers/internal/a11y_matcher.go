@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/sclevine/agouti"
+)
+
+type A11yMatcher struct {
+	Impact     string
+	violations []agouti.AxeRule
+}
+
+func (m *A11yMatcher) Match(actual interface{}) (success bool, err error) {
+	actualAuditor, ok := actual.(interface {
+		RunAccessibilityAudit(options agouti.AxeOptions) (*agouti.AxeResults, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("HaveNoA11yViolations matcher requires a *Page or *Selection.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	results, err := actualAuditor.RunAccessibilityAudit(agouti.AxeOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	m.violations = results.ViolationsWithImpact(m.Impact)
+	return len(m.violations) == 0, nil
+}
+
+func (m *A11yMatcher) FailureMessage(actual interface{}) (message string) {
+	var descriptions []string
+	for _, violation := range m.violations {
+		description := fmt.Sprintf("%s (%s): %s", violation.ID, violation.Impact, violation.Help)
+		for _, node := range violation.Nodes {
+			description += "\n" + tab + tab + strings.Join(node.Target, " ")
+		}
+		descriptions = append(descriptions, description)
+	}
+	return equalityMessage(actual, "to have no accessibility violations"+m.impactMessage()+", but found", strings.Join(descriptions, "\n"+tab))
+}
+
+func (m *A11yMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return booleanMessage(actual, "to have accessibility violations"+m.impactMessage())
+}
+
+func (m *A11yMatcher) impactMessage() string {
+	if m.Impact == "" {
+		return ""
+	}
+	return fmt.Sprintf(" with at least %s impact", m.Impact)
+}
@@ -0,0 +1,88 @@
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti"
+	. "github.com/sclevine/agouti/matchers/internal"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("A11yMatcher", func() {
+	var (
+		matcher *A11yMatcher
+		page    *mocks.Page
+	)
+
+	BeforeEach(func() {
+		page = &mocks.Page{}
+		page.RunAccessibilityAuditCall.ReturnResults = &agouti.AxeResults{
+			Violations: []agouti.AxeRule{
+				{ID: "label", Impact: "critical", Help: "Form elements must have labels", Nodes: []agouti.AxeNode{
+					{Target: []string{"#email"}},
+					{Target: []string{"iframe", "#password"}},
+				}},
+				{ID: "region", Impact: "moderate", Help: "All page content should be contained by landmarks"},
+			},
+		}
+		matcher = &A11yMatcher{Impact: "serious"}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object can be audited", func() {
+			It("should run an audit with the default options", func() {
+				matcher.Match(page)
+				Expect(page.RunAccessibilityAuditCall.Options).To(Equal(agouti.AxeOptions{}))
+			})
+
+			Context("when there are no violations with at least the expected impact", func() {
+				It("should successfully return true", func() {
+					page.RunAccessibilityAuditCall.ReturnResults.Violations = page.RunAccessibilityAuditCall.ReturnResults.Violations[1:]
+					Expect(matcher.Match(page)).To(BeTrue())
+				})
+			})
+
+			Context("when there are violations with at least the expected impact", func() {
+				It("should successfully return false", func() {
+					Expect(matcher.Match(page)).To(BeFalse())
+				})
+			})
+
+			Context("when the audit fails", func() {
+				It("should return an error", func() {
+					page.RunAccessibilityAuditCall.Err = errors.New("some error")
+					_, err := matcher.Match(page)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object cannot be audited", func() {
+			It("should return an error", func() {
+				_, err := matcher.Match("not a page")
+				Expect(err).To(MatchError("HaveNoA11yViolations matcher requires a *Page or *Selection.  Got:\n    <string>: not a page"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("should return a failure message listing the violations and their elements", func() {
+			matcher.Match(page)
+			message := matcher.FailureMessage(page)
+			Expect(message).To(Equal("Expected page to have no accessibility violations with at least serious impact, but found\n" +
+				"    label (critical): Form elements must have labels\n" +
+				"        #email\n" +
+				"        iframe #password"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("should return a negated failure message", func() {
+			matcher.Impact = ""
+			message := matcher.NegatedFailureMessage(page)
+			Expect(message).To(Equal("Expected page to have accessibility violations"))
+		})
+	})
+})
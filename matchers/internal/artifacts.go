@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ArtifactsDirectoryEnv is the environment variable that provides the
+// artifacts directory when none is set using SetArtifactsDirectory.
+const ArtifactsDirectoryEnv = "AGOUTI_ARTIFACTS_DIR"
+
+var failureArtifacts struct {
+	sync.Mutex
+	directory string
+	count     int
+}
+
+// SetArtifactsDirectory sets the directory that snapshots are saved to when a
+// failure message is generated. An empty directory disables snapshots, unless
+// the directory is provided by ArtifactsDirectoryEnv.
+func SetArtifactsDirectory(directory string) {
+	failureArtifacts.Lock()
+	defer failureArtifacts.Unlock()
+	failureArtifacts.directory = directory
+}
+
+func nextArtifactName() (directory, name string) {
+	failureArtifacts.Lock()
+	defer failureArtifacts.Unlock()
+	directory = failureArtifacts.directory
+	if directory == "" {
+		directory = os.Getenv(ArtifactsDirectoryEnv)
+	}
+	if directory == "" {
+		return "", ""
+	}
+	failureArtifacts.count++
+	name = fmt.Sprintf("failure-%s-%d", time.Now().Format("20060102-150405"), failureArtifacts.count)
+	return directory, name
+}
+
+func artifactsMessage(actual interface{}) string {
+	snapshotter, ok := actual.(interface {
+		SaveSnapshot(directory, name string) ([]string, error)
+	})
+	if !ok {
+		return ""
+	}
+
+	directory, name := nextArtifactName()
+	if directory == "" {
+		return ""
+	}
+
+	paths, err := snapshotter.SaveSnapshot(directory, name)
+	message := ""
+	if len(paths) > 0 {
+		message += fmt.Sprintf("\nSnapshot saved to:\n%s%s", tab, strings.Join(paths, "\n"+tab))
+	}
+	if err != nil {
+		message += fmt.Sprintf("\nFailed to save snapshot: %s", err)
+	}
+	return message
+}
@@ -0,0 +1,75 @@
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers/internal"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("Failure artifacts", func() {
+	var (
+		matcher   *ValueMatcher
+		selection *mocks.Selection
+	)
+
+	BeforeEach(func() {
+		selection = &mocks.Selection{}
+		selection.StringCall.ReturnString = "selection 'CSS: #selector'"
+		selection.TextCall.ReturnText = "some other text"
+		matcher = &ValueMatcher{Method: "Text", Property: "text", Expected: "some text"}
+		matcher.Match(selection)
+	})
+
+	AfterEach(func() {
+		SetArtifactsDirectory("")
+	})
+
+	Context("when no artifacts directory is set", func() {
+		It("should not save a snapshot", func() {
+			message := matcher.FailureMessage(selection)
+			Expect(message).NotTo(ContainSubstring("Snapshot"))
+			Expect(selection.SaveSnapshotCall.Directory).To(BeEmpty())
+		})
+	})
+
+	Context("when an artifacts directory is set", func() {
+		BeforeEach(func() {
+			SetArtifactsDirectory("some/directory")
+		})
+
+		It("should save a uniquely named snapshot to the directory", func() {
+			matcher.FailureMessage(selection)
+			Expect(selection.SaveSnapshotCall.Directory).To(Equal("some/directory"))
+			firstName := selection.SaveSnapshotCall.Name
+			Expect(firstName).To(HavePrefix("failure-"))
+			matcher.NegatedFailureMessage(selection)
+			Expect(selection.SaveSnapshotCall.Name).NotTo(Equal(firstName))
+		})
+
+		It("should include the paths of the saved files at the end of the failure message", func() {
+			selection.SaveSnapshotCall.ReturnPaths = []string{"some/directory/failure.png", "some/directory/failure.html"}
+			message := matcher.FailureMessage(selection)
+			Expect(message).To(HavePrefix("Expected selection 'CSS: #selector' to have text equaling\n    some text"))
+			Expect(message).To(HaveSuffix("\nSnapshot saved to:\n    some/directory/failure.png\n    some/directory/failure.html"))
+		})
+
+		Context("when saving the snapshot fails", func() {
+			It("should include the error and any saved paths in the failure message", func() {
+				selection.SaveSnapshotCall.ReturnPaths = []string{"some/directory/failure.html"}
+				selection.SaveSnapshotCall.Err = errors.New("some error")
+				message := matcher.FailureMessage(selection)
+				Expect(message).To(HaveSuffix("\nSnapshot saved to:\n    some/directory/failure.html\nFailed to save snapshot: some error"))
+			})
+		})
+
+		Context("when the actual object cannot save a snapshot", func() {
+			It("should return the failure message without a snapshot", func() {
+				matcher := &BooleanMatcher{Method: "Visible", Property: "visible"}
+				Expect(matcher.FailureMessage("not a selection")).To(Equal("Expected not a selection to be visible"))
+			})
+		})
+	})
+})
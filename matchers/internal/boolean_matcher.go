@@ -3,19 +3,23 @@ package internal
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/onsi/gomega/format"
+	"github.com/sclevine/agouti"
 )
 
 type BooleanMatcher struct {
 	Method   string
 	Property string
+	Name     string
+	Report   bool
 }
 
 func (m *BooleanMatcher) Match(actual interface{}) (success bool, err error) {
 	method := reflect.ValueOf(actual).MethodByName(m.Method)
 	if !method.IsValid() {
-		return false, fmt.Errorf("Be%s matcher requires a *Selection.  Got:\n%s", m.Method, format.Object(actual, 1))
+		return false, fmt.Errorf("%s matcher requires a *Selection.  Got:\n%s", m.name(), format.Object(actual, 1))
 	}
 
 	results := method.Call(nil)
@@ -28,9 +32,31 @@ func (m *BooleanMatcher) Match(actual interface{}) (success bool, err error) {
 }
 
 func (m *BooleanMatcher) FailureMessage(actual interface{}) (message string) {
-	return booleanMessage(actual, "to be "+m.Property)
+	return booleanMessage(actual, "to be "+m.Property+m.report(actual))
 }
 
 func (m *BooleanMatcher) NegatedFailureMessage(actual interface{}) (message string) {
-	return booleanMessage(actual, "not to be "+m.Property)
+	return booleanMessage(actual, "not to be "+m.Property+m.report(actual))
+}
+
+func (m *BooleanMatcher) name() string {
+	if m.Name != "" {
+		return m.Name
+	}
+	return "Be" + m.Method
+}
+
+func (m *BooleanMatcher) report(actual interface{}) string {
+	reporter, ok := actual.(interface {
+		Report() (*agouti.ElementReport, error)
+	})
+	if !m.Report || !ok {
+		return ""
+	}
+
+	report, err := reporter.Report()
+	if err != nil {
+		return fmt.Sprintf("\n%selement report unavailable: %s", tab, err)
+	}
+	return "\n" + tab + strings.Replace(report.String(), "\n", "\n"+tab, -1)
 }
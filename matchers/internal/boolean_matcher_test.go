@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti"
 	. "github.com/sclevine/agouti/matchers/internal"
 	"github.com/sclevine/agouti/matchers/internal/mocks"
 )
@@ -71,4 +72,36 @@ var _ = Describe("BooleanMatcher", func() {
 			Expect(message).To(Equal("Expected selection 'CSS: #selector' not to be visible"))
 		})
 	})
+
+	Context("when the matcher reports on the element", func() {
+		BeforeEach(func() {
+			matcher = &BooleanMatcher{Method: "Visible", Property: "visible", Name: "BeShown", Report: true}
+		})
+
+		It("should include the element report in the failure messages", func() {
+			selection.ReportCall.ReturnReport = &agouti.ElementReport{
+				Element:    "div#dialog",
+				Display:    "none",
+				Visibility: "visible",
+				Opacity:    "1",
+				CoveredBy:  "div#overlay",
+			}
+			message := matcher.FailureMessage(selection)
+			Expect(message).To(HavePrefix("Expected selection 'CSS: #selector' to be visible\n    element: div#dialog\n"))
+			Expect(message).To(ContainSubstring("\n    display: none, visibility: visible, opacity: 1\n"))
+			Expect(message).To(HaveSuffix("\n    covered by: div#overlay"))
+			Expect(matcher.NegatedFailureMessage(selection)).To(HavePrefix("Expected selection 'CSS: #selector' not to be visible\n    element: div#dialog\n"))
+		})
+
+		It("should include the error when the element report cannot be retrieved", func() {
+			selection.ReportCall.Err = errors.New("some error")
+			message := matcher.FailureMessage(selection)
+			Expect(message).To(Equal("Expected selection 'CSS: #selector' to be visible\n    element report unavailable: some error"))
+		})
+
+		It("should use the provided name in errors", func() {
+			_, err := matcher.Match("missing method")
+			Expect(err).To(MatchError("BeShown matcher requires a *Selection.  Got:\n    <string>: missing method"))
+		})
+	})
 })
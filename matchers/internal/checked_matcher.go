@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type CheckedMatcher struct {
+	Checked bool
+}
+
+func (m *CheckedMatcher) Match(actual interface{}) (success bool, err error) {
+	actualSelection, ok := actual.(interface {
+		Selected() (bool, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("%s matcher requires a *Selection.  Got:\n%s", m.name(), format.Object(actual, 1))
+	}
+
+	selected, err := actualSelection.Selected()
+	if err != nil {
+		return false, err
+	}
+	return selected == m.Checked, nil
+}
+
+func (m *CheckedMatcher) FailureMessage(actual interface{}) (message string) {
+	return booleanMessage(actual, "to be "+m.state())
+}
+
+func (m *CheckedMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return booleanMessage(actual, "not to be "+m.state())
+}
+
+func (m *CheckedMatcher) name() string {
+	if m.Checked {
+		return "BeChecked"
+	}
+	return "BeUnchecked"
+}
+
+func (m *CheckedMatcher) state() string {
+	if m.Checked {
+		return "checked"
+	}
+	return "unchecked"
+}
@@ -0,0 +1,74 @@
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers/internal"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("CheckedMatcher", func() {
+	var (
+		matcher   *CheckedMatcher
+		selection *mocks.Selection
+	)
+
+	BeforeEach(func() {
+		selection = &mocks.Selection{}
+		selection.StringCall.ReturnString = "selection 'CSS: #selector'"
+		matcher = &CheckedMatcher{Checked: true}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a selection", func() {
+			It("should successfully return whether the selection is checked", func() {
+				selection.SelectedCall.ReturnSelected = true
+				Expect(matcher.Match(selection)).To(BeTrue())
+				selection.SelectedCall.ReturnSelected = false
+				Expect(matcher.Match(selection)).To(BeFalse())
+			})
+
+			It("should successfully return whether the selection is unchecked when Checked is false", func() {
+				matcher.Checked = false
+				selection.SelectedCall.ReturnSelected = false
+				Expect(matcher.Match(selection)).To(BeTrue())
+				selection.SelectedCall.ReturnSelected = true
+				Expect(matcher.Match(selection)).To(BeFalse())
+			})
+
+			Context("when retrieving the state fails", func() {
+				It("should return an error", func() {
+					selection.SelectedCall.Err = errors.New("some error")
+					_, err := matcher.Match(selection)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a selection", func() {
+			It("should return an error", func() {
+				_, err := matcher.Match("not a selection")
+				Expect(err).To(MatchError("BeChecked matcher requires a *Selection.  Got:\n    <string>: not a selection"))
+				matcher.Checked = false
+				_, err = matcher.Match("not a selection")
+				Expect(err).To(MatchError("BeUnchecked matcher requires a *Selection.  Got:\n    <string>: not a selection"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("should return a failure message with the expected state", func() {
+			Expect(matcher.FailureMessage(selection)).To(Equal("Expected selection 'CSS: #selector' to be checked"))
+			matcher.Checked = false
+			Expect(matcher.FailureMessage(selection)).To(Equal("Expected selection 'CSS: #selector' to be unchecked"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("should return a negated failure message with the expected state", func() {
+			Expect(matcher.NegatedFailureMessage(selection)).To(Equal("Expected selection 'CSS: #selector' not to be checked"))
+		})
+	})
+})
@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type CompareCountMatcher struct {
+	Name        string
+	Comparison  string
+	Expected    int
+	actualCount int
+}
+
+func (m *CompareCountMatcher) Match(actual interface{}) (success bool, err error) {
+	actualSelection, ok := actual.(interface {
+		Count() (int, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("%s matcher requires a *Selection.  Got:\n%s", m.Name, format.Object(actual, 1))
+	}
+
+	m.actualCount, err = actualSelection.Count()
+	if err != nil {
+		return false, err
+	}
+
+	switch m.Comparison {
+	case "greater than":
+		return m.actualCount > m.Expected, nil
+	case "less than":
+		return m.actualCount < m.Expected, nil
+	}
+	return false, fmt.Errorf("%s matcher has invalid comparison: %s", m.Name, m.Comparison)
+}
+
+func (m *CompareCountMatcher) FailureMessage(actual interface{}) (message string) {
+	return valueMessage(actual, fmt.Sprintf("to have element count %s", m.Comparison), m.Expected, m.actualCount)
+}
+
+func (m *CompareCountMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return valueMessage(actual, fmt.Sprintf("not to have element count %s", m.Comparison), m.Expected, m.actualCount)
+}
@@ -0,0 +1,85 @@
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers/internal"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("CompareCountMatcher", func() {
+	var (
+		matcher   *CompareCountMatcher
+		selection *mocks.Selection
+	)
+
+	BeforeEach(func() {
+		selection = &mocks.Selection{}
+		selection.StringCall.ReturnString = "selection 'CSS: #selector'"
+		matcher = &CompareCountMatcher{Name: "HaveCountGreaterThan", Comparison: "greater than", Expected: 2}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a selection", func() {
+			It("should successfully return true when the count satisfies the comparison", func() {
+				selection.CountCall.ReturnCount = 3
+				Expect(matcher.Match(selection)).To(BeTrue())
+				matcher.Comparison = "less than"
+				selection.CountCall.ReturnCount = 1
+				Expect(matcher.Match(selection)).To(BeTrue())
+			})
+
+			It("should successfully return false when the count does not satisfy the comparison", func() {
+				selection.CountCall.ReturnCount = 2
+				Expect(matcher.Match(selection)).To(BeFalse())
+				matcher.Comparison = "less than"
+				Expect(matcher.Match(selection)).To(BeFalse())
+			})
+
+			Context("when retrieving the count fails", func() {
+				It("should return an error", func() {
+					selection.CountCall.Err = errors.New("some error")
+					_, err := matcher.Match(selection)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+
+			Context("when the comparison is invalid", func() {
+				It("should return an error", func() {
+					matcher.Comparison = "some comparison"
+					_, err := matcher.Match(selection)
+					Expect(err).To(MatchError("HaveCountGreaterThan matcher has invalid comparison: some comparison"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a selection", func() {
+			It("should return an error", func() {
+				_, err := matcher.Match("not a selection")
+				Expect(err).To(MatchError("HaveCountGreaterThan matcher requires a *Selection.  Got:\n    <string>: not a selection"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("should return a failure message with the comparison", func() {
+			selection.CountCall.ReturnCount = 1
+			matcher.Match(selection)
+			message := matcher.FailureMessage(selection)
+			Expect(message).To(ContainSubstring("Expected selection 'CSS: #selector' to have element count greater than\n    2"))
+			Expect(message).To(ContainSubstring("but found\n    1"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("should return a negated failure message with the comparison", func() {
+			selection.CountCall.ReturnCount = 3
+			matcher.Match(selection)
+			message := matcher.NegatedFailureMessage(selection)
+			Expect(message).To(ContainSubstring("Expected selection 'CSS: #selector' not to have element count greater than\n    2"))
+			Expect(message).To(ContainSubstring("but found\n    3"))
+		})
+	})
+})
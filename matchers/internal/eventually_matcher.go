@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onsi/gomega/types"
+)
+
+type EventuallyMatcher struct {
+	Matcher  types.GomegaMatcher
+	Timeout  time.Duration
+	Interval time.Duration
+}
+
+func (m *EventuallyMatcher) Match(actual interface{}) (success bool, err error) {
+	deadline := time.Now().Add(m.Timeout)
+	for {
+		success, err = m.Matcher.Match(actual)
+		if err == nil && success {
+			return true, nil
+		}
+
+		if time.Now().Add(m.Interval).After(deadline) {
+			if err != nil {
+				return false, fmt.Errorf("failed to match within %s: %s", m.Timeout, err)
+			}
+			return false, nil
+		}
+		time.Sleep(m.Interval)
+	}
+}
+
+func (m *EventuallyMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Timed out after %s.\n%s", m.Timeout, m.Matcher.FailureMessage(actual))
+}
+
+func (m *EventuallyMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return m.Matcher.NegatedFailureMessage(actual)
+}
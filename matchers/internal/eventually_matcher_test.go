@@ -0,0 +1,86 @@
+package internal_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers/internal"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+type attemptMatcher struct {
+	passAfter int
+	count     int
+}
+
+func (m *attemptMatcher) Match(actual interface{}) (bool, error) {
+	m.count++
+	return m.count >= m.passAfter, nil
+}
+
+func (m *attemptMatcher) FailureMessage(actual interface{}) string {
+	return "some failure"
+}
+
+func (m *attemptMatcher) NegatedFailureMessage(actual interface{}) string {
+	return "some negated failure"
+}
+
+var _ = Describe("EventuallyMatcher", func() {
+	var (
+		matcher   *EventuallyMatcher
+		selection *mocks.Selection
+	)
+
+	BeforeEach(func() {
+		selection = &mocks.Selection{}
+		selection.StringCall.ReturnString = "selection 'CSS: #selector'"
+		matcher = &EventuallyMatcher{
+			Matcher:  &ValueMatcher{Method: "Text", Property: "text", Expected: "some text"},
+			Timeout:  50 * time.Millisecond,
+			Interval: time.Millisecond,
+		}
+	})
+
+	Describe("#Match", func() {
+		It("should successfully return true as soon as the provided matcher matches", func() {
+			attempts := &attemptMatcher{passAfter: 3}
+			matcher.Matcher = attempts
+			Expect(matcher.Match(selection)).To(BeTrue())
+			Expect(attempts.count).To(Equal(3))
+		})
+
+		It("should successfully return false when the provided matcher does not match before the timeout", func() {
+			selection.TextCall.ReturnText = "some other text"
+			start := time.Now()
+			Expect(matcher.Match(selection)).To(BeFalse())
+			Expect(time.Since(start)).To(BeNumerically(">=", 40*time.Millisecond))
+		})
+
+		It("should return an error when the provided matcher only returns errors before the timeout", func() {
+			selection.TextCall.Err = errors.New("some error")
+			_, err := matcher.Match(selection)
+			Expect(err).To(MatchError("failed to match within 50ms: some error"))
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("should return the failure message of the provided matcher with the timeout", func() {
+			selection.TextCall.ReturnText = "some other text"
+			matcher.Match(selection)
+			message := matcher.FailureMessage(selection)
+			Expect(message).To(HavePrefix("Timed out after 50ms.\nExpected selection 'CSS: #selector' to have text equaling\n    some text"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("should return the negated failure message of the provided matcher", func() {
+			selection.TextCall.ReturnText = "some text"
+			matcher.Match(selection)
+			message := matcher.NegatedFailureMessage(selection)
+			Expect(message).To(HavePrefix("Expected selection 'CSS: #selector' not to have text equaling"))
+		})
+	})
+})
@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type HaveClassMatcher struct {
+	ExpectedClass string
+	actualClasses []string
+}
+
+func (m *HaveClassMatcher) Match(actual interface{}) (success bool, err error) {
+	actualSelection, ok := actual.(interface {
+		Classes() ([]string, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("HaveClass matcher requires a *Selection.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	m.actualClasses, err = actualSelection.Classes()
+	if err != nil {
+		return false, err
+	}
+
+	for _, class := range m.actualClasses {
+		if class == m.ExpectedClass {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *HaveClassMatcher) FailureMessage(actual interface{}) (message string) {
+	return valueMessage(actual, "to have class", m.ExpectedClass, m.actualClasses)
+}
+
+func (m *HaveClassMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return valueMessage(actual, "not to have class", m.ExpectedClass, m.actualClasses)
+}
@@ -0,0 +1,72 @@
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers/internal"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("HaveClassMatcher", func() {
+	var (
+		matcher   *HaveClassMatcher
+		selection *mocks.Selection
+	)
+
+	BeforeEach(func() {
+		selection = &mocks.Selection{}
+		selection.StringCall.ReturnString = "selection 'CSS: #selector'"
+		matcher = &HaveClassMatcher{ExpectedClass: "active"}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a selection", func() {
+			It("should successfully return true when the class list contains the expected class", func() {
+				selection.ClassesCall.ReturnClasses = []string{"some-class", "active"}
+				Expect(matcher.Match(selection)).To(BeTrue())
+			})
+
+			It("should successfully return false when only part of a class matches the expected class", func() {
+				selection.ClassesCall.ReturnClasses = []string{"inactive"}
+				Expect(matcher.Match(selection)).To(BeFalse())
+			})
+
+			Context("when retrieving the class list fails", func() {
+				It("should return an error", func() {
+					selection.ClassesCall.Err = errors.New("some error")
+					_, err := matcher.Match(selection)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a selection", func() {
+			It("should return an error", func() {
+				_, err := matcher.Match("not a selection")
+				Expect(err).To(MatchError("HaveClass matcher requires a *Selection.  Got:\n    <string>: not a selection"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("should return a failure message with the expected class and actual classes", func() {
+			selection.ClassesCall.ReturnClasses = []string{"inactive"}
+			matcher.Match(selection)
+			message := matcher.FailureMessage(selection)
+			Expect(message).To(ContainSubstring("Expected selection 'CSS: #selector' to have class\n    active"))
+			Expect(message).To(ContainSubstring("but found\n    [inactive]"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("should return a negated failure message with the expected class and actual classes", func() {
+			selection.ClassesCall.ReturnClasses = []string{"active"}
+			matcher.Match(selection)
+			message := matcher.NegatedFailureMessage(selection)
+			Expect(message).To(ContainSubstring("Expected selection 'CSS: #selector' not to have class\n    active"))
+			Expect(message).To(ContainSubstring("but found\n    [active]"))
+		})
+	})
+})
@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"github.com/sclevine/agouti"
+)
+
+type HaveTableRowMatcher struct {
+	Expected   interface{}
+	actualRows interface{}
+}
+
+func (m *HaveTableRowMatcher) Match(actual interface{}) (success bool, err error) {
+	actualSelection, ok := actual.(interface {
+		Table() (*agouti.Table, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("HaveTableRow matcher requires a *Selection.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	table, err := actualSelection.Table()
+	if err != nil {
+		return false, err
+	}
+
+	matcher, ok := m.Expected.(types.GomegaMatcher)
+	if !ok {
+		matcher = gomega.Equal(m.Expected)
+	}
+
+	var rows []interface{}
+	if len(table.Headers) > 0 {
+		for _, record := range table.Records() {
+			rows = append(rows, record)
+		}
+		m.actualRows = table.Records()
+	} else {
+		for _, row := range table.Rows {
+			rows = append(rows, row)
+		}
+		m.actualRows = table.Rows
+	}
+
+	for _, row := range rows {
+		if success, err := matcher.Match(row); err != nil || success {
+			return success, err
+		}
+	}
+	return false, nil
+}
+
+func (m *HaveTableRowMatcher) FailureMessage(actual interface{}) (message string) {
+	return tableRowMessage(actual, "to have a table row matching", m.Expected, m.actualRows)
+}
+
+func (m *HaveTableRowMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return tableRowMessage(actual, "not to have a table row matching", m.Expected, m.actualRows)
+}
+
+func tableRowMessage(actual interface{}, message string, expected, actualRows interface{}) string {
+	failureMessage := "Expected %s %s\n%s\nbut found rows\n%s"
+	return fmt.Sprintf(failureMessage, actual, message, format.Object(expected, 1), format.Object(actualRows, 1)) + artifactsMessage(actual)
+}
@@ -0,0 +1,103 @@
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti"
+	. "github.com/sclevine/agouti/matchers/internal"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("HaveTableRowMatcher", func() {
+	var (
+		matcher   *HaveTableRowMatcher
+		selection *mocks.Selection
+	)
+
+	BeforeEach(func() {
+		selection = &mocks.Selection{}
+		selection.StringCall.ReturnString = "selection 'CSS: #selector'"
+		selection.TableCall.ReturnTable = &agouti.Table{
+			Headers: []string{"Name", "Age"},
+			Rows:    [][]string{{"Bob", "30"}, {"Alice", "25"}},
+		}
+		matcher = &HaveTableRowMatcher{Expected: map[string]string{"Name": "Alice", "Age": "25"}}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a selection", func() {
+			Context("when a row equals the expected value", func() {
+				It("should successfully return true", func() {
+					Expect(matcher.Match(selection)).To(BeTrue())
+				})
+			})
+
+			Context("when no row equals the expected value", func() {
+				It("should successfully return false", func() {
+					matcher.Expected = map[string]string{"Name": "Alice", "Age": "30"}
+					Expect(matcher.Match(selection)).To(BeFalse())
+				})
+			})
+
+			Context("when the expected value is a matcher", func() {
+				It("should match each row using the matcher", func() {
+					matcher.Expected = HaveKeyWithValue("Age", "30")
+					Expect(matcher.Match(selection)).To(BeTrue())
+					matcher.Expected = HaveKeyWithValue("Age", "40")
+					Expect(matcher.Match(selection)).To(BeFalse())
+				})
+			})
+
+			Context("when the table has no headers", func() {
+				It("should match each row as a slice of cell text", func() {
+					selection.TableCall.ReturnTable = &agouti.Table{Rows: [][]string{{"Bob", "30"}}}
+					matcher.Expected = []string{"Bob", "30"}
+					Expect(matcher.Match(selection)).To(BeTrue())
+				})
+			})
+
+			Context("when the matcher fails", func() {
+				It("should return an error", func() {
+					matcher.Expected = HaveLen("not a number")
+					_, err := matcher.Match(selection)
+					Expect(err).To(HaveOccurred())
+				})
+			})
+
+			Context("when retrieving the table fails", func() {
+				It("should return an error", func() {
+					selection.TableCall.Err = errors.New("some error")
+					_, err := matcher.Match(selection)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a selection", func() {
+			It("should return an error", func() {
+				_, err := matcher.Match("not a selection")
+				Expect(err).To(MatchError("HaveTableRow matcher requires a *Selection.  Got:\n    <string>: not a selection"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("should return a failure message listing the rows", func() {
+			matcher.Match(selection)
+			message := matcher.FailureMessage(selection)
+			Expect(message).To(ContainSubstring("Expected selection 'CSS: #selector' to have a table row matching\n"))
+			Expect(message).To(ContainSubstring("but found rows\n"))
+			Expect(message).To(ContainSubstring("Bob"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("should return a negated failure message listing the rows", func() {
+			matcher.Match(selection)
+			message := matcher.NegatedFailureMessage(selection)
+			Expect(message).To(ContainSubstring("Expected selection 'CSS: #selector' not to have a table row matching\n"))
+		})
+	})
+})
@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/sclevine/agouti"
+)
+
+type JSErrorsMatcher struct {
+	jsErrors []agouti.JSError
+}
+
+func (m *JSErrorsMatcher) Match(actual interface{}) (success bool, err error) {
+	actualPage, ok := actual.(interface {
+		JSErrors() ([]agouti.JSError, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("HaveNoJSErrors matcher requires a Page.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	jsErrors, err := actualPage.JSErrors()
+	if err != nil {
+		return false, err
+	}
+
+	m.jsErrors = jsErrors
+	return len(jsErrors) == 0, nil
+}
+
+func (m *JSErrorsMatcher) FailureMessage(actual interface{}) (message string) {
+	var descriptions []string
+	for _, jsError := range m.jsErrors {
+		description := jsError.Message
+		if jsError.Source != "" {
+			description += fmt.Sprintf(" (%s:%d:%d)", jsError.Source, jsError.Line, jsError.Column)
+		}
+		descriptions = append(descriptions, description)
+	}
+	return equalityMessage(actual, "to have no JavaScript errors, but found", strings.Join(descriptions, "\n"+tab))
+}
+
+func (m *JSErrorsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return booleanMessage(actual, "to have JavaScript errors")
+}
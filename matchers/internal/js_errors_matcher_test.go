@@ -0,0 +1,76 @@
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti"
+	. "github.com/sclevine/agouti/matchers/internal"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("JSErrorsMatcher", func() {
+	var (
+		matcher *JSErrorsMatcher
+		page    *mocks.Page
+	)
+
+	BeforeEach(func() {
+		page = &mocks.Page{}
+		matcher = &JSErrorsMatcher{}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a page", func() {
+			Context("when the page has no JavaScript errors", func() {
+				It("should successfully return true", func() {
+					Expect(matcher.Match(page)).To(BeTrue())
+				})
+			})
+
+			Context("when the page has JavaScript errors", func() {
+				It("should successfully return false", func() {
+					page.JSErrorsCall.ReturnErrors = []agouti.JSError{{Message: "some error"}}
+					Expect(matcher.Match(page)).To(BeFalse())
+				})
+			})
+
+			Context("when retrieving the JavaScript errors fails", func() {
+				It("should return an error", func() {
+					page.JSErrorsCall.Err = errors.New("some error")
+					_, err := matcher.Match(page)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a page", func() {
+			It("should return an error", func() {
+				_, err := matcher.Match("not a page")
+				Expect(err).To(MatchError("HaveNoJSErrors matcher requires a Page.  Got:\n    <string>: not a page"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("should return a failure message listing the JavaScript errors", func() {
+			page.JSErrorsCall.ReturnErrors = []agouti.JSError{
+				{Message: "some error", Source: "http://example.com/app.js", Line: 12, Column: 34},
+				{Message: "Unhandled promise rejection: some other error"},
+			}
+			matcher.Match(page)
+			message := matcher.FailureMessage(page)
+			Expect(message).To(Equal("Expected page to have no JavaScript errors, but found\n" +
+				"    some error (http://example.com/app.js:12:34)\n" +
+				"    Unhandled promise rejection: some other error"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("should return a negated failure message", func() {
+			message := matcher.NegatedFailureMessage(page)
+			Expect(message).To(Equal("Expected page to have JavaScript errors"))
+		})
+	})
+})
@@ -2,7 +2,6 @@ package internal_test
 
 import (
 	"errors"
-	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -37,9 +36,9 @@ var _ = Describe("LogMatcher", func() {
 			Context("when all expected logs have been logged with an expected level", func() {
 				It("should successfully return true", func() {
 					page.ReadAllLogsCall.ReturnLogs = []agouti.Log{
-						{"some log", "", "SECOND", time.Time{}},
-						{"some other log", "", "FIRST", time.Time{}},
-						{"another log", "", "OTHER", time.Time{}},
+						{Message: "some log", Level: "SECOND"},
+						{Message: "some other log", Level: "FIRST"},
+						{Message: "another log", Level: "OTHER"},
 					}
 					Expect(matcher.Match(page)).To(BeTrue())
 				})
@@ -48,9 +47,9 @@ var _ = Describe("LogMatcher", func() {
 			Context("when not all expected logs have been logged", func() {
 				It("should successfully return false", func() {
 					page.ReadAllLogsCall.ReturnLogs = []agouti.Log{
-						{"some log", "", "FIRST", time.Time{}},
-						{"another log", "", "SECOND", time.Time{}},
-						{"yet another log", "", "THIRD", time.Time{}},
+						{Message: "some log", Level: "FIRST"},
+						{Message: "another log", Level: "SECOND"},
+						{Message: "yet another log", Level: "THIRD"},
 					}
 					Expect(matcher.Match(page)).To(BeFalse())
 				})
@@ -59,9 +58,9 @@ var _ = Describe("LogMatcher", func() {
 			Context("when not all expected logs have been logged with expected levels", func() {
 				It("should successfully return false", func() {
 					page.ReadAllLogsCall.ReturnLogs = []agouti.Log{
-						{"some log", "", "FIRST", time.Time{}},
-						{"some other log", "", "OTHER", time.Time{}},
-						{"another log", "", "SECOND", time.Time{}},
+						{Message: "some log", Level: "FIRST"},
+						{Message: "some other log", Level: "OTHER"},
+						{Message: "another log", Level: "SECOND"},
 					}
 					Expect(matcher.Match(page)).To(BeFalse())
 				})
@@ -75,8 +74,8 @@ var _ = Describe("LogMatcher", func() {
 				Context("when any log of an expected type is logged", func() {
 					It("should successfully return true", func() {
 						page.ReadAllLogsCall.ReturnLogs = []agouti.Log{
-							{"first log", "", "OTHER", time.Time{}},
-							{"second log", "", "SECOND", time.Time{}},
+							{Message: "first log", Level: "OTHER"},
+							{Message: "second log", Level: "SECOND"},
 						}
 						Expect(matcher.Match(page)).To(BeTrue())
 					})
@@ -85,8 +84,8 @@ var _ = Describe("LogMatcher", func() {
 				Context("when no logs of an expected type are logged", func() {
 					It("should successfully return false", func() {
 						page.ReadAllLogsCall.ReturnLogs = []agouti.Log{
-							{"first log", "", "OTHER", time.Time{}},
-							{"second log", "", "ANOTHER", time.Time{}},
+							{Message: "first log", Level: "OTHER"},
+							{Message: "second log", Level: "ANOTHER"},
 						}
 						Expect(matcher.Match(page)).To(BeFalse())
 					})
@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/sclevine/agouti"
+)
+
+type MatchDOMSnapshotMatcher struct {
+	Golden  string
+	Options agouti.DOMSnapshotOptions
+	failure string
+}
+
+func (m *MatchDOMSnapshotMatcher) Match(actual interface{}) (success bool, err error) {
+	actualSnapshotter, ok := actual.(interface {
+		DOMSnapshot(options agouti.DOMSnapshotOptions) (string, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("HaveDOMMatchingSnapshot matcher requires a *Page.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	snapshot, err := actualSnapshotter.DOMSnapshot(m.Options)
+	if err != nil {
+		return false, err
+	}
+
+	if os.Getenv(UpdateGoldensEnv) != "" {
+		if err := writeFile(m.Golden, []byte(snapshot)); err != nil {
+			return false, fmt.Errorf("failed to update golden snapshot: %s", err)
+		}
+		return true, nil
+	}
+
+	golden, err := ioutil.ReadFile(m.Golden)
+	if os.IsNotExist(err) {
+		m.failure = fmt.Sprintf("but the golden snapshot does not exist (set %s=1 to create it)", UpdateGoldensEnv)
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read golden snapshot: %s", err)
+	}
+
+	// Golden snapshots may be checked out with Windows line endings.
+	expected := strings.Replace(string(golden), "\r\n", "\n", -1)
+	if expected == snapshot {
+		return true, nil
+	}
+
+	m.failure = firstDifference(expected, snapshot)
+	filename := artifactPath(m.Golden, "actual")
+	if err := writeFile(filename, []byte(snapshot)); err != nil {
+		return false, fmt.Errorf("failed to save actual snapshot: %s", err)
+	}
+	m.failure += fmt.Sprintf("\nactual snapshot saved to %s", filename)
+	return false, nil
+}
+
+func (m *MatchDOMSnapshotMatcher) FailureMessage(actual interface{}) (message string) {
+	return equalityMessage(actual, "to have DOM matching snapshot", m.Golden+"\n"+m.failure)
+}
+
+func (m *MatchDOMSnapshotMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return equalityMessage(actual, "not to have DOM matching snapshot", m.Golden)
+}
+
+func firstDifference(expected, actual string) string {
+	expectedLines, actualLines := strings.Split(expected, "\n"), strings.Split(actual, "\n")
+	for index := 0; index < len(expectedLines) || index < len(actualLines); index++ {
+		expectedLine, actualLine := "<end of snapshot>", "<end of snapshot>"
+		if index < len(expectedLines) {
+			expectedLine = expectedLines[index]
+		}
+		if index < len(actualLines) {
+			actualLine = actualLines[index]
+		}
+		if expectedLine != actualLine {
+			return fmt.Sprintf("but line %d differs:\n%sexpected: %s\n%sactual:   %s", index+1, tab, expectedLine, tab, actualLine)
+		}
+	}
+	return "but the snapshots differ"
+}
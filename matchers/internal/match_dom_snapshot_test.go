@@ -0,0 +1,120 @@
+package internal_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti"
+	. "github.com/sclevine/agouti/matchers/internal"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("MatchDOMSnapshotMatcher", func() {
+	var (
+		matcher   *MatchDOMSnapshotMatcher
+		page      *mocks.Page
+		directory string
+		golden    string
+	)
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "golden")
+		Expect(err).NotTo(HaveOccurred())
+		golden = filepath.Join(directory, "cart.html")
+		Expect(ioutil.WriteFile(golden, []byte("<ul>\n  <li>one</li>\n</ul>\n"), 0666)).To(Succeed())
+
+		page = &mocks.Page{}
+		page.DOMSnapshotCall.ReturnSnapshot = "<ul>\n  <li>one</li>\n</ul>\n"
+		matcher = &MatchDOMSnapshotMatcher{Golden: golden, Options: agouti.DOMSnapshotOptions{Selector: "ul"}}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a page", func() {
+			It("should request a snapshot with the provided options", func() {
+				matcher.Match(page)
+				Expect(page.DOMSnapshotCall.Options).To(Equal(agouti.DOMSnapshotOptions{Selector: "ul"}))
+			})
+
+			It("should successfully return true when the snapshot matches the golden snapshot", func() {
+				Expect(matcher.Match(page)).To(BeTrue())
+			})
+
+			It("should successfully return true when the golden snapshot has Windows line endings", func() {
+				Expect(ioutil.WriteFile(golden, []byte("<ul>\r\n  <li>one</li>\r\n</ul>\r\n"), 0666)).To(Succeed())
+				Expect(matcher.Match(page)).To(BeTrue())
+			})
+
+			It("should successfully return false and save the actual snapshot when the snapshot differs", func() {
+				page.DOMSnapshotCall.ReturnSnapshot = "<ul>\n  <li>two</li>\n</ul>\n"
+				Expect(matcher.Match(page)).To(BeFalse())
+				Expect(ioutil.ReadFile(filepath.Join(directory, "cart.actual.html"))).To(Equal([]byte("<ul>\n  <li>two</li>\n</ul>\n")))
+			})
+
+			It("should successfully return false when the golden snapshot does not exist", func() {
+				matcher.Golden = filepath.Join(directory, "missing.html")
+				Expect(matcher.Match(page)).To(BeFalse())
+			})
+
+			It("should return an error when the snapshot cannot be retrieved", func() {
+				page.DOMSnapshotCall.Err = errors.New("some error")
+				_, err := matcher.Match(page)
+				Expect(err).To(MatchError("some error"))
+			})
+
+			Context("when the golden snapshots are being updated", func() {
+				BeforeEach(func() {
+					os.Setenv(UpdateGoldensEnv, "1")
+				})
+
+				AfterEach(func() {
+					os.Unsetenv(UpdateGoldensEnv)
+				})
+
+				It("should save the snapshot as the golden snapshot and successfully return true", func() {
+					page.DOMSnapshotCall.ReturnSnapshot = "<ul></ul>\n"
+					Expect(matcher.Match(page)).To(BeTrue())
+					Expect(ioutil.ReadFile(golden)).To(Equal([]byte("<ul></ul>\n")))
+				})
+			})
+		})
+
+		Context("when the actual object is not a page", func() {
+			It("should return an error", func() {
+				_, err := matcher.Match("not a page")
+				Expect(err).To(MatchError("HaveDOMMatchingSnapshot matcher requires a *Page.  Got:\n    <string>: not a page"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("should return a failure message with the first differing line", func() {
+			page.DOMSnapshotCall.ReturnSnapshot = "<ul>\n  <li>two</li>\n</ul>\n"
+			matcher.Match(page)
+			message := matcher.FailureMessage(page)
+			Expect(message).To(ContainSubstring("Expected page to have DOM matching snapshot\n    " + golden))
+			Expect(message).To(ContainSubstring("but line 2 differs:\n    expected:   <li>one</li>\n    actual:     <li>two</li>"))
+			Expect(message).To(ContainSubstring("actual snapshot saved to " + filepath.Join(directory, "cart.actual.html")))
+		})
+
+		It("should report a missing line at the end of a snapshot", func() {
+			page.DOMSnapshotCall.ReturnSnapshot = "<ul>\n  <li>one</li>\n"
+			matcher.Match(page)
+			Expect(matcher.FailureMessage(page)).To(ContainSubstring("but line 3 differs:\n    expected: </ul>\n    actual:   "))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("should return a negated failure message", func() {
+			Expect(matcher.NegatedFailureMessage(page)).To(Equal("Expected page not to have DOM matching snapshot\n    " + golden))
+		})
+	})
+})
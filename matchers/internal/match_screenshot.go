@@ -0,0 +1,216 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+// UpdateGoldensEnv is the environment variable that, when set to any
+// non-empty value, causes MatchScreenshotMatcher to save screenshots as the
+// golden images instead of comparing them.
+const UpdateGoldensEnv = "AGOUTI_UPDATE_GOLDENS"
+
+// The maximum YIQ distance between two colors.
+const maxColorDelta = 35215
+
+type MatchScreenshotMatcher struct {
+	Golden         string
+	Threshold      float64
+	ColorTolerance float64
+	failure        string
+}
+
+func (m *MatchScreenshotMatcher) Match(actual interface{}) (success bool, err error) {
+	actualScreenshotter, ok := actual.(interface {
+		Screenshot(filename string) error
+	})
+
+	if !ok {
+		return false, fmt.Errorf("MatchScreenshot matcher requires a *Page or *Selection.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	actualImage, actualPNG, err := takeScreenshot(actualScreenshotter.Screenshot)
+	if err != nil {
+		return false, err
+	}
+
+	if os.Getenv(UpdateGoldensEnv) != "" {
+		if err := writeFile(m.Golden, actualPNG); err != nil {
+			return false, fmt.Errorf("failed to update golden image: %s", err)
+		}
+		return true, nil
+	}
+
+	goldenImage, err := readImage(m.Golden)
+	if os.IsNotExist(err) {
+		m.failure = fmt.Sprintf("but the golden image does not exist (set %s=1 to create it)", UpdateGoldensEnv)
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read golden image: %s", err)
+	}
+
+	if goldenImage.Bounds().Size() != actualImage.Bounds().Size() {
+		m.failure = fmt.Sprintf("but the screenshot is %s while the golden image is %s",
+			actualImage.Bounds().Size(), goldenImage.Bounds().Size())
+		return false, m.saveArtifact("actual", actualPNG)
+	}
+
+	diffCount, diffImage := diffImages(goldenImage, actualImage, m.ColorTolerance)
+	size := actualImage.Bounds().Size()
+	diffRatio := 0.0
+	if pixels := size.X * size.Y; pixels > 0 {
+		diffRatio = float64(diffCount) / float64(pixels)
+	}
+	if diffRatio <= m.Threshold {
+		return true, nil
+	}
+
+	m.failure = fmt.Sprintf("but %.2f%% of pixels differ (threshold %.2f%%)", diffRatio*100, m.Threshold*100)
+	if err := m.saveArtifact("actual", actualPNG); err != nil {
+		return false, err
+	}
+	diffPNG, err := encodeImage(diffImage)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode diff image: %s", err)
+	}
+	return false, m.saveArtifact("diff", diffPNG)
+}
+
+func (m *MatchScreenshotMatcher) FailureMessage(actual interface{}) (message string) {
+	return equalityMessage(actual, "to match screenshot", m.Golden+"\n"+m.failure)
+}
+
+func (m *MatchScreenshotMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return equalityMessage(actual, "not to match screenshot", m.Golden)
+}
+
+func (m *MatchScreenshotMatcher) saveArtifact(kind string, data []byte) error {
+	filename := artifactPath(m.Golden, kind)
+	if err := writeFile(filename, data); err != nil {
+		return fmt.Errorf("failed to save %s image: %s", kind, err)
+	}
+	m.failure += fmt.Sprintf("\n%s image saved to %s", kind, filename)
+	return nil
+}
+
+// Artifacts are saved next to the golden file, ex. header.diff.png for
+// header.png, so that they are easy to find and to exclude from version
+// control.
+func artifactPath(golden, kind string) string {
+	extension := filepath.Ext(golden)
+	return fmt.Sprintf("%s.%s%s", strings.TrimSuffix(golden, extension), kind, extension)
+}
+
+func takeScreenshot(screenshot func(filename string) error) (image.Image, []byte, error) {
+	file, err := ioutil.TempFile("", "agouti-screenshot-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create screenshot file: %s", err)
+	}
+	file.Close()
+	defer os.Remove(file.Name())
+
+	if err := screenshot(file.Name()); err != nil {
+		return nil, nil, err
+	}
+
+	data, err := ioutil.ReadFile(file.Name())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read screenshot: %s", err)
+	}
+	screenshotImage, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode screenshot: %s", err)
+	}
+	return screenshotImage, data, nil
+}
+
+func readImage(filename string) (image.Image, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decodedImage, _, err := image.Decode(file)
+	return decodedImage, err
+}
+
+func encodeImage(img image.Image) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func writeFile(filename string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0777); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, data, 0666)
+}
+
+// diffImages compares images of the same size pixel by pixel. Pixels are
+// considered different when the perceptual (YIQ) distance between their
+// colors exceeds the provided tolerance, from 0 to 1. The returned image
+// shows differing pixels in red over a faded copy of the expected image.
+func diffImages(expected, actual image.Image, tolerance float64) (int, *image.RGBA) {
+	expectedBounds, actualBounds := expected.Bounds(), actual.Bounds()
+	diff := image.NewRGBA(image.Rect(0, 0, expectedBounds.Dx(), expectedBounds.Dy()))
+	maxDelta := maxColorDelta * tolerance * tolerance
+
+	diffCount := 0
+	for y := 0; y < expectedBounds.Dy(); y++ {
+		for x := 0; x < expectedBounds.Dx(); x++ {
+			expectedColor := expected.At(expectedBounds.Min.X+x, expectedBounds.Min.Y+y)
+			actualColor := actual.At(actualBounds.Min.X+x, actualBounds.Min.Y+y)
+			if colorDelta(expectedColor, actualColor) > maxDelta {
+				diffCount++
+				diff.Set(x, y, color.RGBA{R: 255, A: 255})
+				continue
+			}
+			faded := uint8(255 - (255-luma(expectedColor))/10)
+			diff.Set(x, y, color.RGBA{R: faded, G: faded, B: faded, A: 255})
+		}
+	}
+	return diffCount, diff
+}
+
+func colorDelta(first, second color.Color) float64 {
+	firstY, firstI, firstQ := yiq(first)
+	secondY, secondI, secondQ := yiq(second)
+	deltaY, deltaI, deltaQ := firstY-secondY, firstI-secondI, firstQ-secondQ
+	return 0.5053*deltaY*deltaY + 0.299*deltaI*deltaI + 0.1957*deltaQ*deltaQ
+}
+
+// Colors are blended with a white background before they are compared, so
+// that transparent pixels match white pixels.
+func yiq(c color.Color) (y, i, q float64) {
+	r, g, b := blendWhite(c)
+	y = 0.29889531*r + 0.58662247*g + 0.11448223*b
+	i = 0.59597799*r - 0.27417610*g - 0.32180189*b
+	q = 0.21147017*r - 0.52261711*g + 0.31114694*b
+	return y, i, q
+}
+
+func blendWhite(c color.Color) (r, g, b float64) {
+	red, green, blue, alpha := c.RGBA()
+	background := float64(0xffff - alpha)
+	scale := float64(0xffff) / 255
+	return (float64(red) + background) / scale, (float64(green) + background) / scale, (float64(blue) + background) / scale
+}
+
+func luma(c color.Color) float64 {
+	y, _, _ := yiq(c)
+	return y
+}
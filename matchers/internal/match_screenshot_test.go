@@ -0,0 +1,167 @@
+package internal_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers/internal"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+func screenshotPNG(background color.Color, redPixels int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			img.Set(x, y, background)
+		}
+	}
+	for x := 0; x < redPixels; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+
+	var buffer bytes.Buffer
+	png.Encode(&buffer, img)
+	return buffer.Bytes()
+}
+
+var _ = Describe("MatchScreenshotMatcher", func() {
+	var (
+		matcher   *MatchScreenshotMatcher
+		selection *mocks.Selection
+		directory string
+		golden    string
+	)
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "golden")
+		Expect(err).NotTo(HaveOccurred())
+		golden = filepath.Join(directory, "header.png")
+		Expect(ioutil.WriteFile(golden, screenshotPNG(color.White, 0), 0666)).To(Succeed())
+
+		selection = &mocks.Selection{}
+		selection.StringCall.ReturnString = "selection 'CSS: #selector'"
+		matcher = &MatchScreenshotMatcher{Golden: golden, ColorTolerance: 0.1}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a selection", func() {
+			It("should successfully return true when the screenshot matches the golden image", func() {
+				selection.ScreenshotCall.ReturnImage = screenshotPNG(color.White, 0)
+				Expect(matcher.Match(selection)).To(BeTrue())
+			})
+
+			It("should successfully return true when the colors differ by less than the tolerance", func() {
+				selection.ScreenshotCall.ReturnImage = screenshotPNG(color.RGBA{R: 250, G: 250, B: 250, A: 255}, 0)
+				Expect(matcher.Match(selection)).To(BeTrue())
+			})
+
+			It("should successfully return true when fewer pixels than the threshold differ", func() {
+				matcher.Threshold = 0.05
+				selection.ScreenshotCall.ReturnImage = screenshotPNG(color.White, 5)
+				Expect(matcher.Match(selection)).To(BeTrue())
+			})
+
+			It("should successfully return false and save the actual and diff images when the screenshot differs", func() {
+				selection.ScreenshotCall.ReturnImage = screenshotPNG(color.White, 5)
+				Expect(matcher.Match(selection)).To(BeFalse())
+				Expect(ioutil.ReadFile(filepath.Join(directory, "header.actual.png"))).To(Equal(screenshotPNG(color.White, 5)))
+
+				diffFile, err := os.Open(filepath.Join(directory, "header.diff.png"))
+				Expect(err).NotTo(HaveOccurred())
+				defer diffFile.Close()
+				diff, err := png.Decode(diffFile)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(diff.At(0, 0)).To(Equal(color.RGBA{R: 255, A: 255}))
+				Expect(diff.At(9, 9)).NotTo(Equal(color.RGBA{R: 255, A: 255}))
+			})
+
+			It("should successfully return false when the screenshot is a different size", func() {
+				img := image.NewRGBA(image.Rect(0, 0, 5, 5))
+				var buffer bytes.Buffer
+				png.Encode(&buffer, img)
+				selection.ScreenshotCall.ReturnImage = buffer.Bytes()
+				Expect(matcher.Match(selection)).To(BeFalse())
+				Expect(matcher.FailureMessage(selection)).To(ContainSubstring("but the screenshot is (5,5) while the golden image is (10,10)"))
+			})
+
+			It("should successfully return false when the golden image does not exist", func() {
+				matcher.Golden = filepath.Join(directory, "missing.png")
+				selection.ScreenshotCall.ReturnImage = screenshotPNG(color.White, 0)
+				Expect(matcher.Match(selection)).To(BeFalse())
+				Expect(matcher.FailureMessage(selection)).To(ContainSubstring("but the golden image does not exist (set AGOUTI_UPDATE_GOLDENS=1 to create it)"))
+			})
+
+			Context("when golden images are being updated", func() {
+				BeforeEach(func() {
+					os.Setenv(UpdateGoldensEnv, "1")
+				})
+
+				AfterEach(func() {
+					os.Unsetenv(UpdateGoldensEnv)
+				})
+
+				It("should save the screenshot as the golden image and return true", func() {
+					matcher.Golden = filepath.Join(directory, "new", "header.png")
+					selection.ScreenshotCall.ReturnImage = screenshotPNG(color.White, 5)
+					Expect(matcher.Match(selection)).To(BeTrue())
+					Expect(ioutil.ReadFile(matcher.Golden)).To(Equal(screenshotPNG(color.White, 5)))
+				})
+			})
+
+			Context("when the screenshot fails", func() {
+				It("should return an error", func() {
+					selection.ScreenshotCall.Err = errors.New("some error")
+					_, err := matcher.Match(selection)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+
+			Context("when the screenshot is not a PNG image", func() {
+				It("should return an error", func() {
+					selection.ScreenshotCall.ReturnImage = []byte("some data")
+					_, err := matcher.Match(selection)
+					Expect(err).To(MatchError(HavePrefix("failed to decode screenshot: ")))
+				})
+			})
+		})
+
+		Context("when the actual object cannot take a screenshot", func() {
+			It("should return an error", func() {
+				_, err := matcher.Match("not a selection")
+				Expect(err).To(MatchError("MatchScreenshot matcher requires a *Page or *Selection.  Got:\n    <string>: not a selection"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("should return a failure message with the golden image, difference, and saved images", func() {
+			selection.ScreenshotCall.ReturnImage = screenshotPNG(color.White, 5)
+			matcher.Match(selection)
+			message := matcher.FailureMessage(selection)
+			Expect(message).To(HavePrefix("Expected selection 'CSS: #selector' to match screenshot\n    " + golden))
+			Expect(message).To(ContainSubstring("\nbut 5.00% of pixels differ (threshold 0.00%)"))
+			Expect(message).To(ContainSubstring("\nactual image saved to " + filepath.Join(directory, "header.actual.png")))
+			Expect(message).To(HaveSuffix("\ndiff image saved to " + filepath.Join(directory, "header.diff.png")))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("should return a negated failure message with the golden image", func() {
+			message := matcher.NegatedFailureMessage(selection)
+			Expect(message).To(Equal("Expected selection 'CSS: #selector' not to match screenshot\n    " + golden))
+		})
+	})
+})
@@ -10,17 +10,17 @@ var tab = format.Indent
 
 func valueMessage(actual interface{}, message string, expected, actualValue interface{}) string {
 	failureMessage := "Expected %s %s\n%s%s\nbut found\n%s%s"
-	return fmt.Sprintf(failureMessage, actual, message, tab, expected, tab, actualValue)
+	return fmt.Sprintf(failureMessage, actual, message, tab, expected, tab, actualValue) + artifactsMessage(actual)
 }
 
 func booleanMessage(actual interface{}, message string) string {
 	failureMessage := "Expected %s %s"
-	return fmt.Sprintf(failureMessage, actual, message)
+	return fmt.Sprintf(failureMessage, actual, message) + artifactsMessage(actual)
 }
 
 func equalityMessage(actual interface{}, message string, expected interface{}) string {
 	failureMessage := "Expected %s %s\n%s%s"
-	return fmt.Sprintf(failureMessage, actual, message, tab, expected)
+	return fmt.Sprintf(failureMessage, actual, message, tab, expected) + artifactsMessage(actual)
 }
 
 func expectedColorMessage(expectedValue string, expectedColor, actualColor interface{}) string {
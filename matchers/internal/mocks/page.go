@@ -28,6 +28,23 @@ type Page struct {
 		ReturnLogs []agouti.Log
 		Err        error
 	}
+
+	JSErrorsCall struct {
+		ReturnErrors []agouti.JSError
+		Err          error
+	}
+
+	RunAccessibilityAuditCall struct {
+		Options       agouti.AxeOptions
+		ReturnResults *agouti.AxeResults
+		Err           error
+	}
+
+	DOMSnapshotCall struct {
+		Options        agouti.DOMSnapshotOptions
+		ReturnSnapshot string
+		Err            error
+	}
 }
 
 func (*Page) String() string {
@@ -54,3 +71,17 @@ func (p *Page) ReadAllLogs(logType string) ([]agouti.Log, error) {
 	p.ReadAllLogsCall.LogType = logType
 	return p.ReadAllLogsCall.ReturnLogs, p.ReadAllLogsCall.Err
 }
+
+func (p *Page) JSErrors() ([]agouti.JSError, error) {
+	return p.JSErrorsCall.ReturnErrors, p.JSErrorsCall.Err
+}
+
+func (p *Page) RunAccessibilityAudit(options agouti.AxeOptions) (*agouti.AxeResults, error) {
+	p.RunAccessibilityAuditCall.Options = options
+	return p.RunAccessibilityAuditCall.ReturnResults, p.RunAccessibilityAuditCall.Err
+}
+
+func (p *Page) DOMSnapshot(options agouti.DOMSnapshotOptions) (string, error) {
+	p.DOMSnapshotCall.Options = options
+	return p.DOMSnapshotCall.ReturnSnapshot, p.DOMSnapshotCall.Err
+}
@@ -1,5 +1,11 @@
 package mocks
 
+import (
+	"io/ioutil"
+
+	"github.com/sclevine/agouti"
+)
+
 type Selection struct {
 	StringCall struct {
 		ReturnString string
@@ -52,6 +58,39 @@ type Selection struct {
 		ReturnEquals bool
 		Err          error
 	}
+
+	TableCall struct {
+		ReturnTable *agouti.Table
+		Err         error
+	}
+
+	ValueCall struct {
+		ReturnValue string
+		Err         error
+	}
+
+	ClassesCall struct {
+		ReturnClasses []string
+		Err           error
+	}
+
+	ReportCall struct {
+		ReturnReport *agouti.ElementReport
+		Err          error
+	}
+
+	ScreenshotCall struct {
+		Filename    string
+		ReturnImage []byte
+		Err         error
+	}
+
+	SaveSnapshotCall struct {
+		Directory   string
+		Name        string
+		ReturnPaths []string
+		Err         error
+	}
 }
 
 func (s *Selection) String() string {
@@ -96,3 +135,33 @@ func (s *Selection) EqualsElement(selection interface{}) (bool, error) {
 	s.EqualsElementCall.Selection = selection
 	return s.EqualsElementCall.ReturnEquals, s.EqualsElementCall.Err
 }
+
+func (s *Selection) Table() (*agouti.Table, error) {
+	return s.TableCall.ReturnTable, s.TableCall.Err
+}
+
+func (s *Selection) Value() (string, error) {
+	return s.ValueCall.ReturnValue, s.ValueCall.Err
+}
+
+func (s *Selection) Classes() ([]string, error) {
+	return s.ClassesCall.ReturnClasses, s.ClassesCall.Err
+}
+
+func (s *Selection) Report() (*agouti.ElementReport, error) {
+	return s.ReportCall.ReturnReport, s.ReportCall.Err
+}
+
+func (s *Selection) Screenshot(filename string) error {
+	s.ScreenshotCall.Filename = filename
+	if s.ScreenshotCall.Err != nil {
+		return s.ScreenshotCall.Err
+	}
+	return ioutil.WriteFile(filename, s.ScreenshotCall.ReturnImage, 0666)
+}
+
+func (s *Selection) SaveSnapshot(directory, name string) ([]string, error) {
+	s.SaveSnapshotCall.Directory = directory
+	s.SaveSnapshotCall.Name = name
+	return s.SaveSnapshotCall.ReturnPaths, s.SaveSnapshotCall.Err
+}
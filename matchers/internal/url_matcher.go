@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+// URLMatcher compares a single component of the URL of a page. The Component
+// is one of "path", "query parameter", "fragment", or "pattern". Query
+// parameters are identified by the Key.
+type URLMatcher struct {
+	Name        string
+	Component   string
+	Key         string
+	Expected    string
+	actualValue string
+}
+
+func (m *URLMatcher) Match(actual interface{}) (success bool, err error) {
+	actualPage, ok := actual.(interface {
+		URL() (string, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("%s matcher requires a Page.  Got:\n%s", m.Name, format.Object(actual, 1))
+	}
+
+	rawURL, err := actualPage.URL()
+	if err != nil {
+		return false, err
+	}
+
+	if m.Component == "pattern" {
+		m.actualValue = rawURL
+		return regexp.MatchString(m.Expected, rawURL)
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse URL: %s", err)
+	}
+
+	switch m.Component {
+	case "path":
+		m.actualValue = parsedURL.Path
+		return trimPath(m.actualValue) == trimPath(m.Expected), nil
+	case "fragment":
+		m.actualValue = parsedURL.Fragment
+		return m.actualValue == strings.TrimPrefix(m.Expected, "#"), nil
+	case "query parameter":
+		values, present := parsedURL.Query()[m.Key]
+		m.actualValue = strings.Join(values, ", ")
+		if !present {
+			m.actualValue = "<missing>"
+		}
+		for _, value := range values {
+			if value == m.Expected {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("%s matcher has invalid URL component: %s", m.Name, m.Component)
+}
+
+func (m *URLMatcher) FailureMessage(actual interface{}) (message string) {
+	return valueMessage(actual, "to have "+m.description(), m.Expected, m.actualValue)
+}
+
+func (m *URLMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return valueMessage(actual, "not to have "+m.description(), m.Expected, m.actualValue)
+}
+
+func (m *URLMatcher) description() string {
+	switch m.Component {
+	case "pattern":
+		return "URL matching"
+	case "query parameter":
+		return fmt.Sprintf("URL query parameter %s equaling", m.Key)
+	}
+	return fmt.Sprintf("URL %s equaling", m.Component)
+}
+
+// Trailing slashes are ignored, except for the root path.
+func trimPath(path string) string {
+	if trimmed := strings.TrimRight(path, "/"); trimmed != "" {
+		return trimmed
+	}
+	return "/"
+}
@@ -0,0 +1,136 @@
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers/internal"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("URLMatcher", func() {
+	var page *mocks.Page
+
+	BeforeEach(func() {
+		page = &mocks.Page{}
+	})
+
+	Describe("#Match", func() {
+		Context("when comparing the path", func() {
+			It("should ignore trailing slashes, the query, and the fragment", func() {
+				matcher := &URLMatcher{Name: "HaveURLPath", Component: "path", Expected: "/checkout/"}
+				page.URLCall.ReturnURL = "http://example.com/checkout?sku=123#step-2"
+				Expect(matcher.Match(page)).To(BeTrue())
+				page.URLCall.ReturnURL = "http://example.com/checkout/other"
+				Expect(matcher.Match(page)).To(BeFalse())
+			})
+
+			It("should treat an empty path as the root path", func() {
+				matcher := &URLMatcher{Name: "HaveURLPath", Component: "path", Expected: "/"}
+				page.URLCall.ReturnURL = "http://example.com"
+				Expect(matcher.Match(page)).To(BeTrue())
+			})
+		})
+
+		Context("when comparing a query parameter", func() {
+			var matcher *URLMatcher
+
+			BeforeEach(func() {
+				matcher = &URLMatcher{Name: "HaveQueryParam", Component: "query parameter", Key: "sku", Expected: "123"}
+			})
+
+			It("should match any value of the parameter regardless of order", func() {
+				page.URLCall.ReturnURL = "http://example.com/?sku=456&quantity=2&sku=123"
+				Expect(matcher.Match(page)).To(BeTrue())
+				page.URLCall.ReturnURL = "http://example.com/?sku=456"
+				Expect(matcher.Match(page)).To(BeFalse())
+			})
+
+			It("should describe a missing parameter in the failure message", func() {
+				page.URLCall.ReturnURL = "http://example.com/?quantity=2"
+				Expect(matcher.Match(page)).To(BeFalse())
+				Expect(matcher.FailureMessage(page)).To(Equal("Expected page to have URL query parameter sku equaling\n    123\nbut found\n    <missing>"))
+			})
+		})
+
+		Context("when comparing the fragment", func() {
+			It("should ignore a leading # in the expected fragment", func() {
+				matcher := &URLMatcher{Name: "HaveFragment", Component: "fragment", Expected: "#step-2"}
+				page.URLCall.ReturnURL = "http://example.com/#step-2"
+				Expect(matcher.Match(page)).To(BeTrue())
+				page.URLCall.ReturnURL = "http://example.com/"
+				Expect(matcher.Match(page)).To(BeFalse())
+			})
+		})
+
+		Context("when matching a pattern", func() {
+			It("should match the entire URL", func() {
+				matcher := &URLMatcher{Name: "MatchURL", Component: "pattern", Expected: `^http://example\.com/orders/\d+`}
+				page.URLCall.ReturnURL = "http://example.com/orders/42"
+				Expect(matcher.Match(page)).To(BeTrue())
+				page.URLCall.ReturnURL = "http://example.com/orders/new"
+				Expect(matcher.Match(page)).To(BeFalse())
+			})
+
+			It("should return an error when the pattern is invalid", func() {
+				matcher := &URLMatcher{Name: "MatchURL", Component: "pattern", Expected: "("}
+				_, err := matcher.Match(page)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when the URL cannot be retrieved", func() {
+			It("should return an error", func() {
+				matcher := &URLMatcher{Name: "HaveURLPath", Component: "path", Expected: "/"}
+				page.URLCall.Err = errors.New("some error")
+				_, err := matcher.Match(page)
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the URL cannot be parsed", func() {
+			It("should return an error", func() {
+				matcher := &URLMatcher{Name: "HaveURLPath", Component: "path", Expected: "/"}
+				page.URLCall.ReturnURL = "http://[::1"
+				_, err := matcher.Match(page)
+				Expect(err).To(MatchError(HavePrefix("failed to parse URL: ")))
+			})
+		})
+
+		Context("when the component is invalid", func() {
+			It("should return an error", func() {
+				matcher := &URLMatcher{Name: "HaveURLPath", Component: "some component"}
+				page.URLCall.ReturnURL = "http://example.com/"
+				_, err := matcher.Match(page)
+				Expect(err).To(MatchError("HaveURLPath matcher has invalid URL component: some component"))
+			})
+		})
+
+		Context("when the actual object is not a page", func() {
+			It("should return an error", func() {
+				matcher := &URLMatcher{Name: "HaveFragment", Component: "fragment"}
+				_, err := matcher.Match("not a page")
+				Expect(err).To(MatchError("HaveFragment matcher requires a Page.  Got:\n    <string>: not a page"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("should describe the compared URL component", func() {
+			matcher := &URLMatcher{Name: "HaveURLPath", Component: "path", Expected: "/checkout"}
+			page.URLCall.ReturnURL = "http://example.com/cart"
+			matcher.Match(page)
+			Expect(matcher.FailureMessage(page)).To(Equal("Expected page to have URL path equaling\n    /checkout\nbut found\n    /cart"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("should describe the compared URL component", func() {
+			matcher := &URLMatcher{Name: "MatchURL", Component: "pattern", Expected: "example"}
+			page.URLCall.ReturnURL = "http://example.com/"
+			matcher.Match(page)
+			Expect(matcher.NegatedFailureMessage(page)).To(Equal("Expected page not to have URL matching\n    example\nbut found\n    http://example.com/"))
+		})
+	})
+})
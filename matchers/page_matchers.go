@@ -17,6 +17,34 @@ func HaveURL(url string) types.GomegaMatcher {
 	return &internal.ValueMatcher{Method: "URL", Property: "URL", Expected: url}
 }
 
+// HaveURLPath passes when the expected path is equivalent to the path of the
+// current URL of the provided page. Trailing slashes are ignored, so "/cart"
+// and "/cart/" are equivalent.
+func HaveURLPath(path string) types.GomegaMatcher {
+	return &internal.URLMatcher{Name: "HaveURLPath", Component: "path", Expected: path}
+}
+
+// HaveQueryParam passes when the current URL of the provided page has a
+// query parameter with the provided name and expected value, regardless of
+// the order of the query parameters. If the parameter is repeated, any of its
+// values may match.
+func HaveQueryParam(name, value string) types.GomegaMatcher {
+	return &internal.URLMatcher{Name: "HaveQueryParam", Component: "query parameter", Key: name, Expected: value}
+}
+
+// HaveFragment passes when the expected fragment is equivalent to the
+// fragment of the current URL of the provided page. The leading "#" is
+// optional.
+func HaveFragment(fragment string) types.GomegaMatcher {
+	return &internal.URLMatcher{Name: "HaveFragment", Component: "fragment", Expected: fragment}
+}
+
+// MatchURL passes when the expected regular expression matches the current
+// URL of the provided page.
+func MatchURL(regexp string) types.GomegaMatcher {
+	return &internal.URLMatcher{Name: "MatchURL", Component: "pattern", Expected: regexp}
+}
+
 // HavePopupText passes when the expected text is equivalent to the
 // text contents of an open alert, confirm, or prompt popup.
 func HavePopupText(text string) types.GomegaMatcher {
@@ -55,3 +83,20 @@ func HaveLoggedInfo(messages ...string) types.GomegaMatcher {
 		Type:             "browser",
 	}
 }
+
+// HaveNoJSErrors passes when no uncaught JavaScript exceptions or unhandled
+// promise rejections have been collected from the provided page, which must
+// have been opened with the CollectJSErrors Option. The failure message lists
+// each collected error.
+func HaveNoJSErrors() types.GomegaMatcher {
+	return &internal.JSErrorsMatcher{}
+}
+
+// HaveNoA11yViolations passes when an accessibility audit of the provided
+// page or selection finds no violations with at least the provided impact
+// ("minor", "moderate", "serious", or "critical"). All violations are
+// considered if the impact is empty. The failure message lists each violation
+// and the elements it applies to. See *agouti.Page.RunAccessibilityAudit.
+func HaveNoA11yViolations(impact string) types.GomegaMatcher {
+	return &internal.A11yMatcher{Impact: impact}
+}
@@ -1,8 +1,6 @@
 package matchers_test
 
 import (
-	"time"
-
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/sclevine/agouti"
@@ -41,6 +39,39 @@ var _ = Describe("Page Matchers", func() {
 		})
 	})
 
+	Describe("#HaveURLPath", func() {
+		It("should return a URLMatcher that compares the URL path", func() {
+			page.URLCall.ReturnURL = "http://example.com/checkout/?sku=123"
+			Expect(page).To(HaveURLPath("/checkout"))
+			Expect(page).NotTo(HaveURLPath("/cart"))
+		})
+	})
+
+	Describe("#HaveQueryParam", func() {
+		It("should return a URLMatcher that compares a query parameter", func() {
+			page.URLCall.ReturnURL = "http://example.com/checkout?quantity=2&sku=123"
+			Expect(page).To(HaveQueryParam("sku", "123"))
+			Expect(page).NotTo(HaveQueryParam("sku", "456"))
+		})
+	})
+
+	Describe("#HaveFragment", func() {
+		It("should return a URLMatcher that compares the URL fragment", func() {
+			page.URLCall.ReturnURL = "http://example.com/checkout#step-2"
+			Expect(page).To(HaveFragment("step-2"))
+			Expect(page).To(HaveFragment("#step-2"))
+			Expect(page).NotTo(HaveFragment("step-3"))
+		})
+	})
+
+	Describe("#MatchURL", func() {
+		It("should return a URLMatcher that matches the URL against a regular expression", func() {
+			page.URLCall.ReturnURL = "http://example.com/orders/42"
+			Expect(page).To(MatchURL(`/orders/\d+$`))
+			Expect(page).NotTo(MatchURL(`^https://`))
+		})
+	})
+
 	Describe("#HavePopupText", func() {
 		It("should return a ValueMatcher with the 'PopupText' method", func() {
 			page.PopupTextCall.ReturnText = "some text"
@@ -68,9 +99,9 @@ var _ = Describe("Page Matchers", func() {
 	Describe("#HaveLoggedError", func() {
 		It("should return a LogMatcher matcher for SEVERE and WARNING browser logs", func() {
 			page.ReadAllLogsCall.ReturnLogs = []agouti.Log{
-				{"some log", "", "SEVERE", time.Time{}},
-				{"some other log", "", "WARNING", time.Time{}},
-				{"another log", "", "INFO", time.Time{}},
+				{Message: "some log", Level: "SEVERE"},
+				{Message: "some other log", Level: "WARNING"},
+				{Message: "another log", Level: "INFO"},
 			}
 			Expect(page).To(HaveLoggedError("some log"))
 			Expect(page).To(HaveLoggedError("some other log"))
@@ -86,9 +117,9 @@ var _ = Describe("Page Matchers", func() {
 	Describe("#HaveLoggedInfo", func() {
 		It("should return a LogMatcher matcher for INFO browser logs", func() {
 			page.ReadAllLogsCall.ReturnLogs = []agouti.Log{
-				{"some log", "", "SEVERE", time.Time{}},
-				{"some other log", "", "WARNING", time.Time{}},
-				{"another log", "", "INFO", time.Time{}},
+				{Message: "some log", Level: "SEVERE"},
+				{Message: "some other log", Level: "WARNING"},
+				{Message: "another log", Level: "INFO"},
 			}
 			Expect(page).NotTo(HaveLoggedInfo("some log"))
 			Expect(page).NotTo(HaveLoggedInfo("some other log"))
@@ -100,4 +131,22 @@ var _ = Describe("Page Matchers", func() {
 			Expect(HaveLoggedInfo().FailureMessage(nil)).To(ContainSubstring("to have logged info"))
 		})
 	})
+
+	Describe("#HaveNoJSErrors", func() {
+		It("should return a JSErrorsMatcher matcher", func() {
+			Expect(page).To(HaveNoJSErrors())
+			page.JSErrorsCall.ReturnErrors = []agouti.JSError{{Message: "some error"}}
+			Expect(page).NotTo(HaveNoJSErrors())
+		})
+	})
+
+	Describe("#HaveNoA11yViolations", func() {
+		It("should return an A11yMatcher with the provided impact", func() {
+			page.RunAccessibilityAuditCall.ReturnResults = &agouti.AxeResults{
+				Violations: []agouti.AxeRule{{ID: "label", Impact: "moderate"}},
+			}
+			Expect(page).To(HaveNoA11yViolations("serious"))
+			Expect(page).NotTo(HaveNoA11yViolations("minor"))
+		})
+	})
 })
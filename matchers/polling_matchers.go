@@ -0,0 +1,26 @@
+package matchers
+
+import (
+	"time"
+
+	"github.com/onsi/gomega/types"
+	"github.com/sclevine/agouti/matchers/internal"
+)
+
+const defaultPollingInterval = 100 * time.Millisecond
+
+// EventuallyMatch passes when the provided matcher passes within the provided
+// timeout. The matcher is retried every 100 milliseconds, including when it
+// returns an error, such as when the selection does not yet refer to an
+// element. This is useful for asserting on pages that update asynchronously
+// without using Gomega's Eventually.
+//
+// EventuallyMatch should be used with To. To wait for a matcher to stop
+// passing, use Not inside of EventuallyMatch instead of using NotTo.
+//
+// Example:
+//    Expect(page.Find("#status")).To(EventuallyMatch(HaveText("Done"), 5*time.Second))
+//    Expect(page.Find("#spinner")).To(EventuallyMatch(Not(BeVisible()), 5*time.Second))
+func EventuallyMatch(matcher types.GomegaMatcher, timeout time.Duration) types.GomegaMatcher {
+	return &internal.EventuallyMatcher{Matcher: matcher, Timeout: timeout, Interval: defaultPollingInterval}
+}
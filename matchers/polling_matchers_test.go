@@ -0,0 +1,30 @@
+package matchers_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("Polling Matchers", func() {
+	var selection *mocks.Selection
+
+	BeforeEach(func() {
+		selection = &mocks.Selection{}
+	})
+
+	Describe("#EventuallyMatch", func() {
+		It("should return a matcher that passes when the provided matcher passes", func() {
+			selection.TextCall.ReturnText = "some text"
+			Expect(selection).To(EventuallyMatch(HaveText("some text"), time.Second))
+		})
+
+		It("should return a matcher that fails when the provided matcher does not pass before the timeout", func() {
+			selection.TextCall.ReturnText = "some other text"
+			Expect(selection).NotTo(EventuallyMatch(HaveText("some text"), 10*time.Millisecond))
+		})
+	})
+})
@@ -0,0 +1,52 @@
+package matchers
+
+import (
+	"github.com/onsi/gomega/types"
+	"github.com/sclevine/agouti/matchers/internal"
+)
+
+// A ScreenshotOption configures MatchScreenshot.
+type ScreenshotOption func(*internal.MatchScreenshotMatcher)
+
+// WithThreshold provides a ScreenshotOption for specifying the fraction of
+// pixels, from 0 to 1, that may differ from the golden image. By default,
+// no pixels may differ.
+func WithThreshold(fraction float64) ScreenshotOption {
+	return func(m *internal.MatchScreenshotMatcher) {
+		m.Threshold = fraction
+	}
+}
+
+// WithColorTolerance provides a ScreenshotOption for specifying how much the
+// color of a pixel may differ from the golden image before the pixel is
+// considered different, from 0 (exact match) to 1. Colors are compared using
+// their perceptual distance, so that anti-aliasing and color rounding do not
+// cause failures. The default tolerance is 0.1.
+func WithColorTolerance(tolerance float64) ScreenshotOption {
+	return func(m *internal.MatchScreenshotMatcher) {
+		m.ColorTolerance = tolerance
+	}
+}
+
+const defaultColorTolerance = 0.1
+
+// MatchScreenshot passes when a screenshot of the provided *Page or
+// *Selection matches the golden PNG image at the provided path. When the
+// screenshot does not match, the screenshot and an image highlighting the
+// differing pixels in red are saved next to the golden image, with the
+// suffixes ".actual.png" and ".diff.png".
+//
+// When the AGOUTI_UPDATE_GOLDENS environment variable is set to any non-empty
+// value, the screenshot is saved as the golden image instead, and the matcher
+// passes. This may be used to create golden images and to accept intended
+// changes.
+//
+// Example:
+//    Expect(page.Find("header")).To(MatchScreenshot("golden/header.png", WithThreshold(0.01)))
+func MatchScreenshot(golden string, options ...ScreenshotOption) types.GomegaMatcher {
+	matcher := &internal.MatchScreenshotMatcher{Golden: golden, ColorTolerance: defaultColorTolerance}
+	for _, option := range options {
+		option(matcher)
+	}
+	return matcher
+}
@@ -0,0 +1,59 @@
+package matchers_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("Screenshot Matchers", func() {
+	var (
+		selection *mocks.Selection
+		directory string
+		golden    string
+	)
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "golden")
+		Expect(err).NotTo(HaveOccurred())
+		golden = filepath.Join(directory, "header.png")
+
+		var buffer bytes.Buffer
+		png.Encode(&buffer, image.NewRGBA(image.Rect(0, 0, 10, 10)))
+		Expect(ioutil.WriteFile(golden, buffer.Bytes(), 0666)).To(Succeed())
+
+		selection = &mocks.Selection{}
+		selection.ScreenshotCall.ReturnImage = buffer.Bytes()
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+	})
+
+	Describe("#MatchScreenshot", func() {
+		It("should return a MatchScreenshotMatcher for the provided golden image", func() {
+			Expect(selection).To(MatchScreenshot(golden))
+			Expect(selection).NotTo(MatchScreenshot(filepath.Join(directory, "missing.png")))
+		})
+
+		It("should apply the provided options", func() {
+			var buffer bytes.Buffer
+			img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+			img.Pix[3] = 255
+			png.Encode(&buffer, img)
+			selection.ScreenshotCall.ReturnImage = buffer.Bytes()
+			Expect(selection).NotTo(MatchScreenshot(golden))
+			Expect(selection).To(MatchScreenshot(golden, WithThreshold(0.01)))
+			Expect(selection).To(MatchScreenshot(golden, WithColorTolerance(1)))
+		})
+	})
+})
@@ -23,6 +23,45 @@ func HaveCount(count int) types.GomegaMatcher {
 	return &internal.ValueMatcher{Method: "Count", Property: "element count", Expected: count}
 }
 
+// HaveCountGreaterThan passes when the actual number of elements in the
+// selection is greater than the provided count.
+func HaveCountGreaterThan(count int) types.GomegaMatcher {
+	return &internal.CompareCountMatcher{Name: "HaveCountGreaterThan", Comparison: "greater than", Expected: count}
+}
+
+// HaveCountLessThan passes when the actual number of elements in the
+// selection is less than the provided count.
+func HaveCountLessThan(count int) types.GomegaMatcher {
+	return &internal.CompareCountMatcher{Name: "HaveCountLessThan", Comparison: "less than", Expected: count}
+}
+
+// HaveTableRow passes when any row of the provided table selection matches the
+// expected value or matcher. If the table has headers, each row is matched as
+// a map[string]string from header text to cell text, otherwise each row is
+// matched as a []string of cell text. See *Selection.Table.
+// This matcher will fail if the provided selection refers to more than one element.
+//
+// Example:
+//    Expect(page.Find("#orders")).To(HaveTableRow(HaveKeyWithValue("Customer", "Bob")))
+func HaveTableRow(expected interface{}) types.GomegaMatcher {
+	return &internal.HaveTableRowMatcher{Expected: expected}
+}
+
+// HaveValue passes when the expected value is equal to the actual value of
+// the form element, which reflects any text entered into the element.
+// This matcher will fail if the provided selection refers to more than one element.
+func HaveValue(value string) types.GomegaMatcher {
+	return &internal.ValueMatcher{Method: "Value", Property: "value", Expected: value}
+}
+
+// HaveClass passes when the class list of the element contains the expected
+// class. Unlike matching the class attribute, the expected class is never
+// matched by part of another class.
+// This matcher will fail if the provided selection refers to more than one element.
+func HaveClass(class string) types.GomegaMatcher {
+	return &internal.HaveClassMatcher{ExpectedClass: class}
+}
+
 // HaveAttribute passes when the expected attribute and value are present on the element.
 // This matcher will fail if the provided selection refers to more than one element.
 func HaveAttribute(attribute string, value string) types.GomegaMatcher {
@@ -44,16 +83,39 @@ func BeSelected() types.GomegaMatcher {
 	return &internal.BooleanMatcher{Method: "Selected", Property: "selected"}
 }
 
+// BeChecked passes when the provided selection refers to checkboxes or radio
+// buttons that are checked.
+// This matcher will fail if any of the selection's elements are not checked.
+func BeChecked() types.GomegaMatcher {
+	return &internal.CheckedMatcher{Checked: true}
+}
+
+// BeUnchecked passes when the provided selection refers to a checkbox or radio
+// button that is not checked. For selections of multiple elements, this matcher
+// passes when any of the elements are not checked.
+func BeUnchecked() types.GomegaMatcher {
+	return &internal.CheckedMatcher{Checked: false}
+}
+
 // BeVisible passes when the selection refers to elements that are displayed on the page.
 // This matcher will fail if any of the selection's elements are not visible.
+// For selections of exactly one element, the failure message includes an
+// ElementReport describing the element (see *Selection.Report).
 func BeVisible() types.GomegaMatcher {
-	return &internal.BooleanMatcher{Method: "Visible", Property: "visible"}
+	return &internal.BooleanMatcher{Method: "Visible", Property: "visible", Report: true}
 }
 
 // BeEnabled passes when the selection refers to form elements that are enabled.
 // This matcher will fail if any of the selection's form elements are not enabled.
+// Like BeVisible, the failure message includes an ElementReport.
 func BeEnabled() types.GomegaMatcher {
-	return &internal.BooleanMatcher{Method: "Enabled", Property: "enabled"}
+	return &internal.BooleanMatcher{Method: "Enabled", Property: "enabled", Report: true}
+}
+
+// BeFocused passes when the selection refers to the element that has focus.
+// Unlike BeActive, the failure message includes an ElementReport.
+func BeFocused() types.GomegaMatcher {
+	return &internal.BooleanMatcher{Method: "Active", Property: "focused", Name: "BeFocused", Report: true}
 }
 
 // BeActive passes when the selection refers to the active page element.
@@ -3,6 +3,7 @@ package matchers_test
 import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti"
 	. "github.com/sclevine/agouti/matchers"
 	"github.com/sclevine/agouti/matchers/internal/mocks"
 )
@@ -46,6 +47,68 @@ var _ = Describe("Selection Matchers", func() {
 		})
 	})
 
+	Describe("#HaveCountGreaterThan", func() {
+		It("should return a CompareCountMatcher that compares using 'greater than'", func() {
+			selection.CountCall.ReturnCount = 2
+			Expect(selection).To(HaveCountGreaterThan(1))
+			Expect(selection).NotTo(HaveCountGreaterThan(2))
+			Expect(HaveCountGreaterThan(0).FailureMessage(nil)).To(ContainSubstring("to have element count greater than"))
+		})
+	})
+
+	Describe("#HaveCountLessThan", func() {
+		It("should return a CompareCountMatcher that compares using 'less than'", func() {
+			selection.CountCall.ReturnCount = 2
+			Expect(selection).To(HaveCountLessThan(3))
+			Expect(selection).NotTo(HaveCountLessThan(2))
+			Expect(HaveCountLessThan(0).FailureMessage(nil)).To(ContainSubstring("to have element count less than"))
+		})
+	})
+
+	Describe("#HaveTableRow", func() {
+		It("should return a HaveTableRow matcher", func() {
+			selection.TableCall.ReturnTable = &agouti.Table{Headers: []string{"Name"}, Rows: [][]string{{"Bob"}}}
+			Expect(selection).To(HaveTableRow(HaveKeyWithValue("Name", "Bob")))
+			Expect(selection).NotTo(HaveTableRow(map[string]string{"Name": "Alice"}))
+		})
+	})
+
+	Describe("#HaveValue", func() {
+		It("should return a ValueMatcher with the 'Value' method", func() {
+			selection.ValueCall.ReturnValue = "some value"
+			Expect(selection).To(HaveValue("some value"))
+			Expect(selection).NotTo(HaveValue("some other value"))
+		})
+
+		It("should set the matcher property to 'value'", func() {
+			Expect(HaveValue("").FailureMessage(nil)).To(ContainSubstring("to have value equaling"))
+		})
+	})
+
+	Describe("#HaveClass", func() {
+		It("should return a HaveClassMatcher", func() {
+			selection.ClassesCall.ReturnClasses = []string{"active"}
+			Expect(selection).To(HaveClass("active"))
+			Expect(selection).NotTo(HaveClass("act"))
+		})
+	})
+
+	Describe("#BeChecked", func() {
+		It("should return a CheckedMatcher that passes when the selection is checked", func() {
+			selection.SelectedCall.ReturnSelected = true
+			Expect(selection).To(BeChecked())
+			Expect(selection).NotTo(BeUnchecked())
+		})
+	})
+
+	Describe("#BeUnchecked", func() {
+		It("should return a CheckedMatcher that passes when the selection is not checked", func() {
+			selection.SelectedCall.ReturnSelected = false
+			Expect(selection).To(BeUnchecked())
+			Expect(selection).NotTo(BeChecked())
+		})
+	})
+
 	Describe("#HaveAttribute", func() {
 		It("should return a HaveAttribute matcher", func() {
 			selection.AttributeCall.ReturnValue = "some value"
@@ -101,6 +164,24 @@ var _ = Describe("Selection Matchers", func() {
 		})
 	})
 
+	Describe("#BeFocused", func() {
+		It("should return a BooleanMatcher with the 'Active' method", func() {
+			selection.ActiveCall.ReturnActive = true
+			Expect(selection).To(BeFocused())
+			selection.ActiveCall.ReturnActive = false
+			Expect(selection).NotTo(BeFocused())
+		})
+
+		It("should set the matcher property to 'focused'", func() {
+			Expect(BeFocused().FailureMessage(nil)).To(HaveSuffix("to be focused"))
+		})
+
+		It("should include the element report in the failure message", func() {
+			selection.ReportCall.ReturnReport = &agouti.ElementReport{Element: "input#email"}
+			Expect(BeFocused().FailureMessage(selection)).To(ContainSubstring("element: input#email"))
+		})
+	})
+
 	Describe("#BeActive", func() {
 		It("should return a BooleanMatcher with the 'Active' method", func() {
 			selection.ActiveCall.ReturnActive = true
@@ -0,0 +1,190 @@
+package agouti
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// A MatrixBrowser is a browser that a Matrix runs tests against.
+type MatrixBrowser struct {
+	// Name identifies the browser in the names of subtests and in results,
+	// ex. "chrome-headless"
+	Name string
+
+	// NewPage opens a new page in the browser for each test
+	NewPage func() (*Page, error)
+}
+
+// DriverBrowser returns a MatrixBrowser that opens pages using the provided
+// WebDriver with the provided Options. The WebDriver must be started before
+// the Matrix is used, and stopped afterwards.
+//
+// Example:
+//    chromeDriver := agouti.ChromeDriver(agouti.Headless())
+//    agouti.DriverBrowser("chrome-headless", chromeDriver)
+func DriverBrowser(name string, driver *WebDriver, options ...Option) MatrixBrowser {
+	return MatrixBrowser{
+		Name:    name,
+		NewPage: func() (*Page, error) { return driver.NewPage(options...) },
+	}
+}
+
+// RemoteBrowser returns a MatrixBrowser that opens pages using the Selenium
+// Grid or cloud service at the provided URL, as described by Remote.
+//
+// Example:
+//    capabilities := agouti.NewCapabilities().Browser("safari")
+//    agouti.RemoteBrowser("grid-safari", "http://grid.example.com:4444/wd/hub", agouti.Desired(capabilities))
+func RemoteBrowser(name, gridURL string, options ...Option) MatrixBrowser {
+	return MatrixBrowser{
+		Name:    name,
+		NewPage: func() (*Page, error) { return Remote(gridURL, options...) },
+	}
+}
+
+// A Matrix runs the same tests against multiple browsers, and aggregates
+// the results of each browser.
+type Matrix struct {
+	browsers []MatrixBrowser
+	parallel bool
+	mutex    sync.Mutex
+	results  map[string]*MatrixResult
+}
+
+// MatrixResult contains the results of the tests run against one browser.
+type MatrixResult struct {
+	Browser string
+	Passed  int
+	Failed  int
+	Skipped int
+
+	// Failures contains the names of the tests that failed
+	Failures []string
+
+	// Duration is the total time spent running tests against the browser,
+	// including opening and destroying pages
+	Duration time.Duration
+}
+
+// NewMatrix returns a Matrix that runs tests against the provided browsers.
+//
+// Example:
+//    var matrix = agouti.NewMatrix(
+//        agouti.DriverBrowser("chrome-headless", chromeDriver),
+//        agouti.DriverBrowser("firefox", geckoDriver),
+//        agouti.RemoteBrowser("grid-edge", gridURL, agouti.Browser("MicrosoftEdge")),
+//    )
+func NewMatrix(browsers ...MatrixBrowser) *Matrix {
+	return &Matrix{browsers: browsers, results: map[string]*MatrixResult{}}
+}
+
+// Parallel configures the matrix to run the tests for each browser in
+// parallel with each other, using t.Parallel. Parallel returns the matrix.
+func (m *Matrix) Parallel() *Matrix {
+	m.parallel = true
+	return m
+}
+
+// Each runs the provided test body as a subtest of t for each browser of the
+// matrix, named after the browser. Each subtest receives a new page, which
+// is destroyed when the subtest completes. If the subtest fails,
+// HandleFailure is called with the page before it is destroyed, and the
+// failure is reported to Sauce Labs or BrowserStack for pages opened with
+// Capabilities.Sauce or Capabilities.BrowserStack. If the page cannot be
+// opened, the subtest fails.
+//
+// Example:
+//    func TestLogin(t *testing.T) {
+//        matrix.Each(t, func(t *testing.T, page *agouti.Page) {
+//            page.Navigate(server.URL + "/login")
+//            assert.Title(t, page, "Login")
+//        })
+//    }
+func (m *Matrix) Each(t *testing.T, body func(t *testing.T, page *Page)) {
+	t.Helper()
+	for _, browser := range m.browsers {
+		browser := browser
+		t.Run(browser.Name, func(t *testing.T) {
+			if m.parallel {
+				t.Parallel()
+			}
+
+			start := time.Now()
+			t.Cleanup(func() { m.record(browser.Name, t, time.Since(start)) })
+
+			page, err := browser.NewPage()
+			if err != nil {
+				t.Fatalf("failed to open %s page: %s", browser.Name, err)
+			}
+			t.Cleanup(func() {
+				if page.cloud != nil {
+					page.SetTestStatus(!t.Failed(), "")
+				}
+				if err := page.Destroy(); err != nil {
+					t.Errorf("failed to destroy %s page: %s", browser.Name, err)
+				}
+			})
+			page.HandleFailures(t)
+
+			body(t, page)
+		})
+	}
+}
+
+func (m *Matrix) record(browser string, t *testing.T, duration time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	result, ok := m.results[browser]
+	if !ok {
+		result = &MatrixResult{Browser: browser}
+		m.results[browser] = result
+	}
+	switch {
+	case t.Failed():
+		result.Failed++
+		result.Failures = append(result.Failures, t.Name())
+	case t.Skipped():
+		result.Skipped++
+	default:
+		result.Passed++
+	}
+	result.Duration += duration
+}
+
+// Results returns the results of each browser of the matrix, in the order
+// that the browsers were provided to NewMatrix. Results of parallel subtests
+// are only complete after the test that called Each has completed, ex. in a
+// function registered using t.Cleanup or after m.Run in TestMain.
+func (m *Matrix) Results() []MatrixResult {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	results := []MatrixResult{}
+	for _, browser := range m.browsers {
+		result := MatrixResult{Browser: browser.Name}
+		if recorded, ok := m.results[browser.Name]; ok {
+			result = *recorded
+			result.Failures = append([]string(nil), recorded.Failures...)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// String returns a summary of the results of each browser of the matrix,
+// with one line per browser followed by the names of its failed tests.
+func (m *Matrix) String() string {
+	var summary strings.Builder
+	for _, result := range m.Results() {
+		fmt.Fprintf(&summary, "%s: %d passed, %d failed, %d skipped (%s)\n",
+			result.Browser, result.Passed, result.Failed, result.Skipped, result.Duration.Round(time.Millisecond))
+		for _, failure := range result.Failures {
+			fmt.Fprintf(&summary, "    FAIL %s\n", failure)
+		}
+	}
+	return summary.String()
+}
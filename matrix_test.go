@@ -0,0 +1,70 @@
+package agouti_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+// Matrix requires a *testing.T, so it is tested outside of the Ginkgo suite.
+
+func testBrowser(name string, session *mocks.Session) MatrixBrowser {
+	return MatrixBrowser{
+		Name:    name,
+		NewPage: func() (*Page, error) { return NewTestPage(session), nil },
+	}
+}
+
+func TestMatrixEach(t *testing.T) {
+	g := NewGomegaWithT(t)
+	chromeSession, firefoxSession := &mocks.Session{}, &mocks.Session{}
+	matrix := NewMatrix(testBrowser("chrome", chromeSession), testBrowser("firefox", firefoxSession))
+
+	var browsers []string
+	pages := map[string]*Page{}
+	matrix.Each(t, func(t *testing.T, page *Page) {
+		browsers = append(browsers, t.Name())
+		pages[t.Name()] = page
+		if t.Name() == "TestMatrixEach/firefox" {
+			t.Skip("not supported")
+		}
+	})
+
+	g.Expect(browsers).To(Equal([]string{"TestMatrixEach/chrome", "TestMatrixEach/firefox"}))
+	g.Expect(pages["TestMatrixEach/chrome"]).NotTo(BeIdenticalTo(pages["TestMatrixEach/firefox"]))
+	g.Expect(chromeSession.DeleteCall.Called).To(BeTrue())
+	g.Expect(firefoxSession.DeleteCall.Called).To(BeTrue())
+
+	results := matrix.Results()
+	g.Expect(results).To(HaveLen(2))
+	g.Expect(results[0].Browser).To(Equal("chrome"))
+	g.Expect(results[0].Passed).To(Equal(1))
+	g.Expect(results[0].Skipped).To(Equal(0))
+	g.Expect(results[1].Browser).To(Equal("firefox"))
+	g.Expect(results[1].Passed).To(Equal(0))
+	g.Expect(results[1].Skipped).To(Equal(1))
+	g.Expect(matrix.String()).To(MatchRegexp(`^chrome: 1 passed, 0 failed, 0 skipped \(.*\)\nfirefox: 0 passed, 0 failed, 1 skipped \(.*\)\n$`))
+}
+
+func TestMatrixEachParallel(t *testing.T) {
+	matrix := NewMatrix(testBrowser("chrome", &mocks.Session{}), testBrowser("firefox", &mocks.Session{})).Parallel()
+
+	t.Run("group", func(t *testing.T) {
+		matrix.Each(t, func(t *testing.T, page *Page) {})
+		matrix.Each(t, func(t *testing.T, page *Page) {})
+	})
+
+	g := NewGomegaWithT(t)
+	for _, result := range matrix.Results() {
+		g.Expect(result.Passed).To(Equal(2))
+		g.Expect(result.Failures).To(BeEmpty())
+	}
+}
+
+func TestMatrixResults(t *testing.T) {
+	g := NewGomegaWithT(t)
+	matrix := NewMatrix(testBrowser("chrome", &mocks.Session{}))
+	g.Expect(matrix.Results()).To(Equal([]MatrixResult{{Browser: "chrome"}}))
+}
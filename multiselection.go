@@ -1,6 +1,11 @@
 package agouti
 
-import "github.com/sclevine/agouti/internal/target"
+import (
+	"fmt"
+
+	"github.com/sclevine/agouti/internal/element"
+	"github.com/sclevine/agouti/internal/target"
+)
 
 // A MultiSelection is a Selection that may be indexed using the At() method.
 // All Selection methods are available on a MultiSelection.
@@ -17,13 +22,98 @@ type MultiSelection struct {
 	Selection
 }
 
-func newMultiSelection(session apiSession, selectors target.Selectors) *MultiSelection {
-	return &MultiSelection{*newSelection(session, selectors)}
+func newMultiSelection(session apiSession, selectors target.Selectors, staleRetries int, testIDAttribute string, cache *element.Cache) *MultiSelection {
+	return &MultiSelection{*newSelection(session, selectors, staleRetries, testIDAttribute, cache)}
 }
 
 // At finds an element at the provided index. It only applies to the immediate selection,
 // meaning that the returned selection may still refer to multiple elements if any parent
 // of the immediate selection is also a *MultiSelection.
 func (s *MultiSelection) At(index int) *Selection {
-	return newSelection(s.session, s.selectors.At(index))
+	return newSelection(s.session, s.selectors.At(index), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// ForEach calls the provided function with a selection of each element that
+// the multi-selection refers to, in order, stopping at the first error.
+// Elements are only retrieved once, so each provided selection refers to
+// exactly one element, even if a parent of the multi-selection refers to
+// multiple elements. Selections found within a provided selection are
+// retrieved using the selectors of the multi-selection indexed by position.
+//
+// Example:
+//    err := page.All("tr").ForEach(func(row *agouti.Selection) error {
+//        return row.Find("input[type=checkbox]").Check()
+//    })
+func (s *MultiSelection) ForEach(iterator func(*Selection) error) error {
+	elements, err := s.elements.Get()
+	if err != nil {
+		return selectionError("failed to select elements from %s: %s", s, err)
+	}
+
+	for index, selectedElement := range elements {
+		selection := &Selection{
+			selectable{s.session, s.selectors.At(index), s.staleRetries, s.testIDAttribute, s.cache},
+			&element.Fixed{Element: selectedElement},
+		}
+		if err := iterator(selection); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Map calls the provided function with a selection of each element that the
+// multi-selection refers to, as described by ForEach, and returns the
+// results in order.
+//
+// Example:
+//    prices, err := page.All(".item").Map(func(item *agouti.Selection) (string, error) {
+//        return item.Find(".price").Text()
+//    })
+func (s *MultiSelection) Map(mapper func(*Selection) (string, error)) ([]string, error) {
+	results := []string{}
+	err := s.ForEach(func(selection *Selection) error {
+		result, err := mapper(selection)
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Texts returns the text of each element that the multi-selection refers to,
+// in order. Elements are only retrieved once.
+func (s *MultiSelection) Texts() ([]string, error) {
+	return s.properties(element.Element.GetText, "text")
+}
+
+// Attributes returns the value of the provided attribute for each element
+// that the multi-selection refers to, in order. Elements are only retrieved
+// once.
+func (s *MultiSelection) Attributes(attribute string) ([]string, error) {
+	return s.properties(func(selectedElement element.Element) (string, error) {
+		return selectedElement.GetAttribute(attribute)
+	}, fmt.Sprintf("attribute '%s'", attribute))
+}
+
+func (s *MultiSelection) properties(method func(element.Element) (string, error), name string) ([]string, error) {
+	elements, err := s.elements.Get()
+	if err != nil {
+		return nil, selectionError("failed to select elements from %s: %s", s, err)
+	}
+
+	values := []string{}
+	for _, selectedElement := range elements {
+		value, err := method(selectedElement)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve %s for %s: %s", name, s, err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
 }
@@ -1,10 +1,13 @@
 package agouti_test
 
 import (
+	"errors"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/sclevine/agouti"
 	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
 	"github.com/sclevine/agouti/internal/mocks"
 )
 
@@ -32,4 +35,116 @@ var _ = Describe("MultiSelection", func() {
 			Expect(elements[0].ID).To(Equal("some-id"))
 		})
 	})
+
+	Describe("iterating over elements", func() {
+		var (
+			elementRepository *mocks.ElementRepository
+			firstElement      *mocks.Element
+			secondElement     *mocks.Element
+		)
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			firstElement = &mocks.Element{}
+			secondElement = &mocks.Element{}
+			elementRepository.GetCall.ReturnElements = []element.Element{firstElement, secondElement}
+			selection = NewTestMultiSelection(session, elementRepository, "li")
+		})
+
+		Describe("#ForEach", func() {
+			It("should provide a selection of each element in order", func() {
+				firstElement.GetTextCall.ReturnText = "first"
+				secondElement.GetTextCall.ReturnText = "second"
+				var selections, texts []string
+				Expect(selection.ForEach(func(item *Selection) error {
+					selections = append(selections, item.String())
+					text, err := item.Text()
+					texts = append(texts, text)
+					return err
+				})).To(Succeed())
+				Expect(selections).To(Equal([]string{"selection 'CSS: li [0]'", "selection 'CSS: li [1]'"}))
+				Expect(texts).To(Equal([]string{"first", "second"}))
+			})
+
+			Context("when the provided function fails", func() {
+				It("should stop iterating and return the error", func() {
+					calls := 0
+					err := selection.ForEach(func(*Selection) error {
+						calls++
+						return errors.New("some error")
+					})
+					Expect(err).To(MatchError("some error"))
+					Expect(calls).To(Equal(1))
+				})
+			})
+
+			Context("when the element repository fails to return the elements", func() {
+				It("should return an error", func() {
+					elementRepository.GetCall.Err = errors.New("some error")
+					err := selection.ForEach(func(*Selection) error { return nil })
+					Expect(err).To(MatchError("failed to select elements from selection 'CSS: li': some error"))
+				})
+			})
+		})
+
+		Describe("#Map", func() {
+			It("should return the result for each element in order", func() {
+				firstElement.GetAttributeCall.ReturnValue = "first"
+				secondElement.GetAttributeCall.ReturnValue = "second"
+				Expect(selection.Map(func(item *Selection) (string, error) {
+					return item.Attribute("id")
+				})).To(Equal([]string{"first", "second"}))
+			})
+
+			Context("when the provided function fails", func() {
+				It("should return the error", func() {
+					_, err := selection.Map(func(*Selection) (string, error) {
+						return "", errors.New("some error")
+					})
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Describe("#Texts", func() {
+			It("should return the text of each element in order", func() {
+				firstElement.GetTextCall.ReturnText = "first"
+				secondElement.GetTextCall.ReturnText = "second"
+				Expect(selection.Texts()).To(Equal([]string{"first", "second"}))
+			})
+
+			Context("when the element repository fails to return the elements", func() {
+				It("should return an error", func() {
+					elementRepository.GetCall.Err = errors.New("some error")
+					_, err := selection.Texts()
+					Expect(err).To(MatchError("failed to select elements from selection 'CSS: li': some error"))
+				})
+			})
+
+			Context("when the text of an element cannot be retrieved", func() {
+				It("should return an error", func() {
+					secondElement.GetTextCall.Err = errors.New("some error")
+					_, err := selection.Texts()
+					Expect(err).To(MatchError("failed to retrieve text for selection 'CSS: li': some error"))
+				})
+			})
+		})
+
+		Describe("#Attributes", func() {
+			It("should return the attribute value of each element in order", func() {
+				firstElement.GetAttributeCall.ReturnValue = "/first"
+				secondElement.GetAttributeCall.ReturnValue = "/second"
+				Expect(selection.Attributes("href")).To(Equal([]string{"/first", "/second"}))
+				Expect(secondElement.GetAttributeCall.Attribute).To(Equal("href"))
+			})
+
+			Context("when an attribute value cannot be retrieved", func() {
+				It("should return an error", func() {
+					firstElement.GetAttributeCall.Err = errors.New("some error")
+					_, err := selection.Attributes("href")
+					Expect(err).To(MatchError("failed to retrieve attribute 'href' for selection 'CSS: li': some error"))
+				})
+			})
+		})
+	})
 })
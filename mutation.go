@@ -0,0 +1,153 @@
+package agouti
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sclevine/agouti/internal/element"
+)
+
+// The entire document is observed, so that replacing the element, ex. when a
+// component re-renders, is reported as a change. Observers are stored on the
+// window until they are waited for, so that changes made in between are not
+// missed.
+const observeChangeScript = `
+	var element = arguments[0];
+	var observers = window.__agoutiChangeObservers = window.__agoutiChangeObservers || {next: 0};
+	var id = "observer-" + observers.next++;
+	var watch = observers[id] = {changed: false, notify: null};
+	watch.observer = new MutationObserver(function(records) {
+		var changed = !element.isConnected || records.some(function(record) {
+			return element.contains(record.target);
+		});
+		if (changed) {
+			watch.observer.disconnect();
+			watch.changed = true;
+			if (watch.notify) {
+				watch.notify();
+			}
+		}
+	});
+	watch.observer.observe(document.documentElement, {childList: true, subtree: true, attributes: true, characterData: true});
+	return id;
+`
+
+const stopObservingScript = `
+	var observers = window.__agoutiChangeObservers || {}, watch = observers[arguments[0]];
+	delete observers[arguments[0]];
+	if (watch) {
+		watch.observer.disconnect();
+	}
+`
+
+// An observer that no longer exists was discarded along with its document,
+// which means that the element was removed.
+const waitForChangeScript = `
+	var observers = window.__agoutiChangeObservers || {}, id = arguments[0], timeout = arguments[1], done = arguments[2];
+	var watch = observers[id];
+	delete observers[id];
+	if (!watch || watch.changed) {
+		done(true);
+		return;
+	}
+	var timer = setTimeout(function() {
+		watch.observer.disconnect();
+		done(false);
+	}, timeout);
+	watch.notify = function() {
+		clearTimeout(timer);
+		done(true);
+	};
+`
+
+const waitForDOMStableScript = `
+	var quietPeriod = arguments[0], done = arguments[1];
+	var timer = setTimeout(stable, quietPeriod);
+	var observer = new MutationObserver(function() {
+		clearTimeout(timer);
+		timer = setTimeout(stable, quietPeriod);
+	});
+	function stable() {
+		observer.disconnect();
+		done(null);
+	}
+	observer.observe(document.documentElement, {childList: true, subtree: true, attributes: true, characterData: true});
+`
+
+// WaitForChange blocks until exactly one element in the selection, or any of
+// its descendants, is changed, added, or removed, ex. when a React or Vue
+// component re-renders. Changes are observed using a MutationObserver that is
+// installed when WaitForChange is called, so changes that occurred earlier are
+// not reported. To wait for a change caused by an action, such as a click,
+// use WaitForChangeAfter instead. An error is returned if no change occurs
+// before the timeout elapses, or if the timeout exceeds the script timeout set
+// by SetScriptTimeout.
+//
+// Example:
+//    err := page.Find("#notifications").WaitForChange(30 * time.Second)
+func (s *Selection) WaitForChange(timeout time.Duration) error {
+	return s.WaitForChangeAfter(timeout, nil)
+}
+
+// WaitForChangeAfter calls the provided action and then waits for a change
+// as described by WaitForChange. The MutationObserver is installed before the
+// action is called, so changes made by the action are always observed.
+//
+// Example:
+//    cart := page.Find("#cart")
+//    err := cart.WaitForChangeAfter(5*time.Second, page.Find("button.add-to-cart").Click)
+func (s *Selection) WaitForChangeAfter(timeout time.Duration, action func() error) error {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return selectionError("failed to select element from %s: %s", s, err)
+	}
+	apiElement, err := element.Unwrap(selectedElement)
+	if err != nil {
+		return fmt.Errorf("failed to select element from %s: %s", s, err)
+	}
+
+	timeouts, err := s.session.GetTimeouts()
+	if err == nil && timeouts.Script > 0 && timeout > timeouts.Script {
+		return fmt.Errorf("failed to wait for %s to change: timeout of %s exceeds script timeout of %s", s, timeout, timeouts.Script)
+	}
+
+	var observerID string
+	if err := s.session.Execute(observeChangeScript, []interface{}{apiElement}, &observerID); err != nil {
+		return fmt.Errorf("failed to observe %s: %s", s, err)
+	}
+
+	if action != nil {
+		if err := action(); err != nil {
+			s.session.Execute(stopObservingScript, []interface{}{observerID}, nil)
+			return fmt.Errorf("failed to change %s: %s", s, err)
+		}
+	}
+
+	var changed bool
+	timeoutMillis := float64(timeout) / float64(time.Millisecond)
+	if err := s.session.ExecuteAsync(waitForChangeScript, []interface{}{observerID, timeoutMillis}, &changed); err != nil {
+		return fmt.Errorf("failed to wait for %s to change: %s", s, err)
+	}
+	s.cache.Invalidate()
+	if !changed {
+		return fmt.Errorf("%s did not change within %s", s, timeout)
+	}
+	return nil
+}
+
+// WaitForDOMStable blocks until the DOM of the page has not changed for the
+// provided quiet period, so that tests may wait for client-side rendering to
+// settle instead of sleeping. Changes are observed using a MutationObserver.
+// The maximum time to wait for the DOM to settle is set by SetScriptTimeout.
+//
+// Example:
+//    page.Find("#search").Fill("agouti")
+//    err := page.WaitForDOMStable(200 * time.Millisecond)
+func (p *Page) WaitForDOMStable(quietPeriod time.Duration) error {
+	quietMillis := float64(quietPeriod) / float64(time.Millisecond)
+	if err := p.session.ExecuteAsync(waitForDOMStableScript, []interface{}{quietMillis}, nil); err != nil {
+		return fmt.Errorf("failed to wait for DOM to stabilize: %s", err)
+	}
+	p.cache.Invalidate()
+	return nil
+}
@@ -0,0 +1,137 @@
+package agouti_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("DOM Mutations", func() {
+	var session *mocks.Session
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+	})
+
+	Describe("#WaitForChange", func() {
+		var (
+			elementRepository *mocks.ElementRepository
+			selection         *Selection
+			element           *api.Element
+		)
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			element = &api.Element{ID: "some-id"}
+			elementRepository.GetExactlyOneCall.ReturnElement = element
+			selection = NewTestSelection(session, elementRepository, "#cart")
+		})
+
+		It("should observe the selected element until it changes", func() {
+			session.ExecuteCall.Result = `"some-observer"`
+			session.ExecuteAsyncCall.Result = "true"
+			Expect(selection.WaitForChange(2 * time.Second)).To(Succeed())
+			Expect(session.ExecuteCall.Body).To(ContainSubstring("new MutationObserver"))
+			Expect(session.ExecuteCall.Arguments).To(Equal([]interface{}{element}))
+			Expect(session.ExecuteAsyncCall.Arguments).To(Equal([]interface{}{"some-observer", 2000.0}))
+		})
+
+		Context("when the element does not change before the timeout", func() {
+			It("should return an error", func() {
+				session.ExecuteAsyncCall.Result = "false"
+				Expect(selection.WaitForChange(2 * time.Second)).To(MatchError("selection 'CSS: #cart [single]' did not change within 2s"))
+			})
+		})
+
+		Context("when the timeout exceeds the script timeout", func() {
+			It("should return an error", func() {
+				session.GetTimeoutsCall.ReturnTimeouts = api.Timeouts{Script: time.Second}
+				Expect(selection.WaitForChange(2 * time.Second)).To(MatchError("failed to wait for selection 'CSS: #cart [single]' to change: timeout of 2s exceeds script timeout of 1s"))
+				Expect(session.ExecuteCall.Body).To(BeEmpty())
+			})
+		})
+
+		Context("when exactly one element is not selected", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				Expect(selection.WaitForChange(time.Second)).To(MatchError("failed to select element from selection 'CSS: #cart [single]': some error"))
+			})
+		})
+
+		Context("when the element cannot be observed", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(selection.WaitForChange(time.Second)).To(MatchError("failed to observe selection 'CSS: #cart [single]': some error"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				session.ExecuteAsyncCall.Err = errors.New("some error")
+				Expect(selection.WaitForChange(time.Second)).To(MatchError("failed to wait for selection 'CSS: #cart [single]' to change: some error"))
+			})
+		})
+	})
+
+	Describe("#WaitForChangeAfter", func() {
+		var (
+			elementRepository *mocks.ElementRepository
+			selection         *Selection
+		)
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			elementRepository.GetExactlyOneCall.ReturnElement = &api.Element{ID: "some-id"}
+			selection = NewTestSelection(session, elementRepository, "#cart")
+			session.ExecuteCall.Result = `"some-observer"`
+		})
+
+		It("should observe the selected element before calling the action", func() {
+			session.ExecuteAsyncCall.Result = "true"
+			Expect(selection.WaitForChangeAfter(2*time.Second, func() error {
+				Expect(session.ExecuteCall.Body).To(ContainSubstring("new MutationObserver"))
+				Expect(session.ExecuteAsyncCall.Body).To(BeEmpty())
+				return nil
+			})).To(Succeed())
+			Expect(session.ExecuteAsyncCall.Arguments).To(Equal([]interface{}{"some-observer", 2000.0}))
+		})
+
+		Context("when the action fails", func() {
+			It("should stop observing the element and return an error", func() {
+				err := selection.WaitForChangeAfter(time.Second, func() error {
+					return errors.New("some error")
+				})
+				Expect(err).To(MatchError("failed to change selection 'CSS: #cart [single]': some error"))
+				Expect(session.ExecuteCall.Body).To(ContainSubstring("disconnect()"))
+				Expect(session.ExecuteCall.Arguments).To(Equal([]interface{}{"some-observer"}))
+				Expect(session.ExecuteAsyncCall.Body).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("#WaitForDOMStable", func() {
+		var page *Page
+
+		BeforeEach(func() {
+			page = NewTestPage(session)
+		})
+
+		It("should observe the document until it has not changed for the quiet period", func() {
+			Expect(page.WaitForDOMStable(200 * time.Millisecond)).To(Succeed())
+			Expect(session.ExecuteAsyncCall.Body).To(ContainSubstring("new MutationObserver"))
+			Expect(session.ExecuteAsyncCall.Arguments).To(Equal([]interface{}{200.0}))
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				session.ExecuteAsyncCall.Err = errors.New("some error")
+				Expect(page.WaitForDOMStable(time.Second)).To(MatchError("failed to wait for DOM to stabilize: some error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,57 @@
+package agouti
+
+import (
+	"fmt"
+	"time"
+)
+
+const defaultNavigationTimeout = 30 * time.Second
+
+// WaitAfterNavigation sets conditions that Navigate, Back, Forward, and
+// Refresh wait for after they navigate, which is useful for browsers that
+// return before the page has finished loading (ex. with the "eager" page
+// load strategy). The conditions are checked until they are satisfied or
+// the provided timeout elapses, in which case the navigation returns an
+// error. Calling WaitAfterNavigation without any conditions stops waiting.
+//
+// Example:
+//    page.WaitAfterNavigation(10*time.Second, agouti.ReadyState("complete"), agouti.NetworkIdle(500*time.Millisecond))
+func (p *Page) WaitAfterNavigation(timeout time.Duration, conditions ...Condition) {
+	p.navigationTimeout = timeout
+	p.navigationConditions = conditions
+}
+
+// NavigateAndWait navigates to the provided URL and then waits for the
+// document.readyState of the page to reach the provided state ("loading",
+// "interactive", or "complete"). Any conditions set by WaitAfterNavigation
+// are also waited for. The timeout provided to WaitAfterNavigation is used,
+// or 30 seconds if none was provided.
+func (p *Page) NavigateAndWait(url, readyState string) error {
+	if err := p.Navigate(url); err != nil {
+		return err
+	}
+
+	if err := p.Wait(p.navigationWaitTimeout(), 0).Until(ReadyState(readyState)); err != nil {
+		return fmt.Errorf("failed to navigate: %s", err)
+	}
+	return nil
+}
+
+func (p *Page) navigateAndWait(navigate func() error) error {
+	p.cache.Invalidate()
+	if err := p.navigate(navigate); err != nil {
+		return err
+	}
+
+	if len(p.navigationConditions) == 0 {
+		return nil
+	}
+	return p.Wait(p.navigationWaitTimeout(), 0).Until(p.navigationConditions...)
+}
+
+func (p *Page) navigationWaitTimeout() time.Duration {
+	if p.navigationTimeout <= 0 {
+		return defaultNavigationTimeout
+	}
+	return p.navigationTimeout
+}
@@ -0,0 +1,93 @@
+package agouti_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Navigation", func() {
+	var (
+		page    *Page
+		session *mocks.Session
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		page = NewTestPage(session)
+	})
+
+	Describe("#WaitAfterNavigation", func() {
+		var checks int
+
+		BeforeEach(func() {
+			checks = 0
+			page.WaitAfterNavigation(time.Second, func(*Page) (bool, error) {
+				checks++
+				return checks == 2, nil
+			})
+		})
+
+		It("should wait for the conditions after navigating to a URL", func() {
+			Expect(page.Navigate("some-url")).To(Succeed())
+			Expect(session.SetURLCall.URL).To(Equal("some-url"))
+			Expect(checks).To(Equal(2))
+		})
+
+		It("should wait for the conditions after navigating through history", func() {
+			Expect(page.Back()).To(Succeed())
+			Expect(page.Forward()).To(Succeed())
+			Expect(page.Refresh()).To(Succeed())
+			Expect(checks).To(Equal(6))
+		})
+
+		It("should not wait for the conditions when navigation fails", func() {
+			session.SetURLCall.Err = errors.New("some error")
+			Expect(page.Navigate("some-url")).To(MatchError("failed to navigate: some error"))
+			Expect(checks).To(Equal(0))
+		})
+
+		It("should stop waiting when called without conditions", func() {
+			page.WaitAfterNavigation(time.Second)
+			Expect(page.Navigate("some-url")).To(Succeed())
+			Expect(checks).To(Equal(0))
+		})
+
+		Context("when the conditions are not satisfied before the timeout", func() {
+			It("should return an error", func() {
+				page.WaitAfterNavigation(10*time.Millisecond, func(*Page) (bool, error) {
+					return false, nil
+				})
+				Expect(page.Refresh()).To(MatchError("failed to refresh page: failed to satisfy condition within 10ms"))
+			})
+		})
+	})
+
+	Describe("#NavigateAndWait", func() {
+		It("should navigate to the URL and wait for the provided ready state", func() {
+			session.ExecuteCall.Result = `"complete"`
+			Expect(page.NavigateAndWait("some-url", "complete")).To(Succeed())
+			Expect(session.SetURLCall.URL).To(Equal("some-url"))
+			Expect(session.ExecuteCall.Body).To(Equal("return document.readyState;"))
+		})
+
+		Context("when navigation fails", func() {
+			It("should return an error", func() {
+				session.SetURLCall.Err = errors.New("some error")
+				Expect(page.NavigateAndWait("some-url", "complete")).To(MatchError("failed to navigate: some error"))
+			})
+		})
+
+		Context("when the ready state is not reached before the timeout", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Result = `"interactive"`
+				page.WaitAfterNavigation(10 * time.Millisecond)
+				Expect(page.NavigateAndWait("some-url", "complete")).To(MatchError("failed to navigate: failed to satisfy condition within 10ms"))
+			})
+		})
+	})
+})
@@ -1,8 +1,11 @@
 package agouti
 
 import (
+	"io"
 	"net/http"
 	"time"
+
+	"github.com/sclevine/agouti/api"
 )
 
 type config struct {
@@ -12,6 +15,31 @@ type config struct {
 	RejectInvalidSSL    bool
 	Debug               bool
 	HTTPClient          *http.Client
+	DownloadDirectory   string
+	RequestInterception bool
+	PerformanceLogging  bool
+	BiDi                bool
+	CollectJSErrors     bool
+	StaleRetries        int
+	NoStaleRetry        bool
+	DebugLog            io.Writer
+	MobileEmulation     *MobileEmulation
+	W3COnly             bool
+	SingleSession       bool
+	AutoRestart         bool
+	DriverStdout        io.Writer
+	DriverStderr        io.Writer
+	PoolSize            int
+	HeadlessArgument    string
+	UserAgent           string
+	AcceptLanguages     []string
+	Proxy               *api.ProxyConfig
+	TestIDAttribute     string
+	PageLoadStrategy    string
+	NavigationTimeout   time.Duration
+	NavigationWait      []Condition
+	ElementCache        bool
+	InstallDriver       bool
 }
 
 // An Option specifies configuration for a new WebDriver or Page.
@@ -50,6 +78,67 @@ var Debug Option = func(c *config) {
 	c.Debug = true
 }
 
+// AutoRestart is an Option that restarts the WebDriver process if it crashes,
+// ex. if it segfaults or is killed for running out of memory. The pages of
+// the WebDriver are reopened with their original capabilities, but any
+// browser state, such as the URL of the page, is lost. Destroyed pages are
+// not reopened. The command that detected the crash returns an error
+// containing api.ErrDriverCrashed. If the restart fails, later commands
+// return the restart error. This Option only applies when provided to a
+// WebDriver.
+var AutoRestart Option = func(c *config) {
+	c.AutoRestart = true
+}
+
+// InstallDriver is an Option for ChromeDriver and GeckoDriver that downloads
+// the driver that matches the installed browser when the WebDriver is
+// started, if the driver is not in the PATH. Drivers are downloaded using
+// driverinstall, which verifies and caches them. Start returns an error if
+// the driver cannot be installed. This Option only applies when provided to
+// ChromeDriver or GeckoDriver.
+var InstallDriver Option = func(c *config) {
+	c.InstallDriver = true
+}
+
+// DriverStdout provides an Option that copies the standard output of the
+// WebDriver process to the provided writer. The most recent output of the
+// process is also available from *WebDriver.Output. This Option only
+// applies when provided to a WebDriver.
+func DriverStdout(w io.Writer) Option {
+	return func(c *config) {
+		c.DriverStdout = w
+	}
+}
+
+// DriverStderr provides an Option that copies the standard error of the
+// WebDriver process to the provided writer, like DriverStdout.
+func DriverStderr(w io.Writer) Option {
+	return func(c *config) {
+		c.DriverStderr = w
+	}
+}
+
+// PoolSize provides an Option that limits the number of pages that
+// *WebDriver.AcquirePage opens at once. The default size is the value of
+// GOMAXPROCS, which is also the default parallelism of "go test". This
+// Option only applies when provided to a WebDriver.
+func PoolSize(size int) Option {
+	return func(c *config) {
+		c.PoolSize = size
+	}
+}
+
+// DebugLog provides an Option that logs every WebDriver command sent by a
+// page to the provided writer, with its duration and its pretty-printed JSON
+// request and response bodies. Credentials and cookie values are redacted.
+// When provided to a WebDriver, such as ChromeDriver, every page opened by
+// the WebDriver is logged. See api.DebugLog.
+func DebugLog(w io.Writer) Option {
+	return func(c *config) {
+		c.DebugLog = w
+	}
+}
+
 // HTTPClient provides an Option for specifying a *http.Client
 func HTTPClient(client *http.Client) Option {
 	return func(c *config) {
@@ -57,6 +146,224 @@ func HTTPClient(client *http.Client) Option {
 	}
 }
 
+// DownloadDirectory provides an Option for specifying the directory that Chrome
+// and Firefox should save downloaded files to. Pages opened with this Option
+// look for downloads in this directory when *Page.WaitForDownload is called.
+func DownloadDirectory(directory string) Option {
+	return func(c *config) {
+		c.DownloadDirectory = directory
+	}
+}
+
+// EmulateDevice provides an Option for specifying a mobile device, known to
+// Chrome DevTools by the provided name (ex. "iPhone 12"), that Chrome should
+// emulate. The viewport, pixel ratio, user agent, and touch support of the
+// device are emulated without a mobile browser.
+func EmulateDevice(name string) Option {
+	return func(c *config) {
+		c.MobileEmulation = &MobileEmulation{DeviceName: name}
+	}
+}
+
+// EmulateDeviceMetrics provides an Option for specifying the metrics of a
+// custom mobile device that Chrome should emulate.
+func EmulateDeviceMetrics(metrics DeviceMetrics) Option {
+	return func(c *config) {
+		c.MobileEmulation = &MobileEmulation{DeviceMetrics: metrics}
+	}
+}
+
+// RequestInterception is an Option that sends the HTTP requests made by a
+// page through a proxy embedded in the test process, so that they may be
+// intercepted using *Page.InterceptRequests. HTTPS requests are not sent
+// through the proxy. The WebDriver must run on the same machine as the tests.
+var RequestInterception Option = func(c *config) {
+	c.RequestInterception = true
+}
+
+// Proxy provides an Option for specifying the proxies that the browser sends
+// its requests through, ex. to capture traffic using mitmproxy. The proxy
+// configuration is validated when the page is opened. It cannot be combined
+// with the RequestInterception Option.
+//
+// Example:
+//    agouti.ChromeDriver(agouti.Proxy(api.ProxyConfig{
+//        HTTP:    "127.0.0.1:8080",
+//        SSL:     "127.0.0.1:8080",
+//        NoProxy: []string{"localhost"},
+//    }))
+func Proxy(proxy api.ProxyConfig) Option {
+	return func(c *config) {
+		c.Proxy = &proxy
+	}
+}
+
+// PerformanceLogging is an Option that enables the Chrome performance log, so
+// that the network traffic of a page may be recorded using *Page.StartHAR.
+var PerformanceLogging Option = func(c *config) {
+	c.PerformanceLogging = true
+}
+
+// BiDi is an Option that requests a WebDriver BiDi connection for the page,
+// so that browser events may be received using *api.Session.Subscribe.
+var BiDi Option = func(c *config) {
+	c.BiDi = true
+}
+
+// CollectJSErrors is an Option that collects uncaught JavaScript exceptions
+// and unhandled promise rejections from every document loaded by the page,
+// so that they may be retrieved using *Page.JSErrors.
+var CollectJSErrors Option = func(c *config) {
+	c.CollectJSErrors = true
+}
+
+// Headless provides an Option that runs Chrome, Edge, and Firefox without a
+// visible window. Chrome and Edge use the original headless mode, which is
+// faster to start but behaves differently from a visible browser. Unless a
+// window size is already configured, the window is 1920x1080 pixels instead
+// of the small default size of headless browsers.
+func Headless() Option {
+	return func(c *config) {
+		c.HeadlessArgument = "--headless"
+	}
+}
+
+// HeadlessNew provides an Option that runs Chrome and Edge in the new
+// headless mode (--headless=new), which behaves like a visible browser, and
+// otherwise configures browsers like Headless.
+func HeadlessNew() Option {
+	return func(c *config) {
+		c.HeadlessArgument = "--headless=new"
+	}
+}
+
+// UserAgent provides an Option for specifying the user agent that Chrome,
+// Edge, and Firefox send instead of their own. Browsers that support the
+// DevTools Protocol are also configured using the protocol when the page is
+// opened, as some browser versions ignore the command-line argument.
+func UserAgent(userAgent string) Option {
+	return func(c *config) {
+		c.UserAgent = userAgent
+	}
+}
+
+// AcceptLanguage provides an Option for specifying the languages, in order
+// of preference, that Chrome, Edge, and Firefox request content in using
+// the Accept-Language header. Like UserAgent, the DevTools Protocol is also
+// used when available, as headless Chrome ignores the language preference.
+func AcceptLanguage(languages ...string) Option {
+	return func(c *config) {
+		c.AcceptLanguages = languages
+	}
+}
+
+const (
+	defaultHeadlessWidth  = 1920
+	defaultHeadlessHeight = 1080
+)
+
+// w3cOnly is an Option for WebDrivers that only accept W3C capabilities.
+var w3cOnly Option = func(c *config) {
+	c.W3COnly = true
+}
+
+// singleSession is an Option for WebDrivers that only support one session
+// at a time.
+var singleSession Option = func(c *config) {
+	c.SingleSession = true
+}
+
+// StaleRetries provides an Option for specifying the number of times that
+// selections are re-resolved and element commands are retried when the
+// element is no longer attached to the DOM, ex. after the page re-renders.
+// Selections retry 3 times by default.
+func StaleRetries(count int) Option {
+	return func(c *config) {
+		c.StaleRetries = count
+		c.NoStaleRetry = count <= 0
+	}
+}
+
+// NoStaleRetry provides an Option specifying that selections should fail
+// immediately when an element is no longer attached to the DOM.
+func NoStaleRetry() Option {
+	return StaleRetries(0)
+}
+
+// ElementCache is an Option that caches the elements that each selection of
+// the page refers to, so that calling multiple methods of a selection, or of
+// an identical selection, only retrieves its elements once. The cache is
+// invalidated when the page navigates (including Refresh, Back, and Forward),
+// when RunScript or RunScriptAsync is called, when a different window or
+// frame is switched to, and when a selection performs an action that may
+// change the page, such as Click, Fill, Select, or Submit. Cached elements that are no longer attached to the
+// DOM are re-resolved automatically, unless NoStaleRetry is provided. Other
+// changes to the page, such as new elements that match a selection, require
+// *Selection.Reload.
+var ElementCache Option = func(c *config) {
+	c.ElementCache = true
+}
+
+// TestIDAttribute provides an Option for specifying the attribute that
+// FindByTestID and related methods select elements by, ex. "data-test" or
+// "data-qa". The default attribute is "data-testid".
+func TestIDAttribute(attribute string) Option {
+	return func(c *config) {
+		c.TestIDAttribute = attribute
+	}
+}
+
+// PageLoadStrategy provides an Option for specifying when navigation commands
+// return: "normal" waits for the load event, "eager" waits for the document
+// to be parsed (DOMContentLoaded), and "none" returns as soon as the new
+// document is requested. Pages that never fire the load event, ex. due to
+// long-polling requests, should use "eager" so that Navigate does not wait
+// for the page load timeout. See NavigationWait for waiting on the rest of
+// the page after navigating.
+func PageLoadStrategy(strategy string) Option {
+	return func(c *config) {
+		c.PageLoadStrategy = strategy
+	}
+}
+
+// NavigationWait provides an Option that makes Navigate, Back, Forward, and
+// Refresh wait for the provided conditions after navigating. This is most
+// useful with the "eager" or "none" PageLoadStrategy. See
+// *Page.WaitAfterNavigation.
+//
+// Example:
+//    agouti.ChromeDriver(
+//        agouti.PageLoadStrategy("eager"),
+//        agouti.NavigationWait(10*time.Second, agouti.ReadyState("complete")),
+//    )
+func NavigationWait(timeout time.Duration, conditions ...Condition) Option {
+	return func(c *config) {
+		c.NavigationTimeout = timeout
+		c.NavigationWait = conditions
+	}
+}
+
+const defaultTestIDAttribute = "data-testid"
+
+func (c *config) testIDAttribute() string {
+	if c.TestIDAttribute != "" {
+		return c.TestIDAttribute
+	}
+	return defaultTestIDAttribute
+}
+
+const defaultStaleRetries = 3
+
+func (c *config) staleRetries() int {
+	if c.NoStaleRetry {
+		return 0
+	}
+	if c.StaleRetries > 0 {
+		return c.StaleRetries
+	}
+	return defaultStaleRetries
+}
+
 func (c config) Merge(options []Option) *config {
 	for _, option := range options {
 		option(&c)
@@ -75,5 +382,36 @@ func (c *config) Capabilities() Capabilities {
 	if c.RejectInvalidSSL {
 		merged.Without("acceptSslCerts")
 	}
+	if c.DownloadDirectory != "" {
+		merged.DownloadDirectory(c.DownloadDirectory)
+	}
+	if c.Proxy != nil {
+		merged["proxy"] = c.Proxy.Capability()
+	}
+	if c.PerformanceLogging {
+		merged.PerformanceLogging()
+	}
+	if c.MobileEmulation != nil {
+		merged.Chrome(ChromeOptions{MobileEmulation: c.MobileEmulation})
+	}
+	if c.BiDi {
+		merged.With("webSocketUrl")
+	}
+	if c.PageLoadStrategy != "" {
+		merged.PageLoadStrategy(c.PageLoadStrategy)
+	}
+	if c.UserAgent != "" {
+		merged.UserAgent(c.UserAgent)
+	}
+	merged.AcceptLanguage(c.AcceptLanguages...)
+	if c.HeadlessArgument != "" {
+		merged.headless(c.HeadlessArgument)
+		if !merged.hasWindowSize() {
+			merged.WindowSize(defaultHeadlessWidth, defaultHeadlessHeight)
+		}
+	}
+	if c.W3COnly {
+		return Capabilities(api.W3CCapabilities(merged))
+	}
 	return merged
 }
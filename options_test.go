@@ -1,12 +1,14 @@
 package agouti_test
 
 import (
+	"bytes"
 	"net/http"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
 	. "github.com/sclevine/agouti/internal/matchers"
 )
 
@@ -45,6 +47,48 @@ var _ = Describe("Options", func() {
 		})
 	})
 
+	Describe("#AutoRestart", func() {
+		It("should return an Option that restarts a crashed WebDriver", func() {
+			config := NewTestConfig()
+			AutoRestart(config)
+			Expect(config.AutoRestart).To(BeTrue())
+		})
+	})
+
+	Describe("#InstallDriver", func() {
+		It("should return an Option that installs a missing driver", func() {
+			config := NewTestConfig()
+			InstallDriver(config)
+			Expect(config.InstallDriver).To(BeTrue())
+		})
+	})
+
+	Describe("#DriverStdout", func() {
+		It("should return an Option that sets the writer for the output of a WebDriver", func() {
+			config := NewTestConfig()
+			stdout := &bytes.Buffer{}
+			DriverStdout(stdout)(config)
+			Expect(config.DriverStdout).To(ExactlyEqual(stdout))
+		})
+	})
+
+	Describe("#DriverStderr", func() {
+		It("should return an Option that sets the writer for the errors of a WebDriver", func() {
+			config := NewTestConfig()
+			stderr := &bytes.Buffer{}
+			DriverStderr(stderr)(config)
+			Expect(config.DriverStderr).To(ExactlyEqual(stderr))
+		})
+	})
+
+	Describe("#PoolSize", func() {
+		It("should return an Option that sets the size of the page pool", func() {
+			config := NewTestConfig()
+			PoolSize(3)(config)
+			Expect(config.PoolSize).To(Equal(3))
+		})
+	})
+
 	Describe("#Debug", func() {
 		It("should return an Option that debugs a WebDriver", func() {
 			config := NewTestConfig()
@@ -54,6 +98,15 @@ var _ = Describe("Options", func() {
 		})
 	})
 
+	Describe("#DebugLog", func() {
+		It("should return an Option that sets a debug log writer", func() {
+			config := NewTestConfig()
+			writer := &bytes.Buffer{}
+			DebugLog(writer)(config)
+			Expect(config.DebugLog).To(ExactlyEqual(writer))
+		})
+	})
+
 	Describe("#HTTPClient", func() {
 		It("should return an Option that sets a *http.Client", func() {
 			config := NewTestConfig()
@@ -63,6 +116,157 @@ var _ = Describe("Options", func() {
 		})
 	})
 
+	Describe("#DownloadDirectory", func() {
+		It("should return an Option that sets a download directory", func() {
+			config := NewTestConfig()
+			DownloadDirectory("/some/directory")(config)
+			Expect(config.DownloadDirectory).To(Equal("/some/directory"))
+		})
+	})
+
+	Describe("#EmulateDevice", func() {
+		It("should return an Option that sets the name of a device to emulate", func() {
+			config := NewTestConfig()
+			EmulateDevice("iPhone 12")(config)
+			Expect(config.MobileEmulation).To(Equal(&MobileEmulation{DeviceName: "iPhone 12"}))
+		})
+	})
+
+	Describe("#EmulateDeviceMetrics", func() {
+		It("should return an Option that sets the metrics of a device to emulate", func() {
+			config := NewTestConfig()
+			metrics := DeviceMetrics{Width: 390, Height: 844, PixelRatio: 3, UserAgent: "some-agent", Touch: true}
+			EmulateDeviceMetrics(metrics)(config)
+			Expect(config.MobileEmulation).To(Equal(&MobileEmulation{DeviceMetrics: metrics}))
+		})
+	})
+
+	Describe("#StaleRetries", func() {
+		It("should return an Option that sets the number of stale element retries", func() {
+			config := NewTestConfig()
+			StaleRetries(5)(config)
+			Expect(config.StaleRetries).To(Equal(5))
+			Expect(config.NoStaleRetry).To(BeFalse())
+		})
+
+		It("should return an Option that disables stale element retries when the count is not positive", func() {
+			config := NewTestConfig()
+			StaleRetries(0)(config)
+			Expect(config.NoStaleRetry).To(BeTrue())
+		})
+	})
+
+	Describe("#NoStaleRetry", func() {
+		It("should return an Option that disables stale element retries", func() {
+			config := NewTestConfig()
+			StaleRetries(5)(config)
+			NoStaleRetry()(config)
+			Expect(config.StaleRetries).To(Equal(0))
+			Expect(config.NoStaleRetry).To(BeTrue())
+		})
+	})
+
+	Describe("#Headless", func() {
+		It("should return an Option that runs browsers in the original headless mode", func() {
+			config := NewTestConfig()
+			Headless()(config)
+			Expect(config.HeadlessArgument).To(Equal("--headless"))
+		})
+	})
+
+	Describe("#HeadlessNew", func() {
+		It("should return an Option that runs browsers in the new headless mode", func() {
+			config := NewTestConfig()
+			HeadlessNew()(config)
+			Expect(config.HeadlessArgument).To(Equal("--headless=new"))
+		})
+	})
+
+	Describe("#Proxy", func() {
+		It("should return an Option that sets the proxy configuration", func() {
+			config := NewTestConfig()
+			Proxy(api.ProxyConfig{HTTP: "127.0.0.1:8080"})(config)
+			Expect(config.Proxy).To(Equal(&api.ProxyConfig{HTTP: "127.0.0.1:8080"}))
+		})
+	})
+
+	Describe("#UserAgent", func() {
+		It("should return an Option that sets the user agent", func() {
+			config := NewTestConfig()
+			UserAgent("some-agent")(config)
+			Expect(config.UserAgent).To(Equal("some-agent"))
+		})
+	})
+
+	Describe("#AcceptLanguage", func() {
+		It("should return an Option that sets the accepted languages", func() {
+			config := NewTestConfig()
+			AcceptLanguage("fr-CA", "fr")(config)
+			Expect(config.AcceptLanguages).To(Equal([]string{"fr-CA", "fr"}))
+		})
+	})
+
+	Describe("#TestIDAttribute", func() {
+		It("should return an Option that sets the test ID attribute", func() {
+			config := NewTestConfig()
+			TestIDAttribute("data-qa")(config)
+			Expect(config.TestIDAttribute).To(Equal("data-qa"))
+		})
+	})
+
+	Describe("#PageLoadStrategy", func() {
+		It("should return an Option that sets the page load strategy", func() {
+			config := NewTestConfig()
+			PageLoadStrategy("eager")(config)
+			Expect(config.PageLoadStrategy).To(Equal("eager"))
+		})
+	})
+
+	Describe("#NavigationWait", func() {
+		It("should return an Option that sets the conditions to wait for after navigating", func() {
+			config := NewTestConfig()
+			NavigationWait(5*time.Second, ReadyState("complete"), TitleIs("some title"))(config)
+			Expect(config.NavigationTimeout).To(Equal(5 * time.Second))
+			Expect(config.NavigationWait).To(HaveLen(2))
+		})
+	})
+
+	Describe("#RequestInterception", func() {
+		It("should return an Option that enables request interception", func() {
+			config := NewTestConfig()
+			Expect(config.RequestInterception).To(BeFalse())
+			RequestInterception(config)
+			Expect(config.RequestInterception).To(BeTrue())
+		})
+	})
+
+	Describe("#PerformanceLogging", func() {
+		It("should return an Option that enables the performance log", func() {
+			config := NewTestConfig()
+			Expect(config.PerformanceLogging).To(BeFalse())
+			PerformanceLogging(config)
+			Expect(config.PerformanceLogging).To(BeTrue())
+		})
+	})
+
+	Describe("#BiDi", func() {
+		It("should return an Option that requests a WebDriver BiDi connection", func() {
+			config := NewTestConfig()
+			Expect(config.BiDi).To(BeFalse())
+			BiDi(config)
+			Expect(config.BiDi).To(BeTrue())
+		})
+	})
+
+	Describe("#CollectJSErrors", func() {
+		It("should return an Option that enables JavaScript error collection", func() {
+			config := NewTestConfig()
+			Expect(config.CollectJSErrors).To(BeFalse())
+			CollectJSErrors(config)
+			Expect(config.CollectJSErrors).To(BeTrue())
+		})
+	})
+
 	Describe("#Merge", func() {
 		It("should apply any provided options to an existing config", func() {
 			config := NewTestConfig()
@@ -86,5 +290,83 @@ var _ = Describe("Options", func() {
 			Expect(config.Capabilities()["browserName"]).To(Equal("some other browser"))
 			Expect(config.Capabilities()["acceptSslCerts"]).To(BeFalse())
 		})
+
+		It("should configure the download directory", func() {
+			config := NewTestConfig()
+			DownloadDirectory("/some/directory")(config)
+			chromePrefs := config.Capabilities()["goog:chromeOptions"].(map[string]interface{})["prefs"]
+			Expect(chromePrefs).To(HaveKeyWithValue("download.default_directory", "/some/directory"))
+		})
+
+		It("should enable the performance log", func() {
+			config := NewTestConfig()
+			PerformanceLogging(config)
+			Expect(config.Capabilities()["goog:loggingPrefs"]).To(HaveKeyWithValue("performance", "ALL"))
+		})
+
+		It("should configure Chrome mobile emulation", func() {
+			config := NewTestConfig()
+			EmulateDevice("iPhone 12")(config)
+			chromeOptions := config.Capabilities()["goog:chromeOptions"]
+			Expect(chromeOptions).To(HaveKeyWithValue("mobileEmulation", map[string]interface{}{"deviceName": "iPhone 12"}))
+		})
+
+		It("should configure the page load strategy", func() {
+			config := NewTestConfig()
+			PageLoadStrategy("eager")(config)
+			Expect(config.Capabilities()["pageLoadStrategy"]).To(Equal("eager"))
+			Expect(NewTestConfig().Capabilities()).NotTo(HaveKey("pageLoadStrategy"))
+		})
+
+		It("should request the WebDriver BiDi URL", func() {
+			config := NewTestConfig()
+			BiDi(config)
+			Expect(config.Capabilities()["webSocketUrl"]).To(BeTrue())
+		})
+
+		It("should configure the proxy", func() {
+			config := NewTestConfig()
+			Proxy(api.ProxyConfig{PAC: "http://example.com/proxy.pac"})(config)
+			Expect(config.Capabilities()["proxy"]).To(Equal(map[string]interface{}{
+				"proxyType":          "pac",
+				"proxyAutoconfigUrl": "http://example.com/proxy.pac",
+			}))
+		})
+
+		It("should configure the user agent and accepted languages", func() {
+			config := NewTestConfig()
+			UserAgent("some-agent")(config)
+			AcceptLanguage("fr-CA", "fr")(config)
+			firefoxPrefs := config.Capabilities()["moz:firefoxOptions"].(map[string]interface{})["prefs"]
+			Expect(firefoxPrefs).To(HaveKeyWithValue("general.useragent.override", "some-agent"))
+			Expect(firefoxPrefs).To(HaveKeyWithValue("intl.accept_languages", "fr-CA,fr"))
+		})
+
+		It("should configure headless browsers with a default window size", func() {
+			config := NewTestConfig()
+			Headless()(config)
+			capabilities := config.Capabilities()
+			Expect(capabilities["goog:chromeOptions"]).To(HaveKeyWithValue("args", []interface{}{"--headless", "--window-size=1920,1080"}))
+			Expect(capabilities["ms:edgeOptions"]).To(HaveKeyWithValue("args", []interface{}{"--headless", "--window-size=1920,1080"}))
+			Expect(capabilities["moz:firefoxOptions"]).To(HaveKeyWithValue("args", []interface{}{"-headless", "-width=1920", "-height=1080"}))
+		})
+
+		It("should not override the window size of headless browsers", func() {
+			config := NewTestConfig()
+			HeadlessNew()(config)
+			Desired(NewCapabilities().WindowSize(800, 600))(config)
+			capabilities := config.Capabilities()
+			Expect(capabilities["goog:chromeOptions"]).To(HaveKeyWithValue("args", []interface{}{"--window-size=800,600", "--headless=new"}))
+		})
+
+		It("should only include W3C capabilities when the WebDriver requires them", func() {
+			config := NewTestConfig()
+			config.W3COnly = true
+			Desired(NewCapabilities().Browser("firefox").Without("javascriptEnabled"))(config)
+			Expect(config.Capabilities()).To(Equal(Capabilities{
+				"browserName":         "firefox",
+				"acceptInsecureCerts": true,
+			}))
+		})
 	})
 })
@@ -5,20 +5,52 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
+	"github.com/sclevine/agouti/internal/proxy"
 )
 
 // A Page represents an open browser session. Pages may be created using the
-// *WebDriver.Page() method or by calling the NewPage or SauceLabs functions.
+// *WebDriver.Page() method or by calling the NewPage, Remote, or SauceLabs
+// functions.
 type Page struct {
 	selectable
-	logs map[string][]Log
+	logs              map[string][]Log
+	logsMutex         sync.Mutex
+	consoleLogs       *consoleLogStream
+	downloadDirectory string
+	interceptor       *proxy.Proxy
+	performanceLog    performanceLog
+	harRecording      *harRecording
+	tracing           *performanceSubscription
+	nodeURL           string
+	cloud             *cloudSession
+	releaseSession    func()
+	driverOutput      func() []byte
+	pool              *pagePool
+	acquired          bool
+
+	collectJSErrors      bool
+	jsErrorHookPreloaded bool
+	jsErrors             []JSError
+
+	loadScripts []string
+
+	alertsMutex    sync.Mutex
+	capturedAlerts []string
+
+	navigationTimeout    time.Duration
+	navigationConditions []Condition
 }
 
 // A Log represents a single log message
@@ -34,6 +66,20 @@ type Log struct {
 
 	// Time is the time the message was logged.
 	Time time.Time
+
+	// Text is the text of the log message without the source and location
+	// that Chrome prefixes Message with. For other browsers, Text is equal
+	// to Message.
+	Text string
+
+	// Source is the URL of the script or resource that logged the message, or
+	// the kind of source (ex. "console-api") if no URL is known
+	Source string
+
+	// Line and Column are the one-based position of the code that logged the
+	// message in its source, or 0 if unknown
+	Line   int
+	Column int
 }
 
 // NewPage opens a Page using the provided WebDriver URL. This method takes
@@ -41,15 +87,90 @@ type Log struct {
 // method will respect the HTTPClient Option if provided.
 func NewPage(url string, options ...Option) (*Page, error) {
 	pageOptions := config{}.Merge(options)
-	session, err := api.OpenWithClient(url, pageOptions.Capabilities(), pageOptions.HTTPClient)
+	page, err := openPage(pageOptions, func(capabilities map[string]interface{}) (*api.Session, error) {
+		return api.OpenWithClient(url, capabilities, pageOptions.HTTPClient)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page.cloud = newCloudSession(url, page.Session().ID(), pageOptions)
+	return page, nil
+}
+
+// JoinPage attaches to a browser session that is already running using the
+// provided WebDriver URL and session ID, such as a session opened by another
+// process. The session ID of an existing Page may be retrieved with
+// page.Session().ID(). Only the HTTPClient, DownloadDirectory, StaleRetries,
+// TestIDAttribute, and DebugLog Options are respected.
+func JoinPage(url, sessionID string, options ...Option) (*Page, error) {
+	pageOptions := config{}.Merge(options)
+	session, err := api.OpenWithSessionIDAndClient(url, sessionID, pageOptions.HTTPClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to WebDriver session: %s", err)
+	}
+	return newPage(session, pageOptions), nil
+}
+
+func openPage(pageOptions *config, open func(map[string]interface{}) (*api.Session, error)) (*Page, error) {
+	if pageOptions.Proxy != nil {
+		if pageOptions.RequestInterception {
+			return nil, errors.New("failed to configure proxy: the Proxy and RequestInterception Options cannot be combined")
+		}
+		if err := pageOptions.Proxy.Validate(); err != nil {
+			return nil, fmt.Errorf("failed to configure proxy: %s", err)
+		}
+	}
+
+	capabilities := pageOptions.Capabilities()
+
+	var interceptor *proxy.Proxy
+	if pageOptions.RequestInterception {
+		var err error
+		if interceptor, err = proxy.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start request interception proxy: %s", err)
+		}
+		capabilities.Proxy(interceptor.Addr())
+	}
+
+	session, err := open(capabilities)
 	if err != nil {
+		if interceptor != nil {
+			interceptor.Close()
+		}
+		if strings.Contains(err.Error(), "Allow Remote Automation") {
+			return nil, fmt.Errorf("failed to connect to WebDriver: remote automation is disabled in Safari, run 'safaridriver --enable' once to enable it: %s", err)
+		}
 		return nil, fmt.Errorf("failed to connect to WebDriver: %s", err)
 	}
-	return newPage(session), nil
+
+	page := newPage(session, pageOptions)
+	page.interceptor = interceptor
+	if pageOptions.CollectJSErrors {
+		page.startCollectingJSErrors()
+	}
+	if pageOptions.UserAgent != "" || len(pageOptions.AcceptLanguages) > 0 {
+		// Browsers without the DevTools Protocol are configured by their
+		// capabilities alone, so any error is ignored.
+		session.SetUserAgentOverride(pageOptions.UserAgent, strings.Join(pageOptions.AcceptLanguages, ","))
+	}
+	return page, nil
 }
 
-func newPage(session *api.Session) *Page {
-	return &Page{selectable{session, nil}, nil}
+func newPage(session *api.Session, pageOptions *config) *Page {
+	if pageOptions.DebugLog != nil {
+		session.AddCommandHook(api.DebugLog(pageOptions.DebugLog))
+	}
+	var cache *element.Cache
+	if pageOptions.ElementCache {
+		cache = element.NewCache()
+	}
+	return &Page{
+		selectable:           selectable{session, nil, pageOptions.staleRetries(), pageOptions.testIDAttribute(), cache},
+		downloadDirectory:    pageOptions.DownloadDirectory,
+		navigationTimeout:    pageOptions.NavigationTimeout,
+		navigationConditions: pageOptions.NavigationWait,
+	}
 }
 
 // String returns a string representation of the Page. Currently: "page"
@@ -63,11 +184,28 @@ func (p *Page) Session() *api.Session {
 	return p.session.(*api.Session)
 }
 
-// Destroy closes any open browsers by ending the session.
+// Destroy closes any open browsers by ending the session. If a test status
+// was set using SetTestStatus, it is then reported to the cloud provider.
 func (p *Page) Destroy() error {
+	p.stopConsoleLogs()
+
+	if p.interceptor != nil {
+		p.interceptor.Close()
+	}
+
+	if p.releaseSession != nil {
+		defer p.releaseSession()
+	}
+
 	if err := p.session.Delete(); err != nil {
 		return fmt.Errorf("failed to destroy session: %s", err)
 	}
+
+	if p.cloud != nil {
+		if err := p.cloud.report(); err != nil {
+			return fmt.Errorf("failed to report test status: %s", err)
+		}
+	}
 	return nil
 }
 
@@ -102,17 +240,88 @@ func (p *Page) Reset() error {
 		}
 	}
 
-	return p.Navigate("about:blank")
+	// The conditions set by WaitAfterNavigation may never be satisfied by
+	// a blank page, so they are not waited for.
+	p.cache.Invalidate()
+	if err := p.navigate(func() error { return p.session.SetURL("about:blank") }); err != nil {
+		return fmt.Errorf("failed to navigate: %s", err)
+	}
+	return nil
 }
 
 // Navigate navigates to the provided URL.
 func (p *Page) Navigate(url string) error {
-	if err := p.session.SetURL(url); err != nil {
+	if err := p.navigateAndWait(func() error { return p.session.SetURL(url) }); err != nil {
 		return fmt.Errorf("failed to navigate: %s", err)
 	}
 	return nil
 }
 
+// NavigateWithAuth navigates to the provided URL, answering HTTP
+// authentication challenges (ex. Basic or Digest) with the provided
+// credentials until ClearAuth is called or the page is destroyed. The
+// credentials are only sent in response to a challenge, so they are not sent
+// to other origins that the page loads resources from. Challenges are
+// answered using the DevTools Protocol, so only Chromium-based browsers, such
+// as Chrome, support this.
+func (p *Page) NavigateWithAuth(url, username, password string) error {
+	if err := p.session.SetHTTPAuth(username, password); err != nil {
+		return fmt.Errorf("failed to set HTTP authentication: %s", err)
+	}
+	return p.Navigate(url)
+}
+
+// ClearAuth stops answering HTTP authentication challenges with the
+// credentials provided to NavigateWithAuth.
+func (p *Page) ClearAuth() error {
+	if err := p.session.ClearHTTPAuth(); err != nil {
+		return fmt.Errorf("failed to clear HTTP authentication: %s", err)
+	}
+	return nil
+}
+
+// SetExtraHTTPHeaders adds the provided headers to every request made by the
+// browser, replacing any headers provided previously. Passing nil removes
+// them. Only browsers that support the DevTools Protocol (ex. Chrome) support
+// this.
+func (p *Page) SetExtraHTTPHeaders(headers map[string]string) error {
+	if err := p.session.SetExtraHTTPHeaders(headers); err != nil {
+		return fmt.Errorf("failed to set extra HTTP headers: %s", err)
+	}
+	return nil
+}
+
+// Network condition presets for use with SetNetworkConditions, matching
+// the presets in the Chrome DevTools network panel.
+var (
+	Offline = api.NetworkConditions{Offline: true}
+	Slow3G  = api.NetworkConditions{Latency: 2000 * time.Millisecond, Download: 50000, Upload: 50000}
+	Fast3G  = api.NetworkConditions{Latency: 562500 * time.Microsecond, Download: 180000, Upload: 84375}
+)
+
+// SetNetworkConditions emulates the provided network conditions, such as the
+// Slow3G or Offline presets, until ClearNetworkConditions is called. Only
+// Chrome supports this.
+//
+// Example:
+//    page.SetNetworkConditions(agouti.Offline)
+//    Eventually(page.Find(".offline-banner")).Should(BeVisible())
+func (p *Page) SetNetworkConditions(conditions api.NetworkConditions) error {
+	if err := p.session.SetNetworkConditions(conditions); err != nil {
+		return fmt.Errorf("failed to set network conditions: %s", err)
+	}
+	return nil
+}
+
+// ClearNetworkConditions stops emulating the network conditions provided to
+// SetNetworkConditions.
+func (p *Page) ClearNetworkConditions() error {
+	if err := p.session.ClearNetworkConditions(); err != nil {
+		return fmt.Errorf("failed to clear network conditions: %s", err)
+	}
+	return nil
+}
+
 // GetCookies returns all cookies on the page.
 func (p *Page) GetCookies() ([]*http.Cookie, error) {
 	apiCookies, err := p.session.GetCookies()
@@ -121,44 +330,28 @@ func (p *Page) GetCookies() ([]*http.Cookie, error) {
 	}
 	cookies := []*http.Cookie{}
 	for _, apiCookie := range apiCookies {
-		expSeconds := int64(apiCookie.Expiry)
-		expNano := int64(apiCookie.Expiry-float64(expSeconds)) * 1000000000
-		cookie := &http.Cookie{
-			Name:     apiCookie.Name,
-			Value:    apiCookie.Value,
-			Path:     apiCookie.Path,
-			Domain:   apiCookie.Domain,
-			Secure:   apiCookie.Secure,
-			HttpOnly: apiCookie.HTTPOnly,
-			Expires:  time.Unix(expSeconds, expNano),
-		}
-		cookies = append(cookies, cookie)
+		cookies = append(cookies, apiCookie.HTTPCookie())
 	}
 	return cookies, nil
 }
 
+// GetNamedCookie returns the cookie on the page with the provided name,
+// including its SameSite attribute.
+func (p *Page) GetNamedCookie(name string) (*http.Cookie, error) {
+	apiCookie, err := p.session.GetNamedCookie(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cookie %s: %s", name, err)
+	}
+	return apiCookie.HTTPCookie(), nil
+}
+
 // SetCookie sets a cookie on the page.
 func (p *Page) SetCookie(cookie *http.Cookie) error {
 	if cookie == nil {
 		return errors.New("nil cookie is invalid")
 	}
 
-	var expiry int64
-	if !cookie.Expires.IsZero() {
-		expiry = cookie.Expires.Unix()
-	}
-
-	apiCookie := &api.Cookie{
-		Name:     cookie.Name,
-		Value:    cookie.Value,
-		Path:     cookie.Path,
-		Domain:   cookie.Domain,
-		Secure:   cookie.Secure,
-		HTTPOnly: cookie.HttpOnly,
-		Expiry:   float64(expiry),
-	}
-
-	if err := p.session.SetCookie(apiCookie); err != nil {
+	if err := p.session.SetCookie(api.NewCookie(cookie)); err != nil {
 		return fmt.Errorf("failed to set cookie: %s", err)
 	}
 	return nil
@@ -203,15 +396,194 @@ func (p *Page) Size(width, height int) error {
 	return nil
 }
 
+// WindowSize returns the current page size in pixels.
+func (p *Page) WindowSize() (width, height int, err error) {
+	window, err := p.session.GetWindow()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to retrieve window: %s", err)
+	}
+
+	width, height, err = window.GetSize()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to retrieve window size: %s", err)
+	}
+
+	return width, height, nil
+}
+
+// SetWindowPosition moves the top-left corner of the current window to the
+// provided screen coordinates.
+func (p *Page) SetWindowPosition(x, y int) error {
+	window, err := p.session.GetWindow()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve window: %s", err)
+	}
+
+	if err := window.SetPosition(x, y); err != nil {
+		return fmt.Errorf("failed to set window position: %s", err)
+	}
+
+	return nil
+}
+
+// WindowPosition returns the screen coordinates of the top-left corner of the
+// current window.
+func (p *Page) WindowPosition() (x, y int, err error) {
+	window, err := p.session.GetWindow()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to retrieve window: %s", err)
+	}
+
+	x, y, err = window.GetPosition()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to retrieve window position: %s", err)
+	}
+
+	return x, y, nil
+}
+
+// MaximizeWindow maximizes the current window.
+func (p *Page) MaximizeWindow() error {
+	return p.windowCommand("maximize", (*api.Window).Maximize)
+}
+
+// MinimizeWindow minimizes the current window.
+func (p *Page) MinimizeWindow() error {
+	return p.windowCommand("minimize", (*api.Window).Minimize)
+}
+
+// FullscreenWindow makes the current window fill the screen.
+func (p *Page) FullscreenWindow() error {
+	return p.windowCommand("fullscreen", (*api.Window).Fullscreen)
+}
+
+func (p *Page) windowCommand(name string, command func(*api.Window) error) error {
+	window, err := p.session.GetWindow()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve window: %s", err)
+	}
+
+	if err := command(window); err != nil {
+		return fmt.Errorf("failed to %s window: %s", name, err)
+	}
+
+	return nil
+}
+
 // Screenshot takes a screenshot and saves it to the provided filename.
 // The provided filename may be an absolute or relative path.
 func (p *Page) Screenshot(filename string) error {
+	return saveScreenshot(filename, p.session.GetScreenshot)
+}
+
+// FullScreenshot takes a screenshot of the entire page, including any content
+// outside of the viewport, and saves it to the provided filename.
+// The provided filename may be an absolute or relative path.
+func (p *Page) FullScreenshot(filename string) error {
+	return saveScreenshot(filename, p.session.GetFullPageScreenshot)
+}
+
+// WaitForDownload waits up to the provided timeout for a downloaded file that
+// matches the provided pattern (see filepath.Match) to finish downloading, and
+// returns the path of the file. Relative patterns are matched in the directory
+// provided by the DownloadDirectory Option. Files that already match the
+// pattern when WaitForDownload is called are only returned once they are
+// modified, so a download that finishes before WaitForDownload is called is
+// not detected. Use WaitForDownloadAfter to start the download instead.
+//
+// Example:
+//    filename, err := page.WaitForDownload("report-*.csv", 10*time.Second)
+func (p *Page) WaitForDownload(pattern string, timeout time.Duration) (string, error) {
+	return p.WaitForDownloadAfter(pattern, timeout, nil)
+}
+
+// WaitForDownloadAfter calls the provided action, such as clicking a download
+// link, and then waits for a download as described by WaitForDownload. The
+// files that match the pattern are recorded before the action is called, so
+// the download is detected even if it finishes before the action returns.
+//
+// Example:
+//    filename, err := page.WaitForDownloadAfter("report-*.csv", 10*time.Second, page.FindByLink("Export").Click)
+func (p *Page) WaitForDownloadAfter(pattern string, timeout time.Duration, action func() error) (string, error) {
+	if !filepath.IsAbs(pattern) && p.downloadDirectory != "" {
+		pattern = filepath.Join(p.downloadDirectory, pattern)
+	}
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return "", fmt.Errorf("invalid download pattern: %s", err)
+	}
+
+	existing := downloadModTimes(pattern)
+	if action != nil {
+		if err := action(); err != nil {
+			return "", fmt.Errorf("failed to start download: %s", err)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		if filename, ok := completedDownload(pattern, existing); ok {
+			return filename, nil
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("failed to find completed download matching %s within %s", pattern, timeout)
+		}
+		time.Sleep(downloadPollInterval)
+	}
+}
+
+const downloadPollInterval = 100 * time.Millisecond
+
+// Chrome and Firefox store incomplete downloads in files with these suffixes.
+var partialDownloadSuffixes = []string{".crdownload", ".part"}
+
+func downloadModTimes(pattern string) map[string]time.Time {
+	modTimes := map[string]time.Time{}
+	matches, _ := filepath.Glob(pattern)
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil {
+			modTimes[match] = info.ModTime()
+		}
+	}
+	return modTimes
+}
+
+func completedDownload(pattern string, existing map[string]time.Time) (string, bool) {
+	matches, _ := filepath.Glob(pattern)
+	for _, match := range matches {
+		if isPartialDownload(match) {
+			continue
+		}
+		info, err := os.Stat(match)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if modTime, ok := existing[match]; ok && info.ModTime().Equal(modTime) {
+			continue
+		}
+		return match, true
+	}
+	return "", false
+}
+
+func isPartialDownload(filename string) bool {
+	for _, suffix := range partialDownloadSuffixes {
+		if strings.HasSuffix(filename, suffix) {
+			return true
+		}
+		if _, err := os.Stat(filename + suffix); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func saveScreenshot(filename string, getScreenshot func() ([]byte, error)) error {
 	absFilePath, err := filepath.Abs(filename)
 	if err != nil {
 		return fmt.Errorf("failed to find absolute path for filename: %s", err)
 	}
 
-	screenshot, err := p.session.GetScreenshot()
+	screenshot, err := getScreenshot()
 	if err != nil {
 		return fmt.Errorf("failed to retrieve screenshot: %s", err)
 	}
@@ -264,9 +636,44 @@ func (p *Page) RunScript(body string, arguments map[string]interface{}, result i
 	argumentList := strings.Join(keys, ", ")
 	cleanBody := fmt.Sprintf("return (function(%s) { %s; }).apply(this, arguments);", argumentList, body)
 
+	p.cache.Invalidate()
 	if err := p.session.Execute(cleanBody, values, result); err != nil {
 		return fmt.Errorf("failed to run script: %s", err)
 	}
+	p.hydrateSelections(reflect.ValueOf(result))
+
+	return nil
+}
+
+// RunScriptAsync runs the JavaScript provided in the body asynchronously.
+// Like RunScript, any keys present in the arguments map will be available
+// as variables in the body. The body must call the provided done function to
+// complete, and any value passed to done will be unmarshalled into the result
+// argument. The maximum time to wait for done is set by SetScriptTimeout.
+// Simple example:
+//    var number int
+//    page.RunScriptAsync("setTimeout(function() { done(test); }, 10);", map[string]interface{}{"test": 100}, &number)
+//    fmt.Println(number)
+// -> 100
+func (p *Page) RunScriptAsync(body string, arguments map[string]interface{}, result interface{}) error {
+	var (
+		keys   []string
+		values []interface{}
+	)
+
+	for key, value := range arguments {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	argumentList := strings.Join(append(keys, "done"), ", ")
+	cleanBody := fmt.Sprintf("(function(%s) { %s; }).apply(this, arguments);", argumentList, body)
+
+	p.cache.Invalidate()
+	if err := p.session.ExecuteAsync(cleanBody, values, result); err != nil {
+		return fmt.Errorf("failed to run script: %s", err)
+	}
+	p.hydrateSelections(reflect.ValueOf(result))
 
 	return nil
 }
@@ -306,7 +713,7 @@ func (p *Page) CancelPopup() error {
 
 // Forward navigates forward in history.
 func (p *Page) Forward() error {
-	if err := p.session.Forward(); err != nil {
+	if err := p.navigateAndWait(p.session.Forward); err != nil {
 		return fmt.Errorf("failed to navigate forward in history: %s", err)
 	}
 	return nil
@@ -314,7 +721,7 @@ func (p *Page) Forward() error {
 
 // Back navigates backwards in history.
 func (p *Page) Back() error {
-	if err := p.session.Back(); err != nil {
+	if err := p.navigateAndWait(p.session.Back); err != nil {
 		return fmt.Errorf("failed to navigate backwards in history: %s", err)
 	}
 	return nil
@@ -322,7 +729,7 @@ func (p *Page) Back() error {
 
 // Refresh refreshes the page.
 func (p *Page) Refresh() error {
-	if err := p.session.Refresh(); err != nil {
+	if err := p.navigateAndWait(p.session.Refresh); err != nil {
 		return fmt.Errorf("failed to refresh page: %s", err)
 	}
 	return nil
@@ -334,6 +741,7 @@ func (p *Page) Refresh() error {
 //
 // This method is not supported by PhantomJS. Please use SwitchToRootFrame instead.
 func (p *Page) SwitchToParentFrame() error {
+	p.cache.Invalidate()
 	if err := p.session.FrameParent(); err != nil {
 		return fmt.Errorf("failed to switch to parent frame: %s", err)
 	}
@@ -345,21 +753,105 @@ func (p *Page) SwitchToParentFrame() error {
 // will refer to the root frame. All further Page methods will apply to this frame
 // as well.
 func (p *Page) SwitchToRootFrame() error {
+	p.cache.Invalidate()
 	if err := p.session.Frame(nil); err != nil {
 		return fmt.Errorf("failed to switch to original page frame: %s", err)
 	}
 	return nil
 }
 
+// Frames are identified by their index in the window.frames of their parent,
+// which is also available for cross-origin frames.
+const framePathScript = `
+	var path = [];
+	for (var current = window; current !== current.parent; current = current.parent) {
+		for (var index = 0; index < current.parent.frames.length; index++) {
+			if (current.parent.frames[index] === current) {
+				path.unshift(index);
+				break;
+			}
+		}
+	}
+	return path;
+`
+
+// WithinFrame switches to the frame specified by the provided *Selection or
+// *MultiSelection, calls the provided function with the page, and then always
+// switches back to the frame that was focused when WithinFrame was called,
+// even if the function fails. The error returned by the function is returned.
+//
+// Example:
+//    err := page.WithinFrame(page.Find("iframe#payment"), func(frame *agouti.Page) error {
+//        return frame.Find("#card-number").Fill("4242424242424242")
+//    })
+func (p *Page) WithinFrame(frame interface{}, body func(*Page) error) error {
+	selection, err := toSelection(frame)
+	if err != nil {
+		return fmt.Errorf("failed to switch to frame: %s", err)
+	}
+
+	var framePath []int
+	if err := p.session.Execute(framePathScript, nil, &framePath); err != nil {
+		return fmt.Errorf("failed to determine current frame: %s", err)
+	}
+
+	if err := selection.SwitchToFrame(); err != nil {
+		return err
+	}
+
+	bodyErr := body(p)
+	if err := p.switchToFramePath(framePath); err != nil && bodyErr == nil {
+		return fmt.Errorf("failed to switch to original frame: %s", err)
+	}
+	return bodyErr
+}
+
+func (p *Page) switchToFramePath(framePath []int) error {
+	p.cache.Invalidate()
+	if err := p.session.Frame(nil); err != nil {
+		return err
+	}
+	for _, index := range framePath {
+		if err := p.session.FrameIndex(index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SwitchToWindow switches to the first available window with the provided name
 // (JavaScript `window.name` attribute).
 func (p *Page) SwitchToWindow(name string) error {
+	p.cache.Invalidate()
 	if err := p.session.SetWindowByName(name); err != nil {
 		return fmt.Errorf("failed to switch to named window: %s", err)
 	}
 	return nil
 }
 
+// NewTab opens a new tab and switches to it.
+func (p *Page) NewTab() error {
+	return p.openWindow("tab")
+}
+
+// NewWindow opens a new window and switches to it.
+func (p *Page) NewWindow() error {
+	return p.openWindow("window")
+}
+
+func (p *Page) openWindow(kind string) error {
+	window, err := p.session.NewWindow(kind)
+	if err != nil {
+		return fmt.Errorf("failed to open new %s: %s", kind, err)
+	}
+
+	p.cache.Invalidate()
+	if err := p.session.SetWindow(window); err != nil {
+		return fmt.Errorf("failed to switch to new %s: %s", kind, err)
+	}
+	return nil
+}
+
 // NextWindow switches to the next available window.
 func (p *Page) NextWindow() error {
 	windows, err := p.session.GetWindows()
@@ -387,6 +879,7 @@ func (p *Page) NextWindow() error {
 		}
 	}
 
+	p.cache.Invalidate()
 	if err := p.session.SetWindow(activeWindow); err != nil {
 		return fmt.Errorf("failed to change active window: %s", err)
 	}
@@ -396,6 +889,7 @@ func (p *Page) NextWindow() error {
 
 // CloseWindow closes the active window.
 func (p *Page) CloseWindow() error {
+	p.cache.Invalidate()
 	if err := p.session.DeleteWindow(); err != nil {
 		return fmt.Errorf("failed to close active window: %s", err)
 	}
@@ -411,6 +905,102 @@ func (p *Page) WindowCount() (int, error) {
 	return len(windows), nil
 }
 
+// RunInWindow switches to the window with the provided name or handle, calls
+// the provided function with the page, and then always switches back to the
+// original window, even if the function fails. The error returned by the
+// function is returned. The function may close the window.
+//
+// W3C drivers only switch windows by handle, so when no window has the
+// provided handle, each window is checked for a matching window.name.
+//
+// Example:
+//    handle, err := page.WaitForNewWindow(5*time.Second, page.FindByLink("Sign in").Click)
+//    err = page.RunInWindow(handle, func(popup *agouti.Page) error {
+//        return popup.FindByButton("Authorize").Click()
+//    })
+func (p *Page) RunInWindow(nameOrHandle string, body func(*Page) error) error {
+	original, err := p.session.GetWindow()
+	if err != nil {
+		return fmt.Errorf("failed to find active window: %s", err)
+	}
+
+	p.cache.Invalidate()
+	if err := p.session.SetWindowByName(nameOrHandle); err != nil {
+		if found, findErr := p.findWindowByName(nameOrHandle); findErr != nil || !found {
+			p.session.SetWindow(original)
+			return fmt.Errorf("failed to switch to window: %s", err)
+		}
+	}
+
+	bodyErr := body(p)
+	p.cache.Invalidate()
+	if err := p.session.SetWindow(original); err != nil && bodyErr == nil {
+		return fmt.Errorf("failed to switch to original window: %s", err)
+	}
+	return bodyErr
+}
+
+// findWindowByName switches to each available window until it finds one
+// whose window.name matches the provided name.
+func (p *Page) findWindowByName(name string) (bool, error) {
+	windows, err := p.session.GetWindows()
+	if err != nil {
+		return false, err
+	}
+	for _, window := range windows {
+		if err := p.session.SetWindow(window); err != nil {
+			return false, err
+		}
+		var windowName string
+		if err := p.session.Execute("return window.name;", nil, &windowName); err != nil {
+			return false, err
+		}
+		if windowName == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// WaitForNewWindow calls the provided function, ex. to click a link that
+// opens a popup, and then waits up to the provided timeout for a new window
+// to open. The handle of the new window is returned, and may be provided to
+// RunInWindow or SwitchToWindow. The active window is not changed.
+func (p *Page) WaitForNewWindow(timeout time.Duration, open func() error) (string, error) {
+	windows, err := p.session.GetWindows()
+	if err != nil {
+		return "", fmt.Errorf("failed to find available windows: %s", err)
+	}
+
+	knownWindows := map[string]bool{}
+	for _, window := range windows {
+		knownWindows[window.ID] = true
+	}
+
+	if err := open(); err != nil {
+		return "", err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		windows, err := p.session.GetWindows()
+		if err != nil {
+			return "", fmt.Errorf("failed to find available windows: %s", err)
+		}
+		for _, window := range windows {
+			if !knownWindows[window.ID] {
+				return window.ID, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("failed to find new window within %s", timeout)
+		}
+		time.Sleep(windowPollInterval)
+	}
+}
+
+const windowPollInterval = 100 * time.Millisecond
+
 // LogTypes returns all of the valid log types that may be used with a LogReader.
 func (p *Page) LogTypes() ([]string, error) {
 	types, err := p.session.GetLogTypes()
@@ -425,45 +1015,99 @@ func (p *Page) LogTypes() ([]string, error) {
 // logs and errors. Only logs since the last call to ReadNewLogs are returned.
 // Valid log types may be obtained using the LogTypes method.
 func (p *Page) ReadNewLogs(logType string) ([]Log, error) {
+	p.logsMutex.Lock()
+	defer p.logsMutex.Unlock()
+
+	logs, err := p.readNewLogs(logType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve logs: %s", err)
+	}
+	return logs, nil
+}
+
+func (p *Page) readNewLogs(logType string) ([]Log, error) {
 	if p.logs == nil {
 		p.logs = map[string][]Log{}
 	}
 
 	clientLogs, err := p.session.NewLogs(logType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve logs: %s", err)
+		return nil, err
 	}
 
-	messageMatcher := regexp.MustCompile(`^(?s:(.+))\s\(([^)]*:\w*)\)$`)
-
 	var logs []Log
 	for _, clientLog := range clientLogs {
-		matches := messageMatcher.FindStringSubmatch(clientLog.Message)
-		message, location := clientLog.Message, ""
-		if len(matches) > 2 {
-			message, location = matches[1], matches[2]
-		}
-
-		log := Log{message, location, clientLog.Level, msToTime(clientLog.Timestamp)}
-		logs = append(logs, log)
+		logs = append(logs, parseLog(clientLog))
 	}
 	p.logs[logType] = append(p.logs[logType], logs...)
 
 	return logs, nil
 }
 
+var (
+	chromeLogMatcher = regexp.MustCompile(`^(\S+://\S*|console-api|javascript|network|deprecation|intervention|security|worker|other) (?:(\d+):(\d+)|-) (?s:(.*))$`)
+	legacyLogMatcher = regexp.MustCompile(`^(?s:(.+))\s\(([^)]*:\w*)\)$`)
+	locationMatcher  = regexp.MustCompile(`^(\d+):(\d+)$`)
+)
+
+// Chrome prefixes messages with their source and location, while other
+// browsers may suffix them with their location in parentheses. Chrome messages
+// are left unchanged, so that matchers comparing them to the full message
+// continue to match.
+func parseLog(clientLog api.Log) Log {
+	log := Log{
+		Message: clientLog.Message,
+		Level:   clientLog.Level,
+		Time:    msToTime(clientLog.Timestamp),
+		Text:    clientLog.Message,
+	}
+
+	if matches := chromeLogMatcher.FindStringSubmatch(clientLog.Message); matches != nil {
+		log.Source, log.Text = matches[1], matches[4]
+		if matches[2] != "" {
+			log.Location = matches[2] + ":" + matches[3]
+		}
+	} else if matches := legacyLogMatcher.FindStringSubmatch(clientLog.Message); matches != nil {
+		log.Message, log.Location = matches[1], matches[2]
+		log.Text = log.Message
+	}
+
+	if matches := locationMatcher.FindStringSubmatch(log.Location); matches != nil {
+		log.Line, _ = strconv.Atoi(matches[1])
+		log.Column, _ = strconv.Atoi(matches[2])
+	}
+	return log
+}
+
 // ReadAllLogs returns all log messages of the provided log type. For example,
 // page.ReadAllLogs("browser") returns browser console logs, such as JavaScript logs
 // and errors. All logs since the session was created are returned.
 // Valid log types may be obtained using the LogTypes method.
 func (p *Page) ReadAllLogs(logType string) ([]Log, error) {
-	if _, err := p.ReadNewLogs(logType); err != nil {
-		return nil, err
+	p.logsMutex.Lock()
+	defer p.logsMutex.Unlock()
+
+	if _, err := p.readNewLogs(logType); err != nil {
+		return nil, fmt.Errorf("failed to retrieve logs: %s", err)
 	}
 
 	return append([]Log(nil), p.logs[logType]...), nil
 }
 
+// ClearLogs discards any browser console logs that have not been read, and
+// removes all previously read logs of every log type, so that ReadAllLogs
+// only returns logs created after ClearLogs is called.
+func (p *Page) ClearLogs() error {
+	p.logsMutex.Lock()
+	defer p.logsMutex.Unlock()
+
+	if _, err := p.session.NewLogs("browser"); err != nil {
+		return fmt.Errorf("failed to clear logs: %s", err)
+	}
+	p.logs = nil
+	return nil
+}
+
 func msToTime(ms int64) time.Time {
 	seconds := ms / 1000
 	nanoseconds := (ms % 1000) * 1000000
@@ -510,6 +1154,43 @@ func (p *Page) Click(event Click, button Button) error {
 	return nil
 }
 
+// Orientation returns the screen orientation of a mobile device, either
+// "LANDSCAPE" or "PORTRAIT".
+func (p *Page) Orientation() (string, error) {
+	orientation, err := p.session.GetOrientation()
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve orientation: %s", err)
+	}
+	return orientation, nil
+}
+
+// SetOrientation rotates a mobile device to the provided orientation, either
+// "LANDSCAPE" or "PORTRAIT".
+func (p *Page) SetOrientation(orientation string) error {
+	if err := p.session.SetOrientation(orientation); err != nil {
+		return fmt.Errorf("failed to set orientation: %s", err)
+	}
+	return nil
+}
+
+// GetGeolocation returns the geolocation reported by the browser.
+func (p *Page) GetGeolocation() (api.Geolocation, error) {
+	location, err := p.session.GetGeolocation()
+	if err != nil {
+		return api.Geolocation{}, fmt.Errorf("failed to get geolocation: %s", err)
+	}
+	return location, nil
+}
+
+// SetGeolocation overrides the geolocation reported by the browser. The
+// latitude and longitude are in decimal degrees, and the altitude is in meters.
+func (p *Page) SetGeolocation(latitude, longitude, altitude float64) error {
+	if err := p.session.SetGeolocation(latitude, longitude, altitude); err != nil {
+		return fmt.Errorf("failed to set geolocation: %s", err)
+	}
+	return nil
+}
+
 // SetImplicitWait sets the implicit wait timeout (in ms)
 func (p *Page) SetImplicitWait(timeout int) error {
 	return p.session.SetImplicitWait(timeout)
@@ -524,3 +1205,24 @@ func (p *Page) SetPageLoad(timeout int) error {
 func (p *Page) SetScriptTimeout(timeout int) error {
 	return p.session.SetScriptTimeout(timeout)
 }
+
+// SetTimeouts sets the implicit wait, page load, and script timeouts at once.
+// Timeouts that are zero are left unchanged.
+//
+// Example:
+//    page.SetTimeouts(api.Timeouts{Script: 5 * time.Second})
+func (p *Page) SetTimeouts(timeouts api.Timeouts) error {
+	if err := p.session.SetTimeouts(timeouts); err != nil {
+		return fmt.Errorf("failed to set timeouts: %s", err)
+	}
+	return nil
+}
+
+// GetTimeouts returns the current implicit wait, page load, and script timeouts.
+func (p *Page) GetTimeouts() (api.Timeouts, error) {
+	timeouts, err := p.session.GetTimeouts()
+	if err != nil {
+		return api.Timeouts{}, fmt.Errorf("failed to get timeouts: %s", err)
+	}
+	return timeouts, nil
+}
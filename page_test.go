@@ -1,9 +1,11 @@
 package agouti_test
 
 import (
+	"bytes"
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"time"
@@ -170,6 +172,97 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#NavigateWithAuth", func() {
+		It("should answer authentication challenges with the credentials and navigate to the URL", func() {
+			Expect(page.NavigateWithAuth("http://example.com", "some-user", "some-password")).To(Succeed())
+			Expect(session.SetHTTPAuthCall.Username).To(Equal("some-user"))
+			Expect(session.SetHTTPAuthCall.Password).To(Equal("some-password"))
+			Expect(session.SetURLCall.URL).To(Equal("http://example.com"))
+		})
+
+		It("should not send the credentials in a header or the URL", func() {
+			Expect(page.NavigateWithAuth("http://example.com", "some-user", "some-password")).To(Succeed())
+			Expect(session.SetExtraHTTPHeadersCall.Headers).To(BeNil())
+			Expect(session.SetURLCall.URL).NotTo(ContainSubstring("some-user"))
+		})
+
+		Context("when the browser does not support authentication challenges", func() {
+			It("should return an error without navigating", func() {
+				session.SetHTTPAuthCall.Err = errors.New("some error")
+				Expect(page.NavigateWithAuth("http://example.com", "some-user", "some-password")).To(MatchError("failed to set HTTP authentication: some error"))
+				Expect(session.SetURLCall.URL).To(BeEmpty())
+			})
+		})
+
+		Context("when the navigate fails", func() {
+			It("should return an error", func() {
+				session.SetURLCall.Err = errors.New("some error")
+				Expect(page.NavigateWithAuth("http://example.com", "some-user", "some-password")).To(MatchError("failed to navigate: some error"))
+			})
+		})
+	})
+
+	Describe("#ClearAuth", func() {
+		It("should stop answering authentication challenges", func() {
+			Expect(page.ClearAuth()).To(Succeed())
+			Expect(session.ClearHTTPAuthCall.Called).To(BeTrue())
+		})
+
+		Context("when the session fails to clear the credentials", func() {
+			It("should return an error", func() {
+				session.ClearHTTPAuthCall.Err = errors.New("some error")
+				Expect(page.ClearAuth()).To(MatchError("failed to clear HTTP authentication: some error"))
+			})
+		})
+	})
+
+	Describe("#SetExtraHTTPHeaders", func() {
+		It("should successfully set the headers", func() {
+			headers := map[string]string{"Some-Header": "some value"}
+			Expect(page.SetExtraHTTPHeaders(headers)).To(Succeed())
+			Expect(session.SetExtraHTTPHeadersCall.Headers).To(Equal(headers))
+		})
+
+		Context("when the session fails to set the headers", func() {
+			It("should return an error", func() {
+				session.SetExtraHTTPHeadersCall.Err = errors.New("some error")
+				Expect(page.SetExtraHTTPHeaders(nil)).To(MatchError("failed to set extra HTTP headers: some error"))
+			})
+		})
+	})
+
+	Describe("#SetNetworkConditions", func() {
+		It("should successfully set the network conditions", func() {
+			Expect(page.SetNetworkConditions(Slow3G)).To(Succeed())
+			Expect(session.SetNetworkConditionsCall.Conditions).To(Equal(api.NetworkConditions{
+				Latency:  2 * time.Second,
+				Download: 50000,
+				Upload:   50000,
+			}))
+		})
+
+		Context("when the session fails to set the network conditions", func() {
+			It("should return an error", func() {
+				session.SetNetworkConditionsCall.Err = errors.New("some error")
+				Expect(page.SetNetworkConditions(Offline)).To(MatchError("failed to set network conditions: some error"))
+			})
+		})
+	})
+
+	Describe("#ClearNetworkConditions", func() {
+		It("should successfully clear the network conditions", func() {
+			Expect(page.ClearNetworkConditions()).To(Succeed())
+			Expect(session.ClearNetworkConditionsCall.Called).To(BeTrue())
+		})
+
+		Context("when the session fails to clear the network conditions", func() {
+			It("should return an error", func() {
+				session.ClearNetworkConditionsCall.Err = errors.New("some error")
+				Expect(page.ClearNetworkConditions()).To(MatchError("failed to clear network conditions: some error"))
+			})
+		})
+	})
+
 	Describe("#GetCookies", func() {
 		It("should sucessfully retrieve all cookies from the session", func() {
 			session.GetCookiesCall.ReturnCookies = []*api.Cookie{
@@ -180,7 +273,7 @@ var _ = Describe("Page", func() {
 					Domain:   "example.com",
 					Secure:   true,
 					HTTPOnly: true,
-					Expiry:   100,
+					Expiry:   time.Unix(100, 0),
 				},
 				{
 					Name:     "some other cookie",
@@ -189,7 +282,7 @@ var _ = Describe("Page", func() {
 					Domain:   "other.example.com",
 					Secure:   false,
 					HTTPOnly: false,
-					Expiry:   200,
+					Expiry:   time.Unix(200, 0),
 				},
 			}
 			Expect(page.GetCookies()).To(Equal([]*http.Cookie{
@@ -223,6 +316,32 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#GetNamedCookie", func() {
+		It("should successfully retrieve the cookie with the provided name", func() {
+			session.GetNamedCookieCall.ReturnCookie = &api.Cookie{
+				Name:     "some cookie",
+				Value:    "some value",
+				SameSite: "Strict",
+				Expiry:   time.Unix(100, 0),
+			}
+			Expect(page.GetNamedCookie("some cookie")).To(Equal(&http.Cookie{
+				Name:     "some cookie",
+				Value:    "some value",
+				SameSite: http.SameSiteStrictMode,
+				Expires:  time.Unix(100, 0),
+			}))
+			Expect(session.GetNamedCookieCall.Name).To(Equal("some cookie"))
+		})
+
+		Context("when retrieving the cookie from the session fails", func() {
+			It("should return an error", func() {
+				session.GetNamedCookieCall.Err = errors.New("some error")
+				_, err := page.GetNamedCookie("some cookie")
+				Expect(err).To(MatchError("failed to get cookie some cookie: some error"))
+			})
+		})
+	})
+
 	Describe("#SetCookie", func() {
 		It("should successfully instruct the session to add the cookie to the session", func() {
 			cookie := &http.Cookie{
@@ -242,10 +361,15 @@ var _ = Describe("Page", func() {
 				Domain:   "example.com",
 				Secure:   true,
 				HTTPOnly: true,
-				Expiry:   100,
+				Expiry:   time.Unix(100, 0),
 			}))
 		})
 
+		It("should convert the SameSite attribute", func() {
+			Expect(page.SetCookie(&http.Cookie{Name: "some cookie", SameSite: http.SameSiteLaxMode})).To(Succeed())
+			Expect(session.SetCookieCall.Cookie).To(Equal(&api.Cookie{Name: "some cookie", SameSite: "Lax"}))
+		})
+
 		Context("when the expiry is not provided", func() {
 			It("should default to zero", func() {
 				Expect(page.SetCookie(&http.Cookie{})).To(Succeed())
@@ -345,6 +469,268 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("window geometry", func() {
+		var (
+			bus    *mocks.Bus
+			window *api.Window
+		)
+
+		BeforeEach(func() {
+			bus = &mocks.Bus{}
+			window = &api.Window{ID: "some-id", Session: &api.Session{Bus: bus}}
+			session.GetWindowCall.ReturnWindow = window
+		})
+
+		Describe("#WindowSize", func() {
+			It("should return the width and height of the window", func() {
+				bus.SendCall.Result = `{"width": 640, "height": 480}`
+				width, height, err := page.WindowSize()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(width).To(Equal(640))
+				Expect(height).To(Equal(480))
+			})
+
+			Context("when the session fails to retrieve a window", func() {
+				It("should return an error", func() {
+					session.GetWindowCall.Err = errors.New("some error")
+					_, _, err := page.WindowSize()
+					Expect(err).To(MatchError("failed to retrieve window: some error"))
+				})
+			})
+
+			Context("when the window fails to retrieve its size", func() {
+				It("should return an error", func() {
+					bus.SendCall.Err = errors.New("some error")
+					_, _, err := page.WindowSize()
+					Expect(err).To(MatchError("failed to retrieve window size: some error"))
+				})
+			})
+		})
+
+		Describe("#SetWindowPosition", func() {
+			It("should move the window to the provided coordinates", func() {
+				Expect(page.SetWindowPosition(10, 20)).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("window/some-id/position"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"x": 10, "y": 20}`))
+			})
+
+			Context("when the session fails to retrieve a window", func() {
+				It("should return an error", func() {
+					session.GetWindowCall.Err = errors.New("some error")
+					Expect(page.SetWindowPosition(10, 20)).To(MatchError("failed to retrieve window: some error"))
+				})
+			})
+
+			Context("when the window fails to set its position", func() {
+				It("should return an error", func() {
+					bus.SendCall.Err = errors.New("some error")
+					Expect(page.SetWindowPosition(10, 20)).To(MatchError("failed to set window position: some error"))
+				})
+			})
+		})
+
+		Describe("#WindowPosition", func() {
+			It("should return the position of the window", func() {
+				bus.SendCall.Result = `{"x": 10, "y": 20}`
+				x, y, err := page.WindowPosition()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(x).To(Equal(10))
+				Expect(y).To(Equal(20))
+			})
+
+			Context("when the session fails to retrieve a window", func() {
+				It("should return an error", func() {
+					session.GetWindowCall.Err = errors.New("some error")
+					_, _, err := page.WindowPosition()
+					Expect(err).To(MatchError("failed to retrieve window: some error"))
+				})
+			})
+
+			Context("when the window fails to retrieve its position", func() {
+				It("should return an error", func() {
+					bus.SendCall.Err = errors.New("some error")
+					_, _, err := page.WindowPosition()
+					Expect(err).To(MatchError("failed to retrieve window position: some error"))
+				})
+			})
+		})
+
+		Describe("#MaximizeWindow", func() {
+			It("should maximize the window", func() {
+				Expect(page.MaximizeWindow()).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("window/some-id/maximize"))
+			})
+
+			Context("when the session fails to retrieve a window", func() {
+				It("should return an error", func() {
+					session.GetWindowCall.Err = errors.New("some error")
+					Expect(page.MaximizeWindow()).To(MatchError("failed to retrieve window: some error"))
+				})
+			})
+
+			Context("when the window fails to maximize", func() {
+				It("should return an error", func() {
+					bus.SendCall.Err = errors.New("some error")
+					Expect(page.MaximizeWindow()).To(MatchError("failed to maximize window: some error"))
+				})
+			})
+		})
+
+		Describe("#MinimizeWindow", func() {
+			It("should minimize the window", func() {
+				Expect(page.MinimizeWindow()).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("window/minimize"))
+			})
+
+			Context("when the window fails to minimize", func() {
+				It("should return an error", func() {
+					bus.SendCall.Err = errors.New("some error")
+					Expect(page.MinimizeWindow()).To(MatchError("failed to minimize window: some error"))
+				})
+			})
+		})
+
+		Describe("#FullscreenWindow", func() {
+			It("should make the window fill the screen", func() {
+				Expect(page.FullscreenWindow()).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("window/fullscreen"))
+			})
+
+			Context("when the window fails to become fullscreen", func() {
+				It("should return an error", func() {
+					bus.SendCall.Err = errors.New("some error")
+					Expect(page.FullscreenWindow()).To(MatchError("failed to fullscreen window: some error"))
+				})
+			})
+		})
+	})
+
+	Describe("#WaitForDownload", func() {
+		var directory string
+
+		BeforeEach(func() {
+			directory, _ = ioutil.TempDir("", "agouti-downloads")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(directory)
+		})
+
+		It("should return the path of a completed download matching the pattern", func() {
+			ioutil.WriteFile(filepath.Join(directory, "other.txt"), nil, 0644)
+			go func() {
+				defer GinkgoRecover()
+				time.Sleep(200 * time.Millisecond)
+				Expect(ioutil.WriteFile(filepath.Join(directory, "other-2.txt"), nil, 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(directory, "report-1.csv"), nil, 0644)).To(Succeed())
+			}()
+			Expect(page.WaitForDownload(filepath.Join(directory, "report-*.csv"), 5*time.Second)).To(Equal(filepath.Join(directory, "report-1.csv")))
+		})
+
+		It("should wait for the download to complete", func() {
+			partialFile := filepath.Join(directory, "report.csv.crdownload")
+			ioutil.WriteFile(partialFile, nil, 0644)
+			go func() {
+				defer GinkgoRecover()
+				time.Sleep(200 * time.Millisecond)
+				Expect(os.Rename(partialFile, filepath.Join(directory, "report.csv"))).To(Succeed())
+			}()
+			Expect(page.WaitForDownload(filepath.Join(directory, "*"), 5*time.Second)).To(Equal(filepath.Join(directory, "report.csv")))
+		})
+
+		It("should ignore files that already matched the pattern", func() {
+			ioutil.WriteFile(filepath.Join(directory, "report-1.csv"), nil, 0644)
+			go func() {
+				defer GinkgoRecover()
+				time.Sleep(200 * time.Millisecond)
+				Expect(ioutil.WriteFile(filepath.Join(directory, "report-2.csv"), nil, 0644)).To(Succeed())
+			}()
+			Expect(page.WaitForDownload(filepath.Join(directory, "report-*.csv"), 5*time.Second)).To(Equal(filepath.Join(directory, "report-2.csv")))
+		})
+
+		It("should return files that already matched the pattern once they are modified", func() {
+			filename := filepath.Join(directory, "report.csv")
+			ioutil.WriteFile(filename, nil, 0644)
+			lastHour := time.Now().Add(-time.Hour)
+			os.Chtimes(filename, lastHour, lastHour)
+			go func() {
+				defer GinkgoRecover()
+				time.Sleep(200 * time.Millisecond)
+				Expect(ioutil.WriteFile(filename, []byte("some data"), 0644)).To(Succeed())
+			}()
+			Expect(page.WaitForDownload(filepath.Join(directory, "*.csv"), 5*time.Second)).To(Equal(filename))
+		})
+
+		Context("when the page has a download directory", func() {
+			It("should match relative patterns in the download directory", func() {
+				page = NewTestPageWithDownloadDirectory(session, directory)
+				go func() {
+					defer GinkgoRecover()
+					time.Sleep(200 * time.Millisecond)
+					Expect(ioutil.WriteFile(filepath.Join(directory, "report.csv"), nil, 0644)).To(Succeed())
+				}()
+				Expect(page.WaitForDownload("*.csv", 5*time.Second)).To(Equal(filepath.Join(directory, "report.csv")))
+			})
+		})
+
+		Context("when only files that already matched the pattern exist at the timeout", func() {
+			It("should return an error", func() {
+				ioutil.WriteFile(filepath.Join(directory, "report.csv"), nil, 0644)
+				pattern := filepath.Join(directory, "*.csv")
+				_, err := page.WaitForDownload(pattern, 200*time.Millisecond)
+				Expect(err).To(MatchError("failed to find completed download matching " + pattern + " within 200ms"))
+			})
+		})
+
+		Context("when the pattern is invalid", func() {
+			It("should return an error", func() {
+				_, err := page.WaitForDownload("[", time.Second)
+				Expect(err).To(MatchError("invalid download pattern: syntax error in pattern"))
+			})
+		})
+	})
+
+	Describe("#WaitForDownloadAfter", func() {
+		var directory string
+
+		BeforeEach(func() {
+			directory, _ = ioutil.TempDir("", "agouti-downloads")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(directory)
+		})
+
+		It("should return the path of a download started by the action", func() {
+			ioutil.WriteFile(filepath.Join(directory, "report-1.csv"), nil, 0644)
+			filename, err := page.WaitForDownloadAfter(filepath.Join(directory, "report-*.csv"), time.Second, func() error {
+				return ioutil.WriteFile(filepath.Join(directory, "report-2.csv"), nil, 0644)
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(filename).To(Equal(filepath.Join(directory, "report-2.csv")))
+		})
+
+		Context("when the download is still in progress at the timeout", func() {
+			It("should return an error", func() {
+				pattern := filepath.Join(directory, "*.csv")
+				_, err := page.WaitForDownloadAfter(pattern, 200*time.Millisecond, func() error {
+					ioutil.WriteFile(filepath.Join(directory, "report.csv"), nil, 0644)
+					return ioutil.WriteFile(filepath.Join(directory, "report.csv.part"), nil, 0644)
+				})
+				Expect(err).To(MatchError("failed to find completed download matching " + pattern + " within 200ms"))
+			})
+		})
+
+		Context("when the action fails", func() {
+			It("should return an error", func() {
+				_, err := page.WaitForDownloadAfter(filepath.Join(directory, "*.csv"), time.Second, func() error {
+					return errors.New("some error")
+				})
+				Expect(err).To(MatchError("failed to start download: some error"))
+			})
+		})
+	})
+
 	Describe("#Screenshot", func() {
 		It("should successfully saves the screenshot", func() {
 			session.GetScreenshotCall.ReturnImage = []byte("some-image")
@@ -371,6 +757,32 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#FullScreenshot", func() {
+		It("should successfully save the full page screenshot", func() {
+			session.GetFullPageScreenshotCall.ReturnImage = []byte("some-image")
+			filename, _ := filepath.Abs(".test.full.screenshot.png")
+			Expect(page.FullScreenshot(".test.full.screenshot.png")).To(Succeed())
+			defer os.Remove(filename)
+			result, _ := ioutil.ReadFile(filename)
+			Expect(string(result)).To(Equal("some-image"))
+		})
+
+		Context("when a new screenshot file cannot be saved", func() {
+			It("should return an error", func() {
+				err := page.FullScreenshot("")
+				Expect(err.Error()).To(ContainSubstring("failed to save screenshot: open"))
+			})
+		})
+
+		Context("when the session fails to retrieve a screenshot", func() {
+			It("should return an error", func() {
+				session.GetFullPageScreenshotCall.Err = errors.New("some error")
+				err := page.FullScreenshot(".test.full.screenshot.png")
+				Expect(err).To(MatchError("failed to retrieve screenshot: some error"))
+			})
+		})
+	})
+
 	Describe("#Title", func() {
 		It("should successfully return the title of the current page", func() {
 			session.GetTitleCall.ReturnTitle = "Some Title"
@@ -428,6 +840,16 @@ var _ = Describe("Page", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("should provide selections of the elements returned by the script", func() {
+			var rows []*Selection
+			session.ExecuteCall.Result = `[{"ELEMENT": "some-id"}, {"element-6066-11e4-a52e-4f735466cecf": "some-other-id"}]`
+			Expect(page.RunScript("some javascript code", nil, &rows)).To(Succeed())
+			Expect(rows).To(HaveLen(2))
+			Expect(rows[0].String()).To(Equal("selection 'Element: some-id [single]'"))
+			Expect(rows[1].Find(".name").String()).To(Equal("selection 'Element: some-other-id [single] | CSS: .name [single]'"))
+			Expect(rows[1].Elements()).To(Equal([]*api.Element{{ID: "some-other-id"}}))
+		})
+
 		Context("when running the script fails", func() {
 			It("should return the session error", func() {
 				session.ExecuteCall.Err = errors.New("some error")
@@ -437,6 +859,49 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#RunScriptAsync", func() {
+		var (
+			result struct{ Some string }
+			err    error
+		)
+
+		BeforeEach(func() {
+			session.ExecuteAsyncCall.Result = `{"some": "result"}`
+			err = page.RunScriptAsync("some javascript code", map[string]interface{}{"argument": "value"}, &result)
+		})
+
+		It("should provide the session with an argument-provided javascript function that accepts a callback", func() {
+			Expect(session.ExecuteAsyncCall.Body).To(Equal("(function(argument, done) { some javascript code; }).apply(this, arguments);"))
+		})
+
+		It("should provide the session with arguments to call the provided function with", func() {
+			Expect(session.ExecuteAsyncCall.Arguments).To(Equal([]interface{}{"value"}))
+		})
+
+		It("should unmarshall the returned result into the provided result interface", func() {
+			Expect(result.Some).To(Equal("result"))
+		})
+
+		It("should be successful", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when no arguments are provided", func() {
+			It("should provide the session with a javascript function that only accepts a callback", func() {
+				Expect(page.RunScriptAsync("some javascript code", nil, &result)).To(Succeed())
+				Expect(session.ExecuteAsyncCall.Body).To(Equal("(function(done) { some javascript code; }).apply(this, arguments);"))
+			})
+		})
+
+		Context("when running the script fails", func() {
+			It("should return the session error", func() {
+				session.ExecuteAsyncCall.Err = errors.New("some error")
+				err = page.RunScriptAsync("", map[string]interface{}{}, &result)
+				Expect(err).To(MatchError("failed to run script: some error"))
+			})
+		})
+	})
+
 	Describe("#PopupText", func() {
 		It("should return the popup text of the popup and succeed", func() {
 			session.GetAlertTextCall.ReturnText = "some popup text"
@@ -536,6 +1001,56 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("element caching", func() {
+		BeforeEach(func() {
+			page = NewTestPageWithElementCache(session)
+			elementSession := &api.Session{Bus: &mocks.Bus{}}
+			session.GetElementsCall.ReturnElements = []*api.Element{{ID: "some-id", Session: elementSession}}
+			Expect(page.All("#selector").Count()).To(Equal(1))
+			session.GetElementsCall.ReturnElements = []*api.Element{{ID: "some-id"}, {ID: "some-other-id"}}
+		})
+
+		It("should reuse the elements of identical selections", func() {
+			Expect(page.All("#selector").Count()).To(Equal(1))
+		})
+
+		It("should retrieve the elements again after the page navigates", func() {
+			Expect(page.Refresh()).To(Succeed())
+			Expect(page.All("#selector").Count()).To(Equal(2))
+		})
+
+		It("should retrieve the elements again after a script is run", func() {
+			Expect(page.RunScript("document.body.innerHTML = '';", nil, nil)).To(Succeed())
+			Expect(page.All("#selector").Count()).To(Equal(2))
+		})
+
+		It("should retrieve the elements again after switching frames", func() {
+			Expect(page.SwitchToRootFrame()).To(Succeed())
+			Expect(page.All("#selector").Count()).To(Equal(2))
+		})
+
+		It("should retrieve the elements again after running a function within a frame", func() {
+			session.GetElementsCall.ReturnElements = []*api.Element{{ID: "frame"}}
+			Expect(page.WithinFrame(page.Find("iframe"), func(framePage *Page) error {
+				session.GetElementsCall.ReturnElements = []*api.Element{{ID: "some-id"}, {ID: "some-other-id"}}
+				Expect(framePage.All("#selector").Count()).To(Equal(2))
+				return nil
+			})).To(Succeed())
+			session.GetElementsCall.ReturnElements = []*api.Element{{ID: "some-id"}, {ID: "some-other-id"}, {ID: "another-id"}}
+			Expect(page.All("#selector").Count()).To(Equal(3))
+		})
+
+		It("should retrieve the elements again after a selection performs an action", func() {
+			Expect(page.All("#selector").Click()).To(Succeed())
+			Expect(page.All("#selector").Count()).To(Equal(2))
+		})
+
+		It("should retrieve the elements again after a selection is reloaded", func() {
+			Expect(page.All("#selector").Reload()).To(Succeed())
+			Expect(page.All("#selector").Count()).To(Equal(2))
+		})
+	})
+
 	Describe("#SwitchToParentFrame", func() {
 		It("should successfully instruct the session to change focus to the parent frame", func() {
 			Expect(page.SwitchToParentFrame()).To(Succeed())
@@ -565,6 +1080,87 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#WithinFrame", func() {
+		var frame *api.Element
+
+		BeforeEach(func() {
+			frame = &api.Element{ID: "frame"}
+			session.GetElementsCall.ReturnElements = []*api.Element{frame}
+		})
+
+		It("should call the function within the frame and then switch to the original frame", func() {
+			session.ExecuteCall.Result = `[1, 0]`
+			var calledPage *Page
+			Expect(page.WithinFrame(page.Find("iframe"), func(framePage *Page) error {
+				calledPage = framePage
+				Expect(session.FrameCall.Frame).To(ExactlyEqual(frame))
+				Expect(session.FrameIndexCall.Indexes).To(BeEmpty())
+				return nil
+			})).To(Succeed())
+			Expect(calledPage).To(ExactlyEqual(page))
+			Expect(session.ExecuteCall.Body).To(ContainSubstring("current.parent.frames"))
+			Expect(session.FrameCall.Frame).To(BeNil())
+			Expect(session.FrameIndexCall.Indexes).To(Equal([]int{1, 0}))
+		})
+
+		It("should switch to the root frame when the original frame is the root frame", func() {
+			session.ExecuteCall.Result = `[]`
+			Expect(page.WithinFrame(page.Find("iframe"), func(*Page) error { return nil })).To(Succeed())
+			Expect(session.FrameCall.Frame).To(BeNil())
+			Expect(session.FrameIndexCall.Indexes).To(BeEmpty())
+		})
+
+		Context("when the function fails", func() {
+			It("should switch to the original frame and return the error", func() {
+				session.ExecuteCall.Result = `[1]`
+				err := page.WithinFrame(page.Find("iframe"), func(*Page) error {
+					return errors.New("some error")
+				})
+				Expect(err).To(MatchError("some error"))
+				Expect(session.FrameIndexCall.Indexes).To(Equal([]int{1}))
+			})
+		})
+
+		Context("when the current frame cannot be determined", func() {
+			It("should return an error without switching frames", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				err := page.WithinFrame(page.Find("iframe"), func(*Page) error { return nil })
+				Expect(err).To(MatchError("failed to determine current frame: some error"))
+				Expect(session.FrameCall.Frame).To(BeNil())
+			})
+		})
+
+		Context("when the frame cannot be switched to", func() {
+			It("should not call the function", func() {
+				session.FrameCall.Err = errors.New("some error")
+				called := false
+				err := page.WithinFrame(page.Find("iframe"), func(*Page) error {
+					called = true
+					return nil
+				})
+				Expect(err).To(MatchError("failed to switch to frame referred to by selection 'CSS: iframe [single]': some error"))
+				Expect(called).To(BeFalse())
+				Expect(session.FrameIndexCall.Indexes).To(BeEmpty())
+			})
+		})
+
+		Context("when switching to the original frame fails", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Result = `[1]`
+				session.FrameIndexCall.Err = errors.New("some error")
+				err := page.WithinFrame(page.Find("iframe"), func(*Page) error { return nil })
+				Expect(err).To(MatchError("failed to switch to original frame: some error"))
+			})
+		})
+
+		Context("when the frame is not a selection", func() {
+			It("should return an error", func() {
+				err := page.WithinFrame("iframe", func(*Page) error { return nil })
+				Expect(err).To(MatchError("failed to switch to frame: must be *Selection or *MultiSelection"))
+			})
+		})
+	})
+
 	Describe("#SwitchToWindow", func() {
 		It("should successfully instruct the session to switch to the named window", func() {
 			Expect(page.SwitchToWindow("some name")).To(Succeed())
@@ -579,6 +1175,47 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#NewTab", func() {
+		It("should open a new tab and switch to it", func() {
+			window := &api.Window{ID: "some-id"}
+			session.NewWindowCall.ReturnWindow = window
+			Expect(page.NewTab()).To(Succeed())
+			Expect(session.NewWindowCall.Kind).To(Equal("tab"))
+			Expect(session.SetWindowCall.Window).To(ExactlyEqual(window))
+		})
+
+		Context("when opening the tab fails", func() {
+			It("should return an error", func() {
+				session.NewWindowCall.Err = errors.New("some error")
+				Expect(page.NewTab()).To(MatchError("failed to open new tab: some error"))
+			})
+		})
+
+		Context("when switching to the tab fails", func() {
+			It("should return an error", func() {
+				session.SetWindowCall.Err = errors.New("some error")
+				Expect(page.NewTab()).To(MatchError("failed to switch to new tab: some error"))
+			})
+		})
+	})
+
+	Describe("#NewWindow", func() {
+		It("should open a new window and switch to it", func() {
+			window := &api.Window{ID: "some-id"}
+			session.NewWindowCall.ReturnWindow = window
+			Expect(page.NewWindow()).To(Succeed())
+			Expect(session.NewWindowCall.Kind).To(Equal("window"))
+			Expect(session.SetWindowCall.Window).To(ExactlyEqual(window))
+		})
+
+		Context("when opening the window fails", func() {
+			It("should return an error", func() {
+				session.NewWindowCall.Err = errors.New("some error")
+				Expect(page.NewWindow()).To(MatchError("failed to open new window: some error"))
+			})
+		})
+	})
+
 	Describe("#NextWindow", func() {
 		BeforeEach(func() {
 			firstWindow := &api.Window{ID: "first window"}
@@ -683,6 +1320,35 @@ var _ = Describe("Page", func() {
 				Expect(logs[1].Time.Unix()).To(BeEquivalentTo(1418196098))
 			})
 		})
+
+		Context("when the logs are prefixed with their source and location", func() {
+			It("should return logs with the parsed source, line, and column", func() {
+				session.NewLogsCall.ReturnLogs = []api.Log{
+					{Message: "http://example.com/app.js 12:34 Uncaught Error: some error", Level: "SEVERE"},
+					{Message: `console-api 5:10 "some message"`, Level: "INFO"},
+					{Message: "http://example.com/favicon.ico - Failed to load resource", Level: "SEVERE"},
+				}
+
+				logs, err := page.ReadNewLogs("browser")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logs).To(HaveLen(3))
+				Expect(logs[0].Message).To(Equal("http://example.com/app.js 12:34 Uncaught Error: some error"))
+				Expect(logs[0].Text).To(Equal("Uncaught Error: some error"))
+				Expect(logs[0].Source).To(Equal("http://example.com/app.js"))
+				Expect(logs[0].Location).To(Equal("12:34"))
+				Expect(logs[0].Line).To(Equal(12))
+				Expect(logs[0].Column).To(Equal(34))
+				Expect(logs[1].Message).To(Equal(`console-api 5:10 "some message"`))
+				Expect(logs[1].Text).To(Equal(`"some message"`))
+				Expect(logs[1].Source).To(Equal("console-api"))
+				Expect(logs[1].Line).To(Equal(5))
+				Expect(logs[2].Message).To(Equal("http://example.com/favicon.ico - Failed to load resource"))
+				Expect(logs[2].Text).To(Equal("Failed to load resource"))
+				Expect(logs[2].Source).To(Equal("http://example.com/favicon.ico"))
+				Expect(logs[2].Location).To(BeEmpty())
+				Expect(logs[2].Line).To(BeZero())
+			})
+		})
 	})
 
 	Describe("#ReadAllLogs", func() {
@@ -723,6 +1389,149 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#ClearLogs", func() {
+		It("should discard unread browser logs and previously read logs", func() {
+			session.NewLogsCall.ReturnLogs = []api.Log{{Message: "old log"}}
+			page.ReadNewLogs("some type")
+			Expect(page.ClearLogs()).To(Succeed())
+			Expect(session.NewLogsCall.LogType).To(Equal("browser"))
+
+			session.NewLogsCall.ReturnLogs = []api.Log{{Message: "new log"}}
+			logs, err := page.ReadAllLogs("some type")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logs).To(HaveLen(1))
+			Expect(logs[0].Message).To(Equal("new log"))
+		})
+
+		Context("when the session fails to retrieve logs", func() {
+			It("should return an error", func() {
+				session.NewLogsCall.Err = errors.New("some error")
+				Expect(page.ClearLogs()).To(MatchError("failed to clear logs: some error"))
+			})
+		})
+	})
+
+	Describe("#RunInWindow", func() {
+		var original *api.Window
+
+		BeforeEach(func() {
+			original = &api.Window{ID: "original"}
+			session.GetWindowCall.ReturnWindow = original
+		})
+
+		It("should call the function within the window and then switch to the original window", func() {
+			Expect(page.RunInWindow("popup", func(windowPage *Page) error {
+				Expect(windowPage).To(ExactlyEqual(page))
+				Expect(session.SetWindowByNameCall.Name).To(Equal("popup"))
+				Expect(session.SetWindowCall.Window).To(BeNil())
+				return nil
+			})).To(Succeed())
+			Expect(session.SetWindowCall.Window).To(ExactlyEqual(original))
+		})
+
+		Context("when the function fails", func() {
+			It("should switch to the original window and return the error", func() {
+				err := page.RunInWindow("popup", func(*Page) error { return errors.New("some error") })
+				Expect(err).To(MatchError("some error"))
+				Expect(session.SetWindowCall.Window).To(ExactlyEqual(original))
+			})
+		})
+
+		Context("when the active window cannot be found", func() {
+			It("should return an error", func() {
+				session.GetWindowCall.Err = errors.New("some error")
+				err := page.RunInWindow("popup", func(*Page) error { return nil })
+				Expect(err).To(MatchError("failed to find active window: some error"))
+			})
+		})
+
+		Context("when the window can only be found by name", func() {
+			var popup *api.Window
+
+			BeforeEach(func() {
+				popup = &api.Window{ID: "some-handle"}
+				session.SetWindowByNameCall.Err = errors.New("some error")
+				session.GetWindowsCall.ReturnWindows = []*api.Window{popup}
+			})
+
+			It("should switch to the window with the matching name", func() {
+				session.ExecuteCall.Result = `"popup"`
+				Expect(page.RunInWindow("popup", func(*Page) error {
+					Expect(session.ExecuteCall.Body).To(Equal("return window.name;"))
+					Expect(session.SetWindowCall.Window).To(ExactlyEqual(popup))
+					return nil
+				})).To(Succeed())
+				Expect(session.SetWindowCall.Window).To(ExactlyEqual(original))
+			})
+
+			It("should switch to the original window and return an error when no window matches", func() {
+				session.ExecuteCall.Result = `"other"`
+				err := page.RunInWindow("popup", func(*Page) error {
+					Fail("function should not be called")
+					return nil
+				})
+				Expect(err).To(MatchError("failed to switch to window: some error"))
+				Expect(session.SetWindowCall.Window).To(ExactlyEqual(original))
+			})
+		})
+
+		Context("when the window cannot be switched to", func() {
+			It("should return an error without calling the function", func() {
+				session.SetWindowByNameCall.Err = errors.New("some error")
+				err := page.RunInWindow("popup", func(*Page) error {
+					Fail("function should not be called")
+					return nil
+				})
+				Expect(err).To(MatchError("failed to switch to window: some error"))
+			})
+		})
+
+		Context("when the original window cannot be switched to", func() {
+			It("should return an error", func() {
+				session.SetWindowCall.Err = errors.New("some error")
+				err := page.RunInWindow("popup", func(*Page) error { return nil })
+				Expect(err).To(MatchError("failed to switch to original window: some error"))
+			})
+		})
+	})
+
+	Describe("#WaitForNewWindow", func() {
+		BeforeEach(func() {
+			session.GetWindowsCall.ReturnWindows = []*api.Window{{ID: "original"}}
+		})
+
+		It("should return the handle of the window opened by the function", func() {
+			handle, err := page.WaitForNewWindow(time.Second, func() error {
+				session.GetWindowsCall.ReturnWindows = []*api.Window{{ID: "original"}, {ID: "popup"}}
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(handle).To(Equal("popup"))
+		})
+
+		Context("when no new window opens before the timeout", func() {
+			It("should return an error", func() {
+				_, err := page.WaitForNewWindow(time.Millisecond, func() error { return nil })
+				Expect(err).To(MatchError("failed to find new window within 1ms"))
+			})
+		})
+
+		Context("when the function fails", func() {
+			It("should return the error", func() {
+				_, err := page.WaitForNewWindow(time.Second, func() error { return errors.New("some error") })
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the windows cannot be retrieved", func() {
+			It("should return an error", func() {
+				session.GetWindowsCall.Err = errors.New("some error")
+				_, err := page.WaitForNewWindow(time.Second, func() error { return nil })
+				Expect(err).To(MatchError("failed to find available windows: some error"))
+			})
+		})
+	})
+
 	Describe("#LogTypes", func() {
 		It("should successfully return the log types", func() {
 			session.GetLogTypesCall.ReturnTypes = []string{"first type", "second type"}
@@ -796,4 +1605,135 @@ var _ = Describe("Page", func() {
 			})
 		})
 	})
+
+	Describe("#Orientation", func() {
+		It("should successfully return the orientation", func() {
+			session.GetOrientationCall.ReturnOrientation = "LANDSCAPE"
+			Expect(page.Orientation()).To(Equal("LANDSCAPE"))
+		})
+
+		Context("when the session fails to retrieve the orientation", func() {
+			It("should return an error", func() {
+				session.GetOrientationCall.Err = errors.New("some error")
+				_, err := page.Orientation()
+				Expect(err).To(MatchError("failed to retrieve orientation: some error"))
+			})
+		})
+	})
+
+	Describe("#SetOrientation", func() {
+		It("should successfully set the orientation", func() {
+			Expect(page.SetOrientation("PORTRAIT")).To(Succeed())
+			Expect(session.SetOrientationCall.Orientation).To(Equal("PORTRAIT"))
+		})
+
+		Context("when the session fails to set the orientation", func() {
+			It("should return an error", func() {
+				session.SetOrientationCall.Err = errors.New("some error")
+				Expect(page.SetOrientation("PORTRAIT")).To(MatchError("failed to set orientation: some error"))
+			})
+		})
+	})
+
+	Describe("#GetGeolocation", func() {
+		It("should successfully return the geolocation", func() {
+			location := api.Geolocation{Latitude: 1.5, Longitude: -2.5, Altitude: 10}
+			session.GetGeolocationCall.ReturnGeolocation = location
+			Expect(page.GetGeolocation()).To(Equal(location))
+		})
+
+		Context("when the session fails to retrieve the geolocation", func() {
+			It("should return an error", func() {
+				session.GetGeolocationCall.Err = errors.New("some error")
+				_, err := page.GetGeolocation()
+				Expect(err).To(MatchError("failed to get geolocation: some error"))
+			})
+		})
+	})
+
+	Describe("#SetGeolocation", func() {
+		It("should successfully set the geolocation", func() {
+			Expect(page.SetGeolocation(1.5, -2.5, 10)).To(Succeed())
+			Expect(session.SetGeolocationCall.Latitude).To(Equal(1.5))
+			Expect(session.SetGeolocationCall.Longitude).To(Equal(-2.5))
+			Expect(session.SetGeolocationCall.Altitude).To(Equal(10.0))
+		})
+
+		Context("when the session fails to set the geolocation", func() {
+			It("should return an error", func() {
+				session.SetGeolocationCall.Err = errors.New("some error")
+				Expect(page.SetGeolocation(1.5, -2.5, 10)).To(MatchError("failed to set geolocation: some error"))
+			})
+		})
+	})
+
+	Describe("#SetTimeouts", func() {
+		It("should successfully set the session timeouts", func() {
+			timeouts := api.Timeouts{Implicit: time.Second, PageLoad: time.Minute, Script: 30 * time.Second}
+			Expect(page.SetTimeouts(timeouts)).To(Succeed())
+			Expect(session.SetTimeoutsCall.Timeouts).To(Equal(timeouts))
+		})
+
+		Context("when the session fails to set the timeouts", func() {
+			It("should return an error", func() {
+				session.SetTimeoutsCall.Err = errors.New("some error")
+				Expect(page.SetTimeouts(api.Timeouts{})).To(MatchError("failed to set timeouts: some error"))
+			})
+		})
+	})
+
+	Describe("#GetTimeouts", func() {
+		It("should successfully return the session timeouts", func() {
+			timeouts := api.Timeouts{Implicit: time.Second, PageLoad: time.Minute, Script: 30 * time.Second}
+			session.GetTimeoutsCall.ReturnTimeouts = timeouts
+			Expect(page.GetTimeouts()).To(Equal(timeouts))
+		})
+
+		Context("when the session fails to retrieve the timeouts", func() {
+			It("should return an error", func() {
+				session.GetTimeoutsCall.Err = errors.New("some error")
+				_, err := page.GetTimeouts()
+				Expect(err).To(MatchError("failed to get timeouts: some error"))
+			})
+		})
+	})
+})
+
+var _ = Describe(".NewPage", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			switch request.URL.Path {
+			case "/session":
+				response.Write([]byte(`{"value": {"sessionId": "some-id", "capabilities": {}}}`))
+			default:
+				response.Write([]byte(`{"value": "some-url"}`))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("with the DebugLog Option", func() {
+		It("should log every command sent by the page", func() {
+			log := &bytes.Buffer{}
+			page, err := NewPage(server.URL, DebugLog(log))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Navigate("http://example.com")).To(Succeed())
+			Expect(log.String()).To(ContainSubstring("[agouti] POST url ("))
+			Expect(log.String()).To(ContainSubstring(`"url": "http://example.com"`))
+		})
+	})
+
+	Context("with the TestIDAttribute Option", func() {
+		It("should select elements by the provided test ID attribute", func() {
+			page, err := NewPage(server.URL, TestIDAttribute("data-qa"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.FindByTestID("checkout").String()).To(Equal(`selection 'CSS: [data-qa="checkout"] [single]'`))
+			Expect(page.All("form").FindByTestID("checkout").String()).To(Equal(`selection 'CSS: form [data-qa="checkout"] [single]'`))
+		})
+	})
 })
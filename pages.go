@@ -0,0 +1,112 @@
+package agouti
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Pages is a group of independent pages, ex. one page for each user of a
+// chat or other realtime collaboration feature. Each page is a separate
+// browser session, so pages do not share cookies or storage.
+type Pages []*Page
+
+// NewPages opens the provided number of independent pages concurrently, as
+// described by NewPage. If any page cannot be opened, the other pages are
+// destroyed and an error is returned. WebDrivers that only support one
+// session at a time, such as Safari, cannot open more than one page.
+//
+// Example:
+//    pages, err := driver.NewPages(2)
+//    defer pages.Destroy()
+//    alice, bob := pages[0], pages[1]
+func (w *WebDriver) NewPages(count int, options ...Option) (Pages, error) {
+	if w.sessionSlot != nil && count > 1 {
+		return nil, errors.New("failed to open pages: WebDriver only supports one session at a time")
+	}
+
+	pages := make(Pages, count)
+	errs := make([]error, count)
+	var wait sync.WaitGroup
+	for i := range pages {
+		wait.Add(1)
+		go func(i int) {
+			defer wait.Done()
+			pages[i], errs[i] = w.NewPage(options...)
+		}(i)
+	}
+	wait.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			for _, page := range pages {
+				if page != nil {
+					page.Destroy()
+				}
+			}
+			return nil, fmt.Errorf("failed to open page %d: %s", i, err)
+		}
+	}
+	return pages, nil
+}
+
+// Do calls the provided function with each page and its index concurrently,
+// and waits until every call has returned. The first error is returned, with
+// the index of its page.
+//
+// Example:
+//    err := pages.Do(func(i int, page *agouti.Page) error {
+//        return page.Navigate(server.URL + "/chat")
+//    })
+func (p Pages) Do(action func(index int, page *Page) error) error {
+	errs := make([]error, len(p))
+	var wait sync.WaitGroup
+	for i, page := range p {
+		wait.Add(1)
+		go func(i int, page *Page) {
+			defer wait.Done()
+			errs[i] = action(i, page)
+		}(i, page)
+	}
+	wait.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("page %d: %s", i, err)
+		}
+	}
+	return nil
+}
+
+// Sync is a barrier that blocks until every page satisfies all of the
+// provided conditions, as described by Wait.Until. Conditions are checked
+// against each page concurrently, every 100 milliseconds, until the provided
+// timeout elapses. An error is returned for the first page that does not
+// satisfy the conditions.
+//
+// Example:
+//    alice.Find("#message").Fill("Hello!")
+//    alice.Find("#send").Click()
+//    err := pages.Sync(5*time.Second, agouti.ScriptIsTrue(`return document.querySelectorAll(".message").length === 1;`, nil))
+func (p Pages) Sync(timeout time.Duration, conditions ...Condition) error {
+	err := p.Do(func(_ int, page *Page) error {
+		return page.Wait(timeout, defaultWaitInterval).Until(conditions...)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sync pages: %s", err)
+	}
+	return nil
+}
+
+// Destroy destroys every page, even if a page cannot be destroyed. The first
+// error is returned.
+func (p Pages) Destroy() error {
+	var firstErr error
+	for i, page := range p {
+		if err := page.Destroy(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to destroy page %d: %s", i, err)
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,96 @@
+package agouti_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Pages", func() {
+	var (
+		sessions []*mocks.Session
+		pages    Pages
+	)
+
+	BeforeEach(func() {
+		sessions = []*mocks.Session{{}, {}}
+		pages = Pages{NewTestPage(sessions[0]), NewTestPage(sessions[1])}
+	})
+
+	Describe("#Do", func() {
+		It("should call the provided function with each page concurrently", func() {
+			var started sync.WaitGroup
+			started.Add(len(pages))
+			var mutex sync.Mutex
+			indices := map[*Page]int{}
+			Expect(pages.Do(func(index int, page *Page) error {
+				started.Done()
+				started.Wait()
+				mutex.Lock()
+				defer mutex.Unlock()
+				indices[page] = index
+				return nil
+			})).To(Succeed())
+			Expect(indices).To(Equal(map[*Page]int{pages[0]: 0, pages[1]: 1}))
+		})
+
+		Context("when the function fails for any page", func() {
+			It("should return the first error with the index of its page", func() {
+				err := pages.Do(func(index int, page *Page) error {
+					if index == 1 {
+						return errors.New("some error")
+					}
+					return nil
+				})
+				Expect(err).To(MatchError("page 1: some error"))
+			})
+		})
+	})
+
+	Describe("#Sync", func() {
+		It("should wait until every page satisfies the provided conditions", func() {
+			var mutex sync.Mutex
+			checks := map[*Page]int{}
+			condition := func(page *Page) (bool, error) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				checks[page]++
+				return page != pages[1] || checks[page] > 1, nil
+			}
+			Expect(pages.Sync(time.Second, condition)).To(Succeed())
+			Expect(checks[pages[0]]).To(Equal(1))
+			Expect(checks[pages[1]]).To(Equal(2))
+		})
+
+		Context("when any page does not satisfy the conditions before the timeout", func() {
+			It("should return an error", func() {
+				condition := func(page *Page) (bool, error) {
+					return page == pages[0], nil
+				}
+				err := pages.Sync(0, condition)
+				Expect(err).To(MatchError("failed to sync pages: page 1: failed to satisfy condition within 0s"))
+			})
+		})
+	})
+
+	Describe("#Destroy", func() {
+		It("should destroy every page", func() {
+			Expect(pages.Destroy()).To(Succeed())
+			Expect(sessions[0].DeleteCall.Called).To(BeTrue())
+			Expect(sessions[1].DeleteCall.Called).To(BeTrue())
+		})
+
+		Context("when any page cannot be destroyed", func() {
+			It("should destroy the other pages and return the first error", func() {
+				sessions[0].DeleteCall.Err = errors.New("some error")
+				Expect(pages.Destroy()).To(MatchError("failed to destroy page 0: failed to destroy session: some error"))
+				Expect(sessions[1].DeleteCall.Called).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,310 @@
+package agouti
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/sclevine/agouti/api"
+)
+
+var (
+	traceInterval    = 100 * time.Millisecond
+	traceStopTimeout = 10 * time.Second
+)
+
+// DefaultTraceCategories are the trace categories recorded by StartTracing
+// when no categories are provided. They match the categories recorded by the
+// Performance panel of Chrome DevTools.
+var DefaultTraceCategories = []string{
+	"-*",
+	"devtools.timeline",
+	"disabled-by-default-devtools.timeline",
+	"disabled-by-default-devtools.timeline.frame",
+	"toplevel",
+	"blink.console",
+	"blink.user_timing",
+	"latencyInfo",
+	"loading",
+	"v8.execute",
+}
+
+// PerformanceMetrics contains performance measurements of the current
+// document of a page. Durations are relative to the start of the navigation
+// that loaded the document, and are zero if the browser has not measured them.
+type PerformanceMetrics struct {
+	// TimeToFirstByte is the time until the first byte of the response was received
+	TimeToFirstByte time.Duration
+
+	// DOMInteractive is the time until the document was parsed
+	DOMInteractive time.Duration
+
+	// DOMContentLoaded is the time until DOMContentLoaded handlers completed
+	DOMContentLoaded time.Duration
+
+	// Load is the time until load handlers completed
+	Load time.Duration
+
+	// FirstPaint is the time until anything was rendered
+	FirstPaint time.Duration
+
+	// FirstContentfulPaint is the time until any text or image was rendered
+	FirstContentfulPaint time.Duration
+
+	// LargestContentfulPaint is the time until the largest text or image
+	// rendered so far was rendered
+	LargestContentfulPaint time.Duration
+
+	// TransferSize is the size of the document response in bytes, including
+	// its headers
+	TransferSize int
+
+	// Metrics contains the metrics of the DevTools Performance domain, ex.
+	// "JSHeapUsedSize" or "LayoutCount", or nil if the browser is not Chrome.
+	// See: https://chromedevtools.github.io/devtools-protocol/tot/Performance/
+	Metrics map[string]float64
+}
+
+// Largest contentful paint entries are only available to observers, and
+// buffered entries are provided to the observer synchronously.
+const performanceMetricsScript = `
+	var navigation = performance.getEntriesByType("navigation")[0] || {};
+	var paints = {};
+	performance.getEntriesByType("paint").forEach(function(entry) {
+		paints[entry.name] = entry.startTime;
+	});
+	var metrics = {
+		responseStart: navigation.responseStart || 0,
+		domInteractive: navigation.domInteractive || 0,
+		domContentLoaded: navigation.domContentLoadedEventEnd || 0,
+		load: navigation.loadEventEnd || 0,
+		transferSize: navigation.transferSize || 0,
+		firstPaint: paints["first-paint"] || 0,
+		firstContentfulPaint: paints["first-contentful-paint"] || 0,
+		largestContentfulPaint: 0
+	};
+	var types = (window.PerformanceObserver && PerformanceObserver.supportedEntryTypes) || [];
+	if (types.indexOf("largest-contentful-paint") !== -1) {
+		var observer = new PerformanceObserver(function() {});
+		observer.observe({type: "largest-contentful-paint", buffered: true});
+		var entries = observer.takeRecords();
+		observer.disconnect();
+		if (entries.length > 0) {
+			metrics.largestContentfulPaint = entries[entries.length - 1].startTime;
+		}
+	}
+	return metrics;
+`
+
+// PerformanceMetrics returns the Navigation Timing and paint timing
+// measurements of the current document, along with the metrics of the
+// DevTools Performance domain when the browser is Chrome.
+//
+// Example:
+//    metrics, err := page.PerformanceMetrics()
+//    Expect(metrics.LargestContentfulPaint).To(BeNumerically("<", 2500*time.Millisecond))
+func (p *Page) PerformanceMetrics() (*PerformanceMetrics, error) {
+	var timing struct {
+		ResponseStart          float64 `json:"responseStart"`
+		DOMInteractive         float64 `json:"domInteractive"`
+		DOMContentLoaded       float64 `json:"domContentLoaded"`
+		Load                   float64 `json:"load"`
+		TransferSize           int     `json:"transferSize"`
+		FirstPaint             float64 `json:"firstPaint"`
+		FirstContentfulPaint   float64 `json:"firstContentfulPaint"`
+		LargestContentfulPaint float64 `json:"largestContentfulPaint"`
+	}
+	if err := p.session.Execute(performanceMetricsScript, nil, &timing); err != nil {
+		return nil, fmt.Errorf("failed to retrieve performance metrics: %s", err)
+	}
+
+	metrics := &PerformanceMetrics{
+		TimeToFirstByte:        msToDuration(timing.ResponseStart),
+		DOMInteractive:         msToDuration(timing.DOMInteractive),
+		DOMContentLoaded:       msToDuration(timing.DOMContentLoaded),
+		Load:                   msToDuration(timing.Load),
+		FirstPaint:             msToDuration(timing.FirstPaint),
+		FirstContentfulPaint:   msToDuration(timing.FirstContentfulPaint),
+		LargestContentfulPaint: msToDuration(timing.LargestContentfulPaint),
+		TransferSize:           timing.TransferSize,
+	}
+
+	cdpMetrics, err := p.cdpPerformanceMetrics()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve performance metrics: %s", err)
+	}
+	metrics.Metrics = cdpMetrics
+	return metrics, nil
+}
+
+// Browsers other than Chrome do not support the DevTools Protocol endpoint,
+// so their DevTools metrics are skipped.
+func (p *Page) cdpPerformanceMetrics() (map[string]float64, error) {
+	err := p.session.ExecuteCDP("Performance.enable", nil, nil)
+	if api.ErrorCode(err) == api.ErrorUnknownCommand {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Metrics []struct {
+			Name  string  `json:"name"`
+			Value float64 `json:"value"`
+		} `json:"metrics"`
+	}
+	if err := p.session.ExecuteCDP("Performance.getMetrics", nil, &result); err != nil {
+		return nil, err
+	}
+
+	metrics := map[string]float64{}
+	for _, metric := range result.Metrics {
+		metrics[metric.Name] = metric.Value
+	}
+	return metrics, nil
+}
+
+func msToDuration(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// StartTracing starts recording a Chrome trace of the page with the provided
+// trace categories, or DefaultTraceCategories if none are provided. Trace
+// events are read from the Chrome performance log, so the page must have been
+// opened in Chrome with the PerformanceLogging Option. Any HAR recording
+// started by StartHAR continues to record while tracing.
+func (p *Page) StartTracing(categories ...string) error {
+	if p.tracing != nil {
+		return errors.New("failed to start tracing: tracing was already started")
+	}
+	if len(categories) == 0 {
+		categories = DefaultTraceCategories
+	}
+
+	logs, err := p.performanceLog.subscribe(p.session)
+	if err != nil {
+		return fmt.Errorf("failed to start tracing: %s", err)
+	}
+
+	request := struct {
+		TraceConfig struct {
+			IncludedCategories []string `json:"includedCategories"`
+		} `json:"traceConfig"`
+		TransferMode string `json:"transferMode"`
+	}{TransferMode: "ReportEvents"}
+	request.TraceConfig.IncludedCategories = categories
+	if err := p.session.ExecuteCDP("Tracing.start", request, nil); err != nil {
+		logs.close()
+		return fmt.Errorf("failed to start tracing: %s", err)
+	}
+	p.tracing = logs
+	return nil
+}
+
+// StopTracing stops the recording started by StartTracing and saves it as a
+// Chrome trace file, which may be opened in the Performance panel of Chrome
+// DevTools or at https://ui.perfetto.dev.
+//
+// Example:
+//    page.StartTracing()
+//    ... interact with the page ...
+//    err := page.StopTracing("checkout.json")
+func (p *Page) StopTracing(filename string) error {
+	if p.tracing == nil {
+		return errors.New("failed to stop tracing: tracing was not started")
+	}
+	logs := p.tracing
+	p.tracing = nil
+	defer logs.close()
+
+	if err := p.session.ExecuteCDP("Tracing.end", nil, nil); err != nil {
+		return fmt.Errorf("failed to stop tracing: %s", err)
+	}
+
+	events, err := collectTraceEvents(logs)
+	if err != nil {
+		return fmt.Errorf("failed to stop tracing: %s", err)
+	}
+
+	trace, err := json.Marshal(struct {
+		TraceEvents []json.RawMessage `json:"traceEvents"`
+	}{events})
+	if err != nil {
+		return fmt.Errorf("failed to encode trace: %s", err)
+	}
+	if err := ioutil.WriteFile(filename, trace, 0666); err != nil {
+		return fmt.Errorf("failed to save trace: %s", err)
+	}
+	return nil
+}
+
+// Trace events are reported in batches after tracing ends, so they are
+// collected until tracing is complete or no further events are reported.
+func collectTraceEvents(logs *performanceSubscription) ([]json.RawMessage, error) {
+	events := []json.RawMessage{}
+	deadline := time.Now().Add(traceStopTimeout)
+	for {
+		complete := false
+		received := 0
+		err := readPerformanceEvents(logs, func(method string, params json.RawMessage) {
+			switch method {
+			case "Tracing.dataCollected":
+				batch := traceEvents(params)
+				events = append(events, batch...)
+				received += len(batch)
+			case "Tracing.tracingComplete":
+				complete = true
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if complete || (received == 0 && len(events) > 0) {
+			return events, nil
+		}
+		if time.Now().After(deadline) {
+			if len(events) == 0 {
+				return nil, errors.New("no trace events were received")
+			}
+			return events, nil
+		}
+		time.Sleep(traceInterval)
+	}
+}
+
+// Trace events may be reported in batches, as by the DevTools Protocol, or
+// individually, as by ChromeDriver.
+func traceEvents(params json.RawMessage) []json.RawMessage {
+	var batch struct {
+		Value []json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(params, &batch); err == nil && batch.Value != nil {
+		return batch.Value
+	}
+	return []json.RawMessage{params}
+}
+
+func readPerformanceEvents(logs *performanceSubscription, handle func(method string, params json.RawMessage)) error {
+	entries, err := logs.read()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		var event struct {
+			Message struct {
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			} `json:"message"`
+		}
+		if err := json.Unmarshal([]byte(entry.Message), &event); err != nil {
+			continue
+		}
+		handle(event.Message.Method, event.Message.Params)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package agouti
+
+import (
+	"sync"
+
+	"github.com/sclevine/agouti/api"
+)
+
+// A performanceLog reads the Chrome performance log on behalf of every
+// feature of a page that consumes it, such as HAR recording, tracing, and
+// screencasts. Reading the log removes its entries from the browser, so each
+// entry that is read is queued for every subscription instead of only being
+// seen by the feature that read it.
+type performanceLog struct {
+	mutex         sync.Mutex
+	subscriptions map[*performanceSubscription]bool
+}
+
+type performanceSubscription struct {
+	log     *performanceLog
+	session apiSession
+	queued  []api.Log
+}
+
+// subscribe returns a subscription to entries logged after it is called.
+// Earlier entries are queued for the existing subscriptions only.
+func (l *performanceLog) subscribe(session apiSession) (*performanceSubscription, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.poll(session); err != nil {
+		return nil, err
+	}
+
+	subscription := &performanceSubscription{log: l, session: session}
+	if l.subscriptions == nil {
+		l.subscriptions = map[*performanceSubscription]bool{}
+	}
+	l.subscriptions[subscription] = true
+	return subscription, nil
+}
+
+func (l *performanceLog) poll(session apiSession) error {
+	logs, err := session.NewLogs("performance")
+	if err != nil {
+		return err
+	}
+	for subscription := range l.subscriptions {
+		subscription.queued = append(subscription.queued, logs...)
+	}
+	return nil
+}
+
+// read returns the entries logged since the subscription was created or
+// last read.
+func (s *performanceSubscription) read() ([]api.Log, error) {
+	s.log.mutex.Lock()
+	defer s.log.mutex.Unlock()
+
+	if err := s.log.poll(s.session); err != nil {
+		return nil, err
+	}
+	logs := s.queued
+	s.queued = nil
+	return logs, nil
+}
+
+func (s *performanceSubscription) close() {
+	s.log.mutex.Lock()
+	defer s.log.mutex.Unlock()
+	delete(s.log.subscriptions, s)
+}
@@ -0,0 +1,193 @@
+package agouti_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Performance", func() {
+	var (
+		page    *Page
+		session *mocks.Session
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		page = NewTestPage(session)
+	})
+
+	Describe("#PerformanceMetrics", func() {
+		BeforeEach(func() {
+			session.ExecuteCall.Result = `{
+				"responseStart": 12.5, "domInteractive": 100, "domContentLoaded": 150, "load": 300,
+				"transferSize": 2048, "firstPaint": 120, "firstContentfulPaint": 130, "largestContentfulPaint": 250
+			}`
+			session.ExecuteCDPCall.Result = `{"metrics": [{"name": "JSHeapUsedSize", "value": 1024}, {"name": "LayoutCount", "value": 3}]}`
+		})
+
+		It("should return the timing and DevTools metrics of the page", func() {
+			metrics, err := page.PerformanceMetrics()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*metrics).To(Equal(PerformanceMetrics{
+				TimeToFirstByte:        12500 * time.Microsecond,
+				DOMInteractive:         100 * time.Millisecond,
+				DOMContentLoaded:       150 * time.Millisecond,
+				Load:                   300 * time.Millisecond,
+				FirstPaint:             120 * time.Millisecond,
+				FirstContentfulPaint:   130 * time.Millisecond,
+				LargestContentfulPaint: 250 * time.Millisecond,
+				TransferSize:           2048,
+				Metrics:                map[string]float64{"JSHeapUsedSize": 1024, "LayoutCount": 3},
+			}))
+			Expect(session.ExecuteCDPCall.Command).To(Equal("Performance.getMetrics"))
+		})
+
+		Context("when the browser does not support the DevTools Protocol", func() {
+			It("should return the timing metrics only", func() {
+				session.ExecuteCDPCall.Err = &api.Error{Code: api.ErrorUnknownCommand}
+				metrics, err := page.PerformanceMetrics()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metrics.Load).To(Equal(300 * time.Millisecond))
+				Expect(metrics.Metrics).To(BeNil())
+			})
+		})
+
+		Context("when the DevTools metrics cannot be retrieved", func() {
+			It("should return an error", func() {
+				session.ExecuteCDPCall.Err = errors.New("some error")
+				_, err := page.PerformanceMetrics()
+				Expect(err).To(MatchError("failed to retrieve performance metrics: some error"))
+			})
+		})
+
+		Context("when the timing metrics cannot be retrieved", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err := page.PerformanceMetrics()
+				Expect(err).To(MatchError("failed to retrieve performance metrics: some error"))
+			})
+		})
+	})
+
+	Describe("#StartTracing", func() {
+		It("should start tracing with the default categories", func() {
+			Expect(page.StartTracing()).To(Succeed())
+			Expect(session.NewLogsCall.LogType).To(Equal("performance"))
+			Expect(session.ExecuteCDPCall.Command).To(Equal("Tracing.start"))
+			parameters, _ := json.Marshal(session.ExecuteCDPCall.Parameters)
+			Expect(parameters).To(ContainSubstring(`"transferMode":"ReportEvents"`))
+			Expect(parameters).To(ContainSubstring(`"includedCategories":["-*","devtools.timeline"`))
+		})
+
+		It("should start tracing with the provided categories", func() {
+			Expect(page.StartTracing("v8", "blink")).To(Succeed())
+			parameters, _ := json.Marshal(session.ExecuteCDPCall.Parameters)
+			Expect(parameters).To(MatchJSON(`{"traceConfig": {"includedCategories": ["v8", "blink"]}, "transferMode": "ReportEvents"}`))
+		})
+
+		Context("when tracing was already started", func() {
+			It("should return an error", func() {
+				Expect(page.StartTracing()).To(Succeed())
+				Expect(page.StartTracing()).To(MatchError("failed to start tracing: tracing was already started"))
+			})
+		})
+
+		Context("when the performance log is not enabled", func() {
+			It("should return an error", func() {
+				session.NewLogsCall.Err = errors.New("some error")
+				Expect(page.StartTracing()).To(MatchError("failed to start tracing: some error"))
+			})
+		})
+
+		Context("when tracing cannot be started", func() {
+			It("should return an error", func() {
+				session.ExecuteCDPCall.Err = errors.New("some error")
+				Expect(page.StartTracing()).To(MatchError("failed to start tracing: some error"))
+			})
+		})
+	})
+
+	Describe("#StopTracing", func() {
+		var directory string
+
+		BeforeEach(func() {
+			var err error
+			directory, err = ioutil.TempDir("", "trace")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.StartTracing()).To(Succeed())
+			session.NewLogsCall.ReturnLogs = []api.Log{
+				{Message: `{"message": {"method": "Tracing.dataCollected", "params": {"value": [{"name": "first"}, {"name": "second"}]}}}`},
+				{Message: `{"message": {"method": "Tracing.dataCollected", "params": {"name": "third"}}}`},
+				{Message: `{"message": {"method": "Tracing.tracingComplete", "params": {}}}`},
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(directory)
+		})
+
+		It("should end tracing and save the trace events to a trace file", func() {
+			filename := filepath.Join(directory, "trace.json")
+			Expect(page.StopTracing(filename)).To(Succeed())
+			Expect(session.ExecuteCDPCall.Command).To(Equal("Tracing.end"))
+			trace, err := ioutil.ReadFile(filename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(trace).To(MatchJSON(`{"traceEvents": [{"name": "first"}, {"name": "second"}, {"name": "third"}]}`))
+		})
+
+		It("should save trace events that were read by a HAR recording", func() {
+			Expect(page.StartHAR("some page")).To(Succeed())
+			session.NewLogsCall.ReturnLogs = nil
+			Expect(page.StopHAR()).NotTo(BeNil())
+
+			filename := filepath.Join(directory, "trace.json")
+			Expect(page.StopTracing(filename)).To(Succeed())
+			trace, err := ioutil.ReadFile(filename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(trace).To(MatchJSON(`{"traceEvents": [{"name": "first"}, {"name": "second"}, {"name": "third"}]}`))
+		})
+
+		It("should allow tracing to be started again", func() {
+			Expect(page.StopTracing(filepath.Join(directory, "trace.json"))).To(Succeed())
+			Expect(page.StartTracing()).To(Succeed())
+		})
+
+		Context("when tracing was not started", func() {
+			It("should return an error", func() {
+				Expect(page.StopTracing(filepath.Join(directory, "trace.json"))).To(Succeed())
+				Expect(page.StopTracing(filepath.Join(directory, "trace.json"))).To(MatchError("failed to stop tracing: tracing was not started"))
+			})
+		})
+
+		Context("when tracing cannot be ended", func() {
+			It("should return an error", func() {
+				session.ExecuteCDPCall.Err = errors.New("some error")
+				Expect(page.StopTracing(filepath.Join(directory, "trace.json"))).To(MatchError("failed to stop tracing: some error"))
+			})
+		})
+
+		Context("when the trace events cannot be read", func() {
+			It("should return an error", func() {
+				session.NewLogsCall.Err = errors.New("some error")
+				Expect(page.StopTracing(filepath.Join(directory, "trace.json"))).To(MatchError("failed to stop tracing: some error"))
+			})
+		})
+
+		Context("when the trace file cannot be saved", func() {
+			It("should return an error", func() {
+				err := page.StopTracing(filepath.Join(directory, "missing", "trace.json"))
+				Expect(err).To(MatchError(ContainSubstring("failed to save trace: ")))
+			})
+		})
+	})
+})
@@ -0,0 +1,131 @@
+package agouti
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// A pagePool reuses the pages of a WebDriver. Each page that is in use
+// holds a slot, so that no more than size pages are open at once.
+type pagePool struct {
+	driver *WebDriver
+	slots  chan struct{}
+	idle   chan *Page
+}
+
+func newPagePool(driver *WebDriver, size int) *pagePool {
+	return &pagePool{
+		driver: driver,
+		slots:  make(chan struct{}, size),
+		idle:   make(chan *Page, size),
+	}
+}
+
+// AcquirePage returns a page from the pool of pages of the WebDriver,
+// opening a new page if none are available. Pages are opened with the
+// default Options of the WebDriver. When the maximum number of pages
+// specified by the PoolSize Option are in use, AcquirePage waits until a
+// page is released or the provided context is done.
+//
+// Pages should be returned to the pool using *Page.Release instead of
+// being destroyed. Reusing pages is much faster than opening a new browser
+// for every test.
+//
+// Example:
+//    page, err := driver.AcquirePage(ctx)
+//    if err != nil {
+//        return err
+//    }
+//    defer page.Release()
+func (w *WebDriver) AcquirePage(ctx context.Context) (*Page, error) {
+	pool := w.pagePool()
+
+	select {
+	case pool.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to acquire page: %s", ctx.Err())
+	}
+
+	select {
+	case page := <-pool.idle:
+		page.acquired = true
+		return page, nil
+	default:
+	}
+
+	page, err := w.NewPage()
+	if err != nil {
+		<-pool.slots
+		return nil, fmt.Errorf("failed to acquire page: %s", err)
+	}
+	page.pool = pool
+	page.acquired = true
+	return page, nil
+}
+
+// The pool size of WebDrivers that only support one session at a time is
+// limited to one, as idle pages hold the session.
+func (w *WebDriver) pagePool() *pagePool {
+	w.poolMutex.Lock()
+	defer w.poolMutex.Unlock()
+
+	if w.pool == nil {
+		size := w.defaultOptions.PoolSize
+		if size <= 0 {
+			size = runtime.GOMAXPROCS(0)
+		}
+		if w.sessionSlot != nil {
+			size = 1
+		}
+		w.pool = newPagePool(w, size)
+	}
+	return w.pool
+}
+
+// Stop destroys all idle pages in the page pool, and then ends all other
+// sessions and stops the WebDriver process. See api.WebDriver.Stop.
+func (w *WebDriver) Stop() error {
+	w.poolMutex.Lock()
+	pool := w.pool
+	w.pool = nil
+	w.poolMutex.Unlock()
+
+	if pool != nil {
+		pool.destroyIdle()
+	}
+	return w.WebDriver.Stop()
+}
+
+func (p *pagePool) destroyIdle() {
+	for {
+		select {
+		case page := <-p.idle:
+			page.Destroy()
+		default:
+			return
+		}
+	}
+}
+
+// Release returns a page acquired using *WebDriver.AcquirePage to the pool
+// of pages of its WebDriver. The page is reset first, which deletes the
+// cookies of the current domain, clears local and session storage, and
+// navigates to a blank page. If the page cannot be reset, it is destroyed
+// instead, and a new page is opened by the next call to AcquirePage.
+// The page must not be used after it is released.
+func (p *Page) Release() error {
+	if p.pool == nil || !p.acquired {
+		return errors.New("failed to release page: page was not acquired from a WebDriver")
+	}
+	p.acquired = false
+	defer func() { <-p.pool.slots }()
+
+	if err := p.Reset(); err != nil {
+		p.Destroy()
+		return fmt.Errorf("failed to release page: %s", err)
+	}
+	p.pool.idle <- p
+	return nil
+}
@@ -0,0 +1,179 @@
+package agouti
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sclevine/agouti/api"
+)
+
+// SessionInfo describes the browser session of a Page, as reported by the
+// WebDriver when the session was created.
+type SessionInfo struct {
+	// ID is the WebDriver session ID.
+	ID string
+
+	// BrowserName, BrowserVersion, and PlatformName describe the browser
+	// that was started, ex. "chrome", "120.0.6099.109", and "linux".
+	BrowserName    string
+	BrowserVersion string
+	PlatformName   string
+
+	// NodeURL is the URL of the Selenium Grid node that is running the
+	// session. It is only known for pages opened using Remote.
+	NodeURL string
+
+	// VNCURL is the WebSocket URL of the VNC server that displays the
+	// browser, if provided by the Selenium Grid node.
+	VNCURL string
+
+	// Capabilities are all of the capabilities returned by the WebDriver.
+	Capabilities map[string]interface{}
+}
+
+// GridOptions configures a session opened by Selenium Grid 4 when provided
+// to Capabilities.Grid. Zero-valued fields are omitted. The RecordVideo,
+// ScreenResolution, and TimeZone options require a Dynamic Grid.
+// See: https://www.selenium.dev/documentation/grid/configuration/
+type GridOptions struct {
+	// Name is the name of the session displayed by the Grid UI.
+	Name string
+
+	// RecordVideo records a video of the session.
+	RecordVideo bool
+
+	// ScreenResolution is the resolution of the display that the browser is
+	// started in, ex. "1920x1080".
+	ScreenResolution string
+
+	// TimeZone is the time zone of the browser, ex. "Europe/London".
+	TimeZone string
+}
+
+// Grid configures Selenium Grid 4 using the provided options, which are set
+// as capabilities with the "se:" prefix.
+func (c Capabilities) Grid(options GridOptions) Capabilities {
+	if options.Name != "" {
+		c["se:name"] = options.Name
+	}
+	if options.RecordVideo {
+		c["se:recordVideo"] = true
+	}
+	if options.ScreenResolution != "" {
+		c["se:screenResolution"] = options.ScreenResolution
+	}
+	if options.TimeZone != "" {
+		c["se:timeZone"] = options.TimeZone
+	}
+	return c
+}
+
+// Remote opens a Page using the remote WebDriver or Selenium Grid at the
+// provided URL, without starting a local WebDriver process. This method takes
+// the same Options as NewPage. When the URL is a Selenium Grid 3 or 4 hub,
+// the URL of the node assigned to the session is available from
+// *Page.SessionInfo.
+//
+// Example:
+//    capabilities := agouti.NewCapabilities().Browser("chrome").Grid(agouti.GridOptions{Name: "login test"})
+//    page, err := agouti.Remote("http://grid.example.com:4444/wd/hub", agouti.Desired(capabilities))
+func Remote(gridURL string, options ...Option) (*Page, error) {
+	gridURL = strings.TrimSuffix(gridURL, "/")
+	pageOptions := config{}.Merge(options)
+	page, err := openPage(pageOptions, func(capabilities map[string]interface{}) (*api.Session, error) {
+		return api.OpenWithClient(gridURL, capabilities, pageOptions.HTTPClient)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page.nodeURL = gridNodeURL(gridURL, page.Session().ID(), pageOptions.HTTPClient)
+	page.cloud = newCloudSession(gridURL, page.Session().ID(), pageOptions)
+	return page, nil
+}
+
+// SessionInfo returns information about the browser session of the page.
+func (p *Page) SessionInfo() SessionInfo {
+	session := p.Session()
+	capabilities := session.Capabilities
+	return SessionInfo{
+		ID:             session.ID(),
+		BrowserName:    stringCapability(capabilities, "browserName"),
+		BrowserVersion: stringCapability(capabilities, "browserVersion", "version"),
+		PlatformName:   stringCapability(capabilities, "platformName", "platform"),
+		NodeURL:        p.nodeURL,
+		VNCURL:         stringCapability(capabilities, "se:vnc"),
+		Capabilities:   capabilities,
+	}
+}
+
+// The first key is the W3C capability, and any others are legacy names.
+func stringCapability(capabilities map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := capabilities[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Grid 4 provides the node URI using its GraphQL API, while Grid 3 provides
+// it as the proxy ID of the test session. The node URL is empty if neither
+// request succeeds, ex. when the URL is not a Grid.
+func gridNodeURL(gridURL, sessionID string, client *http.Client) string {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	hubURL := strings.TrimSuffix(gridURL, "/wd/hub")
+
+	query, _ := json.Marshal(map[string]string{
+		"query": fmt.Sprintf(`{ session (id: %q) { nodeUri } }`, sessionID),
+	})
+	var grid4Session struct {
+		Data struct {
+			Session struct {
+				NodeURI string `json:"nodeUri"`
+			} `json:"session"`
+		} `json:"data"`
+	}
+	if requestGrid(client, "POST", hubURL+"/graphql", bytes.NewReader(query), &grid4Session) == nil {
+		if nodeURI := grid4Session.Data.Session.NodeURI; nodeURI != "" {
+			return nodeURI
+		}
+	}
+
+	var grid3Session struct {
+		ProxyID string `json:"proxyId"`
+	}
+	testSessionURL := hubURL + "/grid/api/testsession?session=" + url.QueryEscape(sessionID)
+	if requestGrid(client, "GET", testSessionURL, nil, &grid3Session) == nil {
+		return grid3Session.ProxyID
+	}
+	return ""
+}
+
+func requestGrid(client *http.Client, method, url string, body io.Reader, result interface{}) error {
+	request, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("request unsuccessful: %s", response.Status)
+	}
+	return json.NewDecoder(response.Body).Decode(result)
+}
@@ -0,0 +1,138 @@
+package agouti_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+)
+
+var _ = Describe("Remote", func() {
+	var (
+		server         *httptest.Server
+		sessionRequest map[string]interface{}
+		grid4Response  string
+		grid3Response  string
+		graphQLQuery   string
+	)
+
+	BeforeEach(func() {
+		sessionRequest = nil
+		grid4Response, grid3Response, graphQLQuery = "", "", ""
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			switch request.URL.Path {
+			case "/wd/hub/session":
+				body, _ := ioutil.ReadAll(request.Body)
+				json.Unmarshal(body, &sessionRequest)
+				response.Write([]byte(`{"value": {"sessionId": "some-id", "capabilities": {
+					"browserName": "chrome",
+					"browserVersion": "120.0",
+					"platformName": "linux",
+					"se:vnc": "ws://grid:4444/session/some-id/se/vnc"
+				}}}`))
+			case "/graphql":
+				var body struct{ Query string }
+				json.NewDecoder(request.Body).Decode(&body)
+				graphQLQuery = body.Query
+				if grid4Response == "" {
+					response.WriteHeader(404)
+					return
+				}
+				response.Write([]byte(grid4Response))
+			case "/grid/api/testsession":
+				if grid3Response == "" || request.URL.Query().Get("session") != "some-id" {
+					response.WriteHeader(404)
+					return
+				}
+				response.Write([]byte(grid3Response))
+			default:
+				response.Write([]byte(`{"value": null}`))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe(".Remote", func() {
+		It("should open a page using the provided Grid URL and capabilities", func() {
+			capabilities := NewCapabilities().Browser("chrome").Grid(GridOptions{Name: "some-test"})
+			page, err := Remote(server.URL+"/wd/hub/", Desired(capabilities))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Session().ID()).To(Equal("some-id"))
+			alwaysMatch := sessionRequest["capabilities"].(map[string]interface{})["alwaysMatch"]
+			Expect(alwaysMatch).To(HaveKeyWithValue("se:name", "some-test"))
+		})
+
+		It("should retrieve the node URL from Selenium Grid 4", func() {
+			grid4Response = `{"data": {"session": {"nodeUri": "http://some-node:5555"}}}`
+			page, err := Remote(server.URL + "/wd/hub")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.SessionInfo().NodeURL).To(Equal("http://some-node:5555"))
+			Expect(graphQLQuery).To(Equal(`{ session (id: "some-id") { nodeUri } }`))
+		})
+
+		It("should retrieve the node URL from Selenium Grid 3", func() {
+			grid3Response = `{"proxyId": "http://some-node:5555", "success": true}`
+			page, err := Remote(server.URL + "/wd/hub")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.SessionInfo().NodeURL).To(Equal("http://some-node:5555"))
+		})
+
+		Context("when the URL is not a Selenium Grid", func() {
+			It("should open a page without a node URL", func() {
+				page, err := Remote(server.URL + "/wd/hub")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(page.SessionInfo().NodeURL).To(BeEmpty())
+			})
+		})
+
+		Context("when the session cannot be opened", func() {
+			It("should return an error", func() {
+				_, err := Remote(server.URL + "/some/missing/hub")
+				Expect(err).To(MatchError(HavePrefix("failed to connect to WebDriver: ")))
+			})
+		})
+	})
+
+	Describe("#SessionInfo", func() {
+		It("should return information about the session from the returned capabilities", func() {
+			grid4Response = `{"data": {"session": {"nodeUri": "http://some-node:5555"}}}`
+			page, err := Remote(server.URL + "/wd/hub")
+			Expect(err).NotTo(HaveOccurred())
+			info := page.SessionInfo()
+			Expect(info.ID).To(Equal("some-id"))
+			Expect(info.BrowserName).To(Equal("chrome"))
+			Expect(info.BrowserVersion).To(Equal("120.0"))
+			Expect(info.PlatformName).To(Equal("linux"))
+			Expect(info.VNCURL).To(Equal("ws://grid:4444/session/some-id/se/vnc"))
+			Expect(info.Capabilities).To(HaveKeyWithValue("browserName", "chrome"))
+		})
+	})
+
+	Describe("Capabilities#Grid", func() {
+		It("should set the provided options as Selenium Grid capabilities", func() {
+			capabilities := NewCapabilities().Grid(GridOptions{
+				Name:             "some-test",
+				RecordVideo:      true,
+				ScreenResolution: "1920x1080",
+				TimeZone:         "Europe/London",
+			})
+			Expect(capabilities.JSON()).To(MatchJSON(`{
+				"se:name": "some-test",
+				"se:recordVideo": true,
+				"se:screenResolution": "1920x1080",
+				"se:timeZone": "Europe/London"
+			}`))
+		})
+
+		It("should omit options that are not provided", func() {
+			Expect(NewCapabilities().Grid(GridOptions{}).JSON()).To(MatchJSON(`{}`))
+		})
+	})
+})
@@ -0,0 +1,187 @@
+package agouti
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+var screencastInterval = 100 * time.Millisecond
+
+// ScreencastOptions configures a screencast started by Screencast.
+type ScreencastOptions struct {
+	// Format is the image format of each frame, "jpeg" (default) or "png".
+	Format string
+
+	// Quality is the compression quality of JPEG frames, from 0 to 100.
+	Quality int
+
+	// MaxWidth and MaxHeight limit the size of each frame, if non-zero.
+	MaxWidth  int
+	MaxHeight int
+
+	// EveryNthFrame causes only every nth frame rendered by the browser to
+	// be sent, if greater than one.
+	EveryNthFrame int
+}
+
+// A Frame is a single image of a screencast.
+type Frame struct {
+	// Data contains the image in the format requested by ScreencastOptions
+	Data []byte
+
+	// Format is the image format of the frame, ex. "jpeg"
+	Format string
+
+	// Time is the time at which the frame was rendered by the browser
+	Time time.Time
+
+	// Width and Height are the size of the browser viewport, in CSS pixels
+	Width  int
+	Height int
+}
+
+// Screencast starts streaming the rendered frames of the page using the
+// DevTools Page.startScreencast command, and returns a channel that receives
+// each frame along with a function that stops the screencast. The channel is
+// closed when the screencast is stopped or frames can no longer be retrieved.
+//
+// Frames are read from the Chrome performance log, so the page must have been
+// opened in Chrome with the PerformanceLogging Option. HAR recordings and
+// traces may be recorded while a screencast is running. The browser only
+// renders new frames once the previous frame has been received from the
+// channel.
+//
+// Example:
+//    frames, stop, err := page.Screencast(agouti.ScreencastOptions{Format: "png"})
+//    go func() {
+//        for frame := range frames {
+//            ioutil.WriteFile(frame.Time.Format("150405.000")+".png", frame.Data, 0666)
+//        }
+//    }()
+//    ... interact with the page ...
+//    stop()
+func (p *Page) Screencast(options ScreencastOptions) (<-chan Frame, func(), error) {
+	if options.Format == "" {
+		options.Format = "jpeg"
+	}
+
+	logs, err := p.performanceLog.subscribe(p.session)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to start screencast: %s", err)
+	}
+
+	request := struct {
+		Format        string `json:"format"`
+		Quality       int    `json:"quality,omitempty"`
+		MaxWidth      int    `json:"maxWidth,omitempty"`
+		MaxHeight     int    `json:"maxHeight,omitempty"`
+		EveryNthFrame int    `json:"everyNthFrame,omitempty"`
+	}{options.Format, options.Quality, options.MaxWidth, options.MaxHeight, options.EveryNthFrame}
+	if err := p.session.ExecuteCDP("Page.startScreencast", request, nil); err != nil {
+		logs.close()
+		return nil, nil, fmt.Errorf("failed to start screencast: %s", err)
+	}
+
+	frames := make(chan Frame)
+	stopped := make(chan struct{})
+	done := make(chan struct{})
+	go p.pollScreencast(logs, options.Format, frames, stopped, done)
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() { close(stopped) })
+		<-done
+	}
+	return frames, stop, nil
+}
+
+// The screencast is always stopped in the browser before the channel is
+// closed, so that the browser does not continue to render frames.
+func (p *Page) pollScreencast(logs *performanceSubscription, format string, frames chan<- Frame, stopped, done chan struct{}) {
+	defer close(done)
+	defer close(frames)
+	defer logs.close()
+	defer p.session.ExecuteCDP("Page.stopScreencast", nil, nil)
+
+	for {
+		entries, err := logs.read()
+		if err != nil {
+			return
+		}
+
+		for _, log := range entries {
+			frame, sessionID, err := screencastFrame(log.Message, format)
+			if err != nil {
+				continue
+			}
+
+			select {
+			case frames <- frame:
+			case <-stopped:
+				return
+			}
+
+			request := struct {
+				SessionID int `json:"sessionId"`
+			}{sessionID}
+			if err := p.session.ExecuteCDP("Page.screencastFrameAck", request, nil); err != nil {
+				return
+			}
+		}
+
+		select {
+		case <-time.After(screencastInterval):
+		case <-stopped:
+			return
+		}
+	}
+}
+
+// Frame timestamps are in seconds since the epoch.
+func screencastFrame(message, format string) (Frame, int, error) {
+	var event struct {
+		Message struct {
+			Method string `json:"method"`
+			Params struct {
+				Data      string `json:"data"`
+				SessionID int    `json:"sessionId"`
+				Metadata  struct {
+					DeviceWidth  float64 `json:"deviceWidth"`
+					DeviceHeight float64 `json:"deviceHeight"`
+					Timestamp    float64 `json:"timestamp"`
+				} `json:"metadata"`
+			} `json:"params"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(message), &event); err != nil {
+		return Frame{}, 0, err
+	}
+	if event.Message.Method != "Page.screencastFrame" {
+		return Frame{}, 0, errors.New("not a screencast frame")
+	}
+
+	params := event.Message.Params
+	data, err := base64.StdEncoding.DecodeString(params.Data)
+	if err != nil {
+		return Frame{}, 0, err
+	}
+
+	frame := Frame{
+		Data:   data,
+		Format: format,
+		Width:  int(params.Metadata.DeviceWidth),
+		Height: int(params.Metadata.DeviceHeight),
+	}
+	if timestamp := params.Metadata.Timestamp; timestamp > 0 {
+		seconds, fraction := math.Modf(timestamp)
+		frame.Time = time.Unix(int64(seconds), int64(fraction*float64(time.Second)))
+	} else {
+		frame.Time = time.Now()
+	}
+	return frame, params.SessionID, nil
+}
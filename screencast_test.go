@@ -0,0 +1,94 @@
+package agouti_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Screencast", func() {
+	var (
+		page    *Page
+		session *mocks.Session
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		page = NewTestPage(session)
+	})
+
+	Describe("#Screencast", func() {
+		BeforeEach(func() {
+			session.NewLogsCall.ReturnLogs = []api.Log{
+				{Message: `{"message": {"method": "Network.dataReceived", "params": {}}}`},
+				{Message: `{"message": {"method": "Page.screencastFrame", "params": {
+					"data": "c29tZS1pbWFnZQ==", "sessionId": 3,
+					"metadata": {"deviceWidth": 800, "deviceHeight": 600, "timestamp": 1418196097.5}
+				}}}`},
+			}
+		})
+
+		It("should start a screencast with the provided options", func() {
+			_, stop, err := page.Screencast(ScreencastOptions{Quality: 80, MaxWidth: 400})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.NewLogsCall.LogType).To(Equal("performance"))
+			stop()
+			Expect(session.ExecuteCDPCall.Command).To(Equal("Page.stopScreencast"))
+		})
+
+		It("should stream frames from the performance log", func() {
+			frames, stop, err := page.Screencast(ScreencastOptions{Format: "png"})
+			Expect(err).NotTo(HaveOccurred())
+			defer stop()
+
+			var frame Frame
+			Eventually(frames).Should(Receive(&frame))
+			Expect(frame.Data).To(Equal([]byte("some-image")))
+			Expect(frame.Format).To(Equal("png"))
+			Expect(frame.Width).To(Equal(800))
+			Expect(frame.Height).To(Equal(600))
+			Expect(frame.Time.Unix()).To(BeEquivalentTo(1418196097))
+			Expect(frame.Time.Nanosecond()).To(Equal(500000000))
+		})
+
+		It("should close the channel when the screencast is stopped", func() {
+			frames, stop, err := page.Screencast(ScreencastOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			stop()
+			Eventually(frames).Should(BeClosed())
+			Expect(stop).NotTo(Panic())
+		})
+
+		Context("when the browser fails to acknowledge a frame", func() {
+			It("should close the channel", func() {
+				frames, stop, err := page.Screencast(ScreencastOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				defer stop()
+				session.ExecuteCDPCall.Err = errors.New("some error")
+				Eventually(frames).Should(Receive())
+				Eventually(frames).Should(BeClosed())
+			})
+		})
+
+		Context("when the performance log is not enabled", func() {
+			It("should return an error", func() {
+				session.NewLogsCall.Err = errors.New("some error")
+				_, _, err := page.Screencast(ScreencastOptions{})
+				Expect(err).To(MatchError("failed to start screencast: some error"))
+			})
+		})
+
+		Context("when the screencast cannot be started", func() {
+			It("should return an error", func() {
+				session.ExecuteCDPCall.Err = errors.New("some error")
+				_, _, err := page.Screencast(ScreencastOptions{})
+				Expect(err).To(MatchError("failed to start screencast: some error"))
+				Expect(session.ExecuteCDPCall.Command).To(Equal("Page.startScreencast"))
+			})
+		})
+	})
+})
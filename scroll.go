@@ -0,0 +1,86 @@
+package agouti
+
+import (
+	"fmt"
+	"math"
+)
+
+// ScrollOptions configure how *Selection.ScrollIntoView aligns an element in
+// the viewport.
+type ScrollOptions struct {
+	// Smooth scrolls the element into view using a smooth animation. The
+	// scroll may still be in progress when ScrollIntoView returns.
+	Smooth bool
+
+	// Block is the vertical alignment of the element: "start", "center",
+	// "end", or "nearest". Defaults to "start".
+	Block string
+
+	// Inline is the horizontal alignment of the element: "start", "center",
+	// "end", or "nearest". Defaults to "nearest".
+	Inline string
+}
+
+func (o ScrollOptions) script() map[string]string {
+	options := map[string]string{"behavior": "instant", "block": "start", "inline": "nearest"}
+	if o.Smooth {
+		options["behavior"] = "smooth"
+	}
+	if o.Block != "" {
+		options["block"] = o.Block
+	}
+	if o.Inline != "" {
+		options["inline"] = o.Inline
+	}
+	return options
+}
+
+// ScrollIntoView scrolls exactly one element into view, aligned as specified by
+// the provided ScrollOptions.
+//
+// Example:
+//    selection.ScrollIntoView(agouti.ScrollOptions{Block: "center"})
+func (s *Selection) ScrollIntoView(options ScrollOptions) error {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	s.cache.Invalidate()
+	body := "arguments[0].scrollIntoView(arguments[1]);"
+	if err := selectedElement.Execute(body, []interface{}{options.script()}, nil); err != nil {
+		return fmt.Errorf("failed to scroll %s into view: %s", s, err)
+	}
+	return nil
+}
+
+// ScrollBy scrolls the page by the provided offset in pixels.
+func (p *Page) ScrollBy(xOffset, yOffset int) error {
+	body := `window.scrollBy({left: arguments[0], top: arguments[1], behavior: "instant"});`
+	if err := p.session.Execute(body, []interface{}{xOffset, yOffset}, nil); err != nil {
+		return fmt.Errorf("failed to scroll page: %s", err)
+	}
+	return nil
+}
+
+// ScrollTo scrolls the page to the provided position in pixels.
+func (p *Page) ScrollTo(x, y int) error {
+	body := `window.scrollTo({left: arguments[0], top: arguments[1], behavior: "instant"});`
+	if err := p.session.Execute(body, []interface{}{x, y}, nil); err != nil {
+		return fmt.Errorf("failed to scroll page: %s", err)
+	}
+	return nil
+}
+
+// ScrollPosition returns the current scroll position of the page in pixels.
+func (p *Page) ScrollPosition() (x, y int, err error) {
+	var position []float64
+	body := "return [window.pageXOffset, window.pageYOffset];"
+	if err := p.session.Execute(body, nil, &position); err != nil {
+		return 0, 0, fmt.Errorf("failed to retrieve scroll position: %s", err)
+	}
+	if len(position) != 2 {
+		return 0, 0, fmt.Errorf("failed to retrieve scroll position: invalid position %v", position)
+	}
+	return int(math.Round(position[0])), int(math.Round(position[1])), nil
+}
@@ -0,0 +1,113 @@
+package agouti_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Scrolling", func() {
+	var session *mocks.Session
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+	})
+
+	Describe("Selection#ScrollIntoView", func() {
+		var (
+			elementRepository *mocks.ElementRepository
+			selectedElement   *mocks.Element
+			selection         *Selection
+		)
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			selectedElement = &mocks.Element{}
+			elementRepository.GetExactlyOneCall.ReturnElement = selectedElement
+			selection = NewTestSelection(session, elementRepository, "#selector")
+		})
+
+		It("should scroll the element into view with the default alignment", func() {
+			Expect(selection.ScrollIntoView(ScrollOptions{})).To(Succeed())
+			Expect(selectedElement.ExecuteCall.Body).To(Equal("arguments[0].scrollIntoView(arguments[1]);"))
+			Expect(selectedElement.ExecuteCall.Arguments).To(Equal([]interface{}{
+				map[string]string{"behavior": "instant", "block": "start", "inline": "nearest"},
+			}))
+		})
+
+		It("should scroll the element into view with the provided options", func() {
+			Expect(selection.ScrollIntoView(ScrollOptions{Smooth: true, Block: "center", Inline: "end"})).To(Succeed())
+			Expect(selectedElement.ExecuteCall.Arguments).To(Equal([]interface{}{
+				map[string]string{"behavior": "smooth", "block": "center", "inline": "end"},
+			}))
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				err := selection.ScrollIntoView(ScrollOptions{})
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #selector [single]': some error"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				selectedElement.ExecuteCall.Err = errors.New("some error")
+				err := selection.ScrollIntoView(ScrollOptions{})
+				Expect(err).To(MatchError("failed to scroll selection 'CSS: #selector [single]' into view: some error"))
+			})
+		})
+	})
+
+	Describe("Page", func() {
+		var page *Page
+
+		BeforeEach(func() {
+			page = NewTestPage(session)
+		})
+
+		Describe("#ScrollBy", func() {
+			It("should scroll the page by the provided offset", func() {
+				Expect(page.ScrollBy(10, 20)).To(Succeed())
+				Expect(session.ExecuteCall.Body).To(ContainSubstring("window.scrollBy("))
+				Expect(session.ExecuteCall.Arguments).To(Equal([]interface{}{10, 20}))
+			})
+
+			Context("when the script fails", func() {
+				It("should return an error", func() {
+					session.ExecuteCall.Err = errors.New("some error")
+					Expect(page.ScrollBy(10, 20)).To(MatchError("failed to scroll page: some error"))
+				})
+			})
+		})
+
+		Describe("#ScrollTo", func() {
+			It("should scroll the page to the provided position", func() {
+				Expect(page.ScrollTo(10, 20)).To(Succeed())
+				Expect(session.ExecuteCall.Body).To(ContainSubstring("window.scrollTo("))
+				Expect(session.ExecuteCall.Arguments).To(Equal([]interface{}{10, 20}))
+			})
+		})
+
+		Describe("#ScrollPosition", func() {
+			It("should return the scroll position of the page", func() {
+				session.ExecuteCall.Result = "[10, 20.6]"
+				x, y, err := page.ScrollPosition()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(x).To(Equal(10))
+				Expect(y).To(Equal(21))
+			})
+
+			Context("when the script fails", func() {
+				It("should return an error", func() {
+					session.ExecuteCall.Err = errors.New("some error")
+					_, _, err := page.ScrollPosition()
+					Expect(err).To(MatchError("failed to retrieve scroll position: some error"))
+				})
+			})
+		})
+	})
+})
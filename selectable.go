@@ -1,6 +1,9 @@
 package agouti
 
 import (
+	"fmt"
+	"regexp"
+
 	"github.com/sclevine/agouti/api"
 	"github.com/sclevine/agouti/internal/element"
 	"github.com/sclevine/agouti/internal/target"
@@ -11,8 +14,11 @@ type Selectors interface {
 }
 
 type selectable struct {
-	session   apiSession
-	selectors target.Selectors
+	session         apiSession
+	selectors       target.Selectors
+	staleRetries    int
+	testIDAttribute string
+	cache           *element.Cache
 }
 
 type apiSession interface {
@@ -23,9 +29,18 @@ type apiSession interface {
 	GetWindows() ([]*api.Window, error)
 	SetWindow(window *api.Window) error
 	SetWindowByName(name string) error
+	NewWindow(kind string) (*api.Window, error)
+	UploadFile(filename string) (string, error)
+	SetExtraHTTPHeaders(headers map[string]string) error
+	SetHTTPAuth(username, password string) error
+	ClearHTTPAuth() error
+	SetNetworkConditions(conditions api.NetworkConditions) error
+	ClearNetworkConditions() error
 	DeleteWindow() error
 	GetScreenshot() ([]byte, error)
+	GetFullPageScreenshot() ([]byte, error)
 	GetCookies() ([]*api.Cookie, error)
+	GetNamedCookie(name string) (*api.Cookie, error)
 	SetCookie(cookie *api.Cookie) error
 	DeleteCookie(name string) error
 	DeleteCookies() error
@@ -35,8 +50,12 @@ type apiSession interface {
 	GetSource() (string, error)
 	MoveTo(element *api.Element, point api.Offset) error
 	Frame(frame *api.Element) error
+	FrameIndex(index int) error
 	FrameParent() error
 	Execute(body string, arguments []interface{}, result interface{}) error
+	AddInitScript(script string) error
+	PerformActions(actions *api.Actions) error
+	ExecuteAsync(body string, arguments []interface{}, result interface{}) error
 	Forward() error
 	Back() error
 	Refresh() error
@@ -45,6 +64,8 @@ type apiSession interface {
 	AcceptAlert() error
 	DismissAlert() error
 	NewLogs(logType string) ([]api.Log, error)
+	Subscribe(events ...string) (<-chan api.Event, error)
+	ExecuteCDP(command string, parameters, result interface{}) error
 	GetLogTypes() ([]string, error)
 	DoubleClick() error
 	Click(button api.Button) error
@@ -63,129 +84,282 @@ type apiSession interface {
 	SetImplicitWait(timout int) error
 	SetPageLoad(timout int) error
 	SetScriptTimeout(timout int) error
+	SetTimeouts(timeouts api.Timeouts) error
+	GetTimeouts() (api.Timeouts, error)
+	GetGeolocation() (api.Geolocation, error)
+	GetOrientation() (string, error)
+	SetOrientation(orientation string) error
+	SetGeolocation(latitude, longitude, altitude float64) error
 }
 
 // Find finds exactly one element by CSS selector.
 func (s *selectable) Find(selector string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.CSS, selector).Single())
+	return newSelection(s.session, s.selectors.Append(target.CSS, selector).Single(), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // FindByXPath finds exactly one element by XPath selector.
 func (s *selectable) FindByXPath(selector string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.XPath, selector).Single())
+	return newSelection(s.session, s.selectors.Append(target.XPath, selector).Single(), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // FindByLink finds exactly one anchor element by its text content.
 func (s *selectable) FindByLink(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Link, text).Single())
+	return newSelection(s.session, s.selectors.Append(target.Link, text).Single(), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // FindByLabel finds exactly one element by associated label text.
 func (s *selectable) FindByLabel(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Label, text).Single())
+	return newSelection(s.session, s.selectors.Append(target.Label, text).Single(), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // FindByButton finds exactly one button element with the provided text.
 // Supports <button>, <input type="button">, and <input type="submit">.
 func (s *selectable) FindByButton(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Button, text).Single())
+	return newSelection(s.session, s.selectors.Append(target.Button, text).Single(), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // FindByName finds exactly element with the provided name attribute.
 func (s *selectable) FindByName(name string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Name, name).Single())
+	return newSelection(s.session, s.selectors.Append(target.Name, name).Single(), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // FindByClass finds exactly one element with a given CSS class.
 func (s *selectable) FindByClass(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Class, text).Single())
+	return newSelection(s.session, s.selectors.Append(target.Class, text).Single(), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // FindByID finds exactly one element that has the given ID.
 func (s *selectable) FindByID(id string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.ID, id).Single())
+	return newSelection(s.session, s.selectors.Append(target.ID, id).Single(), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FindByRole finds exactly one element with the provided ARIA role, such as
+// "button" or "checkbox", and accessible name. Elements with an implicit
+// role, such as <button>, match unless they have a different role attribute.
+// The accessible name is matched against the ARIA label, the associated
+// label text, the text content, and the value, alt, and title attributes.
+// An empty name matches any element with the role.
+func (s *selectable) FindByRole(role, name string) *Selection {
+	return newSelection(s.session, s.selectors.AppendRole(role, name).Single(), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FindByLabeledInput finds exactly one form control labeled by the provided
+// text, using a <label> element, aria-label, or aria-labelledby.
+func (s *selectable) FindByLabeledInput(text string) *Selection {
+	return newSelection(s.session, s.selectors.Append(target.LabeledInput, text).Single(), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FindByAria finds exactly one element with the provided value for an ARIA
+// attribute. The "aria-" prefix of the attribute is optional.
+func (s *selectable) FindByAria(attribute, value string) *Selection {
+	return newSelection(s.session, s.selectors.AppendAria(attribute, value).Single(), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FindByTestID finds exactly one element with the provided test ID, which is
+// the value of the data-testid attribute unless a different attribute is
+// provided using the TestIDAttribute Option.
+func (s *selectable) FindByTestID(id string) *Selection {
+	return newSelection(s.session, s.testIDSelectors(id).Single(), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FindByText finds exactly one element with the provided text, ignoring
+// leading, trailing, and repeated whitespace. Any element may match, but
+// its ancestors that contain the same text do not.
+func (s *selectable) FindByText(text string) *Selection {
+	return newSelection(s.session, s.selectors.Append(target.Text, text).Single(), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FindByPartialText finds exactly one element containing the provided text.
+// Like FindByText, ancestors of the element are not matched.
+func (s *selectable) FindByPartialText(text string) *Selection {
+	return newSelection(s.session, s.selectors.Append(target.PartialText, text).Single(), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FindByTextMatching finds exactly one element with visible text matching
+// the provided regular expression. Only elements with text of their own,
+// such as <p> but not an enclosing <div>, are matched. Each candidate
+// element is retrieved to match its text, so this is slower than other
+// selectors on large pages. Use it within a parent selection when possible.
+func (s *selectable) FindByTextMatching(pattern *regexp.Regexp) *Selection {
+	return newSelection(s.session, s.selectors.Append(target.TextMatching, pattern.String()).Single(), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FindShadow finds exactly one element by CSS selector within the shadow
+// root of each element in the selection.
+func (s *selectable) FindShadow(selector string) *Selection {
+	return newSelection(s.session, s.selectors.AppendShadow(selector).Single(), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // First finds the first element by CSS selector.
 func (s *selectable) First(selector string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.CSS, selector).At(0))
+	return newSelection(s.session, s.selectors.Append(target.CSS, selector).At(0), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FirstShadow finds the first element by CSS selector within the shadow
+// root of each element in the selection.
+func (s *selectable) FirstShadow(selector string) *Selection {
+	return newSelection(s.session, s.selectors.AppendShadow(selector).At(0), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // FirstByXPath finds the first element by XPath selector.
 func (s *selectable) FirstByXPath(selector string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.XPath, selector).At(0))
+	return newSelection(s.session, s.selectors.Append(target.XPath, selector).At(0), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // FirstByLink finds the first anchor element by its text content.
 func (s *selectable) FirstByLink(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Link, text).At(0))
+	return newSelection(s.session, s.selectors.Append(target.Link, text).At(0), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // FirstByLabel finds the first element by associated label text.
 func (s *selectable) FirstByLabel(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Label, text).At(0))
+	return newSelection(s.session, s.selectors.Append(target.Label, text).At(0), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // FirstByButton finds the first button element with the provided text.
 // Supports <button>, <input type="button">, and <input type="submit">.
 func (s *selectable) FirstByButton(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Button, text).At(0))
+	return newSelection(s.session, s.selectors.Append(target.Button, text).At(0), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FirstByRole finds the first element with the provided ARIA role and
+// accessible name. See FindByRole.
+func (s *selectable) FirstByRole(role, name string) *Selection {
+	return newSelection(s.session, s.selectors.AppendRole(role, name).At(0), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FirstByLabeledInput finds the first form control labeled by the provided text.
+func (s *selectable) FirstByLabeledInput(text string) *Selection {
+	return newSelection(s.session, s.selectors.Append(target.LabeledInput, text).At(0), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FirstByAria finds the first element with the provided ARIA attribute value.
+func (s *selectable) FirstByAria(attribute, value string) *Selection {
+	return newSelection(s.session, s.selectors.AppendAria(attribute, value).At(0), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FirstByTestID finds the first element with the provided test ID.
+func (s *selectable) FirstByTestID(id string) *Selection {
+	return newSelection(s.session, s.testIDSelectors(id).At(0), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FirstByText finds the first element with the provided text.
+func (s *selectable) FirstByText(text string) *Selection {
+	return newSelection(s.session, s.selectors.Append(target.Text, text).At(0), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FirstByPartialText finds the first element containing the provided text.
+func (s *selectable) FirstByPartialText(text string) *Selection {
+	return newSelection(s.session, s.selectors.Append(target.PartialText, text).At(0), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// FirstByTextMatching finds the first element with visible text matching
+// the provided regular expression. See FindByTextMatching.
+func (s *selectable) FirstByTextMatching(pattern *regexp.Regexp) *Selection {
+	return newSelection(s.session, s.selectors.Append(target.TextMatching, pattern.String()).At(0), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // FirstByName finds the first element with the provided name attribute.
 func (s *selectable) FirstByName(name string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Name, name).At(0))
+	return newSelection(s.session, s.selectors.Append(target.Name, name).At(0), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // FirstByClass finds the first element with a given CSS class.
 func (s *selectable) FirstByClass(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Class, text).At(0))
+	return newSelection(s.session, s.selectors.Append(target.Class, text).At(0), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // All finds zero or more elements by CSS selector.
 func (s *selectable) All(selector string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.CSS, selector))
+	return newMultiSelection(s.session, s.selectors.Append(target.CSS, selector), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// AllShadow finds zero or more elements by CSS selector within the shadow
+// root of each element in the selection.
+func (s *selectable) AllShadow(selector string) *MultiSelection {
+	return newMultiSelection(s.session, s.selectors.AppendShadow(selector), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // AllByXPath finds zero or more elements by XPath selector.
 func (s *selectable) AllByXPath(selector string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.XPath, selector))
+	return newMultiSelection(s.session, s.selectors.Append(target.XPath, selector), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // AllByLink finds zero or more anchor elements by their text content.
 func (s *selectable) AllByLink(text string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.Link, text))
+	return newMultiSelection(s.session, s.selectors.Append(target.Link, text), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // AllByLabel finds zero or more elements by associated label text.
 func (s *selectable) AllByLabel(text string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.Label, text))
+	return newMultiSelection(s.session, s.selectors.Append(target.Label, text), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // AllByButton finds zero or more button elements with the provided text.
 // Supports <button>, <input type="button">, and <input type="submit">.
 func (s *selectable) AllByButton(text string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.Button, text))
+	return newMultiSelection(s.session, s.selectors.Append(target.Button, text), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// AllByRole finds zero or more elements with the provided ARIA role and
+// accessible name. See FindByRole.
+func (s *selectable) AllByRole(role, name string) *MultiSelection {
+	return newMultiSelection(s.session, s.selectors.AppendRole(role, name), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// AllByLabeledInput finds zero or more form controls labeled by the provided text.
+func (s *selectable) AllByLabeledInput(text string) *MultiSelection {
+	return newMultiSelection(s.session, s.selectors.Append(target.LabeledInput, text), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// AllByAria finds zero or more elements with the provided ARIA attribute value.
+func (s *selectable) AllByAria(attribute, value string) *MultiSelection {
+	return newMultiSelection(s.session, s.selectors.AppendAria(attribute, value), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// AllByTestID finds zero or more elements with the provided test ID.
+func (s *selectable) AllByTestID(id string) *MultiSelection {
+	return newMultiSelection(s.session, s.testIDSelectors(id), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// AllByText finds zero or more elements with the provided text.
+func (s *selectable) AllByText(text string) *MultiSelection {
+	return newMultiSelection(s.session, s.selectors.Append(target.Text, text), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// AllByPartialText finds zero or more elements containing the provided text.
+func (s *selectable) AllByPartialText(text string) *MultiSelection {
+	return newMultiSelection(s.session, s.selectors.Append(target.PartialText, text), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// AllByTextMatching finds zero or more elements with visible text matching
+// the provided regular expression. See FindByTextMatching.
+func (s *selectable) AllByTextMatching(pattern *regexp.Regexp) *MultiSelection {
+	return newMultiSelection(s.session, s.selectors.Append(target.TextMatching, pattern.String()), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // AllByName finds zero or more elements with the provided name attribute.
 func (s *selectable) AllByName(name string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.Name, name))
+	return newMultiSelection(s.session, s.selectors.Append(target.Name, name), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // AllByClass finds zero or more elements with a given CSS class.
 func (s *selectable) AllByClass(text string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.Class, text))
+	return newMultiSelection(s.session, s.selectors.Append(target.Class, text), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // AllByID finds zero or more elements with a given ID.
 func (s *selectable) AllByID(text string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.ID, text))
+	return newMultiSelection(s.session, s.selectors.Append(target.ID, text), s.staleRetries, s.testIDAttribute, s.cache)
 }
 
 // FirstByClass finds the first element with a given CSS class.
 func (s *selectable) FindForAppium(selectorType string, text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Class, text).At(0))
+	return newSelection(s.session, s.selectors.Append(target.Class, text).At(0), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+func (s *selectable) testIDSelectors(id string) target.Selectors {
+	return s.selectors.Append(target.CSS, fmt.Sprintf(`[%s="%s"]`, s.testIDAttribute, id))
 }
 
 func (s *selectable) Selectors() Selectors {
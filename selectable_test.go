@@ -1,6 +1,8 @@
 package agouti_test
 
 import (
+	"regexp"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/sclevine/agouti"
@@ -29,6 +31,13 @@ var _ = Describe("Selectable", func() {
 		})
 	})
 
+	Describe("#FindShadow", func() {
+		It("should apply a single shadow root CSS selector and return a selection with the same session", func() {
+			Expect(page.Find("host").FindShadow("selector").String()).To(Equal("selection 'CSS: host [single] | Shadow CSS: selector [single]'"))
+			Expect(page.Find("host").FindShadow("selector").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
 	Describe("#FindByXPath", func() {
 		It("should apply a single XPath selector and return a selection with the same session", func() {
 			Expect(page.FindByXPath("selector").String()).To(Equal("selection 'XPath: selector [single]'"))
@@ -78,6 +87,13 @@ var _ = Describe("Selectable", func() {
 		})
 	})
 
+	Describe("#FirstShadow", func() {
+		It("should apply a zero-indexed shadow root CSS selector and return a selection with the same session", func() {
+			Expect(page.Find("host").FirstShadow("selector").String()).To(Equal("selection 'CSS: host [single] | Shadow CSS: selector [0]'"))
+			Expect(page.Find("host").FirstShadow("selector").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
 	Describe("#FirstByXPath", func() {
 		It("should apply a zero-indexed XPath selector and return a selection with the same session", func() {
 			Expect(page.FirstByXPath("selector").String()).To(Equal("selection 'XPath: selector [0]'"))
@@ -92,6 +108,60 @@ var _ = Describe("Selectable", func() {
 		})
 	})
 
+	Describe("#FindByText", func() {
+		It("should apply a single-element-only text selector and return a selection with the same session", func() {
+			Expect(page.FindByText("some text").String()).To(Equal(`selection 'Text: "some text" [single]'`))
+			Expect(page.FindByText("some text").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
+	Describe("#FindByPartialText", func() {
+		It("should apply a single-element-only partial text selector and return a selection with the same session", func() {
+			Expect(page.FindByPartialText("some text").String()).To(Equal(`selection 'Partial Text: "some text" [single]'`))
+			Expect(page.FindByPartialText("some text").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
+	Describe("#FindByTextMatching", func() {
+		It("should apply a single-element-only text pattern selector", func() {
+			Expect(page.FindByTextMatching(regexp.MustCompile(`^Total: \d+$`)).String()).To(Equal(`selection 'Text Matching: /^Total: \d+$/ [single]'`))
+		})
+	})
+
+	Describe("#FindByTestID", func() {
+		It("should apply a single-element-only test ID selector and return a selection with the same session", func() {
+			Expect(page.FindByTestID("checkout").String()).To(Equal(`selection 'CSS: [data-testid="checkout"] [single]'`))
+			Expect(page.FindByTestID("checkout").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+
+		It("should use the same test ID attribute for nested selections", func() {
+			Expect(page.Find("#form").FindByTestID("checkout").String()).To(Equal(`selection 'CSS: #form [single] | CSS: [data-testid="checkout"] [single]'`))
+		})
+	})
+
+	Describe("#FindByRole", func() {
+		It("should apply a single-element-only role selector and return a selection with the same session", func() {
+			Expect(page.FindByRole("button", "Submit").String()).To(Equal(`selection 'Role: button "Submit" [single]'`))
+			Expect(page.FindByRole("button", "").String()).To(Equal(`selection 'Role: button [single]'`))
+			Expect(page.FindByRole("button", "Submit").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
+	Describe("#FindByLabeledInput", func() {
+		It("should apply a single-element-only labeled input selector and return a selection with the same session", func() {
+			Expect(page.FindByLabeledInput("selector").String()).To(Equal(`selection 'Labeled Input: "selector" [single]'`))
+			Expect(page.FindByLabeledInput("selector").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
+	Describe("#FindByAria", func() {
+		It("should apply a single-element-only ARIA attribute selector and return a selection with the same session", func() {
+			Expect(page.FindByAria("label", "Close").String()).To(Equal(`selection 'CSS: [aria-label="Close"] [single]'`))
+			Expect(page.FindByAria("aria-expanded", "true").String()).To(Equal(`selection 'CSS: [aria-expanded="true"] [single]'`))
+			Expect(page.FindByAria("label", "Close").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
 	Describe("#FirstByLabel", func() {
 		It("should apply a zero-indexed label selector and return a selection with the same session", func() {
 			Expect(page.FirstByLabel("selector").String()).To(Equal(`selection 'Label: "selector" [0]'`))
@@ -120,6 +190,13 @@ var _ = Describe("Selectable", func() {
 		})
 	})
 
+	Describe("#AllShadow", func() {
+		It("should apply an un-indexed shadow root CSS selector and return a selection with the same session", func() {
+			Expect(page.All("host").AllShadow("selector").String()).To(Equal("selection 'CSS: host | Shadow CSS: selector'"))
+			Expect(page.All("host").AllShadow("selector").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
 	Describe("#AllByXPath", func() {
 		It("should apply an un-indexed XPath selector and return a selection with the same session", func() {
 			Expect(page.AllByXPath("selector").String()).To(Equal("selection 'XPath: selector'"))
@@ -134,6 +211,54 @@ var _ = Describe("Selectable", func() {
 		})
 	})
 
+	Describe("#FirstByText", func() {
+		It("should apply an indexed text selector and return a selection with the same session", func() {
+			Expect(page.FirstByText("some text").String()).To(Equal(`selection 'Text: "some text" [0]'`))
+			Expect(page.FirstByText("some text").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
+	Describe("#FirstByPartialText", func() {
+		It("should apply an indexed partial text selector and return a selection with the same session", func() {
+			Expect(page.FirstByPartialText("some text").String()).To(Equal(`selection 'Partial Text: "some text" [0]'`))
+			Expect(page.FirstByPartialText("some text").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
+	Describe("#FirstByTextMatching", func() {
+		It("should apply an indexed text pattern selector", func() {
+			Expect(page.FirstByTextMatching(regexp.MustCompile("some.*text")).String()).To(Equal(`selection 'Text Matching: /some.*text/ [0]'`))
+		})
+	})
+
+	Describe("#FirstByTestID", func() {
+		It("should apply an indexed test ID selector and return a selection with the same session", func() {
+			Expect(page.FirstByTestID("checkout").String()).To(Equal(`selection 'CSS: [data-testid="checkout"] [0]'`))
+			Expect(page.FirstByTestID("checkout").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
+	Describe("#FirstByRole", func() {
+		It("should apply an indexed role selector and return a selection with the same session", func() {
+			Expect(page.FirstByRole("link", "Home").String()).To(Equal(`selection 'Role: link "Home" [0]'`))
+			Expect(page.FirstByRole("link", "Home").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
+	Describe("#FirstByLabeledInput", func() {
+		It("should apply an indexed labeled input selector and return a selection with the same session", func() {
+			Expect(page.FirstByLabeledInput("selector").String()).To(Equal(`selection 'Labeled Input: "selector" [0]'`))
+			Expect(page.FirstByLabeledInput("selector").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
+	Describe("#FirstByAria", func() {
+		It("should apply an indexed ARIA attribute selector and return a selection with the same session", func() {
+			Expect(page.FirstByAria("label", "Close").String()).To(Equal(`selection 'CSS: [aria-label="Close"] [0]'`))
+			Expect(page.FirstByAria("label", "Close").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
 	Describe("#AllByLabel", func() {
 		It("should apply an un-indexed label selector and return a selection with the same session", func() {
 			Expect(page.AllByLabel("selector").String()).To(Equal(`selection 'Label: "selector"'`))
@@ -155,6 +280,54 @@ var _ = Describe("Selectable", func() {
 		})
 	})
 
+	Describe("#AllByText", func() {
+		It("should apply an un-indexed text selector and return a selection with the same session", func() {
+			Expect(page.AllByText("some text").String()).To(Equal(`selection 'Text: "some text"'`))
+			Expect(page.AllByText("some text").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
+	Describe("#AllByPartialText", func() {
+		It("should apply an un-indexed partial text selector and return a selection with the same session", func() {
+			Expect(page.AllByPartialText("some text").String()).To(Equal(`selection 'Partial Text: "some text"'`))
+			Expect(page.AllByPartialText("some text").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
+	Describe("#AllByTextMatching", func() {
+		It("should apply an un-indexed text pattern selector", func() {
+			Expect(page.AllByTextMatching(regexp.MustCompile("some.*text")).String()).To(Equal(`selection 'Text Matching: /some.*text/'`))
+		})
+	})
+
+	Describe("#AllByTestID", func() {
+		It("should apply an un-indexed test ID selector and return a selection with the same session", func() {
+			Expect(page.AllByTestID("item").String()).To(Equal(`selection 'CSS: [data-testid="item"]'`))
+			Expect(page.AllByTestID("item").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
+	Describe("#AllByRole", func() {
+		It("should apply an un-indexed role selector and return a selection with the same session", func() {
+			Expect(page.AllByRole("checkbox", "").String()).To(Equal(`selection 'Role: checkbox'`))
+			Expect(page.AllByRole("checkbox", "").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
+	Describe("#AllByLabeledInput", func() {
+		It("should apply an un-indexed labeled input selector and return a selection with the same session", func() {
+			Expect(page.AllByLabeledInput("selector").String()).To(Equal(`selection 'Labeled Input: "selector"'`))
+			Expect(page.AllByLabeledInput("selector").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
+	Describe("#AllByAria", func() {
+		It("should apply an un-indexed ARIA attribute selector and return a selection with the same session", func() {
+			Expect(page.AllByAria("checked", "true").String()).To(Equal(`selection 'CSS: [aria-checked="true"]'`))
+			Expect(page.AllByAria("checked", "true").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+	})
+
 	Describe("#AllByID", func() {
 		It("should apply an un-indexed id selector and return a selection with the same session", func() {
 			Expect(page.AllByID("selector").String()).To(Equal(`selection 'ID: selector'`))
@@ -1,7 +1,10 @@
 package agouti
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 
 	"github.com/sclevine/agouti/api"
 	"github.com/sclevine/agouti/internal/element"
@@ -30,14 +33,17 @@ type elementRepository interface {
 	Get() ([]element.Element, error)
 	GetAtLeastOne() ([]element.Element, error)
 	GetExactlyOne() (element.Element, error)
+	Reload() ([]element.Element, error)
 }
 
-func newSelection(session apiSession, selectors target.Selectors) *Selection {
+func newSelection(session apiSession, selectors target.Selectors, staleRetries int, testIDAttribute string, cache *element.Cache) *Selection {
 	return &Selection{
-		selectable{session, selectors},
+		selectable{session, selectors, staleRetries, testIDAttribute, cache},
 		&element.Repository{
-			Client:    session,
-			Selectors: selectors,
+			Client:       session,
+			Selectors:    selectors,
+			StaleRetries: staleRetries,
+			Cache:        cache,
 		},
 	}
 }
@@ -57,16 +63,33 @@ func (s *Selection) Elements() ([]*api.Element, error) {
 	}
 	apiElements := []*api.Element{}
 	for _, selectedElement := range elements {
-		apiElements = append(apiElements, selectedElement.(*api.Element))
+		apiElement, err := element.Unwrap(selectedElement)
+		if err != nil {
+			return nil, err
+		}
+		apiElements = append(apiElements, apiElement)
 	}
 	return apiElements, nil
 }
 
+// Reload discards all elements cached by the ElementCache Option, and
+// retrieves the elements of the selection again.
+//
+// Example:
+//    page.Find("#add-row").Click()
+//    Expect(page.All("tr").Reload()).To(Succeed())
+func (s *Selection) Reload() error {
+	if _, err := s.elements.Reload(); err != nil {
+		return selectionError("failed to select elements from %s: %s", s, err)
+	}
+	return nil
+}
+
 // Count returns the number of elements that the selection refers to.
 func (s *Selection) Count() (int, error) {
 	elements, err := s.elements.Get()
 	if err != nil {
-		return 0, fmt.Errorf("failed to select elements from %s: %s", s, err)
+		return 0, selectionError("failed to select elements from %s: %s", s, err)
 	}
 
 	return len(elements), nil
@@ -75,26 +98,27 @@ func (s *Selection) Count() (int, error) {
 // EqualsElement returns whether or not two selections of exactly
 // one element refer to the same element.
 func (s *Selection) EqualsElement(other interface{}) (bool, error) {
-	otherSelection, ok := other.(*Selection)
-	if !ok {
-		multiSelection, ok := other.(*MultiSelection)
-		if !ok {
-			return false, fmt.Errorf("must be *Selection or *MultiSelection")
-		}
-		otherSelection = &multiSelection.Selection
+	otherSelection, err := toSelection(other)
+	if err != nil {
+		return false, err
 	}
 
 	selectedElement, err := s.elements.GetExactlyOne()
 	if err != nil {
-		return false, fmt.Errorf("failed to select element from %s: %s", s, err)
+		return false, selectionError("failed to select element from %s: %s", s, err)
 	}
 
 	otherElement, err := otherSelection.elements.GetExactlyOne()
+	if err != nil {
+		return false, selectionError("failed to select element from %s: %s", other, err)
+	}
+
+	otherAPIElement, err := element.Unwrap(otherElement)
 	if err != nil {
 		return false, fmt.Errorf("failed to select element from %s: %s", other, err)
 	}
 
-	equal, err := selectedElement.IsEqualTo(otherElement.(*api.Element))
+	equal, err := selectedElement.IsEqualTo(otherAPIElement)
 	if err != nil {
 		return false, fmt.Errorf("failed to compare %s to %s: %s", s, other, err)
 	}
@@ -102,16 +126,70 @@ func (s *Selection) EqualsElement(other interface{}) (bool, error) {
 	return equal, nil
 }
 
+func toSelection(value interface{}) (*Selection, error) {
+	switch selection := value.(type) {
+	case *Selection:
+		return selection, nil
+	case *MultiSelection:
+		return &selection.Selection, nil
+	}
+	return nil, errors.New("must be *Selection or *MultiSelection")
+}
+
+// selectionError describes an error that occurred while selecting the elements
+// of a selection. Errors indicating that the elements do not exist remain
+// detectable with element.IsNotFound, so that Wait.Until retries them.
+func selectionError(format string, selection interface{}, err error) error {
+	message := fmt.Sprintf(format, selection, err)
+	if element.IsNotFound(err) || api.IsNoSuchElement(err) {
+		return element.NotFoundError(message)
+	}
+	return errors.New(message)
+}
+
 // MouseToElement moves the mouse over exactly one element in the selection.
 func (s *Selection) MouseToElement() error {
 	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	apiElement, err := element.Unwrap(selectedElement)
 	if err != nil {
 		return fmt.Errorf("failed to select element from %s: %s", s, err)
 	}
 
-	if err := s.session.MoveTo(selectedElement.(*api.Element), nil); err != nil {
+	s.cache.Invalidate()
+	if err := s.session.MoveTo(apiElement, nil); err != nil {
 		return fmt.Errorf("failed to move mouse to element for %s: %s", s, err)
 	}
 
 	return nil
 }
+
+// Screenshot takes a screenshot of exactly one element in the selection and
+// saves it to the provided filename. The element is scrolled into view
+// before the screenshot is taken. The provided filename may be an absolute
+// or relative path.
+func (s *Selection) Screenshot(filename string) error {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	absFilePath, err := filepath.Abs(filename)
+	if err != nil {
+		return fmt.Errorf("failed to find absolute path for filename: %s", err)
+	}
+
+	screenshot, err := selectedElement.GetScreenshot()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve screenshot for %s: %s", s, err)
+	}
+
+	if err := ioutil.WriteFile(absFilePath, screenshot, 0666); err != nil {
+		return fmt.Errorf("failed to save screenshot: %s", err)
+	}
+
+	return nil
+}
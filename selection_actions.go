@@ -14,9 +14,10 @@ type actionsFunc func(element.Element) error
 func (s *Selection) forEachElement(actions actionsFunc) error {
 	elements, err := s.elements.GetAtLeastOne()
 	if err != nil {
-		return fmt.Errorf("failed to select elements from %s: %s", s, err)
+		return selectionError("failed to select elements from %s: %s", s, err)
 	}
 
+	s.cache.Invalidate()
 	for _, element := range elements {
 		if err := actions(element); err != nil {
 			return err
@@ -38,7 +39,11 @@ func (s *Selection) Click() error {
 // DoubleClick double-clicks on all of the elements that the selection refers to.
 func (s *Selection) DoubleClick() error {
 	return s.forEachElement(func(selectedElement element.Element) error {
-		if err := s.session.MoveTo(selectedElement.(*api.Element), nil); err != nil {
+		apiElement, err := element.Unwrap(selectedElement)
+		if err != nil {
+			return fmt.Errorf("failed to move mouse to %s: %s", s, err)
+		}
+		if err := s.session.MoveTo(apiElement, nil); err != nil {
 			return fmt.Errorf("failed to move mouse to %s: %s", s, err)
 		}
 		if err := s.session.DoubleClick(); err != nil {
@@ -72,14 +77,26 @@ func (s *Selection) Fill(text string) error {
 }
 
 // UploadFile uploads the provided file to all selected <input type="file" />.
-// The provided filename may be a relative or absolute path.
-// Returns an error if elements of any other type are in the selection.
+// The provided filename may be a relative or absolute path. If the WebDriver
+// accepts file uploads, as remote Selenium servers do, the file is first
+// copied to the machine running the browser. WebDriver implementations that
+// run on the local machine, such as ChromeDriver, do not implement uploads,
+// so the local path is used instead. Any other upload failure is returned.
+// Returns an error if elements of any other type are in the selection, before
+// the file is uploaded.
 func (s *Selection) UploadFile(filename string) error {
 	absFilePath, err := filepath.Abs(filename)
 	if err != nil {
 		return fmt.Errorf("failed to find absolute path for filename: %s", err)
 	}
-	return s.forEachElement(func(selectedElement element.Element) error {
+
+	elements, err := s.elements.GetAtLeastOne()
+	if err != nil {
+		return selectionError("failed to select elements from %s: %s", s, err)
+	}
+	s.cache.Invalidate()
+
+	for _, selectedElement := range elements {
 		tagName, err := selectedElement.GetName()
 		if err != nil {
 			return fmt.Errorf("failed to determine tag name of %s: %s", s, err)
@@ -94,11 +111,21 @@ func (s *Selection) UploadFile(filename string) error {
 		if inputType != "file" {
 			return fmt.Errorf("element for %s is not a file uploader", s)
 		}
-		if err := selectedElement.Value(absFilePath); err != nil {
+	}
+
+	uploadPath, err := s.session.UploadFile(absFilePath)
+	if api.IsUnknownCommand(err) || (err == nil && uploadPath == "") {
+		uploadPath = absFilePath
+	} else if err != nil {
+		return fmt.Errorf("failed to upload file for %s: %s", s, err)
+	}
+
+	for _, selectedElement := range elements {
+		if err := selectedElement.Value(uploadPath); err != nil {
 			return fmt.Errorf("failed to enter text into %s: %s", s, err)
 		}
-		return nil
-	})
+	}
+	return nil
 }
 
 // Check checks all of the unchecked checkboxes that the selection refers to.
@@ -186,7 +213,11 @@ func (s *Selection) Tap(event Tap) error {
 	}
 
 	return s.forEachElement(func(selectedElement element.Element) error {
-		if err := touchFunc(selectedElement.(*api.Element)); err != nil {
+		apiElement, err := element.Unwrap(selectedElement)
+		if err != nil {
+			return fmt.Errorf("failed to %s on %s: %s", event, s, err)
+		}
+		if err := touchFunc(apiElement); err != nil {
 			return fmt.Errorf("failed to %s on %s: %s", event, s, err)
 		}
 		return nil
@@ -225,10 +256,16 @@ func (s *Selection) Touch(event Touch) error {
 func (s *Selection) FlickFinger(xOffset, yOffset int, speed uint) error {
 	selectedElement, err := s.elements.GetExactlyOne()
 	if err != nil {
-		return fmt.Errorf("failed to select element from %s: %s", s, err)
+		return selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	apiElement, err := element.Unwrap(selectedElement)
+	if err != nil {
+		return fmt.Errorf("failed to flick finger on %s: %s", s, err)
 	}
 
-	if err := s.session.TouchFlick(selectedElement.(*api.Element), api.XYOffset{X: xOffset, Y: yOffset}, api.ScalarSpeed(speed)); err != nil {
+	s.cache.Invalidate()
+	if err := s.session.TouchFlick(apiElement, api.XYOffset{X: xOffset, Y: yOffset}, api.ScalarSpeed(speed)); err != nil {
 		return fmt.Errorf("failed to flick finger on %s: %s", s, err)
 	}
 	return nil
@@ -239,20 +276,92 @@ func (s *Selection) FlickFinger(xOffset, yOffset int, speed uint) error {
 func (s *Selection) ScrollFinger(xOffset, yOffset int) error {
 	selectedElement, err := s.elements.GetExactlyOne()
 	if err != nil {
-		return fmt.Errorf("failed to select element from %s: %s", s, err)
+		return selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	apiElement, err := element.Unwrap(selectedElement)
+	if err != nil {
+		return fmt.Errorf("failed to scroll finger on %s: %s", s, err)
 	}
 
-	if err := s.session.TouchScroll(selectedElement.(*api.Element), api.XYOffset{X: xOffset, Y: yOffset}); err != nil {
+	s.cache.Invalidate()
+	if err := s.session.TouchScroll(apiElement, api.XYOffset{X: xOffset, Y: yOffset}); err != nil {
 		return fmt.Errorf("failed to scroll finger on %s: %s", s, err)
 	}
 	return nil
 }
 
-func (s *Selection) SendKeys(key string) error {
+// SendKeys types the provided text and keys into all of the elements that the
+// selection refers to. Special keys such as keys.Enter or keys.Control are
+// provided by the keys package. Modifier keys remain pressed until the end of
+// the key sequence or until keys.Null, so keyboard shortcuts may be sent as:
+//    selection.SendKeys(keys.Control, "a")
+func (s *Selection) SendKeys(text ...string) error {
 	return s.forEachElement(func(selectedElement element.Element) error {
-		if err := selectedElement.Value(key); err != nil {
-			return fmt.Errorf("failed to send key %s on %s: %s", key, s, err)
+		if err := selectedElement.SendKeys(text...); err != nil {
+			return fmt.Errorf("failed to send keys to %s: %s", s, err)
 		}
 		return nil
 	})
 }
+
+const hoverScript = `
+	var rect = arguments[0].getBoundingClientRect();
+	var init = {bubbles: true, cancelable: true, view: window,
+		clientX: rect.left + rect.width / 2, clientY: rect.top + rect.height / 2};
+	arguments[0].dispatchEvent(new MouseEvent("mouseover", init));
+	arguments[0].dispatchEvent(new MouseEvent("mouseenter", {bubbles: false, view: window,
+		clientX: init.clientX, clientY: init.clientY}));
+	arguments[0].dispatchEvent(new MouseEvent("mousemove", init));
+`
+
+// Hover moves the mouse over exactly one element in the selection. If the
+// WebDriver fails to move the mouse, mouseover, mouseenter, and mousemove
+// events are dispatched to the element instead, though CSS :hover styles
+// are not applied in that case.
+func (s *Selection) Hover() error {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	apiElement, err := element.Unwrap(selectedElement)
+	if err != nil {
+		return fmt.Errorf("failed to hover over %s: %s", s, err)
+	}
+
+	s.cache.Invalidate()
+	moveErr := s.session.MoveTo(apiElement, nil)
+	if moveErr == nil {
+		return nil
+	}
+
+	if err := selectedElement.Execute(hoverScript, nil, nil); err != nil {
+		return fmt.Errorf("failed to hover over %s: %s", s, moveErr)
+	}
+	return nil
+}
+
+// Focus focuses exactly one element in the selection.
+func (s *Selection) Focus() error {
+	return s.runElementScript("arguments[0].focus();", "focus")
+}
+
+// Blur removes focus from exactly one element in the selection, which
+// triggers any blur and change event handlers of the element.
+func (s *Selection) Blur() error {
+	return s.runElementScript("arguments[0].blur();", "blur")
+}
+
+func (s *Selection) runElementScript(body, name string) error {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	s.cache.Invalidate()
+	if err := selectedElement.Execute(body, nil, nil); err != nil {
+		return fmt.Errorf("failed to %s %s: %s", name, s, err)
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/sclevine/agouti/internal/element"
 	. "github.com/sclevine/agouti/internal/matchers"
 	"github.com/sclevine/agouti/internal/mocks"
+	"github.com/sclevine/agouti/keys"
 )
 
 var _ = Describe("Selection Actions", func() {
@@ -152,6 +153,28 @@ var _ = Describe("Selection Actions", func() {
 		})
 	})
 
+	Describe("#SendKeys", func() {
+		It("should successfully send the keys to each element", func() {
+			Expect(selection.SendKeys(keys.Control, "a")).To(Succeed())
+			Expect(firstElement.SendKeysCall.Text).To(Equal([]string{keys.Control, "a"}))
+			Expect(secondElement.SendKeysCall.Text).To(Equal([]string{keys.Control, "a"}))
+		})
+
+		Context("when zero elements are returned", func() {
+			It("should return an error", func() {
+				elementRepository.GetAtLeastOneCall.Err = errors.New("some error")
+				Expect(selection.SendKeys("text")).To(MatchError("failed to select elements from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when sending keys to any element fails", func() {
+			It("should return an error", func() {
+				secondElement.SendKeysCall.Err = errors.New("some error")
+				Expect(selection.SendKeys("text")).To(MatchError("failed to send keys to selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
 	Describe("#UploadFile", func() {
 		BeforeEach(func() {
 			firstElement.GetAttributeCall.ReturnValue = "file"
@@ -166,6 +189,37 @@ var _ = Describe("Selection Actions", func() {
 			Expect(secondElement.ValueCall.Text).To(HaveSuffix(filepath.Join("agouti", "some-file")))
 		})
 
+		It("should attempt to upload the file to the machine running the browser", func() {
+			Expect(selection.UploadFile("some-file")).To(Succeed())
+			Expect(session.UploadFileCall.Filename).To(HaveSuffix(filepath.Join("agouti", "some-file")))
+		})
+
+		Context("when the file is uploaded to the machine running the browser", func() {
+			It("should enter the remote file path into each element", func() {
+				session.UploadFileCall.ReturnRemotePath = "/remote/some-file"
+				Expect(selection.UploadFile("some-file")).To(Succeed())
+				Expect(firstElement.ValueCall.Text).To(Equal("/remote/some-file"))
+				Expect(secondElement.ValueCall.Text).To(Equal("/remote/some-file"))
+			})
+		})
+
+		Context("when the WebDriver does not accept file uploads", func() {
+			It("should enter the local file path into each element", func() {
+				session.UploadFileCall.Err = &api.Error{StatusCode: 404, Code: api.ErrorUnknownCommand}
+				Expect(selection.UploadFile("some-file")).To(Succeed())
+				Expect(firstElement.ValueCall.Text).To(HaveSuffix(filepath.Join("agouti", "some-file")))
+				Expect(secondElement.ValueCall.Text).To(HaveSuffix(filepath.Join("agouti", "some-file")))
+			})
+		})
+
+		Context("when the file fails to upload", func() {
+			It("should return an error without entering a path into any element", func() {
+				session.UploadFileCall.Err = errors.New("some error")
+				Expect(selection.UploadFile("some-file")).To(MatchError("failed to upload file for selection 'CSS: #selector': some error"))
+				Expect(firstElement.ValueCall.Text).To(BeEmpty())
+			})
+		})
+
 		It("should request the 'type' attribute for each element", func() {
 			Expect(selection.UploadFile("some-file")).To(Succeed())
 			Expect(firstElement.GetAttributeCall.Attribute).To(Equal("type"))
@@ -201,6 +255,12 @@ var _ = Describe("Selection Actions", func() {
 				err := selection.UploadFile("some-file")
 				Expect(err).To(MatchError("element for selection 'CSS: #selector' is not a file uploader"))
 			})
+
+			It("should not upload the file", func() {
+				secondElement.GetAttributeCall.ReturnValue = "notfile"
+				selection.UploadFile("some-file")
+				Expect(session.UploadFileCall.Filename).To(BeEmpty())
+			})
 		})
 
 		Context("when the type attribute of any element is not retrievable", func() {
@@ -536,4 +596,93 @@ var _ = Describe("Selection Actions", func() {
 			})
 		})
 	})
+
+	Describe("#Hover", func() {
+		var (
+			elementBus   *mocks.Bus
+			firstElement *api.Element
+		)
+
+		BeforeEach(func() {
+			elementBus = &mocks.Bus{}
+			firstElement = &api.Element{ID: "some-id", Session: &api.Session{Bus: elementBus}}
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully move the mouse to the selected element", func() {
+			Expect(selection.Hover()).To(Succeed())
+			Expect(session.MoveToCall.Element).To(ExactlyEqual(firstElement))
+			Expect(session.MoveToCall.Offset).To(BeNil())
+			Expect(elementBus.SendCall.Endpoint).To(BeEmpty())
+		})
+
+		Context("when exactly one element is not returned", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				Expect(selection.Hover()).To(MatchError("failed to select element from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when moving the mouse fails", func() {
+			BeforeEach(func() {
+				session.MoveToCall.Err = errors.New("some error")
+			})
+
+			It("should dispatch mouse events to the element instead", func() {
+				Expect(selection.Hover()).To(Succeed())
+				Expect(elementBus.SendCall.Endpoint).To(Equal("execute"))
+				Expect(elementBus.SendCall.BodyJSON).To(ContainSubstring("mouseover"))
+			})
+
+			Context("when dispatching the mouse events also fails", func() {
+				It("should return the original error", func() {
+					elementBus.SendCall.Err = errors.New("some other error")
+					Expect(selection.Hover()).To(MatchError("failed to hover over selection 'CSS: #selector': some error"))
+				})
+			})
+		})
+	})
+
+	Describe("#Focus", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully focus the selected element", func() {
+			Expect(selection.Focus()).To(Succeed())
+			Expect(firstElement.ExecuteCall.Body).To(Equal("arguments[0].focus();"))
+		})
+
+		Context("when exactly one element is not returned", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				Expect(selection.Focus()).To(MatchError("failed to select element from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				firstElement.ExecuteCall.Err = errors.New("some error")
+				Expect(selection.Focus()).To(MatchError("failed to focus selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#Blur", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully blur the selected element", func() {
+			Expect(selection.Blur()).To(Succeed())
+			Expect(firstElement.ExecuteCall.Body).To(Equal("arguments[0].blur();"))
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				firstElement.ExecuteCall.Err = errors.New("some error")
+				Expect(selection.Blur()).To(MatchError("failed to blur selection 'CSS: #selector': some error"))
+			})
+		})
+	})
 })
@@ -0,0 +1,156 @@
+package agouti
+
+import (
+	"fmt"
+
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
+)
+
+// Native input actions do not trigger HTML5 drag-and-drop event handlers in
+// most browsers, so drags of draggable elements are simulated by dispatching
+// drag events with a shared DataTransfer. The drop target is the provided
+// element or the element at the offset from the center of the source.
+const html5DragScript = `
+	var source = arguments[0], target = arguments[1];
+	var sourceRect = source.getBoundingClientRect();
+	var fromX = sourceRect.left + sourceRect.width / 2;
+	var fromY = sourceRect.top + sourceRect.height / 2;
+	var toX = fromX + arguments[2], toY = fromY + arguments[3];
+	if (target) {
+		var targetRect = target.getBoundingClientRect();
+		toX = targetRect.left + targetRect.width / 2;
+		toY = targetRect.top + targetRect.height / 2;
+	} else {
+		target = document.elementFromPoint(toX, toY);
+	}
+	if (!target) {
+		throw new Error("no element found at drop position");
+	}
+	var dataTransfer;
+	try {
+		dataTransfer = new DataTransfer();
+	} catch (e) {
+		dataTransfer = {
+			data: {}, types: [], files: [], items: [], dropEffect: "move", effectAllowed: "all",
+			setData: function(type, value) {
+				this.data[type] = value;
+				if (this.types.indexOf(type) < 0) this.types.push(type);
+			},
+			getData: function(type) { return this.data[type] || ""; },
+			clearData: function(type) {
+				if (type) { delete this.data[type]; this.types = this.types.filter(function(t) { return t !== type; }); }
+				else { this.data = {}; this.types = []; }
+			},
+			setDragImage: function() {}
+		};
+	}
+	var fire = function(element, type, x, y) {
+		var event = document.createEvent("Event");
+		event.initEvent(type, true, true);
+		Object.defineProperty(event, "dataTransfer", {value: dataTransfer});
+		Object.defineProperty(event, "clientX", {value: x});
+		Object.defineProperty(event, "clientY", {value: y});
+		return element.dispatchEvent(event);
+	};
+	fire(source, "dragstart", fromX, fromY);
+	fire(target, "dragenter", toX, toY);
+	fire(target, "dragover", toX, toY);
+	fire(target, "drop", toX, toY);
+	fire(source, "dragend", toX, toY);
+`
+
+// DragTo drags exactly one element to the center of exactly one element in the
+// provided *Selection or *MultiSelection using the left mouse button. If the
+// dragged element has draggable="true", the drag is simulated by dispatching
+// HTML5 drag-and-drop events instead, as native input actions do not trigger
+// HTML5 drag-and-drop event handlers.
+func (s *Selection) DragTo(target interface{}) error {
+	targetSelection, err := toSelection(target)
+	if err != nil {
+		return err
+	}
+
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	targetElement, err := targetSelection.elements.GetExactlyOne()
+	if err != nil {
+		return selectionError("failed to select element from %s: %s", targetSelection, err)
+	}
+
+	apiElement, err := element.Unwrap(selectedElement)
+	if err != nil {
+		return fmt.Errorf("failed to drag %s to %s: %s", s, targetSelection, err)
+	}
+	targetAPIElement, err := element.Unwrap(targetElement)
+	if err != nil {
+		return fmt.Errorf("failed to drag %s to %s: %s", s, targetSelection, err)
+	}
+
+	s.cache.Invalidate()
+	html5, err := s.isDraggable(selectedElement)
+	if err != nil {
+		return err
+	}
+
+	if html5 {
+		err = selectedElement.Execute(html5DragScript, []interface{}{targetAPIElement, 0, 0}, nil)
+	} else {
+		err = s.session.PerformActions(api.NewActions().
+			PointerMove(apiElement, 0, 0).
+			PointerDown(api.LeftButton).
+			PointerMove(targetAPIElement, 0, 0).
+			PointerUp(api.LeftButton))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to drag %s to %s: %s", s, targetSelection, err)
+	}
+	return nil
+}
+
+// DragBy drags exactly one element by the provided offset in pixels using the
+// left mouse button. Elements with draggable="true" are dropped on the element
+// at the offset using simulated HTML5 drag-and-drop events, as described by
+// DragTo.
+func (s *Selection) DragBy(xOffset, yOffset int) error {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	apiElement, err := element.Unwrap(selectedElement)
+	if err != nil {
+		return fmt.Errorf("failed to drag %s: %s", s, err)
+	}
+
+	s.cache.Invalidate()
+	html5, err := s.isDraggable(selectedElement)
+	if err != nil {
+		return err
+	}
+
+	if html5 {
+		err = selectedElement.Execute(html5DragScript, []interface{}{nil, xOffset, yOffset}, nil)
+	} else {
+		err = s.session.PerformActions(api.NewActions().
+			PointerMove(apiElement, 0, 0).
+			PointerDown(api.LeftButton).
+			PointerMoveBy(xOffset, yOffset).
+			PointerUp(api.LeftButton))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to drag %s: %s", s, err)
+	}
+	return nil
+}
+
+func (s *Selection) isDraggable(selectedElement element.Element) (bool, error) {
+	draggable, err := selectedElement.GetAttribute("draggable")
+	if err != nil {
+		return false, fmt.Errorf("failed to retrieve draggable attribute of %s: %s", s, err)
+	}
+	return draggable == "true", nil
+}
@@ -0,0 +1,113 @@
+package agouti_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Selection Dragging", func() {
+	var (
+		session          *mocks.Session
+		sourceBus        *mocks.Bus
+		sourceElement    *api.Element
+		targetElement    *api.Element
+		sourceRepository *mocks.ElementRepository
+		targetRepository *mocks.ElementRepository
+		selection        *Selection
+		target           *Selection
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		sourceBus = &mocks.Bus{}
+		sourceElement = &api.Element{ID: "source", Session: &api.Session{Bus: sourceBus}}
+		targetElement = &api.Element{ID: "target", Session: &api.Session{Bus: &mocks.Bus{}}}
+		sourceRepository = &mocks.ElementRepository{}
+		sourceRepository.GetExactlyOneCall.ReturnElement = sourceElement
+		targetRepository = &mocks.ElementRepository{}
+		targetRepository.GetExactlyOneCall.ReturnElement = targetElement
+		selection = NewTestSelection(session, sourceRepository, "#source")
+		target = NewTestSelection(session, targetRepository, "#target")
+	})
+
+	Describe("#DragTo", func() {
+		It("should drag the element to the target using input actions", func() {
+			Expect(selection.DragTo(target)).To(Succeed())
+			Expect(sourceBus.SendCall.Endpoint).To(Equal("element/source/attribute/draggable"))
+			Expect(session.PerformActionsCall.Actions).To(Equal(api.NewActions().
+				PointerMove(sourceElement, 0, 0).
+				PointerDown(api.LeftButton).
+				PointerMove(targetElement, 0, 0).
+				PointerUp(api.LeftButton)))
+		})
+
+		Context("when the element is draggable", func() {
+			It("should simulate HTML5 drag-and-drop events", func() {
+				sourceBus.SendCall.Result = `"true"`
+				Expect(selection.DragTo(target)).To(Succeed())
+				Expect(session.PerformActionsCall.Actions).To(BeNil())
+				Expect(sourceBus.SendCall.Endpoint).To(Equal("execute"))
+				Expect(sourceBus.SendCall.BodyJSON).To(ContainSubstring(`"args":[{"ELEMENT":"source"},{"ELEMENT":"target"},0,0]`))
+				Expect(sourceBus.SendCall.BodyJSON).To(ContainSubstring("dragstart"))
+			})
+		})
+
+		Context("when the target is not a selection", func() {
+			It("should return an error", func() {
+				Expect(selection.DragTo("#target")).To(MatchError("must be *Selection or *MultiSelection"))
+			})
+		})
+
+		Context("when the target element cannot be selected", func() {
+			It("should return an error", func() {
+				targetRepository.GetExactlyOneCall.Err = errors.New("some error")
+				Expect(selection.DragTo(target)).To(MatchError("failed to select element from selection 'CSS: #target [single]': some error"))
+			})
+		})
+
+		Context("when the drag fails", func() {
+			It("should return an error", func() {
+				session.PerformActionsCall.Err = errors.New("some error")
+				Expect(selection.DragTo(target)).To(MatchError("failed to drag selection 'CSS: #source [single]' to selection 'CSS: #target [single]': some error"))
+			})
+		})
+	})
+
+	Describe("#DragBy", func() {
+		It("should drag the element by the provided offset using input actions", func() {
+			Expect(selection.DragBy(10, 20)).To(Succeed())
+			Expect(session.PerformActionsCall.Actions).To(Equal(api.NewActions().
+				PointerMove(sourceElement, 0, 0).
+				PointerDown(api.LeftButton).
+				PointerMoveBy(10, 20).
+				PointerUp(api.LeftButton)))
+		})
+
+		Context("when the element is draggable", func() {
+			It("should simulate HTML5 drag-and-drop events at the offset", func() {
+				sourceBus.SendCall.Result = `"true"`
+				Expect(selection.DragBy(10, 20)).To(Succeed())
+				Expect(sourceBus.SendCall.BodyJSON).To(ContainSubstring(`"args":[{"ELEMENT":"source"},null,10,20]`))
+			})
+		})
+
+		Context("when the element cannot be selected", func() {
+			It("should return an error", func() {
+				sourceRepository.GetExactlyOneCall.Err = errors.New("some error")
+				Expect(selection.DragBy(10, 20)).To(MatchError("failed to select element from selection 'CSS: #source [single]': some error"))
+			})
+		})
+
+		Context("when the draggable attribute cannot be retrieved", func() {
+			It("should return an error", func() {
+				sourceBus.SendCall.Err = errors.New("some error")
+				Expect(selection.DragBy(10, 20)).To(MatchError("failed to retrieve draggable attribute of selection 'CSS: #source [single]': some error"))
+			})
+		})
+	})
+})
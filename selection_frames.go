@@ -3,7 +3,8 @@ package agouti
 import (
 	"fmt"
 
-	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
+	"github.com/sclevine/agouti/internal/target"
 )
 
 // SwitchToFrame focuses on the frame specified by the selection. All new and
@@ -11,12 +12,32 @@ import (
 // will apply to this frame as well.
 func (s *Selection) SwitchToFrame() error {
 	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	apiElement, err := element.Unwrap(selectedElement)
 	if err != nil {
 		return fmt.Errorf("failed to select element from %s: %s", s, err)
 	}
 
-	if err := s.session.Frame(selectedElement.(*api.Element)); err != nil {
+	s.cache.Invalidate()
+	if err := s.session.Frame(apiElement); err != nil {
 		return fmt.Errorf("failed to switch to frame referred to by %s: %s", s, err)
 	}
 	return nil
 }
+
+// Frame returns a selection of the root element of the document in the frame
+// specified by the selection. Selections found within the returned selection
+// remember the frame, and switch to it (and to each of its parent frames)
+// before they are retrieved, so they may be used without calling
+// SwitchToFrame. After such a selection is retrieved, all further Page methods
+// apply to its frame until another frame is switched to.
+//
+// Example:
+//    editor := page.Find("iframe#editor").Frame()
+//    editor.Find("button.bold").Click()
+func (s *Selection) Frame() *Selection {
+	return newSelection(s.session, s.selectors.Append(target.Frame, "").Single(), s.staleRetries, s.testIDAttribute, s.cache)
+}
@@ -53,4 +53,23 @@ var _ = Describe("Selection Frames", func() {
 			})
 		})
 	})
+
+	Describe("#Frame", func() {
+		It("should select the root element of the frame document", func() {
+			Expect(selection.Frame().String()).To(Equal("selection 'CSS: #selector [single] | Frame [single]'"))
+		})
+
+		It("should select elements within the frame document", func() {
+			Expect(selection.Frame().Find("button").String()).To(Equal("selection 'CSS: #selector [single] | Frame [single] | CSS: button [single]'"))
+		})
+
+		It("should switch to the frame before retrieving elements within it", func() {
+			frame := &api.Element{ID: "frame"}
+			session.GetElementsCall.ReturnElements = []*api.Element{frame}
+			frameSelection := NewTestPage(session).Find("iframe").Frame()
+			Expect(frameSelection.Count()).To(Equal(1))
+			Expect(session.FrameCall.Frame).To(ExactlyEqual(frame))
+			Expect(session.GetElementsCall.Selector).To(Equal(api.Selector{Using: "css selector", Value: ":root"}))
+		})
+	})
 })
@@ -0,0 +1,98 @@
+package agouti
+
+import (
+	"fmt"
+
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
+)
+
+const boundingRectScript = `
+	var rect = arguments[0].getBoundingClientRect();
+	return {x: rect.left, y: rect.top, width: rect.width, height: rect.height};
+`
+
+const inViewportScript = `
+	var rect = arguments[0].getBoundingClientRect();
+	var width = window.innerWidth || document.documentElement.clientWidth;
+	var height = window.innerHeight || document.documentElement.clientHeight;
+	return rect.width > 0 && rect.height > 0 &&
+		rect.right > 0 && rect.bottom > 0 && rect.left < width && rect.top < height;
+`
+
+const zIndexAboveScript = `
+	var element = arguments[0], other = arguments[1];
+	var rect = element.getBoundingClientRect(), otherRect = other.getBoundingClientRect();
+	var left = Math.max(rect.left, otherRect.left), right = Math.min(rect.right, otherRect.right);
+	var top = Math.max(rect.top, otherRect.top), bottom = Math.min(rect.bottom, otherRect.bottom);
+	if (left >= right || top >= bottom) {
+		return false;
+	}
+	var topmost = document.elementFromPoint((left + right) / 2, (top + bottom) / 2);
+	return topmost !== null && (element === topmost || element.contains(topmost)) &&
+		!(element !== other && element.contains(other) && other.contains(topmost));
+`
+
+// BoundingRect returns the position and size of exactly one element in pixels,
+// relative to the top-left corner of the viewport.
+func (s *Selection) BoundingRect() (api.Rect, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return api.Rect{}, selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	var rect api.Rect
+	if err := selectedElement.Execute(boundingRectScript, nil, &rect); err != nil {
+		return api.Rect{}, fmt.Errorf("failed to retrieve bounding rectangle of %s: %s", s, err)
+	}
+	return rect, nil
+}
+
+// IsInViewport returns true if any part of exactly one element is within the
+// viewport. Elements with no width or height are never in the viewport.
+func (s *Selection) IsInViewport() (bool, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return false, selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	var inViewport bool
+	if err := selectedElement.Execute(inViewportScript, nil, &inViewport); err != nil {
+		return false, fmt.Errorf("failed to determine whether %s is in the viewport: %s", s, err)
+	}
+	return inViewport, nil
+}
+
+// ZIndexAbove returns true if exactly one element is rendered above exactly one
+// element in the provided *Selection or *MultiSelection where they overlap.
+// The element must be (or contain) the topmost element at the center of the
+// overlap, which must be within the viewport. Returns false if the elements
+// do not overlap.
+func (s *Selection) ZIndexAbove(other interface{}) (bool, error) {
+	otherSelection, err := toSelection(other)
+	if err != nil {
+		return false, err
+	}
+
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return false, selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	otherElement, err := otherSelection.elements.GetExactlyOne()
+	if err != nil {
+		return false, selectionError("failed to select element from %s: %s", otherSelection, err)
+	}
+
+	otherAPIElement, err := element.Unwrap(otherElement)
+	if err != nil {
+		return false, fmt.Errorf("failed to select element from %s: %s", otherSelection, err)
+	}
+
+	var above bool
+	arguments := []interface{}{otherAPIElement}
+	if err := selectedElement.Execute(zIndexAboveScript, arguments, &above); err != nil {
+		return false, fmt.Errorf("failed to compare %s to %s: %s", s, otherSelection, err)
+	}
+	return above, nil
+}
@@ -0,0 +1,112 @@
+package agouti_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Selection Geometry", func() {
+	var (
+		selection         *Selection
+		elementRepository *mocks.ElementRepository
+		selectedElement   *mocks.Element
+	)
+
+	BeforeEach(func() {
+		elementRepository = &mocks.ElementRepository{}
+		selectedElement = &mocks.Element{}
+		elementRepository.GetExactlyOneCall.ReturnElement = selectedElement
+		selection = NewTestSelection(&mocks.Session{}, elementRepository, "#selector")
+	})
+
+	Describe("#BoundingRect", func() {
+		It("should return the bounding rectangle of the element", func() {
+			selectedElement.ExecuteCall.Result = `{"x": 10.5, "y": 20, "width": 30, "height": 40}`
+			Expect(selection.BoundingRect()).To(Equal(api.Rect{X: 10.5, Y: 20, Width: 30, Height: 40}))
+			Expect(selectedElement.ExecuteCall.Body).To(ContainSubstring("getBoundingClientRect()"))
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.BoundingRect()
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #selector [single]': some error"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				selectedElement.ExecuteCall.Err = errors.New("some error")
+				_, err := selection.BoundingRect()
+				Expect(err).To(MatchError("failed to retrieve bounding rectangle of selection 'CSS: #selector [single]': some error"))
+			})
+		})
+	})
+
+	Describe("#IsInViewport", func() {
+		It("should return whether the element is in the viewport", func() {
+			selectedElement.ExecuteCall.Result = "true"
+			Expect(selection.IsInViewport()).To(BeTrue())
+			selectedElement.ExecuteCall.Result = "false"
+			Expect(selection.IsInViewport()).To(BeFalse())
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				selectedElement.ExecuteCall.Err = errors.New("some error")
+				_, err := selection.IsInViewport()
+				Expect(err).To(MatchError("failed to determine whether selection 'CSS: #selector [single]' is in the viewport: some error"))
+			})
+		})
+	})
+
+	Describe("#ZIndexAbove", func() {
+		var (
+			otherRepository *mocks.ElementRepository
+			otherElement    *api.Element
+			other           *Selection
+		)
+
+		BeforeEach(func() {
+			otherRepository = &mocks.ElementRepository{}
+			otherElement = &api.Element{ID: "other"}
+			otherRepository.GetExactlyOneCall.ReturnElement = otherElement
+			other = NewTestSelection(&mocks.Session{}, otherRepository, "#other")
+		})
+
+		It("should return whether the element is rendered above the other element", func() {
+			selectedElement.ExecuteCall.Result = "true"
+			Expect(selection.ZIndexAbove(other)).To(BeTrue())
+			Expect(selectedElement.ExecuteCall.Body).To(ContainSubstring("elementFromPoint"))
+			Expect(selectedElement.ExecuteCall.Arguments).To(Equal([]interface{}{otherElement}))
+		})
+
+		Context("when the other value is not a selection", func() {
+			It("should return an error", func() {
+				_, err := selection.ZIndexAbove("#other")
+				Expect(err).To(MatchError("must be *Selection or *MultiSelection"))
+			})
+		})
+
+		Context("when the other element cannot be selected", func() {
+			It("should return an error", func() {
+				otherRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.ZIndexAbove(other)
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #other [single]': some error"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				selectedElement.ExecuteCall.Err = errors.New("some error")
+				_, err := selection.ZIndexAbove(other)
+				Expect(err).To(MatchError("failed to compare selection 'CSS: #selector [single]' to selection 'CSS: #other [single]': some error"))
+			})
+		})
+	})
+})
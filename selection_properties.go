@@ -10,7 +10,7 @@ import (
 func (s *Selection) Text() (string, error) {
 	selectedElement, err := s.elements.GetExactlyOne()
 	if err != nil {
-		return "", fmt.Errorf("failed to select element from %s: %s", s, err)
+		return "", selectionError("failed to select element from %s: %s", s, err)
 	}
 
 	text, err := selectedElement.GetText()
@@ -24,7 +24,7 @@ func (s *Selection) Text() (string, error) {
 func (s *Selection) Active() (bool, error) {
 	selectedElement, err := s.elements.GetExactlyOne()
 	if err != nil {
-		return false, fmt.Errorf("failed to select element from %s: %s", s, err)
+		return false, selectionError("failed to select element from %s: %s", s, err)
 	}
 
 	activeElement, err := s.session.GetActiveElement()
@@ -40,12 +40,58 @@ func (s *Selection) Active() (bool, error) {
 	return equal, nil
 }
 
+// Value returns the current value of exactly one form element. Unlike the
+// value attribute, the value reflects any text entered into the element.
+func (s *Selection) Value() (string, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return "", selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	var value string
+	if err := selectedElement.Execute("return arguments[0].value;", nil, &value); err != nil {
+		return "", fmt.Errorf("failed to retrieve value for %s: %s", s, err)
+	}
+	return value, nil
+}
+
+// Classes returns the classes in the class list of exactly one element.
+func (s *Selection) Classes() ([]string, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return nil, selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	classes := []string{}
+	if err := selectedElement.Execute("return Array.prototype.slice.call(arguments[0].classList);", nil, &classes); err != nil {
+		return nil, fmt.Errorf("failed to retrieve classes for %s: %s", s, err)
+	}
+	return classes, nil
+}
+
+// HasClass returns true if the class list of exactly one element contains
+// the provided class. Unlike matching the class attribute, a class is never
+// matched by part of another class (ex. "active" does not match "inactive").
+func (s *Selection) HasClass(class string) (bool, error) {
+	classes, err := s.Classes()
+	if err != nil {
+		return false, err
+	}
+
+	for _, actualClass := range classes {
+		if actualClass == class {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 type propertyMethod func(element element.Element, property string) (string, error)
 
 func (s *Selection) hasProperty(method propertyMethod, property, name string) (string, error) {
 	selectedElement, err := s.elements.GetExactlyOne()
 	if err != nil {
-		return "", fmt.Errorf("failed to select element from %s: %s", s, err)
+		return "", selectionError("failed to select element from %s: %s", s, err)
 	}
 
 	value, err := method(selectedElement, property)
@@ -70,7 +116,7 @@ type stateMethod func(element element.Element) (bool, error)
 func (s *Selection) hasState(method stateMethod, name string) (bool, error) {
 	elements, err := s.elements.GetAtLeastOne()
 	if err != nil {
-		return false, fmt.Errorf("failed to select elements from %s: %s", s, err)
+		return false, selectionError("failed to select elements from %s: %s", s, err)
 	}
 
 	for _, selectedElement := range elements {
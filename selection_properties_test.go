@@ -56,6 +56,82 @@ var _ = Describe("Selection Properties", func() {
 		})
 	})
 
+	Describe("#Value", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully return the value property", func() {
+			firstElement.ExecuteCall.Result = `"some value"`
+			Expect(selection.Value()).To(Equal("some value"))
+			Expect(firstElement.ExecuteCall.Body).To(Equal("return arguments[0].value;"))
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.Value()
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the value cannot be retrieved", func() {
+			It("should return an error", func() {
+				firstElement.ExecuteCall.Err = errors.New("some error")
+				_, err := selection.Value()
+				Expect(err).To(MatchError("failed to retrieve value for selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#Classes", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully return the class list", func() {
+			firstElement.ExecuteCall.Result = `["some-class", "some-other-class"]`
+			Expect(selection.Classes()).To(Equal([]string{"some-class", "some-other-class"}))
+			Expect(firstElement.ExecuteCall.Body).To(ContainSubstring("arguments[0].classList"))
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.Classes()
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the class list cannot be retrieved", func() {
+			It("should return an error", func() {
+				firstElement.ExecuteCall.Err = errors.New("some error")
+				_, err := selection.Classes()
+				Expect(err).To(MatchError("failed to retrieve classes for selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#HasClass", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+			firstElement.ExecuteCall.Result = `["inactive", "some-class"]`
+		})
+
+		It("should return true only when the class list contains the provided class", func() {
+			Expect(selection.HasClass("some-class")).To(BeTrue())
+			Expect(selection.HasClass("active")).To(BeFalse())
+		})
+
+		Context("when the class list cannot be retrieved", func() {
+			It("should return an error", func() {
+				firstElement.ExecuteCall.Err = errors.New("some error")
+				_, err := selection.HasClass("some-class")
+				Expect(err).To(MatchError("failed to retrieve classes for selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
 	Describe("#Active", func() {
 		BeforeEach(func() {
 			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
@@ -0,0 +1,76 @@
+package agouti
+
+import "github.com/sclevine/agouti/internal/target"
+
+// FindNear finds the element matching the provided CSS selector that is
+// closest to the selection, within 50 pixels of its edges. Like all
+// relative selectors, elements anywhere in the page may match, and they are
+// located using their rects, which requires a W3C-compatible WebDriver.
+func (s *Selection) FindNear(selector string) *Selection {
+	return s.findRelative(target.Near, selector)
+}
+
+// FindAbove finds the element matching the provided CSS selector that is
+// closest to the selection, among the elements entirely above it.
+//
+// Example:
+//    page.FindByLabel("Password").FindAbove("input")
+func (s *Selection) FindAbove(selector string) *Selection {
+	return s.findRelative(target.Above, selector)
+}
+
+// FindBelow finds the element matching the provided CSS selector that is
+// closest to the selection, among the elements entirely below it.
+func (s *Selection) FindBelow(selector string) *Selection {
+	return s.findRelative(target.Below, selector)
+}
+
+// FindLeftOf finds the element matching the provided CSS selector that is
+// closest to the selection, among the elements entirely left of it.
+func (s *Selection) FindLeftOf(selector string) *Selection {
+	return s.findRelative(target.LeftOf, selector)
+}
+
+// FindRightOf finds the element matching the provided CSS selector that is
+// closest to the selection, among the elements entirely right of it.
+func (s *Selection) FindRightOf(selector string) *Selection {
+	return s.findRelative(target.RightOf, selector)
+}
+
+// AllNear finds zero or more elements matching the provided CSS selector
+// within 50 pixels of the selection, ordered from closest to farthest.
+func (s *Selection) AllNear(selector string) *MultiSelection {
+	return s.allRelative(target.Near, selector)
+}
+
+// AllAbove finds zero or more elements matching the provided CSS selector
+// that are above the selection, ordered from closest to farthest.
+func (s *Selection) AllAbove(selector string) *MultiSelection {
+	return s.allRelative(target.Above, selector)
+}
+
+// AllBelow finds zero or more elements matching the provided CSS selector
+// that are below the selection, ordered from closest to farthest.
+func (s *Selection) AllBelow(selector string) *MultiSelection {
+	return s.allRelative(target.Below, selector)
+}
+
+// AllLeftOf finds zero or more elements matching the provided CSS selector
+// that are left of the selection, ordered from closest to farthest.
+func (s *Selection) AllLeftOf(selector string) *MultiSelection {
+	return s.allRelative(target.LeftOf, selector)
+}
+
+// AllRightOf finds zero or more elements matching the provided CSS selector
+// that are right of the selection, ordered from closest to farthest.
+func (s *Selection) AllRightOf(selector string) *MultiSelection {
+	return s.allRelative(target.RightOf, selector)
+}
+
+func (s *Selection) findRelative(relation target.Type, selector string) *Selection {
+	return newSelection(s.session, s.selectors.Append(relation, selector).At(0), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+func (s *Selection) allRelative(relation target.Type, selector string) *MultiSelection {
+	return newMultiSelection(s.session, s.selectors.Append(relation, selector), s.staleRetries, s.testIDAttribute, s.cache)
+}
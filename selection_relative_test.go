@@ -0,0 +1,40 @@
+package agouti_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Selection Relative Locators", func() {
+	var selection *Selection
+
+	BeforeEach(func() {
+		selection = NewTestSelection(&mocks.Session{}, &mocks.ElementRepository{}, "#selector")
+	})
+
+	Describe("#FindNear, #FindAbove, #FindBelow, #FindLeftOf, and #FindRightOf", func() {
+		It("should select the closest element in the provided direction", func() {
+			Expect(selection.FindNear("input").String()).To(Equal(`selection 'CSS: #selector [single] | Near: input [0]'`))
+			Expect(selection.FindAbove("input").String()).To(Equal(`selection 'CSS: #selector [single] | Above: input [0]'`))
+			Expect(selection.FindBelow("input").String()).To(Equal(`selection 'CSS: #selector [single] | Below: input [0]'`))
+			Expect(selection.FindLeftOf("input").String()).To(Equal(`selection 'CSS: #selector [single] | Left Of: input [0]'`))
+			Expect(selection.FindRightOf("input").String()).To(Equal(`selection 'CSS: #selector [single] | Right Of: input [0]'`))
+		})
+	})
+
+	Describe("#AllNear, #AllAbove, #AllBelow, #AllLeftOf, and #AllRightOf", func() {
+		It("should select all elements in the provided direction", func() {
+			Expect(selection.AllNear("input").String()).To(Equal(`selection 'CSS: #selector [single] | Near: input'`))
+			Expect(selection.AllAbove("input").String()).To(Equal(`selection 'CSS: #selector [single] | Above: input'`))
+			Expect(selection.AllBelow("input").String()).To(Equal(`selection 'CSS: #selector [single] | Below: input'`))
+			Expect(selection.AllLeftOf("input").String()).To(Equal(`selection 'CSS: #selector [single] | Left Of: input'`))
+			Expect(selection.AllRightOf("input").String()).To(Equal(`selection 'CSS: #selector [single] | Right Of: input'`))
+		})
+
+		It("should allow the relative selection to be refined", func() {
+			Expect(selection.AllBelow("tr").At(1).Find("td").String()).To(Equal(`selection 'CSS: #selector [single] | Below: tr [1] | CSS: td [single]'`))
+		})
+	})
+})
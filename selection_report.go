@@ -0,0 +1,112 @@
+package agouti
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sclevine/agouti/api"
+)
+
+// An ElementReport describes the state of an element that commonly explains
+// why it is not visible, enabled, or focused. See *Selection.Report.
+type ElementReport struct {
+	// Element describes the element using its tag name, ID, and classes
+	// (ex. "button#submit.primary").
+	Element string
+
+	// Rect is the position and size of the element in pixels, relative to
+	// the top-left corner of the viewport.
+	Rect api.Rect
+
+	// Display, Visibility, and Opacity are the computed CSS values of the
+	// element.
+	Display    string
+	Visibility string
+	Opacity    string
+
+	// Disabled is true if the element is a disabled form element.
+	Disabled bool
+
+	// Focused is true if the element is the active element of the document.
+	Focused bool
+
+	// InViewport is true if the center of the element is within the viewport.
+	InViewport bool
+
+	// CoveredBy describes the element at the center of the element, if that
+	// element is neither the element itself nor one of its descendants.
+	CoveredBy string
+}
+
+// String returns a multi-line description of the report.
+func (r *ElementReport) String() string {
+	lines := []string{
+		fmt.Sprintf("element: %s", r.Element),
+		fmt.Sprintf("rect: x=%g y=%g width=%g height=%g", r.Rect.X, r.Rect.Y, r.Rect.Width, r.Rect.Height),
+		fmt.Sprintf("display: %s, visibility: %s, opacity: %s", r.Display, r.Visibility, r.Opacity),
+		fmt.Sprintf("disabled: %t, focused: %t, in viewport: %t", r.Disabled, r.Focused, r.InViewport),
+	}
+	if r.CoveredBy != "" {
+		lines = append(lines, fmt.Sprintf("covered by: %s", r.CoveredBy))
+	}
+	return strings.Join(lines, "\n")
+}
+
+const reportScript = `
+	var element = arguments[0];
+	var describe = function(node) {
+		var description = node.tagName.toLowerCase();
+		if (node.id) {
+			description += "#" + node.id;
+		}
+		for (var i = 0; i < node.classList.length; i++) {
+			description += "." + node.classList[i];
+		}
+		return description;
+	};
+	var style = window.getComputedStyle(element);
+	var rect = element.getBoundingClientRect();
+	var x = rect.left + rect.width / 2, y = rect.top + rect.height / 2;
+	var width = window.innerWidth || document.documentElement.clientWidth;
+	var height = window.innerHeight || document.documentElement.clientHeight;
+	var inViewport = x >= 0 && y >= 0 && x < width && y < height;
+	var topmost = inViewport ? document.elementFromPoint(x, y) : null;
+	return {
+		element: describe(element),
+		rect: {x: rect.left, y: rect.top, width: rect.width, height: rect.height},
+		display: style.display,
+		visibility: style.visibility,
+		opacity: style.opacity,
+		disabled: !!element.disabled,
+		focused: document.activeElement === element,
+		inViewport: inViewport,
+		coveredBy: topmost && topmost !== element && !element.contains(topmost) ? describe(topmost) : ""
+	};
+`
+
+// Report returns an ElementReport for exactly one element. The report is
+// included in the failure messages of matchers such as BeVisible.
+func (s *Selection) Report() (*ElementReport, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return nil, selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	var result struct {
+		Element    string   `json:"element"`
+		Rect       api.Rect `json:"rect"`
+		Display    string   `json:"display"`
+		Visibility string   `json:"visibility"`
+		Opacity    string   `json:"opacity"`
+		Disabled   bool     `json:"disabled"`
+		Focused    bool     `json:"focused"`
+		InViewport bool     `json:"inViewport"`
+		CoveredBy  string   `json:"coveredBy"`
+	}
+	if err := selectedElement.Execute(reportScript, nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to report on %s: %s", s, err)
+	}
+
+	report := ElementReport(result)
+	return &report, nil
+}
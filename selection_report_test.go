@@ -0,0 +1,88 @@
+package agouti_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Selection Report", func() {
+	var (
+		selection         *Selection
+		elementRepository *mocks.ElementRepository
+		selectedElement   *mocks.Element
+	)
+
+	BeforeEach(func() {
+		elementRepository = &mocks.ElementRepository{}
+		selectedElement = &mocks.Element{}
+		elementRepository.GetExactlyOneCall.ReturnElement = selectedElement
+		selection = NewTestSelection(&mocks.Session{}, elementRepository, "#selector")
+	})
+
+	Describe("#Report", func() {
+		It("should return a report describing the element", func() {
+			selectedElement.ExecuteCall.Result = `{
+				"element": "button#submit.primary",
+				"rect": {"x": 10, "y": 20, "width": 30.5, "height": 40},
+				"display": "block",
+				"visibility": "hidden",
+				"opacity": "0.5",
+				"disabled": true,
+				"focused": false,
+				"inViewport": true,
+				"coveredBy": "div#overlay"
+			}`
+			Expect(selection.Report()).To(Equal(&ElementReport{
+				Element:    "button#submit.primary",
+				Rect:       api.Rect{X: 10, Y: 20, Width: 30.5, Height: 40},
+				Display:    "block",
+				Visibility: "hidden",
+				Opacity:    "0.5",
+				Disabled:   true,
+				InViewport: true,
+				CoveredBy:  "div#overlay",
+			}))
+			Expect(selectedElement.ExecuteCall.Body).To(ContainSubstring("document.elementFromPoint(x, y)"))
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.Report()
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #selector [single]': some error"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				selectedElement.ExecuteCall.Err = errors.New("some error")
+				_, err := selection.Report()
+				Expect(err).To(MatchError("failed to report on selection 'CSS: #selector [single]': some error"))
+			})
+		})
+	})
+
+	Describe("ElementReport#String", func() {
+		It("should describe each property of the report on a separate line", func() {
+			report := &ElementReport{
+				Element:    "div#dialog",
+				Rect:       api.Rect{X: 1, Y: 2, Width: 3, Height: 4.5},
+				Display:    "none",
+				Visibility: "visible",
+				Opacity:    "1",
+				Focused:    true,
+			}
+			Expect(report.String()).To(Equal("element: div#dialog\n" +
+				"rect: x=1 y=2 width=3 height=4.5\n" +
+				"display: none, visibility: visible, opacity: 1\n" +
+				"disabled: false, focused: true, in viewport: false"))
+			report.CoveredBy = "div#overlay"
+			Expect(report.String()).To(HaveSuffix("\ncovered by: div#overlay"))
+		})
+	})
+})
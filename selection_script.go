@@ -0,0 +1,83 @@
+package agouti
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/target"
+)
+
+// UnmarshalJSON decodes an element returned by a script, so that *Selection
+// values may be used anywhere within the result of RunScript or
+// RunScriptAsync. The selection refers to exactly the returned element,
+// which is never re-selected, and selections found within it are retrieved
+// relative to that element.
+//
+// Example:
+//    var rows []*agouti.Selection
+//    page.RunScript("return document.querySelectorAll('tr.selected');", nil, &rows)
+//    rows[0].Find("td.name").Text()
+func (s *Selection) UnmarshalJSON(data []byte) error {
+	apiElement := &api.Element{}
+	if err := json.Unmarshal(data, apiElement); err != nil {
+		return err
+	}
+	*s = Selection{selectable: selectable{selectors: target.ElementSelectors(apiElement)}}
+	return nil
+}
+
+// hydrateSelections provides the session and configuration of the page to
+// each *Selection within the result of a script.
+func (p *Page) hydrateSelections(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return
+		}
+		switch pointer := value.Interface().(type) {
+		case *Selection:
+			p.hydrateSelection(pointer)
+		case *api.Element, *api.Session:
+			// elements are provided their session by the api package
+		default:
+			p.hydrateSelections(value.Elem())
+		}
+	case reflect.Interface:
+		if !value.IsNil() {
+			p.hydrateSelections(value.Elem())
+		}
+	case reflect.Struct:
+		if value.Type() == reflect.TypeOf(Selection{}) {
+			if value.CanAddr() {
+				p.hydrateSelections(value.Addr())
+			}
+			return
+		}
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).PkgPath == "" {
+				p.hydrateSelections(value.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			p.hydrateSelections(value.Index(i))
+		}
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			p.hydrateSelections(value.MapIndex(key))
+		}
+	}
+}
+
+func (p *Page) hydrateSelection(selection *Selection) {
+	if selection.session != nil || len(selection.selectors) == 0 {
+		return
+	}
+
+	apiElement := selection.selectors[0].Element
+	if session, ok := p.session.(*api.Session); ok && apiElement.Session == nil {
+		apiElement.Session = session
+	}
+	*selection = *newSelection(p.session, selection.selectors, p.staleRetries, p.testIDAttribute, p.cache)
+}
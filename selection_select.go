@@ -0,0 +1,159 @@
+package agouti
+
+import (
+	"fmt"
+
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
+	"github.com/sclevine/agouti/internal/target"
+)
+
+// A SelectOption describes an <option> element of a <select> element.
+type SelectOption struct {
+	Text     string
+	Value    string
+	Index    int
+	Selected bool
+}
+
+const optionsScript = `
+	return Array.prototype.map.call(arguments[0].options, function(option) {
+		return {text: option.text.trim(), value: option.value, index: option.index, selected: option.selected};
+	});
+`
+
+// SelectByValue may be called on a selection of any number of <select> elements
+// to select any <option> elements under those <select> elements that have the
+// provided value attribute.
+func (s *Selection) SelectByValue(value string) error {
+	optionXPath := fmt.Sprintf(`.//option[@value="%s"]`, value)
+	return s.selectOptions(optionXPath, fmt.Sprintf(`value "%s"`, value))
+}
+
+// SelectByPartialText may be called on a selection of any number of <select>
+// elements to select any <option> elements under those <select> elements that
+// contain the provided text.
+func (s *Selection) SelectByPartialText(text string) error {
+	optionXPath := fmt.Sprintf(`.//option[contains(normalize-space(), "%s")]`, text)
+	return s.selectOptions(optionXPath, fmt.Sprintf(`text containing "%s"`, text))
+}
+
+// SelectByIndex may be called on a selection of any number of <select> elements
+// to select the <option> element at the provided index under each of those
+// <select> elements.
+func (s *Selection) SelectByIndex(index int) error {
+	return s.forEachElement(func(selectedElement element.Element) error {
+		options, err := s.getOptions(selectedElement, ".//option")
+		if err != nil {
+			return err
+		}
+
+		if index < 0 || index >= len(options) {
+			return fmt.Errorf("no option at index %d found for %s", index, s)
+		}
+		return s.setOptionSelected(options[index], true)
+	})
+}
+
+// DeselectAll may be called on a selection of any number of <select multiple>
+// elements to deselect all of the <option> elements under those <select> elements.
+func (s *Selection) DeselectAll() error {
+	return s.forEachElement(func(selectedElement element.Element) error {
+		multiple, err := selectedElement.GetAttribute("multiple")
+		if err != nil {
+			return fmt.Errorf("failed to retrieve multiple attribute of %s: %s", s, err)
+		}
+
+		if multiple == "" || multiple == "false" {
+			return fmt.Errorf("%s does not refer to a multi-select", s)
+		}
+
+		options, err := s.getOptions(selectedElement, ".//option")
+		if err != nil {
+			return err
+		}
+
+		for _, option := range options {
+			if err := s.setOptionSelected(option, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Options returns all of the <option> elements of exactly one <select> element.
+func (s *Selection) Options() ([]SelectOption, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return nil, selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	var options []SelectOption
+	if err := selectedElement.Execute(optionsScript, nil, &options); err != nil {
+		return nil, fmt.Errorf("failed to retrieve options for %s: %s", s, err)
+	}
+	return options, nil
+}
+
+// SelectedOptions returns the selected <option> elements of exactly one <select>
+// element.
+func (s *Selection) SelectedOptions() ([]SelectOption, error) {
+	options, err := s.Options()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := []SelectOption{}
+	for _, option := range options {
+		if option.Selected {
+			selected = append(selected, option)
+		}
+	}
+	return selected, nil
+}
+
+func (s *Selection) selectOptions(optionXPath, description string) error {
+	return s.forEachElement(func(selectedElement element.Element) error {
+		options, err := s.getOptions(selectedElement, optionXPath)
+		if err != nil {
+			return err
+		}
+
+		if len(options) == 0 {
+			return fmt.Errorf("no options with %s found for %s", description, s)
+		}
+
+		for _, option := range options {
+			if err := s.setOptionSelected(option, true); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Selection) getOptions(selectedElement element.Element, optionXPath string) ([]*api.Element, error) {
+	optionSelector := target.Selector{Type: target.XPath, Value: optionXPath}
+	options, err := selectedElement.GetElements(optionSelector.API())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve options for %s: %s", s, err)
+	}
+	return options, nil
+}
+
+// Options are only clicked if their state differs, as clicking an option of a
+// <select multiple> toggles it.
+func (s *Selection) setOptionSelected(option *api.Element, selected bool) error {
+	optionSelected, err := option.IsSelected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve state of option for %s: %s", s, err)
+	}
+
+	if optionSelected != selected {
+		if err := option.Click(); err != nil {
+			return fmt.Errorf("failed to click on option for %s: %s", s, err)
+		}
+	}
+	return nil
+}
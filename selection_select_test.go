@@ -0,0 +1,182 @@
+package agouti_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Selection Select", func() {
+	var (
+		selection         *MultiSelection
+		elementRepository *mocks.ElementRepository
+		firstElement      *mocks.Element
+		secondElement     *mocks.Element
+		firstOptionBuses  []*mocks.Bus
+		secondOptionBus   *mocks.Bus
+	)
+
+	BeforeEach(func() {
+		firstElement = &mocks.Element{}
+		secondElement = &mocks.Element{}
+		elementRepository = &mocks.ElementRepository{}
+		selection = NewTestMultiSelection(&mocks.Session{}, elementRepository, "#selector")
+		elementRepository.GetAtLeastOneCall.ReturnElements = []element.Element{firstElement, secondElement}
+
+		firstOptionBuses = []*mocks.Bus{{}, {}}
+		secondOptionBus = &mocks.Bus{}
+		firstElement.GetElementsCall.ReturnElements = []*api.Element{
+			{ID: "one", Session: &api.Session{Bus: firstOptionBuses[0]}},
+			{ID: "two", Session: &api.Session{Bus: firstOptionBuses[1]}},
+		}
+		secondElement.GetElementsCall.ReturnElements = []*api.Element{
+			{ID: "three", Session: &api.Session{Bus: secondOptionBus}},
+		}
+		firstOptionBuses[0].SendCall.Result = "false"
+		firstOptionBuses[1].SendCall.Result = "true"
+		secondOptionBus.SendCall.Result = "false"
+	})
+
+	Describe("#SelectByValue", func() {
+		It("should retrieve the options with the provided value for each selected element", func() {
+			Expect(selection.SelectByValue("some value")).To(Succeed())
+			Expect(firstElement.GetElementsCall.Selector).To(Equal(api.Selector{Using: "xpath", Value: `.//option[@value="some value"]`}))
+			Expect(secondElement.GetElementsCall.Selector).To(Equal(api.Selector{Using: "xpath", Value: `.//option[@value="some value"]`}))
+		})
+
+		It("should only click on options that are not already selected", func() {
+			Expect(selection.SelectByValue("some value")).To(Succeed())
+			Expect(firstOptionBuses[0].SendCall.Endpoint).To(Equal("element/one/click"))
+			Expect(firstOptionBuses[1].SendCall.Endpoint).To(Equal("element/two/selected"))
+			Expect(secondOptionBus.SendCall.Endpoint).To(Equal("element/three/click"))
+		})
+
+		Context("when any of the elements has no options with the provided value", func() {
+			It("should return an error", func() {
+				secondElement.GetElementsCall.ReturnElements = []*api.Element{}
+				Expect(selection.SelectByValue("some value")).To(MatchError(`no options with value "some value" found for selection 'CSS: #selector'`))
+			})
+		})
+
+		Context("when we fail to retrieve the options", func() {
+			It("should return an error", func() {
+				secondElement.GetElementsCall.Err = errors.New("some error")
+				Expect(selection.SelectByValue("some value")).To(MatchError("failed to retrieve options for selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the state of any of the options cannot be retrieved", func() {
+			It("should return an error", func() {
+				secondOptionBus.SendCall.Err = errors.New("some error")
+				Expect(selection.SelectByValue("some value")).To(MatchError("failed to retrieve state of option for selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#SelectByPartialText", func() {
+		It("should retrieve the options containing the provided text", func() {
+			Expect(selection.SelectByPartialText("some text")).To(Succeed())
+			Expect(firstElement.GetElementsCall.Selector.Value).To(Equal(`.//option[contains(normalize-space(), "some text")]`))
+		})
+
+		Context("when any of the elements has no options containing the provided text", func() {
+			It("should return an error", func() {
+				firstElement.GetElementsCall.ReturnElements = []*api.Element{}
+				Expect(selection.SelectByPartialText("some text")).To(MatchError(`no options with text containing "some text" found for selection 'CSS: #selector'`))
+			})
+		})
+	})
+
+	Describe("#SelectByIndex", func() {
+		It("should select the option at the provided index for each selected element", func() {
+			Expect(selection.SelectByIndex(0)).To(Succeed())
+			Expect(firstElement.GetElementsCall.Selector.Value).To(Equal(".//option"))
+			Expect(firstOptionBuses[0].SendCall.Endpoint).To(Equal("element/one/click"))
+			Expect(firstOptionBuses[1].SendCall.Endpoint).To(BeEmpty())
+			Expect(secondOptionBus.SendCall.Endpoint).To(Equal("element/three/click"))
+		})
+
+		Context("when the index is out of range for any of the elements", func() {
+			It("should return an error", func() {
+				Expect(selection.SelectByIndex(1)).To(MatchError("no option at index 1 found for selection 'CSS: #selector'"))
+			})
+		})
+	})
+
+	Describe("#DeselectAll", func() {
+		BeforeEach(func() {
+			firstElement.GetAttributeCall.ReturnValue = "true"
+			secondElement.GetAttributeCall.ReturnValue = "true"
+			secondOptionBus.SendCall.Result = "true"
+		})
+
+		It("should deselect all selected options", func() {
+			Expect(selection.DeselectAll()).To(Succeed())
+			Expect(firstElement.GetAttributeCall.Attribute).To(Equal("multiple"))
+			Expect(firstOptionBuses[0].SendCall.Endpoint).To(Equal("element/one/selected"))
+			Expect(firstOptionBuses[1].SendCall.Endpoint).To(Equal("element/two/click"))
+			Expect(secondOptionBus.SendCall.Endpoint).To(Equal("element/three/click"))
+		})
+
+		Context("when any of the elements is not a multi-select", func() {
+			It("should return an error", func() {
+				secondElement.GetAttributeCall.ReturnValue = ""
+				Expect(selection.DeselectAll()).To(MatchError("selection 'CSS: #selector' does not refer to a multi-select"))
+			})
+		})
+
+		Context("when the multiple attribute cannot be retrieved", func() {
+			It("should return an error", func() {
+				firstElement.GetAttributeCall.Err = errors.New("some error")
+				Expect(selection.DeselectAll()).To(MatchError("failed to retrieve multiple attribute of selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#Options", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+			firstElement.ExecuteCall.Result = `[
+				{"text": "One", "value": "1", "index": 0, "selected": false},
+				{"text": "Two", "value": "2", "index": 1, "selected": true}
+			]`
+		})
+
+		It("should return all options of the selected element", func() {
+			Expect(selection.Options()).To(Equal([]SelectOption{
+				{Text: "One", Value: "1", Index: 0},
+				{Text: "Two", Value: "2", Index: 1, Selected: true},
+			}))
+			Expect(firstElement.ExecuteCall.Body).To(ContainSubstring("arguments[0].options"))
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.Options()
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the options cannot be retrieved", func() {
+			It("should return an error", func() {
+				firstElement.ExecuteCall.Err = errors.New("some error")
+				_, err := selection.Options()
+				Expect(err).To(MatchError("failed to retrieve options for selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#SelectedOptions", func() {
+		It("should return the selected options of the selected element", func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+			firstElement.ExecuteCall.Result = `[{"text": "One", "value": "1", "index": 0}, {"text": "Two", "value": "2", "index": 1, "selected": true}]`
+			Expect(selection.SelectedOptions()).To(Equal([]SelectOption{{Text: "Two", Value: "2", Index: 1, Selected: true}}))
+		})
+	})
+})
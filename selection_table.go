@@ -0,0 +1,80 @@
+package agouti
+
+import "fmt"
+
+// A Table contains the text of the header and body cells of an HTML table.
+type Table struct {
+	// Headers contains the text of each cell in the last row of the table
+	// header, or of the first row of the table if it only contains <th>
+	// cells and the table has no <thead>.
+	Headers []string
+
+	// Rows contains the text of each cell in each row of the table that is
+	// not part of the header or footer.
+	Rows [][]string
+}
+
+// Records returns each row of the table as a map from the text of each header
+// to the text of the cell in the same column. Cells without a header are
+// omitted.
+func (t *Table) Records() []map[string]string {
+	records := []map[string]string{}
+	for _, row := range t.Rows {
+		record := map[string]string{}
+		for index, cell := range row {
+			if index < len(t.Headers) {
+				record[t.Headers[index]] = cell
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+const tableScript = `
+	var table = arguments[0];
+	if (table.tagName.toLowerCase() !== "table") {
+		throw new Error("element is not a table");
+	}
+	var cells = function(row) {
+		return Array.prototype.map.call(row.cells, function(cell) {
+			return cell.innerText.trim();
+		});
+	};
+	var rows = Array.prototype.filter.call(table.rows, function(row) {
+		return row.parentNode !== table.tHead && row.parentNode !== table.tFoot;
+	});
+	var headers = [];
+	if (table.tHead && table.tHead.rows.length > 0) {
+		headers = cells(table.tHead.rows[table.tHead.rows.length - 1]);
+	} else if (rows.length > 0 && rows[0].cells.length > 0 &&
+		Array.prototype.every.call(rows[0].cells, function(cell) { return cell.tagName === "TH"; })) {
+		headers = cells(rows.shift());
+	}
+	return {headers: headers, rows: rows.map(cells)};
+`
+
+// Table returns the header and body cell text of exactly one <table> element.
+// The table is read using a single script, so it is much faster than
+// retrieving the text of each cell separately.
+//
+// Example:
+//    table, err := page.Find("#orders").Table()
+//    for _, order := range table.Records() {
+//        fmt.Println(order["Customer"], order["Total"])
+//    }
+func (s *Selection) Table() (*Table, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return nil, selectionError("failed to select element from %s: %s", s, err)
+	}
+
+	var result struct {
+		Headers []string   `json:"headers"`
+		Rows    [][]string `json:"rows"`
+	}
+	if err := selectedElement.Execute(tableScript, nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to read table from %s: %s", s, err)
+	}
+	return &Table{Headers: result.Headers, Rows: result.Rows}, nil
+}
@@ -0,0 +1,66 @@
+package agouti_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Selection Tables", func() {
+	var (
+		selection         *Selection
+		elementRepository *mocks.ElementRepository
+		tableElement      *mocks.Element
+	)
+
+	BeforeEach(func() {
+		elementRepository = &mocks.ElementRepository{}
+		tableElement = &mocks.Element{}
+		elementRepository.GetExactlyOneCall.ReturnElement = tableElement
+		selection = NewTestSelection(&mocks.Session{}, elementRepository, "#selector")
+	})
+
+	Describe("#Table", func() {
+		It("should read the table using a single script", func() {
+			tableElement.ExecuteCall.Result = `{"headers": ["Name", "Age"], "rows": [["Bob", "30"], ["Alice", "25"]]}`
+			table, err := selection.Table()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.Headers).To(Equal([]string{"Name", "Age"}))
+			Expect(table.Rows).To(Equal([][]string{{"Bob", "30"}, {"Alice", "25"}}))
+			Expect(tableElement.ExecuteCall.Body).To(ContainSubstring("table.rows"))
+			Expect(tableElement.ExecuteCall.Arguments).To(BeEmpty())
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.Table()
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #selector [single]': some error"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				tableElement.ExecuteCall.Err = errors.New("some error")
+				_, err := selection.Table()
+				Expect(err).To(MatchError("failed to read table from selection 'CSS: #selector [single]': some error"))
+			})
+		})
+	})
+
+	Describe("Table#Records", func() {
+		It("should return each row as a map from header to cell text", func() {
+			table := &Table{
+				Headers: []string{"Name", "Age"},
+				Rows:    [][]string{{"Bob", "30", "extra"}, {"Alice"}},
+			}
+			Expect(table.Records()).To(Equal([]map[string]string{
+				{"Name": "Bob", "Age": "30"},
+				{"Name": "Alice"},
+			}))
+		})
+	})
+})
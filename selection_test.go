@@ -2,6 +2,9 @@ package agouti_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -54,6 +57,14 @@ var _ = Describe("Selection", func() {
 				Expect(err).To(MatchError("some error"))
 			})
 		})
+
+		Context("when an element is not a WebDriver element", func() {
+			It("should return an error", func() {
+				elementRepository.GetCall.ReturnElements = []element.Element{&mocks.Element{}}
+				_, err := selection.Elements()
+				Expect(err).To(MatchError("element of type *mocks.Element is not a WebDriver element"))
+			})
+		})
 	})
 
 	Describe("#Count", func() {
@@ -77,6 +88,40 @@ var _ = Describe("Selection", func() {
 				elementRepository.GetCall.Err = errors.New("some error")
 				_, err := selection.Count()
 				Expect(err).To(MatchError("failed to select elements from selection 'CSS: #selector': some error"))
+				Expect(element.IsNotFound(err)).To(BeFalse())
+			})
+		})
+
+		Context("when the selection does not refer to an element", func() {
+			It("should return an error that indicates that the element was not found", func() {
+				elementRepository.GetCall.Err = element.ErrNotFound
+				_, err := selection.Count()
+				Expect(err).To(MatchError("failed to select elements from selection 'CSS: #selector': element not found"))
+				Expect(element.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("#Reload", func() {
+		var (
+			selection         *Selection
+			elementRepository *mocks.ElementRepository
+		)
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			selection = NewTestSelection(nil, elementRepository, "#selector")
+		})
+
+		It("should successfully reload the elements of the selection", func() {
+			Expect(selection.Reload()).To(Succeed())
+			Expect(elementRepository.ReloadCall.Called).To(BeTrue())
+		})
+
+		Context("when the elements cannot be retrieved", func() {
+			It("should return an error", func() {
+				elementRepository.ReloadCall.Err = errors.New("some error")
+				Expect(selection.Reload()).To(MatchError("failed to select elements from selection 'CSS: #selector [single]': some error"))
 			})
 		})
 	})
@@ -190,4 +235,48 @@ var _ = Describe("Selection", func() {
 			})
 		})
 	})
+	Describe("#Screenshot", func() {
+		var (
+			selection         *Selection
+			elementRepository *mocks.ElementRepository
+		)
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+			selection = NewTestSelection(&mocks.Session{}, elementRepository, "#selector")
+		})
+
+		It("should successfully save a screenshot of the element", func() {
+			firstElement.GetScreenshotCall.ReturnImage = []byte("some-image")
+			filename, _ := filepath.Abs(".test.element.screenshot.png")
+			Expect(selection.Screenshot(".test.element.screenshot.png")).To(Succeed())
+			defer os.Remove(filename)
+			result, _ := ioutil.ReadFile(filename)
+			Expect(string(result)).To(Equal("some-image"))
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				err := selection.Screenshot(".test.element.screenshot.png")
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #selector [single]': some error"))
+			})
+		})
+
+		Context("when the element fails to retrieve a screenshot", func() {
+			It("should return an error", func() {
+				firstElement.GetScreenshotCall.Err = errors.New("some error")
+				err := selection.Screenshot(".test.element.screenshot.png")
+				Expect(err).To(MatchError("failed to retrieve screenshot for selection 'CSS: #selector [single]': some error"))
+			})
+		})
+
+		Context("when a new screenshot file cannot be saved", func() {
+			It("should return an error", func() {
+				err := selection.Screenshot("")
+				Expect(err.Error()).To(ContainSubstring("failed to save screenshot: open"))
+			})
+		})
+	})
 })
@@ -0,0 +1,45 @@
+package agouti
+
+import "github.com/sclevine/agouti/internal/target"
+
+// Parent selects the parent element of each element in the selection. Each
+// parent is only selected once, even if it is the parent of multiple
+// selected elements.
+func (s *Selection) Parent() *Selection {
+	return newSelection(s.session, s.selectors.Append(target.Parent, "").Single(), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// Children selects the child elements of each element in the selection that
+// match the provided CSS selector, or all child elements if the selector is
+// empty. Unlike All, only direct children are selected.
+func (s *Selection) Children(selector string) *MultiSelection {
+	return newMultiSelection(s.session, s.selectors.Append(target.Children, selector), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// Closest selects the closest element that matches the provided CSS
+// selector for each element in the selection, starting with the element
+// itself and continuing with its ancestors.
+func (s *Selection) Closest(selector string) *Selection {
+	return newSelection(s.session, s.selectors.Append(target.Closest, selector).Single(), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// NextSibling selects the element immediately after each element in the
+// selection that has the same parent.
+func (s *Selection) NextSibling() *Selection {
+	return newSelection(s.session, s.selectors.Append(target.NextSibling, "").Single(), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// PrevSibling selects the element immediately before each element in the
+// selection that has the same parent.
+func (s *Selection) PrevSibling() *Selection {
+	return newSelection(s.session, s.selectors.Append(target.PrevSibling, "").Single(), s.staleRetries, s.testIDAttribute, s.cache)
+}
+
+// Filter reduces the selection to the elements that match the provided CSS
+// selector, ex. ":checked" or "[data-state=open]".
+//
+// Example:
+//    page.All("tr").Filter(".selected").Children("td")
+func (s *Selection) Filter(selector string) *MultiSelection {
+	return newMultiSelection(s.session, s.selectors.Append(target.Filter, selector), s.staleRetries, s.testIDAttribute, s.cache)
+}
@@ -0,0 +1,54 @@
+package agouti_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Selection Traversal", func() {
+	var selection *Selection
+
+	BeforeEach(func() {
+		selection = NewTestSelection(&mocks.Session{}, &mocks.ElementRepository{}, "#selector")
+	})
+
+	Describe("#Parent", func() {
+		It("should select the parent of each element", func() {
+			Expect(selection.Parent().String()).To(Equal(`selection 'CSS: #selector [single] | Parent [single]'`))
+		})
+	})
+
+	Describe("#Children", func() {
+		It("should select the matching children of each element", func() {
+			Expect(selection.Children("li").String()).To(Equal(`selection 'CSS: #selector [single] | Children: li'`))
+			Expect(selection.Children("li").At(1).String()).To(Equal(`selection 'CSS: #selector [single] | Children: li [1]'`))
+		})
+	})
+
+	Describe("#Closest", func() {
+		It("should select the closest matching ancestor of each element", func() {
+			Expect(selection.Closest("form").String()).To(Equal(`selection 'CSS: #selector [single] | Closest: form [single]'`))
+		})
+	})
+
+	Describe("#NextSibling", func() {
+		It("should select the next sibling of each element", func() {
+			Expect(selection.NextSibling().String()).To(Equal(`selection 'CSS: #selector [single] | Next Sibling [single]'`))
+		})
+	})
+
+	Describe("#PrevSibling", func() {
+		It("should select the previous sibling of each element", func() {
+			Expect(selection.PrevSibling().String()).To(Equal(`selection 'CSS: #selector [single] | Previous Sibling [single]'`))
+		})
+	})
+
+	Describe("#Filter", func() {
+		It("should select the elements that match the provided selector", func() {
+			Expect(selection.Filter(".selected").String()).To(Equal(`selection 'CSS: #selector [single] | Filter: .selected'`))
+			Expect(selection.Filter(".selected").Children("td").String()).To(Equal(`selection 'CSS: #selector [single] | Filter: .selected | Children: td'`))
+		})
+	})
+})
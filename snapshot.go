@@ -0,0 +1,56 @@
+package agouti
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// SaveSnapshot saves a screenshot of the viewport and the HTML source of the
+// current page to the provided directory, as <name>.png and <name>.html, and
+// returns the paths of the saved files. The directory is created if it does
+// not exist. If either file cannot be saved, the other file is still saved,
+// and its path is returned along with the error. Selections save a snapshot
+// of the page they were selected from, so that a snapshot may be taken even
+// if the selection does not refer to any elements.
+func (s *selectable) SaveSnapshot(directory, name string) ([]string, error) {
+	if err := os.MkdirAll(directory, 0777); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %s", err)
+	}
+
+	var (
+		paths    []string
+		firstErr error
+	)
+
+	screenshotPath := filepath.Join(directory, name+".png")
+	if err := saveScreenshot(screenshotPath, s.session.GetScreenshot); err != nil {
+		firstErr = err
+	} else {
+		paths = append(paths, screenshotPath)
+	}
+
+	htmlPath := filepath.Join(directory, name+".html")
+	if err := s.saveSource(htmlPath); err != nil {
+		if firstErr == nil {
+			firstErr = err
+		}
+	} else {
+		paths = append(paths, htmlPath)
+	}
+
+	return paths, firstErr
+}
+
+func (s *selectable) saveSource(filename string) error {
+	html, err := s.session.GetSource()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve page HTML: %s", err)
+	}
+
+	if err := ioutil.WriteFile(filename, []byte(html), 0666); err != nil {
+		return fmt.Errorf("failed to save page HTML: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package agouti_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Snapshots", func() {
+	var (
+		session   *mocks.Session
+		directory string
+	)
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "snapshot")
+		Expect(err).NotTo(HaveOccurred())
+		directory = filepath.Join(directory, "artifacts")
+
+		session = &mocks.Session{}
+		session.GetScreenshotCall.ReturnImage = []byte("some-image")
+		session.GetSourceCall.ReturnSource = "<html></html>"
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(filepath.Dir(directory))
+	})
+
+	Describe("#SaveSnapshot", func() {
+		It("should save a screenshot and the HTML source of the page", func() {
+			paths, err := NewTestPage(session).SaveSnapshot(directory, "some-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paths).To(Equal([]string{
+				filepath.Join(directory, "some-name.png"),
+				filepath.Join(directory, "some-name.html"),
+			}))
+			Expect(ioutil.ReadFile(paths[0])).To(Equal([]byte("some-image")))
+			Expect(ioutil.ReadFile(paths[1])).To(Equal([]byte("<html></html>")))
+		})
+
+		It("should save a snapshot of the page for selections", func() {
+			selection := NewTestSelection(session, &mocks.ElementRepository{}, "#selector")
+			paths, err := selection.SaveSnapshot(directory, "some-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paths).To(HaveLen(2))
+		})
+
+		Context("when the screenshot cannot be retrieved", func() {
+			It("should still save the HTML source and return an error", func() {
+				session.GetScreenshotCall.Err = errors.New("some error")
+				paths, err := NewTestPage(session).SaveSnapshot(directory, "some-name")
+				Expect(err).To(MatchError("failed to retrieve screenshot: some error"))
+				Expect(paths).To(Equal([]string{filepath.Join(directory, "some-name.html")}))
+			})
+		})
+
+		Context("when the HTML source cannot be retrieved", func() {
+			It("should still save the screenshot and return an error", func() {
+				session.GetSourceCall.Err = errors.New("some error")
+				paths, err := NewTestPage(session).SaveSnapshot(directory, "some-name")
+				Expect(err).To(MatchError("failed to retrieve page HTML: some error"))
+				Expect(paths).To(Equal([]string{filepath.Join(directory, "some-name.png")}))
+			})
+		})
+	})
+})
@@ -0,0 +1,179 @@
+package agouti
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sclevine/agouti/api"
+)
+
+// DefaultArtifactsDirectory is the directory that the default failure
+// handler saves the state of failed pages to, unless the AGOUTI_ARTIFACTS_DIR
+// environment variable provides another directory. See OnFailure.
+const DefaultArtifactsDirectory = "agouti-artifacts"
+
+var failureHandler struct {
+	sync.Mutex
+	handler func(*Page)
+}
+
+// OnFailure sets the function that HandleFailure calls with a page when a
+// test fails. By default, DumpState is called with the directory provided by
+// the AGOUTI_ARTIFACTS_DIR environment variable, or DefaultArtifactsDirectory,
+// and any error is written to stderr. Providing nil restores the default.
+//
+// Example:
+//    agouti.OnFailure(func(page *agouti.Page) {
+//        directory, err := page.DumpState("test-results")
+//        fmt.Fprintf(GinkgoWriter, "saved page state to %s (%v)\n", directory, err)
+//    })
+func OnFailure(handler func(*Page)) {
+	failureHandler.Lock()
+	defer failureHandler.Unlock()
+	failureHandler.handler = handler
+}
+
+// HandleFailure calls the function provided to OnFailure with the page if
+// the test using the page has failed. It should be called after each test,
+// before the page is destroyed.
+//
+// Example:
+//    AfterEach(func() {
+//        page.HandleFailure(CurrentGinkgoTestDescription().Failed)
+//        Expect(page.Destroy()).To(Succeed())
+//    })
+func (p *Page) HandleFailure(failed bool) {
+	if !failed {
+		return
+	}
+
+	failureHandler.Lock()
+	handler := failureHandler.handler
+	failureHandler.Unlock()
+
+	if handler == nil {
+		handler = dumpStateToArtifacts
+	}
+	handler(p)
+}
+
+// HandleFailures registers a cleanup function with the provided test, such
+// as a *testing.T, that calls HandleFailure when the test completes. As
+// cleanup functions are called in reverse order, HandleFailures should be
+// called after registering any cleanup function that destroys the page.
+//
+// Example:
+//    page, err := driver.NewPage()
+//    t.Cleanup(func() { page.Destroy() })
+//    page.HandleFailures(t)
+func (p *Page) HandleFailures(test interface {
+	Cleanup(func())
+	Failed() bool
+}) {
+	test.Cleanup(func() {
+		p.HandleFailure(test.Failed())
+	})
+}
+
+func dumpStateToArtifacts(page *Page) {
+	directory := os.Getenv("AGOUTI_ARTIFACTS_DIR")
+	if directory == "" {
+		directory = DefaultArtifactsDirectory
+	}
+
+	if _, err := page.DumpState(directory); err != nil {
+		fmt.Fprintf(os.Stderr, "agouti: failed to save page state to %s: %s\n", directory, err)
+	}
+}
+
+// DumpState saves the state of the page to a new directory within the
+// provided directory, which is named using the current time, and returns
+// the path of the new directory. The new directory contains:
+//   - screenshot.png, a screenshot of the viewport
+//   - page.html, the HTML source of the page
+//   - cookies.json, the cookies of the page
+//   - console.log, all browser console logs, if the browser provides them
+//   - driver.log, the recent output of the WebDriver process, if the page
+//     was opened using a *WebDriver that started the process
+// If a file cannot be saved, the other files are still saved, and the first
+// error is returned.
+func (p *Page) DumpState(directory string) (string, error) {
+	if err := os.MkdirAll(directory, 0777); err != nil {
+		return "", fmt.Errorf("failed to create state directory: %s", err)
+	}
+	stateDirectory, err := ioutil.TempDir(directory, time.Now().Format("20060102-150405")+"-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create state directory: %s", err)
+	}
+
+	var firstErr error
+	save := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	save(saveScreenshot(filepath.Join(stateDirectory, "screenshot.png"), p.session.GetScreenshot))
+	save(p.saveSource(filepath.Join(stateDirectory, "page.html")))
+	save(p.saveCookies(filepath.Join(stateDirectory, "cookies.json")))
+	save(p.saveConsoleLogs(filepath.Join(stateDirectory, "console.log")))
+
+	if p.driverOutput != nil {
+		if err := ioutil.WriteFile(filepath.Join(stateDirectory, "driver.log"), p.driverOutput(), 0666); err != nil {
+			save(fmt.Errorf("failed to save driver output: %s", err))
+		}
+	}
+	return stateDirectory, firstErr
+}
+
+func (p *Page) saveCookies(filename string) error {
+	cookies, err := p.GetCookies()
+	if err != nil {
+		return err
+	}
+
+	cookiesJSON, err := json.MarshalIndent(cookies, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cookies: %s", err)
+	}
+	if err := ioutil.WriteFile(filename, cookiesJSON, 0666); err != nil {
+		return fmt.Errorf("failed to save cookies: %s", err)
+	}
+	return nil
+}
+
+// Browsers that do not provide logs, such as Firefox, are skipped.
+func (p *Page) saveConsoleLogs(filename string) error {
+	p.logsMutex.Lock()
+	_, err := p.readNewLogs("browser")
+	logs := append([]Log(nil), p.logs["browser"]...)
+	p.logsMutex.Unlock()
+
+	if api.ErrorCode(err) == api.ErrorUnknownCommand {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to retrieve logs: %s", err)
+	}
+
+	var contents []byte
+	for _, log := range logs {
+		line := log.Time.Format(time.RFC3339Nano) + " " + log.Level
+		if log.Source != "" {
+			line += " " + log.Source
+		}
+		if log.Location != "" {
+			line += ":" + log.Location
+		}
+		contents = append(contents, line+" "+log.Text+"\n"...)
+	}
+	if err := ioutil.WriteFile(filename, contents, 0666); err != nil {
+		return fmt.Errorf("failed to save console logs: %s", err)
+	}
+	return nil
+}
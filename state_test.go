@@ -0,0 +1,152 @@
+package agouti_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Page State", func() {
+	var (
+		session   *mocks.Session
+		page      *Page
+		directory string
+	)
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "state")
+		Expect(err).NotTo(HaveOccurred())
+
+		session = &mocks.Session{}
+		session.GetScreenshotCall.ReturnImage = []byte("some-image")
+		session.GetSourceCall.ReturnSource = "<html></html>"
+		session.GetCookiesCall.ReturnCookies = []*api.Cookie{{Name: "some-name", Value: "some-value"}}
+		session.NewLogsCall.ReturnLogs = []api.Log{{Message: "some message", Level: "WARNING", Timestamp: 1000}}
+		page = NewTestPageWithDriverOutput(session, func() []byte { return []byte("some driver output") })
+	})
+
+	AfterEach(func() {
+		OnFailure(nil)
+		os.RemoveAll(directory)
+	})
+
+	readState := func(stateDirectory, name string) string {
+		contents, err := ioutil.ReadFile(filepath.Join(stateDirectory, name))
+		Expect(err).NotTo(HaveOccurred())
+		return string(contents)
+	}
+
+	Describe("#DumpState", func() {
+		It("should save the state of the page to a new directory", func() {
+			stateDirectory, err := page.DumpState(filepath.Join(directory, "artifacts"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(filepath.Dir(stateDirectory)).To(Equal(filepath.Join(directory, "artifacts")))
+			Expect(readState(stateDirectory, "screenshot.png")).To(Equal("some-image"))
+			Expect(readState(stateDirectory, "page.html")).To(Equal("<html></html>"))
+			Expect(readState(stateDirectory, "cookies.json")).To(ContainSubstring(`"Value": "some-value"`))
+			Expect(readState(stateDirectory, "console.log")).To(HaveSuffix(" WARNING some message\n"))
+			Expect(readState(stateDirectory, "driver.log")).To(Equal("some driver output"))
+			Expect(session.NewLogsCall.LogType).To(Equal("browser"))
+		})
+
+		It("should create a different directory each time", func() {
+			firstDirectory, err := page.DumpState(directory)
+			Expect(err).NotTo(HaveOccurred())
+			secondDirectory, err := page.DumpState(directory)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(firstDirectory).NotTo(Equal(secondDirectory))
+		})
+
+		Context("when the page was not opened by a WebDriver", func() {
+			It("should not save driver output", func() {
+				stateDirectory, err := NewTestPage(session).DumpState(directory)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(filepath.Join(stateDirectory, "driver.log")).NotTo(BeAnExistingFile())
+			})
+		})
+
+		Context("when the browser does not provide logs", func() {
+			It("should skip the console logs", func() {
+				session.NewLogsCall.Err = &api.Error{Code: api.ErrorUnknownCommand}
+				stateDirectory, err := page.DumpState(directory)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(filepath.Join(stateDirectory, "console.log")).NotTo(BeAnExistingFile())
+			})
+		})
+
+		Context("when part of the state cannot be retrieved", func() {
+			It("should save the rest of the state and return the first error", func() {
+				session.GetScreenshotCall.Err = errors.New("some error")
+				session.GetCookiesCall.Err = errors.New("some other error")
+				stateDirectory, err := page.DumpState(directory)
+				Expect(err).To(MatchError("failed to retrieve screenshot: some error"))
+				Expect(readState(stateDirectory, "page.html")).To(Equal("<html></html>"))
+				Expect(readState(stateDirectory, "driver.log")).To(Equal("some driver output"))
+			})
+		})
+
+		Context("when the directory cannot be created", func() {
+			It("should return an error", func() {
+				filename := filepath.Join(directory, "some-file")
+				Expect(ioutil.WriteFile(filename, nil, 0666)).To(Succeed())
+				_, err := page.DumpState(filename)
+				Expect(err).To(MatchError(ContainSubstring("failed to create state directory")))
+			})
+		})
+	})
+
+	Describe("#HandleFailure", func() {
+		It("should call the failure handler with the page only when the test failed", func() {
+			var failedPages []*Page
+			OnFailure(func(failedPage *Page) {
+				failedPages = append(failedPages, failedPage)
+			})
+			page.HandleFailure(false)
+			Expect(failedPages).To(BeEmpty())
+			page.HandleFailure(true)
+			Expect(failedPages).To(Equal([]*Page{page}))
+		})
+
+		It("should dump the state of the page to the artifacts directory by default", func() {
+			os.Setenv("AGOUTI_ARTIFACTS_DIR", directory)
+			defer os.Unsetenv("AGOUTI_ARTIFACTS_DIR")
+			page.HandleFailure(true)
+			stateDirectories, err := ioutil.ReadDir(directory)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stateDirectories).To(HaveLen(1))
+		})
+	})
+
+	Describe("#HandleFailures", func() {
+		It("should handle the failure of the test when it is cleaned up", func() {
+			called := false
+			OnFailure(func(*Page) { called = true })
+			test := &fakeTest{}
+			page.HandleFailures(test)
+			test.failed = true
+			test.cleanup()
+			Expect(called).To(BeTrue())
+		})
+	})
+})
+
+type fakeTest struct {
+	failed  bool
+	cleanup func()
+}
+
+func (t *fakeTest) Cleanup(cleanup func()) {
+	t.cleanup = cleanup
+}
+
+func (t *fakeTest) Failed() bool {
+	return t.failed
+}
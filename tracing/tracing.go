@@ -0,0 +1,189 @@
+// Package tracing reports the WebDriver commands sent by an *agouti.Page as
+// spans, so that browser tests may be traced alongside the backend services
+// they exercise. Spans are started by a provided StartFunc, which adapts them
+// to a tracing library such as OpenTelemetry, so that agouti does not depend
+// on any tracing library.
+//
+// Example using OpenTelemetry:
+//    otelTracer := otel.Tracer("github.com/sclevine/agouti/tracing")
+//    start := func(ctx context.Context, span tracing.Span) (context.Context, tracing.EndFunc) {
+//        var attributes []attribute.KeyValue
+//        for key, value := range span.Attributes {
+//            attributes = append(attributes, attribute.String(key, value))
+//        }
+//        kind := trace.SpanKindInternal
+//        if span.Command {
+//            kind = trace.SpanKindClient
+//        }
+//        ctx, otelSpan := otelTracer.Start(ctx, span.Name, trace.WithTimestamp(span.Start),
+//            trace.WithSpanKind(kind), trace.WithAttributes(attributes...))
+//        return ctx, func(end time.Time, err error) {
+//            if err != nil {
+//                otelSpan.RecordError(err)
+//                otelSpan.SetStatus(codes.Error, err.Error())
+//            }
+//            otelSpan.End(trace.WithTimestamp(end))
+//        }
+//    }
+//
+//    tracer, err := tracing.New(ctx, page, start)
+//    err = tracer.Action(ctx, "log in", func() error {
+//        return page.Find("#login").Submit()
+//    })
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+)
+
+// Attribute keys set on the spans reported by a Tracer.
+const (
+	SessionIDKey  = "webdriver.session_id"
+	MethodKey     = "webdriver.method"
+	SelectorKey   = "webdriver.selector"
+	ErrorCodeKey  = "webdriver.error_code"
+	ActionNameKey = "agouti.action"
+)
+
+// A Span describes a WebDriver command or an action started using Action.
+type Span struct {
+	// Name is the WebDriver endpoint of a command, with element IDs replaced
+	// by "{id}", ex. "element/{id}/click", or the name of an action
+	Name string
+
+	// Command is true for WebDriver commands and false for actions
+	Command bool
+
+	// Start is the time that the command was sent or the action started
+	Start time.Time
+
+	// Attributes describe the span, ex. the session ID under SessionIDKey
+	Attributes map[string]string
+}
+
+// A StartFunc starts a span using a tracing library. The returned context
+// contains the new span, and is the parent of the spans started while an
+// action runs. The returned EndFunc is called when the span ends.
+type StartFunc func(ctx context.Context, span Span) (context.Context, EndFunc)
+
+// An EndFunc ends a span at the provided time with the error returned by the
+// command or action, if any.
+type EndFunc func(end time.Time, err error)
+
+// A Tracer reports a span for every WebDriver command sent by a page, and for
+// every action started using Action. Command spans are children of the
+// innermost running action, or of the context provided to New.
+type Tracer struct {
+	start     StartFunc
+	sessionID string
+
+	mutex    sync.Mutex
+	contexts []context.Context
+}
+
+// New returns a Tracer that reports spans for every subsequent command sent
+// by the provided page using the provided StartFunc.
+func New(ctx context.Context, page *agouti.Page, start StartFunc) (*Tracer, error) {
+	session := page.Session()
+	tracer := &Tracer{
+		start:     start,
+		sessionID: session.ID(),
+		contexts:  []context.Context{ctx},
+	}
+	if err := session.AddCommandHook(tracer.command); err != nil {
+		return nil, err
+	}
+	return tracer, nil
+}
+
+// Action runs the provided function within a span with the provided name,
+// such that the spans of the commands it sends are children of the action
+// span. The action span ends with any error returned by the function.
+// Actions may be nested, but concurrent actions on the same Tracer may
+// receive each other's command spans.
+func (t *Tracer) Action(ctx context.Context, name string, action func() error) error {
+	ctx, end := t.start(ctx, Span{
+		Name:  name,
+		Start: time.Now(),
+		Attributes: map[string]string{
+			SessionIDKey:  t.sessionID,
+			ActionNameKey: name,
+		},
+	})
+
+	t.mutex.Lock()
+	t.contexts = append(t.contexts, ctx)
+	t.mutex.Unlock()
+
+	defer func() {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		for index := len(t.contexts) - 1; index > 0; index-- {
+			if t.contexts[index] == ctx {
+				t.contexts = append(t.contexts[:index], t.contexts[index+1:]...)
+				break
+			}
+		}
+	}()
+
+	err := action()
+	end(time.Now(), err)
+	return err
+}
+
+func (t *Tracer) command(method, endpoint string, body, result []byte, err error, duration time.Duration) {
+	t.mutex.Lock()
+	ctx := t.contexts[len(t.contexts)-1]
+	t.mutex.Unlock()
+
+	end := time.Now()
+	attributes := map[string]string{
+		SessionIDKey: t.sessionID,
+		MethodKey:    method,
+	}
+	if selector := commandSelector(body); selector != "" {
+		attributes[SelectorKey] = selector
+	}
+	if code := api.ErrorCode(err); code != "" {
+		attributes[ErrorCodeKey] = code
+	}
+
+	_, endSpan := t.start(ctx, Span{
+		Name:       spanName(endpoint),
+		Command:    true,
+		Start:      end.Add(-duration),
+		Attributes: attributes,
+	})
+	endSpan(end, err)
+}
+
+func spanName(endpoint string) string {
+	segments := strings.Split(endpoint, "/")
+	for index := 1; index < len(segments); index++ {
+		switch segments[index-1] {
+		case "element", "shadow":
+			if segments[index] != "active" {
+				segments[index] = "{id}"
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func commandSelector(body []byte) string {
+	var selector struct {
+		Using string `json:"using"`
+		Value string `json:"value"`
+	}
+	if len(body) == 0 || json.Unmarshal(body, &selector) != nil || selector.Using == "" {
+		return ""
+	}
+	return selector.Using + ": " + selector.Value
+}
@@ -0,0 +1,170 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	. "github.com/sclevine/agouti/tracing"
+)
+
+type parentKey struct{}
+
+type recordedSpan struct {
+	Span
+	parent *recordedSpan
+	end    time.Time
+	err    error
+}
+
+// A spanRecorder starts spans that record their parent, and keeps the spans
+// in the order that they end.
+type spanRecorder struct {
+	ended []*recordedSpan
+}
+
+func (r *spanRecorder) start(ctx context.Context, span Span) (context.Context, EndFunc) {
+	recorded := &recordedSpan{Span: span}
+	recorded.parent, _ = ctx.Value(parentKey{}).(*recordedSpan)
+	return context.WithValue(ctx, parentKey{}, recorded), func(end time.Time, err error) {
+		recorded.end, recorded.err = end, err
+		r.ended = append(r.ended, recorded)
+	}
+}
+
+var _ = Describe("Tracer", func() {
+	var (
+		server   *httptest.Server
+		page     *agouti.Page
+		recorder *spanRecorder
+		tracer   *Tracer
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			switch request.URL.Path {
+			case "/session":
+				response.Write([]byte(`{"value": {"sessionId": "some-id", "capabilities": {}}}`))
+			case "/session/some-id/elements":
+				response.Write([]byte(`{"value": [{"` + api.W3CElementKey + `": "some-element"}]}`))
+			case "/session/some-id/element/some-element/click":
+				response.Write([]byte(`{"value": null}`))
+			case "/session/some-id/url":
+				response.Write([]byte(`{"value": "some-url"}`))
+			default:
+				response.WriteHeader(404)
+				response.Write([]byte(`{"value": {"error": "no such window", "message": "some message"}}`))
+			}
+		}))
+
+		var err error
+		page, err = agouti.NewPage(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+
+		recorder = &spanRecorder{}
+		tracer, err = New(context.Background(), page, recorder.start)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe(".New", func() {
+		It("should report a span for each command named after its endpoint", func() {
+			Expect(page.URL()).To(Equal("some-url"))
+			spans := recorder.ended
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("url"))
+			Expect(spans[0].Command).To(BeTrue())
+			Expect(spans[0].parent).To(BeNil())
+			Expect(spans[0].Attributes).To(Equal(map[string]string{
+				SessionIDKey: "some-id",
+				MethodKey:    "GET",
+			}))
+			Expect(spans[0].end.Sub(spans[0].Start)).To(BeNumerically(">", 0))
+			Expect(spans[0].err).NotTo(HaveOccurred())
+		})
+
+		It("should replace element IDs in span names and record selectors", func() {
+			Expect(page.Find("#some-id").Click()).To(Succeed())
+			spans := recorder.ended
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].Name).To(Equal("elements"))
+			Expect(spans[0].Attributes[SelectorKey]).To(Equal("css selector: #some-id"))
+			Expect(spans[1].Name).To(Equal("element/{id}/click"))
+		})
+
+		It("should record errors and their WebDriver error codes", func() {
+			Expect(page.Refresh()).NotTo(Succeed())
+			spans := recorder.ended
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].err).To(MatchError("request unsuccessful: some message"))
+			Expect(spans[0].Attributes[ErrorCodeKey]).To(Equal("no such window"))
+		})
+
+		It("should use spans from the provided context as the parent of command spans", func() {
+			parent := &recordedSpan{}
+			tracer, err := New(context.WithValue(context.Background(), parentKey{}, parent), page, recorder.start)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tracer).NotTo(BeNil())
+
+			page.URL()
+			spans := recorder.ended
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].parent).To(BeNil())
+			Expect(spans[1].parent).To(BeIdenticalTo(parent))
+		})
+	})
+
+	Describe("#Action", func() {
+		It("should report a span for the action with the spans of its commands as children", func() {
+			Expect(tracer.Action(context.Background(), "some action", func() error {
+				_, err := page.URL()
+				return err
+			})).To(Succeed())
+
+			spans := recorder.ended
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[1].Name).To(Equal("some action"))
+			Expect(spans[1].Command).To(BeFalse())
+			Expect(spans[1].Attributes[ActionNameKey]).To(Equal("some action"))
+			Expect(spans[1].Attributes[SessionIDKey]).To(Equal("some-id"))
+			Expect(spans[0].parent).To(BeIdenticalTo(spans[1]))
+		})
+
+		It("should nest actions and restore the parent of commands when they end", func() {
+			tracer.Action(context.Background(), "outer action", func() error {
+				return tracer.Action(context.Background(), "inner action", func() error {
+					_, err := page.URL()
+					return err
+				})
+			})
+			page.URL()
+
+			spans := recorder.ended
+			Expect(spans).To(HaveLen(4))
+			Expect(spans[0].parent).To(BeIdenticalTo(spans[1]))
+			Expect(spans[1].Name).To(Equal("inner action"))
+			Expect(spans[2].Name).To(Equal("outer action"))
+			Expect(spans[3].parent).To(BeNil())
+		})
+
+		It("should end the action span with errors from the action", func() {
+			err := tracer.Action(context.Background(), "some action", func() error {
+				return errors.New("some error")
+			})
+			Expect(err).To(MatchError("some error"))
+
+			spans := recorder.ended
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].err).To(MatchError("some error"))
+		})
+	})
+})
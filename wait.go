@@ -0,0 +1,266 @@
+package agouti
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
+)
+
+// A Condition reports whether a page is in an expected state. Conditions are
+// checked repeatedly by Wait.Until until they are satisfied. Any func with
+// this signature may be used as a custom Condition.
+//
+// Errors indicating that an element could not be found or is no longer
+// attached to the DOM (see api.IsNoSuchElement and api.IsStaleElement), as
+// well as errors from selections that do not yet refer to an element, are
+// treated as an unsatisfied condition, so that the condition is retried.
+type Condition func(page *Page) (bool, error)
+
+// A Wait repeatedly checks conditions against a page. Waits are created
+// using Page.Wait.
+type Wait struct {
+	page     *Page
+	timeout  time.Duration
+	interval time.Duration
+}
+
+const defaultWaitInterval = 100 * time.Millisecond
+
+// Wait returns a Wait that checks conditions every interval until the
+// provided timeout elapses. If the interval is not positive, conditions are
+// checked every 100 milliseconds.
+//
+// Example:
+//    err := page.Wait(5*time.Second, 100*time.Millisecond).Until(agouti.Visible(page.Find("#dialog")))
+func (p *Page) Wait(timeout, interval time.Duration) *Wait {
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+	return &Wait{p, timeout, interval}
+}
+
+// Until blocks until all of the provided conditions are satisfied. The
+// conditions are always checked at least once. An error is returned if the
+// conditions are not satisfied before the timeout elapses, or if a condition
+// returns an error that is not retried.
+func (w *Wait) Until(conditions ...Condition) error {
+	condition := AllOf(conditions...)
+	deadline := time.Now().Add(w.timeout)
+
+	var lastErr error
+	for {
+		satisfied, err := condition(w.page)
+		if err != nil && !isRetryable(err) {
+			return fmt.Errorf("failed to wait for condition: %s", err)
+		}
+		if err == nil && satisfied {
+			return nil
+		}
+		lastErr = err
+
+		if time.Now().Add(w.interval).After(deadline) {
+			if lastErr != nil {
+				return fmt.Errorf("failed to satisfy condition within %s: %s", w.timeout, lastErr)
+			}
+			return fmt.Errorf("failed to satisfy condition within %s", w.timeout)
+		}
+		time.Sleep(w.interval)
+	}
+}
+
+func isRetryable(err error) bool {
+	return api.IsStaleElement(err) || api.IsNoSuchElement(err) || element.IsNotFound(err)
+}
+
+// AllOf returns a Condition that is satisfied when all of the provided
+// conditions are satisfied.
+func AllOf(conditions ...Condition) Condition {
+	return func(page *Page) (bool, error) {
+		for _, condition := range conditions {
+			if satisfied, err := condition(page); err != nil || !satisfied {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+}
+
+// AnyOf returns a Condition that is satisfied when any of the provided
+// conditions are satisfied.
+func AnyOf(conditions ...Condition) Condition {
+	return func(page *Page) (bool, error) {
+		var lastErr error
+		for _, condition := range conditions {
+			satisfied, err := condition(page)
+			if err != nil {
+				if !isRetryable(err) {
+					return false, err
+				}
+				lastErr = err
+				continue
+			}
+			if satisfied {
+				return true, nil
+			}
+		}
+		return false, lastErr
+	}
+}
+
+// Negate returns a Condition that is satisfied when the provided condition is
+// not satisfied. Errors that Wait.Until would retry count as the condition not
+// being satisfied, so that a condition on an element is negated once the
+// element is removed.
+//
+// Example:
+//    err := page.Wait(5*time.Second, 0).Until(agouti.Negate(agouti.Visible(page.Find("#spinner"))))
+func Negate(condition Condition) Condition {
+	return func(page *Page) (bool, error) {
+		satisfied, err := condition(page)
+		if err != nil {
+			if isRetryable(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		return !satisfied, nil
+	}
+}
+
+// Visible returns a Condition that is satisfied when the provided
+// *Selection or *MultiSelection refers to at least one element and all of
+// the elements it refers to are visible.
+func Visible(selection interface{}) Condition {
+	return func(*Page) (bool, error) {
+		actualSelection, err := toSelection(selection)
+		if err != nil {
+			return false, err
+		}
+
+		elements, err := actualSelection.elements.Get()
+		if err != nil || len(elements) == 0 {
+			return false, err
+		}
+
+		for _, selectedElement := range elements {
+			if visible, err := selectedElement.IsDisplayed(); err != nil || !visible {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+}
+
+// ElementCount returns a Condition that is satisfied when the provided
+// *Selection or *MultiSelection refers to exactly the provided number of
+// elements.
+func ElementCount(selection interface{}, count int) Condition {
+	return func(*Page) (bool, error) {
+		actualSelection, err := toSelection(selection)
+		if err != nil {
+			return false, err
+		}
+
+		elements, err := actualSelection.elements.Get()
+		if err != nil {
+			return false, err
+		}
+		return len(elements) == count, nil
+	}
+}
+
+// TitleIs returns a Condition that is satisfied when the title of the page
+// is the provided title.
+func TitleIs(title string) Condition {
+	return func(page *Page) (bool, error) {
+		actualTitle, err := page.Title()
+		if err != nil {
+			return false, err
+		}
+		return actualTitle == title, nil
+	}
+}
+
+// URLMatches returns a Condition that is satisfied when the current URL of
+// the page matches the provided regular expression.
+func URLMatches(pattern string) Condition {
+	return func(page *Page) (bool, error) {
+		matcher, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid URL pattern: %s", err)
+		}
+
+		url, err := page.URL()
+		if err != nil {
+			return false, err
+		}
+		return matcher.MatchString(url), nil
+	}
+}
+
+var readyStates = map[string]int{"loading": 0, "interactive": 1, "complete": 2}
+
+// ReadyState returns a Condition that is satisfied when the document.readyState
+// of the page is at least the provided state ("loading", "interactive", or
+// "complete").
+func ReadyState(state string) Condition {
+	return func(page *Page) (bool, error) {
+		expected, ok := readyStates[state]
+		if !ok {
+			return false, fmt.Errorf("invalid ready state: %s", state)
+		}
+
+		var actual string
+		if err := page.session.Execute("return document.readyState;", nil, &actual); err != nil {
+			return false, fmt.Errorf("failed to retrieve ready state: %s", err)
+		}
+		actualIndex, ok := readyStates[actual]
+		return ok && actualIndex >= expected, nil
+	}
+}
+
+// ScriptIsTrue returns a Condition that is satisfied when the provided
+// JavaScript returns true. The script is run as described by Page.RunScript.
+//
+// Example:
+//    err := page.Wait(5*time.Second, 0).Until(agouti.ScriptIsTrue("return window.appReady;", nil))
+func ScriptIsTrue(body string, arguments map[string]interface{}) Condition {
+	return func(page *Page) (bool, error) {
+		var result bool
+		if err := page.RunScript(body, arguments, &result); err != nil {
+			return false, err
+		}
+		return result, nil
+	}
+}
+
+const networkIdleScript = `
+	if (document.readyState !== "complete") {
+		return false;
+	}
+	var entries = performance.getEntriesByType("navigation").concat(performance.getEntriesByType("resource"));
+	var lastResponse = 0;
+	for (var i = 0; i < entries.length; i++) {
+		lastResponse = Math.max(lastResponse, entries[i].responseEnd);
+	}
+	return performance.now() - lastResponse >= arguments[0];
+`
+
+// NetworkIdle returns a Condition that is satisfied when the page has
+// finished loading and no network request made by the page has completed
+// within the provided quiet period. Requests are detected using the Resource
+// Timing API, so requests that are still in progress are only detected once
+// they complete.
+func NetworkIdle(quiet time.Duration) Condition {
+	return func(page *Page) (bool, error) {
+		var idle bool
+		quietMillis := float64(quiet) / float64(time.Millisecond)
+		if err := page.session.Execute(networkIdleScript, []interface{}{quietMillis}, &idle); err != nil {
+			return false, fmt.Errorf("failed to retrieve network activity: %s", err)
+		}
+		return idle, nil
+	}
+}
@@ -0,0 +1,362 @@
+package agouti_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Wait", func() {
+	var (
+		page    *Page
+		session *mocks.Session
+		wait    *Wait
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		page = NewTestPage(session)
+		wait = page.Wait(50*time.Millisecond, time.Millisecond)
+	})
+
+	Describe("#Until", func() {
+		It("should successfully return when all of the conditions are satisfied", func() {
+			firstChecks, secondChecks := 0, 0
+			Expect(wait.Until(func(*Page) (bool, error) {
+				firstChecks++
+				return firstChecks == 3, nil
+			}, func(*Page) (bool, error) {
+				secondChecks++
+				return true, nil
+			})).To(Succeed())
+			Expect(firstChecks).To(Equal(3))
+			Expect(secondChecks).To(Equal(1))
+		})
+
+		It("should provide the page to the conditions", func() {
+			var checkedPage *Page
+			Expect(wait.Until(func(actualPage *Page) (bool, error) {
+				checkedPage = actualPage
+				return true, nil
+			})).To(Succeed())
+			Expect(checkedPage).To(Equal(page))
+		})
+
+		It("should check the conditions at least once", func() {
+			checks := 0
+			Expect(page.Wait(0, time.Millisecond).Until(func(*Page) (bool, error) {
+				checks++
+				return true, nil
+			})).To(Succeed())
+			Expect(checks).To(Equal(1))
+		})
+
+		It("should retry conditions that fail to find an element or find a stale element", func() {
+			errs := []error{
+				&api.Error{Code: api.ErrorNoSuchElement, Message: "no such element"},
+				&api.Error{Code: api.ErrorStaleElement, Message: "stale element"},
+			}
+			Expect(wait.Until(func(*Page) (bool, error) {
+				if len(errs) == 0 {
+					return true, nil
+				}
+				err := errs[0]
+				errs = errs[1:]
+				return false, err
+			})).To(Succeed())
+		})
+
+		It("should retry conditions for selections that do not yet refer to an element", func() {
+			errs := []error{
+				element.NotFoundError("failed to select elements from selection: element not found"),
+				element.NotFoundError("failed to select elements from selection: element index out of range"),
+			}
+			Expect(wait.Until(func(*Page) (bool, error) {
+				if len(errs) == 0 {
+					return true, nil
+				}
+				err := errs[0]
+				errs = errs[1:]
+				return false, err
+			})).To(Succeed())
+		})
+
+		Context("when the conditions are not satisfied before the timeout elapses", func() {
+			It("should return an error", func() {
+				err := wait.Until(func(*Page) (bool, error) { return false, nil })
+				Expect(err).To(MatchError("failed to satisfy condition within 50ms"))
+			})
+
+			It("should return an error with the last retried error", func() {
+				err := wait.Until(func(*Page) (bool, error) {
+					return false, &api.Error{Code: api.ErrorStaleElement, Message: "stale element"}
+				})
+				Expect(err).To(MatchError("failed to satisfy condition within 50ms: request unsuccessful: stale element"))
+			})
+		})
+
+		Context("when a condition returns an error that is not retried", func() {
+			It("should return the error without retrying", func() {
+				checks := 0
+				err := wait.Until(func(*Page) (bool, error) {
+					checks++
+					return false, errors.New("some error")
+				})
+				Expect(err).To(MatchError("failed to wait for condition: some error"))
+				Expect(checks).To(Equal(1))
+			})
+		})
+
+		Context("when the interval is not positive", func() {
+			It("should check the conditions every 100 milliseconds", func() {
+				var checks []time.Time
+				Expect(page.Wait(time.Second, 0).Until(func(*Page) (bool, error) {
+					checks = append(checks, time.Now())
+					return len(checks) == 2, nil
+				})).To(Succeed())
+				Expect(checks[1].Sub(checks[0])).To(BeNumerically(">=", 100*time.Millisecond))
+			})
+		})
+	})
+
+	Describe("condition composition", func() {
+		var (
+			satisfied   Condition
+			unsatisfied Condition
+			stale       Condition
+			failing     Condition
+		)
+
+		BeforeEach(func() {
+			satisfied = func(*Page) (bool, error) { return true, nil }
+			unsatisfied = func(*Page) (bool, error) { return false, nil }
+			stale = func(*Page) (bool, error) {
+				return false, &api.Error{Code: api.ErrorStaleElement, Message: "stale element"}
+			}
+			failing = func(*Page) (bool, error) { return false, errors.New("some error") }
+		})
+
+		Describe("#AllOf", func() {
+			It("should be satisfied when all of the conditions are satisfied", func() {
+				Expect(AllOf(satisfied, satisfied)(page)).To(BeTrue())
+				Expect(AllOf(satisfied, unsatisfied)(page)).To(BeFalse())
+				Expect(AllOf()(page)).To(BeTrue())
+			})
+
+			It("should return any error from the conditions", func() {
+				_, err := AllOf(satisfied, failing)(page)
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+
+		Describe("#AnyOf", func() {
+			It("should be satisfied when any of the conditions are satisfied", func() {
+				Expect(AnyOf(unsatisfied, satisfied)(page)).To(BeTrue())
+				Expect(AnyOf(unsatisfied, unsatisfied)(page)).To(BeFalse())
+				Expect(AnyOf()(page)).To(BeFalse())
+			})
+
+			It("should be satisfied when a condition that is retried precedes a satisfied condition", func() {
+				Expect(AnyOf(stale, satisfied)(page)).To(BeTrue())
+			})
+
+			It("should return the last retried error when no conditions are satisfied", func() {
+				_, err := AnyOf(stale, unsatisfied)(page)
+				Expect(api.IsStaleElement(err)).To(BeTrue())
+			})
+
+			It("should return any error that is not retried", func() {
+				_, err := AnyOf(failing, satisfied)(page)
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+
+		Describe("#Negate", func() {
+			It("should be satisfied when the condition is not satisfied", func() {
+				Expect(Negate(unsatisfied)(page)).To(BeTrue())
+				Expect(Negate(satisfied)(page)).To(BeFalse())
+			})
+
+			It("should be satisfied when the condition fails with an error that would be retried", func() {
+				Expect(Negate(stale)(page)).To(BeTrue())
+			})
+
+			It("should return any other error from the condition", func() {
+				_, err := Negate(failing)(page)
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("selection conditions", func() {
+		var (
+			selection         *MultiSelection
+			elementRepository *mocks.ElementRepository
+			firstElement      *mocks.Element
+			secondElement     *mocks.Element
+		)
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			firstElement = &mocks.Element{}
+			secondElement = &mocks.Element{}
+			selection = NewTestMultiSelection(session, elementRepository, "#selector")
+			elementRepository.GetCall.ReturnElements = []element.Element{firstElement, secondElement}
+		})
+
+		Describe("#Visible", func() {
+			It("should be satisfied when all of the elements are visible", func() {
+				firstElement.IsDisplayedCall.ReturnDisplayed = true
+				secondElement.IsDisplayedCall.ReturnDisplayed = true
+				Expect(Visible(selection)(page)).To(BeTrue())
+				Expect(Visible(&selection.Selection)(page)).To(BeTrue())
+			})
+
+			It("should not be satisfied when any of the elements are not visible", func() {
+				firstElement.IsDisplayedCall.ReturnDisplayed = true
+				Expect(Visible(selection)(page)).To(BeFalse())
+			})
+
+			It("should not be satisfied when the selection refers to no elements", func() {
+				elementRepository.GetCall.ReturnElements = nil
+				Expect(Visible(selection)(page)).To(BeFalse())
+			})
+
+			It("should return errors from the element repository and elements", func() {
+				elementRepository.GetCall.Err = errors.New("some error")
+				_, err := Visible(selection)(page)
+				Expect(err).To(MatchError("some error"))
+
+				elementRepository.GetCall.Err = nil
+				firstElement.IsDisplayedCall.Err = &api.Error{Code: api.ErrorStaleElement}
+				_, err = Visible(selection)(page)
+				Expect(api.IsStaleElement(err)).To(BeTrue())
+			})
+
+			It("should be negated once the selected element no longer exists", func() {
+				firstElement.IsDisplayedCall.ReturnDisplayed = true
+				secondElement.IsDisplayedCall.ReturnDisplayed = true
+				Expect(Negate(Visible(selection))(page)).To(BeFalse())
+				elementRepository.GetCall.Err = element.ErrNotFound
+				Expect(wait.Until(Negate(Visible(selection)))).To(Succeed())
+			})
+
+			It("should return an error when not provided with a selection", func() {
+				_, err := Visible("not a selection")(page)
+				Expect(err).To(MatchError("must be *Selection or *MultiSelection"))
+			})
+		})
+
+		Describe("#ElementCount", func() {
+			It("should be satisfied when the selection refers to the provided number of elements", func() {
+				Expect(ElementCount(selection, 2)(page)).To(BeTrue())
+				Expect(ElementCount(selection, 1)(page)).To(BeFalse())
+			})
+
+			It("should return errors from the element repository", func() {
+				elementRepository.GetCall.Err = errors.New("some error")
+				_, err := ElementCount(selection, 2)(page)
+				Expect(err).To(MatchError("some error"))
+			})
+
+			It("should return an error when not provided with a selection", func() {
+				_, err := ElementCount("not a selection", 2)(page)
+				Expect(err).To(MatchError("must be *Selection or *MultiSelection"))
+			})
+		})
+	})
+
+	Describe("#TitleIs", func() {
+		It("should be satisfied when the page has the provided title", func() {
+			session.GetTitleCall.ReturnTitle = "some title"
+			Expect(TitleIs("some title")(page)).To(BeTrue())
+			Expect(TitleIs("some other title")(page)).To(BeFalse())
+		})
+
+		It("should return an error when the title cannot be retrieved", func() {
+			session.GetTitleCall.Err = errors.New("some error")
+			_, err := TitleIs("some title")(page)
+			Expect(err).To(MatchError("failed to retrieve page title: some error"))
+		})
+	})
+
+	Describe("#URLMatches", func() {
+		It("should be satisfied when the URL of the page matches the provided pattern", func() {
+			session.GetURLCall.ReturnURL = "http://example.com/some/path"
+			Expect(URLMatches(`/some/path$`)(page)).To(BeTrue())
+			Expect(URLMatches(`^https://`)(page)).To(BeFalse())
+		})
+
+		It("should return an error when the pattern is invalid", func() {
+			_, err := URLMatches(`(`)(page)
+			Expect(err).To(MatchError(ContainSubstring("invalid URL pattern: ")))
+		})
+
+		It("should return an error when the URL cannot be retrieved", func() {
+			session.GetURLCall.Err = errors.New("some error")
+			_, err := URLMatches(`.*`)(page)
+			Expect(err).To(MatchError("failed to retrieve URL: some error"))
+		})
+	})
+
+	Describe("#ReadyState", func() {
+		It("should be satisfied when the document has reached the provided ready state", func() {
+			session.ExecuteCall.Result = `"interactive"`
+			Expect(ReadyState("loading")(page)).To(BeTrue())
+			Expect(ReadyState("interactive")(page)).To(BeTrue())
+			Expect(ReadyState("complete")(page)).To(BeFalse())
+			Expect(session.ExecuteCall.Body).To(Equal("return document.readyState;"))
+		})
+
+		It("should return an error when the ready state is invalid", func() {
+			_, err := ReadyState("some state")(page)
+			Expect(err).To(MatchError("invalid ready state: some state"))
+		})
+
+		It("should return an error when the ready state cannot be retrieved", func() {
+			session.ExecuteCall.Err = errors.New("some error")
+			_, err := ReadyState("complete")(page)
+			Expect(err).To(MatchError("failed to retrieve ready state: some error"))
+		})
+	})
+
+	Describe("#ScriptIsTrue", func() {
+		It("should be satisfied when the script returns true", func() {
+			session.ExecuteCall.Result = "true"
+			Expect(ScriptIsTrue("return ready;", map[string]interface{}{"ready": true})(page)).To(BeTrue())
+			Expect(session.ExecuteCall.Body).To(Equal("return (function(ready) { return ready;; }).apply(this, arguments);"))
+			Expect(session.ExecuteCall.Arguments).To(Equal([]interface{}{true}))
+			session.ExecuteCall.Result = "false"
+			Expect(ScriptIsTrue("return ready;", map[string]interface{}{"ready": false})(page)).To(BeFalse())
+		})
+
+		It("should return an error when the script fails", func() {
+			session.ExecuteCall.Err = errors.New("some error")
+			_, err := ScriptIsTrue("return true;", nil)(page)
+			Expect(err).To(MatchError("failed to run script: some error"))
+		})
+	})
+
+	Describe("#NetworkIdle", func() {
+		It("should be satisfied when the page reports that the network is idle", func() {
+			session.ExecuteCall.Result = "true"
+			Expect(NetworkIdle(500 * time.Millisecond)(page)).To(BeTrue())
+			Expect(session.ExecuteCall.Body).To(ContainSubstring(`performance.getEntriesByType("resource")`))
+			Expect(session.ExecuteCall.Arguments).To(Equal([]interface{}{500.0}))
+			session.ExecuteCall.Result = "false"
+			Expect(NetworkIdle(500 * time.Millisecond)(page)).To(BeFalse())
+		})
+
+		It("should return an error when the network activity cannot be retrieved", func() {
+			session.ExecuteCall.Err = errors.New("some error")
+			_, err := NetworkIdle(time.Second)(page)
+			Expect(err).To(MatchError("failed to retrieve network activity: some error"))
+		})
+	})
+})
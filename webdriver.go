@@ -1,7 +1,7 @@
 package agouti
 
 import (
-	"fmt"
+	"sync"
 
 	"github.com/sclevine/agouti/api"
 )
@@ -11,6 +11,9 @@ import (
 type WebDriver struct {
 	*api.WebDriver
 	defaultOptions *config
+	sessionSlot    chan struct{}
+	pool           *pagePool
+	poolMutex      sync.Mutex
 }
 
 // NewWebDriver returns an instance of a WebDriver specified by
@@ -36,12 +39,27 @@ type WebDriver struct {
 //   command := []string{"java", "-jar", "selenium-server.jar", "-port", "{{.Port}}"}
 //   agouti.NewWebDriver("http://{{.Address}}/wd/hub", command)
 func NewWebDriver(url string, command []string, options ...Option) *WebDriver {
-	apiWebDriver := api.NewWebDriver(url, command)
+	return newWebDriver(api.NewWebDriver(url, command), options)
+}
+
+func newWebDriver(apiWebDriver *api.WebDriver, options []Option) *WebDriver {
 	defaultOptions := config{Timeout: apiWebDriver.Timeout}.Merge(options)
 	apiWebDriver.Timeout = defaultOptions.Timeout
 	apiWebDriver.Debug = defaultOptions.Debug
 	apiWebDriver.HTTPClient = defaultOptions.HTTPClient
-	return &WebDriver{apiWebDriver, defaultOptions}
+	apiWebDriver.AutoRestart = defaultOptions.AutoRestart
+	apiWebDriver.Stdout = defaultOptions.DriverStdout
+	apiWebDriver.Stderr = defaultOptions.DriverStderr
+
+	var sessionSlot chan struct{}
+	if defaultOptions.SingleSession {
+		sessionSlot = make(chan struct{}, 1)
+	}
+	return &WebDriver{
+		WebDriver:      apiWebDriver,
+		defaultOptions: defaultOptions,
+		sessionSlot:    sessionSlot,
+	}
 }
 
 // NewPage returns a *Page that corresponds to a new WebDriver session.
@@ -57,12 +75,31 @@ func NewWebDriver(url string, command []string, options ...Option) *WebDriver {
 // The HTTPClient Option will be ignored if passed to this function. New pages
 // will always use the *http.Client provided to their WebDriver, or
 // http.DefaultClient if none was provided.
+//
+// For WebDrivers that only support one session at a time, such as Safari,
+// NewPage waits until the previous page is destroyed.
 func (w *WebDriver) NewPage(options ...Option) (*Page, error) {
 	newOptions := w.defaultOptions.Merge(options)
-	session, err := w.Open(newOptions.Capabilities())
+	if w.sessionSlot == nil {
+		page, err := openPage(newOptions, w.Open)
+		if err != nil {
+			return nil, err
+		}
+		page.driverOutput = w.Output
+		return page, nil
+	}
+
+	w.sessionSlot <- struct{}{}
+	page, err := openPage(newOptions, w.Open)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to WebDriver: %s", err)
+		<-w.sessionSlot
+		return nil, err
 	}
+	page.driverOutput = w.Output
 
-	return newPage(session), nil
+	var releaseOnce sync.Once
+	page.releaseSession = func() {
+		releaseOnce.Do(func() { <-w.sessionSlot })
+	}
+	return page, nil
 }
@@ -0,0 +1,276 @@
+package agouti_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+)
+
+var _ = Describe("WebDriver", func() {
+	var (
+		server        *httptest.Server
+		mutex         sync.Mutex
+		openSessions  int
+		sessionCount  int
+		sessionStatus int
+		sessionError  string
+		currentURL    string
+	)
+
+	BeforeEach(func() {
+		openSessions, sessionCount = 0, 0
+		sessionStatus, sessionError = 200, ""
+		currentURL = "http://example.com"
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			switch {
+			case request.URL.Path == "/session" && sessionStatus != 200:
+				response.WriteHeader(sessionStatus)
+				fmt.Fprintf(response, `{"value": {"error": "session not created", "message": %q}}`, sessionError)
+			case request.URL.Path == "/session":
+				openSessions++
+				sessionCount++
+				fmt.Fprintf(response, `{"value": {"sessionId": "some-id-%d", "capabilities": {}}}`, sessionCount)
+			case request.Method == "DELETE" && strings.Count(request.URL.Path, "/") == 2:
+				openSessions--
+				response.Write([]byte(`{"value": null}`))
+			case request.Method == "GET" && strings.HasSuffix(request.URL.Path, "/url"):
+				fmt.Fprintf(response, `{"value": %q}`, currentURL)
+			case request.Method == "DELETE" || strings.HasSuffix(request.URL.Path, "/execute"):
+				response.Write([]byte(`{"value": null}`))
+			default:
+				response.Write([]byte(`{"value": {"ready": true}}`))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("ChromeDriver and GeckoDriver", func() {
+		It("should not install the driver by default", func() {
+			Expect(ChromeDriver().InstallBinary).To(BeNil())
+			Expect(GeckoDriver().InstallBinary).To(BeNil())
+		})
+
+		It("should install the driver when it is started if the InstallDriver Option is provided", func() {
+			Expect(ChromeDriver(InstallDriver).InstallBinary).NotTo(BeNil())
+			Expect(GeckoDriver(InstallDriver).InstallBinary).NotTo(BeNil())
+		})
+	})
+
+	Describe("#Output", func() {
+		It("should capture the output of the WebDriver process and copy it to the provided writers", func() {
+			stdout, stderr := gbytes.NewBuffer(), gbytes.NewBuffer()
+			command := []string{"sh", "-c", "echo some-output; echo some-error >&2; sleep 60"}
+			driver := NewWebDriver(server.URL, command, DriverStdout(stdout), DriverStderr(stderr))
+			Expect(driver.Start()).To(Succeed())
+			defer driver.Stop()
+			Eventually(stdout).Should(gbytes.Say("some-output"))
+			Eventually(stderr).Should(gbytes.Say("some-error"))
+			Expect(string(driver.Output())).To(ContainSubstring("some-output"))
+		})
+	})
+
+	Describe("#NewPage", func() {
+		Context("when the WebDriver only supports one session at a time", func() {
+			var driver *WebDriver
+
+			BeforeEach(func() {
+				driver = NewWebDriver(server.URL, []string{"sleep", "60"}, SingleSession)
+				Expect(driver.Start()).To(Succeed())
+			})
+
+			AfterEach(func() {
+				driver.Stop()
+			})
+
+			It("should wait until the previous page is destroyed", func() {
+				firstPage, err := driver.NewPage()
+				Expect(err).NotTo(HaveOccurred())
+
+				secondPage := make(chan *Page)
+				go func() {
+					defer GinkgoRecover()
+					page, err := driver.NewPage()
+					Expect(err).NotTo(HaveOccurred())
+					secondPage <- page
+				}()
+				Consistently(secondPage).ShouldNot(Receive())
+
+				Expect(firstPage.Destroy()).To(Succeed())
+				var page *Page
+				Eventually(secondPage).Should(Receive(&page))
+				Expect(page.Session().ID()).To(Equal("some-id-2"))
+				mutex.Lock()
+				defer mutex.Unlock()
+				Expect(openSessions).To(Equal(1))
+			})
+
+			It("should not wait for pages that failed to open", func() {
+				sessionStatus = 500
+				_, err := driver.NewPage()
+				Expect(err).To(HaveOccurred())
+				sessionStatus = 200
+				_, err = driver.NewPage()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the proxy configuration is invalid", func() {
+			It("should return an error without opening a session", func() {
+				_, err := NewPage(server.URL, Proxy(api.ProxyConfig{NoProxy: []string{"localhost"}}))
+				Expect(err).To(MatchError("failed to configure proxy: no proxy address provided"))
+				_, err = NewPage(server.URL, Proxy(api.ProxyConfig{HTTP: "127.0.0.1:8080"}), RequestInterception)
+				Expect(err).To(MatchError("failed to configure proxy: the Proxy and RequestInterception Options cannot be combined"))
+				mutex.Lock()
+				defer mutex.Unlock()
+				Expect(sessionCount).To(Equal(0))
+			})
+		})
+
+		Context("when remote automation is disabled in Safari", func() {
+			It("should return an error explaining how to enable it", func() {
+				sessionStatus = 500
+				sessionError = "Could not create a session: You must enable the 'Allow Remote Automation' option in Safari's Develop menu to control Safari via WebDriver."
+				_, err := NewPage(server.URL)
+				Expect(err).To(MatchError(HavePrefix("failed to connect to WebDriver: remote automation is disabled in Safari, run 'safaridriver --enable' once to enable it: ")))
+			})
+		})
+	})
+
+	Describe("#NewPages", func() {
+		var driver *WebDriver
+
+		BeforeEach(func() {
+			driver = NewWebDriver(server.URL, []string{"sleep", "60"})
+			Expect(driver.Start()).To(Succeed())
+		})
+
+		AfterEach(func() {
+			driver.Stop()
+		})
+
+		It("should open the provided number of independent pages", func() {
+			pages, err := driver.NewPages(2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pages).To(HaveLen(2))
+			Expect(pages[0].Session().ID()).NotTo(Equal(pages[1].Session().ID()))
+			mutex.Lock()
+			Expect(openSessions).To(Equal(2))
+			mutex.Unlock()
+
+			Expect(pages.Destroy()).To(Succeed())
+			mutex.Lock()
+			defer mutex.Unlock()
+			Expect(openSessions).To(Equal(0))
+		})
+
+		Context("when any page cannot be opened", func() {
+			It("should destroy the other pages and return an error", func() {
+				sessionStatus = 500
+				_, err := driver.NewPages(2)
+				Expect(err).To(MatchError(HavePrefix("failed to open page ")))
+				mutex.Lock()
+				defer mutex.Unlock()
+				Expect(openSessions).To(Equal(0))
+			})
+		})
+
+		Context("when the WebDriver only supports one session at a time", func() {
+			It("should return an error without opening any pages", func() {
+				singleDriver := NewWebDriver(server.URL, []string{"sleep", "60"}, SingleSession)
+				_, err := singleDriver.NewPages(2)
+				Expect(err).To(MatchError("failed to open pages: WebDriver only supports one session at a time"))
+				mutex.Lock()
+				defer mutex.Unlock()
+				Expect(sessionCount).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("#AcquirePage", func() {
+		var driver *WebDriver
+
+		BeforeEach(func() {
+			driver = NewWebDriver(server.URL, []string{"sleep", "60"}, PoolSize(1))
+			Expect(driver.Start()).To(Succeed())
+		})
+
+		AfterEach(func() {
+			driver.Stop()
+		})
+
+		It("should reuse pages that are released", func() {
+			page, err := driver.AcquirePage(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Release()).To(Succeed())
+
+			page, err = driver.AcquirePage(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Session().ID()).To(Equal("some-id-1"))
+			mutex.Lock()
+			defer mutex.Unlock()
+			Expect(sessionCount).To(Equal(1))
+		})
+
+		It("should wait until a page is released when the pool is full", func() {
+			page, err := driver.AcquirePage(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			secondPage := make(chan *Page)
+			go func() {
+				defer GinkgoRecover()
+				page, err := driver.AcquirePage(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				secondPage <- page
+			}()
+			Consistently(secondPage).ShouldNot(Receive())
+
+			Expect(page.Release()).To(Succeed())
+			Eventually(secondPage).Should(Receive(Equal(page)))
+		})
+
+		It("should return an error when the context is done before a page is released", func() {
+			_, err := driver.AcquirePage(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			_, err = driver.AcquirePage(ctx)
+			Expect(err).To(MatchError("failed to acquire page: context deadline exceeded"))
+		})
+
+		It("should destroy idle pages when the WebDriver is stopped", func() {
+			page, err := driver.AcquirePage(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Release()).To(Succeed())
+			Expect(driver.Stop()).To(Succeed())
+			mutex.Lock()
+			defer mutex.Unlock()
+			Expect(openSessions).To(Equal(0))
+		})
+	})
+
+	Describe("Page#Release", func() {
+		It("should return an error for pages that were not acquired from a WebDriver", func() {
+			page, err := NewPage(server.URL)
+			Expect(err).NotTo(HaveOccurred())
+			defer page.Destroy()
+			Expect(page.Release()).To(MatchError("failed to release page: page was not acquired from a WebDriver"))
+		})
+	})
+
+})